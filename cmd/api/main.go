@@ -6,19 +6,41 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/Xiancel/ecommerce/internal/db"
+	"github.com/Xiancel/ecommerce/internal/i18n"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	"github.com/Xiancel/ecommerce/internal/payment"
+	"github.com/Xiancel/ecommerce/internal/storage"
+	"github.com/Xiancel/ecommerce/internal/worker"
 	"github.com/joho/godotenv"
 
 	httpHandler "github.com/Xiancel/ecommerce/internal/handler/http"
 	postgres "github.com/Xiancel/ecommerce/internal/repository/postgres"
 	authService "github.com/Xiancel/ecommerce/internal/service/auth"
+	backInStockService "github.com/Xiancel/ecommerce/internal/service/backinstock"
+	bundleService "github.com/Xiancel/ecommerce/internal/service/bundle"
 	cartService "github.com/Xiancel/ecommerce/internal/service/cart"
+	collectionService "github.com/Xiancel/ecommerce/internal/service/collection"
+	downloadService "github.com/Xiancel/ecommerce/internal/service/download"
+	exportService "github.com/Xiancel/ecommerce/internal/service/export"
+	importService "github.com/Xiancel/ecommerce/internal/service/importer"
+	inventoryService "github.com/Xiancel/ecommerce/internal/service/inventory"
+	mediaService "github.com/Xiancel/ecommerce/internal/service/media"
+	merchService "github.com/Xiancel/ecommerce/internal/service/merchandising"
 	orderService "github.com/Xiancel/ecommerce/internal/service/order"
+	priceAlertService "github.com/Xiancel/ecommerce/internal/service/pricealert"
 	productService "github.com/Xiancel/ecommerce/internal/service/product"
+	questionService "github.com/Xiancel/ecommerce/internal/service/question"
+	recommendationService "github.com/Xiancel/ecommerce/internal/service/recommendation"
+	reviewService "github.com/Xiancel/ecommerce/internal/service/review"
+	subscriptionService "github.com/Xiancel/ecommerce/internal/service/subscription"
+	translationService "github.com/Xiancel/ecommerce/internal/service/translation"
 	userService "github.com/Xiancel/ecommerce/internal/service/user"
+	wishlistService "github.com/Xiancel/ecommerce/internal/service/wishlist"
 )
 
 // @title E-Commerce API
@@ -45,6 +67,40 @@ func main() {
 	dbSSLMode := getEnv("DB_SSLMODE", "disable")
 	serverPort := getEnv("APP_PORT", "8080")
 	jwtSecret := getEnv("JWT_SECRET", "kfJ+JpWThVtZ5p0hIM9s7jFGucNvHdn59aTfzT7fQ2iqlt3rH2bnSKTwsm4B3Q3P")
+	suggestMinSimilarity := getEnvFloat("SUGGEST_MIN_SIMILARITY", 0.3)
+	suggestLimit := getEnvInt("SUGGEST_LIMIT", 10)
+	storageDir := getEnv("STORAGE_DIR", "./uploads")
+	imageBaseURL := getEnv("IMAGE_BASE_URL", "/api/v1/images")
+	imageMaxUploadMB := getEnvInt("IMAGE_MAX_UPLOAD_MB", 10)
+	productScheduleInterval := getEnvDuration("PRODUCT_SCHEDULE_INTERVAL", time.Minute)
+	recommendationsInterval := getEnvDuration("RECOMMENDATIONS_INTERVAL", time.Hour)
+	recommendationsMinSupport := getEnvInt("RECOMMENDATIONS_MIN_SUPPORT", 2)
+	storeURL := getEnv("STORE_URL", "http://localhost:8080")
+	storeName := getEnv("STORE_NAME", "E-Commerce")
+	storeCurrency := getEnv("STORE_CURRENCY", "UAH")
+	notificationWebhookURL := getEnv("NOTIFICATION_WEBHOOK_URL", "")
+	lowStockAlertInterval := getEnvDuration("LOW_STOCK_ALERT_INTERVAL", time.Minute)
+	reorderDigestInterval := getEnvDuration("REORDER_DIGEST_INTERVAL", 24*time.Hour)
+	backInStockInterval := getEnvDuration("BACK_IN_STOCK_INTERVAL", time.Minute)
+	backInStockBatchSize := getEnvInt("BACK_IN_STOCK_BATCH_SIZE", 100)
+	priceAlertInterval := getEnvDuration("PRICE_ALERT_INTERVAL", time.Minute)
+	priceAlertBatchSize := getEnvInt("PRICE_ALERT_BATCH_SIZE", 100)
+	priceAlertUserLimit := getEnvInt("PRICE_ALERT_USER_LIMIT", 5)
+	priceAlertUserWindow := getEnvDuration("PRICE_ALERT_USER_WINDOW", 24*time.Hour)
+	subscriptionInterval := getEnvDuration("SUBSCRIPTION_INTERVAL", 5*time.Minute)
+	subscriptionBatchSize := getEnvInt("SUBSCRIPTION_BATCH_SIZE", 50)
+	subscriptionRetryInterval := getEnvDuration("SUBSCRIPTION_RETRY_INTERVAL", 24*time.Hour)
+	subscriptionMaxAttempts := getEnvInt("SUBSCRIPTION_MAX_ATTEMPTS", 3)
+	paymentGatewayURL := getEnv("PAYMENT_GATEWAY_URL", "")
+	downloadSigningSecret := mustGetEnv("DOWNLOAD_SIGNING_SECRET")
+	downloadLinkTTL := getEnvDuration("DOWNLOAD_LINK_TTL", 72*time.Hour)
+	downloadMaxCount := getEnvInt("DOWNLOAD_MAX_COUNT", 5)
+	downloadMaxUploadMB := getEnvInt("DOWNLOAD_MAX_UPLOAD_MB", 200)
+	cartTokenSecret := mustGetEnv("CART_TOKEN_SECRET")
+	guestCartTTL := getEnvDuration("GUEST_CART_TTL", 30*24*time.Hour)
+	guestCartCleanupInterval := getEnvDuration("GUEST_CART_CLEANUP_INTERVAL", time.Hour)
+	defaultLocale := getEnv("DEFAULT_LOCALE", i18n.DefaultLocale)
+	supportedLocales := getEnv("SUPPORTED_LOCALES", "uk,en")
 
 	// конфігурація бази данних
 	dbConfig := db.Config{
@@ -65,34 +121,208 @@ func main() {
 
 	log.Println("✅ Database connetion established")
 
+	// ініціалізація сховища файлів
+	blobStorage, err := storage.NewLocalStorage(storageDir, imageBaseURL)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
 	// ініціалізація репозеторіїв
 	productRepo := postgres.NewProductRepository(database)
 	userRepo := postgres.NewUserRepository(database)
 	cartRepo := postgres.NewCartRepository(database)
 	orderRepo := postgres.NewOrderRepository(database)
+	searchRuleRepo := postgres.NewSearchRuleRepository(database)
+	productImageRepo := postgres.NewProductImageRepository(database)
+	reviewRepo := postgres.NewReviewRepository(database)
+	questionRepo := postgres.NewQuestionRepository(database)
+	recommendationRepo := postgres.NewRecommendationRepository(database)
+	categoryRepo := postgres.NewCategoryRepository(database)
+	collectionRepo := postgres.NewCollectionRepository(database)
+	inventoryRepo := postgres.NewInventoryRepository(database)
+	stockSubscriptionRepo := postgres.NewStockSubscriptionRepository(database)
+	downloadRepo := postgres.NewDownloadRepository(database)
+	translationRepo := postgres.NewTranslationRepository(database)
+	wishlistRepo := postgres.NewWishlistRepository(database)
+	priceAlertRepo := postgres.NewPriceAlertRepository(database)
+	bundleRepo := postgres.NewBundleRepository(database)
+	guestCartRepo := postgres.NewGuestCartRepository(database)
+	subscriptionRepo := postgres.NewSubscriptionRepository(database)
 
 	log.Println("✅ Repository initialized")
 
+	// сповіщення: вебхук, якщо налаштований, інакше лог
+	notifier := notification.NewLogNotifier()
+	if notificationWebhookURL != "" {
+		notifier = notification.NewWebhookNotifier(notificationWebhookURL)
+	}
+
+	// оплата підписок: платіжний шлюз, якщо налаштований, інакше лог
+	gateway := payment.NewLogGateway()
+	if paymentGatewayURL != "" {
+		gateway = payment.NewWebhookGateway(paymentGatewayURL)
+	}
+
+	// мови вмісту: базовий вміст каталогу зберігається мовою за замовчуванням
+	locales := i18n.NewLocales(defaultLocale, i18n.ParseList(supportedLocales))
+
 	// ініціалізація сервісів
-	productSrv := productService.NewService(productRepo)
+	// переклади продуктів для відповідей поза каталогом (колекції, рекомендації, списки бажань, комплекти)
+	productLocalizer := productService.NewLocalizer(translationRepo, locales.Default)
+	productSrv := productService.NewService(productRepo, searchRuleRepo, categoryRepo, translationRepo, inventoryRepo, productService.Config{
+		SuggestMinSimilarity: suggestMinSimilarity,
+		SuggestLimit:         suggestLimit,
+		DefaultLocale:        locales.Default,
+	})
 	userSrv := userService.NewService(userRepo)
-	authSrv := authService.NewService(userRepo, jwtSecret)
-	cartSrv := cartService.NewService(cartRepo)
-	orderService := orderService.NewService(orderRepo, productRepo)
+	recommendationSrv := recommendationService.NewService(recommendationRepo, productRepo, productLocalizer, recommendationService.Config{
+		MinSupport: recommendationsMinSupport,
+	})
+	cartSrv := cartService.NewService(cartRepo, guestCartRepo, productRepo, recommendationSrv, cartService.Config{
+		TokenSecret: cartTokenSecret,
+		GuestTTL:    guestCartTTL,
+	})
+	authSrv := authService.NewService(userRepo, jwtSecret, cartSrv)
+	orderService := orderService.NewService(orderRepo, productRepo, inventoryRepo, bundleRepo)
+	merchSrv := merchService.NewService(searchRuleRepo, productRepo)
+	mediaSrv := mediaService.NewService(productImageRepo, productRepo, blobStorage, mediaService.Config{
+		MaxUploadBytes: int64(imageMaxUploadMB) << 20,
+	})
+	importSrv := importService.NewService(productRepo, blobStorage)
+	reviewSrv := reviewService.NewService(reviewRepo, productRepo)
+	questionSrv := questionService.NewService(questionRepo, productRepo, reviewRepo, notifier)
+	collectionSrv := collectionService.NewService(collectionRepo, productRepo, productLocalizer)
+	inventorySrv := inventoryService.NewService(inventoryRepo, productRepo, notifier)
+	backInStockSrv := backInStockService.NewService(stockSubscriptionRepo, productRepo, notifier, backInStockBatchSize)
+	downloadSrv := downloadService.NewService(downloadRepo, orderRepo, productRepo, blobStorage, downloadService.Config{
+		Secret:         downloadSigningSecret,
+		LinkTTL:        downloadLinkTTL,
+		MaxDownloads:   downloadMaxCount,
+		BaseURL:        storeURL + "/api/v1",
+		MaxUploadBytes: int64(downloadMaxUploadMB) << 20,
+	})
+	translationSrv := translationService.NewService(translationRepo, productRepo, categoryRepo, locales)
+	wishlistSrv := wishlistService.NewService(wishlistRepo, productRepo, cartRepo, productLocalizer)
+	priceAlertSrv := priceAlertService.NewService(priceAlertRepo, productRepo, notifier, priceAlertService.Config{
+		BatchSize:  priceAlertBatchSize,
+		UserLimit:  priceAlertUserLimit,
+		UserWindow: priceAlertUserWindow,
+	})
+	bundleSrv := bundleService.NewService(bundleRepo, productRepo, productLocalizer)
+	subscriptionSrv := subscriptionService.NewService(subscriptionRepo, productRepo, orderService, gateway, notifier, subscriptionService.Config{
+		BatchSize:     subscriptionBatchSize,
+		MaxAttempts:   subscriptionMaxAttempts,
+		RetryInterval: subscriptionRetryInterval,
+	})
+	exportSrv := exportService.NewService(productRepo, exportService.Config{
+		StoreURL:  storeURL,
+		StoreName: storeName,
+		Currency:  storeCurrency,
+	})
 
 	log.Println("✅ Services initialized")
 
 	// ініціалізація http router
 	router := httpHandler.NewRouter(httpHandler.RouterConfig{
-		AuthService:    authSrv,
-		ProductService: productSrv,
-		CartService:    cartSrv,
-		OrderService:   orderService,
-		UserService:    userSrv,
+		AuthService:           authSrv,
+		ProductService:        productSrv,
+		CartService:           cartSrv,
+		OrderService:          orderService,
+		UserService:           userSrv,
+		MerchService:          merchSrv,
+		MediaService:          mediaSrv,
+		ImportService:         importSrv,
+		ExportService:         exportSrv,
+		ReviewService:         reviewSrv,
+		QuestionService:       questionSrv,
+		RecommendationService: recommendationSrv,
+		CollectionService:     collectionSrv,
+		InventoryService:      inventorySrv,
+		BackInStockService:    backInStockSrv,
+		DownloadService:       downloadSrv,
+		TranslationService:    translationSrv,
+		WishlistService:       wishlistSrv,
+		PriceAlertService:     priceAlertSrv,
+		BundleService:         bundleSrv,
+		SubscriptionService:   subscriptionSrv,
+		Locales:               locales,
 	})
 
 	log.Println("✅ HTTP router initialized")
 
+	// запуск фонових задач
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go worker.Run(workerCtx, "product-schedule", productScheduleInterval, func(ctx context.Context) error {
+		published, unpublished, err := productSrv.ApplySchedule(ctx)
+		if published > 0 || unpublished > 0 {
+			log.Printf("Product schedule: %d published, %d unpublished", published, unpublished)
+		}
+		return err
+	})
+
+	go worker.Run(workerCtx, "recommendations", recommendationsInterval, func(ctx context.Context) error {
+		count, err := recommendationSrv.Recompute(ctx)
+		if err == nil {
+			log.Printf("Recommendations: %d product pairs computed", count)
+		}
+		return err
+	})
+
+	go worker.Run(workerCtx, "low-stock-alerts", lowStockAlertInterval, func(ctx context.Context) error {
+		count, err := inventorySrv.DeliverAlerts(ctx)
+		if count > 0 {
+			log.Printf("Low stock alerts: %d sent", count)
+		}
+		return err
+	})
+
+	go worker.Run(workerCtx, "reorder-digest", reorderDigestInterval, func(ctx context.Context) error {
+		count, err := inventorySrv.SendReorderDigest(ctx)
+		if count > 0 {
+			log.Printf("Reorder digest: %d products need reorder", count)
+		}
+		return err
+	})
+
+	go worker.Run(workerCtx, "back-in-stock", backInStockInterval, func(ctx context.Context) error {
+		count, err := backInStockSrv.DeliverNotifications(ctx)
+		if count > 0 {
+			log.Printf("Back in stock: %d subscribers notified", count)
+		}
+		return err
+	})
+
+	go worker.Run(workerCtx, "price-alerts", priceAlertInterval, func(ctx context.Context) error {
+		// спершу розпродажі, що почались, потім відправка з лімітом на користувача
+		triggered, err := priceAlertSrv.EvaluateSales(ctx)
+		if err != nil {
+			return err
+		}
+		count, err := priceAlertSrv.DeliverNotifications(ctx)
+		if triggered > 0 || count > 0 {
+			log.Printf("Price alerts: %d triggered by sales, %d sent", triggered, count)
+		}
+		return err
+	})
+
+	go worker.Run(workerCtx, "guest-carts", guestCartCleanupInterval, func(ctx context.Context) error {
+		count, err := cartSrv.ExpireGuestCarts(ctx)
+		if count > 0 {
+			log.Printf("Guest carts: %d expired carts removed", count)
+		}
+		return err
+	})
+
+	go worker.Run(workerCtx, "subscriptions", subscriptionInterval, func(ctx context.Context) error {
+		count, err := subscriptionSrv.ProcessDue(ctx)
+		if count > 0 {
+			log.Printf("Subscriptions: %d processed", count)
+		}
+		return err
+	})
+
 	// створення HTTP серверу
 	server := &http.Server{
 		Addr:         ":" + serverPort,
@@ -118,6 +348,7 @@ func main() {
 	<-quit
 
 	log.Println("⚠️ Shutting down server...")
+	stopWorkers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -135,3 +366,42 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// mustGetEnv повертає значення обов'язкової змінної (секрети не мають значень за замовчуванням)
+func mustGetEnv(key string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		log.Fatalf("%s is required", key)
+	}
+	return value
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if v, err := strconv.Atoi(value); err == nil {
+			return v
+		}
+		log.Printf("Invalid value for %s, using default %d", key, defaultValue)
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+		log.Printf("Invalid value for %s, using default %v", key, defaultValue)
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if v, err := time.ParseDuration(value); err == nil && v > 0 {
+			return v
+		}
+		log.Printf("Invalid value for %s, using default %s", key, defaultValue)
+	}
+	return defaultValue
+}
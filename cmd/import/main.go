@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Xiancel/ecommerce/internal/db"
+	postgres "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	importService "github.com/Xiancel/ecommerce/internal/service/importer"
+	"github.com/Xiancel/ecommerce/internal/storage"
+	"github.com/joho/godotenv"
+)
+
+// import — CLI для масового імпорту продуктів з CSV або JSON Lines.
+//
+//	go run ./cmd/import -file products.csv [-format csv|jsonl] [-dry-run] [-report errors.csv]
+func main() {
+	filePath := flag.String("file", "", "шлях до файлу імпорту")
+	format := flag.String("format", "", "формат файлу (csv, jsonl); за замовчуванням з розширення")
+	dryRun := flag.Bool("dry-run", false, "тільки перевірити файл, нічого не зберігаючи")
+	reportPath := flag.String("report", "", "куди зберегти звіт про помилки (CSV)")
+	flag.Parse()
+
+	if *filePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// завантаження .env файлів
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment varibles")
+	}
+
+	// ініціалізація бази данних
+	database, err := db.NewDB(db.Config{
+		Host:     getEnv("DB_HOST", "db"),
+		Port:     getEnv("DB_PORT", "5432"),
+		User:     getEnv("DB_USER", "user"),
+		Password: getEnv("DB_PASSWORD", "1234!"),
+		DBName:   getEnv("DB_NAME", "ecommerce_db"),
+		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	// ініціалізація сховища файлів (звіти імпорту)
+	blobStorage, err := storage.NewLocalStorage(getEnv("STORAGE_DIR", "./uploads"), getEnv("IMAGE_BASE_URL", "/api/v1/images"))
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	importSrv := importService.NewService(postgres.NewProductRepository(database), blobStorage)
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Fatalf("Failed to open import file: %v", err)
+	}
+	defer file.Close()
+
+	if *format == "" {
+		*format = formatFromExt(*filePath)
+	}
+
+	// імпорт
+	ctx := context.Background()
+	result, err := importSrv.ImportProducts(ctx, file, importService.ImportOptions{
+		Format: *format,
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	// копіювання звіту про помилки
+	if *reportPath != "" {
+		if err := saveReport(ctx, importSrv, result, *reportPath); err != nil {
+			log.Fatalf("Failed to save import report: %v", err)
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(result)
+
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// saveReport зберігає звіт імпорту у файл
+func saveReport(ctx context.Context, importSrv importService.ImportService, result *importService.ImportResult, path string) error {
+	report, err := importSrv.OpenReport(ctx, result.ID)
+	if err != nil {
+		return err
+	}
+	defer report.Close()
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, report); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// formatFromExt визначає формат файлу за розширенням
+func formatFromExt(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return importService.FormatCSV
+	case ".jsonl", ".ndjson":
+		return importService.FormatJSONL
+	}
+	return ""
+}
+
+// отримання змінної оточення
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
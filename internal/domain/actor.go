@@ -0,0 +1,23 @@
+package models
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type actorKey struct{}
+
+// ContextWithActor зберігає в контексті користувача, який виконує дію (для журналів змін)
+func ContextWithActor(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext повертає користувача, який виконує дію; nil - системна дія (фонова задача)
+func ActorFromContext(ctx context.Context) *uuid.UUID {
+	userID, ok := ctx.Value(actorKey{}).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BundleComponent компонент комплекту: продукт та його кількість в одному комплекті
+type BundleComponent struct {
+	BundleID    uuid.UUID `db:"bundle_id" json:"bundle_id"`
+	ComponentID uuid.UUID `db:"component_id" json:"component_id"`
+	Quantity    int       `db:"quantity" json:"quantity"`
+	// дані продукту-компонента
+	Name      string     `db:"name" json:"name"`
+	Slug      string     `db:"slug" json:"slug"`
+	SKU       *string    `db:"sku" json:"sku,omitempty"`
+	Price     float64    `db:"price" json:"price"`
+	Stock     int        `db:"stock" json:"stock"`
+	DeletedAt *time.Time `db:"deleted_at" json:"-"`
+}
+
+// OrderItemComponent компонент комплекту в позиції замовлення
+type OrderItemComponent struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Name      string    `json:"name"`
+	SKU       *string   `json:"sku,omitempty"`
+	// QuantityPerBundle кількість в одному комплекті
+	QuantityPerBundle int `json:"quantity_per_bundle"`
+	// Quantity загальна кількість для позиції (QuantityPerBundle * кількість комплектів)
+	Quantity int `json:"quantity"`
+}
+
+// OrderItemComponents склад комплекту в позиції замовлення (JSONB)
+type OrderItemComponents []OrderItemComponent
+
+// Value зберігає склад комплекту як JSONB; порожній склад зберігається як NULL
+func (c OrderItemComponents) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+// Scan читає склад комплекту з JSONB
+func (c *OrderItemComponents) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for order item components")
+	}
+	return json.Unmarshal(data, c)
+}
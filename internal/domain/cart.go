@@ -12,7 +12,9 @@ type CartItem struct {
 	UserID    uuid.UUID `db:"user_id" json:"user_id,omitempty"`
 	ProductID uuid.UUID `db:"product_id" json:"product_id,omitempty"`
 	Quantity  int       `db:"quantity" json:"quantity"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	// PriceAtAdd ціна товару на момент додавання в кошик
+	PriceAtAdd float64   `db:"price_at_add" json:"price_at_add"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
 }
 
 // структура товарів у кошику
@@ -21,4 +23,29 @@ type CartItemWithProduct struct {
 	ProductName  string  `db:"product_name" json:"product_name"`
 	ProductPrice float64 `db:"product_price" json:"product_price"`
 	ProductStock int     `db:"product_stock" json:"product_stock"`
+	// поточний стан продукту для перевірки кошика
+	ProductStatus    string     `db:"product_status" json:"product_status"`
+	ProductDeletedAt *time.Time `db:"product_deleted_at" json:"-"`
+	IsDigital        bool       `db:"is_digital" json:"is_digital"`
+	BackorderPolicy  string     `db:"backorder_policy" json:"backorder_policy"`
+}
+
+// Purchasable повертає true, якщо продукт опублікований і не видалений
+func (i *CartItemWithProduct) Purchasable() bool {
+	return i.ProductStatus == ProductStatusPublished && i.ProductDeletedAt == nil
+}
+
+// StockLimited повертає true, якщо кількість обмежена залишком (не цифровий товар і без продажу під замовлення)
+func (i *CartItemWithProduct) StockLimited() bool {
+	return !i.IsDigital && i.BackorderPolicy != BackorderAllow && i.BackorderPolicy != BackorderPreorder
+}
+
+// структура кошика гостя (без авторизації)
+type GuestCart struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+	// Token підписаний токен кошика для заголовка X-Cart-Token (заповнюється сервісом)
+	Token string `db:"-" json:"cart_token,omitempty"`
 }
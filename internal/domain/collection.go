@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// типи колекцій
+const (
+	CollectionTypeManual = "manual"
+	CollectionTypeSmart  = "smart"
+)
+
+// структура колекції товарів
+type Collection struct {
+	ID          uuid.UUID        `db:"id" json:"id"`
+	Slug        string           `db:"slug" json:"slug"`
+	Title       string           `db:"title" json:"title"`
+	Description *string          `db:"description" json:"description,omitempty"`
+	Type        string           `db:"type" json:"type"`
+	Rules       *CollectionRules `db:"rules" json:"rules,omitempty"`
+	IsPublished bool             `db:"is_published" json:"is_published"`
+	CreatedAt   time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time        `db:"updated_at" json:"updated_at"`
+}
+
+// CollectionRules правила розумної колекції (всі задані умови мають виконуватись)
+type CollectionRules struct {
+	CategoryID        *uuid.UUID `json:"category_id,omitempty"`
+	MinPrice          *float64   `json:"min_price,omitempty"`
+	MaxPrice          *float64   `json:"max_price,omitempty"`
+	Tag               string     `json:"tag,omitempty"`
+	CreatedWithinDays int        `json:"created_within_days,omitempty"`
+}
+
+// Value зберігає правила як JSONB
+func (r CollectionRules) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan читає правила з JSONB
+func (r *CollectionRules) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for collection rules")
+	}
+	return json.Unmarshal(data, r)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// структура файлу цифрового товару
+type ProductFile struct {
+	ProductID   uuid.UUID `db:"product_id" json:"product_id"`
+	StorageKey  string    `db:"storage_key" json:"-"`
+	FileName    string    `db:"file_name" json:"file_name"`
+	ContentType string    `db:"content_type" json:"content_type"`
+	SizeBytes   int64     `db:"size_bytes" json:"size_bytes"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// структура права на завантаження цифрової позиції замовлення
+type DownloadGrant struct {
+	ID               uuid.UUID  `db:"id" json:"id"`
+	OrderID          uuid.UUID  `db:"order_id" json:"order_id"`
+	OrderItemID      uuid.UUID  `db:"order_item_id" json:"order_item_id"`
+	ProductID        uuid.UUID  `db:"product_id" json:"product_id"`
+	ProductName      string     `db:"product_name" json:"product_name"`
+	MaxDownloads     int        `db:"max_downloads" json:"max_downloads"`
+	DownloadCount    int        `db:"download_count" json:"download_count"`
+	ExpiresAt        time.Time  `db:"expires_at" json:"expires_at"`
+	LastDownloadedAt *time.Time `db:"last_downloaded_at" json:"last_downloaded_at,omitempty"`
+	CreatedAt        time.Time  `db:"created_at" json:"created_at"`
+	// статус замовлення: скасування замовлення відкликає право на завантаження
+	OrderStatus string `db:"order_status" json:"-"`
+	// файл продукту (nil, якщо адміністратор ще не завантажив файл)
+	FileName    *string `db:"file_name" json:"file_name,omitempty"`
+	ContentType *string `db:"content_type" json:"-"`
+	StorageKey  *string `db:"storage_key" json:"-"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// назви варіантів зображення
+const (
+	ImageVariantOriginal  = "original"
+	ImageVariantThumbnail = "thumbnail"
+	ImageVariantMedium    = "medium"
+	ImageVariantLarge     = "large"
+)
+
+// структура зображення продукту
+type ProductImage struct {
+	ID        uuid.UUID      `db:"id" json:"id"`
+	ProductID uuid.UUID      `db:"product_id" json:"product_id"`
+	AltText   *string        `db:"alt_text" json:"alt_text,omitempty"`
+	SortOrder int            `db:"sort_order" json:"sort_order"`
+	Width     int            `db:"width" json:"width"`
+	Height    int            `db:"height" json:"height"`
+	Variants  []ImageVariant `db:"variants" json:"variants"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// структура варіанту зображення (оригінал, мініатюра тощо)
+type ImageVariant struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Key    string `json:"key"`
+	URL    string `json:"url"`
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// структура складу
+type StockLocation struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	Code      string    `db:"code" json:"code"`
+	Name      string    `db:"name" json:"name"`
+	Country   *string   `db:"country" json:"country,omitempty"`
+	IsDefault bool      `db:"is_default" json:"is_default"`
+	IsActive  bool      `db:"is_active" json:"is_active"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// структура залишку товару на складі
+type LocationStock struct {
+	LocationID   uuid.UUID `db:"location_id" json:"location_id"`
+	LocationCode string    `db:"location_code" json:"location_code"`
+	Country      *string   `db:"country" json:"country,omitempty"`
+	IsActive     bool      `db:"is_active" json:"is_active"`
+	ProductID    uuid.UUID `db:"product_id" json:"product_id"`
+	Quantity     int       `db:"quantity" json:"quantity"`
+	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// структура розподілу позиції замовлення по складу
+type StockAllocation struct {
+	OrderItemID  uuid.UUID `db:"order_item_id" json:"order_item_id"`
+	ProductID    uuid.UUID `db:"product_id" json:"product_id"`
+	LocationID   uuid.UUID `db:"location_id" json:"location_id"`
+	LocationCode string    `db:"location_code" json:"location_code"`
+	Quantity     int       `db:"quantity" json:"quantity"`
+}
+
+// причини руху товару в журналі
+const (
+	MovementOpeningBalance = "opening_balance"
+	MovementSale           = "sale"
+	MovementCancellation   = "cancellation"
+	MovementReturn         = "return"
+	MovementAdjustment     = "adjustment"
+	MovementCycleCount     = "cycle_count"
+	MovementImport         = "import"
+	MovementTransferIn     = "transfer_in"
+	MovementTransferOut    = "transfer_out"
+)
+
+// структура запису журналу руху товару
+type StockMovement struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	ProductID      uuid.UUID  `db:"product_id" json:"product_id"`
+	LocationID     uuid.UUID  `db:"location_id" json:"location_id"`
+	LocationCode   string     `db:"location_code" json:"location_code"`
+	QuantityChange int        `db:"quantity_change" json:"quantity_change"`
+	QuantityAfter  int        `db:"quantity_after" json:"quantity_after"`
+	Reason         string     `db:"reason" json:"reason"`
+	ActorID        *uuid.UUID `db:"actor_id" json:"actor_id,omitempty"`
+	ReferenceID    *uuid.UUID `db:"reference_id" json:"reference_id,omitempty"`
+	Note           *string    `db:"note" json:"note,omitempty"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+}
+
+// MovementSource описує, чому змінюється залишок: причина, посилання (замовлення, переміщення) та коментар.
+// Автор зміни береться з контексту запиту
+type MovementSource struct {
+	Reason      string
+	ReferenceID *uuid.UUID
+	Note        *string
+}
+
+// StockChange нове значення загального залишку продукту: різниця з поточним залишком
+// застосовується до складу LocationID (nil - склад за замовчуванням)
+type StockChange struct {
+	Stock      int
+	LocationID *uuid.UUID
+	Source     MovementSource
+}
+
+// структура для фільтрації журналу руху товару
+type StockMovementFilter struct {
+	ProductID  uuid.UUID
+	LocationID *uuid.UUID
+	Reason     string
+	Limit      int
+	Offset     int
+}
+
+// структура звірки журналу з поточним залишком на складі
+type StockReconciliation struct {
+	LocationID     uuid.UUID `db:"location_id" json:"location_id"`
+	LocationCode   string    `db:"location_code" json:"location_code"`
+	Quantity       int       `db:"quantity" json:"quantity"`
+	LedgerQuantity int       `db:"ledger_quantity" json:"ledger_quantity"`
+	Difference     int       `db:"difference" json:"difference"`
+}
+
+// статуси сповіщення про низький залишок
+const (
+	AlertStatusOpen     = "open"
+	AlertStatusResolved = "resolved"
+)
+
+// структура налаштувань дозамовлення продукту; LowStockThreshold nil - сповіщення вимкнені
+type ReorderSettings struct {
+	ProductID         uuid.UUID `db:"id" json:"product_id"`
+	LowStockThreshold *int      `db:"low_stock_threshold" json:"low_stock_threshold"`
+	ReorderQuantity   int       `db:"reorder_quantity" json:"reorder_quantity"`
+}
+
+// структура сповіщення про низький залишок
+type LowStockAlert struct {
+	ID              uuid.UUID  `db:"id" json:"id"`
+	ProductID       uuid.UUID  `db:"product_id" json:"product_id"`
+	ProductName     string     `db:"product_name" json:"product_name"`
+	SKU             *string    `db:"sku" json:"sku,omitempty"`
+	Stock           int        `db:"stock" json:"stock"`
+	Threshold       int        `db:"threshold" json:"threshold"`
+	ReorderQuantity int        `db:"reorder_quantity" json:"reorder_quantity"`
+	Status          string     `db:"status" json:"status"`
+	NotifiedAt      *time.Time `db:"notified_at" json:"notified_at,omitempty"`
+	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
+	ResolvedAt      *time.Time `db:"resolved_at" json:"resolved_at,omitempty"`
+}
+
+// структура для фільтрації сповіщень про низький залишок
+type LowStockAlertFilter struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+// структура продукту, який потрібно дозамовити
+type ReorderItem struct {
+	ProductID       uuid.UUID `db:"id" json:"product_id"`
+	Name            string    `db:"name" json:"name"`
+	SKU             *string   `db:"sku" json:"sku,omitempty"`
+	Stock           int       `db:"stock" json:"stock"`
+	Threshold       int       `db:"low_stock_threshold" json:"threshold"`
+	ReorderQuantity int       `db:"reorder_quantity" json:"reorder_quantity"`
+}
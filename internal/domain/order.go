@@ -1,8 +1,12 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 
+	"github.com/Xiancel/ecommerce/internal/pagination"
 	"github.com/google/uuid"
 )
 
@@ -14,8 +18,24 @@ type Order struct {
 	TotalAmount     float64         `db:"total_amount" json:"total_amount"`
 	ShippingAddress ShippingAddress `db:"shipping_address" json:"shipping_address"`
 	PaymentMethod   string          `db:"payment_method" json:"payment_method"`
+	PaidAt          *time.Time      `db:"paid_at" json:"paid_at,omitempty"`
 	CreatedAt       time.Time       `db:"created_at" json:"created_at"`
 	UpdatedAt       time.Time       `db:"updated_at" json:"updated_at"`
+	// Items позиції замовлення (заповнюються сервісом)
+	Items []*OrderItem `db:"-" json:"items,omitempty"`
+	// ExpectedShipDate найпізніша очікувана дата відвантаження серед позицій
+	ExpectedShipDate *time.Time `db:"-" json:"expected_ship_date,omitempty"`
+}
+
+// структура для фільтрації замовлень (від нових до старих)
+type OrderListFilter struct {
+	// UserID замовлення користувача (nil - усі)
+	UserID *uuid.UUID
+	Status string
+	// Cursor позиція keyset пагінації; якщо задана, Offset не використовується
+	Cursor *pagination.Cursor
+	Limit  int
+	Offset int
 }
 
 // структура адреси для замовлень
@@ -26,6 +46,25 @@ type ShippingAddress struct {
 	Country    string
 }
 
+// Value зберігає адресу як JSONB
+func (a ShippingAddress) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// Scan читає адресу з JSONB
+func (a *ShippingAddress) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for shipping address")
+	}
+	return json.Unmarshal(data, a)
+}
+
 // структура товарів у замовлені
 type OrderItem struct {
 	ID        uuid.UUID `db:"id" json:"id"`
@@ -33,5 +72,12 @@ type OrderItem struct {
 	ProductID uuid.UUID `db:"product_id" json:"product_id"`
 	Quantity  int       `db:"quantity" json:"quantity"`
 	Price     float64   `db:"price" json:"price"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	// Backordered позиція (частково) оформлена під замовлення
+	Backordered bool `db:"backordered" json:"backordered"`
+	// BackorderedQuantity кількість, яка ще чекає на надходження товару
+	BackorderedQuantity int        `db:"backordered_quantity" json:"backordered_quantity"`
+	ExpectedShipDate    *time.Time `db:"expected_ship_date" json:"expected_ship_date,omitempty"`
+	// Components склад комплекту на момент замовлення (тільки для комплектів)
+	Components OrderItemComponents `db:"components" json:"components,omitempty"`
+	CreatedAt  time.Time           `db:"created_at" json:"created_at"`
 }
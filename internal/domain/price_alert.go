@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// структура сповіщення про зниження ціни продукту до цільової
+type PriceAlert struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	ProductID   uuid.UUID `db:"product_id" json:"product_id"`
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	TargetPrice float64   `db:"target_price" json:"target_price"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	// TriggeredAt ціна досягла цільової, сповіщення чекає на відправку
+	TriggeredAt    *time.Time `db:"triggered_at" json:"triggered_at,omitempty"`
+	TriggeredPrice *float64   `db:"triggered_price" json:"triggered_price,omitempty"`
+	NotifiedAt     *time.Time `db:"notified_at" json:"notified_at,omitempty"`
+}
+
+// структура сповіщення з даними продукту та поточною ціною (з урахуванням розпродажу)
+type PriceAlertWithProduct struct {
+	PriceAlert
+	ProductName  string  `db:"product_name" json:"product_name"`
+	ProductSlug  string  `db:"product_slug" json:"product_slug"`
+	CurrentPrice float64 `db:"current_price" json:"current_price"`
+	// RecentNotifications кількість сповіщень, вже відправлених користувачу за період ліміту
+	RecentNotifications int `db:"recent_notifications" json:"-"`
+}
@@ -1,22 +1,108 @@
 package models
 
 import (
+	"strconv"
 	"time"
 
+	"github.com/Xiancel/ecommerce/internal/pagination"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// статуси продукту
+const (
+	ProductStatusDraft     = "draft"
+	ProductStatusPublished = "published"
+	ProductStatusArchived  = "archived"
+)
+
+// політики продажу при нульовому залишку
+const (
+	BackorderDeny     = "deny"
+	BackorderAllow    = "allow"
+	BackorderPreorder = "preorder"
 )
 
 // структура Продуктів
 type Product struct {
-	ID          uuid.UUID  `db:"id" json:"id"`
-	Name        string     `db:"name" json:"name"`
-	Description *string    `db:"description" json:"description"`
-	Price       float64    `db:"price" json:"price"`
-	Stock       int        `db:"stock" json:"stock"`
-	CategoryID  *uuid.UUID `db:"category_id" json:"category_id,omitempty"`
-	ImageURL    *string    `db:"image_url" json:"image_url,omitempty"`
-	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+	ID          uuid.UUID      `db:"id" json:"id"`
+	SKU         *string        `db:"sku" json:"sku,omitempty"`
+	Slug        string         `db:"slug" json:"slug"`
+	Name        string         `db:"name" json:"name"`
+	Description *string        `db:"description" json:"description"`
+	Price       float64        `db:"price" json:"price"`
+	Stock       int            `db:"stock" json:"stock"`
+	CategoryID  *uuid.UUID     `db:"category_id" json:"category_id,omitempty"`
+	ImageURL    *string        `db:"image_url" json:"image_url,omitempty"`
+	Tags        pq.StringArray `db:"tags" json:"tags"`
+	Status      string         `db:"status" json:"status"`
+	RatingAvg   float64        `db:"rating_avg" json:"rating_avg"`
+	RatingCount int            `db:"rating_count" json:"rating_count"`
+	PublishAt   *time.Time     `db:"publish_at" json:"publish_at,omitempty"`
+	UnpublishAt *time.Time     `db:"unpublish_at" json:"unpublish_at,omitempty"`
+	// BackorderPolicy чи можна купити товар при нульовому залишку (deny, allow, preorder)
+	BackorderPolicy string `db:"backorder_policy" json:"backorder_policy"`
+	// AvailableAt очікувана дата надходження; для передзамовлення - дата релізу
+	AvailableAt *time.Time `db:"available_at" json:"available_at,omitempty"`
+	// IsDigital цифровий товар (файл для завантаження): без складу та доставки
+	IsDigital bool `db:"is_digital" json:"is_digital"`
+	// IsBundle комплект з інших продуктів; Stock обчислюється із залишків компонентів
+	IsBundle bool `db:"is_bundle" json:"is_bundle"`
+	// SalePrice ціна розпродажу, що діє з SaleStartsAt до SaleEndsAt (межі необов'язкові)
+	SalePrice    *float64   `db:"sale_price" json:"sale_price,omitempty"`
+	SaleStartsAt *time.Time `db:"sale_starts_at" json:"sale_starts_at,omitempty"`
+	SaleEndsAt   *time.Time `db:"sale_ends_at" json:"sale_ends_at,omitempty"`
+	DeletedAt    *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// IsVisible повертає true, якщо продукт опублікований і не видалений
+func (p *Product) IsVisible() bool {
+	return p.Status == ProductStatusPublished && p.DeletedAt == nil
+}
+
+// IsPhysical повертає true, якщо товар відвантажується зі складу
+func (p *Product) IsPhysical() bool {
+	return !p.IsDigital
+}
+
+// AllowsBackorder повертає true, якщо товар можна замовити понад наявний залишок
+func (p *Product) AllowsBackorder() bool {
+	return p.BackorderPolicy == BackorderAllow || p.BackorderPolicy == BackorderPreorder
+}
+
+// SaleActive повертає true, якщо розпродаж діє в момент now
+func (p *Product) SaleActive(now time.Time) bool {
+	if p.SalePrice == nil {
+		return false
+	}
+	if p.SaleStartsAt != nil && p.SaleStartsAt.After(now) {
+		return false
+	}
+	return p.SaleEndsAt == nil || p.SaleEndsAt.After(now)
+}
+
+// EffectivePrice повертає ціну, за якою товар продається в момент now (з урахуванням розпродажу)
+func (p *Product) EffectivePrice(now time.Time) float64 {
+	if p.SaleActive(now) && *p.SalePrice < p.Price {
+		return *p.SalePrice
+	}
+	return p.Price
+}
+
+// Availability повертає наявність товару: in_stock, backorder, preorder або out_of_stock
+func (p *Product) Availability() string {
+	if p.Stock > 0 || p.IsDigital {
+		return "in_stock"
+	}
+	switch p.BackorderPolicy {
+	case BackorderAllow:
+		return "backorder"
+	case BackorderPreorder:
+		return "preorder"
+	}
+	return "out_of_stock"
 }
 
 // структура для фільтрації продіктів
@@ -24,8 +110,51 @@ type ListFilter struct {
 	CategoryID *uuid.UUID
 	MinPrice   *float64
 	MaxPrice   *float64
-	Search     string
-	Limit      int
-	Offset     int
-	OrderBy    string
+	// MinRating мінімальний середній рейтинг
+	MinRating *float64
+	Search    string
+	// Status фільтр за статусом (порожній - будь-який); видалені продукти не повертаються
+	Status string
+	// Tag продукти з цим тегом
+	Tag string
+	// CreatedAfter продукти, створені не раніше цього часу
+	CreatedAfter *time.Time
+	// CollectionID продукти ручної колекції (за замовчуванням у порядку колекції)
+	CollectionID *uuid.UUID
+	// SearchTerms розширені синонімами терміни пошуку (якщо порожні, використовується Search)
+	SearchTerms []string
+	// InStock тільки продукти, доступні для покупки (див. Availability)
+	InStock bool
+	// Cursor позиція keyset пагінації; якщо задана, Offset не використовується
+	Cursor  *pagination.Cursor
+	Limit   int
+	Offset  int
+	OrderBy string
+}
+
+// сортування списку продуктів без явного order_by
+const (
+	ProductSortNewest    = "newest"
+	ProductSortRelevance = "relevance"
+)
+
+// ProductSortValues повертає значення колонок сортування продукту для курсора
+// (у порядку колонок ключа сортування в репозиторії)
+func ProductSortValues(p *Product, orderBy string) []string {
+	switch orderBy {
+	case "price_asc", "price_desc":
+		return []string{strconv.FormatFloat(p.Price, 'f', -1, 64)}
+	case "name_asc", "name_desc":
+		return []string{p.Name}
+	case "rating_asc", "rating_desc":
+		return []string{strconv.FormatFloat(p.RatingAvg, 'f', -1, 64), strconv.Itoa(p.RatingCount)}
+	default:
+		return []string{p.CreatedAt.Format(time.RFC3339Nano)}
+	}
+}
+
+// ExportProduct продукт з назвою категорії для експорту та фідів
+type ExportProduct struct {
+	Product
+	CategoryName *string `db:"category_name" json:"category_name,omitempty"`
 }
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// статуси модерації питань та відповідей
+const (
+	QAStatusPending  = "pending"
+	QAStatusApproved = "approved"
+	QAStatusRejected = "rejected"
+)
+
+// структура питання про продукт
+type ProductQuestion struct {
+	ID          uuid.UUID        `db:"id" json:"id"`
+	ProductID   uuid.UUID        `db:"product_id" json:"product_id"`
+	UserID      uuid.UUID        `db:"user_id" json:"user_id"`
+	Body        string           `db:"body" json:"body"`
+	Status      string           `db:"status" json:"status"`
+	AnswerCount int              `db:"answer_count" json:"answer_count"`
+	Answers     []*ProductAnswer `db:"-" json:"answers,omitempty"`
+	CreatedAt   time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time        `db:"updated_at" json:"updated_at"`
+}
+
+// структура відповіді на питання
+type ProductAnswer struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	QuestionID       uuid.UUID `db:"question_id" json:"question_id"`
+	UserID           uuid.UUID `db:"user_id" json:"user_id"`
+	Body             string    `db:"body" json:"body"`
+	IsAdmin          bool      `db:"is_admin" json:"is_admin"`
+	VerifiedPurchase bool      `db:"verified_purchase" json:"verified_purchase"`
+	Status           string    `db:"status" json:"status"`
+	HelpfulCount     int       `db:"helpful_count" json:"helpful_count"`
+	UnhelpfulCount   int       `db:"unhelpful_count" json:"unhelpful_count"`
+	CreatedAt        time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// структура для фільтрації питань
+type QuestionFilter struct {
+	ProductID *uuid.UUID
+	Status    string
+	Limit     int
+	Offset    int
+}
+
+// структура для фільтрації відповідей
+type AnswerFilter struct {
+	QuestionIDs []uuid.UUID
+	Status      string
+	Limit       int
+	Offset      int
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// статуси модерації відгуку
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// структура відгуку на продукт
+type Review struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	ProductID        uuid.UUID `db:"product_id" json:"product_id"`
+	UserID           uuid.UUID `db:"user_id" json:"user_id"`
+	Rating           int       `db:"rating" json:"rating"`
+	Title            *string   `db:"title" json:"title,omitempty"`
+	Body             *string   `db:"body" json:"body,omitempty"`
+	VerifiedPurchase bool      `db:"verified_purchase" json:"verified_purchase"`
+	Status           string    `db:"status" json:"status"`
+	CreatedAt        time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// структура для фільтрації відгуків
+type ReviewFilter struct {
+	ProductID    *uuid.UUID
+	Status       string
+	Rating       int
+	VerifiedOnly bool
+	Limit        int
+	Offset       int
+	OrderBy      string
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// структура набору синонімів для пошуку
+type SynonymSet struct {
+	ID        uuid.UUID      `db:"id" json:"id"`
+	Terms     pq.StringArray `db:"terms" json:"terms" swaggertype:"array,string"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+}
+
+// структура коефіцієнта підсилення продукту в пошуку
+type ProductBoost struct {
+	ProductID uuid.UUID `db:"product_id" json:"product_id"`
+	Factor    float64   `db:"factor" json:"factor"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// структура закріпленого продукту для пошукового запиту
+type SearchPin struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	Query     string    `db:"query" json:"query"`
+	ProductID uuid.UUID `db:"product_id" json:"product_id"`
+	Position  int       `db:"position" json:"position"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// структура результату пошуку з компонентами релевантності
+type ProductSearchHit struct {
+	Product
+	TextScore   float64 `db:"text_score"`
+	Boost       float64 `db:"boost"`
+	PinPosition *int    `db:"pin_position"`
+	Score       float64 `db:"score"`
+}
+
+// структура пояснення оцінки результату пошуку (debug режим)
+type SearchExplanation struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	Name         string    `json:"name"`
+	MatchedTerms []string  `json:"matched_terms"`
+	TextScore    float64   `json:"text_score"`
+	Boost        float64   `json:"boost"`
+	Pinned       bool      `json:"pinned"`
+	PinPosition  *int      `json:"pin_position,omitempty"`
+	Score        float64   `json:"score"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// структура підписки на сповіщення про надходження товару
+type StockSubscription struct {
+	ID         uuid.UUID  `db:"id" json:"id"`
+	ProductID  uuid.UUID  `db:"product_id" json:"product_id"`
+	UserID     uuid.UUID  `db:"user_id" json:"user_id"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	ReadyAt    *time.Time `db:"ready_at" json:"ready_at,omitempty"`
+	NotifiedAt *time.Time `db:"notified_at" json:"notified_at,omitempty"`
+}
+
+// структура підписки, готової до сповіщення, з даними продукту
+type ReadyStockSubscription struct {
+	StockSubscription
+	ProductName string `db:"product_name" json:"product_name"`
+	ProductSlug string `db:"product_slug" json:"product_slug"`
+	Stock       int    `db:"stock" json:"stock"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// статуси підписки
+const (
+	SubscriptionActive = "active"
+	SubscriptionPaused = "paused"
+	// SubscriptionPastDue оплата замовлення не пройшла, чекає повторної спроби
+	SubscriptionPastDue = "past_due"
+	// SubscriptionSuspended спроби оплати вичерпано, потрібен новий платіжний засіб
+	SubscriptionSuspended = "suspended"
+	SubscriptionCancelled = "cancelled"
+)
+
+// структура підписки на регулярну доставку
+type Subscription struct {
+	ID              uuid.UUID       `db:"id" json:"id"`
+	UserID          uuid.UUID       `db:"user_id" json:"user_id"`
+	Status          string          `db:"status" json:"status"`
+	IntervalWeeks   int             `db:"interval_weeks" json:"interval_weeks"`
+	NextRunAt       time.Time       `db:"next_run_at" json:"next_run_at"`
+	ShippingAddress ShippingAddress `db:"shipping_address" json:"shipping_address"`
+	PaymentMethod   string          `db:"payment_method" json:"payment_method"`
+	// PaymentReference токен збереженої картки у платіжного провайдера
+	PaymentReference *string `db:"payment_reference" json:"payment_reference,omitempty"`
+	// FailedAttempts кількість невдалих спроб оплати поточного циклу
+	FailedAttempts int        `db:"failed_attempts" json:"failed_attempts"`
+	RetryAt        *time.Time `db:"retry_at" json:"retry_at,omitempty"`
+	LastError      *string    `db:"last_error" json:"last_error,omitempty"`
+	// LastOrderID останнє створене замовлення; для past_due - замовлення, що чекає оплати
+	LastOrderID *uuid.UUID `db:"last_order_id" json:"last_order_id,omitempty"`
+	PausedAt    *time.Time `db:"paused_at" json:"paused_at,omitempty"`
+	CancelledAt *time.Time `db:"cancelled_at" json:"cancelled_at,omitempty"`
+	// LockedUntil планувальник обробляє підписку до цього часу
+	LockedUntil *time.Time `db:"locked_until" json:"-"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	// UpdatedAt версія рядка: оновлення проходить, лише якщо підписку не змінили після читання
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	// Items товари підписки (заповнюються сервісом)
+	Items []*SubscriptionItem `db:"-" json:"items,omitempty"`
+}
+
+// структура товару підписки з поточними даними продукту
+type SubscriptionItem struct {
+	SubscriptionID uuid.UUID `db:"subscription_id" json:"subscription_id"`
+	ProductID      uuid.UUID `db:"product_id" json:"product_id"`
+	Quantity       int       `db:"quantity" json:"quantity"`
+	ProductName    string    `db:"product_name" json:"product_name"`
+	// Price поточна ціна продукту з урахуванням розпродажу
+	Price float64 `db:"price" json:"price"`
+}
+
+// структура замовлення, створеного підпискою
+type SubscriptionOrder struct {
+	SubscriptionID uuid.UUID `db:"subscription_id" json:"subscription_id"`
+	OrderID        uuid.UUID `db:"order_id" json:"order_id"`
+	// CycleAt дата циклу, за який створено замовлення
+	CycleAt     time.Time `db:"cycle_at" json:"cycle_at"`
+	OrderStatus string    `db:"order_status" json:"order_status"`
+	TotalAmount float64   `db:"total_amount" json:"total_amount"`
+	// TransactionID транзакція оплати замовлення (nil, якщо ще не оплачено карткою)
+	TransactionID *string   `db:"transaction_id" json:"transaction_id,omitempty"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "github.com/google/uuid"
+
+// типи підказок автодоповнення
+const (
+	SuggestionTypeProduct  = "product"
+	SuggestionTypeCategory = "category"
+)
+
+// структура підказки для автодоповнення пошуку
+type Suggestion struct {
+	Type  string    `db:"type" json:"type"`
+	ID    uuid.UUID `db:"id" json:"id"`
+	Text  string    `db:"text" json:"text"`
+	Score float64   `db:"score" json:"score"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// переклад назви та опису продукту
+type ProductTranslation struct {
+	ProductID   uuid.UUID `db:"product_id" json:"product_id"`
+	Locale      string    `db:"locale" json:"locale"`
+	Name        string    `db:"name" json:"name"`
+	Description *string   `db:"description" json:"description,omitempty"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// переклад категорії разом із локалізованим slug
+type CategoryTranslation struct {
+	CategoryID  uuid.UUID `db:"category_id" json:"category_id"`
+	Locale      string    `db:"locale" json:"locale"`
+	Name        string    `db:"name" json:"name"`
+	Slug        string    `db:"slug" json:"slug"`
+	Description *string   `db:"description" json:"description,omitempty"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// запис без перекладу (для звіту про відсутні переклади)
+type MissingTranslation struct {
+	ID   uuid.UUID `db:"id" json:"id"`
+	Name string    `db:"name" json:"name"`
+	Slug string    `db:"slug" json:"slug"`
+}
+
+type localeKey struct{}
+
+// ContextWithLocale зберігає в контексті мову, якою потрібно повертати вміст
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext повертає мову запиту; порожній рядок - базовий вміст без перекладу
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
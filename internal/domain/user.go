@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/Xiancel/ecommerce/internal/pagination"
 	"github.com/google/uuid"
 )
 
@@ -17,3 +18,14 @@ type User struct {
 	CreatedAt    time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
 }
+
+// структура для фільтрації користувачів (від нових до старих)
+type UserListFilter struct {
+	// Search частина імені, прізвища або email
+	Search string
+	Role   string
+	// Cursor позиція keyset пагінації; якщо задана, Offset не використовується
+	Cursor *pagination.Cursor
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// структура списку бажань користувача
+type Wishlist struct {
+	ID       uuid.UUID `db:"id" json:"id"`
+	UserID   uuid.UUID `db:"user_id" json:"user_id,omitempty"`
+	Name     string    `db:"name" json:"name"`
+	IsPublic bool      `db:"is_public" json:"is_public"`
+	// ShareToken токен публічного посилання (лише для публічного списку)
+	ShareToken *string   `db:"share_token" json:"share_token,omitempty"`
+	ItemCount  int       `db:"item_count" json:"item_count"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// структура товару в списку бажань
+type WishlistItem struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	WishlistID uuid.UUID `db:"wishlist_id" json:"wishlist_id"`
+	ProductID  uuid.UUID `db:"product_id" json:"product_id"`
+	// PriceAtAdd ціна товару на момент додавання
+	PriceAtAdd float64   `db:"price_at_add" json:"price_at_add"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// структура товару списку бажань з поточною ціною та наявністю
+type WishlistItemWithProduct struct {
+	WishlistItem
+	ProductName     string  `db:"product_name" json:"product_name"`
+	ProductSlug     string  `db:"product_slug" json:"product_slug"`
+	ImageURL        *string `db:"image_url" json:"image_url,omitempty"`
+	Price           float64 `db:"price" json:"price"`
+	Stock           int     `db:"stock" json:"stock"`
+	BackorderPolicy string  `db:"backorder_policy" json:"-"`
+	IsDigital       bool    `db:"is_digital" json:"-"`
+	// Visible продукт опублікований і не видалений
+	Visible bool `db:"visible" json:"visible"`
+	// Availability наявність: in_stock, backorder, preorder або out_of_stock
+	Availability string `db:"-" json:"availability"`
+	// PriceDropped поточна ціна нижча за ціну на момент додавання
+	PriceDropped bool    `db:"-" json:"price_dropped"`
+	PriceDrop    float64 `db:"-" json:"price_drop,omitempty"`
+}
@@ -27,9 +27,13 @@ func NewAdminHandler(productSrv productSrv.ProductService, orderSrv orderSrv.Ord
 func (h *AdminHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/admin", func(r chi.Router) {
 		//Product
+		r.Get("/products", h.ListProducts)
 		r.Post("/products", h.CreateProduct)
 		r.Put("/products/{id}", h.UpdateProduct)
-		//r.Delete("products/{id}", h.DeleteProduct)
+		r.Delete("/products/{id}", h.DeleteProduct)
+
+		//Category
+		r.Post("/categories", h.CreateCategory)
 
 		//Order
 		r.Get("/orders", h.ListAllOrder)
@@ -75,6 +79,36 @@ func (h *AdminHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, createProd)
 }
 
+// CreateCategory godoc
+// @Summary Створення категорії (Admin)
+// @Description Створює категорію; slug генерується з назви (з транслітерацією кирилиці)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param category body product.CreateCategoryRequest true "Дані категорії"
+// @Success 201 {object} models.Category
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or validation error"
+// @Failure 409 {object} http.ErrorResponse "Category already exists"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/categories [post]
+func (h *AdminHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	// отримання данних з request
+	var req productSrv.CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	category, err := h.productSrv.CreateCategory(r.Context(), req)
+	if err != nil {
+		handlerServiceProductError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, category)
+}
+
 // UpdateProduct godoc
 // @Summary Оновлення продукту (Admin)
 // @Description Оновлює існуючий продукт
@@ -114,6 +148,97 @@ func (h *AdminHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, updProd)
 }
 
+// DeleteProduct godoc
+// @Summary Видалення продукту (Admin)
+// @Description М'яко видаляє продукт: він зникає з каталогу, але лишається в історії замовлень
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid product ID"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id} [delete]
+func (h *AdminHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// видалення продукта
+	if err := h.productSrv.DeleteProduct(r.Context(), id); err != nil {
+		handlerServiceProductError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "product deleted",
+	})
+}
+
+// ListProducts godoc
+// @Summary Отримати список продуктів (Admin)
+// @Description Повертає продукти з будь-яким статусом (видалені не повертаються)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "Статус продукту (draft, published, archived, all)" default(all)
+// @Param search query string false "Пошуковий запит"
+// @Param limit query int false "Кількість елементів на сторінку" default(20)
+// @Param offset query int false "Зміщення для пагінації" default(0)
+// @Param cursor query string false "Курсор сторінки (next_cursor або prev_cursor з попередньої відповіді)"
+// @Success 200 {object} product.ProductListResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products [get]
+func (h *AdminHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
+	// встановлення фільтрів за замовчуванням
+	filter := productSrv.ProductFilter{
+		Status: productSrv.StatusAll,
+		Limit:  20,
+		Offset: 0,
+	}
+	// фільтрація
+	if status := r.URL.Query().Get("status"); status != "" {
+		filter.Status = status
+	}
+	filter.Search = r.URL.Query().Get("search")
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid Offset")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	// курсор сторінки з next_cursor/prev_cursor (має пріоритет над offset)
+	filter.Cursor = r.URL.Query().Get("cursor")
+
+	// отримання списку продуктів
+	response, err := h.productSrv.ListProduct(r.Context(), filter)
+	if err != nil {
+		handlerServiceProductError(w, err)
+		return
+	}
+	setPageLinks(w, r, response.NextCursor, response.PrevCursor)
+	respondJSON(w, http.StatusOK, response)
+}
+
 // Orders
 
 // ListAllOrder godoc
@@ -125,6 +250,7 @@ func (h *AdminHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 // @Param status query string false "Статус замовлення (pending, paid, shipped, canceled, delivered)"
 // @Param limit query int false "Кількість елементів на сторінку" default(20)
 // @Param offset query int false "Зміщення для пагінації" default(0)
+// @Param cursor query string false "Курсор сторінки (next_cursor або prev_cursor з попередньої відповіді)"
 // @Success 200 {object} order.OrderListResponse
 // @Failure 400 {object} http.ErrorResponse "Invalid query parameters"
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
@@ -157,12 +283,16 @@ func (h *AdminHandler) ListAllOrder(w http.ResponseWriter, r *http.Request) {
 		filter.Offset = offset
 	}
 
+	// курсор сторінки з next_cursor/prev_cursor (має пріоритет над offset)
+	filter.Cursor = r.URL.Query().Get("cursor")
+
 	// отримання списку замовлень
 	orders, err := h.orderSrv.ListOrder(r.Context(), filter)
 	if err != nil {
 		handlerOrderError(w, err)
 		return
 	}
+	setPageLinks(w, r, orders.NextCursor, orders.PrevCursor)
 	respondJSON(w, http.StatusOK, orders)
 }
 
@@ -177,6 +307,7 @@ func (h *AdminHandler) ListAllOrder(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} models.Order
 // @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
 // @Failure 404 {object} http.ErrorResponse "Order not found"
+// @Failure 409 {object} http.ErrorResponse "Order already canceled"
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /admin/orders/{id}/status [put]
@@ -247,6 +378,7 @@ func (h *AdminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 // @Param role query string false "Роль користувача (user, admin)"
 // @Param limit query int false "Кількість елементів на сторінку" default(20)
 // @Param offset query int false "Зміщення для пагінації" default(0)
+// @Param cursor query string false "Курсор сторінки (next_cursor або prev_cursor з попередньої відповіді)"
 // @Success 200 {object} user.UserListResponse
 // @Failure 400 {object} http.ErrorResponse "Invalid query parameters"
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
@@ -270,6 +402,7 @@ func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		limit, err := strconv.Atoi(limitStr)
 		if err != nil || limit <= 0 {
 			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
 		}
 		filter.Limit = limit
 	}
@@ -278,15 +411,21 @@ func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		offset, err := strconv.Atoi(offsetStr)
 		if err != nil || offset < 0 {
 			respondError(w, http.StatusBadRequest, "Invalid Offset")
+			return
 		}
 		filter.Offset = offset
 	}
 
+	// курсор сторінки з next_cursor/prev_cursor (має пріоритет над offset)
+	filter.Cursor = r.URL.Query().Get("cursor")
+
 	// отримання списку всіх користувачів
 	response, err := h.userSrv.ListUser(r.Context(), filter)
 	if err != nil {
 		handlerServiceUserError(w, err)
+		return
 	}
+	setPageLinks(w, r, response.NextCursor, response.PrevCursor)
 	respondJSON(w, http.StatusOK, response)
 }
 
@@ -68,11 +68,11 @@ func TestAdmin_CreateProduct_Error(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 
-	var resp models.Product
+	var resp ErrorResponse
 	err := json.NewDecoder(rr.Body).Decode(&resp)
 	assert.NoError(t, err)
 
-	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, http.StatusInternalServerError, resp.Status)
 	mockSrv.AssertExpectations(t)
 }
 
@@ -277,3 +277,77 @@ func TestAdmin_UpdateUser_Success(t *testing.T) {
 	assert.Equal(t, respBody.FirstName, resp.FirstName)
 	mockSrv.AssertExpectations(t)
 }
+
+func TestAdmin_DeleteProduct_Success(t *testing.T) {
+	mockSrv := new(MockProductService)
+	handler := NewAdminHandler(mockSrv, nil, nil)
+
+	productID := uuid.New()
+	mockSrv.On("DeleteProduct", mock.Anything, productID).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/products/"+productID.String(), nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.DeleteProduct(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestAdmin_ListProducts_DefaultsToAllStatuses(t *testing.T) {
+	mockSrv := new(MockProductService)
+	handler := NewAdminHandler(mockSrv, nil, nil)
+
+	filter := productSrv.ProductFilter{
+		Status: productSrv.StatusAll,
+		Limit:  20,
+		Offset: 0,
+	}
+	mockSrv.On("ListProduct", mock.Anything, filter).Return(&productSrv.ProductListResponse{Products: []*models.Product{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/products", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListProducts(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestAdmin_CreateCategory_Success(t *testing.T) {
+	mockSrv := new(MockProductService)
+	handler := NewAdminHandler(mockSrv, nil, nil)
+
+	reqBody := productSrv.CreateCategoryRequest{Name: "Книги"}
+	mockSrv.On("CreateCategory", mock.Anything, reqBody).Return(&models.Category{ID: uuid.New(), Name: "Книги", Slug: "knyhy"}, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/admin/categories", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateCategory(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var resp models.Category
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "knyhy", resp.Slug)
+}
+
+func TestAdmin_CreateCategory_Exists(t *testing.T) {
+	mockSrv := new(MockProductService)
+	handler := NewAdminHandler(mockSrv, nil, nil)
+
+	reqBody := productSrv.CreateCategoryRequest{Name: "Books"}
+	mockSrv.On("CreateCategory", mock.Anything, reqBody).Return(nil, productSrv.ErrCategoryExists)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/admin/categories", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateCategory(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
@@ -26,10 +26,11 @@ func (h *AuthHandler) RegisterRoutes(r chi.Router) {
 
 // Register godoc
 // @Summary Реєстрація нового користувача
-// @Description Реєструє нового користувача та повертає токени доступу
+// @Description Реєструє нового користувача та повертає токени доступу. Кошик гостя (cart_token або X-Cart-Token) переноситься в кошик користувача.
 // @Tags auth
 // @Accept json
 // @Produce json
+// @Param X-Cart-Token header string false "Токен кошика гостя"
 // @Param user body auth.RegisterRequest true "Дані для реєстрації"
 // @Success 201 {object} auth.AuthResponse
 // @Failure 400 {object} http.ErrorResponse "Invalid request body"
@@ -43,6 +44,10 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
+	// кошик гостя переноситься в кошик нового користувача
+	if req.CartToken == "" {
+		req.CartToken = r.Header.Get(CartTokenHeader)
+	}
 
 	// реєстрація користувача
 	resp, err := h.AuthSrv.Register(r.Context(), req)
@@ -55,10 +60,11 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 // Login godoc
 // @Summary Логін користувача
-// @Description Логін користувача та повертає токени доступу
+// @Description Логін користувача та повертає токени доступу. Кошик гостя (cart_token або X-Cart-Token) додається до кошика користувача: кількості сумуються в межах залишку.
 // @Tags auth
 // @Accept json
 // @Produce json
+// @Param X-Cart-Token header string false "Токен кошика гостя"
 // @Param user body auth.LoginRequset true "Дані для логіну"
 // @Success 200 {object} auth.AuthResponse
 // @Failure 400 {object} http.ErrorResponse "Invalid request body"
@@ -72,6 +78,10 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
+	// кошик гостя переноситься в кошик користувача
+	if req.CartToken == "" {
+		req.CartToken = r.Header.Get(CartTokenHeader)
+	}
 	// антефікація користувача
 	resp, err := h.AuthSrv.Login(r.Context(), req)
 	if err != nil {
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"net/http"
+
+	backInStockSrv "github.com/Xiancel/ecommerce/internal/service/backinstock"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type BackInStockHandler struct {
+	backInStockSrv backInStockSrv.BackInStockService
+}
+
+func NewBackInStockHandler(backInStockSrv backInStockSrv.BackInStockService) *BackInStockHandler {
+	return &BackInStockHandler{backInStockSrv: backInStockSrv}
+}
+
+// RegisterUserRoutes маршрути підписок на надходження товару для авторизованих користувачів
+func (h *BackInStockHandler) RegisterUserRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/products/{id}/notify-me", h.GetStatus)
+		r.Post("/products/{id}/notify-me", h.Subscribe)
+		r.Delete("/products/{id}/notify-me", h.Unsubscribe)
+	})
+}
+
+// Subscribe godoc
+// @Summary Повідомити про надходження
+// @Description Підписує користувача на сповіщення, коли товар, якого немає в наявності, знову з'явиться
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 201 {object} models.StockSubscription
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 409 {object} http.ErrorResponse "Already subscribed or product in stock"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /products/{id}/notify-me [post]
+func (h *BackInStockHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	subscription, err := h.backInStockSrv.Subscribe(r.Context(), userID, productID)
+	if err != nil {
+		handlerBackInStockError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, subscription)
+}
+
+// Unsubscribe godoc
+// @Summary Скасувати сповіщення про надходження
+// @Description Скасовує активну підписку користувача на продукт
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Subscription not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /products/{id}/notify-me [delete]
+func (h *BackInStockHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	if err := h.backInStockSrv.Unsubscribe(r.Context(), userID, productID); err != nil {
+		handlerBackInStockError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "subscription cancelled",
+	})
+}
+
+// GetStatus godoc
+// @Summary Статус підписки на надходження
+// @Description Повертає, чи підписаний користувач на сповіщення про надходження продукту
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} backinstock.SubscriptionStatusResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /products/{id}/notify-me [get]
+func (h *BackInStockHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	status, err := h.backInStockSrv.GetStatus(r.Context(), userID, productID)
+	if err != nil {
+		handlerBackInStockError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, status)
+}
+
+// handlerBackInStockError повертає помилки
+func handlerBackInStockError(w http.ResponseWriter, err error) {
+	switch err {
+	case backInStockSrv.ErrProductNotFound,
+		backInStockSrv.ErrSubscriptionNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case backInStockSrv.ErrAlreadySubscribed,
+		backInStockSrv.ErrProductInStock:
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	backInStockService "github.com/Xiancel/ecommerce/internal/service/backinstock"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBackInStockService struct {
+	mock.Mock
+}
+
+func (m *MockBackInStockService) Subscribe(ctx context.Context, userID, productID uuid.UUID) (*models.StockSubscription, error) {
+	args := m.Called(ctx, userID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockSubscription), args.Error(1)
+}
+func (m *MockBackInStockService) Unsubscribe(ctx context.Context, userID, productID uuid.UUID) error {
+	args := m.Called(ctx, userID, productID)
+	return args.Error(0)
+}
+func (m *MockBackInStockService) GetStatus(ctx context.Context, userID, productID uuid.UUID) (*backInStockService.SubscriptionStatusResponse, error) {
+	args := m.Called(ctx, userID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*backInStockService.SubscriptionStatusResponse), args.Error(1)
+}
+func (m *MockBackInStockService) DeliverNotifications(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+// newNotifyMeRequest створює запит з ID продукту в маршруті та (опційно) користувачем у контексті
+func newNotifyMeRequest(method string, productID string, userID *uuid.UUID) *http.Request {
+	req := httptest.NewRequest(method, "/products/"+productID+"/notify-me", nil)
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", productID)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx)
+	if userID != nil {
+		ctx = context.WithValue(ctx, ContextKeyUserID, *userID)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestSubscribeBackInStock_Success(t *testing.T) {
+	mockSrv := new(MockBackInStockService)
+	handler := NewBackInStockHandler(mockSrv)
+	userID, productID := uuid.New(), uuid.New()
+
+	mockSrv.On("Subscribe", mock.Anything, userID, productID).
+		Return(&models.StockSubscription{ID: uuid.New(), ProductID: productID, UserID: userID}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.Subscribe(rr, newNotifyMeRequest(http.MethodPost, productID.String(), &userID))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestSubscribeBackInStock_Duplicate(t *testing.T) {
+	mockSrv := new(MockBackInStockService)
+	handler := NewBackInStockHandler(mockSrv)
+	userID, productID := uuid.New(), uuid.New()
+
+	mockSrv.On("Subscribe", mock.Anything, userID, productID).Return(nil, backInStockService.ErrAlreadySubscribed)
+
+	rr := httptest.NewRecorder()
+	handler.Subscribe(rr, newNotifyMeRequest(http.MethodPost, productID.String(), &userID))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestSubscribeBackInStock_Unauthorized(t *testing.T) {
+	mockSrv := new(MockBackInStockService)
+	handler := NewBackInStockHandler(mockSrv)
+
+	rr := httptest.NewRecorder()
+	handler.Subscribe(rr, newNotifyMeRequest(http.MethodPost, uuid.NewString(), nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	mockSrv.AssertNotCalled(t, "Subscribe", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUnsubscribeBackInStock_NotFound(t *testing.T) {
+	mockSrv := new(MockBackInStockService)
+	handler := NewBackInStockHandler(mockSrv)
+	userID, productID := uuid.New(), uuid.New()
+
+	mockSrv.On("Unsubscribe", mock.Anything, userID, productID).Return(backInStockService.ErrSubscriptionNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.Unsubscribe(rr, newNotifyMeRequest(http.MethodDelete, productID.String(), &userID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	bundleSrv "github.com/Xiancel/ecommerce/internal/service/bundle"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type BundleHandler struct {
+	bundleSrv bundleSrv.BundleService
+}
+
+func NewBundleHandler(bundleSrv bundleSrv.BundleService) *BundleHandler {
+	return &BundleHandler{bundleSrv: bundleSrv}
+}
+
+// RegisterRoutes публічні маршрути комплектів
+func (h *BundleHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/products/{id}/bundle", h.GetBundle)
+	})
+}
+
+// RegisterAdminRoutes маршрути керування складом комплектів
+func (h *BundleHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Put("/admin/products/{id}/bundle", h.SetComponents)
+		r.Delete("/admin/products/{id}/bundle", h.DeleteBundle)
+	})
+}
+
+// GetBundle godoc
+// @Summary Склад комплекту
+// @Description Повертає компоненти комплекту, його ціну, вартість компонентів окремо та кількість доступних комплектів
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} bundle.BundleResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Product is not a bundle"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /products/{id}/bundle [get]
+func (h *BundleHandler) GetBundle(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	bundle, err := h.bundleSrv.GetBundle(r.Context(), productID)
+	if err != nil {
+		handlerBundleError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, bundle)
+}
+
+// SetComponents godoc
+// @Summary Задати склад комплекту
+// @Description Замінює компоненти комплекту; продукт без власного залишку стає комплектом. Залишок комплекту обчислюється із залишків компонентів
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param bundle body bundle.SetComponentsRequest true "Компоненти з кількістю"
+// @Success 200 {object} bundle.BundleResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 403 {object} http.ErrorResponse "Forbidden"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 409 {object} http.ErrorResponse "Product cannot be a bundle"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/bundle [put]
+func (h *BundleHandler) SetComponents(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// отримання данних з request
+	var req bundleSrv.SetComponentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	bundle, err := h.bundleSrv.SetComponents(r.Context(), productID, req)
+	if err != nil {
+		handlerBundleError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, bundle)
+}
+
+// DeleteBundle godoc
+// @Summary Розформувати комплект
+// @Description Видаляє склад комплекту; продукт стає звичайним, а його залишок знову рахується по складах
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 403 {object} http.ErrorResponse "Forbidden"
+// @Failure 404 {object} http.ErrorResponse "Product is not a bundle"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/bundle [delete]
+func (h *BundleHandler) DeleteBundle(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	if err := h.bundleSrv.DeleteBundle(r.Context(), productID); err != nil {
+		handlerBundleError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "bundle removed",
+	})
+}
+
+// handlerBundleError повертає помилки
+func handlerBundleError(w http.ResponseWriter, err error) {
+	switch err {
+	case bundleSrv.ErrComponentsRequired,
+		bundleSrv.ErrTooManyComponents,
+		bundleSrv.ErrInvalidComponentQuantity,
+		bundleSrv.ErrDuplicateComponent,
+		bundleSrv.ErrSelfComponent,
+		bundleSrv.ErrInvalidComponent:
+		respondError(w, http.StatusBadRequest, err.Error())
+	case bundleSrv.ErrProductNotFound,
+		bundleSrv.ErrNotBundle,
+		bundleSrv.ErrComponentNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case bundleSrv.ErrInvalidBundle,
+		bundleSrv.ErrBundleHasStock,
+		bundleSrv.ErrBundleIsComponent:
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bundleService "github.com/Xiancel/ecommerce/internal/service/bundle"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBundleService struct {
+	mock.Mock
+}
+
+func (m *MockBundleService) GetBundle(ctx context.Context, productID uuid.UUID) (*bundleService.BundleResponse, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundleService.BundleResponse), args.Error(1)
+}
+func (m *MockBundleService) SetComponents(ctx context.Context, productID uuid.UUID, req bundleService.SetComponentsRequest) (*bundleService.BundleResponse, error) {
+	args := m.Called(ctx, productID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundleService.BundleResponse), args.Error(1)
+}
+func (m *MockBundleService) DeleteBundle(ctx context.Context, productID uuid.UUID) error {
+	args := m.Called(ctx, productID)
+	return args.Error(0)
+}
+
+// newBundleRequest створює запит з ID продукту в маршруті
+func newBundleRequest(method, productID, body string) *http.Request {
+	req := httptest.NewRequest(method, "/products/"+productID+"/bundle", bytes.NewBufferString(body))
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", productID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func TestGetBundle_Success(t *testing.T) {
+	mockSrv := new(MockBundleService)
+	handler := NewBundleHandler(mockSrv)
+	productID := uuid.New()
+
+	mockSrv.On("GetBundle", mock.Anything, productID).Return(&bundleService.BundleResponse{ProductID: productID, Stock: 2}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetBundle(rr, newBundleRequest(http.MethodGet, productID.String(), ""))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"stock":2`)
+}
+
+func TestGetBundle_NotBundle(t *testing.T) {
+	mockSrv := new(MockBundleService)
+	handler := NewBundleHandler(mockSrv)
+	productID := uuid.New()
+
+	mockSrv.On("GetBundle", mock.Anything, productID).Return(nil, bundleService.ErrNotBundle)
+
+	rr := httptest.NewRecorder()
+	handler.GetBundle(rr, newBundleRequest(http.MethodGet, productID.String(), ""))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestSetBundleComponents_Success(t *testing.T) {
+	mockSrv := new(MockBundleService)
+	handler := NewBundleHandler(mockSrv)
+	productID, componentID := uuid.New(), uuid.New()
+
+	mockSrv.On("SetComponents", mock.Anything, productID, bundleService.SetComponentsRequest{
+		Components: []bundleService.ComponentRequest{{ProductID: componentID, Quantity: 2}},
+	}).Return(&bundleService.BundleResponse{ProductID: productID}, nil)
+
+	rr := httptest.NewRecorder()
+	body := `{"components":[{"product_id":"` + componentID.String() + `","quantity":2}]}`
+	handler.SetComponents(rr, newBundleRequest(http.MethodPut, productID.String(), body))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestSetBundleComponents_HasStock(t *testing.T) {
+	mockSrv := new(MockBundleService)
+	handler := NewBundleHandler(mockSrv)
+	productID := uuid.New()
+
+	mockSrv.On("SetComponents", mock.Anything, productID, mock.Anything).Return(nil, bundleService.ErrBundleHasStock)
+
+	rr := httptest.NewRecorder()
+	handler.SetComponents(rr, newBundleRequest(http.MethodPut, productID.String(), `{"components":[]}`))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestSetBundleComponents_InvalidBody(t *testing.T) {
+	mockSrv := new(MockBundleService)
+	handler := NewBundleHandler(mockSrv)
+	productID := uuid.New()
+
+	rr := httptest.NewRecorder()
+	handler.SetComponents(rr, newBundleRequest(http.MethodPut, productID.String(), `{`))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "SetComponents", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteBundle_Success(t *testing.T) {
+	mockSrv := new(MockBundleService)
+	handler := NewBundleHandler(mockSrv)
+	productID := uuid.New()
+
+	mockSrv.On("DeleteBundle", mock.Anything, productID).Return(nil)
+
+	rr := httptest.NewRecorder()
+	handler.DeleteBundle(rr, newBundleRequest(http.MethodDelete, productID.String(), ""))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	models "github.com/Xiancel/ecommerce/internal/domain"
 	cartSrv "github.com/Xiancel/ecommerce/internal/service/cart"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -17,6 +18,7 @@ func NewCartHandler(srv cartSrv.CartService) *CartHandler {
 	return &CartHandler{CartSrv: srv}
 }
 
+// RegisterRoutes маршрути кошика; без авторизації працюють з кошиком гостя за заголовком X-Cart-Token
 func (h *CartHandler) RegisterRoutes(r chi.Router) {
 	r.Group(func(r chi.Router) {
 		r.Get("/cart", h.ListItems)
@@ -29,24 +31,21 @@ func (h *CartHandler) RegisterRoutes(r chi.Router) {
 
 // AddItem godoc
 // @Summary Додає товар у кошик
-// @Description Додає новий товар у кошик користувача
+// @Description Додає новий товар у кошик користувача та фіксує його поточну ціну (price_at_add). Перевіряє, що продукт опублікований і є в наявності. Без авторизації товар додається в кошик гостя; якщо X-Cart-Token не передано або кошик прострочений, створюється новий кошик, токен якого повертається в заголовку X-Cart-Token.
 // @Tags cart
 // @Accept json
 // @Produce json
+// @Param X-Cart-Token header string false "Токен кошика гостя"
 // @Param item body cart.AddCartItemRequest true "Товар для додавання"
 // @Success 201 {object} models.CartItem
-// @Failure 400 {object} http.ErrorResponse "Invalid request body or quantity"
-// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 400 {object} http.ErrorResponse "Invalid request body, quantity or product not available"
+// @Failure 401 {object} http.ErrorResponse "Invalid or expired token"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 409 {object} http.ErrorResponse "Product out of stock or not enough stock"
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /cart/items [post]
 func (h *CartHandler) AddItem(w http.ResponseWriter, r *http.Request) {
-	// отримання ID користувача з контексту
-	userID, ok := GetUserIDFromContext(r.Context())
-	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authorized")
-		return
-	}
 	// отримання данних товару з request
 	var req cartSrv.AddCartItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -54,8 +53,20 @@ func (h *CartHandler) AddItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// додавання товар у кошик користувача
-	item, err := h.CartSrv.AddItem(r.Context(), userID, req)
+	// отримання ID користувача з контексту
+	var item *models.CartItem
+	var err error
+	if userID, ok := GetUserIDFromContext(r.Context()); ok {
+		// додавання товар у кошик користувача
+		item, err = h.CartSrv.AddItem(r.Context(), userID, req)
+	} else {
+		// кошик гостя створюється при першому додаванні товару
+		cart, ok := h.guestCart(w, r, true)
+		if !ok {
+			return
+		}
+		item, err = h.CartSrv.AddGuestItem(r.Context(), cart.ID, req)
+	}
 	if err != nil {
 		handlerCartError(w, err)
 		return
@@ -65,27 +76,22 @@ func (h *CartHandler) AddItem(w http.ResponseWriter, r *http.Request) {
 
 // UpdateItem godoc
 // @Summary Оновлює товар у кошику
-// @Description Змінює кількість або товар у кошику користувача
+// @Description Змінює кількість або товар у кошику користувача або гостя (X-Cart-Token). Збільшення кількості перевіряється за залишком.
 // @Tags cart
 // @Accept json
 // @Produce json
+// @Param X-Cart-Token header string false "Токен кошика гостя"
 // @Param id path string true "ID товару у кошику"
 // @Param item body cart.UpdateCartItemRequest true "Оновлені дані товару"
 // @Success 200 {object} models.CartItem
 // @Failure 400 {object} http.ErrorResponse "Invalid request body or quantity"
-// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 401 {object} http.ErrorResponse "Invalid or expired token"
 // @Failure 404 {object} http.ErrorResponse "Item not found"
+// @Failure 409 {object} http.ErrorResponse "Not enough stock"
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /cart/items/{id} [put]
 func (h *CartHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
-	// отримання ID користувача з контексту
-	userID, ok := GetUserIDFromContext(r.Context())
-	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authorized")
-		return
-	}
-
 	// отримання ID товару
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
@@ -102,7 +108,16 @@ func (h *CartHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// оновлення товару в кошику
-	item, err := h.CartSrv.UpdateItem(r.Context(), userID, id, req)
+	var item *models.CartItem
+	if userID, ok := GetUserIDFromContext(r.Context()); ok {
+		item, err = h.CartSrv.UpdateItem(r.Context(), userID, id, req)
+	} else {
+		cart, ok := h.guestCart(w, r, false)
+		if !ok {
+			return
+		}
+		item, err = h.CartSrv.UpdateGuestItem(r.Context(), cart.ID, id, req)
+	}
 	if err != nil {
 		handlerCartError(w, err)
 		return
@@ -112,26 +127,20 @@ func (h *CartHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 
 // DeleteItem godoc
 // @Summary Видаляє товар з кошика
-// @Description Видаляє конкретний товар з кошика користувача
+// @Description Видаляє конкретний товар з кошика користувача або гостя (X-Cart-Token)
 // @Tags cart
 // @Accept json
 // @Produce json
+// @Param X-Cart-Token header string false "Токен кошика гостя"
 // @Param id path string true "ID товару у кошику"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} http.ErrorResponse "Invalid item ID"
-// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 401 {object} http.ErrorResponse "Invalid or expired token"
 // @Failure 404 {object} http.ErrorResponse "Item not found"
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /cart/items/{id} [delete]
 func (h *CartHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
-	// отримання ID користувача з контексту
-	userID, ok := GetUserIDFromContext(r.Context())
-	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authorized")
-		return
-	}
-
 	// отримання ID товару
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
@@ -141,7 +150,16 @@ func (h *CartHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// видалення товару з кошика
-	if err := h.CartSrv.DeleteItem(r.Context(), userID, id); err != nil {
+	if userID, ok := GetUserIDFromContext(r.Context()); ok {
+		err = h.CartSrv.DeleteItem(r.Context(), userID, id)
+	} else {
+		cart, ok := h.guestCart(w, r, false)
+		if !ok {
+			return
+		}
+		err = h.CartSrv.DeleteGuestItem(r.Context(), cart.ID, id)
+	}
+	if err != nil {
 		handlerCartError(w, err)
 		return
 	}
@@ -152,25 +170,30 @@ func (h *CartHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 
 // ClearCart godoc
 // @Summary Очищає кошик
-// @Description Видаляє всі товари з кошика користувача
+// @Description Видаляє всі товари з кошика користувача або гостя (X-Cart-Token)
 // @Tags cart
 // @Accept json
 // @Produce json
+// @Param X-Cart-Token header string false "Токен кошика гостя"
 // @Success 200 {object} map[string]string
-// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 401 {object} http.ErrorResponse "Invalid or expired token"
+// @Failure 404 {object} http.ErrorResponse "Guest cart not found"
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /cart [delete]
 func (h *CartHandler) ClearCart(w http.ResponseWriter, r *http.Request) {
-	// отримання ID користувача з контексту
-	userID, ok := GetUserIDFromContext(r.Context())
-	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authorized")
-		return
+	// очищення кошика користувача
+	var err error
+	if userID, ok := GetUserIDFromContext(r.Context()); ok {
+		err = h.CartSrv.ClearItem(r.Context(), userID)
+	} else {
+		cart, ok := h.guestCart(w, r, false)
+		if !ok {
+			return
+		}
+		err = h.CartSrv.ClearGuestCart(r.Context(), cart.ID)
 	}
-
-	// очищення кошика користувача 
-	if err := h.CartSrv.ClearItem(r.Context(), userID); err != nil {
+	if err != nil {
 		handlerCartError(w, err)
 		return
 	}
@@ -182,25 +205,39 @@ func (h *CartHandler) ClearCart(w http.ResponseWriter, r *http.Request) {
 
 // ListItems godoc
 // @Summary Повертає список товарів у кошику
-// @Description Повертає всі товари користувача в кошику
+// @Description Повертає всі товари користувача в кошику. Кожна позиція перевіряється за поточними даними продукту: warnings містить зміни ціни відносно price_at_add, видалені або недоступні товари та кількість понад залишок. Без авторизації повертає кошик гостя за X-Cart-Token (порожній, якщо токен не передано).
 // @Tags cart
 // @Accept json
 // @Produce json
+// @Param X-Cart-Token header string false "Токен кошика гостя"
 // @Success 200 {object} cart.CartListResponse
-// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 401 {object} http.ErrorResponse "Invalid or expired token"
+// @Failure 404 {object} http.ErrorResponse "Guest cart not found"
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /cart [get]
 func (h *CartHandler) ListItems(w http.ResponseWriter, r *http.Request) {
-	// отримання ID користувача з контексту
-	userID, ok := GetUserIDFromContext(r.Context())
-	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authorized")
-		return
-	}
-
 	// отримання списку товарів у кошику користувача
-	items, err := h.CartSrv.ListItem(r.Context(), userID)
+	var items *cartSrv.CartListResponse
+	var err error
+	if userID, ok := GetUserIDFromContext(r.Context()); ok {
+		items, err = h.CartSrv.ListItem(r.Context(), userID)
+	} else {
+		// гість ще нічого не додав - кошик порожній
+		if r.Header.Get(CartTokenHeader) == "" {
+			respondJSON(w, http.StatusOK, &cartSrv.CartListResponse{
+				Items:      []*models.CartItem{},
+				Warnings:   []cartSrv.CartWarning{},
+				AlsoBought: []*models.Product{},
+			})
+			return
+		}
+		cart, ok := h.guestCart(w, r, false)
+		if !ok {
+			return
+		}
+		items, err = h.CartSrv.ListGuestItems(r.Context(), cart.ID)
+	}
 	if err != nil {
 		handlerCartError(w, err)
 		return
@@ -208,14 +245,47 @@ func (h *CartHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, items)
 }
 
-//  handlerCartError повертає помилки
+// guestCart повертає кошик гостя за заголовком X-Cart-Token та повертає токен у відповіді.
+// create - створити новий кошик, якщо токен не передано або кошик прострочений
+func (h *CartHandler) guestCart(w http.ResponseWriter, r *http.Request, create bool) (*models.GuestCart, bool) {
+	token := r.Header.Get(CartTokenHeader)
+	if token == "" && !create {
+		respondError(w, http.StatusNotFound, cartSrv.ErrGuestCartNotFound.Error())
+		return nil, false
+	}
+
+	var cart *models.GuestCart
+	var err error
+	if token != "" {
+		cart, err = h.CartSrv.ResolveGuestCart(r.Context(), token)
+	}
+	if token == "" || (create && err == cartSrv.ErrGuestCartNotFound) {
+		cart, err = h.CartSrv.CreateGuestCart(r.Context())
+	}
+	if err != nil {
+		handlerCartError(w, err)
+		return nil, false
+	}
+
+	w.Header().Set(CartTokenHeader, cart.Token)
+	return cart, true
+}
+
+// handlerCartError повертає помилки
 func handlerCartError(w http.ResponseWriter, err error) {
 	switch err {
-	case cartSrv.ErrItemNotFound:
+	case cartSrv.ErrItemNotFound,
+		cartSrv.ErrProductNotFound,
+		cartSrv.ErrGuestCartNotFound:
 		respondError(w, http.StatusNotFound, err.Error())
+	case cartSrv.ErrOutOfStock,
+		cartSrv.ErrInsufficientStock:
+		respondError(w, http.StatusConflict, err.Error())
 	case cartSrv.ErrInvalidQuantity,
+		cartSrv.ErrEmptyQuantity,
 		cartSrv.ErrProductNotAvailable,
-		cartSrv.ErrInvalidProductID:
+		cartSrv.ErrInvalidProductID,
+		cartSrv.ErrInvalidCartToken:
 		respondError(w, http.StatusBadRequest, err.Error())
 	default:
 		respondError(w, http.StatusInternalServerError, "internal server error")
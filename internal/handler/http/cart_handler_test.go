@@ -50,6 +50,57 @@ func (m *MockCartService) ClearItem(ctx context.Context, userID uuid.UUID) error
 	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
+func (m *MockCartService) CreateGuestCart(ctx context.Context) (*models.GuestCart, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GuestCart), args.Error(1)
+}
+func (m *MockCartService) ResolveGuestCart(ctx context.Context, token string) (*models.GuestCart, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GuestCart), args.Error(1)
+}
+func (m *MockCartService) AddGuestItem(ctx context.Context, cartID uuid.UUID, req cartService.AddCartItemRequest) (*models.CartItem, error) {
+	args := m.Called(ctx, cartID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CartItem), args.Error(1)
+}
+func (m *MockCartService) UpdateGuestItem(ctx context.Context, cartID, itemID uuid.UUID, req cartService.UpdateCartItemRequest) (*models.CartItem, error) {
+	args := m.Called(ctx, cartID, itemID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CartItem), args.Error(1)
+}
+func (m *MockCartService) DeleteGuestItem(ctx context.Context, cartID, itemID uuid.UUID) error {
+	args := m.Called(ctx, cartID, itemID)
+	return args.Error(0)
+}
+func (m *MockCartService) ListGuestItems(ctx context.Context, cartID uuid.UUID) (*cartService.CartListResponse, error) {
+	args := m.Called(ctx, cartID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cartService.CartListResponse), args.Error(1)
+}
+func (m *MockCartService) ClearGuestCart(ctx context.Context, cartID uuid.UUID) error {
+	args := m.Called(ctx, cartID)
+	return args.Error(0)
+}
+func (m *MockCartService) MergeGuestCart(ctx context.Context, userID uuid.UUID, token string) (int, error) {
+	args := m.Called(ctx, userID, token)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockCartService) ExpireGuestCarts(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
 
 func TestListItems_Succes(t *testing.T) {
 	mockService := new(MockCartService)
@@ -137,6 +188,61 @@ func TestAddItem_BadRequest(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
+func TestAddItem_OutOfStock(t *testing.T) {
+	mockSrv := new(MockCartService)
+	handler := NewCartHandler(mockSrv)
+
+	userID := uuid.New()
+	reqBody := `{"product_id":"` + uuid.New().String() + `","quantity":5}`
+
+	mockSrv.On("AddItem", mock.Anything, userID, mock.Anything).
+		Return(nil, cartService.ErrInsufficientStock)
+
+	req := httptest.NewRequest(http.MethodPost, "/cart/items", strings.NewReader(reqBody))
+	req = req.WithContext(context.WithValue(req.Context(), ContextKeyUserID, userID))
+	rr := httptest.NewRecorder()
+
+	handler.AddItem(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestListItems_Warnings(t *testing.T) {
+	mockSrv := new(MockCartService)
+	handler := NewCartHandler(mockSrv)
+
+	userID, itemID := uuid.New(), uuid.New()
+	priceAtAdd, current := 20.0, 25.0
+	expected := &cartService.CartListResponse{
+		Items: []*models.CartItem{{ID: itemID, Quantity: 1, PriceAtAdd: priceAtAdd}},
+		Warnings: []cartService.CartWarning{{
+			ItemID:       itemID,
+			Code:         cartService.WarningPriceChanged,
+			PriceAtAdd:   &priceAtAdd,
+			CurrentPrice: &current,
+		}},
+		TotalPrice: current,
+	}
+
+	mockSrv.On("ListItem", mock.Anything, userID).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/cart", nil)
+	req = req.WithContext(context.WithValue(req.Context(), ContextKeyUserID, userID))
+	rr := httptest.NewRecorder()
+
+	handler.ListItems(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp cartService.CartListResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Len(t, resp.Warnings, 1)
+	assert.Equal(t, cartService.WarningPriceChanged, resp.Warnings[0].Code)
+	assert.Equal(t, 25.0, *resp.Warnings[0].CurrentPrice)
+	mockSrv.AssertExpectations(t)
+}
+
 func TestUpdateItem_Success(t *testing.T) {
 	mockSrv := new(MockCartService)
 	handler := NewCartHandler(mockSrv)
@@ -251,3 +357,93 @@ func TestClearCart_Success(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	mockSrv.AssertExpectations(t)
 }
+
+func TestAddItem_GuestCreatesCart(t *testing.T) {
+	mockSrv := new(MockCartService)
+	handler := NewCartHandler(mockSrv)
+	cart := &models.GuestCart{ID: uuid.New(), Token: "new-token"}
+
+	mockSrv.On("CreateGuestCart", mock.Anything).Return(cart, nil)
+	mockSrv.On("AddGuestItem", mock.Anything, cart.ID, mock.Anything).Return(&models.CartItem{Quantity: 1}, nil)
+
+	reqBody := `{"product_id":"` + uuid.New().String() + `","quantity":1}`
+	req := httptest.NewRequest(http.MethodPost, "/cart/items", strings.NewReader(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.AddItem(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "new-token", rr.Header().Get(CartTokenHeader))
+	mockSrv.AssertExpectations(t)
+}
+
+func TestAddItem_GuestExpiredCartReplaced(t *testing.T) {
+	mockSrv := new(MockCartService)
+	handler := NewCartHandler(mockSrv)
+	cart := &models.GuestCart{ID: uuid.New(), Token: "new-token"}
+
+	mockSrv.On("ResolveGuestCart", mock.Anything, "old-token").Return(nil, cartService.ErrGuestCartNotFound)
+	mockSrv.On("CreateGuestCart", mock.Anything).Return(cart, nil)
+	mockSrv.On("AddGuestItem", mock.Anything, cart.ID, mock.Anything).Return(&models.CartItem{Quantity: 1}, nil)
+
+	reqBody := `{"product_id":"` + uuid.New().String() + `","quantity":1}`
+	req := httptest.NewRequest(http.MethodPost, "/cart/items", strings.NewReader(reqBody))
+	req.Header.Set(CartTokenHeader, "old-token")
+	rr := httptest.NewRecorder()
+
+	handler.AddItem(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "new-token", rr.Header().Get(CartTokenHeader))
+}
+
+func TestListItems_GuestWithoutToken(t *testing.T) {
+	mockSrv := new(MockCartService)
+	handler := NewCartHandler(mockSrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/cart", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListItems(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertNotCalled(t, "CreateGuestCart", mock.Anything)
+}
+
+func TestListItems_Guest(t *testing.T) {
+	mockSrv := new(MockCartService)
+	handler := NewCartHandler(mockSrv)
+	cart := &models.GuestCart{ID: uuid.New(), Token: "token"}
+
+	mockSrv.On("ResolveGuestCart", mock.Anything, "token").Return(cart, nil)
+	mockSrv.On("ListGuestItems", mock.Anything, cart.ID).Return(&cartService.CartListResponse{Items: []*models.CartItem{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/cart", nil)
+	req.Header.Set(CartTokenHeader, "token")
+	rr := httptest.NewRecorder()
+
+	handler.ListItems(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestDeleteItem_GuestInvalidToken(t *testing.T) {
+	mockSrv := new(MockCartService)
+	handler := NewCartHandler(mockSrv)
+	itemID := uuid.New()
+
+	mockSrv.On("ResolveGuestCart", mock.Anything, "forged").Return(nil, cartService.ErrInvalidCartToken)
+
+	req := httptest.NewRequest(http.MethodDelete, "/cart/items/"+itemID.String(), nil)
+	req.Header.Set(CartTokenHeader, "forged")
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", itemID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+	rr := httptest.NewRecorder()
+
+	handler.DeleteItem(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "DeleteGuestItem", mock.Anything, mock.Anything, mock.Anything)
+}
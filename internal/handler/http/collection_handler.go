@@ -0,0 +1,309 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	collectionSrv "github.com/Xiancel/ecommerce/internal/service/collection"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type CollectionHandler struct {
+	collectionSrv collectionSrv.CollectionService
+}
+
+func NewCollectionHandler(collectionSrv collectionSrv.CollectionService) *CollectionHandler {
+	return &CollectionHandler{collectionSrv: collectionSrv}
+}
+
+// RegisterRoutes публічні маршрути колекцій
+func (h *CollectionHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/collections", h.ListPublishedCollections)
+		r.Get("/collections/{slug}/products", h.ListCollectionProducts)
+	})
+}
+
+// RegisterAdminRoutes маршрути керування колекціями (Admin)
+func (h *CollectionHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/admin/collections", h.ListCollections)
+		r.Post("/admin/collections", h.CreateCollection)
+		r.Get("/admin/collections/{id}", h.GetCollection)
+		r.Put("/admin/collections/{id}", h.UpdateCollection)
+		r.Delete("/admin/collections/{id}", h.DeleteCollection)
+		r.Put("/admin/collections/{id}/products", h.SetCollectionProducts)
+	})
+}
+
+// ListPublishedCollections godoc
+// @Summary Список колекцій
+// @Description Повертає опубліковані колекції товарів
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Collection
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /collections [get]
+func (h *CollectionHandler) ListPublishedCollections(w http.ResponseWriter, r *http.Request) {
+	collections, err := h.collectionSrv.ListCollections(r.Context(), true)
+	if err != nil {
+		handlerCollectionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, collections)
+}
+
+// ListCollectionProducts godoc
+// @Summary Продукти колекції
+// @Description Повертає опубліковані продукти колекції. Ручна колекція за замовчуванням у порядку, заданому адміністратором; розумна обчислюється за її правилами.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param slug path string true "Slug колекції"
+// @Param order_by query string false "Сортування (price_asc, price_desc, name_asc, name_desc, rating_asc, rating_desc)"
+// @Param limit query integer false "Кількість елементів на сторінку" default(20) minimum(1) maximum(100)
+// @Param offset query integer false "Зміщення для пагінації" default(0) minimum(0)
+// @Success 200 {object} collection.CollectionProductsResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid parameters"
+// @Failure 404 {object} http.ErrorResponse "Collection not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /collections/{slug}/products [get]
+func (h *CollectionHandler) ListCollectionProducts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := collectionSrv.CollectionProductsFilter{
+		Limit:   20,
+		OrderBy: query.Get("order_by"),
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		filter.Limit = limit
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid Offset")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	response, err := h.collectionSrv.ListCollectionProducts(r.Context(), chi.URLParam(r, "slug"), filter)
+	if err != nil {
+		handlerCollectionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// ListCollections godoc
+// @Summary Список колекцій (Admin)
+// @Description Повертає всі колекції, включно з неопублікованими
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Collection
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/collections [get]
+func (h *CollectionHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	collections, err := h.collectionSrv.ListCollections(r.Context(), false)
+	if err != nil {
+		handlerCollectionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, collections)
+}
+
+// CreateCollection godoc
+// @Summary Створення колекції (Admin)
+// @Description Створює ручну колекцію (впорядкований список product_ids) або розумну (rules: category_id, min_price, max_price, tag, created_within_days)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param collection body collection.CreateCollectionRequest true "Дані колекції"
+// @Success 201 {object} models.Collection
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or validation error"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 409 {object} http.ErrorResponse "Slug already exists"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/collections [post]
+func (h *CollectionHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	// отримання данних з request
+	var req collectionSrv.CreateCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	collection, err := h.collectionSrv.CreateCollection(r.Context(), req)
+	if err != nil {
+		handlerCollectionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, collection)
+}
+
+// GetCollection godoc
+// @Summary Отримати колекцію (Admin)
+// @Description Повертає колекцію; для ручної колекції також впорядкований список product_ids
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID колекції"
+// @Success 200 {object} collection.CollectionResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Collection not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/collections/{id} [get]
+func (h *CollectionHandler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid collection ID")
+		return
+	}
+
+	collection, err := h.collectionSrv.GetCollection(r.Context(), id)
+	if err != nil {
+		handlerCollectionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, collection)
+}
+
+// UpdateCollection godoc
+// @Summary Оновлення колекції (Admin)
+// @Description Оновлює назву, slug, опис, публікацію або правила розумної колекції
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID колекції"
+// @Param collection body collection.UpdateCollectionRequest true "Дані для оновлення"
+// @Success 200 {object} models.Collection
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
+// @Failure 404 {object} http.ErrorResponse "Collection not found"
+// @Failure 409 {object} http.ErrorResponse "Slug already exists"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/collections/{id} [put]
+func (h *CollectionHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid collection ID")
+		return
+	}
+
+	// отримання данних з request
+	var req collectionSrv.UpdateCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	collection, err := h.collectionSrv.UpdateCollection(r.Context(), id, req)
+	if err != nil {
+		handlerCollectionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, collection)
+}
+
+// DeleteCollection godoc
+// @Summary Видалення колекції (Admin)
+// @Description Видаляє колекцію (продукти не змінюються)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID колекції"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Collection not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/collections/{id} [delete]
+func (h *CollectionHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid collection ID")
+		return
+	}
+
+	if err := h.collectionSrv.DeleteCollection(r.Context(), id); err != nil {
+		handlerCollectionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "collection deleted",
+	})
+}
+
+// SetCollectionProducts godoc
+// @Summary Продукти ручної колекції (Admin)
+// @Description Замінює список продуктів ручної колекції; порядок у списку визначає порядок на вітрині
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID колекції"
+// @Param products body collection.SetCollectionProductsRequest true "Впорядкований список ID продуктів"
+// @Success 200 {object} collection.CollectionResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
+// @Failure 404 {object} http.ErrorResponse "Collection or product not found"
+// @Failure 409 {object} http.ErrorResponse "Not a manual collection"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/collections/{id}/products [put]
+func (h *CollectionHandler) SetCollectionProducts(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid collection ID")
+		return
+	}
+
+	// отримання данних з request
+	var req collectionSrv.SetCollectionProductsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	collection, err := h.collectionSrv.SetCollectionProducts(r.Context(), id, req)
+	if err != nil {
+		handlerCollectionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, collection)
+}
+
+// handlerCollectionError повертає помилки
+func handlerCollectionError(w http.ResponseWriter, err error) {
+	switch err {
+	case collectionSrv.ErrCollectionNotFound,
+		collectionSrv.ErrProductNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case collectionSrv.ErrTitleRequired,
+		collectionSrv.ErrInvalidType,
+		collectionSrv.ErrInvalidSlug,
+		collectionSrv.ErrInvalidRules,
+		collectionSrv.ErrEmptyRules,
+		collectionSrv.ErrInvalidOrderBy:
+		respondError(w, http.StatusBadRequest, err.Error())
+	case collectionSrv.ErrSlugExists,
+		collectionSrv.ErrNotManualCollection:
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
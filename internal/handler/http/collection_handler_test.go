@@ -0,0 +1,264 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	collectionService "github.com/Xiancel/ecommerce/internal/service/collection"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCollectionService struct {
+	mock.Mock
+}
+
+func (m *MockCollectionService) CreateCollection(ctx context.Context, req collectionService.CreateCollectionRequest) (*models.Collection, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Collection), args.Error(1)
+}
+func (m *MockCollectionService) UpdateCollection(ctx context.Context, id uuid.UUID, req collectionService.UpdateCollectionRequest) (*models.Collection, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Collection), args.Error(1)
+}
+func (m *MockCollectionService) DeleteCollection(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockCollectionService) GetCollection(ctx context.Context, id uuid.UUID) (*collectionService.CollectionResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*collectionService.CollectionResponse), args.Error(1)
+}
+func (m *MockCollectionService) ListCollections(ctx context.Context, publishedOnly bool) ([]*models.Collection, error) {
+	args := m.Called(ctx, publishedOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Collection), args.Error(1)
+}
+func (m *MockCollectionService) SetCollectionProducts(ctx context.Context, id uuid.UUID, req collectionService.SetCollectionProductsRequest) (*collectionService.CollectionResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*collectionService.CollectionResponse), args.Error(1)
+}
+func (m *MockCollectionService) ListCollectionProducts(ctx context.Context, slug string, filter collectionService.CollectionProductsFilter) (*collectionService.CollectionProductsResponse, error) {
+	args := m.Called(ctx, slug, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*collectionService.CollectionProductsResponse), args.Error(1)
+}
+
+func TestListPublishedCollections_Success(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	mockSrv.On("ListCollections", mock.Anything, true).
+		Return([]*models.Collection{{ID: uuid.New(), Slug: "new-arrivals", Type: models.CollectionTypeSmart}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/collections", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListPublishedCollections(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var collections []models.Collection
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&collections))
+	assert.Len(t, collections, 1)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestListCollectionProducts_Success(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	filter := collectionService.CollectionProductsFilter{Limit: 10, Offset: 5, OrderBy: "price_asc"}
+	mockSrv.On("ListCollectionProducts", mock.Anything, "summer", filter).
+		Return(&collectionService.CollectionProductsResponse{
+			Collection: &models.Collection{Slug: "summer"},
+			Products:   []*models.Product{{ID: uuid.New()}},
+			Total:      1,
+			Limit:      10,
+			Offset:     5,
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/collections/summer/products?limit=10&offset=5&order_by=price_asc", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "summer")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.ListCollectionProducts(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp collectionService.CollectionProductsResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Len(t, resp.Products, 1)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestListCollectionProducts_InvalidLimit(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/collections/summer/products?limit=abc", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "summer")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.ListCollectionProducts(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "ListCollectionProducts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListCollectionProducts_NotFound(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	mockSrv.On("ListCollectionProducts", mock.Anything, "missing", mock.Anything).
+		Return(nil, collectionService.ErrCollectionNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/collections/missing/products", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "missing")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.ListCollectionProducts(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestCreateCollection_Success(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	tag := "summer"
+	reqBody := collectionService.CreateCollectionRequest{
+		Title: "Summer",
+		Type:  models.CollectionTypeSmart,
+		Rules: &models.CollectionRules{Tag: tag},
+	}
+	mockSrv.On("CreateCollection", mock.Anything, reqBody).
+		Return(&models.Collection{ID: uuid.New(), Slug: "summer", Title: "Summer", Type: models.CollectionTypeSmart}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/collections", strings.NewReader(`{"title":"Summer","type":"smart","rules":{"tag":"summer"}}`))
+	rr := httptest.NewRecorder()
+
+	handler.CreateCollection(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestCreateCollection_SlugExists(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	mockSrv.On("CreateCollection", mock.Anything, mock.Anything).Return(nil, collectionService.ErrSlugExists)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/collections", strings.NewReader(`{"title":"Summer","slug":"summer","type":"manual"}`))
+	rr := httptest.NewRecorder()
+
+	handler.CreateCollection(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestCreateCollection_InvalidBody(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/collections", strings.NewReader(`{invalid`))
+	rr := httptest.NewRecorder()
+
+	handler.CreateCollection(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "CreateCollection", mock.Anything, mock.Anything)
+}
+
+func TestSetCollectionProducts_NotManual(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	collectionID, productID := uuid.New(), uuid.New()
+	mockSrv.On("SetCollectionProducts", mock.Anything, collectionID,
+		collectionService.SetCollectionProductsRequest{ProductIDs: []uuid.UUID{productID}}).
+		Return(nil, collectionService.ErrNotManualCollection)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/collections/"+collectionID.String()+"/products",
+		strings.NewReader(`{"product_ids":["`+productID.String()+`"]}`))
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", collectionID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.SetCollectionProducts(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestDeleteCollection_Success(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	collectionID := uuid.New()
+	mockSrv.On("DeleteCollection", mock.Anything, collectionID).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/collections/"+collectionID.String(), nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", collectionID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.DeleteCollection(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestGetCollection_InvalidID(t *testing.T) {
+	mockSrv := new(MockCollectionService)
+	handler := NewCollectionHandler(mockSrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/collections/abc", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "abc")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.GetCollection(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "GetCollection", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,245 @@
+package http
+
+import (
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	downloadSrv "github.com/Xiancel/ecommerce/internal/service/download"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// максимальний розмір запиту з файлом цифрового товару (ліміт файлу перевіряє сервіс)
+const maxDownloadRequestBytes = 512 << 20
+
+// час на передачу великого файлу цифрового товару (перекриває таймаути сервера)
+const downloadRequestTimeout = 30 * time.Minute
+
+type DownloadHandler struct {
+	downloadSrv downloadSrv.DownloadService
+}
+
+func NewDownloadHandler(downloadSrv downloadSrv.DownloadService) *DownloadHandler {
+	return &DownloadHandler{downloadSrv: downloadSrv}
+}
+
+// RegisterRoutes маршрут завантаження за підписаним посиланням (без авторизації)
+func (h *DownloadHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/downloads/{id}", h.Download)
+	})
+}
+
+// RegisterUserRoutes маршрути завантажень для авторизованих користувачів
+func (h *DownloadHandler) RegisterUserRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/orders/{id}/downloads", h.ListOrderDownloads)
+	})
+}
+
+// RegisterAdminRoutes маршрути керування файлами цифрових товарів (Admin)
+func (h *DownloadHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/admin/products/{id}/file", h.GetFile)
+		r.Post("/admin/products/{id}/file", h.UploadFile)
+	})
+}
+
+// ListOrderDownloads godoc
+// @Summary Завантаження замовлення
+// @Description Повертає підписані посилання на цифрові товари оплаченого замовлення. Посилання діють обмежений час та кількість завантажень
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "ID замовлення"
+// @Success 200 {array} download.DownloadLink
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Order not found"
+// @Failure 409 {object} http.ErrorResponse "Order is not paid"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /orders/{id}/downloads [get]
+func (h *DownloadHandler) ListOrderDownloads(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID замовлення з url параметру
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	links, err := h.downloadSrv.ListOrderDownloads(r.Context(), userID, orderID)
+	if err != nil {
+		handlerDownloadError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, links)
+}
+
+// Download godoc
+// @Summary Завантаження файлу
+// @Description Віддає файл цифрового товару за підписаним посиланням з /orders/{id}/downloads
+// @Tags orders
+// @Produce octet-stream
+// @Param id path string true "ID права на завантаження"
+// @Param expires query integer true "Термін дії посилання (unix)"
+// @Param signature query string true "Підпис посилання"
+// @Success 200 {file} binary
+// @Failure 400 {object} http.ErrorResponse "Invalid link"
+// @Failure 403 {object} http.ErrorResponse "Invalid signature"
+// @Failure 404 {object} http.ErrorResponse "Download not found"
+// @Failure 410 {object} http.ErrorResponse "Link expired or download limit reached"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /downloads/{id} [get]
+func (h *DownloadHandler) Download(w http.ResponseWriter, r *http.Request) {
+	grantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid download ID")
+		return
+	}
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid expires")
+		return
+	}
+
+	file, err := h.downloadSrv.Download(r.Context(), downloadSrv.DownloadRequest{
+		GrantID:   grantID,
+		Expires:   expires,
+		Signature: r.URL.Query().Get("signature"),
+	})
+	if err != nil {
+		handlerDownloadError(w, err)
+		return
+	}
+	defer file.Body.Close()
+
+	// великі файли передаються довше за стандартний таймаут запису сервера
+	http.NewResponseController(w).SetWriteDeadline(time.Now().Add(downloadRequestTimeout))
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	// посилання особисте, тому файл не кешується
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	// файл зі сховища з підтримкою Seek віддається з Content-Length та Range
+	if body, ok := file.Body.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, file.FileName, time.Time{}, body)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, file.Body); err != nil {
+		log.Printf("download %s interrupted: %v", grantID, err)
+	}
+}
+
+// GetFile godoc
+// @Summary Файл цифрового товару (Admin)
+// @Description Повертає інформацію про файл цифрового товару
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} models.ProductFile
+// @Failure 400 {object} http.ErrorResponse "Invalid product ID"
+// @Failure 404 {object} http.ErrorResponse "File not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/file [get]
+func (h *DownloadHandler) GetFile(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	file, err := h.downloadSrv.GetFile(r.Context(), productID)
+	if err != nil {
+		handlerDownloadError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, file)
+}
+
+// UploadFile godoc
+// @Summary Завантаження файлу цифрового товару (Admin)
+// @Description Зберігає файл цифрового товару (наприклад, електронної книги); попередній файл замінюється
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param file formData file true "Файл"
+// @Success 201 {object} models.ProductFile
+// @Failure 400 {object} http.ErrorResponse "Invalid request or product is not digital"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 413 {object} http.ErrorResponse "File is too large"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/file [post]
+func (h *DownloadHandler) UploadFile(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// великий файл читається довше за стандартний таймаут читання сервера
+	http.NewResponseController(w).SetReadDeadline(time.Now().Add(downloadRequestTimeout))
+
+	// отримання файлу з multipart форми
+	r.Body = http.MaxBytesReader(w, r.Body, maxDownloadRequestBytes)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "File is required")
+		return
+	}
+	defer file.Close()
+
+	productFile, err := h.downloadSrv.UploadFile(r.Context(), productID, downloadSrv.UploadFileRequest{
+		File:     file,
+		FileName: header.Filename,
+	})
+	if err != nil {
+		handlerDownloadError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, productFile)
+}
+
+// handlerDownloadError повертає помилки
+func handlerDownloadError(w http.ResponseWriter, err error) {
+	switch err {
+	case downloadSrv.ErrProductNotFound,
+		downloadSrv.ErrFileNotFound,
+		downloadSrv.ErrOrderNotFound,
+		downloadSrv.ErrDownloadNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case downloadSrv.ErrFileRequired,
+		downloadSrv.ErrFileNameRequired,
+		downloadSrv.ErrProductNotDigital:
+		respondError(w, http.StatusBadRequest, err.Error())
+	case downloadSrv.ErrFileTooLarge:
+		respondError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case downloadSrv.ErrInvalidSignature:
+		respondError(w, http.StatusForbidden, err.Error())
+	case downloadSrv.ErrOrderNotPaid:
+		respondError(w, http.StatusConflict, err.Error())
+	case downloadSrv.ErrLinkExpired,
+		downloadSrv.ErrDownloadLimitReached:
+		respondError(w, http.StatusGone, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
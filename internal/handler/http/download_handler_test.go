@@ -0,0 +1,168 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	downloadService "github.com/Xiancel/ecommerce/internal/service/download"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDownloadService struct {
+	mock.Mock
+}
+
+func (m *MockDownloadService) UploadFile(ctx context.Context, productID uuid.UUID, req downloadService.UploadFileRequest) (*models.ProductFile, error) {
+	args := m.Called(ctx, productID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductFile), args.Error(1)
+}
+func (m *MockDownloadService) GetFile(ctx context.Context, productID uuid.UUID) (*models.ProductFile, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductFile), args.Error(1)
+}
+func (m *MockDownloadService) ListOrderDownloads(ctx context.Context, userID, orderID uuid.UUID) ([]*downloadService.DownloadLink, error) {
+	args := m.Called(ctx, userID, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*downloadService.DownloadLink), args.Error(1)
+}
+func (m *MockDownloadService) Download(ctx context.Context, req downloadService.DownloadRequest) (*downloadService.DownloadFile, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*downloadService.DownloadFile), args.Error(1)
+}
+
+// newDownloadRequest створює запит з ID в маршруті та (опційно) користувачем у контексті
+func newDownloadRequest(target, id string, userID *uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", id)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx)
+	if userID != nil {
+		ctx = context.WithValue(ctx, ContextKeyUserID, *userID)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestListOrderDownloads_Success(t *testing.T) {
+	mockSrv := new(MockDownloadService)
+	handler := NewDownloadHandler(mockSrv)
+	userID, orderID := uuid.New(), uuid.New()
+
+	mockSrv.On("ListOrderDownloads", mock.Anything, userID, orderID).
+		Return([]*downloadService.DownloadLink{{GrantID: uuid.New(), Available: true}}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.ListOrderDownloads(rr, newDownloadRequest("/orders/"+orderID.String()+"/downloads", orderID.String(), &userID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestListOrderDownloads_NotPaid(t *testing.T) {
+	mockSrv := new(MockDownloadService)
+	handler := NewDownloadHandler(mockSrv)
+	userID, orderID := uuid.New(), uuid.New()
+
+	mockSrv.On("ListOrderDownloads", mock.Anything, userID, orderID).Return(nil, downloadService.ErrOrderNotPaid)
+
+	rr := httptest.NewRecorder()
+	handler.ListOrderDownloads(rr, newDownloadRequest("/orders/"+orderID.String()+"/downloads", orderID.String(), &userID))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestDownload_Success(t *testing.T) {
+	mockSrv := new(MockDownloadService)
+	handler := NewDownloadHandler(mockSrv)
+	grantID := uuid.New()
+
+	mockSrv.On("Download", mock.Anything, downloadService.DownloadRequest{GrantID: grantID, Expires: 1700000000, Signature: "abc"}).
+		Return(&downloadService.DownloadFile{
+			FileName:    "Go Book.epub",
+			ContentType: "application/epub+zip",
+			Body:        io.NopCloser(strings.NewReader("ebook")),
+		}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.Download(rr, newDownloadRequest("/downloads/"+grantID.String()+"?expires=1700000000&signature=abc", grantID.String(), nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "ebook", rr.Body.String())
+	assert.Equal(t, "application/epub+zip", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="Go Book.epub"`, rr.Header().Get("Content-Disposition"))
+	mockSrv.AssertExpectations(t)
+}
+
+// seekBody тіло файлу з підтримкою Seek, як у локальному сховищі
+type seekBody struct {
+	*strings.Reader
+}
+
+func (seekBody) Close() error { return nil }
+
+func TestDownload_Range(t *testing.T) {
+	mockSrv := new(MockDownloadService)
+	handler := NewDownloadHandler(mockSrv)
+	grantID := uuid.New()
+
+	mockSrv.On("Download", mock.Anything, downloadService.DownloadRequest{GrantID: grantID, Expires: 1700000000, Signature: "abc"}).
+		Return(&downloadService.DownloadFile{
+			FileName:    "Go Book.epub",
+			ContentType: "application/epub+zip",
+			Body:        seekBody{strings.NewReader("ebook")},
+		}, nil)
+
+	req := newDownloadRequest("/downloads/"+grantID.String()+"?expires=1700000000&signature=abc", grantID.String(), nil)
+	req.Header.Set("Range", "bytes=1-2")
+	rr := httptest.NewRecorder()
+	handler.Download(rr, req)
+
+	assert.Equal(t, http.StatusPartialContent, rr.Code)
+	assert.Equal(t, "bo", rr.Body.String())
+	assert.Equal(t, "2", rr.Header().Get("Content-Length"))
+	assert.Equal(t, "application/epub+zip", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "private, no-store", rr.Header().Get("Cache-Control"))
+}
+
+func TestDownload_InvalidExpires(t *testing.T) {
+	mockSrv := new(MockDownloadService)
+	handler := NewDownloadHandler(mockSrv)
+	grantID := uuid.New()
+
+	rr := httptest.NewRecorder()
+	handler.Download(rr, newDownloadRequest("/downloads/"+grantID.String()+"?signature=abc", grantID.String(), nil))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "Download", mock.Anything, mock.Anything)
+}
+
+func TestDownload_Expired(t *testing.T) {
+	mockSrv := new(MockDownloadService)
+	handler := NewDownloadHandler(mockSrv)
+	grantID := uuid.New()
+
+	mockSrv.On("Download", mock.Anything, mock.Anything).Return(nil, downloadService.ErrLinkExpired)
+
+	rr := httptest.NewRecorder()
+	handler.Download(rr, newDownloadRequest("/downloads/"+grantID.String()+"?expires=1&signature=abc", grantID.String(), nil))
+
+	assert.Equal(t, http.StatusGone, rr.Code)
+}
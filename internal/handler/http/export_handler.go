@@ -0,0 +1,109 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	exportSrv "github.com/Xiancel/ecommerce/internal/service/export"
+	"github.com/go-chi/chi/v5"
+)
+
+// час на вивантаження великого каталогу (перекриває таймаут запису сервера)
+const exportRequestTimeout = 10 * time.Minute
+
+type ExportHandler struct {
+	exportSrv exportSrv.ExportService
+}
+
+func NewExportHandler(exportSrv exportSrv.ExportService) *ExportHandler {
+	return &ExportHandler{exportSrv: exportSrv}
+}
+
+// RegisterRoutes маршрути експорту каталогу (Admin)
+func (h *ExportHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/admin/exports/products.csv", h.ExportProductsCSV)
+	})
+}
+
+// RegisterFeedRoutes публічні товарні фіди
+func (h *ExportHandler) RegisterFeedRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/feeds/google.xml", h.GoogleFeed)
+	})
+}
+
+// ExportProductsCSV godoc
+// @Summary Експорт каталогу в CSV (Admin)
+// @Description Потоково вивантажує продукти (без видалених) у CSV; колонки сумісні з імпортом продуктів
+// @Tags admin
+// @Produce text/csv
+// @Param status query string false "Статус продуктів (draft, published, archived, all); за замовчуванням all"
+// @Success 200 {file} binary
+// @Failure 400 {object} http.ErrorResponse "Invalid status"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/exports/products.csv [get]
+func (h *ExportHandler) ExportProductsCSV(w http.ResponseWriter, r *http.Request) {
+	http.NewResponseController(w).SetWriteDeadline(time.Now().Add(exportRequestTimeout))
+
+	sw := &streamWriter{ResponseWriter: w, header: func(h http.Header) {
+		h.Set("Content-Type", "text/csv; charset=utf-8")
+		h.Set("Content-Disposition", `attachment; filename="products.csv"`)
+	}}
+	if err := h.exportSrv.ExportProductsCSV(r.Context(), sw, r.URL.Query().Get("status")); err != nil {
+		handlerStreamError(sw, err, "product export")
+	}
+}
+
+// GoogleFeed godoc
+// @Summary Фід Google Merchant
+// @Description RSS 2.0 фід опублікованих продуктів у форматі Google Merchant Center
+// @Tags feeds
+// @Produce xml
+// @Success 200 {file} binary
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /feeds/google.xml [get]
+func (h *ExportHandler) GoogleFeed(w http.ResponseWriter, r *http.Request) {
+	http.NewResponseController(w).SetWriteDeadline(time.Now().Add(exportRequestTimeout))
+
+	sw := &streamWriter{ResponseWriter: w, header: func(h http.Header) {
+		h.Set("Content-Type", "application/xml; charset=utf-8")
+		h.Set("Cache-Control", "public, max-age=3600")
+	}}
+	if err := h.exportSrv.WriteGoogleFeed(r.Context(), sw); err != nil {
+		handlerStreamError(sw, err, "google feed")
+	}
+}
+
+// streamWriter відправляє заголовки відповіді лише перед першим записом,
+// щоб помилку до початку потоку можна було повернути як JSON
+type streamWriter struct {
+	http.ResponseWriter
+	header  func(http.Header)
+	started bool
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if !s.started {
+		s.started = true
+		s.header(s.ResponseWriter.Header())
+		s.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+	return s.ResponseWriter.Write(p)
+}
+
+// handlerStreamError повертає помилку, якщо потік ще не почався; інакше лише логує її
+func handlerStreamError(sw *streamWriter, err error, name string) {
+	if sw.started {
+		log.Printf("%s interrupted: %v", name, err)
+		return
+	}
+	switch err {
+	case exportSrv.ErrInvalidStatus:
+		respondError(sw.ResponseWriter, http.StatusBadRequest, err.Error())
+	default:
+		respondError(sw.ResponseWriter, http.StatusInternalServerError, "Internal server error")
+	}
+}
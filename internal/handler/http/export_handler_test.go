@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	exportService "github.com/Xiancel/ecommerce/internal/service/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExportService struct {
+	mock.Mock
+}
+
+func (m *MockExportService) ExportProductsCSV(ctx context.Context, w io.Writer, status string) error {
+	args := m.Called(ctx, w, status)
+	return args.Error(0)
+}
+func (m *MockExportService) WriteGoogleFeed(ctx context.Context, w io.Writer) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func TestExportProductsCSV_Success(t *testing.T) {
+	mockSrv := new(MockExportService)
+	handler := NewExportHandler(mockSrv)
+
+	mockSrv.On("ExportProductsCSV", mock.Anything, mock.Anything, "published").
+		Run(func(args mock.Arguments) {
+			io.WriteString(args.Get(1).(io.Writer), "id,sku,name\n")
+		}).
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/exports/products.csv?status=published", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportProductsCSV(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "id,sku,name\n", rr.Body.String())
+	mockSrv.AssertExpectations(t)
+}
+
+func TestExportProductsCSV_InvalidStatus(t *testing.T) {
+	mockSrv := new(MockExportService)
+	handler := NewExportHandler(mockSrv)
+
+	mockSrv.On("ExportProductsCSV", mock.Anything, mock.Anything, "deleted").Return(exportService.ErrInvalidStatus)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/exports/products.csv?status=deleted", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportProductsCSV(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	mockSrv.AssertExpectations(t)
+}
+
+func TestGoogleFeed_Success(t *testing.T) {
+	mockSrv := new(MockExportService)
+	handler := NewExportHandler(mockSrv)
+
+	mockSrv.On("WriteGoogleFeed", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			io.WriteString(args.Get(1).(io.Writer), "<rss></rss>")
+		}).
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/google.xml", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GoogleFeed(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "<rss></rss>", rr.Body.String())
+	mockSrv.AssertExpectations(t)
+}
+
+func TestGoogleFeed_ErrorAfterStreamStarted(t *testing.T) {
+	mockSrv := new(MockExportService)
+	handler := NewExportHandler(mockSrv)
+
+	mockSrv.On("WriteGoogleFeed", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			io.WriteString(args.Get(1).(io.Writer), "<rss>")
+		}).
+		Return(errors.New("connection reset"))
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/google.xml", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GoogleFeed(rr, req)
+
+	// статус вже відправлено, тіло не доповнюється JSON помилкою
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "<rss>", rr.Body.String())
+	mockSrv.AssertExpectations(t)
+}
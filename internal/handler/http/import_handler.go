@@ -0,0 +1,160 @@
+package http
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	importSrv "github.com/Xiancel/ecommerce/internal/service/importer"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// час на обробку великого файлу імпорту (перекриває таймаути сервера)
+const importRequestTimeout = 10 * time.Minute
+
+type ImportHandler struct {
+	importSrv importSrv.ImportService
+}
+
+func NewImportHandler(importSrv importSrv.ImportService) *ImportHandler {
+	return &ImportHandler{importSrv: importSrv}
+}
+
+func (h *ImportHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Post("/admin/products/import", h.ImportProducts)
+		r.Get("/admin/products/import/{id}/report", h.GetImportReport)
+	})
+}
+
+// ImportProducts godoc
+// @Summary Масовий імпорт продуктів (Admin)
+// @Description Потоково імпортує продукти з CSV або JSON Lines з upsert за SKU. Рядки валідуються так само, як при створенні продукту; помилки потрапляють у звіт.
+// @Tags admin
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Accept multipart/form-data
+// @Produce json
+// @Param format query string false "Формат файлу (csv, jsonl); за замовчуванням визначається з Content-Type або імені файлу"
+// @Param dry_run query boolean false "Тільки перевірити файл, нічого не зберігаючи"
+// @Param file formData file false "Файл імпорту (для multipart/form-data)"
+// @Success 200 {object} importer.ImportResult
+// @Failure 400 {object} http.ErrorResponse "Invalid file or format"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/import [post]
+func (h *ImportHandler) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	// великі файли обробляються довше за стандартні таймаути сервера
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Now().Add(importRequestTimeout))
+	rc.SetWriteDeadline(time.Now().Add(importRequestTimeout))
+
+	// отримання файлу: multipart частина "file" або тіло запиту
+	var body io.Reader = r.Body
+	filename := ""
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid multipart body")
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Import file is required")
+				return
+			}
+			if part.FormName() == "file" {
+				body = part
+				filename = part.FileName()
+				mediaType, _, _ = mime.ParseMediaType(part.Header.Get("Content-Type"))
+				break
+			}
+		}
+	}
+
+	opts := importSrv.ImportOptions{
+		Format: r.URL.Query().Get("format"),
+		DryRun: r.URL.Query().Get("dry_run") == "true",
+	}
+	if opts.Format == "" {
+		opts.Format = detectImportFormat(mediaType, filename)
+	}
+
+	// імпорт
+	result, err := h.importSrv.ImportProducts(r.Context(), body, opts)
+	if err != nil {
+		handlerImportError(w, err)
+		return
+	}
+	result.ReportURL = "/api/v1/admin/products/import/" + result.ID.String() + "/report"
+	respondJSON(w, http.StatusOK, result)
+}
+
+// GetImportReport godoc
+// @Summary Звіт про помилки імпорту (Admin)
+// @Description Повертає CSV з рядками, які не вдалося імпортувати (line, sku, error)
+// @Tags admin
+// @Produce text/csv
+// @Param id path string true "ID імпорту"
+// @Success 200 {file} binary
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Report not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/import/{id}/report [get]
+func (h *ImportHandler) GetImportReport(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "InvalidID")
+		return
+	}
+
+	report, err := h.importSrv.OpenReport(r.Context(), id)
+	if err != nil {
+		handlerImportError(w, err)
+		return
+	}
+	defer report.Close()
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="import-`+id.String()+`-report.csv"`)
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, report)
+}
+
+// detectImportFormat визначає формат файлу з Content-Type або розширення
+func detectImportFormat(mediaType, filename string) string {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".csv":
+		return importSrv.FormatCSV
+	case ".jsonl", ".ndjson":
+		return importSrv.FormatJSONL
+	}
+	switch mediaType {
+	case "text/csv":
+		return importSrv.FormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return importSrv.FormatJSONL
+	}
+	return ""
+}
+
+// handlerImportError повертає помилки
+func handlerImportError(w http.ResponseWriter, err error) {
+	switch err {
+	case importSrv.ErrReportNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case importSrv.ErrUnsupportedFormat,
+		importSrv.ErrInvalidHeader:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	importService "github.com/Xiancel/ecommerce/internal/service/importer"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) ImportProducts(ctx context.Context, r io.Reader, opts importService.ImportOptions) (*importService.ImportResult, error) {
+	args := m.Called(ctx, r, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*importService.ImportResult), args.Error(1)
+}
+func (m *MockImportService) OpenReport(ctx context.Context, id uuid.UUID) (io.ReadCloser, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func TestImportProducts_RawCSVDryRun(t *testing.T) {
+	mockSrv := new(MockImportService)
+	handler := NewImportHandler(mockSrv)
+
+	importID := uuid.New()
+	opts := importService.ImportOptions{Format: importService.FormatCSV, DryRun: true}
+	mockSrv.On("ImportProducts", mock.Anything, mock.Anything, opts).
+		Return(&importService.ImportResult{ID: importID, Format: "csv", DryRun: true, Total: 2, Created: 2}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/import?dry_run=true", strings.NewReader("sku,name,price\nA,B,1\n"))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	rr := httptest.NewRecorder()
+
+	handler.ImportProducts(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp importService.ImportResult
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.Created)
+	assert.Equal(t, "/api/v1/admin/products/import/"+importID.String()+"/report", resp.ReportURL)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestImportProducts_MultipartJSONL(t *testing.T) {
+	mockSrv := new(MockImportService)
+	handler := NewImportHandler(mockSrv)
+
+	opts := importService.ImportOptions{Format: importService.FormatJSONL}
+	mockSrv.On("ImportProducts", mock.Anything, mock.Anything, opts).
+		Return(&importService.ImportResult{ID: uuid.New(), Format: "jsonl"}, nil)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "catalog.jsonl")
+	part.Write([]byte(`{"sku":"A","name":"B","price":1}`))
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/import", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	rr := httptest.NewRecorder()
+
+	handler.ImportProducts(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestImportProducts_UnsupportedFormat(t *testing.T) {
+	mockSrv := new(MockImportService)
+	handler := NewImportHandler(mockSrv)
+
+	mockSrv.On("ImportProducts", mock.Anything, mock.Anything, importService.ImportOptions{}).
+		Return(nil, importService.ErrUnsupportedFormat)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/import", strings.NewReader("data"))
+	rr := httptest.NewRecorder()
+
+	handler.ImportProducts(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestGetImportReport_Success(t *testing.T) {
+	mockSrv := new(MockImportService)
+	handler := NewImportHandler(mockSrv)
+
+	importID := uuid.New()
+	mockSrv.On("OpenReport", mock.Anything, importID).Return(io.NopCloser(strings.NewReader("line,sku,error\n")), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/products/import/"+importID.String()+"/report", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", importID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.GetImportReport(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "line,sku,error\n", rr.Body.String())
+	mockSrv.AssertExpectations(t)
+}
@@ -0,0 +1,540 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	inventorySrv "github.com/Xiancel/ecommerce/internal/service/inventory"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type InventoryHandler struct {
+	inventorySrv inventorySrv.InventoryService
+}
+
+func NewInventoryHandler(inventorySrv inventorySrv.InventoryService) *InventoryHandler {
+	return &InventoryHandler{inventorySrv: inventorySrv}
+}
+
+// RegisterRoutes маршрути складів та залишків (Admin)
+func (h *InventoryHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/admin/stock-locations", h.ListLocations)
+		r.Post("/admin/stock-locations", h.CreateLocation)
+		r.Put("/admin/stock-locations/{id}", h.UpdateLocation)
+		r.Get("/admin/products/{id}/stock", h.GetProductStock)
+		r.Put("/admin/products/{id}/stock/{locationId}", h.SetLocationStock)
+		r.Post("/admin/products/{id}/stock-adjustments", h.AdjustStock)
+		r.Get("/admin/products/{id}/stock-movements", h.ListMovements)
+		r.Get("/admin/products/{id}/stock-reconciliation", h.ReconcileStock)
+		r.Post("/admin/stock-transfers", h.TransferStock)
+		r.Get("/admin/orders/{id}/allocations", h.GetOrderAllocations)
+		r.Get("/admin/products/{id}/reorder-settings", h.GetReorderSettings)
+		r.Put("/admin/products/{id}/reorder-settings", h.UpdateReorderSettings)
+		r.Get("/admin/inventory/alerts", h.ListAlerts)
+		r.Get("/admin/inventory/reorder", h.ListReorderNeeded)
+	})
+}
+
+// ListLocations godoc
+// @Summary Список складів (Admin)
+// @Description Повертає всі склади; склад за замовчуванням першим
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.StockLocation
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/stock-locations [get]
+func (h *InventoryHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.inventorySrv.ListLocations(r.Context())
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, locations)
+}
+
+// CreateLocation godoc
+// @Summary Створення складу (Admin)
+// @Description Створює склад. Країна (ISO код, наприклад UA) використовується для вибору складу, ближчого до адреси доставки
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param location body inventory.CreateLocationRequest true "Дані складу"
+// @Success 201 {object} models.StockLocation
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or validation error"
+// @Failure 409 {object} http.ErrorResponse "Location code already exists"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/stock-locations [post]
+func (h *InventoryHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	// отримання данних з request
+	var req inventorySrv.CreateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	location, err := h.inventorySrv.CreateLocation(r.Context(), req)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, location)
+}
+
+// UpdateLocation godoc
+// @Summary Оновлення складу (Admin)
+// @Description Оновлює назву, країну або активність складу. Товар неактивного складу не враховується в наявності
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID складу"
+// @Param location body inventory.UpdateLocationRequest true "Дані для оновлення"
+// @Success 200 {object} models.StockLocation
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
+// @Failure 404 {object} http.ErrorResponse "Location not found"
+// @Failure 409 {object} http.ErrorResponse "Default location cannot be deactivated"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/stock-locations/{id} [put]
+func (h *InventoryHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid location ID")
+		return
+	}
+
+	// отримання данних з request
+	var req inventorySrv.UpdateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	location, err := h.inventorySrv.UpdateLocation(r.Context(), id, req)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, location)
+}
+
+// GetProductStock godoc
+// @Summary Залишки продукту по складах (Admin)
+// @Description Повертає залишок продукту на кожному складі; total - сума по активних складах
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} inventory.ProductStockResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/stock [get]
+func (h *InventoryHandler) GetProductStock(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	stock, err := h.inventorySrv.GetProductStock(r.Context(), productID)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, stock)
+}
+
+// SetLocationStock godoc
+// @Summary Залишок продукту на складі (Admin)
+// @Description Встановлює залишок продукту на складі після інвентаризації; різниця записується в журнал руху як cycle_count
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param locationId path string true "ID складу"
+// @Param stock body inventory.SetStockRequest true "Кількість"
+// @Success 200 {object} inventory.ProductStockResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
+// @Failure 404 {object} http.ErrorResponse "Product or location not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/stock/{locationId} [put]
+func (h *InventoryHandler) SetLocationStock(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметрів
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	locationID, err := uuid.Parse(chi.URLParam(r, "locationId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid location ID")
+		return
+	}
+
+	// отримання данних з request
+	var req inventorySrv.SetStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	stock, err := h.inventorySrv.SetLocationStock(r.Context(), productID, locationID, req)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, stock)
+}
+
+// AdjustStock godoc
+// @Summary Коригування залишку продукту (Admin)
+// @Description Змінює залишок на складі на задану кількість (від'ємна - списання). Причина: adjustment або return
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param adjustment body inventory.AdjustStockRequest true "Дані коригування"
+// @Success 200 {object} inventory.ProductStockResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or validation error"
+// @Failure 404 {object} http.ErrorResponse "Product or location not found"
+// @Failure 409 {object} http.ErrorResponse "Insufficient stock"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/stock-adjustments [post]
+func (h *InventoryHandler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// отримання данних з request
+	var req inventorySrv.AdjustStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	stock, err := h.inventorySrv.AdjustStock(r.Context(), productID, req)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, stock)
+}
+
+// ListMovements godoc
+// @Summary Журнал руху товару (Admin)
+// @Description Повертає історію змін залишку продукту (нові першими): причина, автор, посилання на замовлення чи переміщення
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param location_id query string false "ID складу"
+// @Param reason query string false "Причина (sale, cancellation, return, adjustment, cycle_count, import, transfer_in, transfer_out, opening_balance)"
+// @Param limit query int false "Кількість записів (за замовчуванням 50, максимум 100)"
+// @Param offset query int false "Зміщення"
+// @Success 200 {object} inventory.MovementListResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid parameters"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/stock-movements [get]
+func (h *InventoryHandler) ListMovements(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := inventorySrv.MovementFilter{Reason: query.Get("reason")}
+
+	if locationStr := query.Get("location_id"); locationStr != "" {
+		locationID, err := uuid.Parse(locationStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid location ID")
+			return
+		}
+		filter.LocationID = &locationID
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		filter.Limit = limit
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid Offset")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	movements, err := h.inventorySrv.ListMovements(r.Context(), productID, filter)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, movements)
+}
+
+// ReconcileStock godoc
+// @Summary Звірка журналу руху із залишками (Admin)
+// @Description Порівнює суму журналу руху з поточним залишком на кожному складі; balanced=false означає розбіжність
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} inventory.ReconciliationResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/stock-reconciliation [get]
+func (h *InventoryHandler) ReconcileStock(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	reconciliation, err := h.inventorySrv.ReconcileStock(r.Context(), productID)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, reconciliation)
+}
+
+// TransferStock godoc
+// @Summary Переміщення товару між складами (Admin)
+// @Description Переміщує кількість товару з одного складу на інший; transfer_id пов'язує обидва записи журналу руху
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param transfer body inventory.TransferStockRequest true "Дані переміщення"
+// @Success 200 {object} inventory.TransferStockResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or validation error"
+// @Failure 404 {object} http.ErrorResponse "Product or location not found"
+// @Failure 409 {object} http.ErrorResponse "Insufficient stock or inactive location"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/stock-transfers [post]
+func (h *InventoryHandler) TransferStock(w http.ResponseWriter, r *http.Request) {
+	// отримання данних з request
+	var req inventorySrv.TransferStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	stock, err := h.inventorySrv.TransferStock(r.Context(), req)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, stock)
+}
+
+// GetOrderAllocations godoc
+// @Summary Склади відвантаження замовлення (Admin)
+// @Description Повертає, з яких складів відвантажується кожна позиція замовлення
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID замовлення"
+// @Success 200 {array} models.StockAllocation
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/orders/{id}/allocations [get]
+func (h *InventoryHandler) GetOrderAllocations(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	allocations, err := h.inventorySrv.GetOrderAllocations(r.Context(), orderID)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, allocations)
+}
+
+// GetReorderSettings godoc
+// @Summary Налаштування дозамовлення продукту (Admin)
+// @Description Повертає поріг низького залишку та кількість для дозамовлення
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} models.ReorderSettings
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/reorder-settings [get]
+func (h *InventoryHandler) GetReorderSettings(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	settings, err := h.inventorySrv.GetReorderSettings(r.Context(), productID)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, settings)
+}
+
+// UpdateReorderSettings godoc
+// @Summary Оновлення налаштувань дозамовлення (Admin)
+// @Description Встановлює поріг низького залишку (null вимикає сповіщення) та кількість для дозамовлення.
+// @Description Коли залишок опускається до порогу або нижче, створюється сповіщення
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param settings body inventory.UpdateReorderSettingsRequest true "Налаштування"
+// @Success 200 {object} models.ReorderSettings
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or validation error"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/reorder-settings [put]
+func (h *InventoryHandler) UpdateReorderSettings(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// отримання данних з request
+	var req inventorySrv.UpdateReorderSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	settings, err := h.inventorySrv.UpdateReorderSettings(r.Context(), productID, req)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, settings)
+}
+
+// ListAlerts godoc
+// @Summary Сповіщення про низький залишок (Admin)
+// @Description Повертає сповіщення про низький залишок, новіші першими. Сповіщення закривається, коли залишок піднімається вище порогу
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "Статус (open, resolved)"
+// @Param limit query int false "Кількість записів (за замовчуванням 50, максимум 100)"
+// @Param offset query int false "Зміщення"
+// @Success 200 {object} inventory.AlertListResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid parameters"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/inventory/alerts [get]
+func (h *InventoryHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := inventorySrv.AlertFilter{Status: query.Get("status")}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		filter.Limit = limit
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid Offset")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	alerts, err := h.inventorySrv.ListAlerts(r.Context(), filter)
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, alerts)
+}
+
+// ListReorderNeeded godoc
+// @Summary Продукти для дозамовлення (Admin)
+// @Description Повертає продукти, залишок яких на рівні порогу або нижче, разом із кількістю для дозамовлення
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.ReorderItem
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/inventory/reorder [get]
+func (h *InventoryHandler) ListReorderNeeded(w http.ResponseWriter, r *http.Request) {
+	items, err := h.inventorySrv.ListReorderNeeded(r.Context())
+	if err != nil {
+		handlerInventoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, items)
+}
+
+// handlerInventoryError повертає помилки
+func handlerInventoryError(w http.ResponseWriter, err error) {
+	switch err {
+	case inventorySrv.ErrLocationNotFound,
+		inventorySrv.ErrProductNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case inventorySrv.ErrInvalidLocationCode,
+		inventorySrv.ErrLocationNameRequired,
+		inventorySrv.ErrInvalidCountry,
+		inventorySrv.ErrInvalidQuantity,
+		inventorySrv.ErrZeroAdjustment,
+		inventorySrv.ErrInvalidReason,
+		inventorySrv.ErrSameLocation,
+		inventorySrv.ErrInvalidThreshold,
+		inventorySrv.ErrInvalidReorderQuantity,
+		inventorySrv.ErrInvalidAlertStatus:
+		respondError(w, http.StatusBadRequest, err.Error())
+	case inventorySrv.ErrLocationCodeExists,
+		inventorySrv.ErrDefaultLocationInactive,
+		inventorySrv.ErrLocationInactive,
+		inventorySrv.ErrInsufficientStock,
+		inventorySrv.ErrBundleStock:
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
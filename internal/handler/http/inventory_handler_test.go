@@ -0,0 +1,387 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	inventoryService "github.com/Xiancel/ecommerce/internal/service/inventory"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInventoryService struct {
+	mock.Mock
+}
+
+func (m *MockInventoryService) ListLocations(ctx context.Context) ([]*models.StockLocation, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryService) CreateLocation(ctx context.Context, req inventoryService.CreateLocationRequest) (*models.StockLocation, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryService) UpdateLocation(ctx context.Context, id uuid.UUID, req inventoryService.UpdateLocationRequest) (*models.StockLocation, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryService) GetProductStock(ctx context.Context, productID uuid.UUID) (*inventoryService.ProductStockResponse, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventoryService.ProductStockResponse), args.Error(1)
+}
+func (m *MockInventoryService) SetLocationStock(ctx context.Context, productID, locationID uuid.UUID, req inventoryService.SetStockRequest) (*inventoryService.ProductStockResponse, error) {
+	args := m.Called(ctx, productID, locationID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventoryService.ProductStockResponse), args.Error(1)
+}
+func (m *MockInventoryService) AdjustStock(ctx context.Context, productID uuid.UUID, req inventoryService.AdjustStockRequest) (*inventoryService.ProductStockResponse, error) {
+	args := m.Called(ctx, productID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventoryService.ProductStockResponse), args.Error(1)
+}
+func (m *MockInventoryService) TransferStock(ctx context.Context, req inventoryService.TransferStockRequest) (*inventoryService.TransferStockResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventoryService.TransferStockResponse), args.Error(1)
+}
+func (m *MockInventoryService) GetOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*models.StockAllocation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockAllocation), args.Error(1)
+}
+func (m *MockInventoryService) ListMovements(ctx context.Context, productID uuid.UUID, filter inventoryService.MovementFilter) (*inventoryService.MovementListResponse, error) {
+	args := m.Called(ctx, productID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventoryService.MovementListResponse), args.Error(1)
+}
+func (m *MockInventoryService) ReconcileStock(ctx context.Context, productID uuid.UUID) (*inventoryService.ReconciliationResponse, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventoryService.ReconciliationResponse), args.Error(1)
+}
+func (m *MockInventoryService) GetReorderSettings(ctx context.Context, productID uuid.UUID) (*models.ReorderSettings, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReorderSettings), args.Error(1)
+}
+func (m *MockInventoryService) UpdateReorderSettings(ctx context.Context, productID uuid.UUID, req inventoryService.UpdateReorderSettingsRequest) (*models.ReorderSettings, error) {
+	args := m.Called(ctx, productID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReorderSettings), args.Error(1)
+}
+func (m *MockInventoryService) ListAlerts(ctx context.Context, filter inventoryService.AlertFilter) (*inventoryService.AlertListResponse, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventoryService.AlertListResponse), args.Error(1)
+}
+func (m *MockInventoryService) ListReorderNeeded(ctx context.Context) ([]*models.ReorderItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ReorderItem), args.Error(1)
+}
+func (m *MockInventoryService) DeliverAlerts(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockInventoryService) SendReorderDigest(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+// withInventoryURLParams додає chi параметри маршруту до запиту
+func withInventoryURLParams(req *http.Request, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestCreateLocation_Success(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	reqBody := inventoryService.CreateLocationRequest{Code: "warsaw", Name: "Warsaw", Country: "PL"}
+	mockSrv.On("CreateLocation", mock.Anything, reqBody).Return(&models.StockLocation{ID: uuid.New(), Code: "warsaw"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/stock-locations", strings.NewReader(`{"code":"warsaw","name":"Warsaw","country":"PL"}`))
+	rr := httptest.NewRecorder()
+
+	handler.CreateLocation(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestCreateLocation_CodeExists(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	mockSrv.On("CreateLocation", mock.Anything, mock.Anything).Return(nil, inventoryService.ErrLocationCodeExists)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/stock-locations", strings.NewReader(`{"code":"main","name":"Main"}`))
+	rr := httptest.NewRecorder()
+
+	handler.CreateLocation(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestSetLocationStock_InvalidLocationID(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID := uuid.New()
+	req := httptest.NewRequest(http.MethodPut, "/admin/products/"+productID.String()+"/stock/abc", strings.NewReader(`{"quantity":5}`))
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String(), "locationId": "abc"})
+	rr := httptest.NewRecorder()
+
+	handler.SetLocationStock(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "SetLocationStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetLocationStock_Success(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID, locationID := uuid.New(), uuid.New()
+	mockSrv.On("SetLocationStock", mock.Anything, productID, locationID, inventoryService.SetStockRequest{Quantity: 5}).
+		Return(&inventoryService.ProductStockResponse{ProductID: productID, Total: 5}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/products/"+productID.String()+"/stock/"+locationID.String(), strings.NewReader(`{"quantity":5}`))
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String(), "locationId": locationID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.SetLocationStock(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestTransferStock_Insufficient(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	mockSrv.On("TransferStock", mock.Anything, mock.Anything).Return(nil, inventoryService.ErrInsufficientStock)
+
+	body := `{"product_id":"` + uuid.NewString() + `","from_location_id":"` + uuid.NewString() + `","to_location_id":"` + uuid.NewString() + `","quantity":10}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/stock-transfers", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.TransferStock(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestGetProductStock_NotFound(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID := uuid.New()
+	mockSrv.On("GetProductStock", mock.Anything, productID).Return(nil, inventoryService.ErrProductNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/products/"+productID.String()+"/stock", nil)
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.GetProductStock(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestAdjustStock_Success(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID, locationID := uuid.New(), uuid.New()
+	mockSrv.On("AdjustStock", mock.Anything, productID, inventoryService.AdjustStockRequest{LocationID: locationID, Quantity: -2, Reason: "adjustment"}).
+		Return(&inventoryService.ProductStockResponse{ProductID: productID, Total: 3}, nil)
+
+	body := `{"location_id":"` + locationID.String() + `","quantity":-2,"reason":"adjustment"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/"+productID.String()+"/stock-adjustments", strings.NewReader(body))
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.AdjustStock(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestAdjustStock_InvalidReason(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID := uuid.New()
+	mockSrv.On("AdjustStock", mock.Anything, productID, mock.Anything).Return(nil, inventoryService.ErrInvalidReason)
+
+	body := `{"location_id":"` + uuid.NewString() + `","quantity":1,"reason":"sale"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/"+productID.String()+"/stock-adjustments", strings.NewReader(body))
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.AdjustStock(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestListMovements_ParsesFilter(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID, locationID := uuid.New(), uuid.New()
+	mockSrv.On("ListMovements", mock.Anything, productID, inventoryService.MovementFilter{
+		LocationID: &locationID,
+		Reason:     "sale",
+		Limit:      10,
+		Offset:     20,
+	}).Return(&inventoryService.MovementListResponse{Movements: []*models.StockMovement{}, Limit: 10, Offset: 20}, nil)
+
+	url := "/admin/products/" + productID.String() + "/stock-movements?location_id=" + locationID.String() + "&reason=sale&limit=10&offset=20"
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.ListMovements(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestListMovements_InvalidLocation(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/admin/products/"+productID.String()+"/stock-movements?location_id=abc", nil)
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.ListMovements(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "ListMovements", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReconcileStock_Success(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID := uuid.New()
+	mockSrv.On("ReconcileStock", mock.Anything, productID).
+		Return(&inventoryService.ReconciliationResponse{ProductID: productID, Balanced: true}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/products/"+productID.String()+"/stock-reconciliation", nil)
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.ReconcileStock(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"balanced":true`)
+}
+
+func TestUpdateReorderSettings_Success(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID := uuid.New()
+	threshold := 5
+	mockSrv.On("UpdateReorderSettings", mock.Anything, productID, inventoryService.UpdateReorderSettingsRequest{LowStockThreshold: &threshold, ReorderQuantity: 20}).
+		Return(&models.ReorderSettings{ProductID: productID, LowStockThreshold: &threshold, ReorderQuantity: 20}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/products/"+productID.String()+"/reorder-settings", strings.NewReader(`{"low_stock_threshold":5,"reorder_quantity":20}`))
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateReorderSettings(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestUpdateReorderSettings_InvalidThreshold(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	productID := uuid.New()
+	mockSrv.On("UpdateReorderSettings", mock.Anything, productID, mock.Anything).Return(nil, inventoryService.ErrInvalidThreshold)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/products/"+productID.String()+"/reorder-settings", strings.NewReader(`{"low_stock_threshold":-1}`))
+	req = withInventoryURLParams(req, map[string]string{"id": productID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateReorderSettings(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestListAlerts_ParsesFilter(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	mockSrv.On("ListAlerts", mock.Anything, inventoryService.AlertFilter{Status: "open", Limit: 10}).
+		Return(&inventoryService.AlertListResponse{Alerts: []*models.LowStockAlert{}, Limit: 10}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/inventory/alerts?status=open&limit=10", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListAlerts(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestListAlerts_InvalidLimit(t *testing.T) {
+	mockSrv := new(MockInventoryService)
+	handler := NewInventoryHandler(mockSrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/inventory/alerts?limit=abc", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListAlerts(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "ListAlerts", mock.Anything, mock.Anything)
+}
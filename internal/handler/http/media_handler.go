@@ -0,0 +1,254 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	mediaSrv "github.com/Xiancel/ecommerce/internal/service/media"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// максимальний розмір multipart запиту із зображенням
+const maxImageRequestBytes = 32 << 20
+
+type MediaHandler struct {
+	mediaSrv mediaSrv.MediaService
+}
+
+func NewMediaHandler(mediaSrv mediaSrv.MediaService) *MediaHandler {
+	return &MediaHandler{mediaSrv: mediaSrv}
+}
+
+// RegisterRoutes публічні маршрути зображень
+func (h *MediaHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/products/{id}/images", h.ListImages)
+		r.Get("/images/*", h.ServeImage)
+	})
+}
+
+// RegisterAdminRoutes маршрути керування зображеннями (Admin)
+func (h *MediaHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Post("/admin/products/{id}/images", h.UploadImage)
+		r.Put("/admin/products/{id}/images/{imageId}", h.UpdateImage)
+		r.Delete("/admin/products/{id}/images/{imageId}", h.DeleteImage)
+	})
+}
+
+// ListImages godoc
+// @Summary Зображення продукту
+// @Description Повертає зображення продукту з URL усіх варіантів (оригінал, large, medium, thumbnail)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {array} models.ProductImage
+// @Failure 400 {object} http.ErrorResponse "Invalid product ID"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /products/{id}/images [get]
+func (h *MediaHandler) ListImages(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	images, err := h.mediaSrv.ListImages(r.Context(), productID)
+	if err != nil {
+		handlerMediaError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, images)
+}
+
+// ServeImage godoc
+// @Summary Файл зображення
+// @Description Віддає збережений файл зображення за ключем
+// @Tags products
+// @Produce image/jpeg
+// @Produce image/png
+// @Produce image/gif
+// @Produce image/webp
+// @Param key path string true "Ключ файлу"
+// @Success 200 {file} binary
+// @Failure 404 {object} http.ErrorResponse "Image not found"
+// @Router /images/{key} [get]
+func (h *MediaHandler) ServeImage(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "*")
+
+	file, err := h.mediaSrv.OpenFile(r.Context(), key)
+	if err != nil {
+		handlerMediaError(w, err)
+		return
+	}
+	defer file.Close()
+
+	// ключі незмінні (містять ID зображення), тому файл можна кешувати надовго
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, file)
+}
+
+// UploadImage godoc
+// @Summary Завантаження зображення продукту (Admin)
+// @Description Завантажує зображення (JPEG, PNG, GIF) та генерує зменшені варіанти у форматах JPEG та WebP
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param file formData file true "Файл зображення"
+// @Param alt_text formData string false "Alt текст"
+// @Param sort_order formData integer false "Порядок сортування"
+// @Success 201 {object} models.ProductImage
+// @Failure 400 {object} http.ErrorResponse "Invalid request or unsupported image"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 413 {object} http.ErrorResponse "Image is too large"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/images [post]
+func (h *MediaHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// отримання файлу з multipart форми
+	r.Body = http.MaxBytesReader(w, r.Body, maxImageRequestBytes)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Image file is required")
+		return
+	}
+	defer file.Close()
+
+	req := mediaSrv.UploadImageRequest{
+		File:    file,
+		AltText: r.FormValue("alt_text"),
+	}
+	if sortStr := r.FormValue("sort_order"); sortStr != "" {
+		sortOrder, err := strconv.Atoi(sortStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid sort order")
+			return
+		}
+		req.SortOrder = &sortOrder
+	}
+
+	// завантаження зображення
+	image, err := h.mediaSrv.UploadImage(r.Context(), productID, req)
+	if err != nil {
+		handlerMediaError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, image)
+}
+
+// UpdateImage godoc
+// @Summary Оновлення зображення продукту (Admin)
+// @Description Оновлює alt текст та порядок сортування зображення
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param imageId path string true "ID зображення"
+// @Param image body media.UpdateImageRequest true "Нові дані"
+// @Success 200 {object} models.ProductImage
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
+// @Failure 404 {object} http.ErrorResponse "Image not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/images/{imageId} [put]
+func (h *MediaHandler) UpdateImage(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметрів
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	imageID, err := uuid.Parse(chi.URLParam(r, "imageId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid image ID")
+		return
+	}
+
+	// отримання данних з request
+	var req mediaSrv.UpdateImageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	image, err := h.mediaSrv.UpdateImage(r.Context(), productID, imageID, req)
+	if err != nil {
+		handlerMediaError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, image)
+}
+
+// DeleteImage godoc
+// @Summary Видалення зображення продукту (Admin)
+// @Description Видаляє зображення та всі його варіанти
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param imageId path string true "ID зображення"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Image not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/images/{imageId} [delete]
+func (h *MediaHandler) DeleteImage(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметрів
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	imageID, err := uuid.Parse(chi.URLParam(r, "imageId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid image ID")
+		return
+	}
+
+	if err := h.mediaSrv.DeleteImage(r.Context(), productID, imageID); err != nil {
+		handlerMediaError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "image deleted",
+	})
+}
+
+// handlerMediaError повертає помилки
+func handlerMediaError(w http.ResponseWriter, err error) {
+	switch err {
+	case mediaSrv.ErrProductNotFound,
+		mediaSrv.ErrImageNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case mediaSrv.ErrImageTooLarge:
+		respondError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case mediaSrv.ErrFileRequired,
+		mediaSrv.ErrUnsupportedFormat,
+		mediaSrv.ErrAltTextTooLong,
+		mediaSrv.ErrInvalidSortOrder,
+		mediaSrv.ErrNoFieldsToUpdate:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	mediaService "github.com/Xiancel/ecommerce/internal/service/media"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMediaService struct {
+	mock.Mock
+}
+
+func (m *MockMediaService) UploadImage(ctx context.Context, productID uuid.UUID, req mediaService.UploadImageRequest) (*models.ProductImage, error) {
+	args := m.Called(ctx, productID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductImage), args.Error(1)
+}
+func (m *MockMediaService) ListImages(ctx context.Context, productID uuid.UUID) ([]*models.ProductImage, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductImage), args.Error(1)
+}
+func (m *MockMediaService) UpdateImage(ctx context.Context, productID, imageID uuid.UUID, req mediaService.UpdateImageRequest) (*models.ProductImage, error) {
+	args := m.Called(ctx, productID, imageID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductImage), args.Error(1)
+}
+func (m *MockMediaService) DeleteImage(ctx context.Context, productID, imageID uuid.UUID) error {
+	args := m.Called(ctx, productID, imageID)
+	return args.Error(0)
+}
+func (m *MockMediaService) OpenFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func TestUploadImage_Success(t *testing.T) {
+	mockSrv := new(MockMediaService)
+	handler := NewMediaHandler(mockSrv)
+
+	productID := uuid.New()
+	expected := &models.ProductImage{ID: uuid.New(), ProductID: productID, SortOrder: 2}
+
+	mockSrv.On("UploadImage", mock.Anything, productID, mock.MatchedBy(func(req mediaService.UploadImageRequest) bool {
+		return req.AltText == "Front" && req.SortOrder != nil && *req.SortOrder == 2 && req.File != nil
+	})).Return(expected, nil)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "photo.png")
+	part.Write([]byte("fake image"))
+	form.WriteField("alt_text", "Front")
+	form.WriteField("sort_order", "2")
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/"+productID.String()+"/images", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.UploadImage(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var resp models.ProductImage
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.ID, resp.ID)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestUploadImage_MissingFile(t *testing.T) {
+	mockSrv := new(MockMediaService)
+	handler := NewMediaHandler(mockSrv)
+
+	productID := uuid.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/"+productID.String()+"/images", strings.NewReader(""))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.UploadImage(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "UploadImage")
+}
+
+func TestUploadImage_TooLarge(t *testing.T) {
+	mockSrv := new(MockMediaService)
+	handler := NewMediaHandler(mockSrv)
+
+	productID := uuid.New()
+	mockSrv.On("UploadImage", mock.Anything, productID, mock.Anything).Return(nil, mediaService.ErrImageTooLarge)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "photo.png")
+	part.Write([]byte("fake image"))
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/"+productID.String()+"/images", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.UploadImage(rr, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestDeleteImage_NotFound(t *testing.T) {
+	mockSrv := new(MockMediaService)
+	handler := NewMediaHandler(mockSrv)
+
+	productID, imageID := uuid.New(), uuid.New()
+	mockSrv.On("DeleteImage", mock.Anything, productID, imageID).Return(mediaService.ErrImageNotFound)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/products/"+productID.String()+"/images/"+imageID.String(), nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", productID.String())
+	rctx.URLParams.Add("imageId", imageID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.DeleteImage(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestServeImage_Success(t *testing.T) {
+	mockSrv := new(MockMediaService)
+	handler := NewMediaHandler(mockSrv)
+
+	key := "products/a/b/medium.jpg"
+	mockSrv.On("OpenFile", mock.Anything, key).Return(io.NopCloser(strings.NewReader("jpeg-bytes")), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/images/"+key, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("*", key)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.ServeImage(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "image/jpeg", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "jpeg-bytes", rr.Body.String())
+	mockSrv.AssertExpectations(t)
+}
@@ -0,0 +1,369 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	merchSrv "github.com/Xiancel/ecommerce/internal/service/merchandising"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type MerchandisingHandler struct {
+	merchSrv   merchSrv.MerchandisingService
+	productSrv productSrv.ProductService
+}
+
+func NewMerchandisingHandler(merchSrv merchSrv.MerchandisingService, productSrv productSrv.ProductService) *MerchandisingHandler {
+	return &MerchandisingHandler{merchSrv: merchSrv,
+		productSrv: productSrv}
+}
+
+func (h *MerchandisingHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		//Synonyms
+		r.Post("/admin/search/synonyms", h.CreateSynonymSet)
+		r.Get("/admin/search/synonyms", h.ListSynonymSets)
+		r.Delete("/admin/search/synonyms/{id}", h.DeleteSynonymSet)
+
+		//Boosts
+		r.Put("/admin/search/boosts/{productId}", h.SetBoost)
+		r.Get("/admin/search/boosts", h.ListBoosts)
+		r.Delete("/admin/search/boosts/{productId}", h.DeleteBoost)
+
+		//Pins
+		r.Post("/admin/search/pins", h.CreatePin)
+		r.Get("/admin/search/pins", h.ListPins)
+		r.Delete("/admin/search/pins/{id}", h.DeletePin)
+
+		//Debug
+		r.Get("/admin/search/explain", h.ExplainSearch)
+	})
+}
+
+// CreateSynonymSet godoc
+// @Summary Створення набору синонімів (Admin)
+// @Description Створює набір взаємозамінних пошукових термінів
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param synonyms body merchandising.CreateSynonymSetRequest true "Терміни"
+// @Success 201 {object} models.SynonymSet
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or validation error"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/synonyms [post]
+func (h *MerchandisingHandler) CreateSynonymSet(w http.ResponseWriter, r *http.Request) {
+	// отримання данних з request
+	var req merchSrv.CreateSynonymSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// створення набору синонімів
+	set, err := h.merchSrv.CreateSynonymSet(r.Context(), req)
+	if err != nil {
+		handlerMerchandisingError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, set)
+}
+
+// ListSynonymSets godoc
+// @Summary Список наборів синонімів (Admin)
+// @Description Повертає всі набори синонімів
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.SynonymSet
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/synonyms [get]
+func (h *MerchandisingHandler) ListSynonymSets(w http.ResponseWriter, r *http.Request) {
+	sets, err := h.merchSrv.ListSynonymSets(r.Context())
+	if err != nil {
+		handlerMerchandisingError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, sets)
+}
+
+// DeleteSynonymSet godoc
+// @Summary Видалення набору синонімів (Admin)
+// @Description Видаляє набір синонімів
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID набору"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Synonym set not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/synonyms/{id} [delete]
+func (h *MerchandisingHandler) DeleteSynonymSet(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "InvalidID")
+		return
+	}
+
+	if err := h.merchSrv.DeleteSynonymSet(r.Context(), id); err != nil {
+		handlerMerchandisingError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "synonym set deleted",
+	})
+}
+
+// SetBoost godoc
+// @Summary Встановлення підсилення продукту (Admin)
+// @Description Встановлює коефіцієнт, на який множиться релевантність продукту в пошуку
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param productId path string true "ID продукту"
+// @Param boost body merchandising.SetBoostRequest true "Коефіцієнт"
+// @Success 200 {object} models.ProductBoost
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/boosts/{productId} [put]
+func (h *MerchandisingHandler) SetBoost(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "productId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// отримання данних з request
+	var req merchSrv.SetBoostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// встановлення підсилення
+	boost, err := h.merchSrv.SetBoost(r.Context(), productID, req)
+	if err != nil {
+		handlerMerchandisingError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, boost)
+}
+
+// ListBoosts godoc
+// @Summary Список підсилень продуктів (Admin)
+// @Description Повертає всі коефіцієнти підсилення
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.ProductBoost
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/boosts [get]
+func (h *MerchandisingHandler) ListBoosts(w http.ResponseWriter, r *http.Request) {
+	boosts, err := h.merchSrv.ListBoosts(r.Context())
+	if err != nil {
+		handlerMerchandisingError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, boosts)
+}
+
+// DeleteBoost godoc
+// @Summary Видалення підсилення продукту (Admin)
+// @Description Видаляє коефіцієнт підсилення продукту
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param productId path string true "ID продукту"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid product ID"
+// @Failure 404 {object} http.ErrorResponse "Boost not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/boosts/{productId} [delete]
+func (h *MerchandisingHandler) DeleteBoost(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "productId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	if err := h.merchSrv.DeleteBoost(r.Context(), productID); err != nil {
+		handlerMerchandisingError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "boost deleted",
+	})
+}
+
+// CreatePin godoc
+// @Summary Закріплення продукту за запитом (Admin)
+// @Description Закріплює продукт на вказаній позиції у результатах пошуку для запиту
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param pin body merchandising.CreatePinRequest true "Дані закріплення"
+// @Success 201 {object} models.SearchPin
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or validation error"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/pins [post]
+func (h *MerchandisingHandler) CreatePin(w http.ResponseWriter, r *http.Request) {
+	// отримання данних з request
+	var req merchSrv.CreatePinRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// створення закріплення
+	pin, err := h.merchSrv.CreatePin(r.Context(), req)
+	if err != nil {
+		handlerMerchandisingError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, pin)
+}
+
+// ListPins godoc
+// @Summary Список закріплень (Admin)
+// @Description Повертає закріплені продукти, опціонально для конкретного запиту
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param query query string false "Пошуковий запит"
+// @Success 200 {array} models.SearchPin
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/pins [get]
+func (h *MerchandisingHandler) ListPins(w http.ResponseWriter, r *http.Request) {
+	pins, err := h.merchSrv.ListPins(r.Context(), r.URL.Query().Get("query"))
+	if err != nil {
+		handlerMerchandisingError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, pins)
+}
+
+// DeletePin godoc
+// @Summary Видалення закріплення (Admin)
+// @Description Видаляє закріплення продукту
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID закріплення"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Pin not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/pins/{id} [delete]
+func (h *MerchandisingHandler) DeletePin(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "InvalidID")
+		return
+	}
+
+	if err := h.merchSrv.DeletePin(r.Context(), id); err != nil {
+		handlerMerchandisingError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "pin deleted",
+	})
+}
+
+// ExplainSearch godoc
+// @Summary Пояснення оцінок пошуку (Admin)
+// @Description Виконує пошук так само, як для покупця, і пояснює оцінку кожного результату
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param q query string true "Пошуковий запит"
+// @Param limit query integer false "Кількість елементів на сторінку" default(20) minimum(1) maximum(100)
+// @Param offset query integer false "Зміщення для пагінації" default(0) minimum(0)
+// @Param cursor query string false "Курсор сторінки (next_cursor або prev_cursor з попередньої відповіді)"
+// @Success 200 {object} product.ProductListResponse
+// @Failure 400 {object} http.ErrorResponse "Search query is required or invalid parameters"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/search/explain [get]
+func (h *MerchandisingHandler) ExplainSearch(w http.ResponseWriter, r *http.Request) {
+	// отримання query
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		respondError(w, http.StatusBadRequest, "Search query is required")
+		return
+	}
+
+	// встановлення фільтрів за замовчуванням
+	filter := productSrv.ProductFilter{
+		Search: query,
+		Limit:  20,
+		Offset: 0,
+		Debug:  true,
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid Offset")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	// курсор сторінки з next_cursor/prev_cursor (має пріоритет над offset)
+	filter.Cursor = r.URL.Query().Get("cursor")
+
+	// пошук з поясненнями
+	response, err := h.productSrv.ListProduct(r.Context(), filter)
+	if err != nil {
+		handlerServiceProductError(w, err)
+		return
+	}
+	setPageLinks(w, r, response.NextCursor, response.PrevCursor)
+	respondJSON(w, http.StatusOK, response)
+}
+
+// handlerMerchandisingError повертає помилки
+func handlerMerchandisingError(w http.ResponseWriter, err error) {
+	switch err {
+	case merchSrv.ErrProductNotFound,
+		merchSrv.ErrSynonymSetNotFound,
+		merchSrv.ErrBoostNotFound,
+		merchSrv.ErrPinNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case merchSrv.ErrNotEnoughTerms,
+		merchSrv.ErrInvalidBoost,
+		merchSrv.ErrQueryRequired,
+		merchSrv.ErrInvalidPosition:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
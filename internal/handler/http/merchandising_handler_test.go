@@ -0,0 +1,184 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	merchService "github.com/Xiancel/ecommerce/internal/service/merchandising"
+	productService "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMerchandisingService struct {
+	mock.Mock
+}
+
+func (m *MockMerchandisingService) CreateSynonymSet(ctx context.Context, req merchService.CreateSynonymSetRequest) (*models.SynonymSet, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SynonymSet), args.Error(1)
+}
+func (m *MockMerchandisingService) ListSynonymSets(ctx context.Context) ([]*models.SynonymSet, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SynonymSet), args.Error(1)
+}
+func (m *MockMerchandisingService) DeleteSynonymSet(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockMerchandisingService) SetBoost(ctx context.Context, productID uuid.UUID, req merchService.SetBoostRequest) (*models.ProductBoost, error) {
+	args := m.Called(ctx, productID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductBoost), args.Error(1)
+}
+func (m *MockMerchandisingService) ListBoosts(ctx context.Context) ([]*models.ProductBoost, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductBoost), args.Error(1)
+}
+func (m *MockMerchandisingService) DeleteBoost(ctx context.Context, productID uuid.UUID) error {
+	args := m.Called(ctx, productID)
+	return args.Error(0)
+}
+func (m *MockMerchandisingService) CreatePin(ctx context.Context, req merchService.CreatePinRequest) (*models.SearchPin, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SearchPin), args.Error(1)
+}
+func (m *MockMerchandisingService) ListPins(ctx context.Context, query string) ([]*models.SearchPin, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SearchPin), args.Error(1)
+}
+func (m *MockMerchandisingService) DeletePin(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestCreateSynonymSet_Success(t *testing.T) {
+	mockSrv := new(MockMerchandisingService)
+	handler := NewMerchandisingHandler(mockSrv, nil)
+
+	reqBody := merchService.CreateSynonymSetRequest{Terms: []string{"tv", "television"}}
+	expected := &models.SynonymSet{ID: uuid.New(), Terms: []string{"tv", "television"}}
+
+	mockSrv.On("CreateSynonymSet", mock.Anything, reqBody).Return(expected, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/admin/search/synonyms", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateSynonymSet(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var resp models.SynonymSet
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.ID, resp.ID)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestCreateSynonymSet_ValidationError(t *testing.T) {
+	mockSrv := new(MockMerchandisingService)
+	handler := NewMerchandisingHandler(mockSrv, nil)
+
+	reqBody := merchService.CreateSynonymSetRequest{Terms: []string{"tv"}}
+	mockSrv.On("CreateSynonymSet", mock.Anything, reqBody).Return(nil, merchService.ErrNotEnoughTerms)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/admin/search/synonyms", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateSynonymSet(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestSetBoost_InvalidProductID(t *testing.T) {
+	mockSrv := new(MockMerchandisingService)
+	handler := NewMerchandisingHandler(mockSrv, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/search/boosts/invalid", bytes.NewReader([]byte(`{"factor":2}`)))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("productId", "invalid")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.SetBoost(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "SetBoost")
+}
+
+func TestDeletePin_NotFound(t *testing.T) {
+	mockSrv := new(MockMerchandisingService)
+	handler := NewMerchandisingHandler(mockSrv, nil)
+
+	pinID := uuid.New()
+	mockSrv.On("DeletePin", mock.Anything, pinID).Return(merchService.ErrPinNotFound)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/search/pins/"+pinID.String(), nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", pinID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.DeletePin(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestExplainSearch_Success(t *testing.T) {
+	mockProductSrv := new(MockProductService)
+	handler := NewMerchandisingHandler(new(MockMerchandisingService), mockProductSrv)
+
+	filter := productService.ProductFilter{
+		Search: "tv",
+		Limit:  20,
+		Debug:  true,
+	}
+	productID := uuid.New()
+	expected := &productService.ProductListResponse{
+		Products:     []*models.Product{{ID: productID, Name: "Television"}},
+		Total:        1,
+		Limit:        20,
+		Explanations: []*models.SearchExplanation{{ProductID: productID, TextScore: 2, Boost: 1, Score: 2}},
+	}
+
+	mockProductSrv.On("ListProduct", mock.Anything, filter).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/search/explain?q=tv", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExplainSearch(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp productService.ProductListResponse
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Explanations, 1)
+	mockProductSrv.AssertExpectations(t)
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strings"
 
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/i18n"
 	authService "github.com/Xiancel/ecommerce/internal/service/auth"
 	"github.com/google/uuid"
 )
@@ -18,13 +20,31 @@ const (
 	ContextKeyUserEmail contextKey = "user_email"
 )
 
+// CartTokenHeader заголовок з токеном кошика гостя
+const CartTokenHeader = "X-Cart-Token"
+
 // RequireAuth перевіряє наявність та валідність JWT токена
 func RequireAuth(authSrv authService.AuthService) func(http.Handler) http.Handler {
+	return authenticate(authSrv, false)
+}
+
+// OptionalAuth пропускає запит без заголовка Authorization як гостьовий;
+// наявний, але невалідний токен відхиляється так само, як у RequireAuth
+func OptionalAuth(authSrv authService.AuthService) func(http.Handler) http.Handler {
+	return authenticate(authSrv, true)
+}
+
+// authenticate валідує JWT токен та додає дані користувача в контекст запиту
+func authenticate(authSrv authService.AuthService, optional bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// отримання токену
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
+				if optional {
+					next.ServeHTTP(w, r)
+					return
+				}
 				respondError(w, http.StatusUnauthorized, "Missing auth header")
 				return
 			}
@@ -53,6 +73,8 @@ func RequireAuth(authSrv authService.AuthService) func(http.Handler) http.Handle
 			ctx = context.WithValue(ctx, ContextKeyUserID, claims.UserID)
 			ctx = context.WithValue(ctx, ContextKeyUserRole, claims.Role)
 			ctx = context.WithValue(ctx, ContextKeyUserEmail, claims.Email)
+			// автор змін для журналів (рух товару тощо)
+			ctx = models.ContextWithActor(ctx, claims.UserID)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -81,7 +103,8 @@ func CORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept,Content-Type,Content-Length,Accept-Encoding,Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept,Accept-Language,Content-Type,Content-Length,Accept-Encoding,Authorization,"+CartTokenHeader)
+		w.Header().Set("Access-Control-Expose-Headers", CartTokenHeader)
 		w.Header().Set("Access-Control-Max-Age", "3600")
 
 		if r.Method == "OPTIONS" {
@@ -93,6 +116,32 @@ func CORS(next http.Handler) http.Handler {
 	})
 }
 
+// Localize визначає мову відповіді з параметра lang або заголовка Accept-Language
+// та зберігає її в контексті запиту
+func Localize(locales i18n.Locales) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := locales.Resolve(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+
+			w.Header().Set("Content-Language", locale)
+			w.Header().Add("Vary", "Accept-Language")
+
+			ctx := models.ContextWithLocale(r.Context(), locale)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// BaseContent вимикає переклади: адмінка редагує вміст мовою за замовчуванням
+func BaseContent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Del("Content-Language")
+
+		ctx := models.ContextWithLocale(r.Context(), "")
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // GetUserIDFromContext повертає ID користувача з контексту
 func GetUserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
 	userID, ok := ctx.Value(ContextKeyUserID).(uuid.UUID)
@@ -61,7 +61,7 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 
 // CreateOrder godoc
 // @Summary Створити замовлення
-// @Description Створює нове замовлення для авторизованого користувача
+// @Description Створює нове замовлення для авторизованого користувача. Кожна позиція відвантажується зі складу країни доставки, а за його відсутності - зі складу з найбільшим залишком
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -69,6 +69,7 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 // @Success 201 {object} models.Order
 // @Failure 400 {object} http.ErrorResponse "Invalid request body"
 // @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 409 {object} http.ErrorResponse "Insufficient stock"
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /orders [post]
@@ -138,6 +139,7 @@ func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
 // @Param status query string false "Фільтр по статусу" Enums(pending, paid, shipped, canceled, delivered)
 // @Param limit query int false "Кількість елементів на сторінку" default(20)
 // @Param offset query int false "Зміщення для пагінації" default(0)
+// @Param cursor query string false "Курсор сторінки (next_cursor або prev_cursor з попередньої відповіді)"
 // @Success 200 {object} order.OrderListResponse
 // @Failure 400 {object} http.ErrorResponse "Invalid parameters"
 // @Failure 401 {object} http.ErrorResponse "User not authorized"
@@ -180,18 +182,23 @@ func (h *OrderHandler) ListOrder(w http.ResponseWriter, r *http.Request) {
 		filter.Offset = offset
 	}
 
+	// курсор сторінки з next_cursor/prev_cursor (має пріоритет над offset)
+	filter.Cursor = r.URL.Query().Get("cursor")
+
 	// вивід списку замовлень користувача
 	orders, err := h.OrderSrv.ListOrder(r.Context(), filter)
 	if err != nil {
 		handlerOrderError(w, err)
 		return
 	}
+	setPageLinks(w, r, orders.NextCursor, orders.PrevCursor)
 	respondJSON(w, http.StatusOK, orders)
 }
 
 func handlerOrderError(w http.ResponseWriter, err error) {
 	switch err {
-	case orderSrv.ErrOrderNotFound:
+	case orderSrv.ErrOrderNotFound,
+		orderSrv.ErrProductNotFound:
 		respondError(w, http.StatusNotFound, err.Error())
 
 	case orderSrv.ErrOrderIDRequired,
@@ -201,11 +208,15 @@ func handlerOrderError(w http.ResponseWriter, err error) {
 		orderSrv.ErrProductIDRequired,
 		orderSrv.ErrStatusRequired,
 		orderSrv.ErrInvalidStatus,
-		orderSrv.ErrOrderEmpty:
+		orderSrv.ErrOrderEmpty,
+		orderSrv.ErrInvalidProductQuantity,
+		orderSrv.ErrInvalidCursor:
 		respondError(w, http.StatusBadRequest, err.Error())
 
 	case orderSrv.ErrOrderAlreadyCanceled,
-		orderSrv.ErrCannotCancelDelivered:
+		orderSrv.ErrCannotCancelDelivered,
+		orderSrv.ErrProductUnavailable,
+		orderSrv.ErrInsufficientStock:
 		respondError(w, http.StatusConflict, err.Error())
 
 	default:
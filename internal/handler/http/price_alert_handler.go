@@ -0,0 +1,188 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	priceAlertSrv "github.com/Xiancel/ecommerce/internal/service/pricealert"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type PriceAlertHandler struct {
+	priceAlertSrv priceAlertSrv.PriceAlertService
+}
+
+func NewPriceAlertHandler(priceAlertSrv priceAlertSrv.PriceAlertService) *PriceAlertHandler {
+	return &PriceAlertHandler{priceAlertSrv: priceAlertSrv}
+}
+
+// RegisterUserRoutes маршрути сповіщень про зниження ціни для авторизованих користувачів
+func (h *PriceAlertHandler) RegisterUserRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/price-alerts", h.ListAlerts)
+		r.Get("/products/{id}/price-alert", h.GetAlert)
+		r.Put("/products/{id}/price-alert", h.SetAlert)
+		r.Delete("/products/{id}/price-alert", h.DeleteAlert)
+	})
+}
+
+// SetAlert godoc
+// @Summary Повідомити про зниження ціни
+// @Description Встановлює цільову ціну продукту; сповіщення приходить, коли ціна з урахуванням розпродажу опуститься до неї. Повторний запит змінює ціль.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param alert body pricealert.SetPriceAlertRequest true "Цільова ціна"
+// @Success 200 {object} models.PriceAlert
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 409 {object} http.ErrorResponse "Price already at or below target"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /products/{id}/price-alert [put]
+func (h *PriceAlertHandler) SetAlert(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// отримання данних з request
+	var req priceAlertSrv.SetPriceAlertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	alert, err := h.priceAlertSrv.SetAlert(r.Context(), userID, productID, req)
+	if err != nil {
+		handlerPriceAlertError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, alert)
+}
+
+// GetAlert godoc
+// @Summary Сповіщення про зниження ціни продукту
+// @Description Повертає активне сповіщення користувача на продукт
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} models.PriceAlert
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Price alert not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /products/{id}/price-alert [get]
+func (h *PriceAlertHandler) GetAlert(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	alert, err := h.priceAlertSrv.GetAlert(r.Context(), userID, productID)
+	if err != nil {
+		handlerPriceAlertError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, alert)
+}
+
+// DeleteAlert godoc
+// @Summary Скасувати сповіщення про зниження ціни
+// @Description Скасовує активне сповіщення користувача на продукт
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Price alert not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /products/{id}/price-alert [delete]
+func (h *PriceAlertHandler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	if err := h.priceAlertSrv.DeleteAlert(r.Context(), userID, productID); err != nil {
+		handlerPriceAlertError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "price alert cancelled",
+	})
+}
+
+// ListAlerts godoc
+// @Summary Сповіщення про зниження ціни користувача
+// @Description Повертає активні сповіщення користувача з поточною ціною продуктів
+// @Tags products
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.PriceAlertWithProduct
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /price-alerts [get]
+func (h *PriceAlertHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+
+	alerts, err := h.priceAlertSrv.ListAlerts(r.Context(), userID)
+	if err != nil {
+		handlerPriceAlertError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, alerts)
+}
+
+// handlerPriceAlertError повертає помилки
+func handlerPriceAlertError(w http.ResponseWriter, err error) {
+	switch err {
+	case priceAlertSrv.ErrInvalidTargetPrice:
+		respondError(w, http.StatusBadRequest, err.Error())
+	case priceAlertSrv.ErrProductNotFound,
+		priceAlertSrv.ErrAlertNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case priceAlertSrv.ErrTargetReached:
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
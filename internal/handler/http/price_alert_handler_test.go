@@ -0,0 +1,129 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	priceAlertService "github.com/Xiancel/ecommerce/internal/service/pricealert"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPriceAlertService struct {
+	mock.Mock
+}
+
+func (m *MockPriceAlertService) SetAlert(ctx context.Context, userID, productID uuid.UUID, req priceAlertService.SetPriceAlertRequest) (*models.PriceAlert, error) {
+	args := m.Called(ctx, userID, productID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PriceAlert), args.Error(1)
+}
+func (m *MockPriceAlertService) GetAlert(ctx context.Context, userID, productID uuid.UUID) (*models.PriceAlert, error) {
+	args := m.Called(ctx, userID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PriceAlert), args.Error(1)
+}
+func (m *MockPriceAlertService) ListAlerts(ctx context.Context, userID uuid.UUID) ([]*models.PriceAlertWithProduct, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PriceAlertWithProduct), args.Error(1)
+}
+func (m *MockPriceAlertService) DeleteAlert(ctx context.Context, userID, productID uuid.UUID) error {
+	args := m.Called(ctx, userID, productID)
+	return args.Error(0)
+}
+func (m *MockPriceAlertService) EvaluateSales(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockPriceAlertService) DeliverNotifications(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+// newPriceAlertRequest створює запит з ID продукту в маршруті та (опційно) користувачем у контексті
+func newPriceAlertRequest(method, productID, body string, userID *uuid.UUID) *http.Request {
+	req := httptest.NewRequest(method, "/products/"+productID+"/price-alert", bytes.NewBufferString(body))
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", productID)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx)
+	if userID != nil {
+		ctx = context.WithValue(ctx, ContextKeyUserID, *userID)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestSetPriceAlert_Success(t *testing.T) {
+	mockSrv := new(MockPriceAlertService)
+	handler := NewPriceAlertHandler(mockSrv)
+	userID, productID := uuid.New(), uuid.New()
+
+	mockSrv.On("SetAlert", mock.Anything, userID, productID, priceAlertService.SetPriceAlertRequest{TargetPrice: 49.99}).
+		Return(&models.PriceAlert{ID: uuid.New(), ProductID: productID, UserID: userID, TargetPrice: 49.99}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.SetAlert(rr, newPriceAlertRequest(http.MethodPut, productID.String(), `{"target_price":49.99}`, &userID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestSetPriceAlert_TargetReached(t *testing.T) {
+	mockSrv := new(MockPriceAlertService)
+	handler := NewPriceAlertHandler(mockSrv)
+	userID, productID := uuid.New(), uuid.New()
+
+	mockSrv.On("SetAlert", mock.Anything, userID, productID, mock.Anything).Return(nil, priceAlertService.ErrTargetReached)
+
+	rr := httptest.NewRecorder()
+	handler.SetAlert(rr, newPriceAlertRequest(http.MethodPut, productID.String(), `{"target_price":100}`, &userID))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestSetPriceAlert_InvalidBody(t *testing.T) {
+	mockSrv := new(MockPriceAlertService)
+	handler := NewPriceAlertHandler(mockSrv)
+	userID, productID := uuid.New(), uuid.New()
+
+	rr := httptest.NewRecorder()
+	handler.SetAlert(rr, newPriceAlertRequest(http.MethodPut, productID.String(), `{`, &userID))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "SetAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeletePriceAlert_NotFound(t *testing.T) {
+	mockSrv := new(MockPriceAlertService)
+	handler := NewPriceAlertHandler(mockSrv)
+	userID, productID := uuid.New(), uuid.New()
+
+	mockSrv.On("DeleteAlert", mock.Anything, userID, productID).Return(priceAlertService.ErrAlertNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.DeleteAlert(rr, newPriceAlertRequest(http.MethodDelete, productID.String(), "", &userID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetPriceAlert_Unauthorized(t *testing.T) {
+	mockSrv := new(MockPriceAlertService)
+	handler := NewPriceAlertHandler(mockSrv)
+
+	rr := httptest.NewRecorder()
+	handler.GetAlert(rr, newPriceAlertRequest(http.MethodGet, uuid.NewString(), "", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
 	"github.com/go-chi/chi/v5"
@@ -21,8 +22,11 @@ func (h *ProductHandler) RegisterRoutes(r chi.Router) {
 	r.Group(func(r chi.Router) {
 		r.Get("/products", h.ListProducts)
 		r.Get("/products/search", h.SearchProduct)
+		r.Get("/products/suggest", h.SuggestProduct)
+		r.Get("/products/by-slug/{slug}", h.GetProductBySlug)
 		r.Get("/products/{id}", h.GetProduct)
 		r.Get("/categories", h.ListCategories)
+		r.Get("/categories/by-slug/{slug}", h.GetCategoryBySlug)
 	})
 }
 
@@ -56,6 +60,37 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, product)
 }
 
+// GetProductBySlug godoc
+// @Summary Отримати продукт за slug
+// @Description Повертає продукт за його slug. Якщо slug старий (продукт перейменовано), повертає 301 редирект на поточний slug
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param slug path string true "Slug продукту"
+// @Success 200 {object} models.Product
+// @Success 301 "Redirect to the current slug"
+// @Failure 404 {object} ErrorResponse "Product not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /products/by-slug/{slug} [get]
+func (h *ProductHandler) GetProductBySlug(w http.ResponseWriter, r *http.Request) {
+	// отримання slug з url параметру
+	slug := chi.URLParam(r, "slug")
+
+	product, err := h.ProductSrv.GetProductBySlug(r.Context(), slug)
+	if err != nil {
+		handlerServiceProductError(w, err)
+		return
+	}
+
+	// старий slug: постійний редирект, щоб посилання та пошукові системи оновились
+	if product.Slug != slug {
+		location := strings.TrimSuffix(r.URL.Path, slug) + product.Slug
+		http.Redirect(w, r, location, http.StatusMovedPermanently)
+		return
+	}
+	respondJSON(w, http.StatusOK, product)
+}
+
 // ListProducts godoc
 // @Summary Отримати список продуктів
 // @Description Повертає список продуктів з можливістю фільтрації за категорією, ціною, наявністю та пагінацією
@@ -66,9 +101,13 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 // @Param min_price query number false "Мінімальна ціна"
 // @Param max_price query number false "Максимальна ціна"
 // @Param search query string false "Пошуковий запит"
-// @Param in_stock query boolean false "Тільки товари в наявності"
+// @Param in_stock query boolean false "Тільки товари, доступні для покупки (є залишок, цифрові або під замовлення)"
+// @Param tag query string false "Тег продукту"
+// @Param min_rating query number false "Мінімальний середній рейтинг (0-5)"
+// @Param order_by query string false "Сортування (price_asc, price_desc, name_asc, name_desc, rating_asc, rating_desc)"
 // @Param limit query integer false "Кількість елементів на сторінку" default(20) minimum(1) maximum(100)
 // @Param offset query integer false "Зміщення для пагінації" default(0) minimum(0)
+// @Param cursor query string false "Курсор сторінки (next_cursor або prev_cursor з попередньої відповіді)"
 // @Success 200 {object} product.ProductListResponse
 // @Failure 400 {object} ErrorResponse "Invalid parameters"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -108,9 +147,25 @@ func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 		filter.MaxPrice = &maxPrice
 	}
 
+	//minRating
+	if minRatingStr := r.URL.Query().Get("min_rating"); minRatingStr != "" {
+		minRating, err := strconv.ParseFloat(minRatingStr, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid min_rating")
+			return
+		}
+		filter.MinRating = &minRating
+	}
+
 	//Search
 	filter.Search = r.URL.Query().Get("search")
 
+	//Tag
+	filter.Tag = r.URL.Query().Get("tag")
+
+	//OrderBy
+	filter.OrderBy = r.URL.Query().Get("order_by")
+
 	//InStock
 	if inStockStr := r.URL.Query().Get("in_stock"); inStockStr != "" {
 		instock := inStockStr == "true"
@@ -137,11 +192,16 @@ func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 		filter.Offset = offset
 	}
 
+	// курсор сторінки з next_cursor/prev_cursor (має пріоритет над offset)
+	filter.Cursor = r.URL.Query().Get("cursor")
+
 	// отримання списку продуктів
 	response, err := h.ProductSrv.ListProduct(r.Context(), filter)
 	if err != nil {
 		handlerServiceProductError(w, err)
+		return
 	}
+	setPageLinks(w, r, response.NextCursor, response.PrevCursor)
 	respondJSON(w, http.StatusOK, response)
 }
 
@@ -151,15 +211,39 @@ func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 // @Tags products
 // @Accept json
 // @Produce json
-// @Success 200 {array} interface{}
+// @Success 200 {array} models.Category
 // @Failure 500 {object} http.ErrorResponse "Internal server error"
 // @Router /categories [get]
 func (h *ProductHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
 	// повернення списку категорії продуктів
-	categories := []interface{}{}
+	categories, err := h.ProductSrv.ListCategories(r.Context())
+	if err != nil {
+		handlerServiceProductError(w, err)
+		return
+	}
 	respondJSON(w, http.StatusOK, categories)
 }
 
+// GetCategoryBySlug godoc
+// @Summary Отримати категорію за slug
+// @Description Повертає категорію за її slug
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param slug path string true "Slug категорії"
+// @Success 200 {object} models.Category
+// @Failure 404 {object} http.ErrorResponse "Category not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /categories/by-slug/{slug} [get]
+func (h *ProductHandler) GetCategoryBySlug(w http.ResponseWriter, r *http.Request) {
+	category, err := h.ProductSrv.GetCategoryBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		handlerServiceProductError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, category)
+}
+
 // SearchProduct godoc
 // @Summary Пошук продуктів
 // @Description Повертає список продуктів, що відповідають пошуковому запиту
@@ -212,17 +296,78 @@ func (h *ProductHandler) SearchProduct(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, products)
 }
 
+// SuggestProduct godoc
+// @Summary Підказки автодоповнення
+// @Description Повертає швидкі префіксні та нечіткі (триграмні) підказки по назвах продуктів і категорій
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param q query string true "Пошуковий запит"
+// @Param limit query integer false "Максимальна кількість підказок" minimum(1)
+// @Success 200 {object} product.SuggestResponse
+// @Failure 400 {object} http.ErrorResponse "Search query is required or invalid parameters"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /products/suggest [get]
+func (h *ProductHandler) SuggestProduct(w http.ResponseWriter, r *http.Request) {
+	// отримання query
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		respondError(w, http.StatusBadRequest, "Search query is required")
+		return
+	}
+
+	// ліміт за замовчуванням визначає сервіс
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = l
+	}
+
+	// отримання підказок
+	resp, err := h.ProductSrv.SuggestProduct(r.Context(), query, limit)
+	if err != nil {
+		handlerServiceProductError(w, err)
+		return
+	}
+	// підказки запитуються на кожне натискання клавіші, тому дозволяємо коротке кешування
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	respondJSON(w, http.StatusOK, resp)
+}
+
 // handlerServiceProductError повертає помилки
 func handlerServiceProductError(w http.ResponseWriter, err error) {
 	switch err {
-	case productSrv.ErrProductNotFound:
+	case productSrv.ErrProductNotFound,
+		productSrv.ErrCategoryNotFound,
+		productSrv.ErrStockLocationNotFound:
 		respondError(w, http.StatusNotFound, err.Error())
 	case productSrv.ErrProductNameRequired,
 		productSrv.ErrInvalidPrice,
 		productSrv.ErrInvalidStock,
-		productSrv.ErrInvalidQuantity:
+		productSrv.ErrInvalidQuantity,
+		productSrv.ErrInvalidStatus,
+		productSrv.ErrInvalidSchedule,
+		productSrv.ErrInvalidSKU,
+		productSrv.ErrInvalidRating,
+		productSrv.ErrInvalidTag,
+		productSrv.ErrInvalidBackorderPolicy,
+		productSrv.ErrInvalidCursor,
+		productSrv.ErrReleaseDateRequired,
+		productSrv.ErrInvalidSalePrice,
+		productSrv.ErrInvalidSaleSchedule,
+		productSrv.ErrInvalidStockReason,
+		productSrv.ErrCategoryNameRequired,
+		productSrv.ErrCategoryNameTooLong:
 		respondError(w, http.StatusBadRequest, err.Error())
-	case productSrv.ErrInsufficientStock:
+	case productSrv.ErrInsufficientStock,
+		productSrv.ErrBundleStock,
+		productSrv.ErrInvalidBundle,
+		productSrv.ErrSKUExists,
+		productSrv.ErrCategoryExists:
 		respondError(w, http.StatusConflict, err.Error())
 	default:
 		respondError(w, http.StatusInternalServerError, "Internal server error")
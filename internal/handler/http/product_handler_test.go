@@ -26,6 +26,34 @@ func (m *MockProductService) CreateProduct(ctx context.Context, req productServi
 	}
 	return args.Get(0).(*models.Product), args.Error(1)
 }
+func (m *MockProductService) GetProductBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductService) ListCategories(ctx context.Context) ([]*models.Category, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Category), args.Error(1)
+}
+func (m *MockProductService) GetCategoryBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+func (m *MockProductService) CreateCategory(ctx context.Context, req productService.CreateCategoryRequest) (*models.Category, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
 func (m *MockProductService) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -47,6 +75,13 @@ func (m *MockProductService) SearchProduct(ctx context.Context, query string, li
 	}
 	return args.Get(0).([]*models.Product), args.Error(1)
 }
+func (m *MockProductService) SuggestProduct(ctx context.Context, query string, limit int) (*productService.SuggestResponse, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*productService.SuggestResponse), args.Error(1)
+}
 func (m *MockProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req productService.UpdateProductRequest) (*models.Product, error) {
 	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
@@ -54,17 +89,17 @@ func (m *MockProductService) UpdateProduct(ctx context.Context, id uuid.UUID, re
 	}
 	return args.Get(0).(*models.Product), args.Error(1)
 }
-func (m *MockProductService) CheckAvailability(ctx context.Context, id uuid.UUID, quantity int) (bool, error) {
-	args := m.Called(ctx, id, quantity)
-	return args.Bool(0), args.Error(1)
-}
-func (m *MockProductService) ReserveStock(ctx context.Context, id uuid.UUID, quantity int) error {
-	args := m.Called(ctx, id, quantity)
+func (m *MockProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
-func (m *MockProductService) ReleaseStock(ctx context.Context, id uuid.UUID, quantity int) error {
+func (m *MockProductService) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+func (m *MockProductService) CheckAvailability(ctx context.Context, id uuid.UUID, quantity int) (bool, error) {
 	args := m.Called(ctx, id, quantity)
-	return args.Error(0)
+	return args.Bool(0), args.Error(1)
 }
 
 // GetProduct
@@ -159,6 +194,51 @@ func TestListProducts_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestListProducts_CursorLinks(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	filter := productService.ProductFilter{
+		OrderBy: "price_asc",
+		Limit:   2,
+		Offset:  40,
+		Cursor:  "abc",
+	}
+	expected := &productService.ProductListResponse{
+		Products:   []*models.Product{{ID: uuid.New(), Name: "PT1", Price: 10}},
+		Total:      5,
+		NextCursor: "next",
+		PrevCursor: "prev",
+	}
+	mockService.On("ListProduct", mock.Anything, filter).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?order_by=price_asc&limit=2&offset=40&cursor=abc", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListProducts(rr, req)
+
+	// посилання зберігають фільтри, а offset замінюється курсором
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `</api/v1/products?cursor=next&limit=2&order_by=price_asc>; rel="next", `+
+		`</api/v1/products?cursor=prev&limit=2&order_by=price_asc>; rel="prev"`, rr.Header().Get("Link"))
+	mockService.AssertExpectations(t)
+}
+
+func TestListProducts_InvalidCursor(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	mockService.On("ListProduct", mock.Anything, mock.Anything).Return(nil, productService.ErrInvalidCursor)
+
+	req := httptest.NewRequest(http.MethodGet, "/products?cursor=broken", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListProducts(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Empty(t, rr.Header().Get("Link"))
+}
+
 func TestListProducts_InvalidMinPrice(t *testing.T) {
 	mockService := new(MockProductService)
 	handler := NewProductHandler(mockService)
@@ -219,10 +299,52 @@ func TestSearchProduct_MissingQuery(t *testing.T) {
 	mockService.AssertNotCalled(t, "SearchProduct")
 }
 
+func TestSuggestProduct_Success(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	expected := &productService.SuggestResponse{
+		Query: "hedphones",
+		Suggestions: []*models.Suggestion{
+			{Type: models.SuggestionTypeProduct, ID: uuid.New(), Text: "Headphones", Score: 0.7},
+		},
+	}
+
+	mockService.On("SuggestProduct", mock.Anything, "hedphones", 5).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/suggest?q=hedphones&limit=5", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SuggestProduct(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp productService.SuggestResponse
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Suggestions, 1)
+	mockService.AssertExpectations(t)
+}
+
+func TestSuggestProduct_MissingQuery(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/suggest", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SuggestProduct(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "SuggestProduct")
+}
+
 func TestListCategories_Success(t *testing.T) {
 	mockService := new(MockProductService)
 	handler := NewProductHandler(mockService)
 
+	mockService.On("ListCategories", mock.Anything).Return([]*models.Category{}, nil)
+
 	req := httptest.NewRequest(http.MethodGet, "/categories", nil)
 	rr := httptest.NewRecorder()
 
@@ -235,3 +357,85 @@ func TestListCategories_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, resp, 0) 
 }
+
+func TestGetProductBySlug_Success(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	product := &models.Product{ID: uuid.New(), Name: "Laptop Pro", Slug: "laptop-pro"}
+	mockService.On("GetProductBySlug", mock.Anything, "laptop-pro").Return(product, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/by-slug/laptop-pro", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "laptop-pro")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.GetProductBySlug(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp models.Product
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, product.ID, resp.ID)
+}
+
+func TestGetProductBySlug_OldSlugRedirects(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	product := &models.Product{ID: uuid.New(), Name: "Laptop Pro 2", Slug: "laptop-pro-2"}
+	mockService.On("GetProductBySlug", mock.Anything, "laptop-pro").Return(product, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/by-slug/laptop-pro", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "laptop-pro")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.GetProductBySlug(rr, req)
+
+	assert.Equal(t, http.StatusMovedPermanently, rr.Code)
+	assert.Equal(t, "/api/v1/products/by-slug/laptop-pro-2", rr.Header().Get("Location"))
+}
+
+func TestGetProductBySlug_NotFound(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	mockService.On("GetProductBySlug", mock.Anything, "missing").Return(nil, productService.ErrProductNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/by-slug/missing", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "missing")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.GetProductBySlug(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetCategoryBySlug_Success(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	category := &models.Category{ID: uuid.New(), Name: "Books", Slug: "books"}
+	mockService.On("GetCategoryBySlug", mock.Anything, "books").Return(category, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/categories/by-slug/books", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "books")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.GetCategoryBySlug(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp models.Category
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "books", resp.Slug)
+}
@@ -0,0 +1,390 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	questionSrv "github.com/Xiancel/ecommerce/internal/service/question"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type QuestionHandler struct {
+	questionSrv questionSrv.QuestionService
+}
+
+func NewQuestionHandler(questionSrv questionSrv.QuestionService) *QuestionHandler {
+	return &QuestionHandler{questionSrv: questionSrv}
+}
+
+// RegisterRoutes публічні маршрути питань
+func (h *QuestionHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/products/{id}/questions", h.ListProductQuestions)
+	})
+}
+
+// RegisterUserRoutes маршрути питань для авторизованих користувачів
+func (h *QuestionHandler) RegisterUserRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Post("/products/{id}/questions", h.AskQuestion)
+		r.Post("/questions/{id}/answers", h.AnswerQuestion)
+		r.Post("/answers/{id}/votes", h.VoteAnswer)
+	})
+}
+
+// RegisterAdminRoutes маршрути модерації питань та відповідей (Admin)
+func (h *QuestionHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/admin/questions", h.ListQuestions)
+		r.Put("/admin/questions/{id}/status", h.ModerateQuestion)
+		r.Get("/admin/answers", h.ListAnswers)
+		r.Put("/admin/answers/{id}/status", h.ModerateAnswer)
+	})
+}
+
+// ListProductQuestions godoc
+// @Summary Питання про продукт
+// @Description Повертає схвалені питання продукту зі схваленими відповідями (відповіді адміністратора та найкорисніші першими)
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param limit query integer false "Кількість елементів на сторінку" default(20) minimum(1) maximum(100)
+// @Param offset query integer false "Зміщення для пагінації" default(0) minimum(0)
+// @Success 200 {object} question.QuestionListResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid parameters"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /products/{id}/questions [get]
+func (h *QuestionHandler) ListProductQuestions(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	filter, ok := parseQuestionFilter(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.questionSrv.ListProductQuestions(r.Context(), productID, filter)
+	if err != nil {
+		handlerQuestionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// AskQuestion godoc
+// @Summary Поставити питання про продукт
+// @Description Створює питання, яке з'явиться після модерації
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param question body question.AskQuestionRequest true "Питання"
+// @Success 201 {object} models.ProductQuestion
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /products/{id}/questions [post]
+func (h *QuestionHandler) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// отримання данних з request
+	var req questionSrv.AskQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	question, err := h.questionSrv.AskQuestion(r.Context(), userID, productID, req)
+	if err != nil {
+		handlerQuestionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, question)
+}
+
+// AnswerQuestion godoc
+// @Summary Відповісти на питання
+// @Description Відповідати можуть адміністратори (публікується одразу) та покупці з доставленим замовленням продукту (після модерації). Автор питання отримує сповіщення, коли відповідь опубліковано.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID питання"
+// @Param answer body question.AnswerQuestionRequest true "Відповідь"
+// @Success 201 {object} models.ProductAnswer
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 403 {object} http.ErrorResponse "Not allowed to answer"
+// @Failure 404 {object} http.ErrorResponse "Question not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /questions/{id}/answers [post]
+func (h *QuestionHandler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	// отримання ID та ролі користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	role, _ := GetUserRoleFromContext(r.Context())
+
+	// отримання ID питання з url параметру
+	questionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid question ID")
+		return
+	}
+
+	// отримання данних з request
+	var req questionSrv.AnswerQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	author := questionSrv.Author{UserID: userID, IsAdmin: role == "admin"}
+	answer, err := h.questionSrv.AnswerQuestion(r.Context(), author, questionID, req)
+	if err != nil {
+		handlerQuestionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, answer)
+}
+
+// VoteAnswer godoc
+// @Summary Оцінити корисність відповіді
+// @Description Зберігає голос користувача; повторний голос замінює попередній
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID відповіді"
+// @Param vote body question.VoteAnswerRequest true "Голос"
+// @Success 200 {object} models.ProductAnswer
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or own answer"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Answer not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /answers/{id}/votes [post]
+func (h *QuestionHandler) VoteAnswer(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID відповіді з url параметру
+	answerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid answer ID")
+		return
+	}
+
+	// отримання данних з request
+	var req questionSrv.VoteAnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	answer, err := h.questionSrv.VoteAnswer(r.Context(), userID, answerID, req)
+	if err != nil {
+		handlerQuestionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, answer)
+}
+
+// ListQuestions godoc
+// @Summary Питання для модерації (Admin)
+// @Description Повертає питання за статусом модерації (за замовчуванням pending)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "Статус (pending, approved, rejected)"
+// @Param limit query integer false "Кількість елементів на сторінку" default(20) minimum(1) maximum(100)
+// @Param offset query integer false "Зміщення для пагінації" default(0) minimum(0)
+// @Success 200 {object} question.QuestionListResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid parameters"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/questions [get]
+func (h *QuestionHandler) ListQuestions(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseQuestionFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.Status = r.URL.Query().Get("status")
+
+	response, err := h.questionSrv.ListQuestions(r.Context(), filter)
+	if err != nil {
+		handlerQuestionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// ModerateQuestion godoc
+// @Summary Модерація питання (Admin)
+// @Description Змінює статус питання
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID питання"
+// @Param status body question.ModerateRequest true "Новий статус"
+// @Success 200 {object} models.ProductQuestion
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
+// @Failure 404 {object} http.ErrorResponse "Question not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/questions/{id}/status [put]
+func (h *QuestionHandler) ModerateQuestion(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid question ID")
+		return
+	}
+
+	// отримання данних з request
+	var req questionSrv.ModerateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	question, err := h.questionSrv.ModerateQuestion(r.Context(), id, req)
+	if err != nil {
+		handlerQuestionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, question)
+}
+
+// ListAnswers godoc
+// @Summary Відповіді для модерації (Admin)
+// @Description Повертає відповіді за статусом модерації (за замовчуванням pending)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "Статус (pending, approved, rejected)"
+// @Param limit query integer false "Кількість елементів на сторінку" default(20) minimum(1) maximum(100)
+// @Param offset query integer false "Зміщення для пагінації" default(0) minimum(0)
+// @Success 200 {array} models.ProductAnswer
+// @Failure 400 {object} http.ErrorResponse "Invalid parameters"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/answers [get]
+func (h *QuestionHandler) ListAnswers(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseQuestionFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.Status = r.URL.Query().Get("status")
+
+	answers, err := h.questionSrv.ListAnswers(r.Context(), filter)
+	if err != nil {
+		handlerQuestionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, answers)
+}
+
+// ModerateAnswer godoc
+// @Summary Модерація відповіді (Admin)
+// @Description Змінює статус відповіді; при схваленні автор питання отримує сповіщення
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID відповіді"
+// @Param status body question.ModerateRequest true "Новий статус"
+// @Success 200 {object} models.ProductAnswer
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
+// @Failure 404 {object} http.ErrorResponse "Answer not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/answers/{id}/status [put]
+func (h *QuestionHandler) ModerateAnswer(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid answer ID")
+		return
+	}
+
+	// отримання данних з request
+	var req questionSrv.ModerateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	answer, err := h.questionSrv.ModerateAnswer(r.Context(), id, req)
+	if err != nil {
+		handlerQuestionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, answer)
+}
+
+// parseQuestionFilter читає пагінацію з query параметрів
+func parseQuestionFilter(w http.ResponseWriter, r *http.Request) (questionSrv.QuestionFilter, bool) {
+	filter := questionSrv.QuestionFilter{Limit: 20}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return filter, false
+		}
+		filter.Limit = limit
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid Offset")
+			return filter, false
+		}
+		filter.Offset = offset
+	}
+	return filter, true
+}
+
+// handlerQuestionError повертає помилки
+func handlerQuestionError(w http.ResponseWriter, err error) {
+	switch err {
+	case questionSrv.ErrProductNotFound,
+		questionSrv.ErrQuestionNotFound,
+		questionSrv.ErrAnswerNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case questionSrv.ErrNotAllowedToAnswer:
+		respondError(w, http.StatusForbidden, err.Error())
+	case questionSrv.ErrBodyRequired,
+		questionSrv.ErrBodyTooLong,
+		questionSrv.ErrInvalidStatus,
+		questionSrv.ErrCannotVoteOwnAnswer:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	questionService "github.com/Xiancel/ecommerce/internal/service/question"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockQuestionService struct {
+	mock.Mock
+}
+
+func (m *MockQuestionService) AskQuestion(ctx context.Context, userID, productID uuid.UUID, req questionService.AskQuestionRequest) (*models.ProductQuestion, error) {
+	args := m.Called(ctx, userID, productID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductQuestion), args.Error(1)
+}
+func (m *MockQuestionService) ListProductQuestions(ctx context.Context, productID uuid.UUID, filter questionService.QuestionFilter) (*questionService.QuestionListResponse, error) {
+	args := m.Called(ctx, productID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*questionService.QuestionListResponse), args.Error(1)
+}
+func (m *MockQuestionService) AnswerQuestion(ctx context.Context, author questionService.Author, questionID uuid.UUID, req questionService.AnswerQuestionRequest) (*models.ProductAnswer, error) {
+	args := m.Called(ctx, author, questionID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductAnswer), args.Error(1)
+}
+func (m *MockQuestionService) VoteAnswer(ctx context.Context, userID, answerID uuid.UUID, req questionService.VoteAnswerRequest) (*models.ProductAnswer, error) {
+	args := m.Called(ctx, userID, answerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductAnswer), args.Error(1)
+}
+func (m *MockQuestionService) ListQuestions(ctx context.Context, filter questionService.QuestionFilter) (*questionService.QuestionListResponse, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*questionService.QuestionListResponse), args.Error(1)
+}
+func (m *MockQuestionService) ModerateQuestion(ctx context.Context, id uuid.UUID, req questionService.ModerateRequest) (*models.ProductQuestion, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductQuestion), args.Error(1)
+}
+func (m *MockQuestionService) ListAnswers(ctx context.Context, filter questionService.QuestionFilter) ([]*models.ProductAnswer, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductAnswer), args.Error(1)
+}
+func (m *MockQuestionService) ModerateAnswer(ctx context.Context, id uuid.UUID, req questionService.ModerateRequest) (*models.ProductAnswer, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductAnswer), args.Error(1)
+}
+
+func TestListProductQuestions_Success(t *testing.T) {
+	mockSrv := new(MockQuestionService)
+	handler := NewQuestionHandler(mockSrv)
+
+	productID := uuid.New()
+	mockSrv.On("ListProductQuestions", mock.Anything, productID, questionService.QuestionFilter{Limit: 5, Offset: 10}).
+		Return(&questionService.QuestionListResponse{Questions: []*models.ProductQuestion{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String()+"/questions?limit=5&offset=10", nil)
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+	rr := httptest.NewRecorder()
+
+	handler.ListProductQuestions(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestAnswerQuestion_AdminRole(t *testing.T) {
+	mockSrv := new(MockQuestionService)
+	handler := NewQuestionHandler(mockSrv)
+
+	userID, questionID := uuid.New(), uuid.New()
+	author := questionService.Author{UserID: userID, IsAdmin: true}
+	mockSrv.On("AnswerQuestion", mock.Anything, author, questionID, questionService.AnswerQuestionRequest{Body: "Yes"}).
+		Return(&models.ProductAnswer{ID: uuid.New(), IsAdmin: true, Status: models.QAStatusApproved}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/questions/"+questionID.String()+"/answers", strings.NewReader(`{"body":"Yes"}`))
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", questionID.String())
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx)
+	ctx = context.WithValue(ctx, ContextKeyUserID, userID)
+	ctx = context.WithValue(ctx, ContextKeyUserRole, "admin")
+	req = req.WithContext(ctx)
+	rr := httptest.NewRecorder()
+
+	handler.AnswerQuestion(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestAnswerQuestion_NotAllowed(t *testing.T) {
+	mockSrv := new(MockQuestionService)
+	handler := NewQuestionHandler(mockSrv)
+
+	userID, questionID := uuid.New(), uuid.New()
+	author := questionService.Author{UserID: userID}
+	mockSrv.On("AnswerQuestion", mock.Anything, author, questionID, mock.Anything).
+		Return(nil, questionService.ErrNotAllowedToAnswer)
+
+	req := httptest.NewRequest(http.MethodPost, "/questions/"+questionID.String()+"/answers", strings.NewReader(`{"body":"Maybe"}`))
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", questionID.String())
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx)
+	ctx = context.WithValue(ctx, ContextKeyUserID, userID)
+	ctx = context.WithValue(ctx, ContextKeyUserRole, "user")
+	req = req.WithContext(ctx)
+	rr := httptest.NewRecorder()
+
+	handler.AnswerQuestion(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestVoteAnswer_Unauthorized(t *testing.T) {
+	mockSrv := new(MockQuestionService)
+	handler := NewQuestionHandler(mockSrv)
+
+	req := httptest.NewRequest(http.MethodPost, "/answers/"+uuid.New().String()+"/votes", strings.NewReader(`{"helpful":true}`))
+	rr := httptest.NewRecorder()
+
+	handler.VoteAnswer(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	mockSrv.AssertNotCalled(t, "VoteAnswer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	recommendationSrv "github.com/Xiancel/ecommerce/internal/service/recommendation"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type RecommendationHandler struct {
+	recommendationSrv recommendationSrv.RecommendationService
+}
+
+func NewRecommendationHandler(recommendationSrv recommendationSrv.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{recommendationSrv: recommendationSrv}
+}
+
+// RegisterRoutes публічні маршрути рекомендацій
+func (h *RecommendationHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/products/{id}/recommendations", h.GetProductRecommendations)
+	})
+}
+
+// GetProductRecommendations godoc
+// @Summary Рекомендації до продукту
+// @Description Повертає товари, які купують разом з продуктом (за історією замовлень). Якщо таких мало, список доповнюється бестселерами тієї ж категорії.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param limit query integer false "Максимальна кількість рекомендацій" default(10) minimum(1) maximum(50)
+// @Success 200 {object} recommendation.ProductRecommendationsResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid parameters"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /products/{id}/recommendations [get]
+func (h *RecommendationHandler) GetProductRecommendations(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// ліміт за замовчуванням визначає сервіс
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = l
+	}
+
+	// отримання рекомендацій
+	resp, err := h.recommendationSrv.GetProductRecommendations(r.Context(), productID, limit)
+	if err != nil {
+		handlerRecommendationError(w, err)
+		return
+	}
+	// рекомендації перераховуються періодично, тому дозволяємо коротке кешування
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handlerRecommendationError повертає помилки
+func handlerRecommendationError(w http.ResponseWriter, err error) {
+	switch err {
+	case recommendationSrv.ErrProductNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case recommendationSrv.ErrInvalidLimit:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	recommendationService "github.com/Xiancel/ecommerce/internal/service/recommendation"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecommendationService struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationService) GetProductRecommendations(ctx context.Context, productID uuid.UUID, limit int) (*recommendationService.ProductRecommendationsResponse, error) {
+	args := m.Called(ctx, productID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recommendationService.ProductRecommendationsResponse), args.Error(1)
+}
+func (m *MockRecommendationService) CartRecommendations(ctx context.Context, productIDs []uuid.UUID, limit int) ([]*models.Product, error) {
+	args := m.Called(ctx, productIDs, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+func (m *MockRecommendationService) Recompute(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestGetProductRecommendations_Success(t *testing.T) {
+	mockSrv := new(MockRecommendationService)
+	handler := NewRecommendationHandler(mockSrv)
+
+	productID := uuid.New()
+	mockSrv.On("GetProductRecommendations", mock.Anything, productID, 4).Return(&recommendationService.ProductRecommendationsResponse{
+		ProductID:      productID,
+		BoughtTogether: []*models.Product{{ID: uuid.New(), Name: "Case"}},
+		Related:        []*models.Product{},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String()+"/recommendations?limit=4", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.GetProductRecommendations(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp recommendationService.ProductRecommendationsResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Len(t, resp.BoughtTogether, 1)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestGetProductRecommendations_InvalidLimit(t *testing.T) {
+	mockSrv := new(MockRecommendationService)
+	handler := NewRecommendationHandler(mockSrv)
+
+	productID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String()+"/recommendations?limit=abc", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.GetProductRecommendations(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "GetProductRecommendations", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetProductRecommendations_NotFound(t *testing.T) {
+	mockSrv := new(MockRecommendationService)
+	handler := NewRecommendationHandler(mockSrv)
+
+	productID := uuid.New()
+	mockSrv.On("GetProductRecommendations", mock.Anything, productID, 0).Return(nil, recommendationService.ErrProductNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String()+"/recommendations", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.GetProductRecommendations(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetProductRecommendations_InternalError(t *testing.T) {
+	mockSrv := new(MockRecommendationService)
+	handler := NewRecommendationHandler(mockSrv)
+
+	productID := uuid.New()
+	mockSrv.On("GetProductRecommendations", mock.Anything, productID, 0).Return(nil, errors.New("db error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String()+"/recommendations", nil)
+	rr := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.GetProductRecommendations(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
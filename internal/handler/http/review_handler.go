@@ -0,0 +1,279 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	reviewSrv "github.com/Xiancel/ecommerce/internal/service/review"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type ReviewHandler struct {
+	reviewSrv reviewSrv.ReviewService
+}
+
+func NewReviewHandler(reviewSrv reviewSrv.ReviewService) *ReviewHandler {
+	return &ReviewHandler{reviewSrv: reviewSrv}
+}
+
+// RegisterRoutes публічні маршрути відгуків
+func (h *ReviewHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/products/{id}/reviews", h.ListProductReviews)
+	})
+}
+
+// RegisterUserRoutes маршрути відгуків для авторизованих користувачів
+func (h *ReviewHandler) RegisterUserRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Post("/products/{id}/reviews", h.CreateReview)
+	})
+}
+
+// RegisterAdminRoutes маршрути модерації відгуків (Admin)
+func (h *ReviewHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/admin/reviews", h.ListReviews)
+		r.Put("/admin/reviews/{id}/status", h.ModerateReview)
+		r.Delete("/admin/reviews/{id}", h.DeleteReview)
+	})
+}
+
+// CreateReview godoc
+// @Summary Залишити відгук на продукт
+// @Description Створює відгук з оцінкою 1-5; відгук з'являється після модерації. Покупка перевіряється за доставленими замовленнями.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param review body review.CreateReviewRequest true "Відгук"
+// @Success 201 {object} models.Review
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 409 {object} http.ErrorResponse "Product already reviewed"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /products/{id}/reviews [post]
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// отримання данних з request
+	var req reviewSrv.CreateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	review, err := h.reviewSrv.CreateReview(r.Context(), userID, productID, req)
+	if err != nil {
+		handlerReviewError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, review)
+}
+
+// ListProductReviews godoc
+// @Summary Відгуки продукту
+// @Description Повертає схвалені відгуки продукту разом із середнім рейтингом та кількістю відгуків
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param rating query integer false "Тільки відгуки з цією оцінкою (1-5)"
+// @Param verified query boolean false "Тільки перевірені покупки"
+// @Param order_by query string false "Сортування (newest, oldest, rating_desc, rating_asc)"
+// @Param limit query integer false "Кількість елементів на сторінку" default(20) minimum(1) maximum(100)
+// @Param offset query integer false "Зміщення для пагінації" default(0) minimum(0)
+// @Success 200 {object} review.ReviewListResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid parameters"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /products/{id}/reviews [get]
+func (h *ReviewHandler) ListProductReviews(w http.ResponseWriter, r *http.Request) {
+	// отримання ID продукту з url параметру
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	filter, ok := parseReviewFilter(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.reviewSrv.ListProductReviews(r.Context(), productID, filter)
+	if err != nil {
+		handlerReviewError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// ListReviews godoc
+// @Summary Відгуки для модерації (Admin)
+// @Description Повертає відгуки за статусом модерації (за замовчуванням pending)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "Статус (pending, approved, rejected)"
+// @Param rating query integer false "Тільки відгуки з цією оцінкою (1-5)"
+// @Param verified query boolean false "Тільки перевірені покупки"
+// @Param order_by query string false "Сортування (newest, oldest, rating_desc, rating_asc)"
+// @Param limit query integer false "Кількість елементів на сторінку" default(20) minimum(1) maximum(100)
+// @Param offset query integer false "Зміщення для пагінації" default(0) minimum(0)
+// @Success 200 {object} review.ReviewListResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid parameters"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/reviews [get]
+func (h *ReviewHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseReviewFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.Status = r.URL.Query().Get("status")
+
+	response, err := h.reviewSrv.ListReviews(r.Context(), filter)
+	if err != nil {
+		handlerReviewError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// ModerateReview godoc
+// @Summary Модерація відгуку (Admin)
+// @Description Змінює статус відгуку; рейтинг продукту враховує тільки схвалені відгуки
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID відгуку"
+// @Param status body review.ModerateReviewRequest true "Новий статус"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} http.ErrorResponse "Invalid request body or ID"
+// @Failure 404 {object} http.ErrorResponse "Review not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/reviews/{id}/status [put]
+func (h *ReviewHandler) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid review ID")
+		return
+	}
+
+	// отримання данних з request
+	var req reviewSrv.ModerateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	review, err := h.reviewSrv.ModerateReview(r.Context(), id, req)
+	if err != nil {
+		handlerReviewError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, review)
+}
+
+// DeleteReview godoc
+// @Summary Видалення відгуку (Admin)
+// @Description Видаляє відгук та перераховує рейтинг продукту
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID відгуку"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Review not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/reviews/{id} [delete]
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid review ID")
+		return
+	}
+
+	if err := h.reviewSrv.DeleteReview(r.Context(), id); err != nil {
+		handlerReviewError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "review deleted",
+	})
+}
+
+// parseReviewFilter читає фільтр відгуків з query параметрів
+func parseReviewFilter(w http.ResponseWriter, r *http.Request) (reviewSrv.ReviewFilter, bool) {
+	query := r.URL.Query()
+	filter := reviewSrv.ReviewFilter{
+		Limit:        20,
+		VerifiedOnly: query.Get("verified") == "true",
+		OrderBy:      query.Get("order_by"),
+	}
+
+	if ratingStr := query.Get("rating"); ratingStr != "" {
+		rating, err := strconv.Atoi(ratingStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid rating")
+			return filter, false
+		}
+		filter.Rating = rating
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return filter, false
+		}
+		filter.Limit = limit
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid Offset")
+			return filter, false
+		}
+		filter.Offset = offset
+	}
+	return filter, true
+}
+
+// handlerReviewError повертає помилки
+func handlerReviewError(w http.ResponseWriter, err error) {
+	switch err {
+	case reviewSrv.ErrProductNotFound,
+		reviewSrv.ErrReviewNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case reviewSrv.ErrReviewExists:
+		respondError(w, http.StatusConflict, err.Error())
+	case reviewSrv.ErrInvalidRating,
+		reviewSrv.ErrTitleTooLong,
+		reviewSrv.ErrBodyTooLong,
+		reviewSrv.ErrInvalidStatus,
+		reviewSrv.ErrInvalidOrderBy:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
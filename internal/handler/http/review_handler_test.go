@@ -0,0 +1,158 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	reviewService "github.com/Xiancel/ecommerce/internal/service/review"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewService struct {
+	mock.Mock
+}
+
+func (m *MockReviewService) CreateReview(ctx context.Context, userID, productID uuid.UUID, req reviewService.CreateReviewRequest) (*models.Review, error) {
+	args := m.Called(ctx, userID, productID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Review), args.Error(1)
+}
+func (m *MockReviewService) ListProductReviews(ctx context.Context, productID uuid.UUID, filter reviewService.ReviewFilter) (*reviewService.ReviewListResponse, error) {
+	args := m.Called(ctx, productID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*reviewService.ReviewListResponse), args.Error(1)
+}
+func (m *MockReviewService) ListReviews(ctx context.Context, filter reviewService.ReviewFilter) (*reviewService.ReviewListResponse, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*reviewService.ReviewListResponse), args.Error(1)
+}
+func (m *MockReviewService) ModerateReview(ctx context.Context, id uuid.UUID, req reviewService.ModerateReviewRequest) (*models.Review, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Review), args.Error(1)
+}
+func (m *MockReviewService) DeleteReview(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// withReviewURLParam додає chi параметр маршруту до запиту
+func withReviewURLParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestCreateReview_Success(t *testing.T) {
+	mockSrv := new(MockReviewService)
+	handler := NewReviewHandler(mockSrv)
+
+	userID, productID := uuid.New(), uuid.New()
+	reqBody := reviewService.CreateReviewRequest{Rating: 5, Body: "Great"}
+	mockSrv.On("CreateReview", mock.Anything, userID, productID, reqBody).
+		Return(&models.Review{ID: uuid.New(), Rating: 5, VerifiedPurchase: true, Status: models.ReviewStatusPending}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/products/"+productID.String()+"/reviews", strings.NewReader(`{"rating":5,"body":"Great"}`))
+	req = withReviewURLParam(req, "id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), ContextKeyUserID, userID))
+	rr := httptest.NewRecorder()
+
+	handler.CreateReview(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var review models.Review
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&review))
+	assert.True(t, review.VerifiedPurchase)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestCreateReview_AlreadyReviewed(t *testing.T) {
+	mockSrv := new(MockReviewService)
+	handler := NewReviewHandler(mockSrv)
+
+	userID, productID := uuid.New(), uuid.New()
+	mockSrv.On("CreateReview", mock.Anything, userID, productID, mock.Anything).Return(nil, reviewService.ErrReviewExists)
+
+	req := httptest.NewRequest(http.MethodPost, "/products/"+productID.String()+"/reviews", strings.NewReader(`{"rating":4}`))
+	req = withReviewURLParam(req, "id", productID.String())
+	req = req.WithContext(context.WithValue(req.Context(), ContextKeyUserID, userID))
+	rr := httptest.NewRecorder()
+
+	handler.CreateReview(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestListProductReviews_Filters(t *testing.T) {
+	mockSrv := new(MockReviewService)
+	handler := NewReviewHandler(mockSrv)
+
+	productID := uuid.New()
+	avg, count := 4.5, 2
+	filter := reviewService.ReviewFilter{Rating: 5, VerifiedOnly: true, OrderBy: "newest", Limit: 10}
+	mockSrv.On("ListProductReviews", mock.Anything, productID, filter).
+		Return(&reviewService.ReviewListResponse{Reviews: []*models.Review{}, RatingAvg: &avg, RatingCount: &count, Limit: 10}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String()+"/reviews?rating=5&verified=true&order_by=newest&limit=10", nil)
+	req = withReviewURLParam(req, "id", productID.String())
+	rr := httptest.NewRecorder()
+
+	handler.ListProductReviews(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp reviewService.ReviewListResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, 4.5, *resp.RatingAvg)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestListProductReviews_InvalidRating(t *testing.T) {
+	mockSrv := new(MockReviewService)
+	handler := NewReviewHandler(mockSrv)
+
+	productID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String()+"/reviews?rating=abc", nil)
+	req = withReviewURLParam(req, "id", productID.String())
+	rr := httptest.NewRecorder()
+
+	handler.ListProductReviews(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "ListProductReviews", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModerateReview_NotFound(t *testing.T) {
+	mockSrv := new(MockReviewService)
+	handler := NewReviewHandler(mockSrv)
+
+	reviewID := uuid.New()
+	mockSrv.On("ModerateReview", mock.Anything, reviewID, reviewService.ModerateReviewRequest{Status: "approved"}).
+		Return(nil, reviewService.ErrReviewNotFound)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/reviews/"+reviewID.String()+"/status", strings.NewReader(`{"status":"approved"}`))
+	req = withReviewURLParam(req, "id", reviewID.String())
+	rr := httptest.NewRecorder()
+
+	handler.ModerateReview(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
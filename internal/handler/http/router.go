@@ -4,28 +4,67 @@ import (
 	"net/http"
 
 	_ "github.com/Xiancel/ecommerce/docs"
+	"github.com/Xiancel/ecommerce/internal/i18n"
 	authService "github.com/Xiancel/ecommerce/internal/service/auth"
+	backInStockService "github.com/Xiancel/ecommerce/internal/service/backinstock"
+	bundleService "github.com/Xiancel/ecommerce/internal/service/bundle"
 	cartService "github.com/Xiancel/ecommerce/internal/service/cart"
+	collectionService "github.com/Xiancel/ecommerce/internal/service/collection"
+	downloadService "github.com/Xiancel/ecommerce/internal/service/download"
+	exportService "github.com/Xiancel/ecommerce/internal/service/export"
+	importService "github.com/Xiancel/ecommerce/internal/service/importer"
+	inventoryService "github.com/Xiancel/ecommerce/internal/service/inventory"
+	mediaService "github.com/Xiancel/ecommerce/internal/service/media"
+	merchService "github.com/Xiancel/ecommerce/internal/service/merchandising"
 	orderService "github.com/Xiancel/ecommerce/internal/service/order"
+	priceAlertService "github.com/Xiancel/ecommerce/internal/service/pricealert"
 	productService "github.com/Xiancel/ecommerce/internal/service/product"
+	questionService "github.com/Xiancel/ecommerce/internal/service/question"
+	recommendationService "github.com/Xiancel/ecommerce/internal/service/recommendation"
+	reviewService "github.com/Xiancel/ecommerce/internal/service/review"
+	subscriptionService "github.com/Xiancel/ecommerce/internal/service/subscription"
+	translationService "github.com/Xiancel/ecommerce/internal/service/translation"
 	userService "github.com/Xiancel/ecommerce/internal/service/user"
+	wishlistService "github.com/Xiancel/ecommerce/internal/service/wishlist"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 type RouterConfig struct {
-	AuthService    authService.AuthService
-	ProductService productService.ProductService
-	CartService    cartService.CartService
-	OrderService   orderService.OrderService
-	UserService    userService.UserService
+	AuthService           authService.AuthService
+	ProductService        productService.ProductService
+	CartService           cartService.CartService
+	OrderService          orderService.OrderService
+	UserService           userService.UserService
+	MerchService          merchService.MerchandisingService
+	MediaService          mediaService.MediaService
+	ImportService         importService.ImportService
+	ExportService         exportService.ExportService
+	ReviewService         reviewService.ReviewService
+	QuestionService       questionService.QuestionService
+	RecommendationService recommendationService.RecommendationService
+	CollectionService     collectionService.CollectionService
+	InventoryService      inventoryService.InventoryService
+	BackInStockService    backInStockService.BackInStockService
+	DownloadService       downloadService.DownloadService
+	TranslationService    translationService.TranslationService
+	WishlistService       wishlistService.WishlistService
+	PriceAlertService     priceAlertService.PriceAlertService
+	BundleService         bundleService.BundleService
+	SubscriptionService   subscriptionService.SubscriptionService
+	// Locales мови вмісту; за замовчуванням лише базова мова
+	Locales i18n.Locales
 }
 
 // створення путів
 func NewRouter(config RouterConfig) *chi.Mux {
 	r := chi.NewRouter()
 
+	if config.Locales.Default == "" {
+		config.Locales = i18n.NewLocales("", config.Locales.Supported)
+	}
+
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestID)
@@ -40,29 +79,86 @@ func NewRouter(config RouterConfig) *chi.Mux {
 
 	r.Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL("http://localhost:8080/swagger/doc.json")))
 
+	// товарні фіди для пошукових систем
+	exportHandler := NewExportHandler(config.ExportService)
+	exportHandler.RegisterFeedRoutes(r)
+
 	r.Route("/api/v1", func(r chi.Router) {
+		// мова вмісту з параметра lang або Accept-Language
+		r.Use(Localize(config.Locales))
+
 		authHandler := NewAuthHandler(config.AuthService)
 		authHandler.RegisterRoutes(r)
 
 		ProductHandler := NewProductHandler(config.ProductService)
 		ProductHandler.RegisterRoutes(r)
 
+		mediaHandler := NewMediaHandler(config.MediaService)
+		mediaHandler.RegisterRoutes(r)
+
+		reviewHandler := NewReviewHandler(config.ReviewService)
+		reviewHandler.RegisterRoutes(r)
+
+		questionHandler := NewQuestionHandler(config.QuestionService)
+		questionHandler.RegisterRoutes(r)
+
+		recommendationHandler := NewRecommendationHandler(config.RecommendationService)
+		recommendationHandler.RegisterRoutes(r)
+
+		collectionHandler := NewCollectionHandler(config.CollectionService)
+		collectionHandler.RegisterRoutes(r)
+
+		downloadHandler := NewDownloadHandler(config.DownloadService)
+		downloadHandler.RegisterRoutes(r)
+
+		translationHandler := NewTranslationHandler(config.TranslationService)
+		translationHandler.RegisterRoutes(r)
+
+		wishlistHandler := NewWishlistHandler(config.WishlistService)
+		wishlistHandler.RegisterRoutes(r)
+
+		bundleHandler := NewBundleHandler(config.BundleService)
+		bundleHandler.RegisterRoutes(r)
+
+		// кошик доступний і гостям (за токеном кошика)
+		r.Group(func(r chi.Router) {
+			r.Use(OptionalAuth(config.AuthService))
+
+			cartHandler := NewCartHandler(config.CartService)
+			cartHandler.RegisterRoutes(r)
+		})
+
 		r.Group(func(r chi.Router) {
 			r.Use(RequireAuth(config.AuthService))
 
 			userHandler := NewUserHandler(config.UserService)
 			userHandler.RegisterRoutes(r)
 
-			cartHandler := NewCartHandler(config.CartService)
-			cartHandler.RegisterRoutes(r)
-
 			orderHandler := NewOrderHandler(config.OrderService)
 			orderHandler.RegisterRoutes(r)
+
+			reviewHandler.RegisterUserRoutes(r)
+
+			questionHandler.RegisterUserRoutes(r)
+
+			backInStockHandler := NewBackInStockHandler(config.BackInStockService)
+			backInStockHandler.RegisterUserRoutes(r)
+
+			downloadHandler.RegisterUserRoutes(r)
+
+			wishlistHandler.RegisterUserRoutes(r)
+
+			priceAlertHandler := NewPriceAlertHandler(config.PriceAlertService)
+			priceAlertHandler.RegisterUserRoutes(r)
+
+			subscriptionHandler := NewSubscriptionHandler(config.SubscriptionService)
+			subscriptionHandler.RegisterUserRoutes(r)
 		})
 
 		r.Group(func(r chi.Router) {
 			r.Use(RequireAuth(config.AuthService))
 			r.Use(RequireAdmin)
+			r.Use(BaseContent)
 
 			adminHandler := NewAdminHandler(
 				config.ProductService,
@@ -71,6 +167,31 @@ func NewRouter(config RouterConfig) *chi.Mux {
 			)
 
 			adminHandler.RegisterRoutes(r)
+
+			merchHandler := NewMerchandisingHandler(config.MerchService, config.ProductService)
+			merchHandler.RegisterRoutes(r)
+
+			mediaHandler.RegisterAdminRoutes(r)
+
+			importHandler := NewImportHandler(config.ImportService)
+			importHandler.RegisterRoutes(r)
+
+			exportHandler.RegisterRoutes(r)
+
+			reviewHandler.RegisterAdminRoutes(r)
+
+			questionHandler.RegisterAdminRoutes(r)
+
+			collectionHandler.RegisterAdminRoutes(r)
+
+			inventoryHandler := NewInventoryHandler(config.InventoryService)
+			inventoryHandler.RegisterRoutes(r)
+
+			downloadHandler.RegisterAdminRoutes(r)
+
+			translationHandler.RegisterAdminRoutes(r)
+
+			bundleHandler.RegisterAdminRoutes(r)
 		})
 	})
 	return r
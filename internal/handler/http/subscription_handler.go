@@ -0,0 +1,325 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	subscriptionSrv "github.com/Xiancel/ecommerce/internal/service/subscription"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type SubscriptionHandler struct {
+	subscriptionSrv subscriptionSrv.SubscriptionService
+}
+
+func NewSubscriptionHandler(subscriptionSrv subscriptionSrv.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{subscriptionSrv: subscriptionSrv}
+}
+
+// RegisterUserRoutes маршрути підписок на регулярну доставку для авторизованих користувачів
+func (h *SubscriptionHandler) RegisterUserRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/subscriptions", h.ListSubscriptions)
+		r.Post("/subscriptions", h.CreateSubscription)
+		r.Get("/subscriptions/{id}", h.GetSubscription)
+		r.Put("/subscriptions/{id}", h.UpdateSubscription)
+		r.Delete("/subscriptions/{id}", h.CancelSubscription)
+		r.Post("/subscriptions/{id}/pause", h.PauseSubscription)
+		r.Post("/subscriptions/{id}/resume", h.ResumeSubscription)
+		r.Post("/subscriptions/{id}/skip", h.SkipNext)
+	})
+}
+
+// CreateSubscription godoc
+// @Summary Створити підписку
+// @Description Створює підписку на регулярну доставку товарів кожні N тижнів. Замовлення створюються автоматично; для оплати карткою потрібен токен збереженої картки.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param subscription body subscription.CreateSubscriptionRequest true "Дані підписки"
+// @Success 201 {object} models.Subscription
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 409 {object} http.ErrorResponse "Subscription limit reached"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /subscriptions [post]
+func (h *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+
+	// отримання данних з request
+	var req subscriptionSrv.CreateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	subscription, err := h.subscriptionSrv.CreateSubscription(r.Context(), userID, req)
+	if err != nil {
+		handlerSubscriptionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, subscription)
+}
+
+// ListSubscriptions godoc
+// @Summary Підписки користувача
+// @Description Повертає підписки користувача з товарами за поточними цінами
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Subscription
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /subscriptions [get]
+func (h *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+
+	subscriptions, err := h.subscriptionSrv.ListSubscriptions(r.Context(), userID)
+	if err != nil {
+		handlerSubscriptionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, subscriptions)
+}
+
+// GetSubscription godoc
+// @Summary Отримати підписку
+// @Description Повертає підписку з товарами та останніми створеними замовленнями
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID підписки"
+// @Success 200 {object} subscription.SubscriptionResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Subscription not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /subscriptions/{id} [get]
+func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID підписки з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	subscription, err := h.subscriptionSrv.GetSubscription(r.Context(), userID, id)
+	if err != nil {
+		handlerSubscriptionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, subscription)
+}
+
+// UpdateSubscription godoc
+// @Summary Оновити підписку
+// @Description Змінює товари та кількості, інтервал, адресу або спосіб оплати. Новий платіжний засіб одразу повторює невдалу оплату або відновлює призупинену підписку.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID підписки"
+// @Param subscription body subscription.UpdateSubscriptionRequest true "Зміни підписки"
+// @Success 200 {object} models.Subscription
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Subscription not found"
+// @Failure 409 {object} http.ErrorResponse "Subscription is cancelled, being processed or changed concurrently"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /subscriptions/{id} [put]
+func (h *SubscriptionHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID підписки з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	// отримання данних з request
+	var req subscriptionSrv.UpdateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	subscription, err := h.subscriptionSrv.UpdateSubscription(r.Context(), userID, id, req)
+	if err != nil {
+		handlerSubscriptionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, subscription)
+}
+
+// CancelSubscription godoc
+// @Summary Скасувати підписку
+// @Description Скасовує підписку; неоплачене замовлення поточного циклу також скасовується
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID підписки"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Subscription not found"
+// @Failure 409 {object} http.ErrorResponse "Subscription is cancelled, being processed or changed concurrently"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /subscriptions/{id} [delete]
+func (h *SubscriptionHandler) CancelSubscription(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID підписки з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	if err := h.subscriptionSrv.CancelSubscription(r.Context(), userID, id); err != nil {
+		handlerSubscriptionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "subscription cancelled",
+	})
+}
+
+// PauseSubscription godoc
+// @Summary Призупинити підписку
+// @Description Призупиняє доставки до відновлення підписки
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID підписки"
+// @Success 200 {object} models.Subscription
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Subscription not found"
+// @Failure 409 {object} http.ErrorResponse "Action is not allowed in the current status or subscription is being processed"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /subscriptions/{id}/pause [post]
+func (h *SubscriptionHandler) PauseSubscription(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.subscriptionSrv.PauseSubscription)
+}
+
+// ResumeSubscription godoc
+// @Summary Відновити підписку
+// @Description Відновлює призупинену підписку; пропущена дата доставки переноситься на зараз
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID підписки"
+// @Success 200 {object} models.Subscription
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Subscription not found"
+// @Failure 409 {object} http.ErrorResponse "Action is not allowed in the current status or subscription is being processed"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /subscriptions/{id}/resume [post]
+func (h *SubscriptionHandler) ResumeSubscription(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.subscriptionSrv.ResumeSubscription)
+}
+
+// SkipNext godoc
+// @Summary Пропустити наступну доставку
+// @Description Переносить найближчу доставку на один інтервал
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "ID підписки"
+// @Success 200 {object} models.Subscription
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Subscription not found"
+// @Failure 409 {object} http.ErrorResponse "Action is not allowed in the current status or subscription is being processed"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /subscriptions/{id}/skip [post]
+func (h *SubscriptionHandler) SkipNext(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.subscriptionSrv.SkipNext)
+}
+
+// changeStatus виконує дію над підпискою без тіла запиту
+func (h *SubscriptionHandler) changeStatus(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error)) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID підписки з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	subscription, err := action(r.Context(), userID, id)
+	if err != nil {
+		handlerSubscriptionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, subscription)
+}
+
+// handlerSubscriptionError повертає помилки
+func handlerSubscriptionError(w http.ResponseWriter, err error) {
+	switch err {
+	case subscriptionSrv.ErrItemsRequired,
+		subscriptionSrv.ErrTooManyItems,
+		subscriptionSrv.ErrInvalidQuantity,
+		subscriptionSrv.ErrDuplicateItem,
+		subscriptionSrv.ErrInvalidInterval,
+		subscriptionSrv.ErrShippingAddressRequired,
+		subscriptionSrv.ErrInvalidPaymentMethod,
+		subscriptionSrv.ErrPaymentReferenceRequired,
+		subscriptionSrv.ErrProductUnavailable:
+		respondError(w, http.StatusBadRequest, err.Error())
+	case subscriptionSrv.ErrProductNotFound,
+		subscriptionSrv.ErrSubscriptionNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case subscriptionSrv.ErrSubscriptionLimit,
+		subscriptionSrv.ErrSubscriptionCancelled,
+		subscriptionSrv.ErrInvalidStatus,
+		subscriptionSrv.ErrSubscriptionBusy,
+		subscriptionSrv.ErrSubscriptionChanged:
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	subscriptionService "github.com/Xiancel/ecommerce/internal/service/subscription"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSubscriptionService struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionService) CreateSubscription(ctx context.Context, userID uuid.UUID, req subscriptionService.CreateSubscriptionRequest) (*models.Subscription, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Subscription), args.Error(1)
+}
+func (m *MockSubscriptionService) GetSubscription(ctx context.Context, userID, id uuid.UUID) (*subscriptionService.SubscriptionResponse, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscriptionService.SubscriptionResponse), args.Error(1)
+}
+func (m *MockSubscriptionService) ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Subscription), args.Error(1)
+}
+func (m *MockSubscriptionService) UpdateSubscription(ctx context.Context, userID, id uuid.UUID, req subscriptionService.UpdateSubscriptionRequest) (*models.Subscription, error) {
+	args := m.Called(ctx, userID, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Subscription), args.Error(1)
+}
+func (m *MockSubscriptionService) PauseSubscription(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Subscription), args.Error(1)
+}
+func (m *MockSubscriptionService) ResumeSubscription(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Subscription), args.Error(1)
+}
+func (m *MockSubscriptionService) SkipNext(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Subscription), args.Error(1)
+}
+func (m *MockSubscriptionService) CancelSubscription(ctx context.Context, userID, id uuid.UUID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+func (m *MockSubscriptionService) ProcessDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+// newSubscriptionRequest створює запит з ID підписки в маршруті та (опційно) користувачем у контексті
+func newSubscriptionRequest(method, target, id, body string, userID *uuid.UUID) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	chiCtx := chi.NewRouteContext()
+	if id != "" {
+		chiCtx.URLParams.Add("id", id)
+	}
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx)
+	if userID != nil {
+		ctx = context.WithValue(ctx, ContextKeyUserID, *userID)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestCreateSubscription_Success(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+	userID, productID := uuid.New(), uuid.New()
+
+	mockSrv.On("CreateSubscription", mock.Anything, userID, mock.MatchedBy(func(req subscriptionService.CreateSubscriptionRequest) bool {
+		return req.IntervalWeeks == 2 && len(req.Items) == 1 && req.Items[0].ProductID == productID && req.PaymentMethod == "cash"
+	})).Return(&models.Subscription{ID: uuid.New(), UserID: userID, Status: models.SubscriptionActive}, nil)
+
+	body := `{"items":[{"product_id":"` + productID.String() + `","quantity":1}],"interval_weeks":2,"payment_method":"cash"}`
+	rr := httptest.NewRecorder()
+	handler.CreateSubscription(rr, newSubscriptionRequest(http.MethodPost, "/subscriptions", "", body, &userID))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestCreateSubscription_Unauthorized(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+
+	rr := httptest.NewRecorder()
+	handler.CreateSubscription(rr, newSubscriptionRequest(http.MethodPost, "/subscriptions", "", `{}`, nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	mockSrv.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateSubscription_InvalidBody(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+	userID := uuid.New()
+
+	rr := httptest.NewRecorder()
+	handler.CreateSubscription(rr, newSubscriptionRequest(http.MethodPost, "/subscriptions", "", `{bad`, &userID))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestCreateSubscription_Limit(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+	userID := uuid.New()
+
+	mockSrv.On("CreateSubscription", mock.Anything, userID, mock.Anything).Return(nil, subscriptionService.ErrSubscriptionLimit)
+
+	rr := httptest.NewRecorder()
+	handler.CreateSubscription(rr, newSubscriptionRequest(http.MethodPost, "/subscriptions", "", `{}`, &userID))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestGetSubscription_NotFound(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+	userID, id := uuid.New(), uuid.New()
+
+	mockSrv.On("GetSubscription", mock.Anything, userID, id).Return(nil, subscriptionService.ErrSubscriptionNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.GetSubscription(rr, newSubscriptionRequest(http.MethodGet, "/subscriptions/"+id.String(), id.String(), "", &userID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetSubscription_InvalidID(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+	userID := uuid.New()
+
+	rr := httptest.NewRecorder()
+	handler.GetSubscription(rr, newSubscriptionRequest(http.MethodGet, "/subscriptions/bad", "bad", "", &userID))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestUpdateSubscription_Success(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+	userID, id := uuid.New(), uuid.New()
+	interval := 3
+
+	mockSrv.On("UpdateSubscription", mock.Anything, userID, id, subscriptionService.UpdateSubscriptionRequest{IntervalWeeks: &interval}).
+		Return(&models.Subscription{ID: id, IntervalWeeks: 3}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.UpdateSubscription(rr, newSubscriptionRequest(http.MethodPut, "/subscriptions/"+id.String(), id.String(), `{"interval_weeks":3}`, &userID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestPauseSubscription_InvalidStatus(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+	userID, id := uuid.New(), uuid.New()
+
+	mockSrv.On("PauseSubscription", mock.Anything, userID, id).Return(nil, subscriptionService.ErrInvalidStatus)
+
+	rr := httptest.NewRecorder()
+	handler.PauseSubscription(rr, newSubscriptionRequest(http.MethodPost, "/subscriptions/"+id.String()+"/pause", id.String(), "", &userID))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestSkipNext_Success(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+	userID, id := uuid.New(), uuid.New()
+
+	mockSrv.On("SkipNext", mock.Anything, userID, id).Return(&models.Subscription{ID: id}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.SkipNext(rr, newSubscriptionRequest(http.MethodPost, "/subscriptions/"+id.String()+"/skip", id.String(), "", &userID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestCancelSubscription_Success(t *testing.T) {
+	mockSrv := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockSrv)
+	userID, id := uuid.New(), uuid.New()
+
+	mockSrv.On("CancelSubscription", mock.Anything, userID, id).Return(nil)
+
+	rr := httptest.NewRecorder()
+	handler.CancelSubscription(rr, newSubscriptionRequest(http.MethodDelete, "/subscriptions/"+id.String(), id.String(), "", &userID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
@@ -0,0 +1,308 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	translationSrv "github.com/Xiancel/ecommerce/internal/service/translation"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type TranslationHandler struct {
+	translationSrv translationSrv.TranslationService
+}
+
+func NewTranslationHandler(translationSrv translationSrv.TranslationService) *TranslationHandler {
+	return &TranslationHandler{translationSrv: translationSrv}
+}
+
+// RegisterRoutes публічні маршрути мов
+func (h *TranslationHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/locales", h.GetLocales)
+	})
+}
+
+// RegisterAdminRoutes маршрути керування перекладами для адміна
+func (h *TranslationHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/admin/products/{id}/translations", h.ListProductTranslations)
+		r.Put("/admin/products/{id}/translations/{locale}", h.SetProductTranslation)
+		r.Delete("/admin/products/{id}/translations/{locale}", h.DeleteProductTranslation)
+
+		r.Get("/admin/categories/{id}/translations", h.ListCategoryTranslations)
+		r.Put("/admin/categories/{id}/translations/{locale}", h.SetCategoryTranslation)
+		r.Delete("/admin/categories/{id}/translations/{locale}", h.DeleteCategoryTranslation)
+
+		r.Get("/admin/translations/missing", h.MissingTranslations)
+	})
+}
+
+// GetLocales godoc
+// @Summary Підтримувані мови
+// @Description Повертає мови вмісту та мову за замовчуванням. Мова відповіді обирається параметром lang або заголовком Accept-Language
+// @Tags locales
+// @Produce json
+// @Success 200 {object} translation.LocalesResponse
+// @Router /locales [get]
+func (h *TranslationHandler) GetLocales(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, h.translationSrv.Locales())
+}
+
+// ListProductTranslations godoc
+// @Summary Переклади продукту (Admin)
+// @Description Повертає всі переклади назви та опису продукту
+// @Tags admin
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Success 200 {array} models.ProductTranslation
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/translations [get]
+func (h *TranslationHandler) ListProductTranslations(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	translations, err := h.translationSrv.ListProductTranslations(r.Context(), id)
+	if err != nil {
+		handlerTranslationError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, translations)
+}
+
+// SetProductTranslation godoc
+// @Summary Зберегти переклад продукту (Admin)
+// @Description Створює або замінює переклад назви та опису продукту вказаною мовою
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param locale path string true "Мова перекладу (наприклад, en)"
+// @Param translation body translation.ProductTranslationRequest true "Переклад"
+// @Success 200 {object} models.ProductTranslation
+// @Failure 400 {object} http.ErrorResponse "Invalid request or locale"
+// @Failure 404 {object} http.ErrorResponse "Product not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/translations/{locale} [put]
+func (h *TranslationHandler) SetProductTranslation(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// отримання данних з request
+	var req translationSrv.ProductTranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	translation, err := h.translationSrv.SetProductTranslation(r.Context(), id, chi.URLParam(r, "locale"), req)
+	if err != nil {
+		handlerTranslationError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, translation)
+}
+
+// DeleteProductTranslation godoc
+// @Summary Видалити переклад продукту (Admin)
+// @Description Видаляє переклад; продукт повертатиметься мовою за замовчуванням
+// @Tags admin
+// @Produce json
+// @Param id path string true "ID продукту"
+// @Param locale path string true "Мова перекладу"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID or locale"
+// @Failure 404 {object} http.ErrorResponse "Translation not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/products/{id}/translations/{locale} [delete]
+func (h *TranslationHandler) DeleteProductTranslation(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	if err := h.translationSrv.DeleteProductTranslation(r.Context(), id, chi.URLParam(r, "locale")); err != nil {
+		handlerTranslationError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "translation deleted",
+	})
+}
+
+// ListCategoryTranslations godoc
+// @Summary Переклади категорії (Admin)
+// @Description Повертає всі переклади назви, slug та опису категорії
+// @Tags admin
+// @Produce json
+// @Param id path string true "ID категорії"
+// @Success 200 {array} models.CategoryTranslation
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 404 {object} http.ErrorResponse "Category not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/categories/{id}/translations [get]
+func (h *TranslationHandler) ListCategoryTranslations(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	translations, err := h.translationSrv.ListCategoryTranslations(r.Context(), id)
+	if err != nil {
+		handlerTranslationError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, translations)
+}
+
+// SetCategoryTranslation godoc
+// @Summary Зберегти переклад категорії (Admin)
+// @Description Створює або замінює переклад категорії; без slug він генерується з перекладеної назви
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID категорії"
+// @Param locale path string true "Мова перекладу (наприклад, en)"
+// @Param translation body translation.CategoryTranslationRequest true "Переклад"
+// @Success 200 {object} models.CategoryTranslation
+// @Failure 400 {object} http.ErrorResponse "Invalid request or locale"
+// @Failure 404 {object} http.ErrorResponse "Category not found"
+// @Failure 409 {object} http.ErrorResponse "Slug already taken"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/categories/{id}/translations/{locale} [put]
+func (h *TranslationHandler) SetCategoryTranslation(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	// отримання данних з request
+	var req translationSrv.CategoryTranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	translation, err := h.translationSrv.SetCategoryTranslation(r.Context(), id, chi.URLParam(r, "locale"), req)
+	if err != nil {
+		handlerTranslationError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, translation)
+}
+
+// DeleteCategoryTranslation godoc
+// @Summary Видалити переклад категорії (Admin)
+// @Description Видаляє переклад; категорія повертатиметься мовою за замовчуванням
+// @Tags admin
+// @Produce json
+// @Param id path string true "ID категорії"
+// @Param locale path string true "Мова перекладу"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID or locale"
+// @Failure 404 {object} http.ErrorResponse "Translation not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/categories/{id}/translations/{locale} [delete]
+func (h *TranslationHandler) DeleteCategoryTranslation(w http.ResponseWriter, r *http.Request) {
+	// отримання ID з url параметру
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	if err := h.translationSrv.DeleteCategoryTranslation(r.Context(), id, chi.URLParam(r, "locale")); err != nil {
+		handlerTranslationError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "translation deleted",
+	})
+}
+
+// MissingTranslations godoc
+// @Summary Відсутні переклади (Admin)
+// @Description Звіт про продукти та категорії без перекладу вказаною мовою
+// @Tags admin
+// @Produce json
+// @Param locale query string true "Мова перекладу"
+// @Param limit query int false "Кількість продуктів (за замовчуванням 50, максимум 200)"
+// @Param offset query int false "Зміщення"
+// @Success 200 {object} translation.MissingTranslationsResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid locale or pagination"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/translations/missing [get]
+func (h *TranslationHandler) MissingTranslations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// пагінація
+	limit, offset := 0, 0
+	if limitStr := query.Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = l
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		o, err := strconv.Atoi(offsetStr)
+		if err != nil || o < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid offset")
+			return
+		}
+		offset = o
+	}
+
+	report, err := h.translationSrv.MissingTranslations(r.Context(), query.Get("locale"), limit, offset)
+	if err != nil {
+		handlerTranslationError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, report)
+}
+
+// handlerTranslationError повертає помилки
+func handlerTranslationError(w http.ResponseWriter, err error) {
+	switch err {
+	case translationSrv.ErrUnsupportedLocale,
+		translationSrv.ErrDefaultLocale,
+		translationSrv.ErrNameRequired,
+		translationSrv.ErrNameTooLong,
+		translationSrv.ErrInvalidSlug:
+		respondError(w, http.StatusBadRequest, err.Error())
+	case translationSrv.ErrProductNotFound,
+		translationSrv.ErrCategoryNotFound,
+		translationSrv.ErrTranslationNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case translationSrv.ErrSlugTaken:
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
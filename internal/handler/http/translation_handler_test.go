@@ -0,0 +1,214 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/i18n"
+	translationService "github.com/Xiancel/ecommerce/internal/service/translation"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTranslationService struct {
+	mock.Mock
+}
+
+func (m *MockTranslationService) Locales() translationService.LocalesResponse {
+	args := m.Called()
+	return args.Get(0).(translationService.LocalesResponse)
+}
+func (m *MockTranslationService) ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationService) SetProductTranslation(ctx context.Context, productID uuid.UUID, locale string, req translationService.ProductTranslationRequest) (*models.ProductTranslation, error) {
+	args := m.Called(ctx, productID, locale, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationService) DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	args := m.Called(ctx, productID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationService) ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationService) SetCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string, req translationService.CategoryTranslationRequest) (*models.CategoryTranslation, error) {
+	args := m.Called(ctx, categoryID, locale, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationService) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	args := m.Called(ctx, categoryID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationService) MissingTranslations(ctx context.Context, locale string, limit, offset int) (*translationService.MissingTranslationsResponse, error) {
+	args := m.Called(ctx, locale, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*translationService.MissingTranslationsResponse), args.Error(1)
+}
+
+// newTranslationRequest створює запит з ID та мовою в маршруті
+func newTranslationRequest(method, target, id, locale, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", id)
+	if locale != "" {
+		chiCtx.URLParams.Add("locale", locale)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func TestSetProductTranslation_Success(t *testing.T) {
+	mockSrv := new(MockTranslationService)
+	handler := NewTranslationHandler(mockSrv)
+	productID := uuid.New()
+
+	mockSrv.On("SetProductTranslation", mock.Anything, productID, "en", translationService.ProductTranslationRequest{Name: "Headphones"}).
+		Return(&models.ProductTranslation{ProductID: productID, Locale: "en", Name: "Headphones"}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.SetProductTranslation(rr, newTranslationRequest(http.MethodPut, "/admin/products/"+productID.String()+"/translations/en",
+		productID.String(), "en", `{"name":"Headphones"}`))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Headphones")
+	mockSrv.AssertExpectations(t)
+}
+
+func TestSetProductTranslation_UnsupportedLocale(t *testing.T) {
+	mockSrv := new(MockTranslationService)
+	handler := NewTranslationHandler(mockSrv)
+	productID := uuid.New()
+
+	mockSrv.On("SetProductTranslation", mock.Anything, productID, "de", mock.Anything).Return(nil, translationService.ErrUnsupportedLocale)
+
+	rr := httptest.NewRecorder()
+	handler.SetProductTranslation(rr, newTranslationRequest(http.MethodPut, "/admin/products/"+productID.String()+"/translations/de",
+		productID.String(), "de", `{"name":"Kopfhörer"}`))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestSetProductTranslation_InvalidBody(t *testing.T) {
+	mockSrv := new(MockTranslationService)
+	handler := NewTranslationHandler(mockSrv)
+	productID := uuid.New()
+
+	rr := httptest.NewRecorder()
+	handler.SetProductTranslation(rr, newTranslationRequest(http.MethodPut, "/admin/products/"+productID.String()+"/translations/en",
+		productID.String(), "en", `{`))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "SetProductTranslation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetCategoryTranslation_SlugTaken(t *testing.T) {
+	mockSrv := new(MockTranslationService)
+	handler := NewTranslationHandler(mockSrv)
+	categoryID := uuid.New()
+
+	mockSrv.On("SetCategoryTranslation", mock.Anything, categoryID, "en", mock.Anything).Return(nil, translationService.ErrSlugTaken)
+
+	rr := httptest.NewRecorder()
+	handler.SetCategoryTranslation(rr, newTranslationRequest(http.MethodPut, "/admin/categories/"+categoryID.String()+"/translations/en",
+		categoryID.String(), "en", `{"name":"Books","slug":"books"}`))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestDeleteCategoryTranslation_NotFound(t *testing.T) {
+	mockSrv := new(MockTranslationService)
+	handler := NewTranslationHandler(mockSrv)
+	categoryID := uuid.New()
+
+	mockSrv.On("DeleteCategoryTranslation", mock.Anything, categoryID, "en").Return(translationService.ErrTranslationNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.DeleteCategoryTranslation(rr, newTranslationRequest(http.MethodDelete, "/admin/categories/"+categoryID.String()+"/translations/en",
+		categoryID.String(), "en", ""))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestMissingTranslations_Success(t *testing.T) {
+	mockSrv := new(MockTranslationService)
+	handler := NewTranslationHandler(mockSrv)
+
+	mockSrv.On("MissingTranslations", mock.Anything, "en", 10, 20).
+		Return(&translationService.MissingTranslationsResponse{Locale: "en", ProductsTotal: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/translations/missing?locale=en&limit=10&offset=20", nil)
+	rr := httptest.NewRecorder()
+	handler.MissingTranslations(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestMissingTranslations_InvalidLimit(t *testing.T) {
+	mockSrv := new(MockTranslationService)
+	handler := NewTranslationHandler(mockSrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/translations/missing?locale=en&limit=abc", nil)
+	rr := httptest.NewRecorder()
+	handler.MissingTranslations(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestLocalize_ResolvesLocale(t *testing.T) {
+	locales := i18n.NewLocales("uk", []string{"en"})
+	var got string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = models.LocaleFromContext(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9,uk;q=0.8")
+	rr := httptest.NewRecorder()
+	Localize(locales)(next).ServeHTTP(rr, req)
+
+	assert.Equal(t, "en", got)
+	assert.Equal(t, "en", rr.Header().Get("Content-Language"))
+
+	// параметр lang має пріоритет над заголовком
+	req = httptest.NewRequest(http.MethodGet, "/products?lang=uk", nil)
+	req.Header.Set("Accept-Language", "en")
+	Localize(locales)(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "uk", got)
+}
+
+func TestBaseContent_ClearsLocale(t *testing.T) {
+	got := "unset"
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = models.LocaleFromContext(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/products", nil)
+	req = req.WithContext(models.ContextWithLocale(req.Context(), "en"))
+	BaseContent(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "", got)
+}
@@ -108,7 +108,8 @@ func handlerServiceUserError(w http.ResponseWriter, err error) {
 		userSrv.ErrInvalidEmail,
 		userSrv.ErrInvalidRole,
 		userSrv.ErrPasswordRequired,
-		userSrv.ErrNoFields:
+		userSrv.ErrNoFields,
+		userSrv.ErrInvalidCursor:
 		respondError(w, http.StatusBadRequest, err.Error())
 
 	case userSrv.ErrEmailAlreadyExists:
@@ -2,7 +2,10 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // допоміжні функції
@@ -27,3 +30,22 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
+
+// setPageLinks додає заголовок Link з посиланнями на сусідні сторінки.
+// Посилання зберігають параметри запиту, зміщення замінюється курсором
+func setPageLinks(w http.ResponseWriter, r *http.Request, next, prev string) {
+	var links []string
+	for _, page := range []struct{ rel, cursor string }{{"next", next}, {"prev", prev}} {
+		if page.cursor == "" {
+			continue
+		}
+		query := r.URL.Query()
+		query.Del("offset")
+		query.Set("cursor", page.cursor)
+		link := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, link.String(), page.rel))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,501 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	wishlistSrv "github.com/Xiancel/ecommerce/internal/service/wishlist"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type WishlistHandler struct {
+	wishlistSrv wishlistSrv.WishlistService
+}
+
+func NewWishlistHandler(wishlistSrv wishlistSrv.WishlistService) *WishlistHandler {
+	return &WishlistHandler{wishlistSrv: wishlistSrv}
+}
+
+// RegisterRoutes публічні маршрути списків бажань (доступ за токеном)
+func (h *WishlistHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/wishlists/shared/{token}", h.GetSharedWishlist)
+	})
+}
+
+// RegisterUserRoutes маршрути списків бажань для авторизованих користувачів
+func (h *WishlistHandler) RegisterUserRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Get("/wishlists", h.ListWishlists)
+		r.Post("/wishlists", h.CreateWishlist)
+		r.Get("/wishlists/{id}", h.GetWishlist)
+		r.Put("/wishlists/{id}", h.UpdateWishlist)
+		r.Delete("/wishlists/{id}", h.DeleteWishlist)
+		r.Post("/wishlists/{id}/items", h.AddItem)
+		r.Post("/wishlists/{id}/items/from-cart", h.MoveCartItem)
+		r.Delete("/wishlists/{id}/items/{itemId}", h.RemoveItem)
+		r.Post("/wishlists/{id}/items/{itemId}/move", h.MoveItem)
+		r.Post("/wishlists/{id}/items/{itemId}/move-to-cart", h.MoveItemToCart)
+	})
+}
+
+// ListWishlists godoc
+// @Summary Списки бажань користувача
+// @Description Повертає всі списки бажань користувача з кількістю товарів
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Wishlist
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists [get]
+func (h *WishlistHandler) ListWishlists(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+
+	wishlists, err := h.wishlistSrv.ListWishlists(r.Context(), userID)
+	if err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, wishlists)
+}
+
+// CreateWishlist godoc
+// @Summary Створити список бажань
+// @Description Створює іменований список; публічний список отримує токен для посилання
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param wishlist body wishlist.CreateWishlistRequest true "Список бажань"
+// @Success 201 {object} models.Wishlist
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 409 {object} http.ErrorResponse "Name taken or too many wishlists"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists [post]
+func (h *WishlistHandler) CreateWishlist(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+
+	// отримання данних з request
+	var req wishlistSrv.CreateWishlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	wishlist, err := h.wishlistSrv.CreateWishlist(r.Context(), userID, req)
+	if err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, wishlist)
+}
+
+// GetWishlist godoc
+// @Summary Список бажань з товарами
+// @Description Повертає список з поточною ціною, наявністю товарів та позначкою зниження ціни з моменту додавання
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID списку"
+// @Success 200 {object} wishlist.WishlistResponse
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Wishlist not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists/{id} [get]
+func (h *WishlistHandler) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID списку з url параметру
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wishlist ID")
+		return
+	}
+
+	wishlist, err := h.wishlistSrv.GetWishlist(r.Context(), userID, wishlistID)
+	if err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, wishlist)
+}
+
+// UpdateWishlist godoc
+// @Summary Оновити список бажань
+// @Description Перейменовує список або змінює доступ; закритий список втрачає посилання
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID списку"
+// @Param wishlist body wishlist.UpdateWishlistRequest true "Зміни"
+// @Success 200 {object} models.Wishlist
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Wishlist not found"
+// @Failure 409 {object} http.ErrorResponse "Name taken"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists/{id} [put]
+func (h *WishlistHandler) UpdateWishlist(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID списку з url параметру
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wishlist ID")
+		return
+	}
+
+	// отримання данних з request
+	var req wishlistSrv.UpdateWishlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	wishlist, err := h.wishlistSrv.UpdateWishlist(r.Context(), userID, wishlistID, req)
+	if err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, wishlist)
+}
+
+// DeleteWishlist godoc
+// @Summary Видалити список бажань
+// @Description Видаляє список разом з товарами
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID списку"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Wishlist not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists/{id} [delete]
+func (h *WishlistHandler) DeleteWishlist(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID списку з url параметру
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wishlist ID")
+		return
+	}
+
+	if err := h.wishlistSrv.DeleteWishlist(r.Context(), userID, wishlistID); err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "wishlist deleted",
+	})
+}
+
+// GetSharedWishlist godoc
+// @Summary Публічний список бажань
+// @Description Повертає публічний список за токеном посилання без даних власника
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param token path string true "Токен посилання"
+// @Success 200 {object} wishlist.WishlistResponse
+// @Failure 404 {object} http.ErrorResponse "Wishlist not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Router /wishlists/shared/{token} [get]
+func (h *WishlistHandler) GetSharedWishlist(w http.ResponseWriter, r *http.Request) {
+	wishlist, err := h.wishlistSrv.GetSharedWishlist(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, wishlist)
+}
+
+// AddItem godoc
+// @Summary Додати товар у список бажань
+// @Description Додає опублікований продукт у список, запам'ятовуючи поточну ціну
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID списку"
+// @Param item body wishlist.AddWishlistItemRequest true "Продукт"
+// @Success 201 {object} models.WishlistItem
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Wishlist or product not found"
+// @Failure 409 {object} http.ErrorResponse "Product already in wishlist"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists/{id}/items [post]
+func (h *WishlistHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID списку з url параметру
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wishlist ID")
+		return
+	}
+
+	// отримання данних з request
+	var req wishlistSrv.AddWishlistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	item, err := h.wishlistSrv.AddItem(r.Context(), userID, wishlistID, req)
+	if err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, item)
+}
+
+// RemoveItem godoc
+// @Summary Видалити товар зі списку бажань
+// @Description Видаляє товар зі списку користувача
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID списку"
+// @Param itemId path string true "ID товару у списку"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid ID"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Wishlist or item not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists/{id}/items/{itemId} [delete]
+func (h *WishlistHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID списку та товару з url параметрів
+	wishlistID, itemID, ok := wishlistItemParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.wishlistSrv.RemoveItem(r.Context(), userID, wishlistID, itemID); err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "item removed",
+	})
+}
+
+// MoveItem godoc
+// @Summary Перенести товар в інший список
+// @Description Переносить товар в інший список користувача, зберігаючи ціну на момент додавання
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID списку"
+// @Param itemId path string true "ID товару у списку"
+// @Param move body wishlist.MoveWishlistItemRequest true "Цільовий список"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Wishlist or item not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists/{id}/items/{itemId}/move [post]
+func (h *WishlistHandler) MoveItem(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID списку та товару з url параметрів
+	wishlistID, itemID, ok := wishlistItemParams(w, r)
+	if !ok {
+		return
+	}
+
+	// отримання данних з request
+	var req wishlistSrv.MoveWishlistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.wishlistSrv.MoveItem(r.Context(), userID, wishlistID, itemID, req); err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "item moved",
+	})
+}
+
+// MoveItemToCart godoc
+// @Summary Перенести товар у кошик
+// @Description Додає товар у кошик і видаляє його зі списку; тіло запиту необов'язкове (кількість 1)
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID списку"
+// @Param itemId path string true "ID товару у списку"
+// @Param move body wishlist.MoveToCartRequest false "Кількість"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Wishlist, item or product not found"
+// @Failure 409 {object} http.ErrorResponse "Product not available or insufficient stock"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists/{id}/items/{itemId}/move-to-cart [post]
+func (h *WishlistHandler) MoveItemToCart(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID списку та товару з url параметрів
+	wishlistID, itemID, ok := wishlistItemParams(w, r)
+	if !ok {
+		return
+	}
+
+	// тіло необов'язкове
+	var req wishlistSrv.MoveToCartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.wishlistSrv.MoveItemToCart(r.Context(), userID, wishlistID, itemID, req); err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "item moved to cart",
+	})
+}
+
+// MoveCartItem godoc
+// @Summary Перенести товар з кошика у список
+// @Description Видаляє товар з кошика і додає його у список за поточною ціною
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID списку"
+// @Param move body wishlist.MoveFromCartRequest true "Товар кошика"
+// @Success 201 {object} models.WishlistItem
+// @Failure 400 {object} http.ErrorResponse "Invalid request body"
+// @Failure 401 {object} http.ErrorResponse "User not authorized"
+// @Failure 404 {object} http.ErrorResponse "Wishlist or cart item not found"
+// @Failure 500 {object} http.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /wishlists/{id}/items/from-cart [post]
+func (h *WishlistHandler) MoveCartItem(w http.ResponseWriter, r *http.Request) {
+	// отримання ID користувача з контексту
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "User not authorized")
+		return
+	}
+	// отримання ID списку з url параметру
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wishlist ID")
+		return
+	}
+
+	// отримання данних з request
+	var req wishlistSrv.MoveFromCartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	item, err := h.wishlistSrv.MoveCartItem(r.Context(), userID, wishlistID, req)
+	if err != nil {
+		handlerWishlistError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, item)
+}
+
+// wishlistItemParams розбирає ID списку та товару з маршруту
+func wishlistItemParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	wishlistID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid wishlist ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	itemID, err := uuid.Parse(chi.URLParam(r, "itemId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid item ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return wishlistID, itemID, true
+}
+
+// handlerWishlistError повертає помилки
+func handlerWishlistError(w http.ResponseWriter, err error) {
+	switch err {
+	case wishlistSrv.ErrNameRequired,
+		wishlistSrv.ErrNameTooLong,
+		wishlistSrv.ErrInvalidQuantity,
+		wishlistSrv.ErrSameWishlist:
+		respondError(w, http.StatusBadRequest, err.Error())
+	case wishlistSrv.ErrWishlistNotFound,
+		wishlistSrv.ErrItemNotFound,
+		wishlistSrv.ErrCartItemNotFound,
+		wishlistSrv.ErrProductNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case wishlistSrv.ErrNameTaken,
+		wishlistSrv.ErrTooManyWishlists,
+		wishlistSrv.ErrItemExists,
+		wishlistSrv.ErrProductNotAvailable,
+		wishlistSrv.ErrInsufficientStock:
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
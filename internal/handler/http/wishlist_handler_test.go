@@ -0,0 +1,249 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	wishlistService "github.com/Xiancel/ecommerce/internal/service/wishlist"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWishlistService struct {
+	mock.Mock
+}
+
+func (m *MockWishlistService) ListWishlists(ctx context.Context, userID uuid.UUID) ([]*models.Wishlist, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Wishlist), args.Error(1)
+}
+func (m *MockWishlistService) CreateWishlist(ctx context.Context, userID uuid.UUID, req wishlistService.CreateWishlistRequest) (*models.Wishlist, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wishlist), args.Error(1)
+}
+func (m *MockWishlistService) GetWishlist(ctx context.Context, userID, wishlistID uuid.UUID) (*wishlistService.WishlistResponse, error) {
+	args := m.Called(ctx, userID, wishlistID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wishlistService.WishlistResponse), args.Error(1)
+}
+func (m *MockWishlistService) UpdateWishlist(ctx context.Context, userID, wishlistID uuid.UUID, req wishlistService.UpdateWishlistRequest) (*models.Wishlist, error) {
+	args := m.Called(ctx, userID, wishlistID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wishlist), args.Error(1)
+}
+func (m *MockWishlistService) DeleteWishlist(ctx context.Context, userID, wishlistID uuid.UUID) error {
+	args := m.Called(ctx, userID, wishlistID)
+	return args.Error(0)
+}
+func (m *MockWishlistService) GetSharedWishlist(ctx context.Context, token string) (*wishlistService.WishlistResponse, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wishlistService.WishlistResponse), args.Error(1)
+}
+func (m *MockWishlistService) AddItem(ctx context.Context, userID, wishlistID uuid.UUID, req wishlistService.AddWishlistItemRequest) (*models.WishlistItem, error) {
+	args := m.Called(ctx, userID, wishlistID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WishlistItem), args.Error(1)
+}
+func (m *MockWishlistService) RemoveItem(ctx context.Context, userID, wishlistID, itemID uuid.UUID) error {
+	args := m.Called(ctx, userID, wishlistID, itemID)
+	return args.Error(0)
+}
+func (m *MockWishlistService) MoveItem(ctx context.Context, userID, wishlistID, itemID uuid.UUID, req wishlistService.MoveWishlistItemRequest) error {
+	args := m.Called(ctx, userID, wishlistID, itemID, req)
+	return args.Error(0)
+}
+func (m *MockWishlistService) MoveItemToCart(ctx context.Context, userID, wishlistID, itemID uuid.UUID, req wishlistService.MoveToCartRequest) error {
+	args := m.Called(ctx, userID, wishlistID, itemID, req)
+	return args.Error(0)
+}
+func (m *MockWishlistService) MoveCartItem(ctx context.Context, userID, wishlistID uuid.UUID, req wishlistService.MoveFromCartRequest) (*models.WishlistItem, error) {
+	args := m.Called(ctx, userID, wishlistID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WishlistItem), args.Error(1)
+}
+
+// newWishlistRequest створює запит з параметрами маршруту та (опційно) користувачем у контексті
+func newWishlistRequest(method, target string, body []byte, params map[string]string, userID *uuid.UUID) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	chiCtx := chi.NewRouteContext()
+	for key, value := range params {
+		chiCtx.URLParams.Add(key, value)
+	}
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx)
+	if userID != nil {
+		ctx = context.WithValue(ctx, ContextKeyUserID, *userID)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestCreateWishlist_Success(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+	userID := uuid.New()
+	reqBody := wishlistService.CreateWishlistRequest{Name: "Birthday", IsPublic: true}
+	body, _ := json.Marshal(reqBody)
+
+	mockSrv.On("CreateWishlist", mock.Anything, userID, reqBody).
+		Return(&models.Wishlist{ID: uuid.New(), UserID: userID, Name: "Birthday"}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.CreateWishlist(rr, newWishlistRequest(http.MethodPost, "/wishlists", body, nil, &userID))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestCreateWishlist_NameTaken(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+	userID := uuid.New()
+	body, _ := json.Marshal(wishlistService.CreateWishlistRequest{Name: "Birthday"})
+
+	mockSrv.On("CreateWishlist", mock.Anything, userID, mock.Anything).Return(nil, wishlistService.ErrNameTaken)
+
+	rr := httptest.NewRecorder()
+	handler.CreateWishlist(rr, newWishlistRequest(http.MethodPost, "/wishlists", body, nil, &userID))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestCreateWishlist_Unauthorized(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+
+	rr := httptest.NewRecorder()
+	handler.CreateWishlist(rr, newWishlistRequest(http.MethodPost, "/wishlists", []byte(`{}`), nil, nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	mockSrv.AssertNotCalled(t, "CreateWishlist", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetWishlist_NotFound(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+	userID, wishlistID := uuid.New(), uuid.New()
+
+	mockSrv.On("GetWishlist", mock.Anything, userID, wishlistID).Return(nil, wishlistService.ErrWishlistNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.GetWishlist(rr, newWishlistRequest(http.MethodGet, "/wishlists/"+wishlistID.String(), nil,
+		map[string]string{"id": wishlistID.String()}, &userID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetSharedWishlist_Success(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+
+	mockSrv.On("GetSharedWishlist", mock.Anything, "abc").Return(&wishlistService.WishlistResponse{
+		Wishlist: &models.Wishlist{Name: "Gifts", IsPublic: true},
+		Items:    []*models.WishlistItemWithProduct{},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetSharedWishlist(rr, newWishlistRequest(http.MethodGet, "/wishlists/shared/abc", nil,
+		map[string]string{"token": "abc"}, nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"name":"Gifts"`)
+}
+
+func TestAddWishlistItem_Exists(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+	userID, wishlistID := uuid.New(), uuid.New()
+	body, _ := json.Marshal(wishlistService.AddWishlistItemRequest{ProductID: uuid.New()})
+
+	mockSrv.On("AddItem", mock.Anything, userID, wishlistID, mock.Anything).Return(nil, wishlistService.ErrItemExists)
+
+	rr := httptest.NewRecorder()
+	handler.AddItem(rr, newWishlistRequest(http.MethodPost, "/wishlists/"+wishlistID.String()+"/items", body,
+		map[string]string{"id": wishlistID.String()}, &userID))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestMoveWishlistItem_InvalidItemID(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+	userID, wishlistID := uuid.New(), uuid.New()
+
+	rr := httptest.NewRecorder()
+	handler.MoveItem(rr, newWishlistRequest(http.MethodPost, "/wishlists/x/items/bad/move", []byte(`{}`),
+		map[string]string{"id": wishlistID.String(), "itemId": "bad"}, &userID))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSrv.AssertNotCalled(t, "MoveItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMoveWishlistItemToCart_EmptyBody(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+	userID, wishlistID, itemID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSrv.On("MoveItemToCart", mock.Anything, userID, wishlistID, itemID, wishlistService.MoveToCartRequest{}).Return(nil)
+
+	rr := httptest.NewRecorder()
+	handler.MoveItemToCart(rr, newWishlistRequest(http.MethodPost, "/wishlists/x/items/y/move-to-cart", nil,
+		map[string]string{"id": wishlistID.String(), "itemId": itemID.String()}, &userID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
+
+func TestMoveWishlistItemToCart_NotAvailable(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+	userID, wishlistID, itemID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSrv.On("MoveItemToCart", mock.Anything, userID, wishlistID, itemID, mock.Anything).Return(wishlistService.ErrProductNotAvailable)
+
+	rr := httptest.NewRecorder()
+	handler.MoveItemToCart(rr, newWishlistRequest(http.MethodPost, "/wishlists/x/items/y/move-to-cart", []byte(`{"quantity":2}`),
+		map[string]string{"id": wishlistID.String(), "itemId": itemID.String()}, &userID))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestMoveCartItemToWishlist_Success(t *testing.T) {
+	mockSrv := new(MockWishlistService)
+	handler := NewWishlistHandler(mockSrv)
+	userID, wishlistID, cartItemID := uuid.New(), uuid.New(), uuid.New()
+	reqBody := wishlistService.MoveFromCartRequest{CartItemID: cartItemID}
+	body, _ := json.Marshal(reqBody)
+
+	mockSrv.On("MoveCartItem", mock.Anything, userID, wishlistID, reqBody).
+		Return(&models.WishlistItem{ID: uuid.New(), WishlistID: wishlistID}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.MoveCartItem(rr, newWishlistRequest(http.MethodPost, "/wishlists/x/items/from-cart", body,
+		map[string]string{"id": wishlistID.String()}, &userID))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockSrv.AssertExpectations(t)
+}
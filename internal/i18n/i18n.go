@@ -0,0 +1,129 @@
+// Package i18n визначає мову відповіді за параметром lang та заголовком Accept-Language
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale мова базового вмісту каталогу
+const DefaultLocale = "uk"
+
+// Locales підтримувані мови та мова за замовчуванням
+type Locales struct {
+	Default   string
+	Supported []string
+}
+
+// NewLocales нормалізує налаштування; мова за замовчуванням завжди підтримується
+func NewLocales(defaultLocale string, supported []string) Locales {
+	def := Normalize(defaultLocale)
+	if def == "" {
+		def = DefaultLocale
+	}
+
+	locales := Locales{Default: def, Supported: []string{def}}
+	for _, s := range supported {
+		locale := Normalize(s)
+		if locale != "" && !locales.IsSupported(locale) {
+			locales.Supported = append(locales.Supported, locale)
+		}
+	}
+	return locales
+}
+
+// ParseList розбирає список мов через кому (наприклад, зі змінної оточення)
+func ParseList(value string) []string {
+	var locales []string
+	for _, part := range strings.Split(value, ",") {
+		if locale := Normalize(part); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+// Normalize зводить мовний тег до основної мови в нижньому регістрі: "en-US" -> "en"
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	for _, r := range tag {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+	return tag
+}
+
+// IsSupported перевіряє, чи підтримується мова
+func (l Locales) IsSupported(locale string) bool {
+	for _, s := range l.Supported {
+		if s == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve обирає мову відповіді: параметр lang має пріоритет над Accept-Language,
+// непідтримувані мови пропускаються, інакше повертається мова за замовчуванням
+func (l Locales) Resolve(lang, acceptLanguage string) string {
+	if locale := Normalize(lang); l.IsSupported(locale) {
+		return locale
+	}
+	for _, tag := range ParseAcceptLanguage(acceptLanguage) {
+		if locale := Normalize(tag); l.IsSupported(locale) {
+			return locale
+		}
+	}
+	return l.Default
+}
+
+// ParseAcceptLanguage повертає мовні теги заголовка Accept-Language за спаданням ваги q.
+// Теги з q=0 та "*" пропускаються
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err != nil {
+				value = 0
+			}
+			q = value
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+
+	// стабільне сортування зберігає порядок тегів з однаковою вагою
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLocales(t *testing.T) {
+	locales := NewLocales("UK", []string{"en-US", "uk", " ", "en"})
+
+	assert.Equal(t, "uk", locales.Default)
+	assert.Equal(t, []string{"uk", "en"}, locales.Supported)
+}
+
+func TestNewLocales_DefaultFallback(t *testing.T) {
+	locales := NewLocales("", nil)
+
+	assert.Equal(t, DefaultLocale, locales.Default)
+	assert.True(t, locales.IsSupported(DefaultLocale))
+}
+
+func TestResolve(t *testing.T) {
+	locales := NewLocales("uk", []string{"en"})
+
+	tests := []struct {
+		name   string
+		lang   string
+		accept string
+		want   string
+	}{
+		{"lang param wins", "en", "uk", "en"},
+		{"unsupported lang falls back to header", "de", "en-GB,en;q=0.9", "en"},
+		{"header by weight", "", "uk;q=0.5, en;q=0.8", "en"},
+		{"first supported tag", "", "de-DE, fr;q=0.9, uk;q=0.7", "uk"},
+		{"zero weight ignored", "", "en;q=0", "uk"},
+		{"wildcard ignored", "", "*", "uk"},
+		{"empty", "", "", "uk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, locales.Resolve(tt.lang, tt.accept))
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tags := ParseAcceptLanguage("fr;q=0.5, en-US, de;q=bad, uk;q=0.5")
+
+	assert.Equal(t, []string{"en-US", "fr", "uk"}, tags)
+}
+
+func TestParseList(t *testing.T) {
+	assert.Equal(t, []string{"uk", "en"}, ParseList("uk, en-US,,"))
+}
@@ -0,0 +1,24 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+)
+
+type logNotifier struct{}
+
+// NewLogNotifier створює Notifier, який лише пише сповіщення в лог
+func NewLogNotifier() Notifier {
+	return logNotifier{}
+}
+
+// Notify пише сповіщення в лог
+func (logNotifier) Notify(ctx context.Context, n Notification) error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	log.Printf("notification: %s", data)
+	return nil
+}
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// типи сповіщень
+const (
+	TypeQuestionAnswered = "question_answered"
+	TypeLowStock         = "low_stock"
+	TypeReorderDigest    = "reorder_digest"
+	TypeBackInStock      = "back_in_stock"
+	TypePriceDrop        = "price_drop"
+	// сповіщення підписок на регулярну доставку
+	TypeSubscriptionOrder         = "subscription_order"
+	TypeSubscriptionPaymentFailed = "subscription_payment_failed"
+	TypeSubscriptionSkipped       = "subscription_skipped"
+)
+
+// Notification подія, про яку потрібно сповістити користувача або адміністратора
+type Notification struct {
+	ID        uuid.UUID              `json:"id"`
+	Type      string                 `json:"type"`
+	UserID    *uuid.UUID             `json:"user_id,omitempty"`
+	Data      map[string]interface{} `json:"data"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// Notifier доставляє сповіщення (лог, вебхук тощо)
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// New створює сповіщення з ID та часом створення
+func New(notificationType string, userID *uuid.UUID, data map[string]interface{}) Notification {
+	return Notification{
+		ID:        uuid.New(),
+		Type:      notificationType,
+		UserID:    userID,
+		Data:      data,
+		CreatedAt: time.Now().UTC(),
+	}
+}
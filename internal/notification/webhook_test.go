@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifier_Success(t *testing.T) {
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	userID := uuid.New()
+	n := New(TypeQuestionAnswered, &userID, map[string]interface{}{"question_id": "q1"})
+
+	err := NewWebhookNotifier(server.URL).Notify(context.Background(), n)
+
+	assert.NoError(t, err)
+	assert.Equal(t, n.ID, received.ID)
+	assert.Equal(t, TypeQuestionAnswered, received.Type)
+	assert.Equal(t, userID, *received.UserID)
+	assert.Equal(t, "q1", received.Data["question_id"])
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL).Notify(context.Background(), New(TypeQuestionAnswered, nil, nil))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+// Package pagination кодує позицію сторінки списку в непрозорий курсор
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor курсор пошкоджений або сформований для іншого сортування
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor позиція сторінки: ключ сортування крайнього запису (keyset)
+// або зміщення для сортувань без стабільного ключа (релевантність пошуку)
+type Cursor struct {
+	// Sort сортування, для якого сформовано курсор
+	Sort string `json:"s"`
+	// Values значення колонок сортування крайнього запису сторінки
+	Values []string `json:"v,omitempty"`
+	// ID крайнього запису; розрізняє записи з однаковими значеннями сортування
+	ID string `json:"id,omitempty"`
+	// Backward попередня сторінка: записи перед крайнім
+	Backward bool `json:"b,omitempty"`
+	// Offset зміщення, якщо keyset неможливий
+	Offset int `json:"o,omitempty"`
+}
+
+// IsKeyset повертає true, якщо курсор вказує на запис, а не на зміщення
+func (c *Cursor) IsKeyset() bool {
+	return c.ID != ""
+}
+
+// Encode повертає непрозорий токен курсора
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode розбирає токен і перевіряє, що він сформований для сортування sort
+func Decode(token, sort string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != sort || c.Offset < 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// Page результат вибірки limit+1 записів: чи є сусідні сторінки
+type Page struct {
+	HasNext bool
+	HasPrev bool
+}
+
+// Trim відкидає зайвий запис вибірки limit+1 і визначає наявність сусідніх сторінок.
+// При русі назад зайвий запис стоїть на початку (записи вже в порядку відображення)
+func Trim[T any](items []T, limit int, cursor *Cursor) ([]T, Page) {
+	var page Page
+	backward := cursor != nil && cursor.Backward
+	extra := len(items) > limit
+
+	if backward {
+		if extra {
+			items = items[len(items)-limit:]
+		}
+		page.HasPrev = extra
+		page.HasNext = true
+	} else {
+		if extra {
+			items = items[:limit]
+		}
+		page.HasNext = extra
+		page.HasPrev = cursor != nil && (cursor.IsKeyset() || cursor.Offset > 0)
+	}
+	return items, page
+}
@@ -0,0 +1,68 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	cursor := Cursor{Sort: "price_asc", Values: []string{"19.99"}, ID: "0b7e3b1c-2f4d-4a55-8c1e-0b0c5d7e9f10", Backward: true}
+
+	decoded, err := Decode(cursor.Encode(), "price_asc")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &cursor, decoded)
+	assert.True(t, decoded.IsKeyset())
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"not base64", "%%%"},
+		{"not json", "bm90LWpzb24"},
+		{"other sort", Cursor{Sort: "name_asc", ID: "x"}.Encode()},
+		{"negative offset", Cursor{Sort: "price_asc", Offset: -5}.Encode()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := Decode(tt.token, "price_asc")
+
+			assert.Nil(t, cursor)
+			assert.Equal(t, ErrInvalidCursor, err)
+		})
+	}
+}
+
+func TestTrim_Forward(t *testing.T) {
+	items, page := Trim([]int{1, 2, 3}, 2, nil)
+
+	assert.Equal(t, []int{1, 2}, items)
+	assert.True(t, page.HasNext)
+	assert.False(t, page.HasPrev)
+
+	// остання сторінка після курсора
+	items, page = Trim([]int{3}, 2, &Cursor{ID: "x"})
+
+	assert.Equal(t, []int{3}, items)
+	assert.False(t, page.HasNext)
+	assert.True(t, page.HasPrev)
+}
+
+func TestTrim_Backward(t *testing.T) {
+	// зайвий запис при русі назад стоїть на початку
+	items, page := Trim([]int{1, 2, 3}, 2, &Cursor{ID: "x", Backward: true})
+
+	assert.Equal(t, []int{2, 3}, items)
+	assert.True(t, page.HasNext)
+	assert.True(t, page.HasPrev)
+
+	// перша сторінка
+	items, page = Trim([]int{1, 2}, 2, &Cursor{ID: "x", Backward: true})
+
+	assert.Equal(t, []int{1, 2}, items)
+	assert.True(t, page.HasNext)
+	assert.False(t, page.HasPrev)
+}
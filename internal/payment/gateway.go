@@ -0,0 +1,28 @@
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrDeclined платіж відхилено провайдером (недостатньо коштів, картка заблокована тощо)
+var ErrDeclined = errors.New("payment declined")
+
+// Charge списання коштів за замовлення збереженим платіжним засобом
+type Charge struct {
+	OrderID uuid.UUID `json:"order_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Amount  float64   `json:"amount"`
+	// Reference токен збереженої картки у провайдера
+	Reference string `json:"reference"`
+	// IdempotencyKey ключ, за яким провайдер не списує кошти вдруге при повторі того ж платежу
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// Gateway списує кошти (лог, вебхук платіжного провайдера тощо)
+type Gateway interface {
+	// Charge повертає ID транзакції; ErrDeclined, якщо провайдер відхилив платіж
+	Charge(ctx context.Context, c Charge) (string, error)
+}
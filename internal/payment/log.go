@@ -0,0 +1,26 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+type logGateway struct{}
+
+// NewLogGateway створює Gateway, який лише пише платіж у лог і підтверджує його (для розробки)
+func NewLogGateway() Gateway {
+	return logGateway{}
+}
+
+// Charge пише платіж у лог
+func (logGateway) Charge(ctx context.Context, c Charge) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("payment: %s", data)
+	return uuid.NewString(), nil
+}
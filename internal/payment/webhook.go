@@ -0,0 +1,66 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// таймаут запиту до платіжного провайдера
+const webhookTimeout = 10 * time.Second
+
+type webhookGateway struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookGateway створює Gateway, який відправляє платіж POST запитом з JSON тілом.
+// Провайдер відповідає 2xx з {"transaction_id": "..."} або 402, якщо платіж відхилено
+func NewWebhookGateway(url string) Gateway {
+	return &webhookGateway{url: url,
+		client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// Charge відправляє платіж провайдеру
+func (w *webhookGateway) Charge(ctx context.Context, c Charge) (string, error) {
+	body, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal charge: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create payment request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// повторна спроба того ж платежу не списує кошти двічі
+	key := c.IdempotencyKey
+	if key == "" {
+		key = c.OrderID.String()
+	}
+	req.Header.Set("Idempotency-Key", key)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPaymentRequired {
+		return "", ErrDeclined
+	}
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("payment gateway returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		TransactionID string `json:"transaction_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode payment response: %w", err)
+	}
+	return result.TransactionID, nil
+}
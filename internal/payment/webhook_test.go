@@ -0,0 +1,71 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookGateway_Success(t *testing.T) {
+	var received Charge
+	orderID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, orderID.String(), r.Header.Get("Idempotency-Key"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Write([]byte(`{"transaction_id":"tx-1"}`))
+	}))
+	defer server.Close()
+
+	transactionID, err := NewWebhookGateway(server.URL).Charge(context.Background(), Charge{
+		OrderID:   orderID,
+		UserID:    uuid.New(),
+		Amount:    19.99,
+		Reference: "card_123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tx-1", transactionID)
+	assert.Equal(t, 19.99, received.Amount)
+	assert.Equal(t, "card_123", received.Reference)
+}
+
+func TestWebhookGateway_IdempotencyKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "sub-order-1", r.Header.Get("Idempotency-Key"))
+		w.Write([]byte(`{"transaction_id":"tx-1"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewWebhookGateway(server.URL).Charge(context.Background(), Charge{OrderID: uuid.New(), IdempotencyKey: "sub-order-1"})
+
+	assert.NoError(t, err)
+}
+
+func TestWebhookGateway_Declined(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	_, err := NewWebhookGateway(server.URL).Charge(context.Background(), Charge{OrderID: uuid.New()})
+
+	assert.Equal(t, ErrDeclined, err)
+}
+
+func TestWebhookGateway_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := NewWebhookGateway(server.URL).Charge(context.Background(), Charge{OrderID: uuid.New()})
+
+	assert.Error(t, err)
+	assert.NotEqual(t, ErrDeclined, err)
+}
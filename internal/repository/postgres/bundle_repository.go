@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// BundleRepository інтерфейс для роботи зі складом комплектів
+type BundleRepository interface {
+	ListComponents(ctx context.Context, bundleIDs []uuid.UUID) ([]*models.BundleComponent, error)
+	IsComponent(ctx context.Context, productID uuid.UUID) (bool, error)
+	SetComponents(ctx context.Context, bundleID uuid.UUID, components []*models.BundleComponent) error
+	Delete(ctx context.Context, bundleID uuid.UUID) error
+}
+
+type bundleRepo struct {
+	db *database.DB
+}
+
+func NewBundleRepository(db *database.DB) BundleRepository {
+	return &bundleRepo{db: db}
+}
+
+// ListComponents повертає компоненти комплектів з даними продуктів-компонентів
+func (r *bundleRepo) ListComponents(ctx context.Context, bundleIDs []uuid.UUID) ([]*models.BundleComponent, error) {
+	if len(bundleIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+	SELECT bi.bundle_id, bi.component_id, bi.quantity,
+		p.name, p.slug, p.sku, p.price, p.stock, p.deleted_at
+	FROM product_bundle_items bi
+	JOIN products p ON p.id = bi.component_id
+	WHERE bi.bundle_id = ANY($1::uuid[])
+	ORDER BY bi.bundle_id, p.name, p.id
+	`
+
+	var components []*models.BundleComponent
+	if err := r.db.SelectContext(ctx, &components, query, pq.Array(uuidStrings(bundleIDs))); err != nil {
+		return nil, fmt.Errorf("failed to list bundle components: %w", err)
+	}
+	return components, nil
+}
+
+// IsComponent перевіряє, чи входить продукт до складу будь-якого комплекту
+func (r *bundleRepo) IsComponent(ctx context.Context, productID uuid.UUID) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM product_bundle_items WHERE component_id = $1)`
+	if err := r.db.GetContext(ctx, &exists, query, productID); err != nil {
+		return false, fmt.Errorf("failed to check bundle component: %w", err)
+	}
+	return exists, nil
+}
+
+// SetComponents замінює склад комплекту та позначає продукт як комплект;
+// залишок комплекту перераховується в тій самій транзакції.
+// Якщо продукт не знайдено, повертає sql.ErrNoRows
+func (r *bundleRepo) SetComponents(ctx context.Context, bundleID uuid.UUID, components []*models.BundleComponent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// позначка комплекту (з блокуванням рядка до кінця транзакції)
+	var id uuid.UUID
+	err = tx.QueryRowxContext(ctx, `
+	UPDATE products SET is_bundle = TRUE, updated_at = NOW()
+	WHERE id = $1 AND deleted_at IS NULL
+	RETURNING id
+	`, bundleID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to mark bundle: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_bundle_items WHERE bundle_id = $1`, bundleID); err != nil {
+		return fmt.Errorf("failed to clear bundle components: %w", err)
+	}
+
+	itemQuery := `
+	INSERT INTO product_bundle_items (bundle_id, component_id, quantity)
+	VALUES ($1, $2, $3)
+	`
+	for _, component := range components {
+		if _, err := tx.ExecContext(ctx, itemQuery, bundleID, component.ComponentID, component.Quantity); err != nil {
+			return fmt.Errorf("failed to add bundle component: %w", err)
+		}
+	}
+
+	if err := syncBundleStock(ctx, tx, bundleID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit bundle components: %w", err)
+	}
+	return nil
+}
+
+// Delete перетворює комплект на звичайний продукт: склад видаляється,
+// а залишок знову рахується по складах. Якщо продукт не є комплектом, повертає sql.ErrNoRows
+func (r *bundleRepo) Delete(ctx context.Context, bundleID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+	UPDATE products SET is_bundle = FALSE, updated_at = NOW()
+	WHERE id = $1 AND is_bundle
+	`, bundleID)
+	if err != nil {
+		return fmt.Errorf("failed to unmark bundle: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_bundle_items WHERE bundle_id = $1`, bundleID); err != nil {
+		return fmt.Errorf("failed to delete bundle components: %w", err)
+	}
+	if err := syncProductStock(ctx, tx, bundleID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit bundle delete: %w", err)
+	}
+	return nil
+}
@@ -33,8 +33,8 @@ func NewCartRepository(db *database.DB) CartRepository {
 // AddItem додавання товарів у кошик
 func (c *CartRepo) AddItem(ctx context.Context, item *models.CartItem) error {
 	query := `
-	INSERT INTO cart_items (id, user_id, product_id, quantity, created_at)
-	VALUES ($1, $2, $3, $4, NOW())
+	INSERT INTO cart_items (id, user_id, product_id, quantity, price_at_add, created_at)
+	VALUES ($1, $2, $3, $4, $5, NOW())
 	`
 
 	// додавання нового товару
@@ -43,6 +43,7 @@ func (c *CartRepo) AddItem(ctx context.Context, item *models.CartItem) error {
 		item.UserID,
 		item.ProductID,
 		item.Quantity,
+		item.PriceAtAdd,
 	)
 	// обробка помилок
 	if err != nil {
@@ -78,10 +79,15 @@ func (c *CartRepo) GetByUserId(ctx context.Context, userID uuid.UUID) ([]*models
 		ci.user_id,
 		ci.product_id,
 		ci.quantity,
+		ci.price_at_add,
 		ci.created_at,
 		p.name AS product_name,
-		p.price AS product_price,
-		p.stock AS product_stock
+		` + effectivePriceSQL + ` AS product_price,
+		p.stock AS product_stock,
+		p.status AS product_status,
+		p.deleted_at AS product_deleted_at,
+		p.is_digital,
+		p.backorder_policy
 	FROM cart_items ci
 	JOIN products p ON ci.product_id = p.id
 	WHERE ci.user_id = $1
@@ -100,7 +106,7 @@ func (c *CartRepo) GetByUserId(ctx context.Context, userID uuid.UUID) ([]*models
 func (c *CartRepo) GetItemByID(ctx context.Context, userID, itemID uuid.UUID) (*models.CartItem, error) {
 	var item models.CartItem
 	query := `
-	SELECT id, user_id, product_id, quantity, created_at
+	SELECT id, user_id, product_id, quantity, price_at_add, created_at
 	FROM cart_items
 	WHERE id = $1 AND user_id = $2
 	`
@@ -120,7 +126,7 @@ func (c *CartRepo) GetItemByID(ctx context.Context, userID, itemID uuid.UUID) (*
 func (c *CartRepo) GetItem(ctx context.Context, userId uuid.UUID, productId uuid.UUID) (*models.CartItem, error) {
 	var item models.CartItem
 	query := `
-	SELECT id, user_id, product_id, quantity, created_at
+	SELECT id, user_id, product_id, quantity, price_at_add, created_at
 	FROM cart_items
 	WHERE user_id = $1 AND product_id = $2
 	`
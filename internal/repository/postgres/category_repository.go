@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// CategoryRepository інтерфейс для роботи з категоріями
+type CategoryRepository interface {
+	Create(ctx context.Context, category *models.Category) error
+	List(ctx context.Context) ([]*models.Category, error)
+	GetById(ctx context.Context, id uuid.UUID) (*models.Category, error)
+	GetByName(ctx context.Context, name string) (*models.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Category, error)
+	SlugTaken(ctx context.Context, slug string) (bool, error)
+}
+
+type categoryRepo struct {
+	db *database.DB
+}
+
+func NewCategoryRepository(db *database.DB) CategoryRepository {
+	return &categoryRepo{db: db}
+}
+
+// Create створює категорію
+func (c *categoryRepo) Create(ctx context.Context, category *models.Category) error {
+	query := `
+	INSERT INTO categories (id, name, slug, description, created_at)
+	VALUES ($1, $2, $3, $4, NOW())
+	RETURNING created_at
+	`
+
+	category.ID = uuid.New()
+	err := c.db.QueryRowxContext(ctx, query,
+		category.ID,
+		category.Name,
+		category.Slug,
+		category.Description,
+	).Scan(&category.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+	return nil
+}
+
+// List повертає всі категорії за назвою
+func (c *categoryRepo) List(ctx context.Context) ([]*models.Category, error) {
+	query := `
+	SELECT id, name, slug, description, created_at
+	FROM categories
+	ORDER BY name
+	`
+
+	var categories []*models.Category
+	if err := c.db.SelectContext(ctx, &categories, query); err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	return categories, nil
+}
+
+// GetById повертає категорію за ID (nil, якщо не знайдено)
+func (c *categoryRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	return c.getOne(ctx, "id", id.String())
+}
+
+// GetByName повертає категорію за назвою (nil, якщо не знайдено)
+func (c *categoryRepo) GetByName(ctx context.Context, name string) (*models.Category, error) {
+	return c.getOne(ctx, "name", name)
+}
+
+// GetBySlug повертає категорію за slug (nil, якщо не знайдено)
+func (c *categoryRepo) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	return c.getOne(ctx, "slug", slug)
+}
+
+// SlugTaken перевіряє, чи використовується slug
+func (c *categoryRepo) SlugTaken(ctx context.Context, slug string) (bool, error) {
+	var taken bool
+	err := c.db.QueryRowxContext(ctx, `SELECT EXISTS (SELECT 1 FROM categories WHERE slug = $1)`, slug).Scan(&taken)
+	if err != nil {
+		return false, fmt.Errorf("failed to check category slug: %w", err)
+	}
+	return taken, nil
+}
+
+// getOne повертає категорію за значенням унікальної колонки
+func (c *categoryRepo) getOne(ctx context.Context, column, value string) (*models.Category, error) {
+	query := `
+	SELECT id, name, slug, description, created_at
+	FROM categories
+	WHERE ` + column + ` = $1
+	`
+
+	var category models.Category
+	if err := c.db.GetContext(ctx, &category, query, value); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return &category, nil
+}
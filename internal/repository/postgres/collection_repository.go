@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// CollectionRepository інтерфейс для роботи з колекціями товарів
+type CollectionRepository interface {
+	Create(ctx context.Context, collection *models.Collection) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Collection, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Collection, error)
+	List(ctx context.Context, publishedOnly bool) ([]*models.Collection, error)
+	Update(ctx context.Context, collection *models.Collection) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error)
+	SetProducts(ctx context.Context, collectionID uuid.UUID, productIDs []uuid.UUID) error
+	ListProductIDs(ctx context.Context, collectionID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type collectionRepo struct {
+	db *database.DB
+}
+
+func NewCollectionRepository(db *database.DB) CollectionRepository {
+	return &collectionRepo{db: db}
+}
+
+// Create створює колекцію
+func (c *collectionRepo) Create(ctx context.Context, collection *models.Collection) error {
+	query := `
+	INSERT INTO collections (id, slug, title, description, type, rules, is_published, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+	RETURNING created_at, updated_at
+	`
+
+	collection.ID = uuid.New()
+	err := c.db.QueryRowxContext(ctx, query,
+		collection.ID,
+		collection.Slug,
+		collection.Title,
+		collection.Description,
+		collection.Type,
+		collection.Rules,
+		collection.IsPublished,
+	).Scan(&collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+	return nil
+}
+
+// GetByID повертає колекцію за ID (nil, якщо не знайдено)
+func (c *collectionRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Collection, error) {
+	query := `
+	SELECT id, slug, title, description, type, rules, is_published, created_at, updated_at
+	FROM collections
+	WHERE id = $1
+	`
+
+	var collection models.Collection
+	if err := c.db.GetContext(ctx, &collection, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return &collection, nil
+}
+
+// GetBySlug повертає колекцію за slug (nil, якщо не знайдено)
+func (c *collectionRepo) GetBySlug(ctx context.Context, slug string) (*models.Collection, error) {
+	query := `
+	SELECT id, slug, title, description, type, rules, is_published, created_at, updated_at
+	FROM collections
+	WHERE slug = $1
+	`
+
+	var collection models.Collection
+	if err := c.db.GetContext(ctx, &collection, query, slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return &collection, nil
+}
+
+// List повертає колекції за назвою
+func (c *collectionRepo) List(ctx context.Context, publishedOnly bool) ([]*models.Collection, error) {
+	query := `
+	SELECT id, slug, title, description, type, rules, is_published, created_at, updated_at
+	FROM collections
+	`
+	if publishedOnly {
+		query += " WHERE is_published"
+	}
+	query += " ORDER BY title"
+
+	var collections []*models.Collection
+	if err := c.db.SelectContext(ctx, &collections, query); err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	return collections, nil
+}
+
+// Update оновлює колекцію
+func (c *collectionRepo) Update(ctx context.Context, collection *models.Collection) error {
+	query := `
+	UPDATE collections
+	SET slug = $1,
+		title = $2,
+		description = $3,
+		rules = $4,
+		is_published = $5,
+		updated_at = NOW()
+	WHERE id = $6
+	RETURNING updated_at
+	`
+
+	err := c.db.QueryRowxContext(ctx, query,
+		collection.Slug,
+		collection.Title,
+		collection.Description,
+		collection.Rules,
+		collection.IsPublished,
+		collection.ID,
+	).Scan(&collection.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+	return nil
+}
+
+// Delete видаляє колекцію
+func (c *collectionRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	res, err := c.db.ExecContext(ctx, `DELETE FROM collections WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SlugTaken перевіряє, чи використовується slug іншою колекцією
+func (c *collectionRepo) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	var taken bool
+	err := c.db.QueryRowxContext(ctx, `SELECT EXISTS (SELECT 1 FROM collections WHERE slug = $1 AND id <> $2)`, slug, excludeID).Scan(&taken)
+	if err != nil {
+		return false, fmt.Errorf("failed to check collection slug: %w", err)
+	}
+	return taken, nil
+}
+
+// SetProducts замінює список продуктів ручної колекції; порядок визначається порядком productIDs
+func (c *collectionRepo) SetProducts(ctx context.Context, collectionID uuid.UUID, productIDs []uuid.UUID) error {
+	tx, err := c.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM collection_products WHERE collection_id = $1`, collectionID); err != nil {
+		return fmt.Errorf("failed to clear collection products: %w", err)
+	}
+
+	for i, productID := range productIDs {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO collection_products (collection_id, product_id, position) VALUES ($1, $2, $3)`,
+			collectionID, productID, i,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add collection product: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE collections SET updated_at = NOW() WHERE id = $1`, collectionID); err != nil {
+		return fmt.Errorf("failed to touch collection: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit collection products: %w", err)
+	}
+	return nil
+}
+
+// ListProductIDs повертає ID продуктів ручної колекції у порядку колекції
+func (c *collectionRepo) ListProductIDs(ctx context.Context, collectionID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+	SELECT product_id
+	FROM collection_products
+	WHERE collection_id = $1
+	ORDER BY position
+	`
+
+	var ids []uuid.UUID
+	if err := c.db.SelectContext(ctx, &ids, query, collectionID); err != nil {
+		return nil, fmt.Errorf("failed to list collection products: %w", err)
+	}
+	return ids, nil
+}
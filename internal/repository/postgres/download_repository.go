@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DownloadRepository інтерфейс для роботи з файлами цифрових товарів та правами на завантаження
+type DownloadRepository interface {
+	GetFile(ctx context.Context, productID uuid.UUID) (*models.ProductFile, error)
+	UpsertFile(ctx context.Context, file *models.ProductFile) error
+	CreateGrants(ctx context.Context, orderID uuid.UUID, expiresAt time.Time, maxDownloads int) error
+	ListGrants(ctx context.Context, orderID uuid.UUID) ([]*models.DownloadGrant, error)
+	GetGrant(ctx context.Context, id uuid.UUID) (*models.DownloadGrant, error)
+	UseGrant(ctx context.Context, id uuid.UUID) error
+}
+
+type downloadRepo struct {
+	db *database.DB
+}
+
+func NewDownloadRepository(db *database.DB) DownloadRepository {
+	return &downloadRepo{db: db}
+}
+
+// вибірка права на завантаження разом з назвою продукту, статусом замовлення та файлом
+const grantSelect = `
+	SELECT g.id, g.order_id, g.order_item_id, g.product_id, p.name AS product_name,
+		g.max_downloads, g.download_count, g.expires_at, g.last_downloaded_at, g.created_at,
+		o.status AS order_status, f.file_name, f.content_type, f.storage_key
+	FROM download_grants g
+	JOIN products p ON p.id = g.product_id
+	JOIN orders o ON o.id = g.order_id
+	LEFT JOIN product_files f ON f.product_id = g.product_id
+	`
+
+// GetFile повертає файл цифрового товару (nil, якщо не знайдено)
+func (d *downloadRepo) GetFile(ctx context.Context, productID uuid.UUID) (*models.ProductFile, error) {
+	query := `
+	SELECT product_id, storage_key, file_name, content_type, size_bytes, created_at, updated_at
+	FROM product_files
+	WHERE product_id = $1
+	`
+
+	var file models.ProductFile
+	if err := d.db.GetContext(ctx, &file, query, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get product file: %w", err)
+	}
+	return &file, nil
+}
+
+// UpsertFile зберігає файл цифрового товару, замінюючи попередній
+func (d *downloadRepo) UpsertFile(ctx context.Context, file *models.ProductFile) error {
+	query := `
+	INSERT INTO product_files (product_id, storage_key, file_name, content_type, size_bytes, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+	ON CONFLICT (product_id) DO UPDATE
+	SET storage_key = EXCLUDED.storage_key,
+		file_name = EXCLUDED.file_name,
+		content_type = EXCLUDED.content_type,
+		size_bytes = EXCLUDED.size_bytes,
+		updated_at = NOW()
+	RETURNING created_at, updated_at
+	`
+
+	err := d.db.QueryRowxContext(ctx, query,
+		file.ProductID,
+		file.StorageKey,
+		file.FileName,
+		file.ContentType,
+		file.SizeBytes,
+	).Scan(&file.CreatedAt, &file.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save product file: %w", err)
+	}
+	return nil
+}
+
+// CreateGrants створює права на завантаження для цифрових позицій замовлення.
+// Повторний виклик не змінює вже створені права
+func (d *downloadRepo) CreateGrants(ctx context.Context, orderID uuid.UUID, expiresAt time.Time, maxDownloads int) error {
+	query := `
+	INSERT INTO download_grants (id, order_id, order_item_id, product_id, max_downloads, expires_at, created_at)
+	SELECT gen_random_uuid(), oi.order_id, oi.id, oi.product_id, $2, $3, NOW()
+	FROM order_items oi
+	JOIN products p ON p.id = oi.product_id
+	WHERE oi.order_id = $1 AND p.is_digital
+	ON CONFLICT (order_item_id) DO NOTHING
+	`
+
+	if _, err := d.db.ExecContext(ctx, query, orderID, maxDownloads, expiresAt); err != nil {
+		return fmt.Errorf("failed to create download grants: %w", err)
+	}
+	return nil
+}
+
+// ListGrants повертає права на завантаження замовлення
+func (d *downloadRepo) ListGrants(ctx context.Context, orderID uuid.UUID) ([]*models.DownloadGrant, error) {
+	query := grantSelect + `
+	WHERE g.order_id = $1
+	ORDER BY g.created_at, p.name
+	`
+
+	var grants []*models.DownloadGrant
+	if err := d.db.SelectContext(ctx, &grants, query, orderID); err != nil {
+		return nil, fmt.Errorf("failed to list download grants: %w", err)
+	}
+	return grants, nil
+}
+
+// GetGrant повертає право на завантаження (nil, якщо не знайдено)
+func (d *downloadRepo) GetGrant(ctx context.Context, id uuid.UUID) (*models.DownloadGrant, error) {
+	query := grantSelect + `
+	WHERE g.id = $1
+	`
+
+	var grant models.DownloadGrant
+	if err := d.db.GetContext(ctx, &grant, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get download grant: %w", err)
+	}
+	return &grant, nil
+}
+
+// UseGrant зараховує одне завантаження.
+// Якщо термін дії минув або ліміт завантажень вичерпано, повертає sql.ErrNoRows
+func (d *downloadRepo) UseGrant(ctx context.Context, id uuid.UUID) error {
+	query := `
+	UPDATE download_grants
+	SET download_count = download_count + 1,
+		last_downloaded_at = NOW()
+	WHERE id = $1 AND download_count < max_downloads AND expires_at > NOW()
+		AND EXISTS (SELECT 1 FROM orders o WHERE o.id = download_grants.order_id AND o.status <> 'cancelled')
+	`
+
+	res, err := d.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to use download grant: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// GuestCartRepository інтерфейс для роботи з кошиками гостей
+type GuestCartRepository interface {
+	Create(ctx context.Context, cart *models.GuestCart) error
+	// Get повертає кошик, якщо він ще не прострочений
+	Get(ctx context.Context, id uuid.UUID) (*models.GuestCart, error)
+	Touch(ctx context.Context, id uuid.UUID, expiresAt time.Time) error
+	AddItem(ctx context.Context, cartID uuid.UUID, item *models.CartItem) error
+	GetItems(ctx context.Context, cartID uuid.UUID) ([]*models.CartItemWithProduct, error)
+	GetItem(ctx context.Context, cartID, productID uuid.UUID) (*models.CartItem, error)
+	GetItemByID(ctx context.Context, cartID, itemID uuid.UUID) (*models.CartItem, error)
+	UpdateQuantity(ctx context.Context, cartID, id uuid.UUID, quantity int) error
+	RemoveItem(ctx context.Context, cartID, id uuid.UUID) error
+	Clear(ctx context.Context, cartID uuid.UUID) error
+	// Merge переносить товари кошика гостя в кошик користувача та видаляє кошик гостя;
+	// повертає кількість перенесених позицій
+	Merge(ctx context.Context, cartID, userID uuid.UUID) (int, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+type guestCartRepo struct {
+	db *database.DB
+}
+
+func NewGuestCartRepository(db *database.DB) GuestCartRepository {
+	return &guestCartRepo{db: db}
+}
+
+// Create створює кошик гостя
+func (g *guestCartRepo) Create(ctx context.Context, cart *models.GuestCart) error {
+	query := `
+	INSERT INTO guest_carts (id, expires_at, created_at, updated_at)
+	VALUES ($1, $2, NOW(), NOW())
+	RETURNING created_at, updated_at
+	`
+
+	err := g.db.QueryRowxContext(ctx, query, cart.ID, cart.ExpiresAt).Scan(&cart.CreatedAt, &cart.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create guest cart: %w", err)
+	}
+	return nil
+}
+
+// Get повертає непрострочений кошик гостя
+func (g *guestCartRepo) Get(ctx context.Context, id uuid.UUID) (*models.GuestCart, error) {
+	var cart models.GuestCart
+	query := `
+	SELECT id, created_at, updated_at, expires_at
+	FROM guest_carts
+	WHERE id = $1 AND expires_at > NOW()
+	`
+
+	err := g.db.GetContext(ctx, &cart, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get guest cart: %w", err)
+	}
+	return &cart, nil
+}
+
+// Touch продовжує термін дії кошика
+func (g *guestCartRepo) Touch(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	query := `
+	UPDATE guest_carts
+	SET expires_at = $2, updated_at = NOW()
+	WHERE id = $1
+	`
+
+	res, err := g.db.ExecContext(ctx, query, id, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to touch guest cart: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// AddItem додавання товару в кошик гостя
+func (g *guestCartRepo) AddItem(ctx context.Context, cartID uuid.UUID, item *models.CartItem) error {
+	query := `
+	INSERT INTO guest_cart_items (id, cart_id, product_id, quantity, price_at_add, created_at)
+	VALUES ($1, $2, $3, $4, $5, NOW())
+	`
+
+	_, err := g.db.ExecContext(ctx, query, item.ID, cartID, item.ProductID, item.Quantity, item.PriceAtAdd)
+	if err != nil {
+		return fmt.Errorf("failed to add guest cart item: %w", err)
+	}
+	return nil
+}
+
+// GetItems повертає товари кошика гостя з поточними даними продуктів
+func (g *guestCartRepo) GetItems(ctx context.Context, cartID uuid.UUID) ([]*models.CartItemWithProduct, error) {
+	var items []*models.CartItemWithProduct
+
+	query := `
+	SELECT
+		gi.id,
+		gi.product_id,
+		gi.quantity,
+		gi.price_at_add,
+		gi.created_at,
+		p.name AS product_name,
+		` + effectivePriceSQL + ` AS product_price,
+		p.stock AS product_stock,
+		p.status AS product_status,
+		p.deleted_at AS product_deleted_at,
+		p.is_digital,
+		p.backorder_policy
+	FROM guest_cart_items gi
+	JOIN products p ON gi.product_id = p.id
+	WHERE gi.cart_id = $1
+	ORDER BY gi.created_at
+	`
+
+	err := g.db.SelectContext(ctx, &items, query, cartID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guest cart items: %w", err)
+	}
+	return items, nil
+}
+
+// GetItem повертає товар кошика гостя за ID продукту
+func (g *guestCartRepo) GetItem(ctx context.Context, cartID, productID uuid.UUID) (*models.CartItem, error) {
+	var item models.CartItem
+	query := `
+	SELECT id, product_id, quantity, price_at_add, created_at
+	FROM guest_cart_items
+	WHERE cart_id = $1 AND product_id = $2
+	`
+
+	err := g.db.GetContext(ctx, &item, query, cartID, productID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get guest cart item: %w", err)
+	}
+	return &item, nil
+}
+
+// GetItemByID повертає товар кошика гостя за його ID
+func (g *guestCartRepo) GetItemByID(ctx context.Context, cartID, itemID uuid.UUID) (*models.CartItem, error) {
+	var item models.CartItem
+	query := `
+	SELECT id, product_id, quantity, price_at_add, created_at
+	FROM guest_cart_items
+	WHERE id = $1 AND cart_id = $2
+	`
+
+	err := g.db.GetContext(ctx, &item, query, itemID, cartID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get guest cart item by id: %w", err)
+	}
+	return &item, nil
+}
+
+// UpdateQuantity оновлення кількості товару в кошику гостя
+func (g *guestCartRepo) UpdateQuantity(ctx context.Context, cartID, id uuid.UUID, quantity int) error {
+	query := `
+	UPDATE guest_cart_items
+	SET quantity = $1
+	WHERE id = $2 AND cart_id = $3
+	`
+
+	res, err := g.db.ExecContext(ctx, query, quantity, id, cartID)
+	if err != nil {
+		return fmt.Errorf("failed to update guest cart quantity: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// RemoveItem видалення товару з кошика гостя
+func (g *guestCartRepo) RemoveItem(ctx context.Context, cartID, id uuid.UUID) error {
+	query := `
+	DELETE FROM guest_cart_items
+	WHERE id = $1 AND cart_id = $2
+	`
+
+	res, err := g.db.ExecContext(ctx, query, id, cartID)
+	if err != nil {
+		return fmt.Errorf("failed to remove guest cart item: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Clear очищення кошика гостя
+func (g *guestCartRepo) Clear(ctx context.Context, cartID uuid.UUID) error {
+	_, err := g.db.ExecContext(ctx, `DELETE FROM guest_cart_items WHERE cart_id = $1`, cartID)
+	if err != nil {
+		return fmt.Errorf("failed to clear guest cart: %w", err)
+	}
+	return nil
+}
+
+// Merge додає товари кошика гостя до кошика користувача в одній транзакції.
+// Кількості однакових товарів сумуються, але не перевищують залишок (крім цифрових товарів
+// та товарів під замовлення); кількість, яка вже була в кошику користувача, не зменшується.
+// Товари, яких немає в наявності, не переносяться
+func (g *guestCartRepo) Merge(ctx context.Context, cartID, userID uuid.UUID) (int, error) {
+	tx, err := g.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// блокування кошика гостя від паралельного злиття; прострочений кошик не зливається
+	var locked uuid.UUID
+	err = tx.GetContext(ctx, &locked, `SELECT id FROM guest_carts WHERE id = $1 AND expires_at > NOW() FOR UPDATE`, cartID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to lock guest cart: %w", err)
+	}
+
+	guestItems := `
+	WITH guest AS (
+		SELECT gi.product_id, gi.quantity, gi.price_at_add, p.stock,
+			(p.is_digital OR p.backorder_policy <> 'deny') AS unlimited
+		FROM guest_cart_items gi
+		JOIN products p ON p.id = gi.product_id
+		WHERE gi.cart_id = $1 AND p.deleted_at IS NULL AND p.status = 'published'
+	)
+	`
+
+	// товари, які вже є в кошику користувача
+	res, err := tx.ExecContext(ctx, guestItems+`
+	UPDATE cart_items ci
+	SET quantity = CASE
+		WHEN g.unlimited THEN ci.quantity + g.quantity
+		ELSE GREATEST(ci.quantity, LEAST(ci.quantity + g.quantity, g.stock))
+	END
+	FROM guest g
+	WHERE ci.user_id = $2 AND ci.product_id = g.product_id
+	`, cartID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to merge cart items: %w", err)
+	}
+	updated, _ := res.RowsAffected()
+
+	// нові товари
+	res, err = tx.ExecContext(ctx, guestItems+`
+	INSERT INTO cart_items (id, user_id, product_id, quantity, price_at_add, created_at)
+	SELECT gen_random_uuid(), $2, g.product_id,
+		CASE WHEN g.unlimited THEN g.quantity ELSE LEAST(g.quantity, g.stock) END,
+		g.price_at_add, NOW()
+	FROM guest g
+	WHERE (g.unlimited OR g.stock > 0)
+		AND NOT EXISTS (SELECT 1 FROM cart_items ci WHERE ci.user_id = $2 AND ci.product_id = g.product_id)
+	`, cartID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert merged cart items: %w", err)
+	}
+	inserted, _ := res.RowsAffected()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM guest_carts WHERE id = $1`, cartID); err != nil {
+		return 0, fmt.Errorf("failed to delete guest cart: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit cart merge: %w", err)
+	}
+	return int(updated + inserted), nil
+}
+
+// DeleteExpired видаляє прострочені кошики гостей разом з товарами
+func (g *guestCartRepo) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	res, err := g.db.ExecContext(ctx, `DELETE FROM guest_carts WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired guest carts: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	return int(rows), nil
+}
@@ -0,0 +1,789 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// InventoryRepository інтерфейс для роботи зі складами та залишками
+type InventoryRepository interface {
+	CreateLocation(ctx context.Context, location *models.StockLocation) error
+	GetLocation(ctx context.Context, id uuid.UUID) (*models.StockLocation, error)
+	GetLocationByCode(ctx context.Context, code string) (*models.StockLocation, error)
+	ListLocations(ctx context.Context) ([]*models.StockLocation, error)
+	UpdateLocation(ctx context.Context, location *models.StockLocation) error
+	ListProductStock(ctx context.Context, productID uuid.UUID) ([]*models.LocationStock, error)
+	ListStockLevels(ctx context.Context, productIDs []uuid.UUID) ([]*models.LocationStock, error)
+	SetStock(ctx context.Context, locationID, productID uuid.UUID, quantity int, src models.MovementSource) error
+	AdjustStock(ctx context.Context, locationID, productID uuid.UUID, delta int, src models.MovementSource) error
+	Transfer(ctx context.Context, productID, fromID, toID uuid.UUID, quantity int, note *string) (uuid.UUID, error)
+	ListOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*models.StockAllocation, error)
+	ListMovements(ctx context.Context, filter models.StockMovementFilter) ([]*models.StockMovement, error)
+	Reconcile(ctx context.Context, productID uuid.UUID) ([]*models.StockReconciliation, error)
+	GetReorderSettings(ctx context.Context, productID uuid.UUID) (*models.ReorderSettings, error)
+	UpdateReorderSettings(ctx context.Context, settings *models.ReorderSettings) error
+	ListAlerts(ctx context.Context, filter models.LowStockAlertFilter) ([]*models.LowStockAlert, error)
+	ListUndeliveredAlerts(ctx context.Context, limit int) ([]*models.LowStockAlert, error)
+	MarkAlertsNotified(ctx context.Context, ids []uuid.UUID) error
+	ListReorderNeeded(ctx context.Context) ([]*models.ReorderItem, error)
+}
+
+type inventoryRepo struct {
+	db *database.DB
+}
+
+func NewInventoryRepository(db *database.DB) InventoryRepository {
+	return &inventoryRepo{db: db}
+}
+
+// CreateLocation створює склад
+func (i *inventoryRepo) CreateLocation(ctx context.Context, location *models.StockLocation) error {
+	query := `
+	INSERT INTO stock_locations (id, code, name, country, is_default, is_active, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, FALSE, $5, NOW(), NOW())
+	RETURNING created_at, updated_at
+	`
+
+	location.ID = uuid.New()
+	err := i.db.QueryRowxContext(ctx, query,
+		location.ID,
+		location.Code,
+		location.Name,
+		location.Country,
+		location.IsActive,
+	).Scan(&location.CreatedAt, &location.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create stock location: %w", err)
+	}
+	return nil
+}
+
+// GetLocation повертає склад за ID (nil, якщо не знайдено)
+func (i *inventoryRepo) GetLocation(ctx context.Context, id uuid.UUID) (*models.StockLocation, error) {
+	query := `
+	SELECT id, code, name, country, is_default, is_active, created_at, updated_at
+	FROM stock_locations
+	WHERE id = $1
+	`
+
+	var location models.StockLocation
+	if err := i.db.GetContext(ctx, &location, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock location: %w", err)
+	}
+	return &location, nil
+}
+
+// GetLocationByCode повертає склад за кодом (nil, якщо не знайдено)
+func (i *inventoryRepo) GetLocationByCode(ctx context.Context, code string) (*models.StockLocation, error) {
+	query := `
+	SELECT id, code, name, country, is_default, is_active, created_at, updated_at
+	FROM stock_locations
+	WHERE code = $1
+	`
+
+	var location models.StockLocation
+	if err := i.db.GetContext(ctx, &location, query, code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock location: %w", err)
+	}
+	return &location, nil
+}
+
+// ListLocations повертає всі склади (склад за замовчуванням першим)
+func (i *inventoryRepo) ListLocations(ctx context.Context) ([]*models.StockLocation, error) {
+	query := `
+	SELECT id, code, name, country, is_default, is_active, created_at, updated_at
+	FROM stock_locations
+	ORDER BY is_default DESC, code
+	`
+
+	var locations []*models.StockLocation
+	if err := i.db.SelectContext(ctx, &locations, query); err != nil {
+		return nil, fmt.Errorf("failed to list stock locations: %w", err)
+	}
+	return locations, nil
+}
+
+// UpdateLocation оновлює склад. Зміна активності перераховує загальний залишок
+// товарів цього складу, бо неактивний склад не враховується в наявності
+func (i *inventoryRepo) UpdateLocation(ctx context.Context, location *models.StockLocation) error {
+	query := `
+	UPDATE stock_locations
+	SET name = $1,
+		country = $2,
+		is_active = $3,
+		updated_at = NOW()
+	WHERE id = $4
+	RETURNING updated_at
+	`
+
+	tx, err := i.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowxContext(ctx, query,
+		location.Name,
+		location.Country,
+		location.IsActive,
+		location.ID,
+	).Scan(&location.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to update stock location: %w", err)
+	}
+
+	var productIDs []uuid.UUID
+	if err := tx.SelectContext(ctx, &productIDs, `SELECT product_id FROM location_stock WHERE location_id = $1`, location.ID); err != nil {
+		return fmt.Errorf("failed to list location products: %w", err)
+	}
+	if err := syncProductStock(ctx, tx, productIDs...); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit stock location update: %w", err)
+	}
+	return nil
+}
+
+// ListProductStock повертає залишки продукту на всіх складах
+func (i *inventoryRepo) ListProductStock(ctx context.Context, productID uuid.UUID) ([]*models.LocationStock, error) {
+	query := `
+	SELECT l.id AS location_id, l.code AS location_code, l.country, l.is_active,
+		$1::uuid AS product_id, COALESCE(ls.quantity, 0) AS quantity, COALESCE(ls.updated_at, l.updated_at) AS updated_at
+	FROM stock_locations l
+	LEFT JOIN location_stock ls ON ls.location_id = l.id AND ls.product_id = $1
+	ORDER BY l.is_default DESC, l.code
+	`
+
+	var stock []*models.LocationStock
+	if err := i.db.SelectContext(ctx, &stock, query, productID); err != nil {
+		return nil, fmt.Errorf("failed to list product stock: %w", err)
+	}
+	return stock, nil
+}
+
+// ListStockLevels повертає ненульові залишки продуктів на активних складах (для розподілу замовлення)
+func (i *inventoryRepo) ListStockLevels(ctx context.Context, productIDs []uuid.UUID) ([]*models.LocationStock, error) {
+	query := `
+	SELECT l.id AS location_id, l.code AS location_code, l.country, l.is_active,
+		ls.product_id, ls.quantity, ls.updated_at
+	FROM location_stock ls
+	JOIN stock_locations l ON l.id = ls.location_id
+	WHERE ls.product_id = ANY($1::uuid[]) AND l.is_active AND ls.quantity > 0
+	`
+
+	var levels []*models.LocationStock
+	if err := i.db.SelectContext(ctx, &levels, query, pq.Array(uuidStrings(productIDs))); err != nil {
+		return nil, fmt.Errorf("failed to list stock levels: %w", err)
+	}
+	return levels, nil
+}
+
+// SetStock встановлює залишок продукту на складі (інвентаризація) та перераховує загальний залишок.
+// Різниця з попереднім залишком записується в журнал
+func (i *inventoryRepo) SetStock(ctx context.Context, locationID, productID uuid.UUID, quantity int, src models.MovementSource) error {
+	tx, err := i.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// поточний залишок з блокуванням рядка
+	var current int
+	err = tx.QueryRowxContext(ctx,
+		`SELECT quantity FROM location_stock WHERE location_id = $1 AND product_id = $2 FOR UPDATE`,
+		locationID, productID,
+	).Scan(&current)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get stock: %w", err)
+	}
+
+	switch delta := quantity - current; {
+	case delta > 0:
+		err = putStock(ctx, tx, locationID, productID, delta, src)
+	case delta < 0:
+		err = takeStock(ctx, tx, locationID, productID, -delta, src)
+	}
+	if err != nil {
+		return err
+	}
+	if err := syncProductStock(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit stock: %w", err)
+	}
+	return nil
+}
+
+// AdjustStock змінює залишок продукту на складі на delta.
+// Якщо залишок став би від'ємним, повертає sql.ErrNoRows
+func (i *inventoryRepo) AdjustStock(ctx context.Context, locationID, productID uuid.UUID, delta int, src models.MovementSource) error {
+	tx, err := i.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if delta > 0 {
+		err = putStock(ctx, tx, locationID, productID, delta, src)
+	} else {
+		err = takeStock(ctx, tx, locationID, productID, -delta, src)
+	}
+	if err != nil {
+		return err
+	}
+	if err := syncProductStock(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit stock adjustment: %w", err)
+	}
+	return nil
+}
+
+// Transfer переміщує товар між складами та повертає ID переміщення
+// (посилання в обох записах журналу). Якщо на складі-джерелі недостатньо товару, повертає sql.ErrNoRows
+func (i *inventoryRepo) Transfer(ctx context.Context, productID, fromID, toID uuid.UUID, quantity int, note *string) (uuid.UUID, error) {
+	tx, err := i.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	transferID := uuid.New()
+	out := models.MovementSource{Reason: models.MovementTransferOut, ReferenceID: &transferID, Note: note}
+	in := models.MovementSource{Reason: models.MovementTransferIn, ReferenceID: &transferID, Note: note}
+
+	if err := takeStock(ctx, tx, fromID, productID, quantity, out); err != nil {
+		return uuid.Nil, err
+	}
+	if err := putStock(ctx, tx, toID, productID, quantity, in); err != nil {
+		return uuid.Nil, err
+	}
+	if err := syncProductStock(ctx, tx, productID); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to commit stock transfer: %w", err)
+	}
+	return transferID, nil
+}
+
+// ListOrderAllocations повертає розподіл позицій замовлення по складах
+func (i *inventoryRepo) ListOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*models.StockAllocation, error) {
+	query := `
+	SELECT a.order_item_id, a.product_id, a.location_id, l.code AS location_code, a.quantity
+	FROM order_item_allocations a
+	JOIN order_items oi ON oi.id = a.order_item_id
+	JOIN stock_locations l ON l.id = a.location_id
+	WHERE oi.order_id = $1
+	ORDER BY oi.created_at, l.code
+	`
+
+	var allocations []*models.StockAllocation
+	if err := i.db.SelectContext(ctx, &allocations, query, orderID); err != nil {
+		return nil, fmt.Errorf("failed to list order allocations: %w", err)
+	}
+	return allocations, nil
+}
+
+// ListMovements повертає журнал руху продукту, новіші записи першими
+func (i *inventoryRepo) ListMovements(ctx context.Context, filter models.StockMovementFilter) ([]*models.StockMovement, error) {
+	query := `
+	SELECT m.id, m.product_id, m.location_id, l.code AS location_code, m.quantity_change, m.quantity_after,
+		m.reason, m.actor_id, m.reference_id, m.note, m.created_at
+	FROM inventory_movements m
+	JOIN stock_locations l ON l.id = m.location_id
+	WHERE m.product_id = $1
+	`
+	args := []interface{}{filter.ProductID}
+	argsCount := 2
+
+	if filter.LocationID != nil {
+		query += fmt.Sprintf(" AND m.location_id = $%d", argsCount)
+		args = append(args, *filter.LocationID)
+		argsCount++
+	}
+	if filter.Reason != "" {
+		query += fmt.Sprintf(" AND m.reason = $%d", argsCount)
+		args = append(args, filter.Reason)
+		argsCount++
+	}
+
+	query += fmt.Sprintf(" ORDER BY m.created_at DESC, m.id LIMIT $%d OFFSET $%d", argsCount, argsCount+1)
+	args = append(args, filter.Limit, filter.Offset)
+
+	var movements []*models.StockMovement
+	if err := i.db.SelectContext(ctx, &movements, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+	return movements, nil
+}
+
+// Reconcile звіряє суму журналу з поточним залишком продукту на кожному складі
+func (i *inventoryRepo) Reconcile(ctx context.Context, productID uuid.UUID) ([]*models.StockReconciliation, error) {
+	query := `
+	SELECT l.id AS location_id, l.code AS location_code,
+		COALESCE(ls.quantity, 0) AS quantity,
+		COALESCE(m.ledger_quantity, 0) AS ledger_quantity,
+		COALESCE(ls.quantity, 0) - COALESCE(m.ledger_quantity, 0) AS difference
+	FROM stock_locations l
+	LEFT JOIN location_stock ls ON ls.location_id = l.id AND ls.product_id = $1
+	LEFT JOIN (
+		SELECT location_id, SUM(quantity_change) AS ledger_quantity
+		FROM inventory_movements
+		WHERE product_id = $1
+		GROUP BY location_id
+	) m ON m.location_id = l.id
+	WHERE ls.product_id IS NOT NULL OR m.location_id IS NOT NULL
+	ORDER BY l.is_default DESC, l.code
+	`
+
+	var lines []*models.StockReconciliation
+	if err := i.db.SelectContext(ctx, &lines, query, productID); err != nil {
+		return nil, fmt.Errorf("failed to reconcile stock: %w", err)
+	}
+	return lines, nil
+}
+
+// GetReorderSettings повертає поріг низького залишку та кількість дозамовлення продукту (nil, якщо не знайдено)
+func (i *inventoryRepo) GetReorderSettings(ctx context.Context, productID uuid.UUID) (*models.ReorderSettings, error) {
+	query := `
+	SELECT id, low_stock_threshold, reorder_quantity
+	FROM products
+	WHERE id = $1
+	`
+
+	var settings models.ReorderSettings
+	if err := i.db.GetContext(ctx, &settings, query, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get reorder settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// UpdateReorderSettings оновлює поріг низького залишку та кількість дозамовлення.
+// Новий поріг одразу застосовується до поточного залишку
+func (i *inventoryRepo) UpdateReorderSettings(ctx context.Context, settings *models.ReorderSettings) error {
+	query := `
+	UPDATE products
+	SET low_stock_threshold = $1,
+		reorder_quantity = $2,
+		updated_at = NOW()
+	WHERE id = $3
+	`
+
+	tx, err := i.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, query, settings.LowStockThreshold, settings.ReorderQuantity, settings.ProductID)
+	if err != nil {
+		return fmt.Errorf("failed to update reorder settings: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	if err := checkLowStock(ctx, tx, settings.ProductID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reorder settings: %w", err)
+	}
+	return nil
+}
+
+// ListAlerts повертає сповіщення про низький залишок, новіші першими
+func (i *inventoryRepo) ListAlerts(ctx context.Context, filter models.LowStockAlertFilter) ([]*models.LowStockAlert, error) {
+	query := `
+	SELECT a.id, a.product_id, p.name AS product_name, p.sku, a.stock, a.threshold, a.reorder_quantity,
+		a.status, a.notified_at, a.created_at, a.resolved_at
+	FROM low_stock_alerts a
+	JOIN products p ON p.id = a.product_id
+	WHERE 1=1
+	`
+	args := []interface{}{}
+	argsCount := 1
+
+	if filter.Status != "" {
+		query += fmt.Sprintf(" AND a.status = $%d", argsCount)
+		args = append(args, filter.Status)
+		argsCount++
+	}
+
+	query += fmt.Sprintf(" ORDER BY a.created_at DESC, a.id LIMIT $%d OFFSET $%d", argsCount, argsCount+1)
+	args = append(args, filter.Limit, filter.Offset)
+
+	var alerts []*models.LowStockAlert
+	if err := i.db.SelectContext(ctx, &alerts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list low stock alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// ListUndeliveredAlerts повертає сповіщення, які ще не були відправлені, старіші першими
+func (i *inventoryRepo) ListUndeliveredAlerts(ctx context.Context, limit int) ([]*models.LowStockAlert, error) {
+	query := `
+	SELECT a.id, a.product_id, p.name AS product_name, p.sku, a.stock, a.threshold, a.reorder_quantity,
+		a.status, a.notified_at, a.created_at, a.resolved_at
+	FROM low_stock_alerts a
+	JOIN products p ON p.id = a.product_id
+	WHERE a.notified_at IS NULL
+	ORDER BY a.created_at, a.id
+	LIMIT $1
+	`
+
+	var alerts []*models.LowStockAlert
+	if err := i.db.SelectContext(ctx, &alerts, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to list undelivered alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// MarkAlertsNotified позначає сповіщення як відправлені
+func (i *inventoryRepo) MarkAlertsNotified(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `UPDATE low_stock_alerts SET notified_at = NOW() WHERE id = ANY($1::uuid[])`
+	if _, err := i.db.ExecContext(ctx, query, pq.Array(uuidStrings(ids))); err != nil {
+		return fmt.Errorf("failed to mark alerts notified: %w", err)
+	}
+	return nil
+}
+
+// ListReorderNeeded повертає продукти із залишком на рівні порогу або нижче
+func (i *inventoryRepo) ListReorderNeeded(ctx context.Context) ([]*models.ReorderItem, error) {
+	query := `
+	SELECT id, name, sku, stock, low_stock_threshold, reorder_quantity
+	FROM products
+	WHERE deleted_at IS NULL AND low_stock_threshold IS NOT NULL AND stock <= low_stock_threshold
+	ORDER BY stock - low_stock_threshold, name
+	`
+
+	var items []*models.ReorderItem
+	if err := i.db.SelectContext(ctx, &items, query); err != nil {
+		return nil, fmt.Errorf("failed to list products to reorder: %w", err)
+	}
+	return items, nil
+}
+
+// takeStock списує товар зі складу та записує рух у журнал; sql.ErrNoRows, якщо залишку недостатньо
+func takeStock(ctx context.Context, tx sqlx.ExtContext, locationID, productID uuid.UUID, quantity int, src models.MovementSource) error {
+	query := `
+	UPDATE location_stock
+	SET quantity = quantity - $1, updated_at = NOW()
+	WHERE location_id = $2 AND product_id = $3 AND quantity >= $1
+	RETURNING quantity
+	`
+
+	var after int
+	if err := tx.QueryRowxContext(ctx, query, quantity, locationID, productID).Scan(&after); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to take stock: %w", err)
+	}
+	return recordMovement(ctx, tx, productID, locationID, -quantity, after, src)
+}
+
+// putStock додає товар на склад та записує рух у журнал
+func putStock(ctx context.Context, tx sqlx.ExtContext, locationID, productID uuid.UUID, quantity int, src models.MovementSource) error {
+	query := `
+	INSERT INTO location_stock (location_id, product_id, quantity, updated_at)
+	VALUES ($1, $2, $3, NOW())
+	ON CONFLICT (location_id, product_id) DO UPDATE
+	SET quantity = location_stock.quantity + EXCLUDED.quantity, updated_at = NOW()
+	RETURNING quantity
+	`
+
+	var after int
+	if err := tx.QueryRowxContext(ctx, query, locationID, productID, quantity).Scan(&after); err != nil {
+		return fmt.Errorf("failed to put stock: %w", err)
+	}
+	return recordMovement(ctx, tx, productID, locationID, quantity, after, src)
+}
+
+// recordMovement додає запис у журнал руху товару; автор береться з контексту
+func recordMovement(ctx context.Context, tx sqlx.ExtContext, productID, locationID uuid.UUID, change, after int, src models.MovementSource) error {
+	query := `
+	INSERT INTO inventory_movements (id, product_id, location_id, quantity_change, quantity_after, reason, actor_id, reference_id, note, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		uuid.New(),
+		productID,
+		locationID,
+		change,
+		after,
+		src.Reason,
+		models.ActorFromContext(ctx),
+		src.ReferenceID,
+		src.Note,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record stock movement: %w", err)
+	}
+	return nil
+}
+
+// adjustDefaultStock змінює залишок на складі за замовчуванням на delta.
+// Так початковий залишок нового продукту та імпорт потрапляють на основний склад
+func adjustDefaultStock(ctx context.Context, tx sqlx.ExtContext, productID uuid.UUID, delta int, src models.MovementSource) error {
+	if err := adjustLocationStock(ctx, tx, nil, productID, delta, src); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("not enough stock at default location")
+		}
+		return err
+	}
+	return nil
+}
+
+// adjustLocationStock змінює залишок на складі locationID (nil - склад за замовчуванням) на delta.
+// Якщо на складі не вистачає товару для списання, повертає sql.ErrNoRows
+func adjustLocationStock(ctx context.Context, tx sqlx.ExtContext, locationID *uuid.UUID, productID uuid.UUID, delta int, src models.MovementSource) error {
+	if delta == 0 {
+		return nil
+	}
+
+	var id uuid.UUID
+	if locationID != nil {
+		id = *locationID
+	} else if err := sqlx.GetContext(ctx, tx, &id, `SELECT id FROM stock_locations WHERE is_default`); err != nil {
+		return fmt.Errorf("failed to get default stock location: %w", err)
+	}
+
+	if delta > 0 {
+		return putStock(ctx, tx, id, productID, delta, src)
+	}
+	return takeStock(ctx, tx, id, productID, -delta, src)
+}
+
+// syncProductStock віддає наявний залишок позиціям під замовлення,
+// перераховує products.stock як суму залишків на активних складах,
+// ставить у чергу підписки на надходження товару, коли залишок змінився з нуля на додатний,
+// та оновлює сповіщення про низький залишок.
+// Після цього перераховується залишок комплектів, до яких входять ці продукти
+func syncProductStock(ctx context.Context, tx sqlx.ExtContext, productIDs ...uuid.UUID) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	if err := fillBackorders(ctx, tx, productIDs...); err != nil {
+		return err
+	}
+
+	query := `
+	WITH old AS (
+		SELECT id, stock FROM products WHERE id = ANY($1::uuid[]) FOR UPDATE
+	), synced AS (
+		UPDATE products p
+		SET stock = COALESCE((
+			SELECT SUM(ls.quantity)
+			FROM location_stock ls
+			JOIN stock_locations l ON l.id = ls.location_id
+			WHERE ls.product_id = p.id AND l.is_active
+		), 0)
+		FROM old
+		WHERE p.id = old.id AND NOT p.is_bundle
+		RETURNING p.id, old.stock AS old_stock, p.stock AS new_stock
+	)
+	UPDATE stock_subscriptions s
+	SET ready_at = NOW()
+	FROM synced
+	WHERE s.product_id = synced.id AND synced.old_stock <= 0 AND synced.new_stock > 0
+		AND s.ready_at IS NULL AND s.notified_at IS NULL
+	`
+
+	if _, err := tx.ExecContext(ctx, query, pq.Array(uuidStrings(productIDs))); err != nil {
+		return fmt.Errorf("failed to sync product stock: %w", err)
+	}
+	if err := checkLowStock(ctx, tx, productIDs...); err != nil {
+		return err
+	}
+	return syncBundleStock(ctx, tx, productIDs...)
+}
+
+// syncBundleStock перераховує залишок комплектів, що містять задані продукти (або є ними):
+// кількість повних комплектів, які можна зібрати із залишків компонентів.
+// Видалений компонент робить комплект недоступним
+func syncBundleStock(ctx context.Context, tx sqlx.ExecerContext, productIDs ...uuid.UUID) error {
+	query := `
+	WITH bundles AS (
+		SELECT DISTINCT bundle_id AS id FROM product_bundle_items
+		WHERE component_id = ANY($1::uuid[]) OR bundle_id = ANY($1::uuid[])
+	), old AS (
+		SELECT p.id, p.stock FROM products p JOIN bundles b ON b.id = p.id
+		WHERE p.is_bundle
+		FOR UPDATE OF p
+	), synced AS (
+		UPDATE products p
+		SET stock = COALESCE((
+			SELECT MIN(CASE WHEN c.deleted_at IS NULL THEN c.stock / bi.quantity ELSE 0 END)
+			FROM product_bundle_items bi
+			JOIN products c ON c.id = bi.component_id
+			WHERE bi.bundle_id = p.id
+		), 0)
+		FROM old
+		WHERE p.id = old.id
+		RETURNING p.id, old.stock AS old_stock, p.stock AS new_stock
+	)
+	UPDATE stock_subscriptions s
+	SET ready_at = NOW()
+	FROM synced
+	WHERE s.product_id = synced.id AND synced.old_stock <= 0 AND synced.new_stock > 0
+		AND s.ready_at IS NULL AND s.notified_at IS NULL
+	`
+
+	if _, err := tx.ExecContext(ctx, query, pq.Array(uuidStrings(productIDs))); err != nil {
+		return fmt.Errorf("failed to sync bundle stock: %w", err)
+	}
+	return nil
+}
+
+// fillBackorders розподіляє залишок на активних складах між позиціями під замовлення
+// в порядку оформлення замовлень (FIFO); позиції скасованих замовлень пропускаються.
+// Після повного покриття позиції очікувана дата відвантаження скидається,
+// крім передзамовлень, де вона є датою релізу
+func fillBackorders(ctx context.Context, tx sqlx.ExtContext, productIDs ...uuid.UUID) error {
+	var backorders []struct {
+		ItemID    uuid.UUID `db:"id"`
+		OrderID   uuid.UUID `db:"order_id"`
+		ProductID uuid.UUID `db:"product_id"`
+		Quantity  int       `db:"backordered_quantity"`
+	}
+	backorderQuery := `
+	SELECT oi.id, oi.order_id, oi.product_id, oi.backordered_quantity
+	FROM order_items oi
+	JOIN orders o ON o.id = oi.order_id
+	WHERE oi.product_id = ANY($1::uuid[]) AND oi.backordered_quantity > 0 AND o.status <> 'cancelled'
+	ORDER BY o.created_at, oi.id
+	FOR UPDATE OF oi
+	`
+	ids := pq.Array(uuidStrings(productIDs))
+	if err := sqlx.SelectContext(ctx, tx, &backorders, backorderQuery, ids); err != nil {
+		return fmt.Errorf("failed to get backorders: %w", err)
+	}
+	if len(backorders) == 0 {
+		return nil
+	}
+
+	var levels []*models.LocationStock
+	levelQuery := `
+	SELECT ls.location_id, ls.product_id, ls.quantity
+	FROM location_stock ls
+	JOIN stock_locations l ON l.id = ls.location_id
+	WHERE ls.product_id = ANY($1::uuid[]) AND l.is_active AND ls.quantity > 0
+	ORDER BY l.is_default DESC, ls.quantity DESC, l.code
+	FOR UPDATE OF ls
+	`
+	if err := sqlx.SelectContext(ctx, tx, &levels, levelQuery, ids); err != nil {
+		return fmt.Errorf("failed to get stock levels: %w", err)
+	}
+	byProduct := make(map[uuid.UUID][]*models.LocationStock)
+	for _, level := range levels {
+		byProduct[level.ProductID] = append(byProduct[level.ProductID], level)
+	}
+
+	allocationQuery := `
+	INSERT INTO order_item_allocations (order_item_id, product_id, location_id, quantity)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (order_item_id, product_id, location_id) DO UPDATE SET quantity = order_item_allocations.quantity + EXCLUDED.quantity
+	`
+	itemQuery := `
+	UPDATE order_items oi
+	SET backordered_quantity = oi.backordered_quantity - $2,
+		expected_ship_date = CASE
+			WHEN oi.backordered_quantity = $2 AND p.backorder_policy <> 'preorder' THEN NULL
+			ELSE oi.expected_ship_date
+		END
+	FROM products p
+	WHERE oi.id = $1 AND p.id = oi.product_id
+	`
+
+	for _, backorder := range backorders {
+		sale := models.MovementSource{Reason: models.MovementSale, ReferenceID: &backorder.OrderID}
+		remaining := backorder.Quantity
+		for _, level := range byProduct[backorder.ProductID] {
+			if remaining == 0 {
+				break
+			}
+			take := min(level.Quantity, remaining)
+			if take == 0 {
+				continue
+			}
+			if err := takeStock(ctx, tx, level.LocationID, backorder.ProductID, take, sale); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, allocationQuery, backorder.ItemID, backorder.ProductID, level.LocationID, take); err != nil {
+				return fmt.Errorf("failed to allocate backorder: %w", err)
+			}
+			level.Quantity -= take
+			remaining -= take
+		}
+		if filled := backorder.Quantity - remaining; filled > 0 {
+			if _, err := tx.ExecContext(ctx, itemQuery, backorder.ItemID, filled); err != nil {
+				return fmt.Errorf("failed to update backorder: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkLowStock відкриває сповіщення, коли залишок опускається до порогу або нижче,
+// та закриває відкриті сповіщення, коли залишок піднявся вище порогу
+func checkLowStock(ctx context.Context, tx sqlx.ExtContext, productIDs ...uuid.UUID) error {
+	resolve := `
+	UPDATE low_stock_alerts a
+	SET status = 'resolved', resolved_at = NOW()
+	FROM products p
+	WHERE a.product_id = p.id AND a.status = 'open' AND p.id = ANY($1::uuid[])
+		AND (p.low_stock_threshold IS NULL OR p.stock > p.low_stock_threshold OR p.deleted_at IS NOT NULL)
+	`
+	raise := `
+	INSERT INTO low_stock_alerts (id, product_id, stock, threshold, reorder_quantity, status, created_at)
+	SELECT gen_random_uuid(), id, stock, low_stock_threshold, reorder_quantity, 'open', NOW()
+	FROM products
+	WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL
+		AND low_stock_threshold IS NOT NULL AND stock <= low_stock_threshold
+	ON CONFLICT (product_id) WHERE status = 'open' DO NOTHING
+	`
+
+	ids := pq.Array(uuidStrings(productIDs))
+	if _, err := tx.ExecContext(ctx, resolve, ids); err != nil {
+		return fmt.Errorf("failed to resolve low stock alerts: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, raise, ids); err != nil {
+		return fmt.Errorf("failed to raise low stock alerts: %w", err)
+	}
+	return nil
+}
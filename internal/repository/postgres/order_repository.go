@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,11 +15,11 @@ import (
 
 // OrderRepository інтерфейс для роботи з заказами
 type OrderRepository interface {
-	Create(ctx context.Context, order *models.Order, items []*models.OrderItem) error
+	Create(ctx context.Context, order *models.Order, items []*models.OrderItem, allocations []*models.StockAllocation) error
 	GetById(ctx context.Context, id uuid.UUID) (*models.Order, error)
 	GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]*models.OrderItem, error)
-	ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Order, error)
-	ListAll(ctx context.Context, limit, offset int) ([]*models.Order, error)
+	List(ctx context.Context, filter models.OrderListFilter) ([]*models.Order, error)
+	Count(ctx context.Context, filter models.OrderListFilter) (total int, estimated bool, err error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
 }
 
@@ -29,8 +31,11 @@ func NewOrderRepository(db *database.DB) OrderRepository {
 	return &orderRepo{db: db}
 }
 
-// Create створення нового замовлення
-func (o *orderRepo) Create(ctx context.Context, order *models.Order, items []*models.OrderItem) error {
+// Create створення нового замовлення.
+// Товар списується зі складів згідно з allocations в тій самій транзакції;
+// якщо на складі вже недостатньо товару, повертає sql.ErrNoRows.
+// Позиції під замовлення (BackorderedQuantity > 0) покриваються пізніше, при надходженні товару
+func (o *orderRepo) Create(ctx context.Context, order *models.Order, items []*models.OrderItem, allocations []*models.StockAllocation) error {
 
 	// маршелізація адреси замовлення
 	shippingJSON, errjson := json.Marshal(order.ShippingAddress)
@@ -43,8 +48,14 @@ func (o *orderRepo) Create(ctx context.Context, order *models.Order, items []*mo
 	VALUES ($1, $2, $3, $4, $5,$6,NOW(),NOW())
 	`
 
+	tx, err := o.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// створення нового замовлення
-	_, err := o.db.ExecContext(ctx, orderQuery,
+	_, err = tx.ExecContext(ctx, orderQuery,
 		order.ID,
 		order.UserID,
 		order.Status,
@@ -58,24 +69,53 @@ func (o *orderRepo) Create(ctx context.Context, order *models.Order, items []*mo
 	}
 
 	itemQuery := `
-		INSERT INTO order_items (id, order_id, product_id, quantity, price, created_at)
-		VALUES ($1, $2, $3, $4, $5, NOW())
+		INSERT INTO order_items (id, order_id, product_id, quantity, price, backordered, backordered_quantity, expected_ship_date, components, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
 	`
 
 	// додавання товарів у замовлення
 	for _, item := range items {
-		_, err := o.db.ExecContext(ctx, itemQuery,
+		_, err := tx.ExecContext(ctx, itemQuery,
 			item.ID,
 			item.OrderID,
 			item.ProductID,
 			item.Quantity,
 			item.Price,
+			item.Backordered,
+			item.BackorderedQuantity,
+			item.ExpectedShipDate,
+			item.Components,
 		)
 		// обробка помилок
 		if err != nil {
 			return fmt.Errorf("failed to create order items: %w", err)
 		}
 	}
+
+	allocationQuery := `
+		INSERT INTO order_item_allocations (order_item_id, product_id, location_id, quantity)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	// списання товару зі складів (для комплекту - кожного компонента)
+	sale := models.MovementSource{Reason: models.MovementSale, ReferenceID: &order.ID}
+	productIDs := make([]uuid.UUID, 0, len(allocations))
+	for _, allocation := range allocations {
+		if err := takeStock(ctx, tx, allocation.LocationID, allocation.ProductID, allocation.Quantity, sale); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, allocationQuery, allocation.OrderItemID, allocation.ProductID, allocation.LocationID, allocation.Quantity); err != nil {
+			return fmt.Errorf("failed to create order allocation: %w", err)
+		}
+		productIDs = append(productIDs, allocation.ProductID)
+	}
+	if err := syncProductStock(ctx, tx, productIDs...); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit order: %w", err)
+	}
 	return nil
 }
 
@@ -85,7 +125,7 @@ func (o *orderRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Order, e
 	var shippingBytes []byte
 
 	query := `
-	SELECT id, user_id, status, total_amount, shipping_address, payment_method, created_at, updated_at
+	SELECT id, user_id, status, total_amount, shipping_address, payment_method, paid_at, created_at, updated_at
 	FROM orders
 	WHERE id = $1
 	`
@@ -97,6 +137,7 @@ func (o *orderRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Order, e
 		TotalAmount   float64    `db:"total_amount"`
 		Shipping      []byte     `db:"shipping_address"`
 		PaymentMethod string     `db:"payment_method"`
+		PaidAt        *time.Time `db:"paid_at"`
 		CreatedAt     time.Time  `db:"created_at"`
 		UpdatedAt     time.Time  `db:"updated_at"`
 	}{}
@@ -113,6 +154,7 @@ func (o *orderRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Order, e
 	order.Status = temp.Status
 	order.TotalAmount = temp.TotalAmount
 	order.PaymentMethod = temp.PaymentMethod
+	order.PaidAt = temp.PaidAt
 	order.CreatedAt = temp.CreatedAt
 	order.UpdatedAt = temp.UpdatedAt
 	shippingBytes = temp.Shipping
@@ -129,9 +171,10 @@ func (o *orderRepo) GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]*mo
 	var items []*models.OrderItem
 
 	query := `
-	SELECT id, order_id, product_id, quantity, price, created_at
+	SELECT id, order_id, product_id, quantity, price, backordered, backordered_quantity, expected_ship_date, components, created_at
 	FROM order_items
 	WHERE order_id = $1
+	ORDER BY created_at, id
 	`
 
 	// отримання товарів в замовленні за ID
@@ -144,15 +187,26 @@ func (o *orderRepo) GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]*mo
 	return items, nil
 }
 
-// ListAll повертає всі замовлення
-func (o *orderRepo) ListAll(ctx context.Context, limit int, offset int) ([]*models.Order, error) {
+// orderSortKey замовлення від нових до старих; значення курсора - created_at
+var orderSortKey = sortKey{columns: []string{"created_at"}, types: []string{"timestamptz"}, desc: true}
+
+// List повертає замовлення за фільтром від нових до старих.
+// Якщо задано keyset курсор, повертає записи після нього (або перед ним при русі назад)
+func (o *orderRepo) List(ctx context.Context, filter models.OrderListFilter) ([]*models.Order, error) {
+	where, args := orderFilter(filter)
+	after, orderBy, args, err := keyset(orderSortKey, "", filter.Cursor, args)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-	SELECT id, user_id, status, total_amount, shipping_address, payment_method, created_at, updated_at
+	SELECT id, user_id, status, total_amount, shipping_address, payment_method, paid_at, created_at, updated_at
 	FROM orders
-	ORDER BY created_at DESC
-	LIMIT $1 OFFSET $2
-	`
-	// временна структура для роботи з shipping adress
+	WHERE 1=1` + where + after + `
+	ORDER BY ` + orderBy
+	query, args = paginate(query, args, filter.Limit, filter.Offset, filter.Cursor)
+
+	// тимчасова структура для роботи з shipping adress
 	var tempOrders []struct {
 		ID            uuid.UUID  `db:"id"`
 		UserID        *uuid.UUID `db:"user_id"`
@@ -160,14 +214,13 @@ func (o *orderRepo) ListAll(ctx context.Context, limit int, offset int) ([]*mode
 		TotalAmount   float64    `db:"total_amount"`
 		Shipping      []byte     `db:"shipping_address"`
 		PaymentMethod string     `db:"payment_method"`
+		PaidAt        *time.Time `db:"paid_at"`
 		CreatedAt     time.Time  `db:"created_at"`
 		UpdatedAt     time.Time  `db:"updated_at"`
 	}
 
-	// отримання всіх замовлень
-	err := o.db.SelectContext(ctx, &tempOrders, query, limit, offset)
-	// обробка помилок
-	if err != nil {
+	// отримання замовлень
+	if err := o.db.SelectContext(ctx, &tempOrders, query, args...); err != nil {
 		return nil, fmt.Errorf("failed to list orders: %w", err)
 	}
 
@@ -180,6 +233,7 @@ func (o *orderRepo) ListAll(ctx context.Context, limit int, offset int) ([]*mode
 			Status:        temp.Status,
 			TotalAmount:   temp.TotalAmount,
 			PaymentMethod: temp.PaymentMethod,
+			PaidAt:        temp.PaidAt,
 			CreatedAt:     temp.CreatedAt,
 			UpdatedAt:     temp.UpdatedAt,
 		}
@@ -189,78 +243,103 @@ func (o *orderRepo) ListAll(ctx context.Context, limit int, offset int) ([]*mode
 		}
 		orders[i] = order
 	}
+	if filter.Cursor != nil && filter.Cursor.Backward {
+		reverse(orders)
+	}
 	return orders, nil
 }
 
-// ListByUserID повертає замовлення по ID користувача
-func (o *orderRepo) ListByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*models.Order, error) {
-	query := `
-	SELECT id, user_id, status, total_amount, shipping_address, payment_method, created_at, updated_at
-	FROM orders
-	WHERE user_id = $1
-	ORDER BY created_at DESC
-	LIMIT $2 OFFSET $3
-	`
-
-	// тимчасова структура
-	var tempOrders []struct {
-		ID            uuid.UUID  `db:"id"`
-		UserID        *uuid.UUID `db:"user_id"`
-		Status        string     `db:"status"`
-		TotalAmount   float64    `db:"total_amount"`
-		Shipping      []byte     `db:"shipping_address"`
-		PaymentMethod string     `db:"payment_method"`
-		CreatedAt     time.Time  `db:"created_at"`
-		UpdatedAt     time.Time  `db:"updated_at"`
-	}
-
-	// поверненя списку заказів по ID користувача
-	err := o.db.SelectContext(ctx, &tempOrders, query, userID, limit, offset)
-	// обробка помилок
+// Count повертає кількість замовлень за фільтром; другий результат - true для оцінки
+func (o *orderRepo) Count(ctx context.Context, filter models.OrderListFilter) (int, bool, error) {
+	where, args := orderFilter(filter)
+	total, estimated, err := countRows(ctx, o.db, "FROM orders WHERE 1=1"+where, args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list orders: %w", err)
+		return 0, false, fmt.Errorf("failed to count orders: %w", err)
 	}
+	return total, estimated, nil
+}
 
-	orders := make([]*models.Order, len(tempOrders))
-	// перетворення тимчасових запитів в структуру Order
-	for i, temp := range tempOrders {
-		order := &models.Order{
-			ID:            temp.ID,
-			UserID:        temp.UserID,
-			Status:        temp.Status,
-			TotalAmount:   temp.TotalAmount,
-			PaymentMethod: temp.PaymentMethod,
-			CreatedAt:     temp.CreatedAt,
-			UpdatedAt:     temp.UpdatedAt,
-		}
-		// де маршелізація адреси замовлення
-		if err := json.Unmarshal(temp.Shipping, &order.ShippingAddress); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal shipping address: %w", err)
-		}
-		orders[i] = order
+// orderFilter будує умови фільтрації замовлень
+func orderFilter(filter models.OrderListFilter) (string, []interface{}) {
+	var where string
+	var args []interface{}
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		where += fmt.Sprintf(" AND user_id = $%d", len(args))
 	}
-	return orders, nil
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	return where, args
 }
 
-// UpdateStatus оновлення статусу замовлення
+// UpdateStatus оновлення статусу замовлення.
+// Перший перехід у статус 'paid' фіксує час оплати.
+// При скасуванні списаний товар повертається на ті склади, з яких його було відвантажено.
+// Скасоване замовлення більше не змінює статус (повертає sql.ErrNoRows)
 func (o *orderRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
 	query := `
 	UPDATE orders
 	SET status = $1,
+		paid_at = CASE WHEN $1 = 'paid' AND paid_at IS NULL THEN NOW() ELSE paid_at END,
 		updated_at = NOW()
 	WHERE id = $2
 	`
 
-	// оновлення статусу замовлення за ID
-	res, err := o.db.ExecContext(ctx, query, status, id)
-	// обробка помилок
+	tx, err := o.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// поточний статус (з блокуванням рядка до кінця транзакції)
+	var oldStatus string
+	err = tx.QueryRowxContext(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, id).Scan(&oldStatus)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("order not found")
+		}
 		return fmt.Errorf("failed to update orders: %w", err)
 	}
-	rows, _ := res.RowsAffected()
-	if rows == 0 {
-		return fmt.Errorf("order not found")
+
+	// скасоване замовлення вже повернуло товар на склад, тому його статус більше не змінюється
+	if oldStatus == "cancelled" && status != "cancelled" {
+		return sql.ErrNoRows
 	}
 
+	// оновлення статусу замовлення за ID
+	if _, err := tx.ExecContext(ctx, query, status, id); err != nil {
+		return fmt.Errorf("failed to update orders: %w", err)
+	}
+
+	if status == "cancelled" && oldStatus != "cancelled" {
+		var allocations []*models.StockAllocation
+		allocationQuery := `
+		SELECT a.order_item_id, a.product_id, a.location_id, a.quantity
+		FROM order_item_allocations a
+		JOIN order_items oi ON oi.id = a.order_item_id
+		WHERE oi.order_id = $1
+		`
+		if err := tx.SelectContext(ctx, &allocations, allocationQuery, id); err != nil {
+			return fmt.Errorf("failed to get order allocations: %w", err)
+		}
+
+		cancellation := models.MovementSource{Reason: models.MovementCancellation, ReferenceID: &id}
+		productIDs := make([]uuid.UUID, 0, len(allocations))
+		for _, allocation := range allocations {
+			if err := putStock(ctx, tx, allocation.LocationID, allocation.ProductID, allocation.Quantity, cancellation); err != nil {
+				return err
+			}
+			productIDs = append(productIDs, allocation.ProductID)
+		}
+		if err := syncProductStock(ctx, tx, productIDs...); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit order status: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Xiancel/ecommerce/internal/pagination"
+	"github.com/jmoiron/sqlx"
+)
+
+// до цієї кількості записів total рахується точно, далі - оцінка планувальника
+const exactCountLimit = 10000
+
+// sortKey колонки keyset сортування (без id, який завжди додається останнім)
+type sortKey struct {
+	columns []string
+	// types типи postgres для приведення значень курсора
+	types []string
+	desc  bool
+}
+
+// keyset повертає умову "після курсора" та ORDER BY для ключа сортування.
+// Для руху назад напрямок інвертується, тому результат потрібно розвернути (reverse)
+func keyset(key sortKey, prefix string, cursor *pagination.Cursor, args []interface{}) (string, string, []interface{}, error) {
+	desc := key.desc
+	if cursor != nil && cursor.Backward {
+		desc = !desc
+	}
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+
+	columns := make([]string, 0, len(key.columns)+1)
+	order := make([]string, 0, len(key.columns)+1)
+	for _, column := range append(append([]string{}, key.columns...), "id") {
+		columns = append(columns, prefix+column)
+		order = append(order, prefix+column+" "+direction)
+	}
+	orderBy := strings.Join(order, ", ")
+
+	if cursor == nil || !cursor.IsKeyset() {
+		return "", orderBy, args, nil
+	}
+	if len(cursor.Values) != len(key.columns) {
+		return "", "", nil, pagination.ErrInvalidCursor
+	}
+
+	// порівняння рядків (a, b, id) > (x, y, z) працює, бо всі колонки мають один напрямок
+	placeholders := make([]string, 0, len(columns))
+	for i, value := range cursor.Values {
+		args = append(args, value)
+		placeholders = append(placeholders, fmt.Sprintf("$%d::%s", len(args), key.types[i]))
+	}
+	args = append(args, cursor.ID)
+	placeholders = append(placeholders, fmt.Sprintf("$%d::uuid", len(args)))
+
+	comparison := ">"
+	if desc {
+		comparison = "<"
+	}
+	where := fmt.Sprintf(" AND (%s) %s (%s)", strings.Join(columns, ", "), comparison, strings.Join(placeholders, ", "))
+	return where, orderBy, args, nil
+}
+
+// reverse розвертає записи, вибрані при русі назад, у порядок відображення
+func reverse[T any](items []T) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+}
+
+// countRows рахує записи "SELECT 1 <from>" точно до exactCountLimit, а більші набори
+// оцінює планувальником; повертає true, якщо результат оціночний
+func countRows(ctx context.Context, q sqlx.QueryerContext, from string, args []interface{}) (int, bool, error) {
+	var count int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM (SELECT 1 %s LIMIT %d) limited", from, exactCountLimit+1)
+	if err := sqlx.GetContext(ctx, q, &count, query, args...); err != nil {
+		return 0, false, fmt.Errorf("failed to count rows: %w", err)
+	}
+	if count <= exactCountLimit {
+		return count, false, nil
+	}
+
+	// великий набір: оцінка кількості рядків з плану запиту
+	var plan []byte
+	if err := sqlx.GetContext(ctx, q, &plan, "EXPLAIN (FORMAT JSON) SELECT 1 "+from, args...); err != nil {
+		return 0, false, fmt.Errorf("failed to estimate rows: %w", err)
+	}
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil {
+		return 0, false, fmt.Errorf("failed to parse query plan: %w", err)
+	}
+	if len(explained) == 0 {
+		return count, true, nil
+	}
+
+	// оцінка не може бути меншою за вже пораховане
+	estimate := int(explained[0].Plan.Rows)
+	if estimate < count {
+		estimate = count
+	}
+	return estimate, true, nil
+}
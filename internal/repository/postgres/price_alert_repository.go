@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// effectivePriceSQL ціна продукту p з урахуванням розпродажу, що діє зараз
+const effectivePriceSQL = `CASE WHEN p.sale_price IS NOT NULL AND p.sale_price < p.price
+		AND (p.sale_starts_at IS NULL OR p.sale_starts_at <= NOW())
+		AND (p.sale_ends_at IS NULL OR p.sale_ends_at > NOW())
+	THEN p.sale_price ELSE p.price END`
+
+// PriceAlertRepository інтерфейс для роботи зі сповіщеннями про зниження ціни
+type PriceAlertRepository interface {
+	Upsert(ctx context.Context, alert *models.PriceAlert) error
+	GetActive(ctx context.Context, productID, userID uuid.UUID) (*models.PriceAlert, error)
+	ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*models.PriceAlertWithProduct, error)
+	Delete(ctx context.Context, productID, userID uuid.UUID) error
+	TriggerActiveSales(ctx context.Context) (int64, error)
+	RequeueRisen(ctx context.Context) (int64, error)
+	ListTriggered(ctx context.Context, since time.Time, perUserLimit, limit int) ([]*models.PriceAlertWithProduct, error)
+	MarkNotified(ctx context.Context, ids []uuid.UUID) error
+}
+
+type priceAlertRepo struct {
+	db *database.DB
+}
+
+func NewPriceAlertRepository(db *database.DB) PriceAlertRepository {
+	return &priceAlertRepo{db: db}
+}
+
+// Upsert створює сповіщення або змінює цільову ціну активного; зміна цілі скидає спрацювання
+func (r *priceAlertRepo) Upsert(ctx context.Context, alert *models.PriceAlert) error {
+	query := `
+	INSERT INTO price_alerts (id, product_id, user_id, target_price, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, NOW(), NOW())
+	ON CONFLICT (product_id, user_id) WHERE notified_at IS NULL DO UPDATE
+	SET target_price = EXCLUDED.target_price,
+		triggered_at = NULL,
+		triggered_price = NULL,
+		updated_at = NOW()
+	RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query,
+		uuid.New(),
+		alert.ProductID,
+		alert.UserID,
+		alert.TargetPrice,
+	).Scan(&alert.ID, &alert.CreatedAt, &alert.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert price alert: %w", err)
+	}
+	alert.TriggeredAt = nil
+	alert.TriggeredPrice = nil
+	return nil
+}
+
+// GetActive повертає активне (ще не відправлене) сповіщення користувача на продукт (nil, якщо не знайдено)
+func (r *priceAlertRepo) GetActive(ctx context.Context, productID, userID uuid.UUID) (*models.PriceAlert, error) {
+	query := `
+	SELECT id, product_id, user_id, target_price, created_at, updated_at, triggered_at, triggered_price, notified_at
+	FROM price_alerts
+	WHERE product_id = $1 AND user_id = $2 AND notified_at IS NULL
+	`
+
+	var alert models.PriceAlert
+	if err := r.db.GetContext(ctx, &alert, query, productID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get price alert: %w", err)
+	}
+	return &alert, nil
+}
+
+// ListActiveByUser повертає активні сповіщення користувача з поточною ціною продуктів
+func (r *priceAlertRepo) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*models.PriceAlertWithProduct, error) {
+	query := `
+	SELECT a.id, a.product_id, a.user_id, a.target_price, a.created_at, a.updated_at, a.triggered_at, a.triggered_price, a.notified_at,
+		p.name AS product_name, p.slug AS product_slug, ` + effectivePriceSQL + ` AS current_price
+	FROM price_alerts a
+	JOIN products p ON p.id = a.product_id
+	WHERE a.user_id = $1 AND a.notified_at IS NULL AND p.deleted_at IS NULL
+	ORDER BY a.created_at DESC, a.id
+	`
+
+	var alerts []*models.PriceAlertWithProduct
+	if err := r.db.SelectContext(ctx, &alerts, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list price alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// Delete видаляє активне сповіщення користувача на продукт
+func (r *priceAlertRepo) Delete(ctx context.Context, productID, userID uuid.UUID) error {
+	query := `DELETE FROM price_alerts WHERE product_id = $1 AND user_id = $2 AND notified_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, productID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete price alert: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// TriggerActiveSales ставить у чергу сповіщення продуктів, розпродаж яких уже діє.
+// Так спрацьовують заплановані розпродажі, бо їх початок не змінює рядок продукту
+func (r *priceAlertRepo) TriggerActiveSales(ctx context.Context) (int64, error) {
+	where := `p.sale_price IS NOT NULL
+		AND (p.sale_starts_at IS NULL OR p.sale_starts_at <= NOW())
+		AND (p.sale_ends_at IS NULL OR p.sale_ends_at > NOW())`
+	return execTriggerPriceAlerts(ctx, r.db, where)
+}
+
+// RequeueRisen повертає в очікування сповіщення, ціна яких знову піднялась вище цільової до відправки
+// (наприклад, розпродаж закінчився)
+func (r *priceAlertRepo) RequeueRisen(ctx context.Context) (int64, error) {
+	query := `
+	UPDATE price_alerts a
+	SET triggered_at = NULL,
+		triggered_price = NULL
+	FROM products p
+	WHERE a.product_id = p.id AND a.triggered_at IS NOT NULL AND a.notified_at IS NULL
+		AND ` + effectivePriceSQL + ` > a.target_price
+	`
+
+	res, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue price alerts: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	return rows, nil
+}
+
+// ListTriggered повертає сповіщення, що чекають на відправку, у порядку спрацювання.
+// Користувачу, якому після since вже відправлено perUserLimit сповіщень, нічого не повертається,
+// а решті - не більше залишку ліміту, тому такі сповіщення не займають місце в партії
+func (r *priceAlertRepo) ListTriggered(ctx context.Context, since time.Time, perUserLimit, limit int) ([]*models.PriceAlertWithProduct, error) {
+	query := `
+	WITH recent AS (
+		SELECT user_id, COUNT(*) AS sent
+		FROM price_alerts
+		WHERE notified_at > $1
+		GROUP BY user_id
+	)
+	SELECT id, product_id, user_id, target_price, created_at, updated_at, triggered_at, triggered_price, notified_at,
+		product_name, product_slug, current_price, recent_notifications
+	FROM (
+		SELECT a.id, a.product_id, a.user_id, a.target_price, a.created_at, a.updated_at, a.triggered_at, a.triggered_price, a.notified_at,
+			p.name AS product_name, p.slug AS product_slug, ` + effectivePriceSQL + ` AS current_price,
+			COALESCE(r.sent, 0) AS recent_notifications,
+			ROW_NUMBER() OVER (PARTITION BY a.user_id ORDER BY a.triggered_at, a.id) AS position
+		FROM price_alerts a
+		JOIN products p ON p.id = a.product_id
+		LEFT JOIN recent r ON r.user_id = a.user_id
+		WHERE a.triggered_at IS NOT NULL AND a.notified_at IS NULL
+			AND p.status = 'published' AND p.deleted_at IS NULL
+	) queue
+	WHERE position <= $2 - recent_notifications
+	ORDER BY triggered_at, id
+	LIMIT $3
+	`
+
+	var alerts []*models.PriceAlertWithProduct
+	if err := r.db.SelectContext(ctx, &alerts, query, since, perUserLimit, limit); err != nil {
+		return nil, fmt.Errorf("failed to list triggered price alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// MarkNotified позначає сповіщення як відправлені
+func (r *priceAlertRepo) MarkNotified(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `UPDATE price_alerts SET notified_at = NOW() WHERE id = ANY($1::uuid[])`
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(uuidStrings(ids))); err != nil {
+		return fmt.Errorf("failed to mark price alerts notified: %w", err)
+	}
+	return nil
+}
+
+// triggerPriceAlerts ставить у чергу сповіщення продуктів, ціна яких досягла цільової
+func triggerPriceAlerts(ctx context.Context, q sqlx.ExecerContext, productIDs ...uuid.UUID) error {
+	where := "p.id = ANY($1::uuid[])"
+	if _, err := execTriggerPriceAlerts(ctx, q, where, pq.Array(uuidStrings(productIDs))); err != nil {
+		return err
+	}
+	return nil
+}
+
+// execTriggerPriceAlerts позначає спрацювання сповіщень опублікованих продуктів за умовою where
+func execTriggerPriceAlerts(ctx context.Context, q sqlx.ExecerContext, where string, args ...interface{}) (int64, error) {
+	query := `
+	UPDATE price_alerts a
+	SET triggered_at = NOW(),
+		triggered_price = current.price
+	FROM (
+		SELECT p.id, ` + effectivePriceSQL + ` AS price
+		FROM products p
+		WHERE p.status = 'published' AND p.deleted_at IS NULL AND ` + where + `
+	) current
+	WHERE a.product_id = current.id AND a.triggered_at IS NULL AND a.notified_at IS NULL
+		AND current.price <= a.target_price
+	`
+
+	res, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to trigger price alerts: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	return rows, nil
+}
+
+// priceChanged повертає true, якщо змінилась ціна або умови розпродажу продукту
+func priceChanged(old, product *models.Product) bool {
+	return old.Price != product.Price ||
+		!equalFloatPtr(old.SalePrice, product.SalePrice) ||
+		!equalTimePtr(old.SaleStartsAt, product.SaleStartsAt) ||
+		!equalTimePtr(old.SaleEndsAt, product.SaleEndsAt)
+}
+
+func equalFloatPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
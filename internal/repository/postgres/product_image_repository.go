@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ProductImageRepository інтерфейс для роботи з зображеннями продуктів
+type ProductImageRepository interface {
+	Create(ctx context.Context, image *models.ProductImage) error
+	GetByID(ctx context.Context, productID, id uuid.UUID) (*models.ProductImage, error)
+	ListByProduct(ctx context.Context, productID uuid.UUID) ([]*models.ProductImage, error)
+	Update(ctx context.Context, image *models.ProductImage) error
+	Delete(ctx context.Context, productID, id uuid.UUID) error
+	SetPrimaryURL(ctx context.Context, productID uuid.UUID, url *string) error
+}
+
+type productImageRepo struct {
+	db *database.DB
+}
+
+func NewProductImageRepository(db *database.DB) ProductImageRepository {
+	return &productImageRepo{db: db}
+}
+
+// тимчасова структура для роботи з variants (JSONB)
+type productImageRow struct {
+	ID        uuid.UUID `db:"id"`
+	ProductID uuid.UUID `db:"product_id"`
+	AltText   *string   `db:"alt_text"`
+	SortOrder int       `db:"sort_order"`
+	Width     int       `db:"width"`
+	Height    int       `db:"height"`
+	Variants  []byte    `db:"variants"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// toModel перетворює рядок таблиці у структуру ProductImage
+func (r *productImageRow) toModel() (*models.ProductImage, error) {
+	image := &models.ProductImage{
+		ID:        r.ID,
+		ProductID: r.ProductID,
+		AltText:   r.AltText,
+		SortOrder: r.SortOrder,
+		Width:     r.Width,
+		Height:    r.Height,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	// де маршелізація варіантів зображення
+	if err := json.Unmarshal(r.Variants, &image.Variants); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal image variants: %w", err)
+	}
+	return image, nil
+}
+
+// Create створює запис про зображення
+func (p *productImageRepo) Create(ctx context.Context, image *models.ProductImage) error {
+	// маршелізація варіантів зображення
+	variantsJSON, err := json.Marshal(image.Variants)
+	if err != nil {
+		return fmt.Errorf("failed to marshal image variants: %w", err)
+	}
+
+	query := `
+	INSERT INTO product_images (id, product_id, alt_text, sort_order, width, height, variants, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+	RETURNING created_at, updated_at
+	`
+
+	// створення запису
+	err = p.db.QueryRowxContext(ctx, query,
+		image.ID,
+		image.ProductID,
+		image.AltText,
+		image.SortOrder,
+		image.Width,
+		image.Height,
+		variantsJSON,
+	).Scan(&image.CreatedAt, &image.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create product image: %w", err)
+	}
+	return nil
+}
+
+// GetByID повертає зображення продукту за його ID
+func (p *productImageRepo) GetByID(ctx context.Context, productID, id uuid.UUID) (*models.ProductImage, error) {
+	query := `
+	SELECT id, product_id, alt_text, sort_order, width, height, variants, created_at, updated_at
+	FROM product_images
+	WHERE id = $1 AND product_id = $2
+	`
+
+	var row productImageRow
+	err := p.db.GetContext(ctx, &row, query, id, productID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get product image: %w", err)
+	}
+	return row.toModel()
+}
+
+// ListByProduct повертає зображення продукту в порядку сортування
+func (p *productImageRepo) ListByProduct(ctx context.Context, productID uuid.UUID) ([]*models.ProductImage, error) {
+	query := `
+	SELECT id, product_id, alt_text, sort_order, width, height, variants, created_at, updated_at
+	FROM product_images
+	WHERE product_id = $1
+	ORDER BY sort_order ASC, created_at ASC
+	`
+
+	var rows []productImageRow
+	err := p.db.SelectContext(ctx, &rows, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product images: %w", err)
+	}
+
+	images := make([]*models.ProductImage, len(rows))
+	for i := range rows {
+		image, err := rows[i].toModel()
+		if err != nil {
+			return nil, err
+		}
+		images[i] = image
+	}
+	return images, nil
+}
+
+// Update оновлює alt текст та порядок сортування зображення
+func (p *productImageRepo) Update(ctx context.Context, image *models.ProductImage) error {
+	query := `
+	UPDATE product_images
+	SET alt_text = $1,
+		sort_order = $2,
+		updated_at = NOW()
+	WHERE id = $3 AND product_id = $4
+	RETURNING updated_at
+	`
+
+	err := p.db.QueryRowxContext(ctx, query,
+		image.AltText,
+		image.SortOrder,
+		image.ID,
+		image.ProductID,
+	).Scan(&image.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to update product image: %w", err)
+	}
+	return nil
+}
+
+// Delete видаляє запис про зображення
+func (p *productImageRepo) Delete(ctx context.Context, productID, id uuid.UUID) error {
+	query := `
+	DELETE FROM product_images WHERE id = $1 AND product_id = $2
+	`
+
+	res, err := p.db.ExecContext(ctx, query, id, productID)
+	if err != nil {
+		return fmt.Errorf("failed to delete product image: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetPrimaryURL оновлює головне зображення продукту (products.image_url)
+func (p *productImageRepo) SetPrimaryURL(ctx context.Context, productID uuid.UUID, url *string) error {
+	query := `
+	UPDATE products SET image_url = $1, updated_at = NOW() WHERE id = $2
+	`
+
+	_, err := p.db.ExecContext(ctx, query, url, productID)
+	if err != nil {
+		return fmt.Errorf("failed to set product image url: %w", err)
+	}
+	return nil
+}
@@ -2,24 +2,41 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	database "github.com/Xiancel/ecommerce/internal/db"
 	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/pagination"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // ProductRepository інтерфейс для роботи з продуктами
 type ProductRepository interface {
 	Create(ctx context.Context, product *models.Product) error
 	GetById(ctx context.Context, id uuid.UUID) (*models.Product, error)
+	GetBySKU(ctx context.Context, sku string) (*models.Product, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Product, error)
+	SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error)
 	List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error)
-	Update(ctx context.Context, product *models.Product) error
-	UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error
+	Count(ctx context.Context, filter models.ListFilter) (total int, estimated bool, err error)
+	Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error)
+	Update(ctx context.Context, product *models.Product, stock *models.StockChange) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error)
+	ApplySchedule(ctx context.Context) (published int, unpublished int, err error)
+	UpsertBySKU(ctx context.Context, product *models.Product) (inserted bool, err error)
+	ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error
 }
 
+// розмір пакету, який читається з курсору експорту за один FETCH
+const exportFetchSize = 500
+
 type productRepo struct {
 	db *database.DB
 }
@@ -31,34 +48,63 @@ func NewProductRepository(db *database.DB) ProductRepository {
 // Create створює новий продукт
 func (p *productRepo) Create(ctx context.Context, product *models.Product) error {
 	query := `
-	INSERT INTO products (id, name, description, price, stock, category_id, image_url, created_at, updated_at)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+	INSERT INTO products (id, sku, slug, name, description, price, stock, category_id, image_url, tags, status, publish_at, unpublish_at, backorder_policy, available_at, is_digital, sale_price, sale_starts_at, sale_ends_at, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NOW(), NOW())
 	`
 
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// присвоєння айді продукту
 	product.ID = uuid.New()
 	// створення продукту
-	_, err := p.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		product.ID,
+		product.SKU,
+		product.Slug,
 		product.Name,
 		product.Description,
 		product.Price,
 		product.Stock,
 		product.CategoryID,
 		product.ImageURL,
+		tagsArray(product.Tags),
+		product.Status,
+		product.PublishAt,
+		product.UnpublishAt,
+		product.BackorderPolicy,
+		product.AvailableAt,
+		product.IsDigital,
+		product.SalePrice,
+		product.SaleStartsAt,
+		product.SaleEndsAt,
 	)
 	// обробка помилки
 	if err != nil {
 		return fmt.Errorf("failed to created product: %w", err)
 	}
 
+	// початковий залишок потрапляє на склад за замовчуванням
+	if err := adjustDefaultStock(ctx, tx, product.ID, product.Stock, models.MovementSource{Reason: models.MovementOpeningBalance}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit product: %w", err)
+	}
 	return nil
 }
 
-// Delete видаляє продукт
+// Delete м'яко видаляє продукт (рядок лишається для історії замовлень)
 func (p *productRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `
-	DELETE FROM products WHERE id = $1
+	UPDATE products
+	SET deleted_at = NOW(),
+		updated_at = NOW()
+	WHERE id = $1 AND deleted_at IS NULL
 	`
 	// видалення продукту за його ID
 	res, err := p.db.ExecContext(ctx, query, id)
@@ -70,14 +116,15 @@ func (p *productRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	if rows == 0 {
 		return fmt.Errorf("product not found")
 	}
-	return nil
+	// комплекти з цим компонентом стають недоступними
+	return syncBundleStock(ctx, p.db, id)
 }
 
 // GetById повертає продукт за його ID
 func (p *productRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
 	var product models.Product
 	query := `
-	SELECT id, name, description, price, stock, category_id, image_url, created_at, updated_at
+	SELECT id, sku, slug, name, description, price, stock, category_id, image_url, tags, status, rating_avg, rating_count, publish_at, unpublish_at, backorder_policy, available_at, is_digital, is_bundle, sale_price, sale_starts_at, sale_ends_at, deleted_at, created_at, updated_at
 	FROM products
 	WHERE id = $1
 	`
@@ -92,69 +139,104 @@ func (p *productRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Produc
 	return &product, nil
 }
 
-// List повертає список продуктів
-func (p *productRepo) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+// GetBySKU повертає продукт за його SKU (nil, якщо не знайдено)
+func (p *productRepo) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	var product models.Product
 	query := `
-	SELECT id, name, description, price, stock, category_id, image_url, created_at, updated_at
+	SELECT id, sku, slug, name, description, price, stock, category_id, image_url, tags, status, rating_avg, rating_count, publish_at, unpublish_at, backorder_policy, available_at, is_digital, is_bundle, sale_price, sale_starts_at, sale_ends_at, deleted_at, created_at, updated_at
 	FROM products
-	WHERE 1=1
+	WHERE sku = $1
 	`
 
-	args := []interface{}{}
-	argsCount := 1
-
-	// фільтрація
-	if filter.CategoryID != nil && *filter.CategoryID != uuid.Nil {
-		query += fmt.Sprintf(" AND category_id = $%d", argsCount)
-		args = append(args, *filter.CategoryID)
-		argsCount++
+	err := p.db.GetContext(ctx, &product, query, sku)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get product by sku: %w", err)
 	}
 
-	if filter.MinPrice != nil {
-		query += fmt.Sprintf(" AND price >= $%d", argsCount)
-		args = append(args, *filter.MinPrice)
-		argsCount++
+	return &product, nil
+}
+
+// GetBySlug повертає продукт за поточним або старим slug (nil, якщо не знайдено).
+// Для старого slug у продукту буде інший (поточний) Slug
+func (p *productRepo) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	var product models.Product
+	query := `
+	SELECT id, sku, slug, name, description, price, stock, category_id, image_url, tags, status, rating_avg, rating_count, publish_at, unpublish_at, backorder_policy, available_at, is_digital, is_bundle, sale_price, sale_starts_at, sale_ends_at, deleted_at, created_at, updated_at
+	FROM products
+	WHERE slug = $1
+	UNION ALL
+	SELECT p.id, p.sku, p.slug, p.name, p.description, p.price, p.stock, p.category_id, p.image_url, p.tags,
+		p.status, p.rating_avg, p.rating_count, p.publish_at, p.unpublish_at, p.backorder_policy, p.available_at, p.is_digital, p.is_bundle, p.sale_price, p.sale_starts_at, p.sale_ends_at, p.deleted_at, p.created_at, p.updated_at
+	FROM product_slug_redirects r
+	JOIN products p ON p.id = r.product_id
+	WHERE r.slug = $1
+	LIMIT 1
+	`
+
+	err := p.db.GetContext(ctx, &product, query, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get product by slug: %w", err)
 	}
 
-	if filter.MaxPrice != nil {
-		query += fmt.Sprintf(" AND price <= $%d", argsCount)
-		args = append(args, *filter.MaxPrice)
-		argsCount++
+	return &product, nil
+}
+
+// SlugTaken перевіряє, чи використовується slug іншим продуктом (як поточний або старий)
+func (p *productRepo) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	query := `
+	SELECT EXISTS (SELECT 1 FROM products WHERE slug = $1 AND id <> $2)
+		OR EXISTS (SELECT 1 FROM product_slug_redirects WHERE slug = $1 AND product_id <> $2)
+	`
+
+	var taken bool
+	if err := p.db.QueryRowxContext(ctx, query, slug, excludeID).Scan(&taken); err != nil {
+		return false, fmt.Errorf("failed to check slug: %w", err)
 	}
+	return taken, nil
+}
+
+// List повертає список продуктів.
+// Якщо задано keyset курсор, повертає записи після нього (або перед ним при русі назад)
+func (p *productRepo) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	where, args := productFilter(filter, "", nil)
 
 	if filter.Search != "" {
-		query += fmt.Sprintf(" AND (name ILIKE $%d OR description ILIKE $%d) ", argsCount, argsCount)
-		args = append(args, "%"+filter.Search+"%")
-		argsCount++
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+		where += fmt.Sprintf(" AND (name ILIKE $%d OR description ILIKE $%d)", len(args), len(args))
 	}
 
-	orderBy := "created_at DESC"
-	if filter.OrderBy != "" {
-		allowedOrders := map[string]bool{
-			"price_asc":  true,
-			"price_desc": true,
-			"name_asc":   true,
-			"name_desc":  true,
+	// ручна колекція за замовчуванням у заданому порядку (без keyset)
+	var orderBy string
+	key, sorted := productSortKeys[filter.OrderBy]
+	if filter.CollectionID != nil && !sorted {
+		args = append(args, *filter.CollectionID)
+		orderBy = fmt.Sprintf("(SELECT position FROM collection_products WHERE collection_id = $%d AND product_id = products.id), created_at DESC, id DESC", len(args))
+	} else {
+		if !sorted {
+			key = productSortKeys[models.ProductSortNewest]
 		}
-		if allowedOrders[filter.OrderBy] {
-			parts := strings.Split(filter.OrderBy, "_")
-			orderBy = parts[0] + " " + strings.ToUpper(parts[1])
+		var after string
+		var err error
+		after, orderBy, args, err = keyset(key, "", filter.Cursor, args)
+		if err != nil {
+			return nil, err
 		}
+		where += after
 	}
-	query += " ORDER BY " + orderBy
 
-	// Pagination
-	if filter.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argsCount)
-		args = append(args, filter.Limit)
-		argsCount++
-	}
+	query := `
+	SELECT id, sku, slug, name, description, price, stock, category_id, image_url, tags, status, rating_avg, rating_count, publish_at, unpublish_at, backorder_policy, available_at, is_digital, is_bundle, sale_price, sale_starts_at, sale_ends_at, deleted_at, created_at, updated_at
+	FROM products
+	WHERE deleted_at IS NULL` + where + `
+	ORDER BY ` + orderBy
 
-	if filter.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET $%d", argsCount)
-		args = append(args, filter.Offset)
-		argsCount++
-	}
+	query, args = paginate(query, args, filter.Limit, filter.Offset, filter.Cursor)
 
 	// отримання продуктів
 	var products []*models.Product
@@ -162,61 +244,520 @@ func (p *productRepo) List(ctx context.Context, filter models.ListFilter) ([]*mo
 	if err != nil {
 		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
+	if filter.Cursor != nil && filter.Cursor.Backward {
+		reverse(products)
+	}
 	return products, nil
 }
 
-// Update оновлення продукту
-func (p *productRepo) Update(ctx context.Context, product *models.Product) error {
+// Count повертає кількість продуктів за фільтром (з пошуком, якщо задано Search).
+// Великі набори оцінюються; другий результат - true для оцінки
+func (p *productRepo) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	var from string
+	var args []interface{}
+	if filter.Search != "" {
+		from, args = searchFrom(filter)
+	} else {
+		var where string
+		where, args = productFilter(filter, "", nil)
+		from = "FROM products WHERE deleted_at IS NULL" + where
+	}
+
+	total, estimated, err := countRows(ctx, p.db, from, args)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to count products: %w", err)
+	}
+	return total, estimated, nil
+}
+
+// productSortKeys ключі keyset сортування продуктів; значення курсора дає models.ProductSortValues
+var productSortKeys = map[string]sortKey{
+	models.ProductSortNewest: {columns: []string{"created_at"}, types: []string{"timestamptz"}, desc: true},
+	"price_asc":              {columns: []string{"price"}, types: []string{"numeric"}},
+	"price_desc":             {columns: []string{"price"}, types: []string{"numeric"}, desc: true},
+	"name_asc":               {columns: []string{"name"}, types: []string{"text"}},
+	"name_desc":              {columns: []string{"name"}, types: []string{"text"}, desc: true},
+	// рейтинг сортується за середнім значенням, а при рівності - за кількістю відгуків
+	"rating_asc":  {columns: []string{"rating_avg", "rating_count"}, types: []string{"numeric", "int"}},
+	"rating_desc": {columns: []string{"rating_avg", "rating_count"}, types: []string{"numeric", "int"}, desc: true},
+}
+
+// availableSQL умова доступності продукту для покупки, як у models.Product.Availability:
+// залишок, цифровий товар або продаж під замовлення; prefix - аліас таблиці
+func availableSQL(prefix string) string {
+	return "(" + prefix + "stock > 0 OR " + prefix + "is_digital OR " + prefix + "backorder_policy IN ('allow', 'preorder'))"
+}
+
+// productFilter будує умови фільтрації продуктів (крім пошуку); prefix - аліас таблиці
+func productFilter(filter models.ListFilter, prefix string, args []interface{}) (string, []interface{}) {
+	var where strings.Builder
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		where.WriteString(" AND " + fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Status != "" {
+		add(prefix+"status = $%d", filter.Status)
+	}
+	if filter.CategoryID != nil && *filter.CategoryID != uuid.Nil {
+		add(prefix+"category_id = $%d", *filter.CategoryID)
+	}
+	if filter.MinPrice != nil {
+		add(prefix+"price >= $%d", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		add(prefix+"price <= $%d", *filter.MaxPrice)
+	}
+	if filter.MinRating != nil {
+		add(prefix+"rating_avg >= $%d", *filter.MinRating)
+	}
+	if filter.Tag != "" {
+		add(prefix+"tags @> ARRAY[$%d]::text[]", filter.Tag)
+	}
+	if filter.CreatedAfter != nil {
+		add(prefix+"created_at >= $%d", *filter.CreatedAfter)
+	}
+	if filter.CollectionID != nil {
+		add(prefix+"id IN (SELECT product_id FROM collection_products WHERE collection_id = $%d)", *filter.CollectionID)
+	}
+	if filter.InStock {
+		where.WriteString(" AND " + availableSQL(prefix))
+	}
+	return where.String(), args
+}
+
+// paginate додає LIMIT та OFFSET; з keyset курсором зміщення не використовується
+func paginate(query string, args []interface{}, limit, offset int, cursor *pagination.Cursor) (string, []interface{}) {
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if offset > 0 && (cursor == nil || !cursor.IsKeyset()) {
+		args = append(args, offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+	return query, args
+}
+
+// tagsArray передає теги в postgres як TEXT[] (порожній масив замість NULL)
+func tagsArray(tags []string) interface{} {
+	if tags == nil {
+		tags = []string{}
+	}
+	return pq.Array(tags)
+}
+
+// searchFrom будує FROM та WHERE пошуку: збіг хоча б одного терміну або закріплення за запитом
+func searchFrom(filter models.ListFilter) (string, []interface{}) {
+	terms := filter.SearchTerms
+	if len(terms) == 0 {
+		terms = []string{filter.Search}
+	}
+	// % та _ у запиті чи синонімах шукаються буквально
+	patterns := make([]string, len(terms))
+	for i, term := range terms {
+		patterns[i] = "%" + escapeLike(term) + "%"
+	}
+
+	// назва важить більше за опис
+	from := `
+	FROM products p
+	CROSS JOIN LATERAL (
+		SELECT CASE
+			WHEN p.name ILIKE ANY($1) THEN 2.0
+			WHEN p.description ILIKE ANY($1) THEN 1.0
+			ELSE 0.0
+		END AS text_score
+	) s
+	LEFT JOIN product_boosts pb ON pb.product_id = p.id
+	LEFT JOIN search_pins sp ON sp.product_id = p.id AND sp.query = $2
+	WHERE (s.text_score > 0 OR sp.product_id IS NOT NULL)
+		AND p.deleted_at IS NULL`
+
+	args := []interface{}{pq.Array(patterns), strings.ToLower(strings.TrimSpace(filter.Search))}
+	where, args := productFilter(filter, "p.", args)
+	return from + where, args
+}
+
+// Search повертає продукти за пошуковим запитом з урахуванням правил мерчандайзингу.
+// За релевантністю сторінки задаються зміщенням, з явним сортуванням - keyset курсором
+func (p *productRepo) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	from, args := searchFrom(filter)
+
+	// явне сортування має пріоритет над релевантністю
+	orderBy := "sp.position ASC NULLS LAST, score DESC, p.created_at DESC, p.id DESC"
+	cursor := filter.Cursor
+	if key, ok := productSortKeys[filter.OrderBy]; ok {
+		var after string
+		var err error
+		after, orderBy, args, err = keyset(key, "p.", cursor, args)
+		if err != nil {
+			return nil, err
+		}
+		from += after
+	} else {
+		cursor = nil
+	}
+
+	// підсилення множить текстову оцінку
+	query := `
+	SELECT p.id, p.sku, p.slug, p.name, p.description, p.price, p.stock, p.category_id, p.image_url, p.tags,
+		p.status, p.rating_avg, p.rating_count, p.publish_at, p.unpublish_at, p.backorder_policy, p.available_at, p.is_digital, p.is_bundle, p.sale_price, p.sale_starts_at, p.sale_ends_at, p.deleted_at, p.created_at, p.updated_at,
+		s.text_score,
+		COALESCE(pb.factor, 1) AS boost,
+		sp.position AS pin_position,
+		s.text_score * COALESCE(pb.factor, 1) AS score` + from + `
+	ORDER BY ` + orderBy
+
+	query, args = paginate(query, args, filter.Limit, filter.Offset, cursor)
+
+	// отримання результатів пошуку
+	var hits []*models.ProductSearchHit
+	err := p.db.SelectContext(ctx, &hits, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+	if cursor != nil && cursor.Backward {
+		reverse(hits)
+	}
+	return hits, nil
+}
+
+// Update оновлення продукту.
+// Якщо slug змінився, старий зберігається як редирект на продукт.
+// Залишок змінюється лише рухом складу за stock (nil - залишок не змінюється) у тій самій транзакції;
+// якщо на складі не вистачає товару для списання, повертає sql.ErrNoRows
+func (p *productRepo) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
 	query := `
 	UPDATE products 
 	SET name = $1,
 		description = $2,
 		price = $3,
-		stock = $4,
-		category_id = $5, 
-		image_url = $6,  
+		category_id = $4, 
+		image_url = $5,  
+		status = $6,
+		publish_at = $7,
+		unpublish_at = $8,
+		sku = $9,
+		slug = $10,
+		tags = $11,
+		backorder_policy = $12,
+		available_at = $13,
+		is_digital = $14,
+		sale_price = $15,
+		sale_starts_at = $16,
+		sale_ends_at = $17,
 		updated_at = NOW()
-	WHERE id = $7
+	WHERE id = $18
 	`
 
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// поточні slug, ціна та залишок (з блокуванням рядка до кінця транзакції)
+	var old models.Product
+	err = tx.GetContext(ctx, &old, `SELECT slug, price, sale_price, sale_starts_at, sale_ends_at, stock FROM products WHERE id = $1 FOR UPDATE`, product.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("product not found")
+		}
+		return fmt.Errorf("failed to get product slug: %w", err)
+	}
+
 	// оновлення даних продукта
-	res, err := p.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		product.Name,
 		product.Description,
 		product.Price,
-		product.Stock,
 		product.CategoryID,
 		product.ImageURL,
+		product.Status,
+		product.PublishAt,
+		product.UnpublishAt,
+		product.SKU,
+		product.Slug,
+		tagsArray(product.Tags),
+		product.BackorderPolicy,
+		product.AvailableAt,
+		product.IsDigital,
+		product.SalePrice,
+		product.SaleStartsAt,
+		product.SaleEndsAt,
 		product.ID,
 	)
 	// обробка помилок
 	if err != nil {
 		return fmt.Errorf("failed to update product: %w", err)
 	}
-	rows, _ := res.RowsAffected()
-	if rows == 0 {
-		return fmt.Errorf("product not found")
+
+	// зміна залишку записується в журнал руху товару
+	if stock != nil {
+		if err := adjustLocationStock(ctx, tx, stock.LocationID, product.ID, stock.Stock-old.Stock, stock.Source); err != nil {
+			return err
+		}
+		if err := syncProductStock(ctx, tx, product.ID); err != nil {
+			return err
+		}
+	}
+
+	// зміна ціни або розпродажу: сповіщення, цільова ціна яких досягнута, стають у чергу
+	if priceChanged(&old, product) {
+		if err := triggerPriceAlerts(ctx, tx, product.ID); err != nil {
+			return err
+		}
+	}
+
+	if old.Slug != product.Slug {
+		// старий slug веде на продукт, а новий більше не є редиректом
+		redirect := `
+		INSERT INTO product_slug_redirects (slug, product_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (slug) DO UPDATE SET product_id = EXCLUDED.product_id, created_at = NOW()
+		`
+		if _, err := tx.ExecContext(ctx, redirect, old.Slug, product.ID); err != nil {
+			return fmt.Errorf("failed to save slug redirect: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM product_slug_redirects WHERE slug = $1`, product.Slug); err != nil {
+			return fmt.Errorf("failed to delete slug redirect: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit product update: %w", err)
 	}
 	return nil
 }
 
-// UpdateStock оновлення количества продукту
-func (p *productRepo) UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error {
-	query := `
+// Suggest повертає підказки для автодоповнення по назвах продуктів та категорій.
+// Триграмний збіг через оператор <% використовує GIN індекси назв; поріг схожості
+// задається лише для транзакції запиту
+func (p *productRepo) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	// префіксний збіг має найвищий пріоритет, далі йде триграмна схожість
+	sqlQuery := `
+	SELECT type, id, text, score FROM (
+		SELECT 'product' AS type, id, name AS text,
+			CASE WHEN name ILIKE $2 THEN 1.0 ELSE word_similarity($1, name) END AS score
+		FROM products
+		WHERE status = 'published' AND deleted_at IS NULL
+			AND (name ILIKE $2 OR $1 <% name)
+		UNION ALL
+		SELECT 'category' AS type, id, name AS text,
+			CASE WHEN name ILIKE $2 THEN 1.0 ELSE word_similarity($1, name) END AS score
+		FROM categories
+		WHERE name ILIKE $2 OR $1 <% name
+	) s
+	ORDER BY score DESC, text ASC
+	LIMIT $3
+	`
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// аналог SET LOCAL, але з параметром: поріг діє до кінця транзакції
+	threshold := strconv.FormatFloat(minSimilarity, 'f', -1, 64)
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`, threshold); err != nil {
+		return nil, fmt.Errorf("failed to set similarity threshold: %w", err)
+	}
+
+	// отримання підказок
+	var suggestions []*models.Suggestion
+	err = tx.SelectContext(ctx, &suggestions, sqlQuery, query, escapeLike(query)+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest products: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit suggestions: %w", err)
+	}
+	return suggestions, nil
+}
+
+// likeEscaper екранує спецсимволи шаблону LIKE (символ екранування за замовчуванням - \)
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike екранує введений текст, щоб шаблон LIKE шукав його буквально
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// ApplySchedule публікує та знімає з публікації продукти, час яких настав
+func (p *productRepo) ApplySchedule(ctx context.Context) (int, int, error) {
+	publishQuery := `
 	UPDATE products
-	SET stock = $1
+	SET status = 'published',
+		publish_at = NULL,
 		updated_at = NOW()
-	WHERE id = $2
+	WHERE status = 'draft' AND publish_at <= NOW() AND deleted_at IS NULL
 	`
-	// оновлення количество продукта по ID
-	res, err := p.db.ExecContext(ctx, query, quantity, id)
-	// обробка помилок
+
+	unpublishQuery := `
+	UPDATE products
+	SET status = 'archived',
+		unpublish_at = NULL,
+		updated_at = NOW()
+	WHERE status = 'published' AND unpublish_at <= NOW() AND deleted_at IS NULL
+	`
+
+	// публікація запланованих продуктів
+	res, err := p.db.ExecContext(ctx, publishQuery)
 	if err != nil {
-		return fmt.Errorf("failed to update stock: %w", err)
+		return 0, 0, fmt.Errorf("failed to publish scheduled products: %w", err)
 	}
-	rows, _ := res.RowsAffected()
-	if rows == 0 {
-		return fmt.Errorf("product not found")
+	published, _ := res.RowsAffected()
+
+	// зняття з публікації
+	res, err = p.db.ExecContext(ctx, unpublishQuery)
+	if err != nil {
+		return int(published), 0, fmt.Errorf("failed to unpublish scheduled products: %w", err)
 	}
-	return nil
+	unpublished, _ := res.RowsAffected()
+
+	return int(published), int(unpublished), nil
+}
+
+// UpsertBySKU створює продукт або оновлює існуючий з тим самим SKU.
+// Порожній статус означає "не змінювати" для існуючого продукту та "published" для нового.
+// Slug задається лише новому продукту, в існуючого він не змінюється.
+// Stock задає загальний залишок: різниця застосовується до складу за замовчуванням.
+// Видалений продукт з тим самим SKU не оновлюється і не відновлюється (повертає sql.ErrNoRows).
+func (p *productRepo) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	query := `
+	INSERT INTO products (id, sku, name, description, price, stock, category_id, image_url, status, slug, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, 0, $6, $7, COALESCE(NULLIF($8, ''), 'published'), $9, NOW(), NOW())
+	ON CONFLICT (sku) DO UPDATE
+	SET name = EXCLUDED.name,
+		description = EXCLUDED.description,
+		price = EXCLUDED.price,
+		category_id = EXCLUDED.category_id,
+		image_url = EXCLUDED.image_url,
+		status = COALESCE(NULLIF($8, ''), products.status),
+		updated_at = NOW()
+	WHERE products.deleted_at IS NULL
+	RETURNING id, slug, status, stock, is_bundle, created_at, updated_at, (xmax = 0) AS inserted
+	`
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// xmax = 0 тільки для щойно вставленого рядка
+	var inserted bool
+	var oldStock int
+	err = tx.QueryRowxContext(ctx, query,
+		uuid.New(),
+		product.SKU,
+		product.Name,
+		product.Description,
+		product.Price,
+		product.CategoryID,
+		product.ImageURL,
+		product.Status,
+		product.Slug,
+	).Scan(&product.ID, &product.Slug, &product.Status, &oldStock, &product.IsBundle, &product.CreatedAt, &product.UpdatedAt, &inserted)
+	if err != nil {
+		// конфлікт з видаленим продуктом: умова DO UPDATE не виконалась і рядок не повернуто
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, err
+		}
+		return false, fmt.Errorf("failed to upsert product: %w", err)
+	}
+
+	// залишок комплекту обчислюється з компонентів, імпорт його не змінює
+	if product.Stock != oldStock && !product.IsBundle {
+		if err := adjustDefaultStock(ctx, tx, product.ID, product.Stock-oldStock, models.MovementSource{Reason: models.MovementImport}); err != nil {
+			return false, err
+		}
+		if err := syncProductStock(ctx, tx, product.ID); err != nil {
+			return false, err
+		}
+	}
+
+	// імпорт міг змінити ціну існуючого продукту
+	if !inserted {
+		if err := triggerPriceAlerts(ctx, tx, product.ID); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit product upsert: %w", err)
+	}
+	return inserted, nil
+}
+
+// ExportProducts потоково читає не видалені продукти (з назвою категорії) через серверний курсор
+// і викликає fn для кожного. Порожній статус означає будь-який статус.
+func (p *productRepo) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	// курсор існує тільки в межах транзакції
+	tx, err := p.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin export transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	where := "p.deleted_at IS NULL"
+	if status != "" {
+		where += " AND p.status = " + pq.QuoteLiteral(status)
+	}
+	declare := `
+	DECLARE product_export NO SCROLL CURSOR FOR
+	SELECT p.id, p.sku, p.slug, p.name, p.description, p.price, p.stock, p.category_id, p.image_url, p.tags,
+		p.status, p.rating_avg, p.rating_count, p.publish_at, p.unpublish_at, p.backorder_policy, p.available_at, p.is_digital, p.is_bundle, p.sale_price, p.sale_starts_at, p.sale_ends_at, p.deleted_at, p.created_at, p.updated_at,
+		c.name AS category_name
+	FROM products p
+	LEFT JOIN categories c ON c.id = p.category_id
+	WHERE ` + where + `
+	ORDER BY p.created_at, p.id
+	`
+	if _, err := tx.ExecContext(ctx, declare); err != nil {
+		return fmt.Errorf("failed to declare export cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM product_export", exportFetchSize)
+	for {
+		fetched, err := p.fetchExportBatch(ctx, tx, fetch, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < exportFetchSize {
+			break
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "CLOSE product_export"); err != nil {
+		return fmt.Errorf("failed to close export cursor: %w", err)
+	}
+	return tx.Commit()
+}
+
+// fetchExportBatch читає один пакет з курсору експорту та повертає кількість рядків
+func (p *productRepo) fetchExportBatch(ctx context.Context, tx *sqlx.Tx, fetch string, fn func(*models.ExportProduct) error) (int, error) {
+	rows, err := tx.QueryxContext(ctx, fetch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch exported products: %w", err)
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		var product models.ExportProduct
+		if err := rows.StructScan(&product); err != nil {
+			return fetched, fmt.Errorf("failed to scan exported product: %w", err)
+		}
+		fetched++
+		if err := fn(&product); err != nil {
+			return fetched, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fetched, fmt.Errorf("failed to fetch exported products: %w", err)
+	}
+	return fetched, nil
 }
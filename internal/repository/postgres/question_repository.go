@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// QuestionRepository інтерфейс для роботи з питаннями та відповідями про продукти
+type QuestionRepository interface {
+	CreateQuestion(ctx context.Context, question *models.ProductQuestion) error
+	GetQuestion(ctx context.Context, id uuid.UUID) (*models.ProductQuestion, error)
+	ListQuestions(ctx context.Context, filter models.QuestionFilter) ([]*models.ProductQuestion, error)
+	CountQuestions(ctx context.Context, filter models.QuestionFilter) (total int, estimated bool, err error)
+	UpdateQuestionStatus(ctx context.Context, id uuid.UUID, status string) (*models.ProductQuestion, error)
+	CreateAnswer(ctx context.Context, answer *models.ProductAnswer) error
+	GetAnswer(ctx context.Context, id uuid.UUID) (*models.ProductAnswer, error)
+	ListAnswers(ctx context.Context, filter models.AnswerFilter) ([]*models.ProductAnswer, error)
+	UpdateAnswerStatus(ctx context.Context, id uuid.UUID, status string) (*models.ProductAnswer, error)
+	VoteAnswer(ctx context.Context, answerID, userID uuid.UUID, helpful bool) (*models.ProductAnswer, error)
+}
+
+type questionRepo struct {
+	db *database.DB
+}
+
+func NewQuestionRepository(db *database.DB) QuestionRepository {
+	return &questionRepo{db: db}
+}
+
+// CreateQuestion створює питання
+func (q *questionRepo) CreateQuestion(ctx context.Context, question *models.ProductQuestion) error {
+	query := `
+	INSERT INTO product_questions (id, product_id, user_id, body, status, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+	RETURNING created_at, updated_at
+	`
+
+	question.ID = uuid.New()
+	err := q.db.QueryRowxContext(ctx, query,
+		question.ID,
+		question.ProductID,
+		question.UserID,
+		question.Body,
+		question.Status,
+	).Scan(&question.CreatedAt, &question.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create question: %w", err)
+	}
+	return nil
+}
+
+// GetQuestion повертає питання за ID
+func (q *questionRepo) GetQuestion(ctx context.Context, id uuid.UUID) (*models.ProductQuestion, error) {
+	query := `
+	SELECT id, product_id, user_id, body, status, created_at, updated_at
+	FROM product_questions
+	WHERE id = $1
+	`
+
+	var question models.ProductQuestion
+	err := q.db.GetContext(ctx, &question, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	return &question, nil
+}
+
+// ListQuestions повертає питання з кількістю схвалених відповідей (нові першими)
+func (q *questionRepo) ListQuestions(ctx context.Context, filter models.QuestionFilter) ([]*models.ProductQuestion, error) {
+	where, args := questionFilter(filter)
+	query := `
+	SELECT q.id, q.product_id, q.user_id, q.body, q.status, q.created_at, q.updated_at,
+		(SELECT COUNT(*) FROM product_answers a WHERE a.question_id = q.id AND a.status = 'approved') AS answer_count
+	FROM product_questions q
+	WHERE 1=1
+	` + where
+
+	query += " ORDER BY q.created_at DESC"
+
+	// Pagination
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	var questions []*models.ProductQuestion
+	err := q.db.SelectContext(ctx, &questions, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+	return questions, nil
+}
+
+// CountQuestions повертає кількість питань за фільтром (без пагінації); true - якщо кількість оціночна
+func (q *questionRepo) CountQuestions(ctx context.Context, filter models.QuestionFilter) (int, bool, error) {
+	where, args := questionFilter(filter)
+	total, estimated, err := countRows(ctx, q.db, "FROM product_questions q WHERE 1=1"+where, args)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to count questions: %w", err)
+	}
+	return total, estimated, nil
+}
+
+// questionFilter будує умови фільтрації питань (аліас таблиці q)
+func questionFilter(filter models.QuestionFilter) (string, []interface{}) {
+	var where string
+	var args []interface{}
+	if filter.ProductID != nil {
+		args = append(args, *filter.ProductID)
+		where += fmt.Sprintf(" AND q.product_id = $%d", len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += fmt.Sprintf(" AND q.status = $%d", len(args))
+	}
+	return where, args
+}
+
+// UpdateQuestionStatus змінює статус модерації питання
+func (q *questionRepo) UpdateQuestionStatus(ctx context.Context, id uuid.UUID, status string) (*models.ProductQuestion, error) {
+	query := `
+	UPDATE product_questions
+	SET status = $1,
+		updated_at = NOW()
+	WHERE id = $2
+	RETURNING id, product_id, user_id, body, status, created_at, updated_at
+	`
+
+	var question models.ProductQuestion
+	err := q.db.GetContext(ctx, &question, query, status, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to update question status: %w", err)
+	}
+	return &question, nil
+}
+
+// CreateAnswer створює відповідь на питання
+func (q *questionRepo) CreateAnswer(ctx context.Context, answer *models.ProductAnswer) error {
+	query := `
+	INSERT INTO product_answers (id, question_id, user_id, body, is_admin, verified_purchase, status, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+	RETURNING created_at, updated_at
+	`
+
+	answer.ID = uuid.New()
+	err := q.db.QueryRowxContext(ctx, query,
+		answer.ID,
+		answer.QuestionID,
+		answer.UserID,
+		answer.Body,
+		answer.IsAdmin,
+		answer.VerifiedPurchase,
+		answer.Status,
+	).Scan(&answer.CreatedAt, &answer.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create answer: %w", err)
+	}
+	return nil
+}
+
+// GetAnswer повертає відповідь за ID
+func (q *questionRepo) GetAnswer(ctx context.Context, id uuid.UUID) (*models.ProductAnswer, error) {
+	query := `
+	SELECT id, question_id, user_id, body, is_admin, verified_purchase, status, helpful_count, unhelpful_count, created_at, updated_at
+	FROM product_answers
+	WHERE id = $1
+	`
+
+	var answer models.ProductAnswer
+	err := q.db.GetContext(ctx, &answer, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+	return &answer, nil
+}
+
+// ListAnswers повертає відповіді; відповіді адміністратора та найкорисніші йдуть першими
+func (q *questionRepo) ListAnswers(ctx context.Context, filter models.AnswerFilter) ([]*models.ProductAnswer, error) {
+	query := `
+	SELECT id, question_id, user_id, body, is_admin, verified_purchase, status, helpful_count, unhelpful_count, created_at, updated_at
+	FROM product_answers
+	WHERE 1=1
+	`
+
+	args := []interface{}{}
+	argsCount := 1
+
+	// фільтрація
+	if filter.QuestionIDs != nil {
+		ids := make([]string, len(filter.QuestionIDs))
+		for i, id := range filter.QuestionIDs {
+			ids[i] = id.String()
+		}
+		query += fmt.Sprintf(" AND question_id = ANY($%d::uuid[])", argsCount)
+		args = append(args, pq.Array(ids))
+		argsCount++
+	}
+
+	if filter.Status != "" {
+		query += fmt.Sprintf(" AND status = $%d", argsCount)
+		args = append(args, filter.Status)
+		argsCount++
+	}
+
+	query += " ORDER BY is_admin DESC, helpful_count - unhelpful_count DESC, created_at ASC"
+
+	// Pagination
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argsCount)
+		args = append(args, filter.Limit)
+		argsCount++
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argsCount)
+		args = append(args, filter.Offset)
+		argsCount++
+	}
+
+	var answers []*models.ProductAnswer
+	err := q.db.SelectContext(ctx, &answers, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list answers: %w", err)
+	}
+	return answers, nil
+}
+
+// UpdateAnswerStatus змінює статус модерації відповіді
+func (q *questionRepo) UpdateAnswerStatus(ctx context.Context, id uuid.UUID, status string) (*models.ProductAnswer, error) {
+	query := `
+	UPDATE product_answers
+	SET status = $1,
+		updated_at = NOW()
+	WHERE id = $2
+	RETURNING id, question_id, user_id, body, is_admin, verified_purchase, status, helpful_count, unhelpful_count, created_at, updated_at
+	`
+
+	var answer models.ProductAnswer
+	err := q.db.GetContext(ctx, &answer, query, status, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to update answer status: %w", err)
+	}
+	return &answer, nil
+}
+
+// VoteAnswer зберігає голос користувача (повторний голос замінює попередній)
+// та перераховує лічильники корисності відповіді
+func (q *questionRepo) VoteAnswer(ctx context.Context, answerID, userID uuid.UUID, helpful bool) (*models.ProductAnswer, error) {
+	voteQuery := `
+	INSERT INTO product_answer_votes (answer_id, user_id, helpful, created_at)
+	VALUES ($1, $2, $3, NOW())
+	ON CONFLICT (answer_id, user_id) DO UPDATE
+	SET helpful = EXCLUDED.helpful
+	`
+
+	countQuery := `
+	UPDATE product_answers a
+	SET helpful_count = v.helpful,
+		unhelpful_count = v.unhelpful
+	FROM (
+		SELECT COUNT(*) FILTER (WHERE helpful) AS helpful,
+			COUNT(*) FILTER (WHERE NOT helpful) AS unhelpful
+		FROM product_answer_votes
+		WHERE answer_id = $1
+	) v
+	WHERE a.id = $1
+	RETURNING a.id, a.question_id, a.user_id, a.body, a.is_admin, a.verified_purchase, a.status,
+		a.helpful_count, a.unhelpful_count, a.created_at, a.updated_at
+	`
+
+	tx, err := q.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, voteQuery, answerID, userID, helpful); err != nil {
+		return nil, fmt.Errorf("failed to save vote: %w", err)
+	}
+
+	var answer models.ProductAnswer
+	if err := tx.GetContext(ctx, &answer, countQuery, answerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to update answer votes: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit vote: %w", err)
+	}
+	return &answer, nil
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// RecommendationRepository інтерфейс для роботи з рекомендаціями продуктів
+type RecommendationRepository interface {
+	Recompute(ctx context.Context, minSupport, maxPerProduct int) (int, error)
+	ListForProduct(ctx context.Context, productID uuid.UUID, limit int) ([]*models.Product, error)
+	ListForProducts(ctx context.Context, productIDs []uuid.UUID, limit int) ([]*models.Product, error)
+	CategoryBestsellers(ctx context.Context, categoryID *uuid.UUID, excludeIDs []uuid.UUID, limit int) ([]*models.Product, error)
+}
+
+type recommendationRepo struct {
+	db *database.DB
+}
+
+func NewRecommendationRepository(db *database.DB) RecommendationRepository {
+	return &recommendationRepo{db: db}
+}
+
+// колонки продукту з префіксом таблиці p
+const recommendedProductColumns = `
+	p.id, p.sku, p.slug, p.name, p.description, p.price, p.stock, p.category_id, p.image_url, p.tags,
+	p.status, p.rating_avg, p.rating_count, p.publish_at, p.unpublish_at, p.backorder_policy, p.available_at, p.is_digital, p.is_bundle, p.sale_price, p.sale_starts_at, p.sale_ends_at, p.deleted_at, p.created_at, p.updated_at`
+
+// Recompute перераховує рекомендації за спільними покупками в нескасованих замовленнях.
+// Оцінка пари - косинусна схожість: co_count / sqrt(orders(a) * orders(b)).
+// Повертає кількість збережених пар
+func (r *recommendationRepo) Recompute(ctx context.Context, minSupport, maxPerProduct int) (int, error) {
+	query := `
+	WITH purchases AS (
+		SELECT DISTINCT oi.order_id, oi.product_id
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE o.status <> 'cancelled'
+	),
+	frequency AS (
+		SELECT product_id, COUNT(*) AS orders
+		FROM purchases
+		GROUP BY product_id
+	),
+	pairs AS (
+		SELECT a.product_id, b.product_id AS recommended_id, COUNT(*) AS co_count
+		FROM purchases a
+		JOIN purchases b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+		GROUP BY a.product_id, b.product_id
+		HAVING COUNT(*) >= $1
+	),
+	scored AS (
+		SELECT pr.product_id, pr.recommended_id, pr.co_count,
+			pr.co_count / SQRT(fa.orders::float8 * fb.orders) AS score
+		FROM pairs pr
+		JOIN frequency fa ON fa.product_id = pr.product_id
+		JOIN frequency fb ON fb.product_id = pr.recommended_id
+	),
+	ranked AS (
+		SELECT *, ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY score DESC, co_count DESC) AS rank
+		FROM scored
+	)
+	INSERT INTO product_recommendations (product_id, recommended_id, score, co_count, computed_at)
+	SELECT product_id, recommended_id, score, co_count, NOW()
+	FROM ranked
+	WHERE rank <= $2
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// читачі бачать старі рекомендації, доки транзакція не завершиться
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_recommendations`); err != nil {
+		return 0, fmt.Errorf("failed to clear recommendations: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, query, minSupport, maxPerProduct)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute recommendations: %w", err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit recommendations: %w", err)
+	}
+	return int(count), nil
+}
+
+// ListForProduct повертає видимі продукти, які найчастіше купують разом з продуктом
+func (r *recommendationRepo) ListForProduct(ctx context.Context, productID uuid.UUID, limit int) ([]*models.Product, error) {
+	query := `
+	SELECT` + recommendedProductColumns + `
+	FROM product_recommendations r
+	JOIN products p ON p.id = r.recommended_id
+	WHERE r.product_id = $1 AND p.status = 'published' AND p.deleted_at IS NULL AND ` + availableSQL("p.") + `
+	ORDER BY r.score DESC, r.co_count DESC
+	LIMIT $2
+	`
+
+	var products []*models.Product
+	if err := r.db.SelectContext(ctx, &products, query, productID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list recommendations: %w", err)
+	}
+	return products, nil
+}
+
+// ListForProducts повертає рекомендації для набору продуктів (наприклад, кошика):
+// оцінки сумуються, а самі продукти набору виключаються
+func (r *recommendationRepo) ListForProducts(ctx context.Context, productIDs []uuid.UUID, limit int) ([]*models.Product, error) {
+	query := `
+	SELECT` + recommendedProductColumns + `
+	FROM (
+		SELECT recommended_id, SUM(score) AS score
+		FROM product_recommendations
+		WHERE product_id = ANY($1::uuid[]) AND NOT recommended_id = ANY($1::uuid[])
+		GROUP BY recommended_id
+	) r
+	JOIN products p ON p.id = r.recommended_id
+	WHERE p.status = 'published' AND p.deleted_at IS NULL AND ` + availableSQL("p.") + `
+	ORDER BY r.score DESC, p.rating_avg DESC
+	LIMIT $2
+	`
+
+	var products []*models.Product
+	if err := r.db.SelectContext(ctx, &products, query, pq.Array(uuidStrings(productIDs)), limit); err != nil {
+		return nil, fmt.Errorf("failed to list recommendations: %w", err)
+	}
+	return products, nil
+}
+
+// CategoryBestsellers повертає найпопулярніші видимі продукти категорії
+// (або всього каталогу, якщо категорія не задана), крім excludeIDs
+func (r *recommendationRepo) CategoryBestsellers(ctx context.Context, categoryID *uuid.UUID, excludeIDs []uuid.UUID, limit int) ([]*models.Product, error) {
+	query := `
+	SELECT` + recommendedProductColumns + `
+	FROM products p
+	LEFT JOIN (
+		SELECT oi.product_id, SUM(oi.quantity) AS sold
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE o.status <> 'cancelled'
+		GROUP BY oi.product_id
+	) s ON s.product_id = p.id
+	WHERE p.status = 'published' AND p.deleted_at IS NULL AND ` + availableSQL("p.") + `
+		AND NOT p.id = ANY($1::uuid[])
+	`
+	args := []interface{}{pq.Array(uuidStrings(excludeIDs))}
+	argsCount := 2
+
+	if categoryID != nil {
+		query += fmt.Sprintf(" AND p.category_id = $%d", argsCount)
+		args = append(args, *categoryID)
+		argsCount++
+	}
+
+	query += fmt.Sprintf(" ORDER BY COALESCE(s.sold, 0) DESC, p.rating_avg DESC, p.created_at DESC LIMIT $%d", argsCount)
+	args = append(args, limit)
+
+	var products []*models.Product
+	if err := r.db.SelectContext(ctx, &products, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list bestsellers: %w", err)
+	}
+	return products, nil
+}
+
+// uuidStrings перетворює UUID у рядки для передачі масивом у postgres
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// ReviewRepository інтерфейс для роботи з відгуками
+type ReviewRepository interface {
+	Create(ctx context.Context, review *models.Review) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Review, error)
+	GetByUserAndProduct(ctx context.Context, userID, productID uuid.UUID) (*models.Review, error)
+	List(ctx context.Context, filter models.ReviewFilter) ([]*models.Review, error)
+	Count(ctx context.Context, filter models.ReviewFilter) (total int, estimated bool, err error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string) (*models.Review, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	HasDeliveredPurchase(ctx context.Context, userID, productID uuid.UUID) (bool, error)
+}
+
+type reviewRepo struct {
+	db *database.DB
+}
+
+func NewReviewRepository(db *database.DB) ReviewRepository {
+	return &reviewRepo{db: db}
+}
+
+// Create створює новий відгук
+func (r *reviewRepo) Create(ctx context.Context, review *models.Review) error {
+	query := `
+	INSERT INTO reviews (id, product_id, user_id, rating, title, body, verified_purchase, status, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+	RETURNING created_at, updated_at
+	`
+
+	review.ID = uuid.New()
+	err := r.db.QueryRowxContext(ctx, query,
+		review.ID,
+		review.ProductID,
+		review.UserID,
+		review.Rating,
+		review.Title,
+		review.Body,
+		review.VerifiedPurchase,
+		review.Status,
+	).Scan(&review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+	return nil
+}
+
+// GetByID повертає відгук за ID
+func (r *reviewRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Review, error) {
+	query := `
+	SELECT id, product_id, user_id, rating, title, body, verified_purchase, status, created_at, updated_at
+	FROM reviews
+	WHERE id = $1
+	`
+
+	var review models.Review
+	err := r.db.GetContext(ctx, &review, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	return &review, nil
+}
+
+// GetByUserAndProduct повертає відгук користувача на продукт
+func (r *reviewRepo) GetByUserAndProduct(ctx context.Context, userID, productID uuid.UUID) (*models.Review, error) {
+	query := `
+	SELECT id, product_id, user_id, rating, title, body, verified_purchase, status, created_at, updated_at
+	FROM reviews
+	WHERE user_id = $1 AND product_id = $2
+	`
+
+	var review models.Review
+	err := r.db.GetContext(ctx, &review, query, userID, productID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	return &review, nil
+}
+
+// List повертає відгуки за фільтром
+func (r *reviewRepo) List(ctx context.Context, filter models.ReviewFilter) ([]*models.Review, error) {
+	where, args := reviewFilter(filter)
+	query := `
+	SELECT id, product_id, user_id, rating, title, body, verified_purchase, status, created_at, updated_at
+	FROM reviews
+	WHERE 1=1
+	` + where
+
+	orderBy := "created_at DESC"
+	switch filter.OrderBy {
+	case "oldest":
+		orderBy = "created_at ASC"
+	case "rating_desc":
+		orderBy = "rating DESC, created_at DESC"
+	case "rating_asc":
+		orderBy = "rating ASC, created_at DESC"
+	}
+	query += " ORDER BY " + orderBy
+
+	// Pagination
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	var reviews []*models.Review
+	err := r.db.SelectContext(ctx, &reviews, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+// Count повертає кількість відгуків за фільтром (без пагінації); true - якщо кількість оціночна
+func (r *reviewRepo) Count(ctx context.Context, filter models.ReviewFilter) (int, bool, error) {
+	where, args := reviewFilter(filter)
+	total, estimated, err := countRows(ctx, r.db, "FROM reviews WHERE 1=1"+where, args)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to count reviews: %w", err)
+	}
+	return total, estimated, nil
+}
+
+// reviewFilter будує умови фільтрації відгуків
+func reviewFilter(filter models.ReviewFilter) (string, []interface{}) {
+	var where string
+	var args []interface{}
+	if filter.ProductID != nil {
+		args = append(args, *filter.ProductID)
+		where += fmt.Sprintf(" AND product_id = $%d", len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if filter.Rating > 0 {
+		args = append(args, filter.Rating)
+		where += fmt.Sprintf(" AND rating = $%d", len(args))
+	}
+	if filter.VerifiedOnly {
+		where += " AND verified_purchase"
+	}
+	return where, args
+}
+
+// UpdateStatus змінює статус модерації відгуку та перераховує рейтинг продукту
+func (r *reviewRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status string) (*models.Review, error) {
+	query := `
+	UPDATE reviews
+	SET status = $1,
+		updated_at = NOW()
+	WHERE id = $2
+	RETURNING id, product_id, user_id, rating, title, body, verified_purchase, status, created_at, updated_at
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var review models.Review
+	if err := tx.GetContext(ctx, &review, query, status, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to update review status: %w", err)
+	}
+
+	if err := refreshProductRating(ctx, tx, review.ProductID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit review status: %w", err)
+	}
+	return &review, nil
+}
+
+// Delete видаляє відгук та перераховує рейтинг продукту
+func (r *reviewRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM reviews WHERE id = $1 RETURNING product_id`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var productID uuid.UUID
+	if err := tx.GetContext(ctx, &productID, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	if err := refreshProductRating(ctx, tx, productID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review deletion: %w", err)
+	}
+	return nil
+}
+
+// HasDeliveredPurchase перевіряє, чи є у користувача доставлене замовлення з продуктом
+func (r *reviewRepo) HasDeliveredPurchase(ctx context.Context, userID, productID uuid.UUID) (bool, error) {
+	query := `
+	SELECT EXISTS (
+		SELECT 1
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE o.user_id = $1 AND oi.product_id = $2 AND o.status = 'delivered'
+	)
+	`
+
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, query, userID, productID); err != nil {
+		return false, fmt.Errorf("failed to check purchase: %w", err)
+	}
+	return exists, nil
+}
+
+// refreshProductRating перераховує середній рейтинг та кількість схвалених відгуків продукту
+func refreshProductRating(ctx context.Context, tx *sqlx.Tx, productID uuid.UUID) error {
+	query := `
+	UPDATE products
+	SET rating_avg = COALESCE(r.avg, 0),
+		rating_count = r.count
+	FROM (
+		SELECT ROUND(AVG(rating), 2) AS avg, COUNT(*) AS count
+		FROM reviews
+		WHERE product_id = $1 AND status = 'approved'
+	) r
+	WHERE products.id = $1
+	`
+
+	if _, err := tx.ExecContext(ctx, query, productID); err != nil {
+		return fmt.Errorf("failed to refresh product rating: %w", err)
+	}
+	return nil
+}
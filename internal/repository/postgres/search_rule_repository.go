@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// SearchRuleRepository інтерфейс для роботи з правилами мерчандайзингу пошуку
+type SearchRuleRepository interface {
+	CreateSynonymSet(ctx context.Context, set *models.SynonymSet) error
+	ListSynonymSets(ctx context.Context) ([]*models.SynonymSet, error)
+	DeleteSynonymSet(ctx context.Context, id uuid.UUID) error
+	GetSynonyms(ctx context.Context, term string) ([]string, error)
+	UpsertBoost(ctx context.Context, boost *models.ProductBoost) error
+	ListBoosts(ctx context.Context) ([]*models.ProductBoost, error)
+	DeleteBoost(ctx context.Context, productID uuid.UUID) error
+	CreatePin(ctx context.Context, pin *models.SearchPin) error
+	ListPins(ctx context.Context, query string) ([]*models.SearchPin, error)
+	DeletePin(ctx context.Context, id uuid.UUID) error
+}
+
+type searchRuleRepo struct {
+	db *database.DB
+}
+
+func NewSearchRuleRepository(db *database.DB) SearchRuleRepository {
+	return &searchRuleRepo{db: db}
+}
+
+// CreateSynonymSet створює набір синонімів
+func (s *searchRuleRepo) CreateSynonymSet(ctx context.Context, set *models.SynonymSet) error {
+	query := `
+	INSERT INTO search_synonyms (id, terms, created_at)
+	VALUES ($1, $2, NOW())
+	RETURNING created_at
+	`
+
+	// присвоєння айді набору
+	set.ID = uuid.New()
+	// створення набору
+	err := s.db.QueryRowxContext(ctx, query, set.ID, set.Terms).Scan(&set.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create synonym set: %w", err)
+	}
+	return nil
+}
+
+// ListSynonymSets повертає всі набори синонімів
+func (s *searchRuleRepo) ListSynonymSets(ctx context.Context) ([]*models.SynonymSet, error) {
+	query := `
+	SELECT id, terms, created_at
+	FROM search_synonyms
+	ORDER BY created_at DESC
+	`
+
+	var sets []*models.SynonymSet
+	err := s.db.SelectContext(ctx, &sets, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list synonym sets: %w", err)
+	}
+	return sets, nil
+}
+
+// DeleteSynonymSet видаляє набір синонімів
+func (s *searchRuleRepo) DeleteSynonymSet(ctx context.Context, id uuid.UUID) error {
+	query := `
+	DELETE FROM search_synonyms WHERE id = $1
+	`
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete synonym set: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetSynonyms повертає всі синоніми терміну з усіх наборів, де він зустрічається
+func (s *searchRuleRepo) GetSynonyms(ctx context.Context, term string) ([]string, error) {
+	query := `
+	SELECT DISTINCT unnest(terms)
+	FROM search_synonyms
+	WHERE $1 = ANY(terms)
+	`
+
+	var synonyms []string
+	err := s.db.SelectContext(ctx, &synonyms, query, term)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get synonyms: %w", err)
+	}
+	return synonyms, nil
+}
+
+// UpsertBoost встановлює коефіцієнт підсилення продукту
+func (s *searchRuleRepo) UpsertBoost(ctx context.Context, boost *models.ProductBoost) error {
+	query := `
+	INSERT INTO product_boosts (product_id, factor, created_at, updated_at)
+	VALUES ($1, $2, NOW(), NOW())
+	ON CONFLICT (product_id) DO UPDATE
+	SET factor = EXCLUDED.factor,
+		updated_at = NOW()
+	RETURNING created_at, updated_at
+	`
+
+	err := s.db.QueryRowxContext(ctx, query, boost.ProductID, boost.Factor).Scan(&boost.CreatedAt, &boost.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert boost: %w", err)
+	}
+	return nil
+}
+
+// ListBoosts повертає всі коефіцієнти підсилення
+func (s *searchRuleRepo) ListBoosts(ctx context.Context) ([]*models.ProductBoost, error) {
+	query := `
+	SELECT product_id, factor, created_at, updated_at
+	FROM product_boosts
+	ORDER BY factor DESC
+	`
+
+	var boosts []*models.ProductBoost
+	err := s.db.SelectContext(ctx, &boosts, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list boosts: %w", err)
+	}
+	return boosts, nil
+}
+
+// DeleteBoost видаляє коефіцієнт підсилення продукту
+func (s *searchRuleRepo) DeleteBoost(ctx context.Context, productID uuid.UUID) error {
+	query := `
+	DELETE FROM product_boosts WHERE product_id = $1
+	`
+
+	res, err := s.db.ExecContext(ctx, query, productID)
+	if err != nil {
+		return fmt.Errorf("failed to delete boost: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CreatePin закріплює продукт за пошуковим запитом
+func (s *searchRuleRepo) CreatePin(ctx context.Context, pin *models.SearchPin) error {
+	query := `
+	INSERT INTO search_pins (id, query, product_id, position, created_at)
+	VALUES ($1, $2, $3, $4, NOW())
+	ON CONFLICT (query, product_id) DO UPDATE
+	SET position = EXCLUDED.position
+	RETURNING id, created_at
+	`
+
+	// присвоєння айді закріплення
+	pin.ID = uuid.New()
+	err := s.db.QueryRowxContext(ctx, query, pin.ID, pin.Query, pin.ProductID, pin.Position).Scan(&pin.ID, &pin.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create pin: %w", err)
+	}
+	return nil
+}
+
+// ListPins повертає закріплення (для всіх запитів, якщо query порожній)
+func (s *searchRuleRepo) ListPins(ctx context.Context, query string) ([]*models.SearchPin, error) {
+	sqlQuery := `
+	SELECT id, query, product_id, position, created_at
+	FROM search_pins
+	WHERE $1 = '' OR query = $1
+	ORDER BY query ASC, position ASC
+	`
+
+	var pins []*models.SearchPin
+	err := s.db.SelectContext(ctx, &pins, sqlQuery, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pins: %w", err)
+	}
+	return pins, nil
+}
+
+// DeletePin видаляє закріплення
+func (s *searchRuleRepo) DeletePin(ctx context.Context, id uuid.UUID) error {
+	query := `
+	DELETE FROM search_pins WHERE id = $1
+	`
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete pin: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
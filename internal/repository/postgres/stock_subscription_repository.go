@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// StockSubscriptionRepository інтерфейс для роботи з підписками на надходження товару
+type StockSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *models.StockSubscription) error
+	GetActive(ctx context.Context, productID, userID uuid.UUID) (*models.StockSubscription, error)
+	Delete(ctx context.Context, productID, userID uuid.UUID) error
+	RequeueSoldOut(ctx context.Context) (int64, error)
+	ListReady(ctx context.Context, limit int) ([]*models.ReadyStockSubscription, error)
+	MarkNotified(ctx context.Context, ids []uuid.UUID) error
+}
+
+type stockSubscriptionRepo struct {
+	db *database.DB
+}
+
+func NewStockSubscriptionRepository(db *database.DB) StockSubscriptionRepository {
+	return &stockSubscriptionRepo{db: db}
+}
+
+// Create створює підписку. Якщо у користувача вже є активна підписка на продукт, повертає sql.ErrNoRows
+func (s *stockSubscriptionRepo) Create(ctx context.Context, subscription *models.StockSubscription) error {
+	query := `
+	INSERT INTO stock_subscriptions (id, product_id, user_id, created_at)
+	VALUES ($1, $2, $3, NOW())
+	ON CONFLICT (product_id, user_id) WHERE notified_at IS NULL DO NOTHING
+	RETURNING created_at
+	`
+
+	subscription.ID = uuid.New()
+	err := s.db.QueryRowxContext(ctx, query,
+		subscription.ID,
+		subscription.ProductID,
+		subscription.UserID,
+	).Scan(&subscription.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to create stock subscription: %w", err)
+	}
+	return nil
+}
+
+// GetActive повертає активну (ще не відправлену) підписку користувача на продукт (nil, якщо не знайдено)
+func (s *stockSubscriptionRepo) GetActive(ctx context.Context, productID, userID uuid.UUID) (*models.StockSubscription, error) {
+	query := `
+	SELECT id, product_id, user_id, created_at, ready_at, notified_at
+	FROM stock_subscriptions
+	WHERE product_id = $1 AND user_id = $2 AND notified_at IS NULL
+	`
+
+	var subscription models.StockSubscription
+	if err := s.db.GetContext(ctx, &subscription, query, productID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+// Delete видаляє активну підписку користувача на продукт
+func (s *stockSubscriptionRepo) Delete(ctx context.Context, productID, userID uuid.UUID) error {
+	query := `DELETE FROM stock_subscriptions WHERE product_id = $1 AND user_id = $2 AND notified_at IS NULL`
+
+	res, err := s.db.ExecContext(ctx, query, productID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete stock subscription: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// RequeueSoldOut повертає в очікування підписки на продукти, які знову закінчились до відправки сповіщень.
+// Порядок підписників зберігається, бо черга впорядкована за часом підписки
+func (s *stockSubscriptionRepo) RequeueSoldOut(ctx context.Context) (int64, error) {
+	query := `
+	UPDATE stock_subscriptions s
+	SET ready_at = NULL
+	FROM products p
+	WHERE s.product_id = p.id AND s.ready_at IS NOT NULL AND s.notified_at IS NULL AND p.stock <= 0
+	`
+
+	res, err := s.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue stock subscriptions: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	return rows, nil
+}
+
+// ListReady повертає підписки на продукти, що з'явились у наявності.
+// Черга справедлива: спершу найраніший підписник кожного продукту, потім другий тощо,
+// щоб популярний продукт не затримував сповіщення про інші
+func (s *stockSubscriptionRepo) ListReady(ctx context.Context, limit int) ([]*models.ReadyStockSubscription, error) {
+	query := `
+	SELECT id, product_id, user_id, created_at, ready_at, notified_at, product_name, product_slug, stock
+	FROM (
+		SELECT s.id, s.product_id, s.user_id, s.created_at, s.ready_at, s.notified_at,
+			p.name AS product_name, p.slug AS product_slug, p.stock,
+			ROW_NUMBER() OVER (PARTITION BY s.product_id ORDER BY s.created_at, s.id) AS position
+		FROM stock_subscriptions s
+		JOIN products p ON p.id = s.product_id
+		WHERE s.ready_at IS NOT NULL AND s.notified_at IS NULL
+			AND ` + availableSQL("p.") + ` AND p.status = 'published' AND p.deleted_at IS NULL
+	) queue
+	ORDER BY position, created_at, id
+	LIMIT $1
+	`
+
+	var subscriptions []*models.ReadyStockSubscription
+	if err := s.db.SelectContext(ctx, &subscriptions, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to list ready stock subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// MarkNotified позначає підписки як відправлені
+func (s *stockSubscriptionRepo) MarkNotified(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `UPDATE stock_subscriptions SET notified_at = NOW() WHERE id = ANY($1::uuid[])`
+	if _, err := s.db.ExecContext(ctx, query, pq.Array(uuidStrings(ids))); err != nil {
+		return fmt.Errorf("failed to mark stock subscriptions notified: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// subscriptionColumns колонки підписки для SELECT/RETURNING
+const subscriptionColumns = `id, user_id, status, interval_weeks, next_run_at, shipping_address, payment_method, payment_reference,
+	failed_attempts, retry_at, last_error, last_order_id, paused_at, cancelled_at, locked_until, created_at, updated_at`
+
+// SubscriptionRepository інтерфейс для роботи з підписками на регулярну доставку
+type SubscriptionRepository interface {
+	Create(ctx context.Context, subscription *models.Subscription, items []*models.SubscriptionItem) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
+	ListItems(ctx context.Context, subscriptionIDs []uuid.UUID) ([]*models.SubscriptionItem, error)
+	// Update та ReplaceItems змінюють підписку, лише якщо її updated_at не змінився після читання
+	Update(ctx context.Context, subscription *models.Subscription) error
+	ReplaceItems(ctx context.Context, subscription *models.Subscription, items []*models.SubscriptionItem) error
+	ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]*models.Subscription, error)
+	// Unlock знімає блокування планувальника після обробки
+	Unlock(ctx context.Context, id uuid.UUID) error
+	// ReserveCycle закріплює ID замовлення за циклом; повертає раніше закріплений ID, якщо цикл уже зарезервовано
+	ReserveCycle(ctx context.Context, subscriptionID uuid.UUID, cycleAt time.Time, orderID uuid.UUID) (uuid.UUID, error)
+	AddOrder(ctx context.Context, order *models.SubscriptionOrder) error
+	// GetOrder повертає замовлення підписки (nil, якщо не знайдено)
+	GetOrder(ctx context.Context, subscriptionID, orderID uuid.UUID) (*models.SubscriptionOrder, error)
+	// SetTransaction записує ID транзакції оплати замовлення підписки
+	SetTransaction(ctx context.Context, subscriptionID, orderID uuid.UUID, transactionID string) error
+	ListOrders(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.SubscriptionOrder, error)
+}
+
+type subscriptionRepo struct {
+	db *database.DB
+}
+
+func NewSubscriptionRepository(db *database.DB) SubscriptionRepository {
+	return &subscriptionRepo{db: db}
+}
+
+// Create створює підписку разом з товарами
+func (r *subscriptionRepo) Create(ctx context.Context, subscription *models.Subscription, items []*models.SubscriptionItem) error {
+	query := `
+	INSERT INTO subscriptions (id, user_id, status, interval_weeks, next_run_at, shipping_address, payment_method, payment_reference, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+	RETURNING created_at, updated_at
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowxContext(ctx, query,
+		subscription.ID,
+		subscription.UserID,
+		subscription.Status,
+		subscription.IntervalWeeks,
+		subscription.NextRunAt,
+		subscription.ShippingAddress,
+		subscription.PaymentMethod,
+		subscription.PaymentReference,
+	).Scan(&subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	if err := insertSubscriptionItems(ctx, tx, subscription.ID, items); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit subscription: %w", err)
+	}
+	return nil
+}
+
+// GetByID повертає підписку за ID (nil, якщо не знайдено)
+func (r *subscriptionRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions WHERE id = $1`
+
+	var subscription models.Subscription
+	if err := r.db.GetContext(ctx, &subscription, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+// ListByUser повертає підписки користувача, новіші першими
+func (r *subscriptionRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions WHERE user_id = $1 ORDER BY created_at DESC, id`
+
+	var subscriptions []*models.Subscription
+	if err := r.db.SelectContext(ctx, &subscriptions, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// CountByUser повертає кількість не скасованих підписок користувача
+func (r *subscriptionRepo) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM subscriptions WHERE user_id = $1 AND status <> 'cancelled'`
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count subscriptions: %w", err)
+	}
+	return count, nil
+}
+
+// ListItems повертає товари підписок з назвою та поточною ціною продуктів
+func (r *subscriptionRepo) ListItems(ctx context.Context, subscriptionIDs []uuid.UUID) ([]*models.SubscriptionItem, error) {
+	if len(subscriptionIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+	SELECT si.subscription_id, si.product_id, si.quantity, p.name AS product_name, ` + effectivePriceSQL + ` AS price
+	FROM subscription_items si
+	JOIN products p ON p.id = si.product_id
+	WHERE si.subscription_id = ANY($1::uuid[])
+	ORDER BY si.subscription_id, p.name, p.id
+	`
+
+	var items []*models.SubscriptionItem
+	if err := r.db.SelectContext(ctx, &items, query, pq.Array(uuidStrings(subscriptionIDs))); err != nil {
+		return nil, fmt.Errorf("failed to list subscription items: %w", err)
+	}
+	return items, nil
+}
+
+// Update зберігає стан підписки, прочитаної з версією subscription.UpdatedAt, та оновлює версію.
+// Якщо підписку не знайдено або її вже змінив інший запит, повертає sql.ErrNoRows
+func (r *subscriptionRepo) Update(ctx context.Context, subscription *models.Subscription) error {
+	query := `
+	UPDATE subscriptions
+	SET status = $1,
+		interval_weeks = $2,
+		next_run_at = $3,
+		shipping_address = $4,
+		payment_method = $5,
+		payment_reference = $6,
+		failed_attempts = $7,
+		retry_at = $8,
+		last_error = $9,
+		last_order_id = $10,
+		paused_at = $11,
+		cancelled_at = $12,
+		updated_at = NOW()
+	WHERE id = $13 AND updated_at = $14
+	RETURNING updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query,
+		subscription.Status,
+		subscription.IntervalWeeks,
+		subscription.NextRunAt,
+		subscription.ShippingAddress,
+		subscription.PaymentMethod,
+		subscription.PaymentReference,
+		subscription.FailedAttempts,
+		subscription.RetryAt,
+		subscription.LastError,
+		subscription.LastOrderID,
+		subscription.PausedAt,
+		subscription.CancelledAt,
+		subscription.ID,
+		subscription.UpdatedAt,
+	).Scan(&subscription.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+	return nil
+}
+
+// ReplaceItems замінює товари підписки та оновлює її версію;
+// якщо підписку вже змінив інший запит, повертає sql.ErrNoRows
+func (r *subscriptionRepo) ReplaceItems(ctx context.Context, subscription *models.Subscription, items []*models.SubscriptionItem) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// перевірка версії блокує рядок до кінця транзакції
+	err = tx.QueryRowxContext(ctx, `
+	UPDATE subscriptions SET updated_at = NOW()
+	WHERE id = $1 AND updated_at = $2
+	RETURNING updated_at
+	`, subscription.ID, subscription.UpdatedAt).Scan(&subscription.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM subscription_items WHERE subscription_id = $1`, subscription.ID); err != nil {
+		return fmt.Errorf("failed to clear subscription items: %w", err)
+	}
+	if err := insertSubscriptionItems(ctx, tx, subscription.ID, items); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit subscription items: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue повертає підписки, для яких настав час замовлення (active) або повторної оплати (past_due),
+// та блокує їх для інших екземплярів планувальника і дій користувача на час lease.
+// Версія (updated_at) оновлюється, тому зміни, прочитані до блокування, не перезапишуть стан планувальника
+func (r *subscriptionRepo) ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]*models.Subscription, error) {
+	query := `
+	UPDATE subscriptions
+	SET locked_until = NOW() + $1 * INTERVAL '1 second',
+		updated_at = NOW()
+	WHERE id IN (
+		SELECT id FROM subscriptions
+		WHERE ((status = 'active' AND next_run_at <= NOW()) OR (status = 'past_due' AND retry_at <= NOW()))
+			AND (locked_until IS NULL OR locked_until < NOW())
+		ORDER BY COALESCE(retry_at, next_run_at), id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + subscriptionColumns
+
+	var subscriptions []*models.Subscription
+	if err := r.db.SelectContext(ctx, &subscriptions, query, lease.Seconds(), limit); err != nil {
+		return nil, fmt.Errorf("failed to claim due subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// Unlock знімає блокування планувальника
+func (r *subscriptionRepo) Unlock(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE subscriptions SET locked_until = NULL WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to unlock subscription: %w", err)
+	}
+	return nil
+}
+
+// ReserveCycle закріплює ID замовлення за циклом підписки до створення замовлення.
+// Якщо цикл уже зарезервовано попередньою (перерваною) обробкою, повертає збережений ID
+func (r *subscriptionRepo) ReserveCycle(ctx context.Context, subscriptionID uuid.UUID, cycleAt time.Time, orderID uuid.UUID) (uuid.UUID, error) {
+	query := `
+	INSERT INTO subscription_cycles (subscription_id, cycle_at, order_id, created_at)
+	VALUES ($1, $2, $3, NOW())
+	ON CONFLICT (subscription_id, cycle_at) DO UPDATE SET order_id = subscription_cycles.order_id
+	RETURNING order_id
+	`
+
+	var reserved uuid.UUID
+	if err := r.db.QueryRowxContext(ctx, query, subscriptionID, cycleAt, orderID).Scan(&reserved); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to reserve subscription cycle: %w", err)
+	}
+	return reserved, nil
+}
+
+// AddOrder записує замовлення, створене підпискою; повторний запис того ж замовлення не змінює його
+func (r *subscriptionRepo) AddOrder(ctx context.Context, order *models.SubscriptionOrder) error {
+	query := `
+	INSERT INTO subscription_orders (subscription_id, order_id, cycle_at, created_at)
+	VALUES ($1, $2, $3, NOW())
+	ON CONFLICT (subscription_id, order_id) DO UPDATE SET cycle_at = subscription_orders.cycle_at
+	RETURNING created_at
+	`
+	if err := r.db.QueryRowxContext(ctx, query, order.SubscriptionID, order.OrderID, order.CycleAt).Scan(&order.CreatedAt); err != nil {
+		return fmt.Errorf("failed to add subscription order: %w", err)
+	}
+	return nil
+}
+
+// GetOrder повертає замовлення підписки з його поточним статусом
+func (r *subscriptionRepo) GetOrder(ctx context.Context, subscriptionID, orderID uuid.UUID) (*models.SubscriptionOrder, error) {
+	query := `
+	SELECT so.subscription_id, so.order_id, so.cycle_at, o.status AS order_status, o.total_amount, so.transaction_id, so.created_at
+	FROM subscription_orders so
+	JOIN orders o ON o.id = so.order_id
+	WHERE so.subscription_id = $1 AND so.order_id = $2
+	`
+
+	var order models.SubscriptionOrder
+	if err := r.db.GetContext(ctx, &order, query, subscriptionID, orderID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get subscription order: %w", err)
+	}
+	return &order, nil
+}
+
+// SetTransaction записує ID транзакції оплати; якщо замовлення не знайдено, повертає sql.ErrNoRows
+func (r *subscriptionRepo) SetTransaction(ctx context.Context, subscriptionID, orderID uuid.UUID, transactionID string) error {
+	query := `
+	UPDATE subscription_orders
+	SET transaction_id = $3
+	WHERE subscription_id = $1 AND order_id = $2
+	`
+
+	res, err := r.db.ExecContext(ctx, query, subscriptionID, orderID, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to set subscription order transaction: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListOrders повертає останні замовлення підписки з їх поточним статусом
+func (r *subscriptionRepo) ListOrders(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.SubscriptionOrder, error) {
+	query := `
+	SELECT so.subscription_id, so.order_id, so.cycle_at, o.status AS order_status, o.total_amount, so.transaction_id, so.created_at
+	FROM subscription_orders so
+	JOIN orders o ON o.id = so.order_id
+	WHERE so.subscription_id = $1
+	ORDER BY so.created_at DESC, so.order_id
+	LIMIT $2
+	`
+
+	var orders []*models.SubscriptionOrder
+	if err := r.db.SelectContext(ctx, &orders, query, subscriptionID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list subscription orders: %w", err)
+	}
+	return orders, nil
+}
+
+// insertSubscriptionItems додає товари підписки в транзакції
+func insertSubscriptionItems(ctx context.Context, tx sqlx.ExecerContext, subscriptionID uuid.UUID, items []*models.SubscriptionItem) error {
+	query := `
+	INSERT INTO subscription_items (subscription_id, product_id, quantity)
+	VALUES ($1, $2, $3)
+	`
+	for _, item := range items {
+		if _, err := tx.ExecContext(ctx, query, subscriptionID, item.ProductID, item.Quantity); err != nil {
+			return fmt.Errorf("failed to add subscription item: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// TranslationRepository інтерфейс для роботи з перекладами продуктів та категорій
+type TranslationRepository interface {
+	// переклади продуктів
+	ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error)
+	GetProductTranslations(ctx context.Context, locale string, productIDs []uuid.UUID) ([]*models.ProductTranslation, error)
+	UpsertProductTranslation(ctx context.Context, translation *models.ProductTranslation) error
+	DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error
+
+	// переклади категорій
+	ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error)
+	GetCategoryTranslations(ctx context.Context, locale string) ([]*models.CategoryTranslation, error)
+	GetCategoryTranslationBySlug(ctx context.Context, locale, slug string) (*models.CategoryTranslation, error)
+	CategorySlugTaken(ctx context.Context, locale, slug string, categoryID uuid.UUID) (bool, error)
+	UpsertCategoryTranslation(ctx context.Context, translation *models.CategoryTranslation) error
+	DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error
+
+	// звіт про відсутні переклади
+	ListMissingProducts(ctx context.Context, locale string, limit, offset int) ([]*models.MissingTranslation, error)
+	ListMissingCategories(ctx context.Context, locale string) ([]*models.MissingTranslation, error)
+	CountMissingProducts(ctx context.Context, locale string) (int, error)
+}
+
+type translationRepo struct {
+	db *database.DB
+}
+
+func NewTranslationRepository(db *database.DB) TranslationRepository {
+	return &translationRepo{db: db}
+}
+
+// ListProductTranslations повертає всі переклади продукту
+func (t *translationRepo) ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error) {
+	query := `
+	SELECT product_id, locale, name, description, updated_at
+	FROM product_translations
+	WHERE product_id = $1
+	ORDER BY locale
+	`
+
+	var translations []*models.ProductTranslation
+	if err := t.db.SelectContext(ctx, &translations, query, productID); err != nil {
+		return nil, fmt.Errorf("failed to list product translations: %w", err)
+	}
+	return translations, nil
+}
+
+// GetProductTranslations повертає переклади продуктів однією мовою
+func (t *translationRepo) GetProductTranslations(ctx context.Context, locale string, productIDs []uuid.UUID) ([]*models.ProductTranslation, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+	SELECT product_id, locale, name, description, updated_at
+	FROM product_translations
+	WHERE locale = $1 AND product_id = ANY($2::uuid[])
+	`
+
+	var translations []*models.ProductTranslation
+	if err := t.db.SelectContext(ctx, &translations, query, locale, pq.Array(uuidStrings(productIDs))); err != nil {
+		return nil, fmt.Errorf("failed to get product translations: %w", err)
+	}
+	return translations, nil
+}
+
+// UpsertProductTranslation створює або замінює переклад продукту
+func (t *translationRepo) UpsertProductTranslation(ctx context.Context, translation *models.ProductTranslation) error {
+	query := `
+	INSERT INTO product_translations (product_id, locale, name, description, updated_at)
+	VALUES ($1, $2, $3, $4, NOW())
+	ON CONFLICT (product_id, locale) DO UPDATE
+	SET name = EXCLUDED.name,
+		description = EXCLUDED.description,
+		updated_at = NOW()
+	RETURNING updated_at
+	`
+
+	err := t.db.QueryRowxContext(ctx, query,
+		translation.ProductID,
+		translation.Locale,
+		translation.Name,
+		translation.Description,
+	).Scan(&translation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save product translation: %w", err)
+	}
+	return nil
+}
+
+// DeleteProductTranslation видаляє переклад продукту
+func (t *translationRepo) DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	res, err := t.db.ExecContext(ctx, `DELETE FROM product_translations WHERE product_id = $1 AND locale = $2`, productID, locale)
+	if err != nil {
+		return fmt.Errorf("failed to delete product translation: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListCategoryTranslations повертає всі переклади категорії
+func (t *translationRepo) ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error) {
+	query := `
+	SELECT category_id, locale, name, slug, description, updated_at
+	FROM category_translations
+	WHERE category_id = $1
+	ORDER BY locale
+	`
+
+	var translations []*models.CategoryTranslation
+	if err := t.db.SelectContext(ctx, &translations, query, categoryID); err != nil {
+		return nil, fmt.Errorf("failed to list category translations: %w", err)
+	}
+	return translations, nil
+}
+
+// GetCategoryTranslations повертає переклади всіх категорій однією мовою
+func (t *translationRepo) GetCategoryTranslations(ctx context.Context, locale string) ([]*models.CategoryTranslation, error) {
+	query := `
+	SELECT category_id, locale, name, slug, description, updated_at
+	FROM category_translations
+	WHERE locale = $1
+	`
+
+	var translations []*models.CategoryTranslation
+	if err := t.db.SelectContext(ctx, &translations, query, locale); err != nil {
+		return nil, fmt.Errorf("failed to get category translations: %w", err)
+	}
+	return translations, nil
+}
+
+// GetCategoryTranslationBySlug повертає переклад категорії за локалізованим slug (nil, якщо не знайдено)
+func (t *translationRepo) GetCategoryTranslationBySlug(ctx context.Context, locale, slug string) (*models.CategoryTranslation, error) {
+	query := `
+	SELECT category_id, locale, name, slug, description, updated_at
+	FROM category_translations
+	WHERE locale = $1 AND slug = $2
+	`
+
+	var translation models.CategoryTranslation
+	if err := t.db.GetContext(ctx, &translation, query, locale, slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get category translation: %w", err)
+	}
+	return &translation, nil
+}
+
+// CategorySlugTaken перевіряє, чи використовується локалізований slug іншою категорією
+func (t *translationRepo) CategorySlugTaken(ctx context.Context, locale, slug string, categoryID uuid.UUID) (bool, error) {
+	query := `
+	SELECT EXISTS (
+		SELECT 1 FROM category_translations
+		WHERE locale = $1 AND slug = $2 AND category_id <> $3
+	)
+	`
+
+	var taken bool
+	if err := t.db.QueryRowxContext(ctx, query, locale, slug, categoryID).Scan(&taken); err != nil {
+		return false, fmt.Errorf("failed to check category translation slug: %w", err)
+	}
+	return taken, nil
+}
+
+// UpsertCategoryTranslation створює або замінює переклад категорії
+func (t *translationRepo) UpsertCategoryTranslation(ctx context.Context, translation *models.CategoryTranslation) error {
+	query := `
+	INSERT INTO category_translations (category_id, locale, name, slug, description, updated_at)
+	VALUES ($1, $2, $3, $4, $5, NOW())
+	ON CONFLICT (category_id, locale) DO UPDATE
+	SET name = EXCLUDED.name,
+		slug = EXCLUDED.slug,
+		description = EXCLUDED.description,
+		updated_at = NOW()
+	RETURNING updated_at
+	`
+
+	err := t.db.QueryRowxContext(ctx, query,
+		translation.CategoryID,
+		translation.Locale,
+		translation.Name,
+		translation.Slug,
+		translation.Description,
+	).Scan(&translation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save category translation: %w", err)
+	}
+	return nil
+}
+
+// DeleteCategoryTranslation видаляє переклад категорії
+func (t *translationRepo) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	res, err := t.db.ExecContext(ctx, `DELETE FROM category_translations WHERE category_id = $1 AND locale = $2`, categoryID, locale)
+	if err != nil {
+		return fmt.Errorf("failed to delete category translation: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListMissingProducts повертає невидалені продукти без перекладу вказаною мовою
+func (t *translationRepo) ListMissingProducts(ctx context.Context, locale string, limit, offset int) ([]*models.MissingTranslation, error) {
+	query := `
+	SELECT p.id, p.name, p.slug
+	FROM products p
+	WHERE p.deleted_at IS NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM product_translations pt
+			WHERE pt.product_id = p.id AND pt.locale = $1
+		)
+	ORDER BY p.created_at DESC, p.id
+	LIMIT $2 OFFSET $3
+	`
+
+	var missing []*models.MissingTranslation
+	if err := t.db.SelectContext(ctx, &missing, query, locale, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list products without translation: %w", err)
+	}
+	return missing, nil
+}
+
+// ListMissingCategories повертає категорії без перекладу вказаною мовою
+func (t *translationRepo) ListMissingCategories(ctx context.Context, locale string) ([]*models.MissingTranslation, error) {
+	query := `
+	SELECT c.id, c.name, c.slug
+	FROM categories c
+	WHERE NOT EXISTS (
+		SELECT 1 FROM category_translations ct
+		WHERE ct.category_id = c.id AND ct.locale = $1
+	)
+	ORDER BY c.name
+	`
+
+	var missing []*models.MissingTranslation
+	if err := t.db.SelectContext(ctx, &missing, query, locale); err != nil {
+		return nil, fmt.Errorf("failed to list categories without translation: %w", err)
+	}
+	return missing, nil
+}
+
+// CountMissingProducts повертає кількість невидалених продуктів без перекладу
+func (t *translationRepo) CountMissingProducts(ctx context.Context, locale string) (int, error) {
+	query := `
+	SELECT COUNT(*)
+	FROM products p
+	WHERE p.deleted_at IS NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM product_translations pt
+			WHERE pt.product_id = p.id AND pt.locale = $1
+		)
+	`
+
+	var count int
+	if err := t.db.QueryRowxContext(ctx, query, locale).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count products without translation: %w", err)
+	}
+	return count, nil
+}
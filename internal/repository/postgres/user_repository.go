@@ -16,7 +16,8 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, limit, offset int) ([]*models.User, error)
+	List(ctx context.Context, filter models.UserListFilter) ([]*models.User, error)
+	Count(ctx context.Context, filter models.UserListFilter) (total int, estimated bool, err error)
 }
 
 type UserRepo struct {
@@ -116,26 +117,62 @@ func (u *UserRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.User, err
 	return &user, nil
 }
 
-// List повертає список користувачів 
-func (u *UserRepo) List(ctx context.Context, limit int, offset int) ([]*models.User, error) {
+// userSortKey користувачі від нових до старих; значення курсора - created_at
+var userSortKey = sortKey{columns: []string{"created_at"}, types: []string{"timestamptz"}, desc: true}
+
+// List повертає список користувачів за фільтром від нових до старих.
+// Якщо задано keyset курсор, повертає записи після нього (або перед ним при русі назад)
+func (u *UserRepo) List(ctx context.Context, filter models.UserListFilter) ([]*models.User, error) {
+	where, args := userFilter(filter)
+	after, orderBy, args, err := keyset(userSortKey, "", filter.Cursor, args)
+	if err != nil {
+		return nil, err
+	}
+
 	// квери запит
 	query := `
 	SELECT id, email,password_hash, first_name, last_name, role, created_at, updated_at
 	FROM users
-	ORDER BY created_at DESC
-	LIMIT $1 OFFSET $2
-	`
+	WHERE 1=1` + where + after + `
+	ORDER BY ` + orderBy
+	query, args = paginate(query, args, filter.Limit, filter.Offset, filter.Cursor)
 
 	// відображення користувачів
 	var users []*models.User
-	err := u.db.SelectContext(ctx, &users, query, limit, offset)
-	if err != nil {
+	if err := u.db.SelectContext(ctx, &users, query, args...); err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
-
+	if filter.Cursor != nil && filter.Cursor.Backward {
+		reverse(users)
+	}
 	return users, nil
 }
 
+// Count повертає кількість користувачів за фільтром; другий результат - true для оцінки
+func (u *UserRepo) Count(ctx context.Context, filter models.UserListFilter) (int, bool, error) {
+	where, args := userFilter(filter)
+	total, estimated, err := countRows(ctx, u.db, "FROM users WHERE 1=1"+where, args)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to count users: %w", err)
+	}
+	return total, estimated, nil
+}
+
+// userFilter будує умови фільтрації користувачів
+func userFilter(filter models.UserListFilter) (string, []interface{}) {
+	var where string
+	var args []interface{}
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		where += fmt.Sprintf(" AND role = $%d", len(args))
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		where += fmt.Sprintf(" AND (first_name ILIKE $%d OR last_name ILIKE $%d OR email ILIKE $%d)", len(args), len(args), len(args))
+	}
+	return where, args
+}
+
 // Update оновлює дані користувача
 func (u *UserRepo) Update(ctx context.Context, user *models.User) error {
 	// квері запит
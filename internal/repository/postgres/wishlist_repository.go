@@ -0,0 +1,324 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	database "github.com/Xiancel/ecommerce/internal/db"
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// WishlistRepository інтерфейс для роботи зі списками бажань
+type WishlistRepository interface {
+	Create(ctx context.Context, wishlist *models.Wishlist) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Wishlist, error)
+	GetByShareToken(ctx context.Context, token string) (*models.Wishlist, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Wishlist, error)
+	Update(ctx context.Context, wishlist *models.Wishlist) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListItems(ctx context.Context, wishlistID uuid.UUID) ([]*models.WishlistItemWithProduct, error)
+	GetItem(ctx context.Context, wishlistID, itemID uuid.UUID) (*models.WishlistItem, error)
+	AddItem(ctx context.Context, item *models.WishlistItem) error
+	RemoveItem(ctx context.Context, wishlistID, itemID uuid.UUID) error
+	MoveItem(ctx context.Context, item *models.WishlistItem, targetWishlistID uuid.UUID) error
+	MoveToCart(ctx context.Context, item *models.WishlistItem, userID uuid.UUID, quantity int) error
+	MoveFromCart(ctx context.Context, cartItem *models.CartItem, item *models.WishlistItem) error
+}
+
+type wishlistRepo struct {
+	db *database.DB
+}
+
+func NewWishlistRepository(db *database.DB) WishlistRepository {
+	return &wishlistRepo{db: db}
+}
+
+// Create створює список бажань. Якщо у користувача вже є список з такою назвою, повертає sql.ErrNoRows
+func (w *wishlistRepo) Create(ctx context.Context, wishlist *models.Wishlist) error {
+	query := `
+	INSERT INTO wishlists (id, user_id, name, is_public, share_token, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+	ON CONFLICT (user_id, name) DO NOTHING
+	RETURNING created_at, updated_at
+	`
+
+	wishlist.ID = uuid.New()
+	err := w.db.QueryRowxContext(ctx, query,
+		wishlist.ID,
+		wishlist.UserID,
+		wishlist.Name,
+		wishlist.IsPublic,
+		wishlist.ShareToken,
+	).Scan(&wishlist.CreatedAt, &wishlist.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to create wishlist: %w", err)
+	}
+	return nil
+}
+
+// wishlistColumns колонки списку з кількістю товарів
+const wishlistColumns = `
+	w.id, w.user_id, w.name, w.is_public, w.share_token, w.created_at, w.updated_at,
+	(SELECT COUNT(*) FROM wishlist_items wi WHERE wi.wishlist_id = w.id) AS item_count
+`
+
+// GetByID повертає список бажань за ID (nil, якщо не знайдено)
+func (w *wishlistRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Wishlist, error) {
+	return w.getOne(ctx, "w.id = $1", id)
+}
+
+// GetByShareToken повертає публічний список бажань за токеном (nil, якщо не знайдено)
+func (w *wishlistRepo) GetByShareToken(ctx context.Context, token string) (*models.Wishlist, error) {
+	return w.getOne(ctx, "w.share_token = $1 AND w.is_public", token)
+}
+
+// getOne повертає список бажань за умовою (nil, якщо не знайдено)
+func (w *wishlistRepo) getOne(ctx context.Context, condition string, arg interface{}) (*models.Wishlist, error) {
+	query := `SELECT ` + wishlistColumns + ` FROM wishlists w WHERE ` + condition
+
+	var wishlist models.Wishlist
+	if err := w.db.GetContext(ctx, &wishlist, query, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get wishlist: %w", err)
+	}
+	return &wishlist, nil
+}
+
+// ListByUser повертає списки бажань користувача у порядку створення
+func (w *wishlistRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Wishlist, error) {
+	query := `SELECT ` + wishlistColumns + ` FROM wishlists w WHERE w.user_id = $1 ORDER BY w.created_at, w.id`
+
+	var wishlists []*models.Wishlist
+	if err := w.db.SelectContext(ctx, &wishlists, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list wishlists: %w", err)
+	}
+	return wishlists, nil
+}
+
+// Update оновлює назву та доступ до списку бажань
+func (w *wishlistRepo) Update(ctx context.Context, wishlist *models.Wishlist) error {
+	query := `
+	UPDATE wishlists
+	SET name = $1, is_public = $2, share_token = $3, updated_at = NOW()
+	WHERE id = $4
+	RETURNING updated_at
+	`
+
+	err := w.db.QueryRowxContext(ctx, query,
+		wishlist.Name,
+		wishlist.IsPublic,
+		wishlist.ShareToken,
+		wishlist.ID,
+	).Scan(&wishlist.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to update wishlist: %w", err)
+	}
+	return nil
+}
+
+// Delete видаляє список бажань разом з товарами
+func (w *wishlistRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	res, err := w.db.ExecContext(ctx, `DELETE FROM wishlists WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete wishlist: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListItems повертає товари списку з поточною ціною та наявністю, нові першими
+func (w *wishlistRepo) ListItems(ctx context.Context, wishlistID uuid.UUID) ([]*models.WishlistItemWithProduct, error) {
+	query := `
+	SELECT
+		wi.id,
+		wi.wishlist_id,
+		wi.product_id,
+		wi.price_at_add,
+		wi.created_at,
+		p.name AS product_name,
+		p.slug AS product_slug,
+		p.image_url,
+		` + effectivePriceSQL + ` AS price,
+		p.stock,
+		p.backorder_policy,
+		p.is_digital,
+		(p.status = 'published' AND p.deleted_at IS NULL) AS visible
+	FROM wishlist_items wi
+	JOIN products p ON p.id = wi.product_id
+	WHERE wi.wishlist_id = $1
+	ORDER BY wi.created_at DESC, wi.id DESC
+	`
+
+	var items []*models.WishlistItemWithProduct
+	if err := w.db.SelectContext(ctx, &items, query, wishlistID); err != nil {
+		return nil, fmt.Errorf("failed to list wishlist items: %w", err)
+	}
+	return items, nil
+}
+
+// GetItem повертає товар списку за ID (nil, якщо не знайдено)
+func (w *wishlistRepo) GetItem(ctx context.Context, wishlistID, itemID uuid.UUID) (*models.WishlistItem, error) {
+	query := `
+	SELECT id, wishlist_id, product_id, price_at_add, created_at
+	FROM wishlist_items
+	WHERE id = $1 AND wishlist_id = $2
+	`
+
+	var item models.WishlistItem
+	if err := w.db.GetContext(ctx, &item, query, itemID, wishlistID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get wishlist item: %w", err)
+	}
+	return &item, nil
+}
+
+// AddItem додає товар у список. Якщо товар уже є в списку, повертає sql.ErrNoRows
+func (w *wishlistRepo) AddItem(ctx context.Context, item *models.WishlistItem) error {
+	if err := insertWishlistItem(ctx, w.db, item); err != nil {
+		return err
+	}
+	if _, err := w.db.ExecContext(ctx, `UPDATE wishlists SET updated_at = NOW() WHERE id = $1`, item.WishlistID); err != nil {
+		return fmt.Errorf("failed to touch wishlist: %w", err)
+	}
+	return nil
+}
+
+// RemoveItem видаляє товар зі списку
+func (w *wishlistRepo) RemoveItem(ctx context.Context, wishlistID, itemID uuid.UUID) error {
+	res, err := w.db.ExecContext(ctx, `DELETE FROM wishlist_items WHERE id = $1 AND wishlist_id = $2`, itemID, wishlistID)
+	if err != nil {
+		return fmt.Errorf("failed to remove wishlist item: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// MoveItem переносить товар в інший список зі збереженням ціни на момент додавання.
+// Якщо товар уже є в цільовому списку, він лише видаляється з поточного
+func (w *wishlistRepo) MoveItem(ctx context.Context, item *models.WishlistItem, targetWishlistID uuid.UUID) error {
+	tx, err := w.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO wishlist_items (id, wishlist_id, product_id, price_at_add, created_at)
+	VALUES ($1, $2, $3, $4, NOW())
+	ON CONFLICT (wishlist_id, product_id) DO NOTHING
+	`, uuid.New(), targetWishlistID, item.ProductID, item.PriceAtAdd)
+	if err != nil {
+		return fmt.Errorf("failed to add wishlist item: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM wishlist_items WHERE id = $1`, item.ID); err != nil {
+		return fmt.Errorf("failed to remove wishlist item: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE wishlists SET updated_at = NOW() WHERE id IN ($1, $2)`, item.WishlistID, targetWishlistID)
+	if err != nil {
+		return fmt.Errorf("failed to touch wishlists: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit wishlist move: %w", err)
+	}
+	return nil
+}
+
+// MoveToCart додає товар у кошик користувача (кількість сумується з наявною) і видаляє його зі списку
+func (w *wishlistRepo) MoveToCart(ctx context.Context, item *models.WishlistItem, userID uuid.UUID, quantity int) error {
+	tx, err := w.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// ціна на момент додавання - поточна ціна продукту
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO cart_items (id, user_id, product_id, quantity, price_at_add, created_at)
+	SELECT $1, $2, p.id, $4, `+effectivePriceSQL+`, NOW()
+	FROM products p
+	WHERE p.id = $3
+	ON CONFLICT (user_id, product_id) DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity
+	`, uuid.New(), userID, item.ProductID, quantity)
+	if err != nil {
+		return fmt.Errorf("failed to add cart item: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM wishlist_items WHERE id = $1`, item.ID); err != nil {
+		return fmt.Errorf("failed to remove wishlist item: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit move to cart: %w", err)
+	}
+	return nil
+}
+
+// MoveFromCart додає товар кошика в список і видаляє його з кошика.
+// Якщо товар уже є в списку, він лише видаляється з кошика
+func (w *wishlistRepo) MoveFromCart(ctx context.Context, cartItem *models.CartItem, item *models.WishlistItem) error {
+	tx, err := w.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertWishlistItem(ctx, tx, item); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM cart_items WHERE id = $1 AND user_id = $2`, cartItem.ID, cartItem.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to remove cart item: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE wishlists SET updated_at = NOW() WHERE id = $1`, item.WishlistID); err != nil {
+		return fmt.Errorf("failed to touch wishlist: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit move from cart: %w", err)
+	}
+	return nil
+}
+
+// insertWishlistItem додає товар у список; sql.ErrNoRows, якщо товар уже є в списку
+func insertWishlistItem(ctx context.Context, q sqlx.QueryerContext, item *models.WishlistItem) error {
+	query := `
+	INSERT INTO wishlist_items (id, wishlist_id, product_id, price_at_add, created_at)
+	VALUES ($1, $2, $3, $4, NOW())
+	ON CONFLICT (wishlist_id, product_id) DO NOTHING
+	RETURNING created_at
+	`
+
+	item.ID = uuid.New()
+	err := q.QueryRowxContext(ctx, query, item.ID, item.WishlistID, item.ProductID, item.PriceAtAdd).Scan(&item.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to add wishlist item: %w", err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
@@ -13,11 +14,13 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-func NewService(authRepo repository.UserRepository, jwtSecret string) AuthService {
+// NewService створює сервіс автентифікації; cartMerger може бути nil, тоді кошик гостя не переноситься
+func NewService(authRepo repository.UserRepository, jwtSecret string, cartMerger CartMerger) AuthService {
 	return &service{
 		userRepo:      authRepo,
 		jwtSecret:     jwtSecret,
 		tokenDuration: 24 * time.Hour,
+		cartMerger:    cartMerger,
 	}
 }
 
@@ -58,9 +61,10 @@ func (s *service) Login(ctx context.Context, req LoginRequset) (*AuthResponse, e
 
 	// повертає данні користувача
 	return &AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		User:         user,
+		AccessToken:     accessToken,
+		RefreshToken:    refreshToken,
+		User:            user,
+		MergedCartItems: s.mergeGuestCart(ctx, user, req.CartToken),
 	}, nil
 }
 
@@ -149,12 +153,27 @@ func (s *service) Register(ctx context.Context, req RegisterRequest) (*AuthRespo
 
 	// повертає данні користувача
 	return &AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		User:         user,
+		AccessToken:     accessToken,
+		RefreshToken:    refreshToken,
+		User:            user,
+		MergedCartItems: s.mergeGuestCart(ctx, user, req.CartToken),
 	}, nil
 }
 
+// mergeGuestCart переносить кошик гостя в кошик користувача; вхід не залежить від результату,
+// тому помилка лише логується
+func (s *service) mergeGuestCart(ctx context.Context, user *models.User, cartToken string) int {
+	if s.cartMerger == nil || cartToken == "" {
+		return 0
+	}
+	merged, err := s.cartMerger.MergeGuestCart(ctx, user.ID, cartToken)
+	if err != nil {
+		log.Printf("failed to merge guest cart for user %s: %v", user.ID, err)
+		return 0
+	}
+	return merged
+}
+
 // ValidateToken валідація токену
 func (s *service) ValidateToken(tokenString string) (*Claims, error) {
 	// парсинг токена з claims
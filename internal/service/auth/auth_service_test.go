@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
@@ -45,17 +46,22 @@ func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
-func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockUserRepository) List(ctx context.Context, filter models.UserListFilter) ([]*models.User, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) Count(ctx context.Context, filter models.UserListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
 func TestRegisterUser_Success(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	service := NewService(mockRepo, "secret")
+	service := NewService(mockRepo, "secret", nil)
 	ctx := context.Background()
 
 	req := RegisterRequest{
@@ -78,7 +84,7 @@ func TestRegisterUser_Success(t *testing.T) {
 
 func TestRegisterUser_UserAlreadyExsists(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	service := NewService(mockRepo, "secret")
+	service := NewService(mockRepo, "secret", nil)
 	ctx := context.Background()
 
 	exsistsUser := &models.User{
@@ -103,7 +109,7 @@ func TestRegisterUser_UserAlreadyExsists(t *testing.T) {
 
 func TestLogin_Succes(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	service := NewService(mockRepo, "secret")
+	service := NewService(mockRepo, "secret", nil)
 	ctx := context.Background()
 
 	password := "SuperSecretPassword123"
@@ -131,7 +137,7 @@ func TestLogin_Succes(t *testing.T) {
 
 func TestLogin_InvalidPassword(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	service := NewService(mockRepo, "secret")
+	service := NewService(mockRepo, "secret", nil)
 	ctx := context.Background()
 
 	user := &models.User{
@@ -152,3 +158,55 @@ func TestLogin_InvalidPassword(t *testing.T) {
 	assert.Equal(t, ErrInvalidCredentials, err)
 	mockRepo.AssertExpectations(t)
 }
+
+type MockCartMerger struct {
+	mock.Mock
+}
+
+func (m *MockCartMerger) MergeGuestCart(ctx context.Context, userID uuid.UUID, token string) (int, error) {
+	args := m.Called(ctx, userID, token)
+	return args.Int(0), args.Error(1)
+}
+
+func TestLogin_MergesGuestCart(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockMerger := new(MockCartMerger)
+	service := NewService(mockRepo, "secret", mockMerger)
+	ctx := context.Background()
+
+	password := "SuperSecretPassword123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	user := &models.User{ID: uuid.New(), Email: "test@test.com", PasswordHash: string(hashedPassword)}
+
+	req := LoginRequset{Email: "test@test.com", Password: password, CartToken: "cart-token"}
+	mockRepo.On("GetByEmail", ctx, req.Email).Return(user, nil)
+	mockMerger.On("MergeGuestCart", ctx, user.ID, "cart-token").Return(3, nil)
+
+	resp, err := service.Login(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, resp.MergedCartItems)
+	mockMerger.AssertExpectations(t)
+}
+
+func TestLogin_MergeErrorIgnored(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockMerger := new(MockCartMerger)
+	service := NewService(mockRepo, "secret", mockMerger)
+	ctx := context.Background()
+
+	password := "SuperSecretPassword123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	user := &models.User{ID: uuid.New(), Email: "test@test.com", PasswordHash: string(hashedPassword)}
+
+	req := LoginRequset{Email: "test@test.com", Password: password, CartToken: "expired"}
+	mockRepo.On("GetByEmail", ctx, req.Email).Return(user, nil)
+	mockMerger.On("MergeGuestCart", ctx, user.ID, "expired").Return(0, errors.New("invalid cart token"))
+
+	resp, err := service.Login(ctx, req)
+
+	// вхід успішний навіть якщо кошик не вдалося перенести
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.Equal(t, 0, resp.MergedCartItems)
+}
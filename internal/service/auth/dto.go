@@ -17,15 +17,21 @@ type RegisterRequest struct {
 	Password  string `json:"password" validate:"required,min=8"`
 	FirstName string `json:"first_name" validate:"required,min=2"`
 	LastName  string `json:"last_name" validate:"required,min=2"`
+	// CartToken токен кошика гостя, який потрібно перенести в кошик користувача
+	CartToken string `json:"cart_token"`
 }
 type LoginRequset struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+	// CartToken токен кошика гостя, який потрібно перенести в кошик користувача
+	CartToken string `json:"cart_token"`
 }
 type AuthResponse struct {
 	AccessToken  string       `json:"access_token"`
 	RefreshToken string       `json:"refresh_token"`
 	User         *models.User `json:"user"`
+	// MergedCartItems кількість позицій, перенесених з кошика гостя
+	MergedCartItems int `json:"merged_cart_items,omitempty"`
 }
 
 type RefreshRequest struct {
@@ -42,4 +48,5 @@ type service struct {
 	userRepo      repository.UserRepository
 	jwtSecret     string
 	tokenDuration time.Duration
+	cartMerger    CartMerger
 }
@@ -1,6 +1,10 @@
 package auth
 
-import "context"
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
 
 // AuthService Інтерфейс для роботи з Антефікацією та авторизацією
 type AuthService interface {
@@ -9,3 +13,8 @@ type AuthService interface {
 	RefreshToken(ctx context.Context, refreshToken string) (*AuthResponse, error)
 	ValidateToken(tokenString string) (*Claims, error)
 }
+
+// CartMerger переносить кошик гостя в кошик користувача після входу або реєстрації
+type CartMerger interface {
+	MergeGuestCart(ctx context.Context, userID uuid.UUID, token string) (int, error)
+}
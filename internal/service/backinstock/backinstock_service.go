@@ -0,0 +1,113 @@
+package backinstock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/google/uuid"
+)
+
+type service struct {
+	subscriptionRepo repository.StockSubscriptionRepository
+	productRepo      repository.ProductRepository
+	notifier         notification.Notifier
+	batchSize        int
+}
+
+func NewService(subscriptionRepo repository.StockSubscriptionRepository, productRepo repository.ProductRepository, notifier notification.Notifier, batchSize int) BackInStockService {
+	return &service{subscriptionRepo: subscriptionRepo,
+		productRepo: productRepo,
+		notifier:    notifier,
+		batchSize:   batchSize}
+}
+
+// Subscribe підписує користувача на сповіщення про надходження товару, якого немає в наявності
+func (s *service) Subscribe(ctx context.Context, userID, productID uuid.UUID) (*models.StockSubscription, error) {
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || !product.IsVisible() {
+		return nil, ErrProductNotFound
+	}
+	if product.Stock > 0 {
+		return nil, ErrProductInStock
+	}
+
+	subscription := &models.StockSubscription{
+		ProductID: productID,
+		UserID:    userID,
+	}
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		// активна підписка вже існує
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAlreadySubscribed
+		}
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// Unsubscribe скасовує активну підписку користувача
+func (s *service) Unsubscribe(ctx context.Context, userID, productID uuid.UUID) error {
+	if err := s.subscriptionRepo.Delete(ctx, productID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSubscriptionNotFound
+		}
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+	return nil
+}
+
+// GetStatus повертає, чи підписаний користувач на продукт
+func (s *service) GetStatus(ctx context.Context, userID, productID uuid.UUID) (*SubscriptionStatusResponse, error) {
+	subscription, err := s.subscriptionRepo.GetActive(ctx, productID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	resp := &SubscriptionStatusResponse{ProductID: productID}
+	if subscription != nil {
+		resp.Subscribed = true
+		resp.SubscribedAt = &subscription.CreatedAt
+	}
+	return resp, nil
+}
+
+// DeliverNotifications відправляє сповіщення про надходження товару черговій партії підписників.
+// Якщо товар знову закінчився, підписники повертаються в очікування зі збереженням черги;
+// підписки, які не вдалося доставити, повторюються наступного запуску
+func (s *service) DeliverNotifications(ctx context.Context) (int, error) {
+	if _, err := s.subscriptionRepo.RequeueSoldOut(ctx); err != nil {
+		return 0, fmt.Errorf("failed to requeue subscriptions: %w", err)
+	}
+
+	subscriptions, err := s.subscriptionRepo.ListReady(ctx, s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list ready subscriptions: %w", err)
+	}
+
+	var delivered []uuid.UUID
+	var deliveryErr error
+	for _, subscription := range subscriptions {
+		n := notification.New(notification.TypeBackInStock, &subscription.UserID, map[string]interface{}{
+			"subscription_id": subscription.ID,
+			"product_id":      subscription.ProductID,
+			"product_name":    subscription.ProductName,
+			"product_slug":    subscription.ProductSlug,
+			"stock":           subscription.Stock,
+		})
+		if err := s.notifier.Notify(ctx, n); err != nil {
+			deliveryErr = fmt.Errorf("failed to send back in stock notification: %w", err)
+			break
+		}
+		delivered = append(delivered, subscription.ID)
+	}
+
+	if err := s.subscriptionRepo.MarkNotified(ctx, delivered); err != nil {
+		return 0, fmt.Errorf("failed to mark subscriptions notified: %w", err)
+	}
+	return len(delivered), deliveryErr
+}
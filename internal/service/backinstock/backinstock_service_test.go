@@ -0,0 +1,316 @@
+package backinstock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockSubscriptionRepository) Create(ctx context.Context, subscription *models.StockSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+func (m *MockStockSubscriptionRepository) GetActive(ctx context.Context, productID, userID uuid.UUID) (*models.StockSubscription, error) {
+	args := m.Called(ctx, productID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockSubscription), args.Error(1)
+}
+func (m *MockStockSubscriptionRepository) Delete(ctx context.Context, productID, userID uuid.UUID) error {
+	args := m.Called(ctx, productID, userID)
+	return args.Error(0)
+}
+func (m *MockStockSubscriptionRepository) RequeueSoldOut(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockStockSubscriptionRepository) ListReady(ctx context.Context, limit int) ([]*models.ReadyStockSubscription, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ReadyStockSubscription), args.Error(1)
+}
+func (m *MockStockSubscriptionRepository) MarkNotified(ctx context.Context, ids []uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, n notification.Notification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+func outOfStockProduct(id uuid.UUID) *models.Product {
+	return &models.Product{ID: id, Name: "Lamp", Status: models.ProductStatusPublished, Stock: 0}
+}
+
+func TestSubscribe_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockStockSubscriptionRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockNotifier), 10)
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(outOfStockProduct(productID), nil)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(s *models.StockSubscription) bool {
+		return s.ProductID == productID && s.UserID == userID
+	})).Return(nil)
+
+	//Act
+	subscription, err := service.Subscribe(ctx, userID, productID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, productID, subscription.ProductID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscribe_InStock(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockStockSubscriptionRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockNotifier), 10)
+	ctx := context.Background()
+	productID := uuid.New()
+	product := outOfStockProduct(productID)
+	product.Stock = 3
+
+	mockProductRepo.On("GetById", ctx, productID).Return(product, nil)
+
+	//Act
+	_, err := service.Subscribe(ctx, uuid.New(), productID)
+
+	//Assert
+	assert.Equal(t, ErrProductInStock, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSubscribe_HiddenProduct(t *testing.T) {
+	//Arrange
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(new(MockStockSubscriptionRepository), mockProductRepo, new(MockNotifier), 10)
+	ctx := context.Background()
+	productID := uuid.New()
+	product := outOfStockProduct(productID)
+	product.Status = models.ProductStatusDraft
+
+	mockProductRepo.On("GetById", ctx, productID).Return(product, nil)
+
+	//Act
+	_, err := service.Subscribe(ctx, uuid.New(), productID)
+
+	//Assert
+	assert.Equal(t, ErrProductNotFound, err)
+}
+
+func TestSubscribe_Duplicate(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockStockSubscriptionRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockNotifier), 10)
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(outOfStockProduct(productID), nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(sql.ErrNoRows)
+
+	//Act
+	_, err := service.Subscribe(ctx, uuid.New(), productID)
+
+	//Assert
+	assert.Equal(t, ErrAlreadySubscribed, err)
+}
+
+func TestUnsubscribe_NotFound(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockStockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockNotifier), 10)
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockRepo.On("Delete", ctx, productID, userID).Return(sql.ErrNoRows)
+
+	//Act
+	err := service.Unsubscribe(ctx, userID, productID)
+
+	//Assert
+	assert.Equal(t, ErrSubscriptionNotFound, err)
+}
+
+func TestGetStatus(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockStockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockNotifier), 10)
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockRepo.On("GetActive", ctx, productID, userID).Return(&models.StockSubscription{ProductID: productID, UserID: userID}, nil)
+
+	//Act
+	status, err := service.GetStatus(ctx, userID, productID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.True(t, status.Subscribed)
+}
+
+func TestDeliverNotifications_Batch(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockStockSubscriptionRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockNotifier, 2)
+	ctx := context.Background()
+	first := &models.ReadyStockSubscription{StockSubscription: models.StockSubscription{ID: uuid.New(), ProductID: uuid.New(), UserID: uuid.New()}, Stock: 4}
+	second := &models.ReadyStockSubscription{StockSubscription: models.StockSubscription{ID: uuid.New(), ProductID: uuid.New(), UserID: uuid.New()}, Stock: 1}
+
+	mockRepo.On("RequeueSoldOut", ctx).Return(int64(0), nil)
+	mockRepo.On("ListReady", ctx, 2).Return([]*models.ReadyStockSubscription{first, second}, nil)
+	mockNotifier.On("Notify", ctx, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Type == notification.TypeBackInStock && n.UserID != nil
+	})).Return(nil)
+	mockRepo.On("MarkNotified", ctx, []uuid.UUID{first.ID, second.ID}).Return(nil)
+
+	//Act
+	count, err := service.DeliverNotifications(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	mockNotifier.AssertNumberOfCalls(t, "Notify", 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeliverNotifications_StopsOnFailure(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockStockSubscriptionRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockNotifier, 10)
+	ctx := context.Background()
+	first := &models.ReadyStockSubscription{StockSubscription: models.StockSubscription{ID: uuid.New(), UserID: uuid.New()}}
+	second := &models.ReadyStockSubscription{StockSubscription: models.StockSubscription{ID: uuid.New(), UserID: uuid.New()}}
+
+	mockRepo.On("RequeueSoldOut", ctx).Return(int64(0), nil)
+	mockRepo.On("ListReady", ctx, 10).Return([]*models.ReadyStockSubscription{first, second}, nil)
+	mockNotifier.On("Notify", ctx, mock.MatchedBy(func(n notification.Notification) bool {
+		return *n.UserID == first.UserID
+	})).Return(nil)
+	mockNotifier.On("Notify", ctx, mock.Anything).Return(errors.New("webhook down"))
+	mockRepo.On("MarkNotified", ctx, []uuid.UUID{first.ID}).Return(nil)
+
+	//Act
+	count, err := service.DeliverNotifications(ctx)
+
+	// друга підписка залишається в черзі
+
+	//Assert
+	assert.Error(t, err)
+	assert.Equal(t, 1, count)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,15 @@
+package backinstock
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DTO структури для підписок на надходження товару
+
+type SubscriptionStatusResponse struct {
+	ProductID    uuid.UUID  `json:"product_id"`
+	Subscribed   bool       `json:"subscribed"`
+	SubscribedAt *time.Time `json:"subscribed_at,omitempty"`
+}
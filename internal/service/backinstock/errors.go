@@ -0,0 +1,12 @@
+package backinstock
+
+import "errors"
+
+// помилки пов'язані з підписками на надходження товару
+var (
+	// Logic errors
+	ErrProductNotFound      = errors.New("product not found")
+	ErrProductInStock       = errors.New("product is in stock")
+	ErrAlreadySubscribed    = errors.New("already subscribed to this product")
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+)
@@ -0,0 +1,17 @@
+package backinstock
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// BackInStockService інтерфейс для підписок на сповіщення про надходження товару
+type BackInStockService interface {
+	Subscribe(ctx context.Context, userID, productID uuid.UUID) (*models.StockSubscription, error)
+	Unsubscribe(ctx context.Context, userID, productID uuid.UUID) error
+	GetStatus(ctx context.Context, userID, productID uuid.UUID) (*SubscriptionStatusResponse, error)
+	// DeliverNotifications відправляє сповіщення черговій партії підписників; повертає кількість відправлених
+	DeliverNotifications(ctx context.Context) (int, error)
+}
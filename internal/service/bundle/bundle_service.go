@@ -0,0 +1,178 @@
+package bundle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/google/uuid"
+)
+
+// обмеження складу комплекту
+const (
+	maxComponents        = 20
+	maxComponentQuantity = 100
+)
+
+type service struct {
+	bundleRepo  repository.BundleRepository
+	productRepo repository.ProductRepository
+	localizer   *productSrv.Localizer
+}
+
+func NewService(bundleRepo repository.BundleRepository, productRepo repository.ProductRepository, localizer *productSrv.Localizer) BundleService {
+	return &service{bundleRepo: bundleRepo,
+		productRepo: productRepo,
+		localizer:   localizer}
+}
+
+// GetBundle повертає склад опублікованого комплекту з його доступністю
+func (s *service) GetBundle(ctx context.Context, productID uuid.UUID) (*BundleResponse, error) {
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || !product.IsVisible() {
+		return nil, ErrProductNotFound
+	}
+	if !product.IsBundle {
+		return nil, ErrNotBundle
+	}
+	return s.bundle(ctx, product)
+}
+
+// SetComponents задає склад комплекту; звичайний продукт без власного залишку стає комплектом.
+// Компонентом може бути тільки фізичний продукт, який сам не є комплектом
+func (s *service) SetComponents(ctx context.Context, productID uuid.UUID, req SetComponentsRequest) (*BundleResponse, error) {
+	// валідація
+	if len(req.Components) == 0 {
+		return nil, ErrComponentsRequired
+	}
+	if len(req.Components) > maxComponents {
+		return nil, ErrTooManyComponents
+	}
+
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || product.DeletedAt != nil {
+		return nil, ErrProductNotFound
+	}
+	// залишок комплекту обчислюється з компонентів, тому під замовлення його продати не можна
+	if product.IsDigital || product.AllowsBackorder() {
+		return nil, ErrInvalidBundle
+	}
+	if !product.IsBundle {
+		// власний залишок на складах лишився б без обліку
+		if product.Stock > 0 {
+			return nil, ErrBundleHasStock
+		}
+		isComponent, err := s.bundleRepo.IsComponent(ctx, productID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check bundle component: %w", err)
+		}
+		if isComponent {
+			return nil, ErrBundleIsComponent
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.Components))
+	components := make([]*models.BundleComponent, 0, len(req.Components))
+	for _, c := range req.Components {
+		if c.Quantity <= 0 || c.Quantity > maxComponentQuantity {
+			return nil, ErrInvalidComponentQuantity
+		}
+		if c.ProductID == productID {
+			return nil, ErrSelfComponent
+		}
+		if seen[c.ProductID] {
+			return nil, ErrDuplicateComponent
+		}
+		seen[c.ProductID] = true
+
+		component, err := s.productRepo.GetById(ctx, c.ProductID)
+		if err != nil || component == nil || component.DeletedAt != nil {
+			return nil, ErrComponentNotFound
+		}
+		if component.IsBundle || component.IsDigital {
+			return nil, ErrInvalidComponent
+		}
+		components = append(components, &models.BundleComponent{
+			BundleID:    productID,
+			ComponentID: c.ProductID,
+			Quantity:    c.Quantity,
+		})
+	}
+
+	if err := s.bundleRepo.SetComponents(ctx, productID, components); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to set bundle components: %w", err)
+	}
+
+	// залишок комплекту перераховано в репозиторії
+	product, err = s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil {
+		return nil, ErrProductNotFound
+	}
+	return s.bundle(ctx, product)
+}
+
+// DeleteBundle перетворює комплект на звичайний продукт
+func (s *service) DeleteBundle(ctx context.Context, productID uuid.UUID) error {
+	if err := s.bundleRepo.Delete(ctx, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotBundle
+		}
+		return fmt.Errorf("failed to delete bundle: %w", err)
+	}
+	return nil
+}
+
+// bundle формує відповідь зі складом комплекту та вартістю компонентів окремо
+func (s *service) bundle(ctx context.Context, product *models.Product) (*BundleResponse, error) {
+	components, err := s.bundleRepo.ListComponents(ctx, []uuid.UUID{product.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	if components == nil {
+		components = []*models.BundleComponent{}
+	}
+
+	// назви комплекту та компонентів мовою запиту
+	ids := []uuid.UUID{product.ID}
+	for _, c := range components {
+		ids = append(ids, c.ComponentID)
+	}
+	names, err := s.localizer.Names(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range components {
+		if name, ok := names[c.ComponentID]; ok {
+			c.Name = name
+		}
+	}
+
+	resp := &BundleResponse{
+		ProductID:    product.ID,
+		Name:         product.Name,
+		Price:        product.EffectivePrice(time.Now()),
+		Stock:        product.Stock,
+		Availability: product.Availability(),
+		Components:   components,
+	}
+	for _, c := range components {
+		resp.ComponentsPrice += c.Price * float64(c.Quantity)
+	}
+	if name, ok := names[product.ID]; ok {
+		resp.Name = name
+	}
+	resp.ComponentsPrice = math.Round(resp.ComponentsPrice*100) / 100
+	if resp.ComponentsPrice > resp.Price {
+		resp.Savings = math.Round((resp.ComponentsPrice-resp.Price)*100) / 100
+	}
+	return resp, nil
+}
@@ -0,0 +1,443 @@
+package bundle
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBundleRepository struct {
+	mock.Mock
+}
+
+func (m *MockBundleRepository) ListComponents(ctx context.Context, bundleIDs []uuid.UUID) ([]*models.BundleComponent, error) {
+	args := m.Called(ctx, bundleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.BundleComponent), args.Error(1)
+}
+func (m *MockBundleRepository) IsComponent(ctx context.Context, productID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, productID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockBundleRepository) SetComponents(ctx context.Context, bundleID uuid.UUID, components []*models.BundleComponent) error {
+	args := m.Called(ctx, bundleID, components)
+	return args.Error(0)
+}
+func (m *MockBundleRepository) Delete(ctx context.Context, bundleID uuid.UUID) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranslationRepository) ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetProductTranslations(ctx context.Context, locale string, productIDs []uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, locale, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertProductTranslation(ctx context.Context, translation *models.ProductTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	args := m.Called(ctx, productID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslations(ctx context.Context, locale string) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslationBySlug(ctx context.Context, locale, slug string) (*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CategorySlugTaken(ctx context.Context, locale, slug string, categoryID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, locale, slug, categoryID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertCategoryTranslation(ctx context.Context, translation *models.CategoryTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	args := m.Called(ctx, categoryID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListMissingProducts(ctx context.Context, locale string, limit, offset int) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) ListMissingCategories(ctx context.Context, locale string) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CountMissingProducts(ctx context.Context, locale string) (int, error) {
+	args := m.Called(ctx, locale)
+	return args.Int(0), args.Error(1)
+}
+
+func TestSetComponents_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockBundleRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	bundleID, monitorID, mouseID := uuid.New(), uuid.New(), uuid.New()
+
+	mockProductRepo.On("GetById", ctx, bundleID).Return(&models.Product{ID: bundleID, Name: "Desk setup", Price: 450, Status: models.ProductStatusPublished, BackorderPolicy: models.BackorderDeny}, nil).Once()
+	mockRepo.On("IsComponent", ctx, bundleID).Return(false, nil)
+	mockProductRepo.On("GetById", ctx, monitorID).Return(&models.Product{ID: monitorID, Price: 300, Stock: 4}, nil)
+	mockProductRepo.On("GetById", ctx, mouseID).Return(&models.Product{ID: mouseID, Price: 100, Stock: 5}, nil)
+	mockRepo.On("SetComponents", ctx, bundleID, mock.MatchedBy(func(components []*models.BundleComponent) bool {
+		return len(components) == 2 && components[0].ComponentID == monitorID && components[1].Quantity == 2
+	})).Return(nil)
+	mockProductRepo.On("GetById", ctx, bundleID).Return(&models.Product{ID: bundleID, Name: "Desk setup", Price: 450, Stock: 2, Status: models.ProductStatusPublished, IsBundle: true}, nil).Once()
+	mockRepo.On("ListComponents", ctx, []uuid.UUID{bundleID}).Return([]*models.BundleComponent{
+		{BundleID: bundleID, ComponentID: monitorID, Quantity: 1, Price: 300, Stock: 4},
+		{BundleID: bundleID, ComponentID: mouseID, Quantity: 2, Price: 100, Stock: 5},
+	}, nil)
+
+	//Act
+	resp, err := service.SetComponents(ctx, bundleID, SetComponentsRequest{Components: []ComponentRequest{
+		{ProductID: monitorID, Quantity: 1},
+		{ProductID: mouseID, Quantity: 2},
+	}})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.Stock)
+	assert.Equal(t, "in_stock", resp.Availability)
+	assert.Equal(t, 500.0, resp.ComponentsPrice)
+	assert.Equal(t, 50.0, resp.Savings)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetComponents_Validation(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockBundleRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	bundleID, componentID := uuid.New(), uuid.New()
+
+	mockProductRepo.On("GetById", ctx, bundleID).Return(&models.Product{ID: bundleID, Price: 100, IsBundle: true}, nil)
+	mockProductRepo.On("GetById", ctx, componentID).Return(&models.Product{ID: componentID, Price: 10}, nil)
+
+	tests := []struct {
+		name       string
+		components []ComponentRequest
+		want       error
+	}{
+		{"empty", nil, ErrComponentsRequired},
+		{"quantity", []ComponentRequest{{ProductID: componentID, Quantity: 0}}, ErrInvalidComponentQuantity},
+		{"self", []ComponentRequest{{ProductID: bundleID, Quantity: 1}}, ErrSelfComponent},
+		{"duplicate", []ComponentRequest{{ProductID: componentID, Quantity: 1}, {ProductID: componentID, Quantity: 2}}, ErrDuplicateComponent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			_, err := service.SetComponents(ctx, bundleID, SetComponentsRequest{Components: tt.components})
+
+			//Assert
+			assert.Equal(t, tt.want, err)
+		})
+	}
+	mockRepo.AssertNotCalled(t, "SetComponents", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetComponents_RejectsNestedAndDigitalComponents(t *testing.T) {
+	//Arrange
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(new(MockBundleRepository), mockProductRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	bundleID, nestedID, ebookID := uuid.New(), uuid.New(), uuid.New()
+
+	mockProductRepo.On("GetById", ctx, bundleID).Return(&models.Product{ID: bundleID, Price: 100, IsBundle: true}, nil)
+	mockProductRepo.On("GetById", ctx, nestedID).Return(&models.Product{ID: nestedID, IsBundle: true}, nil)
+	mockProductRepo.On("GetById", ctx, ebookID).Return(&models.Product{ID: ebookID, IsDigital: true}, nil)
+
+	//Act
+	_, err := service.SetComponents(ctx, bundleID, SetComponentsRequest{Components: []ComponentRequest{{ProductID: nestedID, Quantity: 1}}})
+
+	//Assert
+	assert.Equal(t, ErrInvalidComponent, err)
+
+	_, err = service.SetComponents(ctx, bundleID, SetComponentsRequest{Components: []ComponentRequest{{ProductID: ebookID, Quantity: 1}}})
+	assert.Equal(t, ErrInvalidComponent, err)
+}
+
+func TestSetComponents_ProductWithOwnStock(t *testing.T) {
+	//Arrange
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(new(MockBundleRepository), mockProductRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Price: 100, Stock: 3}, nil)
+
+	//Act
+	_, err := service.SetComponents(ctx, productID, SetComponentsRequest{Components: []ComponentRequest{{ProductID: uuid.New(), Quantity: 1}}})
+
+	//Assert
+	assert.Equal(t, ErrBundleHasStock, err)
+}
+
+func TestSetComponents_ProductIsComponent(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockBundleRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Price: 100}, nil)
+	mockRepo.On("IsComponent", ctx, productID).Return(true, nil)
+
+	//Act
+	_, err := service.SetComponents(ctx, productID, SetComponentsRequest{Components: []ComponentRequest{{ProductID: uuid.New(), Quantity: 1}}})
+
+	//Assert
+	assert.Equal(t, ErrBundleIsComponent, err)
+}
+
+func TestSetComponents_BackorderBundle(t *testing.T) {
+	//Arrange
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(new(MockBundleRepository), mockProductRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Price: 100, BackorderPolicy: models.BackorderAllow}, nil)
+
+	//Act
+	_, err := service.SetComponents(ctx, productID, SetComponentsRequest{Components: []ComponentRequest{{ProductID: uuid.New(), Quantity: 1}}})
+
+	//Assert
+	assert.Equal(t, ErrInvalidBundle, err)
+}
+
+func TestGetBundle_NotBundle(t *testing.T) {
+	//Arrange
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(new(MockBundleRepository), mockProductRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+
+	//Act
+	_, err := service.GetBundle(ctx, productID)
+
+	//Assert
+	assert.Equal(t, ErrNotBundle, err)
+}
+
+func TestGetBundle_HiddenProduct(t *testing.T) {
+	//Arrange
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(new(MockBundleRepository), mockProductRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusDraft, IsBundle: true}, nil)
+
+	//Act
+	_, err := service.GetBundle(ctx, productID)
+
+	//Assert
+	assert.Equal(t, ErrProductNotFound, err)
+}
+
+func TestGetBundle_OutOfStockComponent(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockBundleRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	bundleID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, bundleID).Return(&models.Product{ID: bundleID, Price: 90, Status: models.ProductStatusPublished, IsBundle: true, BackorderPolicy: models.BackorderDeny}, nil)
+	mockRepo.On("ListComponents", ctx, []uuid.UUID{bundleID}).Return([]*models.BundleComponent{
+		{BundleID: bundleID, ComponentID: uuid.New(), Quantity: 1, Price: 60, Stock: 0},
+		{BundleID: bundleID, ComponentID: uuid.New(), Quantity: 1, Price: 40, Stock: 7},
+	}, nil)
+
+	//Act
+	resp, err := service.GetBundle(ctx, bundleID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, resp.Stock)
+	assert.Equal(t, "out_of_stock", resp.Availability)
+	assert.Equal(t, 10.0, resp.Savings)
+}
+
+func TestGetBundle_LocalizedNames(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockBundleRepository)
+	mockProductRepo := new(MockProductRepository)
+	translationRepo := new(MockTranslationRepository)
+	service := NewService(mockRepo, mockProductRepo, productSrv.NewLocalizer(translationRepo, "uk"))
+	ctx := models.ContextWithLocale(context.Background(), "en")
+	bundleID, componentID := uuid.New(), uuid.New()
+
+	mockProductRepo.On("GetById", ctx, bundleID).Return(&models.Product{ID: bundleID, Name: "Набір", Price: 90, Status: models.ProductStatusPublished, IsBundle: true}, nil)
+	mockRepo.On("ListComponents", ctx, []uuid.UUID{bundleID}).Return([]*models.BundleComponent{
+		{BundleID: bundleID, ComponentID: componentID, Name: "Навушники", Quantity: 1, Price: 100, Stock: 3},
+	}, nil)
+	translationRepo.On("GetProductTranslations", ctx, "en", []uuid.UUID{bundleID, componentID}).Return([]*models.ProductTranslation{
+		{ProductID: bundleID, Locale: "en", Name: "Set"},
+		{ProductID: componentID, Locale: "en", Name: "Headphones"},
+	}, nil)
+
+	//Act
+	resp, err := service.GetBundle(ctx, bundleID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Set", resp.Name)
+	assert.Equal(t, "Headphones", resp.Components[0].Name)
+}
+
+func TestDeleteBundle_NotBundle(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockBundleRepository)
+	service := NewService(mockRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockRepo.On("Delete", ctx, productID).Return(sql.ErrNoRows)
+
+	//Act
+	err := service.DeleteBundle(ctx, productID)
+
+	//Assert
+	assert.Equal(t, ErrNotBundle, err)
+}
@@ -0,0 +1,32 @@
+package bundle
+
+import (
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DTO структури для комплектів
+
+type ComponentRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	// Quantity кількість продукту в одному комплекті
+	Quantity int `json:"quantity" validate:"required,min=1,max=100"`
+}
+
+type SetComponentsRequest struct {
+	Components []ComponentRequest `json:"components" validate:"required,min=1,max=20"`
+}
+
+type BundleResponse struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Name      string    `json:"name"`
+	// Price ціна комплекту (з урахуванням розпродажу)
+	Price float64 `json:"price"`
+	// ComponentsPrice вартість компонентів при купівлі окремо
+	ComponentsPrice float64 `json:"components_price"`
+	Savings         float64 `json:"savings"`
+	// Stock кількість комплектів, яку можна зібрати із залишків компонентів
+	Stock        int                       `json:"stock"`
+	Availability string                    `json:"availability"`
+	Components   []*models.BundleComponent `json:"components"`
+}
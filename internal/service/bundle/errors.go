@@ -0,0 +1,22 @@
+package bundle
+
+import "errors"
+
+// помилки пов'язані з комплектами
+var (
+	// Validate errors
+	ErrComponentsRequired       = errors.New("bundle must contain at least one component")
+	ErrTooManyComponents        = errors.New("bundle can contain at most 20 components")
+	ErrInvalidComponentQuantity = errors.New("component quantity must be between 1 and 100")
+	ErrDuplicateComponent       = errors.New("component is listed more than once")
+	ErrSelfComponent            = errors.New("bundle cannot contain itself")
+
+	// Logic errors
+	ErrProductNotFound   = errors.New("product not found")
+	ErrNotBundle         = errors.New("product is not a bundle")
+	ErrComponentNotFound = errors.New("component product not found")
+	ErrInvalidComponent  = errors.New("component must be a physical product that is not a bundle")
+	ErrInvalidBundle     = errors.New("bundle must be a physical product without backorders")
+	ErrBundleHasStock    = errors.New("product with its own stock cannot become a bundle")
+	ErrBundleIsComponent = errors.New("product is a component of another bundle")
+)
@@ -0,0 +1,14 @@
+package bundle
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// BundleService інтерфейс для роботи з комплектами
+type BundleService interface {
+	GetBundle(ctx context.Context, productID uuid.UUID) (*BundleResponse, error)
+	SetComponents(ctx context.Context, productID uuid.UUID, req SetComponentsRequest) (*BundleResponse, error)
+	DeleteBundle(ctx context.Context, productID uuid.UUID) error
+}
@@ -3,18 +3,46 @@ package cart
 import (
 	"context"
 	"fmt"
+	"log"
+	"time"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
 	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
 	"github.com/google/uuid"
 )
 
+// кількість рекомендацій "разом з цим купують" у відповіді кошика
+const alsoBoughtLimit = 6
+
+// термін дії кошика гостя за замовчуванням (від останнього звернення)
+const defaultGuestTTL = 30 * 24 * time.Hour
+
+// Config налаштування кошика
+type Config struct {
+	// TokenSecret ключ HMAC для підпису токенів кошиків гостей
+	TokenSecret string
+	// GuestTTL скільки зберігається кошик гостя після останнього звернення
+	GuestTTL time.Duration
+}
+
 type service struct {
-	CartRepo repository.CartRepository
+	CartRepo      repository.CartRepository
+	GuestCartRepo repository.GuestCartRepository
+	ProductRepo   repository.ProductRepository
+	Recommender   Recommender
+	cfg           Config
 }
 
-func NewService(cartRepo repository.CartRepository) CartService {
-	return &service{CartRepo: cartRepo}
+// NewService створює сервіс кошика; recommender може бути nil, тоді рекомендації не додаються
+func NewService(cartRepo repository.CartRepository, guestCartRepo repository.GuestCartRepository, productRepo repository.ProductRepository, recommender Recommender, cfg Config) CartService {
+	if cfg.GuestTTL <= 0 {
+		cfg.GuestTTL = defaultGuestTTL
+	}
+	return &service{CartRepo: cartRepo,
+		GuestCartRepo: guestCartRepo,
+		ProductRepo:   productRepo,
+		Recommender:   recommender,
+		cfg:           cfg}
 }
 
 // AddItem додавання товару в кошик
@@ -32,10 +60,20 @@ func (s *service) AddItem(ctx context.Context, userID uuid.UUID, req AddCartItem
 	if err != nil {
 		return nil, fmt.Errorf("failed get item: %w", err)
 	}
+
+	// перевірка продукту та залишку з урахуванням кількості, що вже є в кошику
+	quant := req.Quantity
+	if existItem != nil {
+		quant += existItem.Quantity
+	}
+	product, err := s.checkProduct(ctx, req.ProductID, quant)
+	if err != nil {
+		return nil, err
+	}
+
 	// перевірка на існування товару в кошику
 	if existItem != nil {
 		// якщо вже такий товар існює оновлюємо кількість
-		quant := existItem.Quantity + req.Quantity
 		if err := s.CartRepo.UpdateQuantity(ctx, existItem.ID, quant); err != nil {
 			return nil, fmt.Errorf("failed to update item quantity: %w", err)
 		}
@@ -44,11 +82,13 @@ func (s *service) AddItem(ctx context.Context, userID uuid.UUID, req AddCartItem
 	}
 
 	// додавання товару в кошик
+	// ціна фіксується на момент додавання для попереджень про її зміну
 	item := &models.CartItem{
-		ID:        uuid.New(),
-		UserID:    userID,
-		ProductID: req.ProductID,
-		Quantity:  req.Quantity,
+		ID:         uuid.New(),
+		UserID:     userID,
+		ProductID:  req.ProductID,
+		Quantity:   req.Quantity,
+		PriceAtAdd: product.EffectivePrice(time.Now()),
 	}
 	if err := s.CartRepo.AddItem(ctx, item); err != nil {
 		return nil, fmt.Errorf("failed to add item: %w", err)
@@ -105,26 +145,51 @@ func (s *service) ListItem(ctx context.Context, userID uuid.UUID) (*CartListResp
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cart item: %w", err)
 	}
+	return s.listResponse(ctx, items), nil
+}
 
+// listResponse формує відповідь кошика з загальною сумою, попередженнями та рекомендаціями.
+// Кожна позиція перевіряється за поточною ціною та залишком продукту
+func (s *service) listResponse(ctx context.Context, items []*models.CartItemWithProduct) *CartListResponse {
 	// створення відповіді для корзини
 	resp := &CartListResponse{
 		Items:      []*models.CartItem{},
 		TotalPrice: 0,
+		Warnings:   []CartWarning{},
+		AlsoBought: []*models.Product{},
 	}
 
 	// додаванння товарів у список
+	productIDs := make([]uuid.UUID, 0, len(items))
 	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
 		cartItem := &models.CartItem{
-			ID:        item.ID,
-			UserID:    item.UserID,
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
+			ID:         item.ID,
+			UserID:     item.UserID,
+			ProductID:  item.ProductID,
+			Quantity:   item.Quantity,
+			PriceAtAdd: item.PriceAtAdd,
+			CreatedAt:  item.CreatedAt,
 		}
 		resp.Items = append(resp.Items, cartItem)
-		resp.TotalPrice += float64(item.Quantity) * item.ProductPrice
+
+		// недоступні товари та кількість понад залишок не входять у суму
+		warnings, payable := validateItem(item)
+		resp.Warnings = append(resp.Warnings, warnings...)
+		resp.TotalPrice += float64(payable) * item.ProductPrice
+	}
+
+	// рекомендації не є критичними, тому помилка не ламає відповідь кошика
+	if s.Recommender != nil && len(productIDs) > 0 {
+		alsoBought, err := s.Recommender.CartRecommendations(ctx, productIDs, alsoBoughtLimit)
+		if err != nil {
+			log.Printf("failed to get cart recommendations: %v", err)
+		} else {
+			resp.AlsoBought = alsoBought
+		}
 	}
 
-	return resp, nil
+	return resp
 }
 
 // UpdateItem оновлення товару в кошику
@@ -141,7 +206,7 @@ func (s *service) UpdateItem(ctx context.Context, userID uuid.UUID, itemID uuid.
 	}
 
 	// получення товару
-	existItem, err := s.CartRepo.GetItem(ctx, userID, itemID)
+	existItem, err := s.CartRepo.GetItemByID(ctx, userID, itemID)
 	// обробка помилок
 	if err != nil {
 		return nil, fmt.Errorf("failed get item: %w", err)
@@ -150,6 +215,13 @@ func (s *service) UpdateItem(ctx context.Context, userID uuid.UUID, itemID uuid.
 		return nil, ErrProductNotFound
 	}
 
+	// при збільшенні кількості перевіряємо залишок
+	if req.Quantity > existItem.Quantity {
+		if _, err := s.checkProduct(ctx, existItem.ProductID, req.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
 	// валідація
 	if req.ProductID != nil {
 		existItem.ProductID = *req.ProductID
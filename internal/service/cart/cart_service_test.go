@@ -2,7 +2,11 @@ package cart
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
 	"github.com/google/uuid"
@@ -52,9 +56,15 @@ func (m *MockCartRepository) GetItemByID(ctx context.Context, userID, itemID uui
 	return args.Get(0).(*models.CartItem), args.Error(1)
 }
 
+// publishedProduct повертає опублікований продукт із залишком
+func publishedProduct(id uuid.UUID, price float64, stock int) *models.Product {
+	return &models.Product{ID: id, Price: price, Stock: stock, Status: models.ProductStatusPublished, BackorderPolicy: models.BackorderDeny}
+}
+
 func TestAddItem_Success(t *testing.T) {
 	mockRepo := new(MockCartRepository)
-	service := NewService(mockRepo)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
 	ctx := context.Background()
 	userID := uuid.New()
 	productID := uuid.New()
@@ -65,6 +75,7 @@ func TestAddItem_Success(t *testing.T) {
 	}
 
 	mockRepo.On("GetItem", ctx, userID, productID).Return(nil, nil)
+	mockProducts.On("GetById", ctx, productID).Return(publishedProduct(productID, 50, 10), nil)
 	mockRepo.On("AddItem", ctx, mock.AnythingOfType("*models.CartItem")).Return(nil)
 
 	item, err := service.AddItem(ctx, userID, req)
@@ -73,12 +84,109 @@ func TestAddItem_Success(t *testing.T) {
 	assert.NotNil(t, item)
 	assert.Equal(t, req.Quantity, item.Quantity)
 	assert.Equal(t, productID, item.ProductID)
+	assert.Equal(t, 50.0, item.PriceAtAdd)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddItem_SalePriceRecorded(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	product := publishedProduct(productID, 50, 10)
+	salePrice := 40.0
+	product.SalePrice = &salePrice
+
+	mockRepo.On("GetItem", ctx, userID, productID).Return(nil, nil)
+	mockProducts.On("GetById", ctx, productID).Return(product, nil)
+	mockRepo.On("AddItem", ctx, mock.MatchedBy(func(item *models.CartItem) bool {
+		return item.PriceAtAdd == 40
+	})).Return(nil)
+
+	_, err := service.AddItem(ctx, userID, AddCartItemRequest{ProductID: productID, Quantity: 1})
+
+	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAddItem_ProductNotFound(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockRepo.On("GetItem", ctx, userID, productID).Return(nil, nil)
+	mockProducts.On("GetById", ctx, productID).Return(nil, fmt.Errorf("failed to get product: %w", sql.ErrNoRows))
+
+	item, err := service.AddItem(ctx, userID, AddCartItemRequest{ProductID: productID, Quantity: 1})
+
+	assert.ErrorIs(t, err, ErrProductNotFound)
+	assert.Nil(t, item)
+	mockRepo.AssertNotCalled(t, "AddItem", mock.Anything, mock.Anything)
+}
+
+func TestAddItem_Unavailable(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	deletedAt := time.Now()
+	product := publishedProduct(productID, 50, 10)
+	product.DeletedAt = &deletedAt
+
+	mockRepo.On("GetItem", ctx, userID, productID).Return(nil, nil)
+	mockProducts.On("GetById", ctx, productID).Return(product, nil)
+
+	_, err := service.AddItem(ctx, userID, AddCartItemRequest{ProductID: productID, Quantity: 1})
+
+	assert.ErrorIs(t, err, ErrProductNotAvailable)
+	mockRepo.AssertNotCalled(t, "AddItem", mock.Anything, mock.Anything)
+}
+
+func TestAddItem_OutOfStock(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockRepo.On("GetItem", ctx, userID, productID).Return(nil, nil)
+	mockProducts.On("GetById", ctx, productID).Return(publishedProduct(productID, 50, 0), nil)
+
+	_, err := service.AddItem(ctx, userID, AddCartItemRequest{ProductID: productID, Quantity: 1})
+
+	assert.ErrorIs(t, err, ErrOutOfStock)
+}
+
+func TestAddItem_BackorderIgnoresStock(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	product := publishedProduct(productID, 50, 0)
+	product.BackorderPolicy = models.BackorderAllow
+
+	mockRepo.On("GetItem", ctx, userID, productID).Return(nil, nil)
+	mockProducts.On("GetById", ctx, productID).Return(product, nil)
+	mockRepo.On("AddItem", ctx, mock.AnythingOfType("*models.CartItem")).Return(nil)
+
+	item, err := service.AddItem(ctx, userID, AddCartItemRequest{ProductID: productID, Quantity: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, item.Quantity)
+}
+
 func TestAddItem_AlreadyExist(t *testing.T) {
 	mockRepo := new(MockCartRepository)
-	service := NewService(mockRepo)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
 	ctx := context.Background()
 	userID := uuid.New()
 	productID := uuid.New()
@@ -96,6 +204,7 @@ func TestAddItem_AlreadyExist(t *testing.T) {
 	}
 
 	mockRepo.On("GetItem", ctx, userID, productID).Return(existingItem, nil)
+	mockProducts.On("GetById", ctx, productID).Return(publishedProduct(productID, 50, 10), nil)
 	mockRepo.On("UpdateQuantity", ctx, existingItem.ID, 3).Return(nil)
 
 	item, err := service.AddItem(ctx, userID, req)
@@ -105,17 +214,38 @@ func TestAddItem_AlreadyExist(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAddItem_InsufficientStock(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	// у кошику вже 2, залишок 3 - ще 2 додати не можна
+	existingItem := &models.CartItem{ID: uuid.New(), UserID: userID, ProductID: productID, Quantity: 2}
+
+	mockRepo.On("GetItem", ctx, userID, productID).Return(existingItem, nil)
+	mockProducts.On("GetById", ctx, productID).Return(publishedProduct(productID, 50, 3), nil)
+
+	_, err := service.AddItem(ctx, userID, AddCartItemRequest{ProductID: productID, Quantity: 2})
+
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+	mockRepo.AssertNotCalled(t, "UpdateQuantity", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestUpdateItem_Success(t *testing.T) {
 	mockRepo := new(MockCartRepository)
-	service := NewService(mockRepo)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
 	ctx := context.Background()
 	userID := uuid.New()
 	itemID := uuid.New()
+	productID := uuid.New()
 
 	existingItem := &models.CartItem{
 		ID:        itemID,
 		UserID:    userID,
-		ProductID: uuid.New(),
+		ProductID: productID,
 		Quantity:  1,
 	}
 
@@ -123,7 +253,8 @@ func TestUpdateItem_Success(t *testing.T) {
 		Quantity: 5,
 	}
 
-	mockRepo.On("GetItem", ctx, userID, itemID).Return(existingItem, nil)
+	mockRepo.On("GetItemByID", ctx, userID, itemID).Return(existingItem, nil)
+	mockProducts.On("GetById", ctx, productID).Return(publishedProduct(productID, 50, 10), nil)
 	mockRepo.On("UpdateQuantity", ctx, itemID, req.Quantity).Return(nil)
 
 	item, err := service.UpdateItem(ctx, userID, itemID, req)
@@ -133,9 +264,28 @@ func TestUpdateItem_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUpdateItem_DecreaseSkipsStockCheck(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
+	ctx := context.Background()
+	userID, itemID := uuid.New(), uuid.New()
+
+	existingItem := &models.CartItem{ID: itemID, UserID: userID, ProductID: uuid.New(), Quantity: 5}
+
+	mockRepo.On("GetItemByID", ctx, userID, itemID).Return(existingItem, nil)
+	mockRepo.On("UpdateQuantity", ctx, itemID, 2).Return(nil)
+
+	item, err := service.UpdateItem(ctx, userID, itemID, UpdateCartItemRequest{Quantity: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, item.Quantity)
+	mockProducts.AssertNotCalled(t, "GetById", mock.Anything, mock.Anything)
+}
+
 func TestUpdateItem_NotFound(t *testing.T) {
 	mockRepo := new(MockCartRepository)
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, nil, nil, nil, Config{})
 	ctx := context.Background()
 	userID := uuid.New()
 	itemID := uuid.New()
@@ -144,7 +294,7 @@ func TestUpdateItem_NotFound(t *testing.T) {
 		Quantity: 5,
 	}
 
-	mockRepo.On("GetItem", ctx, userID, itemID).Return(nil, ErrItemNotFound)
+	mockRepo.On("GetItemByID", ctx, userID, itemID).Return(nil, ErrItemNotFound)
 
 	item, err := service.UpdateItem(ctx, userID, itemID, req)
 
@@ -153,9 +303,35 @@ func TestUpdateItem_NotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUpdateItem_LooksUpByItemID(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockCartRepository)
+	mockProducts := new(MockProductRepository)
+	service := NewService(mockRepo, nil, mockProducts, nil, Config{})
+	ctx := context.Background()
+	userID, itemID, productID := uuid.New(), uuid.New(), uuid.New()
+
+	// ID позиції кошика не збігається з ID товару
+	existingItem := &models.CartItem{ID: itemID, UserID: userID, ProductID: productID, Quantity: 1}
+
+	mockRepo.On("GetItemByID", ctx, userID, itemID).Return(existingItem, nil)
+	mockProducts.On("GetById", ctx, productID).Return(publishedProduct(productID, 50, 10), nil)
+	mockRepo.On("UpdateQuantity", ctx, itemID, 3).Return(nil)
+
+	//Act
+	item, err := service.UpdateItem(ctx, userID, itemID, UpdateCartItemRequest{Quantity: 3})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, productID, item.ProductID)
+	assert.Equal(t, 3, item.Quantity)
+	mockRepo.AssertNotCalled(t, "GetItem", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestDeleteItem_Success(t *testing.T) {
 	mockRepo := new(MockCartRepository)
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, nil, nil, nil, Config{})
 	ctx := context.Background()
 	userID := uuid.New()
 	itemID := uuid.New()
@@ -177,7 +353,7 @@ func TestDeleteItem_Success(t *testing.T) {
 
 func TestListItem_Success(t *testing.T) {
 	mockRepo := new(MockCartRepository)
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, nil, nil, nil, Config{})
 	ctx := context.Background()
 	userID := uuid.New()
 
@@ -203,3 +379,453 @@ func TestListItem_Success(t *testing.T) {
 	assert.Len(t, resp.Items, 1)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestListItem_Warnings(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	service := NewService(mockRepo, nil, nil, nil, Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	deletedAt := time.Now()
+
+	item := func(quantity int, priceAtAdd, price float64, stock int) *models.CartItemWithProduct {
+		return &models.CartItemWithProduct{
+			CartItem:        models.CartItem{ID: uuid.New(), UserID: userID, ProductID: uuid.New(), Quantity: quantity, PriceAtAdd: priceAtAdd},
+			ProductName:     "Product",
+			ProductPrice:    price,
+			ProductStock:    stock,
+			ProductStatus:   models.ProductStatusPublished,
+			BackorderPolicy: models.BackorderDeny,
+		}
+	}
+	ok := item(1, 10, 10, 5)
+	priceChanged := item(2, 20, 25, 5)
+	outOfStock := item(1, 30, 30, 0)
+	insufficient := item(4, 5, 5, 3)
+	deleted := item(1, 100, 100, 5)
+	deleted.ProductDeletedAt = &deletedAt
+	digital := item(10, 7, 7, 0)
+	digital.IsDigital = true
+
+	items := []*models.CartItemWithProduct{ok, priceChanged, outOfStock, insufficient, deleted, digital}
+	mockRepo.On("GetByUserId", ctx, userID).Return(items, nil)
+
+	resp, err := service.ListItem(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Items, 6)
+	assert.Equal(t, 20.0, resp.Items[1].PriceAtAdd)
+
+	codes := map[uuid.UUID]string{}
+	for _, w := range resp.Warnings {
+		codes[w.ItemID] = w.Code
+	}
+	assert.Len(t, resp.Warnings, 4)
+	assert.Equal(t, WarningPriceChanged, codes[priceChanged.ID])
+	assert.Equal(t, WarningOutOfStock, codes[outOfStock.ID])
+	assert.Equal(t, WarningInsufficientStock, codes[insufficient.ID])
+	assert.Equal(t, WarningProductUnavailable, codes[deleted.ID])
+
+	// 10 + 2*25 + 3*5 + 10*7; недоступні товари не враховуються
+	assert.InDelta(t, 145.0, resp.TotalPrice, 0.001)
+
+	for _, w := range resp.Warnings {
+		switch w.Code {
+		case WarningPriceChanged:
+			assert.Equal(t, 20.0, *w.PriceAtAdd)
+			assert.Equal(t, 25.0, *w.CurrentPrice)
+		case WarningInsufficientStock:
+			assert.Equal(t, 3, *w.Available)
+		}
+	}
+}
+
+func TestListItem_PriceRoundingNoWarning(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	service := NewService(mockRepo, nil, nil, nil, Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+
+	items := []*models.CartItemWithProduct{
+		{
+			CartItem:        models.CartItem{ID: uuid.New(), UserID: userID, ProductID: uuid.New(), Quantity: 1, PriceAtAdd: 19.99},
+			ProductPrice:    19.990000001,
+			ProductStock:    1,
+			ProductStatus:   models.ProductStatusPublished,
+			BackorderPolicy: models.BackorderDeny,
+		},
+	}
+	mockRepo.On("GetByUserId", ctx, userID).Return(items, nil)
+
+	resp, err := service.ListItem(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Warnings)
+}
+
+type MockRecommender struct {
+	mock.Mock
+}
+
+func (m *MockRecommender) CartRecommendations(ctx context.Context, productIDs []uuid.UUID, limit int) ([]*models.Product, error) {
+	args := m.Called(ctx, productIDs, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func TestListItem_AlsoBought(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	mockRecommender := new(MockRecommender)
+	service := NewService(mockRepo, nil, nil, mockRecommender, Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	productID := uuid.New()
+
+	items := []*models.CartItemWithProduct{
+		{
+			CartItem:     models.CartItem{ID: uuid.New(), UserID: userID, ProductID: productID, Quantity: 1},
+			ProductPrice: 50,
+		},
+	}
+	recommended := []*models.Product{{ID: uuid.New(), Name: "Case"}}
+
+	mockRepo.On("GetByUserId", ctx, userID).Return(items, nil)
+	mockRecommender.On("CartRecommendations", ctx, []uuid.UUID{productID}, alsoBoughtLimit).Return(recommended, nil)
+
+	resp, err := service.ListItem(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, recommended, resp.AlsoBought)
+	mockRecommender.AssertExpectations(t)
+}
+
+func TestListItem_AlsoBoughtErrorIgnored(t *testing.T) {
+	mockRepo := new(MockCartRepository)
+	mockRecommender := new(MockRecommender)
+	service := NewService(mockRepo, nil, nil, mockRecommender, Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	productID := uuid.New()
+
+	items := []*models.CartItemWithProduct{
+		{
+			CartItem:     models.CartItem{ID: uuid.New(), UserID: userID, ProductID: productID, Quantity: 1},
+			ProductPrice: 50,
+		},
+	}
+
+	mockRepo.On("GetByUserId", ctx, userID).Return(items, nil)
+	mockRecommender.On("CartRecommendations", ctx, []uuid.UUID{productID}, alsoBoughtLimit).Return(nil, errors.New("db error"))
+
+	resp, err := service.ListItem(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Items, 1)
+	assert.Empty(t, resp.AlsoBought)
+}
+
+type MockGuestCartRepository struct {
+	mock.Mock
+}
+
+func (m *MockGuestCartRepository) Create(ctx context.Context, cart *models.GuestCart) error {
+	args := m.Called(ctx, cart)
+	return args.Error(0)
+}
+func (m *MockGuestCartRepository) Get(ctx context.Context, id uuid.UUID) (*models.GuestCart, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GuestCart), args.Error(1)
+}
+func (m *MockGuestCartRepository) Touch(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	args := m.Called(ctx, id, expiresAt)
+	return args.Error(0)
+}
+func (m *MockGuestCartRepository) AddItem(ctx context.Context, cartID uuid.UUID, item *models.CartItem) error {
+	args := m.Called(ctx, cartID, item)
+	return args.Error(0)
+}
+func (m *MockGuestCartRepository) GetItems(ctx context.Context, cartID uuid.UUID) ([]*models.CartItemWithProduct, error) {
+	args := m.Called(ctx, cartID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CartItemWithProduct), args.Error(1)
+}
+func (m *MockGuestCartRepository) GetItem(ctx context.Context, cartID, productID uuid.UUID) (*models.CartItem, error) {
+	args := m.Called(ctx, cartID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CartItem), args.Error(1)
+}
+func (m *MockGuestCartRepository) GetItemByID(ctx context.Context, cartID, itemID uuid.UUID) (*models.CartItem, error) {
+	args := m.Called(ctx, cartID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CartItem), args.Error(1)
+}
+func (m *MockGuestCartRepository) UpdateQuantity(ctx context.Context, cartID, id uuid.UUID, quantity int) error {
+	args := m.Called(ctx, cartID, id, quantity)
+	return args.Error(0)
+}
+func (m *MockGuestCartRepository) RemoveItem(ctx context.Context, cartID, id uuid.UUID) error {
+	args := m.Called(ctx, cartID, id)
+	return args.Error(0)
+}
+func (m *MockGuestCartRepository) Clear(ctx context.Context, cartID uuid.UUID) error {
+	args := m.Called(ctx, cartID)
+	return args.Error(0)
+}
+func (m *MockGuestCartRepository) Merge(ctx context.Context, cartID, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, cartID, userID)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockGuestCartRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+func newGuestService() (CartService, *MockGuestCartRepository) {
+	guestRepo := new(MockGuestCartRepository)
+	return NewService(new(MockCartRepository), guestRepo, nil, nil, Config{TokenSecret: "secret"}), guestRepo
+}
+
+func TestCreateGuestCart_TokenResolves(t *testing.T) {
+	srv, guestRepo := newGuestService()
+	ctx := context.Background()
+
+	guestRepo.On("Create", ctx, mock.AnythingOfType("*models.GuestCart")).Return(nil)
+
+	cart, err := srv.CreateGuestCart(ctx)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cart.Token)
+	assert.WithinDuration(t, time.Now().Add(defaultGuestTTL), cart.ExpiresAt, time.Minute)
+
+	guestRepo.On("Get", ctx, cart.ID).Return(&models.GuestCart{ID: cart.ID}, nil)
+	guestRepo.On("Touch", ctx, cart.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	resolved, err := srv.ResolveGuestCart(ctx, cart.Token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cart.ID, resolved.ID)
+	assert.Equal(t, cart.Token, resolved.Token)
+	guestRepo.AssertExpectations(t)
+}
+
+func TestResolveGuestCart_InvalidToken(t *testing.T) {
+	srv, guestRepo := newGuestService()
+	ctx := context.Background()
+
+	// токен, підписаний іншим ключем
+	other := NewService(nil, guestRepo, nil, nil, Config{TokenSecret: "other"}).(*service)
+	forged := other.guestToken(uuid.New())
+
+	for _, token := range []string{"garbage", forged} {
+		cart, err := srv.ResolveGuestCart(ctx, token)
+
+		assert.ErrorIs(t, err, ErrInvalidCartToken)
+		assert.Nil(t, cart)
+	}
+	guestRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestResolveGuestCart_Expired(t *testing.T) {
+	srv, guestRepo := newGuestService()
+	ctx := context.Background()
+	cartID := uuid.New()
+	token := srv.(*service).guestToken(cartID)
+
+	guestRepo.On("Get", ctx, cartID).Return(nil, nil)
+
+	cart, err := srv.ResolveGuestCart(ctx, token)
+
+	assert.ErrorIs(t, err, ErrGuestCartNotFound)
+	assert.Nil(t, cart)
+	guestRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddGuestItem_AlreadyExist(t *testing.T) {
+	guestRepo := new(MockGuestCartRepository)
+	mockProducts := new(MockProductRepository)
+	srv := NewService(nil, guestRepo, mockProducts, nil, Config{TokenSecret: "secret"})
+	ctx := context.Background()
+	cartID, productID := uuid.New(), uuid.New()
+	existingItem := &models.CartItem{ID: uuid.New(), ProductID: productID, Quantity: 1}
+
+	guestRepo.On("GetItem", ctx, cartID, productID).Return(existingItem, nil)
+	mockProducts.On("GetById", ctx, productID).Return(publishedProduct(productID, 50, 10), nil)
+	guestRepo.On("UpdateQuantity", ctx, cartID, existingItem.ID, 3).Return(nil)
+
+	item, err := srv.AddGuestItem(ctx, cartID, AddCartItemRequest{ProductID: productID, Quantity: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, item.Quantity)
+	guestRepo.AssertExpectations(t)
+}
+
+func TestAddGuestItem_RecordsPrice(t *testing.T) {
+	guestRepo := new(MockGuestCartRepository)
+	mockProducts := new(MockProductRepository)
+	srv := NewService(nil, guestRepo, mockProducts, nil, Config{TokenSecret: "secret"})
+	ctx := context.Background()
+	cartID, productID := uuid.New(), uuid.New()
+
+	guestRepo.On("GetItem", ctx, cartID, productID).Return(nil, nil)
+	mockProducts.On("GetById", ctx, productID).Return(publishedProduct(productID, 12.5, 10), nil)
+	guestRepo.On("AddItem", ctx, cartID, mock.AnythingOfType("*models.CartItem")).Return(nil)
+
+	item, err := srv.AddGuestItem(ctx, cartID, AddCartItemRequest{ProductID: productID, Quantity: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, item.PriceAtAdd)
+	guestRepo.AssertExpectations(t)
+}
+
+func TestUpdateGuestItem_InsufficientStock(t *testing.T) {
+	guestRepo := new(MockGuestCartRepository)
+	mockProducts := new(MockProductRepository)
+	srv := NewService(nil, guestRepo, mockProducts, nil, Config{TokenSecret: "secret"})
+	ctx := context.Background()
+	cartID, itemID, productID := uuid.New(), uuid.New(), uuid.New()
+
+	guestRepo.On("GetItemByID", ctx, cartID, itemID).Return(&models.CartItem{ID: itemID, ProductID: productID, Quantity: 1}, nil)
+	mockProducts.On("GetById", ctx, productID).Return(publishedProduct(productID, 50, 2), nil)
+
+	_, err := srv.UpdateGuestItem(ctx, cartID, itemID, UpdateCartItemRequest{Quantity: 3})
+
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+	guestRepo.AssertNotCalled(t, "UpdateQuantity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateGuestItem_NotFound(t *testing.T) {
+	srv, guestRepo := newGuestService()
+	ctx := context.Background()
+	cartID, itemID := uuid.New(), uuid.New()
+
+	guestRepo.On("GetItemByID", ctx, cartID, itemID).Return(nil, nil)
+
+	item, err := srv.UpdateGuestItem(ctx, cartID, itemID, UpdateCartItemRequest{Quantity: 2})
+
+	assert.ErrorIs(t, err, ErrItemNotFound)
+	assert.Nil(t, item)
+}
+
+func TestMergeGuestCart_Success(t *testing.T) {
+	srv, guestRepo := newGuestService()
+	ctx := context.Background()
+	cartID, userID := uuid.New(), uuid.New()
+	token := srv.(*service).guestToken(cartID)
+
+	guestRepo.On("Merge", ctx, cartID, userID).Return(2, nil)
+
+	merged, err := srv.MergeGuestCart(ctx, userID, token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, merged)
+	guestRepo.AssertExpectations(t)
+}
+
+func TestMergeGuestCart_InvalidToken(t *testing.T) {
+	srv, guestRepo := newGuestService()
+
+	merged, err := srv.MergeGuestCart(context.Background(), uuid.New(), "garbage")
+
+	assert.ErrorIs(t, err, ErrInvalidCartToken)
+	assert.Equal(t, 0, merged)
+	guestRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
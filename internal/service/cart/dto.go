@@ -18,6 +18,33 @@ type UpdateCartItemRequest struct {
 }
 
 type CartListResponse struct {
-	Items      []*models.CartItem `json:"items"`
-	TotalPrice float64            `json:"total_price"`
+	Items []*models.CartItem `json:"items"`
+	// TotalPrice сума за поточними цінами без недоступних товарів та кількості понад залишок
+	TotalPrice float64 `json:"total_price"`
+	// Warnings зміни з моменту додавання товарів, які треба показати перед оформленням
+	Warnings []CartWarning `json:"warnings"`
+	// AlsoBought товари, які покупці купують разом з товарами кошика
+	AlsoBought []*models.Product `json:"also_bought"`
+}
+
+// коди попереджень кошика
+const (
+	WarningPriceChanged       = "price_changed"
+	WarningProductUnavailable = "product_unavailable"
+	WarningOutOfStock         = "out_of_stock"
+	WarningInsufficientStock  = "insufficient_stock"
+)
+
+// CartWarning попередження щодо позиції кошика
+type CartWarning struct {
+	ItemID    uuid.UUID `json:"item_id"`
+	ProductID uuid.UUID `json:"product_id"`
+	// Code price_changed, product_unavailable, out_of_stock або insufficient_stock
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// ціни для price_changed
+	PriceAtAdd   *float64 `json:"price_at_add,omitempty"`
+	CurrentPrice *float64 `json:"current_price,omitempty"`
+	// доступний залишок для out_of_stock та insufficient_stock
+	Available *int `json:"available,omitempty"`
 }
@@ -15,7 +15,13 @@ var (
 	ErrInvalidProductID    = errors.New("invalid product id")
 	ErrProductNotFound     = errors.New("product not found")
 	ErrProductNotAvailable = errors.New("product not available")
+	ErrOutOfStock          = errors.New("product is out of stock")
+	ErrInsufficientStock   = errors.New("not enough product in stock")
 
 	// Cart item error
 	ErrItemNotFound = errors.New("cart item not found")
+
+	// Guest cart error
+	ErrInvalidCartToken  = errors.New("invalid cart token")
+	ErrGuestCartNotFound = errors.New("guest cart not found or expired")
 )
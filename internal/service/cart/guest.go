@@ -0,0 +1,227 @@
+package cart
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// CreateGuestCart створює кошик гостя з підписаним токеном
+func (s *service) CreateGuestCart(ctx context.Context) (*models.GuestCart, error) {
+	cart := &models.GuestCart{
+		ID:        uuid.New(),
+		ExpiresAt: time.Now().Add(s.cfg.GuestTTL),
+	}
+	if err := s.GuestCartRepo.Create(ctx, cart); err != nil {
+		return nil, fmt.Errorf("failed to create guest cart: %w", err)
+	}
+	cart.Token = s.guestToken(cart.ID)
+	return cart, nil
+}
+
+// ResolveGuestCart повертає кошик гостя за токеном; кожне звернення продовжує термін дії кошика
+func (s *service) ResolveGuestCart(ctx context.Context, token string) (*models.GuestCart, error) {
+	cartID, err := s.parseGuestToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.GuestCartRepo.Get(ctx, cartID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guest cart: %w", err)
+	}
+	if cart == nil {
+		return nil, ErrGuestCartNotFound
+	}
+
+	cart.ExpiresAt = time.Now().Add(s.cfg.GuestTTL)
+	if err := s.GuestCartRepo.Touch(ctx, cart.ID, cart.ExpiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGuestCartNotFound
+		}
+		return nil, fmt.Errorf("failed to extend guest cart: %w", err)
+	}
+	cart.Token = token
+	return cart, nil
+}
+
+// AddGuestItem додавання товару в кошик гостя
+func (s *service) AddGuestItem(ctx context.Context, cartID uuid.UUID, req AddCartItemRequest) (*models.CartItem, error) {
+	// валідація
+	if req.ProductID == uuid.Nil {
+		return nil, ErrProductNotFound
+	}
+	if req.Quantity <= 0 {
+		return nil, ErrEmptyQuantity
+	}
+
+	// якщо товар вже є в кошику - оновлюємо кількість
+	existItem, err := s.GuestCartRepo.GetItem(ctx, cartID, req.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed get item: %w", err)
+	}
+
+	// перевірка продукту та залишку з урахуванням кількості, що вже є в кошику
+	quant := req.Quantity
+	if existItem != nil {
+		quant += existItem.Quantity
+	}
+	product, err := s.checkProduct(ctx, req.ProductID, quant)
+	if err != nil {
+		return nil, err
+	}
+
+	if existItem != nil {
+		if err := s.GuestCartRepo.UpdateQuantity(ctx, cartID, existItem.ID, quant); err != nil {
+			return nil, fmt.Errorf("failed to update item quantity: %w", err)
+		}
+		existItem.Quantity = quant
+		return existItem, nil
+	}
+
+	item := &models.CartItem{
+		ID:         uuid.New(),
+		ProductID:  req.ProductID,
+		Quantity:   req.Quantity,
+		PriceAtAdd: product.EffectivePrice(time.Now()),
+	}
+	if err := s.GuestCartRepo.AddItem(ctx, cartID, item); err != nil {
+		return nil, fmt.Errorf("failed to add item: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateGuestItem оновлення кількості товару в кошику гостя
+func (s *service) UpdateGuestItem(ctx context.Context, cartID, itemID uuid.UUID, req UpdateCartItemRequest) (*models.CartItem, error) {
+	// валідація
+	if itemID == uuid.Nil {
+		return nil, ErrItemIDRequired
+	}
+	if req.Quantity <= 0 {
+		return nil, ErrEmptyQuantity
+	}
+
+	existItem, err := s.GuestCartRepo.GetItemByID(ctx, cartID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed get item: %w", err)
+	}
+	if existItem == nil {
+		return nil, ErrItemNotFound
+	}
+
+	// при збільшенні кількості перевіряємо залишок
+	if req.Quantity > existItem.Quantity {
+		if _, err := s.checkProduct(ctx, existItem.ProductID, req.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.GuestCartRepo.UpdateQuantity(ctx, cartID, itemID, req.Quantity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to update quantity: %w", err)
+	}
+	existItem.Quantity = req.Quantity
+	return existItem, nil
+}
+
+// DeleteGuestItem видалення товару з кошика гостя
+func (s *service) DeleteGuestItem(ctx context.Context, cartID, itemID uuid.UUID) error {
+	// валідація
+	if itemID == uuid.Nil {
+		return ErrItemIDRequired
+	}
+
+	if err := s.GuestCartRepo.RemoveItem(ctx, cartID, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		return fmt.Errorf("failed to delete item: %w", err)
+	}
+	return nil
+}
+
+// ListGuestItems повернення товарів кошика гостя
+func (s *service) ListGuestItems(ctx context.Context, cartID uuid.UUID) (*CartListResponse, error) {
+	items, err := s.GuestCartRepo.GetItems(ctx, cartID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart item: %w", err)
+	}
+	return s.listResponse(ctx, items), nil
+}
+
+// ClearGuestCart очищення кошика гостя
+func (s *service) ClearGuestCart(ctx context.Context, cartID uuid.UUID) error {
+	if err := s.GuestCartRepo.Clear(ctx, cartID); err != nil {
+		return fmt.Errorf("failed to clear item: %w", err)
+	}
+	return nil
+}
+
+// MergeGuestCart переносить товари кошика гостя в кошик користувача після входу.
+// Кількості однакових товарів сумуються в межах залишку, після чого кошик гостя видаляється
+func (s *service) MergeGuestCart(ctx context.Context, userID uuid.UUID, token string) (int, error) {
+	// валідація
+	if userID == uuid.Nil {
+		return 0, ErrUserIDRequired
+	}
+	cartID, err := s.parseGuestToken(token)
+	if err != nil {
+		return 0, err
+	}
+
+	merged, err := s.GuestCartRepo.Merge(ctx, cartID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to merge guest cart: %w", err)
+	}
+	return merged, nil
+}
+
+// ExpireGuestCarts видаляє кошики гостей, до яких не зверталися довше за GuestTTL
+func (s *service) ExpireGuestCarts(ctx context.Context) (int, error) {
+	count, err := s.GuestCartRepo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire guest carts: %w", err)
+	}
+	return count, nil
+}
+
+// guestToken формує непрозорий токен кошика: ID кошика та його підпис HMAC
+func (s *service) guestToken(cartID uuid.UUID) string {
+	token := append(cartID[:], s.signGuestCart(cartID)...)
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// parseGuestToken перевіряє підпис токена та повертає ID кошика
+func (s *service) parseGuestToken(token string) (uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != len(uuid.UUID{})+sha256.Size {
+		return uuid.Nil, ErrInvalidCartToken
+	}
+
+	cartID, err := uuid.FromBytes(raw[:len(uuid.UUID{})])
+	if err != nil {
+		return uuid.Nil, ErrInvalidCartToken
+	}
+	if !hmac.Equal(raw[len(uuid.UUID{}):], s.signGuestCart(cartID)) {
+		return uuid.Nil, ErrInvalidCartToken
+	}
+	return cartID, nil
+}
+
+// signGuestCart підписує ID кошика гостя
+func (s *service) signGuestCart(cartID uuid.UUID) []byte {
+	mac := hmac.New(sha256.New, []byte(s.cfg.TokenSecret))
+	mac.Write([]byte("guest-cart:"))
+	mac.Write(cartID[:])
+	return mac.Sum(nil)
+}
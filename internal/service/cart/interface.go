@@ -14,4 +14,23 @@ type CartService interface {
 	DeleteItem(ctx context.Context, userID, itemID uuid.UUID) error
 	ListItem(ctx context.Context, userID uuid.UUID) (*CartListResponse, error)
 	ClearItem(ctx context.Context, userID uuid.UUID) error
+
+	// кошик гостя: ті самі операції за ID кошика, отриманим з підписаного токена
+	CreateGuestCart(ctx context.Context) (*models.GuestCart, error)
+	// ResolveGuestCart перевіряє токен, повертає кошик та продовжує термін його дії
+	ResolveGuestCart(ctx context.Context, token string) (*models.GuestCart, error)
+	AddGuestItem(ctx context.Context, cartID uuid.UUID, req AddCartItemRequest) (*models.CartItem, error)
+	UpdateGuestItem(ctx context.Context, cartID, itemID uuid.UUID, req UpdateCartItemRequest) (*models.CartItem, error)
+	DeleteGuestItem(ctx context.Context, cartID, itemID uuid.UUID) error
+	ListGuestItems(ctx context.Context, cartID uuid.UUID) (*CartListResponse, error)
+	ClearGuestCart(ctx context.Context, cartID uuid.UUID) error
+	// MergeGuestCart переносить кошик гостя в кошик користувача; повертає кількість перенесених позицій
+	MergeGuestCart(ctx context.Context, userID uuid.UUID, token string) (int, error)
+	// ExpireGuestCarts видаляє прострочені кошики гостей
+	ExpireGuestCarts(ctx context.Context) (int, error)
+}
+
+// Recommender джерело рекомендацій "разом з цим купують" для кошика
+type Recommender interface {
+	CartRecommendations(ctx context.Context, productIDs []uuid.UUID, limit int) ([]*models.Product, error)
 }
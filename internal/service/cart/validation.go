@@ -0,0 +1,102 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// checkProduct перевіряє, що продукт можна покласти в кошик у кількості quantity
+// (разом з тим, що вже є в кошику), та повертає його для фіксації ціни
+func (s *service) checkProduct(ctx context.Context, productID uuid.UUID, quantity int) (*models.Product, error) {
+	product, err := s.ProductRepo.GetById(ctx, productID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, ErrProductNotFound
+	}
+	if !product.IsVisible() {
+		return nil, ErrProductNotAvailable
+	}
+
+	// цифрові товари та товари під замовлення не обмежені залишком
+	if product.IsDigital || product.AllowsBackorder() {
+		return product, nil
+	}
+	if product.Stock <= 0 {
+		return nil, ErrOutOfStock
+	}
+	if quantity > product.Stock {
+		return nil, ErrInsufficientStock
+	}
+	return product, nil
+}
+
+// validateItem перевіряє позицію кошика за поточними даними продукту та повертає попередження.
+// Друге значення - кількість, яку можна купити зараз (враховується в загальній сумі)
+func validateItem(item *models.CartItemWithProduct) ([]CartWarning, int) {
+	var warnings []CartWarning
+
+	if !item.Purchasable() {
+		return append(warnings, CartWarning{
+			ItemID:    item.ID,
+			ProductID: item.ProductID,
+			Code:      WarningProductUnavailable,
+			Message:   fmt.Sprintf("%s is no longer available", item.ProductName),
+		}), 0
+	}
+
+	if cents(item.PriceAtAdd) != cents(item.ProductPrice) {
+		priceAtAdd, current := item.PriceAtAdd, item.ProductPrice
+		warnings = append(warnings, CartWarning{
+			ItemID:       item.ID,
+			ProductID:    item.ProductID,
+			Code:         WarningPriceChanged,
+			Message:      fmt.Sprintf("price of %s changed from %.2f to %.2f", item.ProductName, priceAtAdd, current),
+			PriceAtAdd:   &priceAtAdd,
+			CurrentPrice: &current,
+		})
+	}
+
+	if !item.StockLimited() {
+		return warnings, item.Quantity
+	}
+	available := item.ProductStock
+	if available < 0 {
+		available = 0
+	}
+	if available == 0 {
+		return append(warnings, CartWarning{
+			ItemID:    item.ID,
+			ProductID: item.ProductID,
+			Code:      WarningOutOfStock,
+			Message:   fmt.Sprintf("%s is out of stock", item.ProductName),
+			Available: &available,
+		}), 0
+	}
+	if item.Quantity > available {
+		warnings = append(warnings, CartWarning{
+			ItemID:    item.ID,
+			ProductID: item.ProductID,
+			Code:      WarningInsufficientStock,
+			Message:   fmt.Sprintf("only %d of %s available", available, item.ProductName),
+			Available: &available,
+		})
+		return warnings, available
+	}
+	return warnings, item.Quantity
+}
+
+// cents округлює ціну до копійок для порівняння
+func cents(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
@@ -0,0 +1,377 @@
+package collection
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/Xiancel/ecommerce/internal/slug"
+	"github.com/google/uuid"
+)
+
+// пагінація продуктів колекції
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// дозволені значення сортування продуктів колекції
+var allowedOrders = map[string]bool{
+	"price_asc":   true,
+	"price_desc":  true,
+	"name_asc":    true,
+	"name_desc":   true,
+	"rating_asc":  true,
+	"rating_desc": true,
+}
+
+type service struct {
+	collectionRepo repository.CollectionRepository
+	productRepo    repository.ProductRepository
+	localizer      *productSrv.Localizer
+}
+
+func NewService(collectionRepo repository.CollectionRepository, productRepo repository.ProductRepository, localizer *productSrv.Localizer) CollectionService {
+	return &service{collectionRepo: collectionRepo,
+		productRepo: productRepo,
+		localizer:   localizer}
+}
+
+// CreateCollection створює ручну або розумну колекцію
+func (s *service) CreateCollection(ctx context.Context, req CreateCollectionRequest) (*models.Collection, error) {
+	// валідація
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, ErrTitleRequired
+	}
+
+	collection := &models.Collection{
+		Title:       title,
+		Type:        req.Type,
+		IsPublished: true,
+	}
+	if req.IsPublished != nil {
+		collection.IsPublished = *req.IsPublished
+	}
+	if description := strings.TrimSpace(req.Description); description != "" {
+		collection.Description = &description
+	}
+
+	switch req.Type {
+	case models.CollectionTypeSmart:
+		rules, err := normalizeRules(req.Rules)
+		if err != nil {
+			return nil, err
+		}
+		collection.Rules = rules
+	case models.CollectionTypeManual:
+		if req.Rules != nil {
+			return nil, ErrInvalidRules
+		}
+	default:
+		return nil, ErrInvalidType
+	}
+
+	// slug: заданий вручну або згенерований з назви
+	collectionSlug, err := s.resolveSlug(ctx, req.Slug, title, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	collection.Slug = collectionSlug
+
+	// продукти ручної колекції перевіряються до створення
+	var productIDs []uuid.UUID
+	if len(req.ProductIDs) > 0 {
+		if collection.Type != models.CollectionTypeManual {
+			return nil, ErrNotManualCollection
+		}
+		productIDs, err = s.checkProducts(ctx, req.ProductIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.collectionRepo.Create(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	if len(productIDs) > 0 {
+		if err := s.collectionRepo.SetProducts(ctx, collection.ID, productIDs); err != nil {
+			return nil, fmt.Errorf("failed to set collection products: %w", err)
+		}
+	}
+	return collection, nil
+}
+
+// UpdateCollection оновлює колекцію (тип колекції не змінюється)
+func (s *service) UpdateCollection(ctx context.Context, id uuid.UUID, req UpdateCollectionRequest) (*models.Collection, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	if collection == nil {
+		return nil, ErrCollectionNotFound
+	}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, ErrTitleRequired
+		}
+		collection.Title = title
+	}
+	if req.Description != nil {
+		description := strings.TrimSpace(*req.Description)
+		collection.Description = &description
+		if description == "" {
+			collection.Description = nil
+		}
+	}
+	if req.IsPublished != nil {
+		collection.IsPublished = *req.IsPublished
+	}
+	if req.Rules != nil {
+		if collection.Type != models.CollectionTypeSmart {
+			return nil, ErrInvalidRules
+		}
+		collection.Rules, err = normalizeRules(req.Rules)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Slug != nil && *req.Slug != collection.Slug {
+		collection.Slug, err = s.resolveSlug(ctx, *req.Slug, collection.Title, collection.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.collectionRepo.Update(ctx, collection); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("failed to update collection: %w", err)
+	}
+	return collection, nil
+}
+
+// DeleteCollection видаляє колекцію
+func (s *service) DeleteCollection(ctx context.Context, id uuid.UUID) error {
+	if err := s.collectionRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCollectionNotFound
+		}
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	return nil
+}
+
+// GetCollection повертає колекцію зі списком продуктів ручної колекції
+func (s *service) GetCollection(ctx context.Context, id uuid.UUID) (*CollectionResponse, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	if collection == nil {
+		return nil, ErrCollectionNotFound
+	}
+
+	resp := &CollectionResponse{Collection: collection}
+	if collection.Type == models.CollectionTypeManual {
+		resp.ProductIDs, err = s.collectionRepo.ListProductIDs(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get collection products: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// ListCollections повертає колекції (для покупців - тільки опубліковані)
+func (s *service) ListCollections(ctx context.Context, publishedOnly bool) ([]*models.Collection, error) {
+	collections, err := s.collectionRepo.List(ctx, publishedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	if collections == nil {
+		collections = []*models.Collection{}
+	}
+	return collections, nil
+}
+
+// SetCollectionProducts замінює впорядкований список продуктів ручної колекції
+func (s *service) SetCollectionProducts(ctx context.Context, id uuid.UUID, req SetCollectionProductsRequest) (*CollectionResponse, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	if collection == nil {
+		return nil, ErrCollectionNotFound
+	}
+	if collection.Type != models.CollectionTypeManual {
+		return nil, ErrNotManualCollection
+	}
+
+	productIDs, err := s.checkProducts(ctx, req.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.collectionRepo.SetProducts(ctx, id, productIDs); err != nil {
+		return nil, fmt.Errorf("failed to set collection products: %w", err)
+	}
+	return &CollectionResponse{Collection: collection, ProductIDs: productIDs}, nil
+}
+
+// ListCollectionProducts повертає опубліковані продукти колекції з пагінацією та сортуванням.
+// Ручна колекція за замовчуванням впорядкована адміністратором, розумна обчислюється за правилами
+func (s *service) ListCollectionProducts(ctx context.Context, collectionSlug string, filter CollectionProductsFilter) (*CollectionProductsResponse, error) {
+	// пагінація
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLimit
+	}
+	if filter.Limit > maxLimit {
+		filter.Limit = maxLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	if filter.OrderBy != "" && !allowedOrders[filter.OrderBy] {
+		return nil, ErrInvalidOrderBy
+	}
+
+	collection, err := s.collectionRepo.GetBySlug(ctx, collectionSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	if collection == nil || !collection.IsPublished {
+		return nil, ErrCollectionNotFound
+	}
+
+	listFilter := rulesFilter(collection, time.Now())
+	listFilter.Status = models.ProductStatusPublished
+	listFilter.Limit = filter.Limit
+	listFilter.Offset = filter.Offset
+	listFilter.OrderBy = filter.OrderBy
+
+	products, err := s.productRepo.List(ctx, listFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection products: %w", err)
+	}
+	if products == nil {
+		products = []*models.Product{}
+	}
+	if err := s.localizer.Products(ctx, products...); err != nil {
+		return nil, err
+	}
+
+	total, estimated, err := s.productRepo.Count(ctx, listFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count collection products: %w", err)
+	}
+
+	return &CollectionProductsResponse{
+		Collection:     collection,
+		Products:       products,
+		Total:          total,
+		TotalEstimated: estimated,
+		Limit:          filter.Limit,
+		Offset:         filter.Offset,
+	}, nil
+}
+
+// rulesFilter перетворює колекцію на фільтр продуктів
+func rulesFilter(collection *models.Collection, now time.Time) models.ListFilter {
+	if collection.Type == models.CollectionTypeManual {
+		return models.ListFilter{CollectionID: &collection.ID}
+	}
+
+	var filter models.ListFilter
+	rules := collection.Rules
+	if rules == nil {
+		return filter
+	}
+	filter.CategoryID = rules.CategoryID
+	filter.MinPrice = rules.MinPrice
+	filter.MaxPrice = rules.MaxPrice
+	filter.Tag = rules.Tag
+	if rules.CreatedWithinDays > 0 {
+		createdAfter := now.AddDate(0, 0, -rules.CreatedWithinDays)
+		filter.CreatedAfter = &createdAfter
+	}
+	return filter
+}
+
+// normalizeRules перевіряє правила розумної колекції
+func normalizeRules(rules *models.CollectionRules) (*models.CollectionRules, error) {
+	if rules == nil {
+		return nil, ErrEmptyRules
+	}
+	normalized := *rules
+	normalized.Tag = strings.ToLower(strings.TrimSpace(normalized.Tag))
+
+	if normalized.MinPrice != nil && *normalized.MinPrice < 0 ||
+		normalized.MaxPrice != nil && *normalized.MaxPrice < 0 ||
+		normalized.MinPrice != nil && normalized.MaxPrice != nil && *normalized.MinPrice > *normalized.MaxPrice ||
+		normalized.CreatedWithinDays < 0 {
+		return nil, ErrInvalidRules
+	}
+	if normalized.CategoryID == nil && normalized.MinPrice == nil && normalized.MaxPrice == nil &&
+		normalized.Tag == "" && normalized.CreatedWithinDays == 0 {
+		return nil, ErrEmptyRules
+	}
+	return &normalized, nil
+}
+
+// resolveSlug перевіряє заданий slug або генерує унікальний з назви
+func (s *service) resolveSlug(ctx context.Context, requested, title string, collectionID uuid.UUID) (string, error) {
+	if requested != "" {
+		if slug.Make(requested) != requested {
+			return "", ErrInvalidSlug
+		}
+		taken, err := s.collectionRepo.SlugTaken(ctx, requested, collectionID)
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug: %w", err)
+		}
+		if taken {
+			return "", ErrSlugExists
+		}
+		return requested, nil
+	}
+
+	base := slug.Make(title)
+	if base == "" {
+		base = "collection"
+	}
+	result, err := slug.Unique(ctx, base, func(ctx context.Context, candidate string) (bool, error) {
+		return s.collectionRepo.SlugTaken(ctx, candidate, collectionID)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate slug: %w", err)
+	}
+	return result, nil
+}
+
+// checkProducts прибирає дублікати та перевіряє, що всі продукти існують
+func (s *service) checkProducts(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	result := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		product, err := s.productRepo.GetById(ctx, id)
+		if err != nil || product == nil || product.DeletedAt != nil {
+			return nil, ErrProductNotFound
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
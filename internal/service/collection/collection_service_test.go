@@ -0,0 +1,480 @@
+package collection
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCollectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCollectionRepository) Create(ctx context.Context, collection *models.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+func (m *MockCollectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Collection, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Collection), args.Error(1)
+}
+func (m *MockCollectionRepository) GetBySlug(ctx context.Context, slug string) (*models.Collection, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Collection), args.Error(1)
+}
+func (m *MockCollectionRepository) List(ctx context.Context, publishedOnly bool) ([]*models.Collection, error) {
+	args := m.Called(ctx, publishedOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Collection), args.Error(1)
+}
+func (m *MockCollectionRepository) Update(ctx context.Context, collection *models.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+func (m *MockCollectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockCollectionRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockCollectionRepository) SetProducts(ctx context.Context, collectionID uuid.UUID, productIDs []uuid.UUID) error {
+	args := m.Called(ctx, collectionID, productIDs)
+	return args.Error(0)
+}
+func (m *MockCollectionRepository) ListProductIDs(ctx context.Context, collectionID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, collectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranslationRepository) ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetProductTranslations(ctx context.Context, locale string, productIDs []uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, locale, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertProductTranslation(ctx context.Context, translation *models.ProductTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	args := m.Called(ctx, productID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslations(ctx context.Context, locale string) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslationBySlug(ctx context.Context, locale, slug string) (*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CategorySlugTaken(ctx context.Context, locale, slug string, categoryID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, locale, slug, categoryID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertCategoryTranslation(ctx context.Context, translation *models.CategoryTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	args := m.Called(ctx, categoryID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListMissingProducts(ctx context.Context, locale string, limit, offset int) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) ListMissingCategories(ctx context.Context, locale string) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CountMissingProducts(ctx context.Context, locale string) (int, error) {
+	args := m.Called(ctx, locale)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCreateCollection_SmartGeneratesSlug(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	srv := NewService(collectionRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+
+	maxPrice := 500.0
+	req := CreateCollectionRequest{
+		Title: "Літній розпродаж",
+		Type:  models.CollectionTypeSmart,
+		Rules: &models.CollectionRules{MaxPrice: &maxPrice, Tag: " Summer "},
+	}
+
+	collectionRepo.On("SlugTaken", ctx, "litnii-rozprodazh", uuid.Nil).Return(false, nil)
+	collectionRepo.On("Create", ctx, mock.AnythingOfType("*models.Collection")).Return(nil)
+
+	collection, err := srv.CreateCollection(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "litnii-rozprodazh", collection.Slug)
+	assert.Equal(t, "summer", collection.Rules.Tag)
+	assert.True(t, collection.IsPublished)
+	collectionRepo.AssertNotCalled(t, "SetProducts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateCollection_SmartWithoutRules(t *testing.T) {
+	srv := NewService(new(MockCollectionRepository), new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+
+	_, err := srv.CreateCollection(context.Background(), CreateCollectionRequest{Title: "Sale", Type: models.CollectionTypeSmart, Rules: &models.CollectionRules{}})
+
+	assert.ErrorIs(t, err, ErrEmptyRules)
+}
+
+func TestCreateCollection_InvalidPriceRange(t *testing.T) {
+	srv := NewService(new(MockCollectionRepository), new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	minPrice, maxPrice := 100.0, 10.0
+
+	_, err := srv.CreateCollection(context.Background(), CreateCollectionRequest{
+		Title: "Sale",
+		Type:  models.CollectionTypeSmart,
+		Rules: &models.CollectionRules{MinPrice: &minPrice, MaxPrice: &maxPrice},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidRules)
+}
+
+func TestCreateCollection_ManualWithProducts(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	productRepo := new(MockProductRepository)
+	srv := NewService(collectionRepo, productRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+
+	p1, p2 := uuid.New(), uuid.New()
+	productRepo.On("GetById", ctx, p1).Return(&models.Product{ID: p1}, nil)
+	productRepo.On("GetById", ctx, p2).Return(&models.Product{ID: p2}, nil)
+	collectionRepo.On("SlugTaken", ctx, "summer-sale", uuid.Nil).Return(false, nil)
+	collectionRepo.On("Create", ctx, mock.AnythingOfType("*models.Collection")).Return(nil)
+	collectionRepo.On("SetProducts", ctx, mock.Anything, []uuid.UUID{p2, p1}).Return(nil)
+
+	collection, err := srv.CreateCollection(ctx, CreateCollectionRequest{
+		Title:      "Summer Sale",
+		Slug:       "summer-sale",
+		Type:       models.CollectionTypeManual,
+		ProductIDs: []uuid.UUID{p2, p1, p2},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "summer-sale", collection.Slug)
+	collectionRepo.AssertExpectations(t)
+}
+
+func TestCreateCollection_InvalidSlug(t *testing.T) {
+	srv := NewService(new(MockCollectionRepository), new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+
+	_, err := srv.CreateCollection(context.Background(), CreateCollectionRequest{Title: "Sale", Slug: "Summer Sale", Type: models.CollectionTypeManual})
+
+	assert.ErrorIs(t, err, ErrInvalidSlug)
+}
+
+func TestCreateCollection_SlugExists(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	srv := NewService(collectionRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+
+	collectionRepo.On("SlugTaken", ctx, "sale", uuid.Nil).Return(true, nil)
+
+	_, err := srv.CreateCollection(ctx, CreateCollectionRequest{Title: "Sale", Slug: "sale", Type: models.CollectionTypeManual})
+
+	assert.ErrorIs(t, err, ErrSlugExists)
+}
+
+func TestSetCollectionProducts_SmartCollection(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	srv := NewService(collectionRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	id := uuid.New()
+
+	collectionRepo.On("GetByID", ctx, id).Return(&models.Collection{ID: id, Type: models.CollectionTypeSmart}, nil)
+
+	_, err := srv.SetCollectionProducts(ctx, id, SetCollectionProductsRequest{ProductIDs: []uuid.UUID{uuid.New()}})
+
+	assert.ErrorIs(t, err, ErrNotManualCollection)
+}
+
+func TestSetCollectionProducts_ProductNotFound(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	productRepo := new(MockProductRepository)
+	srv := NewService(collectionRepo, productRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	id, productID := uuid.New(), uuid.New()
+
+	collectionRepo.On("GetByID", ctx, id).Return(&models.Collection{ID: id, Type: models.CollectionTypeManual}, nil)
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, DeletedAt: &time.Time{}}, nil)
+
+	_, err := srv.SetCollectionProducts(ctx, id, SetCollectionProductsRequest{ProductIDs: []uuid.UUID{productID}})
+
+	assert.ErrorIs(t, err, ErrProductNotFound)
+	collectionRepo.AssertNotCalled(t, "SetProducts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListCollectionProducts_Manual(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	productRepo := new(MockProductRepository)
+	srv := NewService(collectionRepo, productRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+
+	collection := &models.Collection{ID: uuid.New(), Slug: "picks", Type: models.CollectionTypeManual, IsPublished: true}
+	products := []*models.Product{{ID: uuid.New()}}
+
+	collectionRepo.On("GetBySlug", ctx, "picks").Return(collection, nil)
+	productRepo.On("List", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.CollectionID != nil && *f.CollectionID == collection.ID &&
+			f.Status == models.ProductStatusPublished && f.Limit == defaultLimit && f.OrderBy == ""
+	})).Return(products, nil)
+	// загальна кількість рахується за тим самим фільтром, а не за розміром сторінки
+	productRepo.On("Count", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.CollectionID != nil && *f.CollectionID == collection.ID
+	})).Return(42, false, nil)
+
+	resp, err := srv.ListCollectionProducts(ctx, "picks", CollectionProductsFilter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, products, resp.Products)
+	assert.Equal(t, 42, resp.Total)
+}
+
+func TestListCollectionProducts_Localized(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	productRepo := new(MockProductRepository)
+	translationRepo := new(MockTranslationRepository)
+	srv := NewService(collectionRepo, productRepo, productSrv.NewLocalizer(translationRepo, "uk"))
+	ctx := models.ContextWithLocale(context.Background(), "en")
+
+	collection := &models.Collection{ID: uuid.New(), Slug: "picks", Type: models.CollectionTypeManual, IsPublished: true}
+	product := &models.Product{ID: uuid.New(), Name: "Навушники"}
+
+	collectionRepo.On("GetBySlug", ctx, "picks").Return(collection, nil)
+	productRepo.On("List", ctx, mock.Anything).Return([]*models.Product{product}, nil)
+	productRepo.On("Count", ctx, mock.Anything).Return(1, false, nil)
+	translationRepo.On("GetProductTranslations", ctx, "en", []uuid.UUID{product.ID}).Return([]*models.ProductTranslation{
+		{ProductID: product.ID, Locale: "en", Name: "Headphones"},
+	}, nil)
+
+	resp, err := srv.ListCollectionProducts(ctx, "picks", CollectionProductsFilter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Headphones", resp.Products[0].Name)
+}
+
+func TestListCollectionProducts_SmartRules(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	productRepo := new(MockProductRepository)
+	srv := NewService(collectionRepo, productRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+
+	categoryID := uuid.New()
+	minPrice := 10.0
+	collection := &models.Collection{
+		ID:          uuid.New(),
+		Slug:        "new-arrivals",
+		Type:        models.CollectionTypeSmart,
+		IsPublished: true,
+		Rules:       &models.CollectionRules{CategoryID: &categoryID, MinPrice: &minPrice, Tag: "summer", CreatedWithinDays: 30},
+	}
+
+	collectionRepo.On("GetBySlug", ctx, "new-arrivals").Return(collection, nil)
+	productRepo.On("List", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		expectedAfter := time.Now().AddDate(0, 0, -30)
+		return f.CollectionID == nil && f.CategoryID == &categoryID && *f.MinPrice == minPrice &&
+			f.Tag == "summer" && f.CreatedAfter != nil && f.CreatedAfter.Sub(expectedAfter).Abs() < time.Minute &&
+			f.OrderBy == "price_asc" && f.Limit == 5 && f.Offset == 10
+	})).Return([]*models.Product{}, nil)
+	productRepo.On("Count", ctx, mock.Anything).Return(0, false, nil)
+
+	resp, err := srv.ListCollectionProducts(ctx, "new-arrivals", CollectionProductsFilter{Limit: 5, Offset: 10, OrderBy: "price_asc"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Products)
+	productRepo.AssertExpectations(t)
+}
+
+func TestListCollectionProducts_Unpublished(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	srv := NewService(collectionRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+
+	collectionRepo.On("GetBySlug", ctx, "hidden").Return(&models.Collection{ID: uuid.New(), Type: models.CollectionTypeManual}, nil)
+
+	_, err := srv.ListCollectionProducts(ctx, "hidden", CollectionProductsFilter{})
+
+	assert.ErrorIs(t, err, ErrCollectionNotFound)
+}
+
+func TestListCollectionProducts_InvalidOrderBy(t *testing.T) {
+	srv := NewService(new(MockCollectionRepository), new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+
+	_, err := srv.ListCollectionProducts(context.Background(), "any", CollectionProductsFilter{OrderBy: "random"})
+
+	assert.ErrorIs(t, err, ErrInvalidOrderBy)
+}
+
+func TestUpdateCollection_RulesOnManual(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	srv := NewService(collectionRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	id := uuid.New()
+
+	collectionRepo.On("GetByID", ctx, id).Return(&models.Collection{ID: id, Type: models.CollectionTypeManual}, nil)
+
+	_, err := srv.UpdateCollection(ctx, id, UpdateCollectionRequest{Rules: &models.CollectionRules{Tag: "x"}})
+
+	assert.ErrorIs(t, err, ErrInvalidRules)
+}
+
+func TestDeleteCollection_NotFound(t *testing.T) {
+	collectionRepo := new(MockCollectionRepository)
+	srv := NewService(collectionRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	id := uuid.New()
+
+	collectionRepo.On("Delete", ctx, id).Return(sql.ErrNoRows)
+
+	err := srv.DeleteCollection(ctx, id)
+
+	assert.ErrorIs(t, err, ErrCollectionNotFound)
+}
@@ -0,0 +1,52 @@
+package collection
+
+import (
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DTO структури для колекцій
+
+type CreateCollectionRequest struct {
+	Title       string                  `json:"title" validate:"required,max=255"`
+	Slug        string                  `json:"slug" validate:"omitempty,max=255"`
+	Description string                  `json:"description"`
+	Type        string                  `json:"type" validate:"required,oneof=manual smart"`
+	Rules       *models.CollectionRules `json:"rules"`
+	IsPublished *bool                   `json:"is_published"`
+	ProductIDs  []uuid.UUID             `json:"product_ids"`
+}
+
+type UpdateCollectionRequest struct {
+	Title       *string                 `json:"title" validate:"omitempty,max=255"`
+	Slug        *string                 `json:"slug" validate:"omitempty,max=255"`
+	Description *string                 `json:"description"`
+	Rules       *models.CollectionRules `json:"rules"`
+	IsPublished *bool                   `json:"is_published"`
+}
+
+type SetCollectionProductsRequest struct {
+	ProductIDs []uuid.UUID `json:"product_ids"`
+}
+
+// CollectionResponse колекція з продуктами ручної колекції (для адміністратора)
+type CollectionResponse struct {
+	*models.Collection
+	ProductIDs []uuid.UUID `json:"product_ids,omitempty"`
+}
+
+type CollectionProductsFilter struct {
+	Limit   int    `json:"limit" validate:"min=1,max=100"`
+	Offset  int    `json:"offset" validate:"gte=0"`
+	OrderBy string `json:"order_by" validate:"omitempty,oneof=price_asc price_desc name_asc name_desc rating_asc rating_desc"`
+}
+
+type CollectionProductsResponse struct {
+	Collection *models.Collection `json:"collection"`
+	Products   []*models.Product  `json:"products"`
+	// Total кількість усіх продуктів колекції (для великих наборів - оцінка)
+	Total          int  `json:"total"`
+	TotalEstimated bool `json:"total_estimated,omitempty"`
+	Limit          int  `json:"limit"`
+	Offset         int  `json:"offset"`
+}
@@ -0,0 +1,20 @@
+package collection
+
+import "errors"
+
+// помилки пов'язані з колекціями
+var (
+	// Validation errors
+	ErrTitleRequired  = errors.New("collection title is required")
+	ErrInvalidType    = errors.New("invalid collection type, use manual or smart")
+	ErrInvalidSlug    = errors.New("slug may contain only lowercase latin letters, digits and dashes")
+	ErrInvalidRules   = errors.New("invalid collection rules")
+	ErrEmptyRules     = errors.New("smart collection needs at least one rule")
+	ErrInvalidOrderBy = errors.New("invalid sort, use price_asc, price_desc, name_asc, name_desc, rating_asc or rating_desc")
+
+	// Logic errors
+	ErrCollectionNotFound  = errors.New("collection not found")
+	ErrSlugExists          = errors.New("collection with this slug already exists")
+	ErrNotManualCollection = errors.New("products can be set only for manual collections")
+	ErrProductNotFound     = errors.New("product not found")
+)
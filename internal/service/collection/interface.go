@@ -0,0 +1,19 @@
+package collection
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// CollectionService інтерфейс для роботи з колекціями товарів
+type CollectionService interface {
+	CreateCollection(ctx context.Context, req CreateCollectionRequest) (*models.Collection, error)
+	UpdateCollection(ctx context.Context, id uuid.UUID, req UpdateCollectionRequest) (*models.Collection, error)
+	DeleteCollection(ctx context.Context, id uuid.UUID) error
+	GetCollection(ctx context.Context, id uuid.UUID) (*CollectionResponse, error)
+	ListCollections(ctx context.Context, publishedOnly bool) ([]*models.Collection, error)
+	SetCollectionProducts(ctx context.Context, id uuid.UUID, req SetCollectionProductsRequest) (*CollectionResponse, error)
+	ListCollectionProducts(ctx context.Context, slug string, filter CollectionProductsFilter) (*CollectionProductsResponse, error)
+}
@@ -0,0 +1,298 @@
+package download
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/Xiancel/ecommerce/internal/storage"
+	"github.com/google/uuid"
+)
+
+// значення за замовчуванням для конфігурації
+const (
+	defaultMaxUploadBytes = 200 << 20
+	defaultLinkTTL        = 72 * time.Hour
+	defaultMaxDownloads   = 5
+	maxFileNameLength     = 255
+)
+
+// Config налаштування сервісу завантажень
+type Config struct {
+	// Secret ключ HMAC для підпису посилань
+	Secret string
+	// LinkTTL скільки діє право на завантаження після оплати
+	LinkTTL time.Duration
+	// MaxDownloads кількість завантажень на позицію замовлення
+	MaxDownloads int
+	// BaseURL адреса API, від якої будуються посилання
+	BaseURL        string
+	MaxUploadBytes int64
+}
+
+type service struct {
+	downloadRepo repository.DownloadRepository
+	orderRepo    repository.OrderRepository
+	productRepo  repository.ProductRepository
+	storage      storage.BlobStorage
+	cfg          Config
+}
+
+func NewService(downloadRepo repository.DownloadRepository, orderRepo repository.OrderRepository, productRepo repository.ProductRepository, blobStorage storage.BlobStorage, cfg Config) DownloadService {
+	if cfg.LinkTTL <= 0 {
+		cfg.LinkTTL = defaultLinkTTL
+	}
+	if cfg.MaxDownloads <= 0 {
+		cfg.MaxDownloads = defaultMaxDownloads
+	}
+	if cfg.MaxUploadBytes <= 0 {
+		cfg.MaxUploadBytes = defaultMaxUploadBytes
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &service{downloadRepo: downloadRepo,
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+		storage:     blobStorage,
+		cfg:         cfg}
+}
+
+// UploadFile зберігає файл цифрового товару; попередній файл замінюється
+func (s *service) UploadFile(ctx context.Context, productID uuid.UUID, req UploadFileRequest) (*models.ProductFile, error) {
+	// валідація
+	if req.File == nil {
+		return nil, ErrFileRequired
+	}
+	fileName := path.Base(strings.ReplaceAll(strings.TrimSpace(req.FileName), "\\", "/"))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, ErrFileNameRequired
+	}
+	if len(fileName) > maxFileNameLength {
+		fileName = fileName[len(fileName)-maxFileNameLength:]
+	}
+
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || product.DeletedAt != nil {
+		return nil, ErrProductNotFound
+	}
+	if !product.IsDigital {
+		return nil, ErrProductNotDigital
+	}
+
+	previous, err := s.downloadRepo.GetFile(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product file: %w", err)
+	}
+
+	// файл пишеться потоком; ключ не містить назви файлу від користувача
+	ext := strings.ToLower(path.Ext(fileName))
+	key := fmt.Sprintf("downloads/%s/%s%s", productID, uuid.New(), safeExtension(ext))
+	body := &countingReader{r: io.LimitReader(req.File, s.cfg.MaxUploadBytes+1)}
+	if err := s.storage.Put(ctx, key, body); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+	if body.n == 0 || body.n > s.cfg.MaxUploadBytes {
+		s.deleteBlob(ctx, key)
+		if body.n == 0 {
+			return nil, ErrFileRequired
+		}
+		return nil, ErrFileTooLarge
+	}
+
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	file := &models.ProductFile{
+		ProductID:   productID,
+		StorageKey:  key,
+		FileName:    fileName,
+		ContentType: contentType,
+		SizeBytes:   body.n,
+	}
+	if err := s.downloadRepo.UpsertFile(ctx, file); err != nil {
+		s.deleteBlob(ctx, key)
+		return nil, fmt.Errorf("failed to save product file: %w", err)
+	}
+
+	if previous != nil && previous.StorageKey != key {
+		s.deleteBlob(ctx, previous.StorageKey)
+	}
+	return file, nil
+}
+
+// GetFile повертає файл цифрового товару
+func (s *service) GetFile(ctx context.Context, productID uuid.UUID) (*models.ProductFile, error) {
+	file, err := s.downloadRepo.GetFile(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product file: %w", err)
+	}
+	if file == nil {
+		return nil, ErrFileNotFound
+	}
+	return file, nil
+}
+
+// ListOrderDownloads повертає посилання на цифрові позиції оплаченого замовлення.
+// Права на завантаження створюються при першому запиті і діють LinkTTL від моменту оплати
+func (s *service) ListOrderDownloads(ctx context.Context, userID, orderID uuid.UUID) ([]*DownloadLink, error) {
+	order, err := s.orderRepo.GetById(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	// чуже замовлення не розкривається
+	if order.UserID == nil || *order.UserID != userID {
+		return nil, ErrOrderNotFound
+	}
+	if order.PaidAt == nil || order.Status == "cancelled" {
+		return nil, ErrOrderNotPaid
+	}
+
+	expiresAt := order.PaidAt.Add(s.cfg.LinkTTL)
+	if err := s.downloadRepo.CreateGrants(ctx, orderID, expiresAt, s.cfg.MaxDownloads); err != nil {
+		return nil, fmt.Errorf("failed to create download grants: %w", err)
+	}
+
+	grants, err := s.downloadRepo.ListGrants(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list download grants: %w", err)
+	}
+
+	now := time.Now()
+	links := make([]*DownloadLink, 0, len(grants))
+	for _, grant := range grants {
+		link := &DownloadLink{
+			GrantID:            grant.ID,
+			ProductID:          grant.ProductID,
+			ProductName:        grant.ProductName,
+			ExpiresAt:          grant.ExpiresAt,
+			DownloadsRemaining: max(grant.MaxDownloads-grant.DownloadCount, 0),
+		}
+		if grant.FileName != nil {
+			link.FileName = *grant.FileName
+		}
+		link.Available = grant.StorageKey != nil && link.DownloadsRemaining > 0 && now.Before(grant.ExpiresAt)
+		if link.Available {
+			link.URL = s.signedURL(grant.ID, grant.ExpiresAt.Unix())
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// Download перевіряє підпис і термін дії посилання, зараховує завантаження та відкриває файл
+func (s *service) Download(ctx context.Context, req DownloadRequest) (*DownloadFile, error) {
+	if !hmac.Equal([]byte(s.sign(req.GrantID, req.Expires)), []byte(req.Signature)) {
+		return nil, ErrInvalidSignature
+	}
+	if time.Now().Unix() >= req.Expires {
+		return nil, ErrLinkExpired
+	}
+
+	grant, err := s.downloadRepo.GetGrant(ctx, req.GrantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get download grant: %w", err)
+	}
+	if grant == nil {
+		return nil, ErrDownloadNotFound
+	}
+	// скасоване замовлення (зокрема повернення коштів) відкликає завантаження
+	if grant.OrderStatus == "cancelled" {
+		return nil, ErrOrderNotPaid
+	}
+	if !time.Now().Before(grant.ExpiresAt) {
+		return nil, ErrLinkExpired
+	}
+	if grant.DownloadCount >= grant.MaxDownloads {
+		return nil, ErrDownloadLimitReached
+	}
+	if grant.StorageKey == nil {
+		return nil, ErrFileNotFound
+	}
+
+	body, err := s.storage.Open(ctx, *grant.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	// завантаження зараховується лише тоді, коли файл вдалося відкрити
+	if err := s.downloadRepo.UseGrant(ctx, grant.ID); err != nil {
+		body.Close()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDownloadLimitReached
+		}
+		return nil, fmt.Errorf("failed to use download grant: %w", err)
+	}
+
+	file := &DownloadFile{
+		FileName:    *grant.FileName,
+		ContentType: "application/octet-stream",
+		Body:        body,
+	}
+	if grant.ContentType != nil {
+		file.ContentType = *grant.ContentType
+	}
+	return file, nil
+}
+
+// signedURL формує посилання на завантаження з підписом HMAC
+func (s *service) signedURL(grantID uuid.UUID, expires int64) string {
+	return fmt.Sprintf("%s/downloads/%s?expires=%d&signature=%s", s.cfg.BaseURL, grantID, expires, s.sign(grantID, expires))
+}
+
+// sign підписує ID права на завантаження разом з терміном дії посилання
+func (s *service) sign(grantID uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Secret))
+	fmt.Fprintf(mac, "%s:%d", grantID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deleteBlob видаляє файл зі сховища; помилка лише логується
+func (s *service) deleteBlob(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		log.Printf("failed to delete file %s: %v", key, err)
+	}
+}
+
+// safeExtension залишає розширення файлу лише з латинських літер та цифр
+func safeExtension(ext string) string {
+	if len(ext) < 2 || len(ext) > 10 {
+		return ""
+	}
+	for _, r := range ext[1:] {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	return ext
+}
+
+// countingReader рахує прочитані байти
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,505 @@
+package download
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDownloadRepository struct {
+	mock.Mock
+}
+
+func (m *MockDownloadRepository) GetFile(ctx context.Context, productID uuid.UUID) (*models.ProductFile, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductFile), args.Error(1)
+}
+func (m *MockDownloadRepository) UpsertFile(ctx context.Context, file *models.ProductFile) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+func (m *MockDownloadRepository) CreateGrants(ctx context.Context, orderID uuid.UUID, expiresAt time.Time, maxDownloads int) error {
+	args := m.Called(ctx, orderID, expiresAt, maxDownloads)
+	return args.Error(0)
+}
+func (m *MockDownloadRepository) ListGrants(ctx context.Context, orderID uuid.UUID) ([]*models.DownloadGrant, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.DownloadGrant), args.Error(1)
+}
+func (m *MockDownloadRepository) GetGrant(ctx context.Context, id uuid.UUID) (*models.DownloadGrant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DownloadGrant), args.Error(1)
+}
+func (m *MockDownloadRepository) UseGrant(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) Create(ctx context.Context, order *models.Order, items []*models.OrderItem, allocations []*models.StockAllocation) error {
+	args := m.Called(ctx, order, items, allocations)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+func (m *MockOrderRepository) GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]*models.OrderItem, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrderItem), args.Error(1)
+}
+func (m *MockOrderRepository) List(ctx context.Context, filter models.OrderListFilter) ([]*models.Order, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Order), args.Error(1)
+}
+func (m *MockOrderRepository) Count(ctx context.Context, filter models.OrderListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+const testSecret = "test-secret"
+
+// testConfig налаштування сервісу для тестів
+var testConfig = Config{
+	Secret:         testSecret,
+	LinkTTL:        time.Hour,
+	MaxDownloads:   3,
+	BaseURL:        "https://shop.example.com/api/v1/",
+	MaxUploadBytes: 16,
+}
+
+// signedRequest формує запит на завантаження з коректним підписом
+func signedRequest(srv DownloadService, grantID uuid.UUID, expires int64) DownloadRequest {
+	return DownloadRequest{GrantID: grantID, Expires: expires, Signature: srv.(*service).sign(grantID, expires)}
+}
+
+func TestUploadFile_Success(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	productRepo := new(MockProductRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, new(MockOrderRepository), productRepo, blobStorage, testConfig)
+	ctx := context.Background()
+	productID := uuid.New()
+
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, IsDigital: true}, nil)
+	downloadRepo.On("GetFile", ctx, productID).Return(nil, nil)
+	downloadRepo.On("UpsertFile", ctx, mock.AnythingOfType("*models.ProductFile")).Return(nil)
+
+	//Act
+	file, err := srv.UploadFile(ctx, productID, UploadFileRequest{File: strings.NewReader("ebook"), FileName: "../Go Book.EPUB"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Go Book.EPUB", file.FileName)
+	assert.Equal(t, int64(5), file.SizeBytes)
+	assert.True(t, strings.HasPrefix(file.StorageKey, "downloads/"+productID.String()+"/"))
+	assert.True(t, strings.HasSuffix(file.StorageKey, ".epub"))
+
+	stored, err := blobStorage.Open(ctx, file.StorageKey)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(stored)
+	stored.Close()
+	assert.Equal(t, "ebook", string(data))
+	downloadRepo.AssertExpectations(t)
+}
+
+func TestUploadFile_NotDigital(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	productRepo := new(MockProductRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, new(MockOrderRepository), productRepo, blobStorage, testConfig)
+	ctx := context.Background()
+	productID := uuid.New()
+
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+
+	//Act
+	file, err := srv.UploadFile(ctx, productID, UploadFileRequest{File: strings.NewReader("ebook"), FileName: "book.pdf"})
+
+	//Assert
+	assert.Nil(t, file)
+	assert.Equal(t, ErrProductNotDigital, err)
+	downloadRepo.AssertNotCalled(t, "UpsertFile", mock.Anything, mock.Anything)
+}
+
+func TestUploadFile_TooLarge(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	productRepo := new(MockProductRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, new(MockOrderRepository), productRepo, blobStorage, testConfig)
+	ctx := context.Background()
+	productID := uuid.New()
+
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, IsDigital: true}, nil)
+	downloadRepo.On("GetFile", ctx, productID).Return(nil, nil)
+
+	//Act
+	file, err := srv.UploadFile(ctx, productID, UploadFileRequest{File: strings.NewReader(strings.Repeat("x", 17)), FileName: "book.pdf"})
+
+	//Assert
+	assert.Nil(t, file)
+	assert.Equal(t, ErrFileTooLarge, err)
+	downloadRepo.AssertNotCalled(t, "UpsertFile", mock.Anything, mock.Anything)
+}
+
+func TestListOrderDownloads_SignsLinks(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	orderRepo := new(MockOrderRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, orderRepo, new(MockProductRepository), blobStorage, testConfig)
+	ctx := context.Background()
+	userID, orderID := uuid.New(), uuid.New()
+	paidAt := time.Now().Add(-10 * time.Minute)
+	fileName, key := "book.epub", "downloads/key.epub"
+	grant := &models.DownloadGrant{
+		ID:           uuid.New(),
+		OrderID:      orderID,
+		ProductName:  "Go Book",
+		MaxDownloads: 3,
+		ExpiresAt:    paidAt.Add(time.Hour),
+		FileName:     &fileName,
+		StorageKey:   &key,
+	}
+	missingFile := &models.DownloadGrant{ID: uuid.New(), OrderID: orderID, MaxDownloads: 3, ExpiresAt: paidAt.Add(time.Hour)}
+
+	orderRepo.On("GetById", ctx, orderID).Return(&models.Order{ID: orderID, UserID: &userID, Status: "paid", PaidAt: &paidAt}, nil)
+	downloadRepo.On("CreateGrants", ctx, orderID, paidAt.Add(time.Hour), 3).Return(nil)
+	downloadRepo.On("ListGrants", ctx, orderID).Return([]*models.DownloadGrant{grant, missingFile}, nil)
+
+	//Act
+	links, err := srv.ListOrderDownloads(ctx, userID, orderID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Len(t, links, 2)
+	expires := grant.ExpiresAt.Unix()
+	assert.Equal(t, fmt.Sprintf("https://shop.example.com/api/v1/downloads/%s?expires=%d&signature=%s",
+		grant.ID, expires, srv.(*service).sign(grant.ID, expires)), links[0].URL)
+	assert.True(t, links[0].Available)
+	assert.Equal(t, 3, links[0].DownloadsRemaining)
+	assert.False(t, links[1].Available)
+	assert.Empty(t, links[1].URL)
+	downloadRepo.AssertExpectations(t)
+}
+
+func TestListOrderDownloads_OtherUser(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	orderRepo := new(MockOrderRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, orderRepo, new(MockProductRepository), blobStorage, testConfig)
+	ctx := context.Background()
+	ownerID, orderID := uuid.New(), uuid.New()
+	paidAt := time.Now()
+
+	orderRepo.On("GetById", ctx, orderID).Return(&models.Order{ID: orderID, UserID: &ownerID, Status: "paid", PaidAt: &paidAt}, nil)
+
+	//Act
+	links, err := srv.ListOrderDownloads(ctx, uuid.New(), orderID)
+
+	//Assert
+	assert.Nil(t, links)
+	assert.Equal(t, ErrOrderNotFound, err)
+	downloadRepo.AssertNotCalled(t, "CreateGrants", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListOrderDownloads_NotPaid(t *testing.T) {
+	//Arrange
+	orderRepo := new(MockOrderRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(new(MockDownloadRepository), orderRepo, new(MockProductRepository), blobStorage, testConfig)
+	ctx := context.Background()
+	userID, orderID := uuid.New(), uuid.New()
+
+	orderRepo.On("GetById", ctx, orderID).Return(&models.Order{ID: orderID, UserID: &userID, Status: "pending"}, nil)
+
+	//Act
+	links, err := srv.ListOrderDownloads(ctx, userID, orderID)
+
+	//Assert
+	assert.Nil(t, links)
+	assert.Equal(t, ErrOrderNotPaid, err)
+}
+
+func TestListOrderDownloads_OrderNotFound(t *testing.T) {
+	//Arrange
+	orderRepo := new(MockOrderRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(new(MockDownloadRepository), orderRepo, new(MockProductRepository), blobStorage, testConfig)
+	ctx := context.Background()
+	orderID := uuid.New()
+
+	orderRepo.On("GetById", ctx, orderID).Return(nil, fmt.Errorf("failed to get order id: %w", sql.ErrNoRows))
+
+	//Act
+	_, err = srv.ListOrderDownloads(ctx, uuid.New(), orderID)
+
+	//Assert
+	assert.Equal(t, ErrOrderNotFound, err)
+}
+
+func TestDownload_Success(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, new(MockOrderRepository), new(MockProductRepository), blobStorage, testConfig)
+	ctx := context.Background()
+	fileName, contentType, key := "book.epub", "application/epub+zip", "downloads/p/book.epub"
+	assert.NoError(t, blobStorage.Put(ctx, key, strings.NewReader("ebook")))
+	grant := &models.DownloadGrant{
+		ID:           uuid.New(),
+		MaxDownloads: 3,
+		ExpiresAt:    time.Now().Add(time.Hour),
+		FileName:     &fileName,
+		ContentType:  &contentType,
+		StorageKey:   &key,
+	}
+
+	downloadRepo.On("GetGrant", ctx, grant.ID).Return(grant, nil)
+	downloadRepo.On("UseGrant", ctx, grant.ID).Return(nil)
+
+	//Act
+	file, err := srv.Download(ctx, signedRequest(srv, grant.ID, grant.ExpiresAt.Unix()))
+
+	//Assert
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(file.Body)
+	file.Body.Close()
+	assert.Equal(t, "ebook", string(data))
+	assert.Equal(t, fileName, file.FileName)
+	assert.Equal(t, contentType, file.ContentType)
+	downloadRepo.AssertExpectations(t)
+}
+
+func TestDownload_InvalidSignature(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, new(MockOrderRepository), new(MockProductRepository), blobStorage, testConfig)
+	grantID := uuid.New()
+	expires := time.Now().Add(time.Hour).Unix()
+	req := signedRequest(srv, grantID, expires)
+	// продовжений термін дії не збігається з підписом
+	req.Expires += 3600
+
+	//Act
+	file, err := srv.Download(context.Background(), req)
+
+	//Assert
+	assert.Nil(t, file)
+	assert.Equal(t, ErrInvalidSignature, err)
+	downloadRepo.AssertNotCalled(t, "GetGrant", mock.Anything, mock.Anything)
+}
+
+func TestDownload_LinkExpired(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, new(MockOrderRepository), new(MockProductRepository), blobStorage, testConfig)
+	grantID := uuid.New()
+
+	//Act
+	file, err := srv.Download(context.Background(), signedRequest(srv, grantID, time.Now().Add(-time.Minute).Unix()))
+
+	//Assert
+	assert.Nil(t, file)
+	assert.Equal(t, ErrLinkExpired, err)
+	downloadRepo.AssertNotCalled(t, "GetGrant", mock.Anything, mock.Anything)
+}
+
+func TestDownload_CancelledOrder(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, new(MockOrderRepository), new(MockProductRepository), blobStorage, testConfig)
+	ctx := context.Background()
+	fileName, key := "book.epub", "downloads/p/book.epub"
+	assert.NoError(t, blobStorage.Put(ctx, key, strings.NewReader("ebook")))
+	// посилання видане до скасування замовлення
+	grant := &models.DownloadGrant{
+		ID:           uuid.New(),
+		MaxDownloads: 3,
+		ExpiresAt:    time.Now().Add(time.Hour),
+		OrderStatus:  "cancelled",
+		FileName:     &fileName,
+		StorageKey:   &key,
+	}
+
+	downloadRepo.On("GetGrant", ctx, grant.ID).Return(grant, nil)
+
+	//Act
+	file, err := srv.Download(ctx, signedRequest(srv, grant.ID, grant.ExpiresAt.Unix()))
+
+	//Assert
+	assert.Nil(t, file)
+	assert.Equal(t, ErrOrderNotPaid, err)
+	downloadRepo.AssertNotCalled(t, "UseGrant", mock.Anything, mock.Anything)
+}
+
+func TestDownload_LimitReached(t *testing.T) {
+	//Arrange
+	downloadRepo := new(MockDownloadRepository)
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	srv := NewService(downloadRepo, new(MockOrderRepository), new(MockProductRepository), blobStorage, testConfig)
+	ctx := context.Background()
+	fileName, key := "book.epub", "downloads/p/book.epub"
+	grant := &models.DownloadGrant{
+		ID:            uuid.New(),
+		MaxDownloads:  3,
+		DownloadCount: 3,
+		ExpiresAt:     time.Now().Add(time.Hour),
+		FileName:      &fileName,
+		StorageKey:    &key,
+	}
+
+	downloadRepo.On("GetGrant", ctx, grant.ID).Return(grant, nil)
+
+	//Act
+	file, err := srv.Download(ctx, signedRequest(srv, grant.ID, grant.ExpiresAt.Unix()))
+
+	//Assert
+	assert.Nil(t, file)
+	assert.Equal(t, ErrDownloadLimitReached, err)
+	downloadRepo.AssertNotCalled(t, "UseGrant", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,39 @@
+package download
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DTO структури для цифрових товарів
+
+type UploadFileRequest struct {
+	File     io.Reader
+	FileName string
+}
+
+type DownloadLink struct {
+	GrantID            uuid.UUID `json:"grant_id"`
+	ProductID          uuid.UUID `json:"product_id"`
+	ProductName        string    `json:"product_name"`
+	FileName           string    `json:"file_name,omitempty"`
+	URL                string    `json:"url,omitempty"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	DownloadsRemaining int       `json:"downloads_remaining"`
+	// Available false, якщо файл ще не завантажено, термін дії минув або ліміт вичерпано
+	Available bool `json:"available"`
+}
+
+type DownloadRequest struct {
+	GrantID   uuid.UUID
+	Expires   int64
+	Signature string
+}
+
+type DownloadFile struct {
+	FileName    string
+	ContentType string
+	Body        io.ReadCloser
+}
@@ -0,0 +1,22 @@
+package download
+
+import "errors"
+
+// помилки пов'язані з цифровими товарами
+var (
+	// Validation errors
+	ErrFileRequired     = errors.New("file is required")
+	ErrFileNameRequired = errors.New("file name is required")
+	ErrFileTooLarge     = errors.New("file is too large")
+
+	// Logic errors
+	ErrProductNotFound      = errors.New("product not found")
+	ErrProductNotDigital    = errors.New("product is not digital")
+	ErrFileNotFound         = errors.New("file not found")
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrOrderNotPaid         = errors.New("order is not paid")
+	ErrDownloadNotFound     = errors.New("download not found")
+	ErrInvalidSignature     = errors.New("invalid download signature")
+	ErrLinkExpired          = errors.New("download link has expired")
+	ErrDownloadLimitReached = errors.New("download limit reached")
+)
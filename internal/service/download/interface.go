@@ -0,0 +1,18 @@
+package download
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DownloadService інтерфейс для цифрових товарів та посилань на завантаження
+type DownloadService interface {
+	UploadFile(ctx context.Context, productID uuid.UUID, req UploadFileRequest) (*models.ProductFile, error)
+	GetFile(ctx context.Context, productID uuid.UUID) (*models.ProductFile, error)
+	// ListOrderDownloads повертає підписані посилання на цифрові позиції оплаченого замовлення користувача
+	ListOrderDownloads(ctx context.Context, userID, orderID uuid.UUID) ([]*DownloadLink, error)
+	// Download перевіряє підписане посилання, зараховує завантаження та відкриває файл
+	Download(ctx context.Context, req DownloadRequest) (*DownloadFile, error)
+}
@@ -0,0 +1,34 @@
+package export
+
+// StatusAll експорт продуктів з будь-яким статусом
+const StatusAll = "all"
+
+// Config налаштування фідів
+type Config struct {
+	// StoreURL адреса магазину для посилань на товари та відносних URL зображень
+	StoreURL string
+	// StoreName назва фіду
+	StoreName string
+	// Currency валюта цін (ISO 4217)
+	Currency string
+}
+
+// feedItem товар у фіді Google Merchant
+type feedItem struct {
+	ID               string `xml:"g:id"`
+	Title            string `xml:"g:title"`
+	Description      string `xml:"g:description"`
+	Link             string `xml:"g:link"`
+	ImageLink        string `xml:"g:image_link,omitempty"`
+	Price            string `xml:"g:price"`
+	Availability     string `xml:"g:availability"`
+	AvailabilityDate string `xml:"g:availability_date,omitempty"`
+	Condition        string `xml:"g:condition"`
+	ProductType      string `xml:"g:product_type,omitempty"`
+	MPN              string `xml:"g:mpn,omitempty"`
+	IdentifierExists string `xml:"g:identifier_exists"`
+
+	// ціна розпродажу та період його дії у форматі ISO 8601 "початок/кінець"
+	SalePrice              string `xml:"g:sale_price,omitempty"`
+	SalePriceEffectiveDate string `xml:"g:sale_price_effective_date,omitempty"`
+}
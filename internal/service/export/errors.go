@@ -0,0 +1,8 @@
+package export
+
+import "errors"
+
+// помилки пов'язані з експортом
+var (
+	ErrInvalidStatus = errors.New("invalid product status, use draft, published, archived or all")
+)
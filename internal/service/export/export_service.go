@@ -0,0 +1,241 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/google/uuid"
+)
+
+// обмеження довжини полів Google Merchant
+const (
+	maxFeedTitleLength       = 150
+	maxFeedDescriptionLength = 5000
+)
+
+// колонки CSV експорту (сумісні з імпортом продуктів)
+var csvHeader = []string{
+	"id", "sku", "name", "description", "price", "stock", "category_id", "category",
+	"image_url", "status", "publish_at", "unpublish_at", "created_at", "updated_at",
+}
+
+type service struct {
+	productRepo repository.ProductRepository
+	config      Config
+}
+
+func NewService(productRepo repository.ProductRepository, config Config) ExportService {
+	if config.Currency == "" {
+		config.Currency = "UAH"
+	}
+	if config.StoreName == "" {
+		config.StoreName = "E-Commerce"
+	}
+	config.StoreURL = strings.TrimRight(config.StoreURL, "/")
+	return &service{productRepo: productRepo,
+		config: config}
+}
+
+// ExportProductsCSV потоково пише каталог у CSV (без видалених продуктів)
+func (s *service) ExportProductsCSV(ctx context.Context, w io.Writer, status string) error {
+	// валідація статусу
+	switch status {
+	case "", StatusAll:
+		status = ""
+	case models.ProductStatusDraft, models.ProductStatusPublished, models.ProductStatusArchived:
+	default:
+		return ErrInvalidStatus
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	err := s.productRepo.ExportProducts(ctx, status, func(p *models.ExportProduct) error {
+		if err := cw.Write(csvRecord(p)); err != nil {
+			return err
+		}
+		// кожен записаний рядок одразу йде клієнту
+		cw.Flush()
+		return cw.Error()
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteGoogleFeed потоково пише RSS 2.0 фід Google Merchant з опублікованих продуктів
+func (s *service) WriteGoogleFeed(ctx context.Context, w io.Writer) error {
+	header := xml.Header +
+		`<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">` + "\n" +
+		"<channel>\n"
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	// заголовок каналу
+	enc := xml.NewEncoder(w)
+	channel := []struct {
+		name, value string
+	}{
+		{"title", s.config.StoreName},
+		{"link", s.config.StoreURL + "/"},
+		{"description", s.config.StoreName + " product feed"},
+	}
+	for _, el := range channel {
+		if err := enc.EncodeElement(el.value, xml.StartElement{Name: xml.Name{Local: el.name}}); err != nil {
+			return err
+		}
+	}
+
+	// товари
+	item := xml.StartElement{Name: xml.Name{Local: "item"}}
+	err := s.productRepo.ExportProducts(ctx, models.ProductStatusPublished, func(p *models.ExportProduct) error {
+		return enc.EncodeElement(s.feedItem(p), item)
+	})
+	if err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n</channel>\n</rss>\n")
+	return err
+}
+
+// feedItem формує товар фіду з продукту
+func (s *service) feedItem(p *models.ExportProduct) feedItem {
+	item := feedItem{
+		ID:               p.ID.String(),
+		Title:            truncate(p.Name, maxFeedTitleLength),
+		Description:      truncate(p.Name, maxFeedDescriptionLength),
+		Link:             s.config.StoreURL + "/products/" + url.PathEscape(p.Slug),
+		Price:            s.feedPrice(p.Price),
+		Availability:     availability(&p.Product),
+		Condition:        "new",
+		IdentifierExists: "no",
+	}
+	if p.SKU != nil && *p.SKU != "" {
+		item.ID = *p.SKU
+		item.MPN = *p.SKU
+	}
+	if p.Description != nil && strings.TrimSpace(*p.Description) != "" {
+		item.Description = truncate(*p.Description, maxFeedDescriptionLength)
+	}
+	if p.ImageURL != nil && *p.ImageURL != "" {
+		item.ImageLink = s.absoluteURL(*p.ImageURL)
+	}
+	if p.CategoryName != nil {
+		item.ProductType = *p.CategoryName
+	}
+	if item.Availability != "in_stock" && item.Availability != "out_of_stock" && p.AvailableAt != nil {
+		item.AvailabilityDate = p.AvailableAt.Format(time.RFC3339)
+	}
+	s.feedSale(&item, &p.Product, time.Now())
+	return item
+}
+
+// feedSale додає до товару фіду ціну розпродажу: діючого або запланованого з відомою датою завершення.
+// Запланований розпродаж без дати завершення потрапить у фід, коли почнеться
+func (s *service) feedSale(item *feedItem, p *models.Product, now time.Time) {
+	if p.SalePrice == nil || *p.SalePrice >= p.Price {
+		return
+	}
+	if !p.SaleActive(now) && (p.SaleEndsAt == nil || !p.SaleEndsAt.After(now)) {
+		return
+	}
+
+	item.SalePrice = s.feedPrice(*p.SalePrice)
+	if p.SaleEndsAt != nil {
+		start := now
+		if p.SaleStartsAt != nil {
+			start = *p.SaleStartsAt
+		}
+		item.SalePriceEffectiveDate = start.Format(time.RFC3339) + "/" + p.SaleEndsAt.Format(time.RFC3339)
+	}
+}
+
+// feedPrice ціна у форматі фіду: сума з двома знаками та валюта
+func (s *service) feedPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', 2, 64) + " " + s.config.Currency
+}
+
+// absoluteURL перетворює відносний URL (наприклад, зображення з локального сховища) на абсолютний
+func (s *service) absoluteURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.IsAbs() {
+		return raw
+	}
+	base, err := url.Parse(s.config.StoreURL + "/")
+	if err != nil {
+		return raw
+	}
+	return base.ResolveReference(u).String()
+}
+
+// availability наявність товару в термінах Google Merchant
+func availability(p *models.Product) string {
+	return p.Availability()
+}
+
+// csvRecord рядок CSV експорту
+func csvRecord(p *models.ExportProduct) []string {
+	return []string{
+		p.ID.String(),
+		derefString(p.SKU),
+		p.Name,
+		derefString(p.Description),
+		strconv.FormatFloat(p.Price, 'f', 2, 64),
+		strconv.Itoa(p.Stock),
+		formatUUID(p.CategoryID),
+		derefString(p.CategoryName),
+		derefString(p.ImageURL),
+		p.Status,
+		formatTime(p.PublishAt),
+		formatTime(p.UnpublishAt),
+		p.CreatedAt.UTC().Format(time.RFC3339),
+		p.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// truncate обрізає рядок до max символів
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,283 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+// exportProducts налаштовує мок на передачу продуктів у callback експорту
+func exportProducts(mockRepo *MockProductRepository, status string, products ...*models.ExportProduct) {
+	mockRepo.On("ExportProducts", mock.Anything, status, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*models.ExportProduct) error)
+			for _, p := range products {
+				if err := fn(p); err != nil {
+					return
+				}
+			}
+		}).
+		Return(nil)
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestExportProductsCSV_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, Config{})
+	categoryID := uuid.New()
+	product := &models.ExportProduct{
+		Product: models.Product{
+			ID:          uuid.New(),
+			SKU:         strPtr("SKU-1"),
+			Name:        "Phone",
+			Description: strPtr("Smart, fast"),
+			Price:       199.5,
+			Stock:       3,
+			CategoryID:  &categoryID,
+			Status:      models.ProductStatusPublished,
+			CreatedAt:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			UpdatedAt:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		CategoryName: strPtr("Electronics"),
+	}
+	exportProducts(mockRepo, "", product)
+
+	//Act
+	var buf bytes.Buffer
+	err := service.ExportProductsCSV(context.Background(), &buf, StatusAll)
+
+	//Assert
+	assert.NoError(t, err)
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, []string{
+		product.ID.String(), "SKU-1", "Phone", "Smart, fast", "199.50", "3", categoryID.String(), "Electronics",
+		"", "published", "", "", "2025-01-02T03:04:05Z", "2025-01-02T03:04:05Z",
+	}, records[1])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExportProductsCSV_InvalidStatus(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, Config{})
+
+	//Act
+	err := service.ExportProductsCSV(context.Background(), &bytes.Buffer{}, "deleted")
+
+	//Assert
+	assert.Equal(t, ErrInvalidStatus, err)
+	mockRepo.AssertNotCalled(t, "ExportProducts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWriteGoogleFeed_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, Config{StoreURL: "https://shop.example.com/", StoreName: "Shop", Currency: "UAH"})
+	inStock := &models.ExportProduct{
+		Product: models.Product{
+			ID:       uuid.New(),
+			SKU:      strPtr("SKU-1"),
+			Slug:     "phone-case",
+			Name:     "Phone & Case",
+			Price:    1999,
+			Stock:    5,
+			ImageURL: strPtr("/api/v1/images/products/1/large.jpg"),
+			Status:   models.ProductStatusPublished,
+		},
+		CategoryName: strPtr("Electronics"),
+	}
+	outOfStock := &models.ExportProduct{
+		Product: models.Product{
+			ID:          uuid.New(),
+			Name:        "Laptop",
+			Description: strPtr("Light laptop"),
+			Price:       45000.5,
+			ImageURL:    strPtr("https://cdn.example.com/laptop.jpg"),
+			Status:      models.ProductStatusPublished,
+		},
+	}
+	exportProducts(mockRepo, models.ProductStatusPublished, inStock, outOfStock)
+
+	//Act
+	var buf bytes.Buffer
+	err := service.WriteGoogleFeed(context.Background(), &buf)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(buf.String(), `xmlns:g="http://base.google.com/ns/1.0"`))
+
+	var feed struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				ID           string `xml:"id"`
+				Title        string `xml:"title"`
+				Description  string `xml:"description"`
+				Link         string `xml:"link"`
+				ImageLink    string `xml:"image_link"`
+				Price        string `xml:"price"`
+				Availability string `xml:"availability"`
+				ProductType  string `xml:"product_type"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &feed))
+	assert.Equal(t, "Shop", feed.Channel.Title)
+	assert.Len(t, feed.Channel.Items, 2)
+
+	first := feed.Channel.Items[0]
+	assert.Equal(t, "SKU-1", first.ID)
+	assert.Equal(t, "Phone & Case", first.Title)
+	assert.Equal(t, "Phone & Case", first.Description)
+	assert.Equal(t, "https://shop.example.com/products/phone-case", first.Link)
+	assert.Equal(t, "https://shop.example.com/api/v1/images/products/1/large.jpg", first.ImageLink)
+	assert.Equal(t, "1999.00 UAH", first.Price)
+	assert.Equal(t, "in_stock", first.Availability)
+	assert.Equal(t, "Electronics", first.ProductType)
+
+	second := feed.Channel.Items[1]
+	assert.Equal(t, outOfStock.ID.String(), second.ID)
+	assert.Equal(t, "Light laptop", second.Description)
+	assert.Equal(t, "https://cdn.example.com/laptop.jpg", second.ImageLink)
+	assert.Equal(t, "45000.50 UAH", second.Price)
+	assert.Equal(t, "out_of_stock", second.Availability)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFeedSale(t *testing.T) {
+	service := &service{config: Config{Currency: "UAH"}}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	salePrice := 79.9
+	starts := now.Add(24 * time.Hour)
+	ends := now.Add(72 * time.Hour)
+
+	// діючий розпродаж без дат
+	item := feedItem{}
+	service.feedSale(&item, &models.Product{Price: 100, SalePrice: &salePrice}, now)
+	assert.Equal(t, "79.90 UAH", item.SalePrice)
+	assert.Empty(t, item.SalePriceEffectiveDate)
+
+	// запланований розпродаж публікується разом з періодом дії
+	item = feedItem{}
+	service.feedSale(&item, &models.Product{Price: 100, SalePrice: &salePrice, SaleStartsAt: &starts, SaleEndsAt: &ends}, now)
+	assert.Equal(t, "79.90 UAH", item.SalePrice)
+	assert.Equal(t, "2025-06-02T12:00:00Z/2025-06-04T12:00:00Z", item.SalePriceEffectiveDate)
+
+	// завершений розпродаж та розпродаж без ціни не публікуються
+	item = feedItem{}
+	ended := now.Add(-time.Hour)
+	service.feedSale(&item, &models.Product{Price: 100, SalePrice: &salePrice, SaleEndsAt: &ended}, now)
+	service.feedSale(&item, &models.Product{Price: 100}, now)
+	assert.Empty(t, item.SalePrice)
+	assert.Empty(t, item.SalePriceEffectiveDate)
+}
+
+func TestAvailability_BackorderPolicy(t *testing.T) {
+	assert.Equal(t, "in_stock", availability(&models.Product{Stock: 1, BackorderPolicy: models.BackorderPreorder}))
+	assert.Equal(t, "backorder", availability(&models.Product{BackorderPolicy: models.BackorderAllow}))
+	assert.Equal(t, "preorder", availability(&models.Product{BackorderPolicy: models.BackorderPreorder}))
+	assert.Equal(t, "out_of_stock", availability(&models.Product{BackorderPolicy: models.BackorderDeny}))
+}
@@ -0,0 +1,12 @@
+package export
+
+import (
+	"context"
+	"io"
+)
+
+// ExportService інтерфейс для експорту каталогу та товарних фідів
+type ExportService interface {
+	ExportProductsCSV(ctx context.Context, w io.Writer, status string) error
+	WriteGoogleFeed(ctx context.Context, w io.Writer) error
+}
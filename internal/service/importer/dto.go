@@ -0,0 +1,27 @@
+package importer
+
+import "github.com/google/uuid"
+
+// формати файлів імпорту
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// DTO структури для імпорту продуктів
+
+type ImportOptions struct {
+	Format string
+	DryRun bool
+}
+
+type ImportResult struct {
+	ID        uuid.UUID `json:"id"`
+	Format    string    `json:"format"`
+	DryRun    bool      `json:"dry_run"`
+	Total     int       `json:"total"`
+	Created   int       `json:"created"`
+	Updated   int       `json:"updated"`
+	Failed    int       `json:"failed"`
+	ReportURL string    `json:"report_url,omitempty"`
+}
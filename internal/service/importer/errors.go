@@ -0,0 +1,19 @@
+package importer
+
+import "errors"
+
+// помилки пов'язані з імпортом продуктів
+var (
+	// File errors
+	ErrUnsupportedFormat = errors.New("unsupported import format, use csv or jsonl")
+	ErrInvalidHeader     = errors.New("csv header must contain sku, name and price columns")
+	ErrReportNotFound    = errors.New("import report not found")
+
+	// Row errors
+	ErrMalformedRow      = errors.New("malformed row")
+	ErrSKURequired       = errors.New("sku is required")
+	ErrInvalidPriceValue = errors.New("price must be a number")
+	ErrInvalidStockValue = errors.New("stock must be an integer")
+	ErrInvalidCategoryID = errors.New("category_id must be a valid uuid")
+	ErrProductDeleted    = errors.New("product with this sku was deleted")
+)
@@ -0,0 +1,184 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/Xiancel/ecommerce/internal/storage"
+	"github.com/google/uuid"
+)
+
+type service struct {
+	productRepo repository.ProductRepository
+	storage     storage.BlobStorage
+}
+
+func NewService(productRepo repository.ProductRepository, blobStorage storage.BlobStorage) ImportService {
+	return &service{productRepo: productRepo,
+		storage: blobStorage}
+}
+
+// ImportProducts потоково імпортує продукти з CSV або JSON Lines з upsert за SKU.
+// Кожен рядок обробляється окремо: помилкові рядки потрапляють у звіт, решта зберігається.
+func (s *service) ImportProducts(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	// валідація формату та заголовка до початку імпорту
+	rows, err := newRowReader(r, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		ID:     uuid.New(),
+		Format: opts.Format,
+		DryRun: opts.DryRun,
+	}
+
+	// звіт пишеться у сховище потоково, без накопичення в пам'яті
+	pr, pw := io.Pipe()
+	putErr := make(chan error, 1)
+	go func() {
+		err := s.storage.Put(ctx, reportKey(result.ID), pr)
+		pr.CloseWithError(err)
+		putErr <- err
+	}()
+
+	report := csv.NewWriter(pw)
+	report.Write([]string{"line", "sku", "error"})
+
+	importErr := s.importRows(ctx, rows, opts.DryRun, result, report)
+	report.Flush()
+	if importErr == nil {
+		importErr = report.Error()
+	}
+	pw.CloseWithError(importErr)
+
+	if err := <-putErr; err != nil && importErr == nil {
+		importErr = fmt.Errorf("failed to store import report: %w", err)
+	}
+	if importErr != nil {
+		return nil, importErr
+	}
+	return result, nil
+}
+
+// OpenReport відкриває звіт про помилки імпорту
+func (s *service) OpenReport(ctx context.Context, id uuid.UUID) (io.ReadCloser, error) {
+	report, err := s.storage.Open(ctx, reportKey(id))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to open import report: %w", err)
+	}
+	return report, nil
+}
+
+// importRows обробляє рядки файлу до кінця або до скасування контексту
+func (s *service) importRows(ctx context.Context, rows rowReader, dryRun bool, result *ImportResult, report *csv.Writer) error {
+	// SKU, які вже зустрілися у файлі (для коректного dry-run)
+	seen := map[string]bool{}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		row, err := rows.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read import file: %w", err)
+		}
+		result.Total++
+
+		if row.err == nil {
+			row.err = s.importRow(ctx, row, dryRun, result, seen)
+		}
+		if row.err != nil {
+			result.Failed++
+			if err := report.Write([]string{strconv.Itoa(row.line), row.SKU, row.err.Error()}); err != nil {
+				return fmt.Errorf("failed to write import report: %w", err)
+			}
+		}
+	}
+}
+
+// importRow валідує рядок за правилами CreateProduct та зберігає його
+func (s *service) importRow(ctx context.Context, row *importRow, dryRun bool, result *ImportResult, seen map[string]bool) error {
+	// валідація
+	if row.SKU == "" {
+		return ErrSKURequired
+	}
+	product, err := productSrv.BuildProduct(productSrv.CreateProductRequest{
+		SKU:         row.SKU,
+		Name:        row.Name,
+		Description: row.Description,
+		Price:       row.Price,
+		Stock:       row.Stock,
+		CategoryID:  row.CategoryID,
+		ImageURL:    row.ImageURL,
+		Status:      row.Status,
+	})
+	if err != nil {
+		return err
+	}
+
+	// dry-run: тільки визначаємо, що сталося б з рядком
+	if dryRun {
+		exists := seen[row.SKU]
+		if !exists {
+			existing, err := s.productRepo.GetBySKU(ctx, row.SKU)
+			if err != nil {
+				return err
+			}
+			// видалений продукт імпорт не оновлює
+			if existing != nil && existing.DeletedAt != nil {
+				return ErrProductDeleted
+			}
+			exists = existing != nil
+			seen[row.SKU] = true
+		}
+		if exists {
+			result.Updated++
+		} else {
+			result.Created++
+		}
+		return nil
+	}
+
+	// без статусу у файлі статус існуючого продукту не змінюється
+	if row.Status == "" {
+		product.Status = ""
+	}
+	// slug використовується лише для нового продукту, існуючий зберігає свій
+	product.Slug, err = productSrv.UniqueSlug(ctx, s.productRepo, product.Name, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	inserted, err := s.productRepo.UpsertBySKU(ctx, product)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductDeleted
+		}
+		return err
+	}
+	if inserted {
+		result.Created++
+	} else {
+		result.Updated++
+	}
+	return nil
+}
+
+// reportKey ключ звіту імпорту у сховищі
+func reportKey(id uuid.UUID) string {
+	return fmt.Sprintf("imports/%s/report.csv", id)
+}
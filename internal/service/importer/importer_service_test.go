@@ -0,0 +1,272 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/Xiancel/ecommerce/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+func newTestStorage(t *testing.T) storage.BlobStorage {
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blobStorage
+}
+
+// readReport читає звіт імпорту
+func readReport(t *testing.T, service ImportService, id uuid.UUID) string {
+	report, err := service.OpenReport(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer report.Close()
+	data, err := io.ReadAll(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestImportProducts_CSVUpsertWithReport(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, newTestStorage(t))
+	ctx := context.Background()
+
+	file := "sku,name,price,stock,status\n" +
+		"A-1,Laptop,999.99,5,\n" +
+		"A-2,Mouse,19.99,10,draft\n" +
+		"A-3,,10,1,\n" +
+		"A-4,Cable,abc,1,\n" +
+		",NoSku,5,1,\n"
+
+	mockRepo.On("SlugTaken", ctx, mock.Anything, uuid.Nil).Return(false, nil)
+	mockRepo.On("UpsertBySKU", ctx, mock.MatchedBy(func(p *models.Product) bool {
+		return *p.SKU == "A-1" && p.Slug == "laptop" && p.Status == "" && p.Price == 999.99 && p.Stock == 5
+	})).Return(true, nil)
+	mockRepo.On("UpsertBySKU", ctx, mock.MatchedBy(func(p *models.Product) bool {
+		return *p.SKU == "A-2" && p.Status == models.ProductStatusDraft
+	})).Return(false, nil)
+
+	result, err := service.ImportProducts(ctx, strings.NewReader(file), ImportOptions{Format: FormatCSV})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, result.Total)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 3, result.Failed)
+
+	report := readReport(t, service, result.ID)
+	assert.Contains(t, report, "4,A-3,"+productSrv.ErrProductNameRequired.Error())
+	assert.Contains(t, report, "5,A-4,"+ErrInvalidPriceValue.Error())
+	assert.Contains(t, report, "6,,"+ErrSKURequired.Error())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportProducts_JSONLDryRun(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, newTestStorage(t))
+	ctx := context.Background()
+
+	file := `{"sku":"B-1","name":"Book","price":12.5,"stock":3}
+
+{"sku":"B-2","name":"Pen","price":1}
+{"sku":"B-1","name":"Book v2","price":13}
+{not json}
+`
+	mockRepo.On("GetBySKU", ctx, "B-1").Return(nil, nil).Once()
+	mockRepo.On("GetBySKU", ctx, "B-2").Return(&models.Product{ID: uuid.New()}, nil).Once()
+
+	result, err := service.ImportProducts(ctx, strings.NewReader(file), ImportOptions{Format: FormatJSONL, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 4, result.Total)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 2, result.Updated)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, readReport(t, service, result.ID), "5,,"+ErrMalformedRow.Error())
+	mockRepo.AssertNotCalled(t, "UpsertBySKU")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportProducts_InvalidHeader(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, newTestStorage(t))
+
+	result, err := service.ImportProducts(context.Background(), strings.NewReader("name,price\nA,1\n"), ImportOptions{Format: FormatCSV})
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrInvalidHeader, err)
+}
+
+func TestImportProducts_UnsupportedFormat(t *testing.T) {
+	service := NewService(new(MockProductRepository), newTestStorage(t))
+
+	result, err := service.ImportProducts(context.Background(), strings.NewReader(""), ImportOptions{Format: "xml"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrUnsupportedFormat, err)
+}
+
+func TestImportProducts_RepositoryErrorIsRowError(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, newTestStorage(t))
+	ctx := context.Background()
+
+	mockRepo.On("SlugTaken", ctx, "chair", uuid.Nil).Return(false, nil)
+	mockRepo.On("UpsertBySKU", ctx, mock.Anything).Return(false, errors.New("foreign key violation"))
+
+	result, err := service.ImportProducts(ctx, strings.NewReader("sku,name,price\nC-1,Chair,10\n"), ImportOptions{Format: FormatCSV})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, readReport(t, service, result.ID), "foreign key violation")
+}
+
+func TestImportProducts_DeletedProductIsReported(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, newTestStorage(t))
+	ctx := context.Background()
+
+	mockRepo.On("SlugTaken", ctx, "chair", uuid.Nil).Return(false, nil)
+	mockRepo.On("UpsertBySKU", ctx, mock.Anything).Return(false, sql.ErrNoRows)
+
+	//Act
+	result, err := service.ImportProducts(ctx, strings.NewReader("sku,name,price\nC-1,Chair,10\n"), ImportOptions{Format: FormatCSV})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Updated)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, readReport(t, service, result.ID), "2,C-1,"+ErrProductDeleted.Error())
+}
+
+func TestImportProducts_DryRunDeletedProductIsReported(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, newTestStorage(t))
+	ctx := context.Background()
+	deletedAt := time.Now()
+
+	mockRepo.On("GetBySKU", ctx, "C-1").Return(&models.Product{ID: uuid.New(), DeletedAt: &deletedAt}, nil)
+
+	//Act
+	result, err := service.ImportProducts(ctx, strings.NewReader("sku,name,price\nC-1,Chair,10\n"), ImportOptions{Format: FormatCSV, DryRun: true})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Updated)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, readReport(t, service, result.ID), "2,C-1,"+ErrProductDeleted.Error())
+}
+
+func TestOpenReport_NotFound(t *testing.T) {
+	service := NewService(new(MockProductRepository), newTestStorage(t))
+
+	report, err := service.OpenReport(context.Background(), uuid.New())
+
+	assert.Nil(t, report)
+	assert.Equal(t, ErrReportNotFound, err)
+}
@@ -0,0 +1,14 @@
+package importer
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// ImportService інтерфейс для масового імпорту продуктів
+type ImportService interface {
+	ImportProducts(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportResult, error)
+	OpenReport(ctx context.Context, id uuid.UUID) (io.ReadCloser, error)
+}
@@ -0,0 +1,164 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// importRow один рядок файлу імпорту
+type importRow struct {
+	line        int
+	SKU         string     `json:"sku"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Price       float64    `json:"price"`
+	Stock       int        `json:"stock"`
+	CategoryID  *uuid.UUID `json:"category_id"`
+	ImageURL    string     `json:"image_url"`
+	Status      string     `json:"status"`
+	err         error
+}
+
+// rowReader читає рядки файлу імпорту по одному (io.EOF в кінці файлу).
+// Помилки окремого рядка повертаються в importRow.err, а не як помилка читання.
+type rowReader interface {
+	Next() (*importRow, error)
+}
+
+// newRowReader створює читача для формату файлу
+func newRowReader(r io.Reader, format string) (rowReader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatJSONL:
+		return &jsonlReader{r: bufio.NewReader(r)}, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// csvReader читає CSV з заголовком; колонки визначаються за назвою
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+
+	// читання заголовка
+	header, err := cr.Read()
+	if err != nil {
+		return nil, ErrInvalidHeader
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"sku", "name", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, ErrInvalidHeader
+		}
+	}
+
+	return &csvReader{r: cr, columns: columns}, nil
+}
+
+// Next повертає наступний рядок CSV
+func (c *csvReader) Next() (*importRow, error) {
+	record, err := c.r.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &importRow{line: parseErr.StartLine, err: ErrMalformedRow}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line, _ := c.r.FieldPos(0)
+	row := &importRow{
+		line:        line,
+		SKU:         c.field(record, "sku"),
+		Name:        c.field(record, "name"),
+		Description: c.field(record, "description"),
+		ImageURL:    c.field(record, "image_url"),
+		Status:      c.field(record, "status"),
+	}
+
+	// числові поля та UUID
+	if row.Price, err = strconv.ParseFloat(c.field(record, "price"), 64); err != nil {
+		row.err = ErrInvalidPriceValue
+		return row, nil
+	}
+	if stock := c.field(record, "stock"); stock != "" {
+		if row.Stock, err = strconv.Atoi(stock); err != nil {
+			row.err = ErrInvalidStockValue
+			return row, nil
+		}
+	}
+	if categoryID := c.field(record, "category_id"); categoryID != "" {
+		id, err := uuid.Parse(categoryID)
+		if err != nil {
+			row.err = ErrInvalidCategoryID
+			return row, nil
+		}
+		row.CategoryID = &id
+	}
+	return row, nil
+}
+
+// field повертає значення колонки (порожнє, якщо колонки немає)
+func (c *csvReader) field(record []string, name string) string {
+	idx, ok := c.columns[name]
+	if !ok || idx >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[idx])
+}
+
+// jsonlReader читає JSON Lines: один JSON об'єкт на рядок
+type jsonlReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+// Next повертає наступний непорожній рядок JSON Lines
+func (j *jsonlReader) Next() (*importRow, error) {
+	for {
+		data, err := j.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if len(data) == 0 && err == io.EOF {
+			return nil, io.EOF
+		}
+		j.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		row := &importRow{}
+		if err := json.Unmarshal(data, row); err != nil {
+			row.err = ErrMalformedRow
+		}
+		row.line = j.line
+		row.SKU = strings.TrimSpace(row.SKU)
+		return row, nil
+	}
+}
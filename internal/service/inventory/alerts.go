@@ -0,0 +1,164 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	"github.com/google/uuid"
+)
+
+// пагінація сповіщень про низький залишок
+const (
+	defaultAlertLimit = 50
+	maxAlertLimit     = 100
+)
+
+// кількість сповіщень, що відправляються за один запуск фонової задачі
+const alertDeliveryBatch = 100
+
+// GetReorderSettings повертає поріг низького залишку та кількість дозамовлення продукту
+func (s *service) GetReorderSettings(ctx context.Context, productID uuid.UUID) (*models.ReorderSettings, error) {
+	if err := s.checkProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.inventoryRepo.GetReorderSettings(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reorder settings: %w", err)
+	}
+	if settings == nil {
+		return nil, ErrProductNotFound
+	}
+	return settings, nil
+}
+
+// UpdateReorderSettings встановлює поріг низького залишку та кількість дозамовлення продукту
+func (s *service) UpdateReorderSettings(ctx context.Context, productID uuid.UUID, req UpdateReorderSettingsRequest) (*models.ReorderSettings, error) {
+	// валідація
+	if req.LowStockThreshold != nil && *req.LowStockThreshold < 0 {
+		return nil, ErrInvalidThreshold
+	}
+	if req.ReorderQuantity < 0 {
+		return nil, ErrInvalidReorderQuantity
+	}
+	if err := s.checkProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	settings := &models.ReorderSettings{
+		ProductID:         productID,
+		LowStockThreshold: req.LowStockThreshold,
+		ReorderQuantity:   req.ReorderQuantity,
+	}
+	if err := s.inventoryRepo.UpdateReorderSettings(ctx, settings); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to update reorder settings: %w", err)
+	}
+	return settings, nil
+}
+
+// ListAlerts повертає сповіщення про низький залишок
+func (s *service) ListAlerts(ctx context.Context, filter AlertFilter) (*AlertListResponse, error) {
+	// пагінація
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAlertLimit
+	}
+	if filter.Limit > maxAlertLimit {
+		filter.Limit = maxAlertLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	if filter.Status != "" && filter.Status != models.AlertStatusOpen && filter.Status != models.AlertStatusResolved {
+		return nil, ErrInvalidAlertStatus
+	}
+
+	alerts, err := s.inventoryRepo.ListAlerts(ctx, models.LowStockAlertFilter{
+		Status: filter.Status,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list low stock alerts: %w", err)
+	}
+	if alerts == nil {
+		alerts = []*models.LowStockAlert{}
+	}
+
+	return &AlertListResponse{
+		Alerts: alerts,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// ListReorderNeeded повертає продукти, залишок яких на рівні порогу або нижче
+func (s *service) ListReorderNeeded(ctx context.Context) ([]*models.ReorderItem, error) {
+	items, err := s.inventoryRepo.ListReorderNeeded(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products to reorder: %w", err)
+	}
+	if items == nil {
+		items = []*models.ReorderItem{}
+	}
+	return items, nil
+}
+
+// DeliverAlerts відправляє нові сповіщення про низький залишок.
+// Сповіщення, які не вдалося доставити, залишаються невідправленими і повторюються наступного запуску
+func (s *service) DeliverAlerts(ctx context.Context) (int, error) {
+	alerts, err := s.inventoryRepo.ListUndeliveredAlerts(ctx, alertDeliveryBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list undelivered alerts: %w", err)
+	}
+
+	var delivered []uuid.UUID
+	var deliveryErr error
+	for _, alert := range alerts {
+		n := notification.New(notification.TypeLowStock, nil, map[string]interface{}{
+			"alert_id":         alert.ID,
+			"product_id":       alert.ProductID,
+			"product_name":     alert.ProductName,
+			"sku":              alert.SKU,
+			"stock":            alert.Stock,
+			"threshold":        alert.Threshold,
+			"reorder_quantity": alert.ReorderQuantity,
+		})
+		if err := s.notifier.Notify(ctx, n); err != nil {
+			deliveryErr = fmt.Errorf("failed to send low stock alert: %w", err)
+			break
+		}
+		delivered = append(delivered, alert.ID)
+	}
+
+	if err := s.inventoryRepo.MarkAlertsNotified(ctx, delivered); err != nil {
+		return 0, fmt.Errorf("failed to mark alerts notified: %w", err)
+	}
+	return len(delivered), deliveryErr
+}
+
+// SendReorderDigest відправляє одне зведення з усіма продуктами, які потрібно дозамовити
+func (s *service) SendReorderDigest(ctx context.Context) (int, error) {
+	items, err := s.inventoryRepo.ListReorderNeeded(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list products to reorder: %w", err)
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	n := notification.New(notification.TypeReorderDigest, nil, map[string]interface{}{
+		"count":    len(items),
+		"products": items,
+	})
+	if err := s.notifier.Notify(ctx, n); err != nil {
+		return 0, fmt.Errorf("failed to send reorder digest: %w", err)
+	}
+	return len(items), nil
+}
@@ -0,0 +1,92 @@
+package inventory
+
+import (
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DTO структури для складів та залишків
+
+type CreateLocationRequest struct {
+	Code     string `json:"code" validate:"required,max=50"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Country  string `json:"country" validate:"omitempty,len=2"`
+	IsActive *bool  `json:"is_active"`
+}
+
+type UpdateLocationRequest struct {
+	Name     *string `json:"name" validate:"omitempty,max=255"`
+	Country  *string `json:"country" validate:"omitempty,len=2"`
+	IsActive *bool   `json:"is_active"`
+}
+
+type SetStockRequest struct {
+	Quantity int     `json:"quantity" validate:"gte=0"`
+	Note     *string `json:"note"`
+}
+
+type AdjustStockRequest struct {
+	LocationID uuid.UUID `json:"location_id" validate:"required"`
+	Quantity   int       `json:"quantity" validate:"required,ne=0"`
+	Reason     string    `json:"reason" validate:"required,oneof=adjustment return"`
+	Note       *string   `json:"note"`
+}
+
+type TransferStockRequest struct {
+	ProductID      uuid.UUID `json:"product_id" validate:"required"`
+	FromLocationID uuid.UUID `json:"from_location_id" validate:"required"`
+	ToLocationID   uuid.UUID `json:"to_location_id" validate:"required"`
+	Quantity       int       `json:"quantity" validate:"required,min=1"`
+	Note           *string   `json:"note"`
+}
+
+// TransferStockResponse залишки після переміщення; transfer_id - посилання в журналі руху
+type TransferStockResponse struct {
+	TransferID uuid.UUID `json:"transfer_id"`
+	*ProductStockResponse
+}
+
+type MovementFilter struct {
+	LocationID *uuid.UUID `json:"location_id"`
+	Reason     string     `json:"reason"`
+	Limit      int        `json:"limit" validate:"min=1,max=100"`
+	Offset     int        `json:"offset" validate:"gte=0"`
+}
+
+type MovementListResponse struct {
+	Movements []*models.StockMovement `json:"movements"`
+	Limit     int                     `json:"limit"`
+	Offset    int                     `json:"offset"`
+}
+
+// ReconciliationResponse звірка журналу руху з поточними залишками; balanced - розбіжностей немає
+type ReconciliationResponse struct {
+	ProductID uuid.UUID                     `json:"product_id"`
+	Balanced  bool                          `json:"balanced"`
+	Locations []*models.StockReconciliation `json:"locations"`
+}
+
+// ProductStockResponse залишки продукту по складах; total - сума по активних складах
+type ProductStockResponse struct {
+	ProductID uuid.UUID               `json:"product_id"`
+	Total     int                     `json:"total"`
+	Locations []*models.LocationStock `json:"locations"`
+}
+
+// UpdateReorderSettingsRequest low_stock_threshold null вимикає сповіщення про низький залишок
+type UpdateReorderSettingsRequest struct {
+	LowStockThreshold *int `json:"low_stock_threshold" validate:"omitempty,gte=0"`
+	ReorderQuantity   int  `json:"reorder_quantity" validate:"gte=0"`
+}
+
+type AlertFilter struct {
+	Status string `json:"status" validate:"omitempty,oneof=open resolved"`
+	Limit  int    `json:"limit" validate:"min=1,max=100"`
+	Offset int    `json:"offset" validate:"gte=0"`
+}
+
+type AlertListResponse struct {
+	Alerts []*models.LowStockAlert `json:"alerts"`
+	Limit  int                     `json:"limit"`
+	Offset int                     `json:"offset"`
+}
@@ -0,0 +1,31 @@
+package inventory
+
+import "errors"
+
+// помилки пов'язані зі складами та залишками
+var (
+	// Location validate errors
+	ErrInvalidLocationCode  = errors.New("location code must contain only lowercase letters, digits and dashes")
+	ErrLocationNameRequired = errors.New("location name is required")
+	ErrInvalidCountry       = errors.New("country must be a two-letter ISO code")
+
+	// Stock validate errors
+	ErrInvalidQuantity = errors.New("quantity must be non-negative")
+	ErrZeroAdjustment  = errors.New("adjustment quantity must not be zero")
+	ErrInvalidReason   = errors.New("invalid adjustment reason")
+	ErrSameLocation    = errors.New("source and destination locations must differ")
+
+	// Reorder validate errors
+	ErrInvalidThreshold       = errors.New("low stock threshold must be non-negative")
+	ErrInvalidReorderQuantity = errors.New("reorder quantity must be non-negative")
+	ErrInvalidAlertStatus     = errors.New("alert status must be open or resolved")
+
+	// logic errors
+	ErrLocationNotFound        = errors.New("stock location not found")
+	ErrLocationCodeExists      = errors.New("stock location code already exists")
+	ErrDefaultLocationInactive = errors.New("default stock location cannot be deactivated")
+	ErrLocationInactive        = errors.New("stock location is inactive")
+	ErrProductNotFound         = errors.New("product not found")
+	ErrInsufficientStock       = errors.New("insufficient stock at source location")
+	ErrBundleStock             = errors.New("bundle stock is computed from its components")
+)
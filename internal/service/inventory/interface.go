@@ -0,0 +1,30 @@
+package inventory
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// InventoryService інтерфейс для роботи зі складами та залишками
+type InventoryService interface {
+	ListLocations(ctx context.Context) ([]*models.StockLocation, error)
+	CreateLocation(ctx context.Context, req CreateLocationRequest) (*models.StockLocation, error)
+	UpdateLocation(ctx context.Context, id uuid.UUID, req UpdateLocationRequest) (*models.StockLocation, error)
+	GetProductStock(ctx context.Context, productID uuid.UUID) (*ProductStockResponse, error)
+	SetLocationStock(ctx context.Context, productID, locationID uuid.UUID, req SetStockRequest) (*ProductStockResponse, error)
+	AdjustStock(ctx context.Context, productID uuid.UUID, req AdjustStockRequest) (*ProductStockResponse, error)
+	TransferStock(ctx context.Context, req TransferStockRequest) (*TransferStockResponse, error)
+	GetOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*models.StockAllocation, error)
+	ListMovements(ctx context.Context, productID uuid.UUID, filter MovementFilter) (*MovementListResponse, error)
+	ReconcileStock(ctx context.Context, productID uuid.UUID) (*ReconciliationResponse, error)
+	GetReorderSettings(ctx context.Context, productID uuid.UUID) (*models.ReorderSettings, error)
+	UpdateReorderSettings(ctx context.Context, productID uuid.UUID, req UpdateReorderSettingsRequest) (*models.ReorderSettings, error)
+	ListAlerts(ctx context.Context, filter AlertFilter) (*AlertListResponse, error)
+	ListReorderNeeded(ctx context.Context) ([]*models.ReorderItem, error)
+	// DeliverAlerts відправляє нові сповіщення про низький залишок; повертає кількість відправлених
+	DeliverAlerts(ctx context.Context) (int, error)
+	// SendReorderDigest відправляє зведення продуктів, які потрібно дозамовити; повертає кількість продуктів
+	SendReorderDigest(ctx context.Context) (int, error)
+}
@@ -0,0 +1,387 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/Xiancel/ecommerce/internal/slug"
+	"github.com/google/uuid"
+)
+
+// максимальна довжина коду складу
+const maxCodeLength = 50
+
+// пагінація журналу руху товару
+const (
+	defaultMovementLimit = 50
+	maxMovementLimit     = 100
+)
+
+// причини, які адміністратор може вказати при ручному коригуванні
+var adjustmentReasons = map[string]bool{
+	models.MovementAdjustment: true,
+	models.MovementReturn:     true,
+}
+
+// допустимі причини для фільтра журналу
+var movementReasons = map[string]bool{
+	models.MovementOpeningBalance: true,
+	models.MovementSale:           true,
+	models.MovementCancellation:   true,
+	models.MovementReturn:         true,
+	models.MovementAdjustment:     true,
+	models.MovementCycleCount:     true,
+	models.MovementImport:         true,
+	models.MovementTransferIn:     true,
+	models.MovementTransferOut:    true,
+}
+
+type service struct {
+	inventoryRepo repository.InventoryRepository
+	productRepo   repository.ProductRepository
+	notifier      notification.Notifier
+}
+
+func NewService(inventoryRepo repository.InventoryRepository, productRepo repository.ProductRepository, notifier notification.Notifier) InventoryService {
+	return &service{inventoryRepo: inventoryRepo,
+		productRepo: productRepo,
+		notifier:    notifier}
+}
+
+// ListLocations повертає всі склади
+func (s *service) ListLocations(ctx context.Context) ([]*models.StockLocation, error) {
+	locations, err := s.inventoryRepo.ListLocations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock locations: %w", err)
+	}
+	if locations == nil {
+		locations = []*models.StockLocation{}
+	}
+	return locations, nil
+}
+
+// CreateLocation створює склад
+func (s *service) CreateLocation(ctx context.Context, req CreateLocationRequest) (*models.StockLocation, error) {
+	// валідація
+	code := strings.TrimSpace(req.Code)
+	if code == "" || len(code) > maxCodeLength || slug.Make(code) != code {
+		return nil, ErrInvalidLocationCode
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrLocationNameRequired
+	}
+	country, err := normalizeCountry(req.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	// перевірка коду на унікальність
+	existing, err := s.inventoryRepo.GetLocationByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check location code: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrLocationCodeExists
+	}
+
+	location := &models.StockLocation{
+		Code:     code,
+		Name:     name,
+		Country:  country,
+		IsActive: true,
+	}
+	if req.IsActive != nil {
+		location.IsActive = *req.IsActive
+	}
+
+	if err := s.inventoryRepo.CreateLocation(ctx, location); err != nil {
+		return nil, fmt.Errorf("failed to create stock location: %w", err)
+	}
+	return location, nil
+}
+
+// UpdateLocation оновлює назву, країну або активність складу
+func (s *service) UpdateLocation(ctx context.Context, id uuid.UUID, req UpdateLocationRequest) (*models.StockLocation, error) {
+	location, err := s.inventoryRepo.GetLocation(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock location: %w", err)
+	}
+	if location == nil {
+		return nil, ErrLocationNotFound
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrLocationNameRequired
+		}
+		location.Name = name
+	}
+	if req.Country != nil {
+		location.Country, err = normalizeCountry(*req.Country)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.IsActive != nil {
+		// на склад за замовчуванням потрапляють залишки з API продуктів та імпорту
+		if location.IsDefault && !*req.IsActive {
+			return nil, ErrDefaultLocationInactive
+		}
+		location.IsActive = *req.IsActive
+	}
+
+	if err := s.inventoryRepo.UpdateLocation(ctx, location); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("failed to update stock location: %w", err)
+	}
+	return location, nil
+}
+
+// GetProductStock повертає залишки продукту по всіх складах
+func (s *service) GetProductStock(ctx context.Context, productID uuid.UUID) (*ProductStockResponse, error) {
+	if err := s.checkProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.productStock(ctx, productID)
+}
+
+// SetLocationStock встановлює залишок продукту на складі після інвентаризації (cycle count)
+func (s *service) SetLocationStock(ctx context.Context, productID, locationID uuid.UUID, req SetStockRequest) (*ProductStockResponse, error) {
+	if req.Quantity < 0 {
+		return nil, ErrInvalidQuantity
+	}
+	if err := s.checkStockedProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getLocation(ctx, locationID); err != nil {
+		return nil, err
+	}
+
+	src := models.MovementSource{Reason: models.MovementCycleCount, Note: req.Note}
+	if err := s.inventoryRepo.SetStock(ctx, locationID, productID, req.Quantity, src); err != nil {
+		return nil, fmt.Errorf("failed to set stock: %w", err)
+	}
+	return s.productStock(ctx, productID)
+}
+
+// AdjustStock змінює залишок продукту на складі на задану кількість з причиною (коригування, повернення)
+func (s *service) AdjustStock(ctx context.Context, productID uuid.UUID, req AdjustStockRequest) (*ProductStockResponse, error) {
+	// валідація
+	if req.Quantity == 0 {
+		return nil, ErrZeroAdjustment
+	}
+	if !adjustmentReasons[req.Reason] {
+		return nil, ErrInvalidReason
+	}
+	if err := s.checkStockedProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getLocation(ctx, req.LocationID); err != nil {
+		return nil, err
+	}
+
+	src := models.MovementSource{Reason: req.Reason, Note: req.Note}
+	if err := s.inventoryRepo.AdjustStock(ctx, req.LocationID, productID, req.Quantity, src); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("failed to adjust stock: %w", err)
+	}
+	return s.productStock(ctx, productID)
+}
+
+// TransferStock переміщує товар між складами
+func (s *service) TransferStock(ctx context.Context, req TransferStockRequest) (*TransferStockResponse, error) {
+	// валідація
+	if req.Quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	if req.FromLocationID == req.ToLocationID {
+		return nil, ErrSameLocation
+	}
+	if err := s.checkStockedProduct(ctx, req.ProductID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getLocation(ctx, req.FromLocationID); err != nil {
+		return nil, err
+	}
+	to, err := s.getLocation(ctx, req.ToLocationID)
+	if err != nil {
+		return nil, err
+	}
+	// переміщення на неактивний склад прибрало б товар з наявності
+	if !to.IsActive {
+		return nil, ErrLocationInactive
+	}
+
+	transferID, err := s.inventoryRepo.Transfer(ctx, req.ProductID, req.FromLocationID, req.ToLocationID, req.Quantity, req.Note)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("failed to transfer stock: %w", err)
+	}
+
+	stock, err := s.productStock(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferStockResponse{TransferID: transferID, ProductStockResponse: stock}, nil
+}
+
+// GetOrderAllocations повертає, з яких складів відвантажується кожна позиція замовлення
+func (s *service) GetOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*models.StockAllocation, error) {
+	allocations, err := s.inventoryRepo.ListOrderAllocations(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order allocations: %w", err)
+	}
+	if allocations == nil {
+		allocations = []*models.StockAllocation{}
+	}
+	return allocations, nil
+}
+
+// ListMovements повертає журнал руху товару продукту
+func (s *service) ListMovements(ctx context.Context, productID uuid.UUID, filter MovementFilter) (*MovementListResponse, error) {
+	// пагінація
+	if filter.Limit <= 0 {
+		filter.Limit = defaultMovementLimit
+	}
+	if filter.Limit > maxMovementLimit {
+		filter.Limit = maxMovementLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	if filter.Reason != "" && !movementReasons[filter.Reason] {
+		return nil, ErrInvalidReason
+	}
+	if err := s.checkProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	movements, err := s.inventoryRepo.ListMovements(ctx, models.StockMovementFilter{
+		ProductID:  productID,
+		LocationID: filter.LocationID,
+		Reason:     filter.Reason,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+	if movements == nil {
+		movements = []*models.StockMovement{}
+	}
+
+	return &MovementListResponse{
+		Movements: movements,
+		Limit:     filter.Limit,
+		Offset:    filter.Offset,
+	}, nil
+}
+
+// ReconcileStock звіряє суму журналу руху з поточним залишком на кожному складі
+func (s *service) ReconcileStock(ctx context.Context, productID uuid.UUID) (*ReconciliationResponse, error) {
+	if err := s.checkProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	lines, err := s.inventoryRepo.Reconcile(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile stock: %w", err)
+	}
+
+	resp := &ReconciliationResponse{
+		ProductID: productID,
+		Balanced:  true,
+		Locations: lines,
+	}
+	if resp.Locations == nil {
+		resp.Locations = []*models.StockReconciliation{}
+	}
+	for _, line := range lines {
+		if line.Difference != 0 {
+			resp.Balanced = false
+		}
+	}
+	return resp, nil
+}
+
+// productStock формує відповідь із залишками продукту
+func (s *service) productStock(ctx context.Context, productID uuid.UUID) (*ProductStockResponse, error) {
+	stock, err := s.inventoryRepo.ListProductStock(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product stock: %w", err)
+	}
+
+	resp := &ProductStockResponse{
+		ProductID: productID,
+		Locations: stock,
+	}
+	if resp.Locations == nil {
+		resp.Locations = []*models.LocationStock{}
+	}
+	for _, level := range stock {
+		if level.IsActive {
+			resp.Total += level.Quantity
+		}
+	}
+	return resp, nil
+}
+
+// checkProduct перевіряє, що продукт існує і не видалений
+func (s *service) checkProduct(ctx context.Context, productID uuid.UUID) error {
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || product.DeletedAt != nil {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+// checkStockedProduct перевіряє, що продукт існує і зберігається на складах:
+// залишок комплекту обчислюється з компонентів і не змінюється напряму
+func (s *service) checkStockedProduct(ctx context.Context, productID uuid.UUID) error {
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || product.DeletedAt != nil {
+		return ErrProductNotFound
+	}
+	if product.IsBundle {
+		return ErrBundleStock
+	}
+	return nil
+}
+
+// getLocation повертає склад або ErrLocationNotFound
+func (s *service) getLocation(ctx context.Context, id uuid.UUID) (*models.StockLocation, error) {
+	location, err := s.inventoryRepo.GetLocation(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock location: %w", err)
+	}
+	if location == nil {
+		return nil, ErrLocationNotFound
+	}
+	return location, nil
+}
+
+// normalizeCountry перевіряє код країни (ISO 3166-1 alpha-2); порожній рядок - країна не задана
+func normalizeCountry(country string) (*string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "" {
+		return nil, nil
+	}
+	if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+		return nil, ErrInvalidCountry
+	}
+	return &country, nil
+}
@@ -0,0 +1,654 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockInventoryRepository) CreateLocation(ctx context.Context, location *models.StockLocation) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) GetLocation(ctx context.Context, id uuid.UUID) (*models.StockLocation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryRepository) GetLocationByCode(ctx context.Context, code string) (*models.StockLocation, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryRepository) ListLocations(ctx context.Context) ([]*models.StockLocation, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryRepository) UpdateLocation(ctx context.Context, location *models.StockLocation) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) ListProductStock(ctx context.Context, productID uuid.UUID) ([]*models.LocationStock, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LocationStock), args.Error(1)
+}
+func (m *MockInventoryRepository) ListStockLevels(ctx context.Context, productIDs []uuid.UUID) ([]*models.LocationStock, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LocationStock), args.Error(1)
+}
+func (m *MockInventoryRepository) SetStock(ctx context.Context, locationID, productID uuid.UUID, quantity int, src models.MovementSource) error {
+	args := m.Called(ctx, locationID, productID, quantity, src)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) AdjustStock(ctx context.Context, locationID, productID uuid.UUID, delta int, src models.MovementSource) error {
+	args := m.Called(ctx, locationID, productID, delta, src)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) Transfer(ctx context.Context, productID, fromID, toID uuid.UUID, quantity int, note *string) (uuid.UUID, error) {
+	args := m.Called(ctx, productID, fromID, toID, quantity, note)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+func (m *MockInventoryRepository) ListMovements(ctx context.Context, filter models.StockMovementFilter) ([]*models.StockMovement, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockMovement), args.Error(1)
+}
+func (m *MockInventoryRepository) Reconcile(ctx context.Context, productID uuid.UUID) ([]*models.StockReconciliation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockReconciliation), args.Error(1)
+}
+func (m *MockInventoryRepository) GetReorderSettings(ctx context.Context, productID uuid.UUID) (*models.ReorderSettings, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReorderSettings), args.Error(1)
+}
+func (m *MockInventoryRepository) UpdateReorderSettings(ctx context.Context, settings *models.ReorderSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) ListAlerts(ctx context.Context, filter models.LowStockAlertFilter) ([]*models.LowStockAlert, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LowStockAlert), args.Error(1)
+}
+func (m *MockInventoryRepository) ListUndeliveredAlerts(ctx context.Context, limit int) ([]*models.LowStockAlert, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LowStockAlert), args.Error(1)
+}
+func (m *MockInventoryRepository) MarkAlertsNotified(ctx context.Context, ids []uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) ListReorderNeeded(ctx context.Context) ([]*models.ReorderItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ReorderItem), args.Error(1)
+}
+func (m *MockInventoryRepository) ListOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*models.StockAllocation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockAllocation), args.Error(1)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, n notification.Notification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+func TestCreateLocation_Success(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockInventory, new(MockProductRepository), new(MockNotifier))
+	ctx := context.Background()
+
+	mockInventory.On("GetLocationByCode", ctx, "warsaw").Return(nil, nil)
+	mockInventory.On("CreateLocation", ctx, mock.MatchedBy(func(l *models.StockLocation) bool {
+		return l.Code == "warsaw" && l.Name == "Warsaw" && *l.Country == "PL" && l.IsActive
+	})).Return(nil)
+
+	location, err := service.CreateLocation(ctx, CreateLocationRequest{Code: "warsaw", Name: " Warsaw ", Country: "pl"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PL", *location.Country)
+	mockInventory.AssertExpectations(t)
+}
+
+func TestCreateLocation_InvalidCode(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockInventory, new(MockProductRepository), new(MockNotifier))
+
+	location, err := service.CreateLocation(context.Background(), CreateLocationRequest{Code: "Main Store", Name: "Main"})
+
+	assert.Nil(t, location)
+	assert.Equal(t, ErrInvalidLocationCode, err)
+	mockInventory.AssertNotCalled(t, "CreateLocation", mock.Anything, mock.Anything)
+}
+
+func TestCreateLocation_InvalidCountry(t *testing.T) {
+	service := NewService(new(MockInventoryRepository), new(MockProductRepository), new(MockNotifier))
+
+	_, err := service.CreateLocation(context.Background(), CreateLocationRequest{Code: "kyiv", Name: "Kyiv", Country: "Ukraine"})
+
+	assert.Equal(t, ErrInvalidCountry, err)
+}
+
+func TestCreateLocation_CodeExists(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockInventory, new(MockProductRepository), new(MockNotifier))
+	ctx := context.Background()
+
+	mockInventory.On("GetLocationByCode", ctx, "main").Return(&models.StockLocation{ID: uuid.New(), Code: "main"}, nil)
+
+	_, err := service.CreateLocation(ctx, CreateLocationRequest{Code: "main", Name: "Main"})
+
+	assert.Equal(t, ErrLocationCodeExists, err)
+}
+
+func TestUpdateLocation_CannotDeactivateDefault(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockInventory, new(MockProductRepository), new(MockNotifier))
+	ctx := context.Background()
+	id := uuid.New()
+	inactive := false
+
+	mockInventory.On("GetLocation", ctx, id).Return(&models.StockLocation{ID: id, IsDefault: true, IsActive: true}, nil)
+
+	_, err := service.UpdateLocation(ctx, id, UpdateLocationRequest{IsActive: &inactive})
+
+	assert.Equal(t, ErrDefaultLocationInactive, err)
+	mockInventory.AssertNotCalled(t, "UpdateLocation", mock.Anything, mock.Anything)
+}
+
+func TestGetProductStock_TotalCountsActiveLocations(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("ListProductStock", ctx, productID).Return([]*models.LocationStock{
+		{LocationCode: "main", IsActive: true, Quantity: 4},
+		{LocationCode: "warsaw", IsActive: true, Quantity: 6},
+		{LocationCode: "old", IsActive: false, Quantity: 100},
+	}, nil)
+
+	resp, err := service.GetProductStock(ctx, productID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, resp.Total)
+	assert.Len(t, resp.Locations, 3)
+}
+
+func TestTransferStock_Success(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID, fromID, toID := uuid.New(), uuid.New(), uuid.New()
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("GetLocation", ctx, fromID).Return(&models.StockLocation{ID: fromID, IsActive: true}, nil)
+	mockInventory.On("GetLocation", ctx, toID).Return(&models.StockLocation{ID: toID, IsActive: true}, nil)
+	transferID := uuid.New()
+	note := "rebalance"
+	mockInventory.On("Transfer", ctx, productID, fromID, toID, 3, &note).Return(transferID, nil)
+	mockInventory.On("ListProductStock", ctx, productID).Return([]*models.LocationStock{}, nil)
+
+	resp, err := service.TransferStock(ctx, TransferStockRequest{ProductID: productID, FromLocationID: fromID, ToLocationID: toID, Quantity: 3, Note: &note})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transferID, resp.TransferID)
+	mockInventory.AssertExpectations(t)
+}
+
+func TestTransferStock_Insufficient(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID, fromID, toID := uuid.New(), uuid.New(), uuid.New()
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("GetLocation", ctx, fromID).Return(&models.StockLocation{ID: fromID, IsActive: true}, nil)
+	mockInventory.On("GetLocation", ctx, toID).Return(&models.StockLocation{ID: toID, IsActive: true}, nil)
+	mockInventory.On("Transfer", ctx, productID, fromID, toID, 3, (*string)(nil)).Return(uuid.Nil, sql.ErrNoRows)
+
+	_, err := service.TransferStock(ctx, TransferStockRequest{ProductID: productID, FromLocationID: fromID, ToLocationID: toID, Quantity: 3})
+
+	assert.Equal(t, ErrInsufficientStock, err)
+}
+
+func TestTransferStock_SameLocation(t *testing.T) {
+	service := NewService(new(MockInventoryRepository), new(MockProductRepository), new(MockNotifier))
+	id := uuid.New()
+
+	_, err := service.TransferStock(context.Background(), TransferStockRequest{ProductID: uuid.New(), FromLocationID: id, ToLocationID: id, Quantity: 1})
+
+	assert.Equal(t, ErrSameLocation, err)
+}
+
+func TestTransferStock_InactiveDestination(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID, fromID, toID := uuid.New(), uuid.New(), uuid.New()
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("GetLocation", ctx, fromID).Return(&models.StockLocation{ID: fromID, IsActive: true}, nil)
+	mockInventory.On("GetLocation", ctx, toID).Return(&models.StockLocation{ID: toID, IsActive: false}, nil)
+
+	_, err := service.TransferStock(ctx, TransferStockRequest{ProductID: productID, FromLocationID: fromID, ToLocationID: toID, Quantity: 1})
+
+	assert.Equal(t, ErrLocationInactive, err)
+	mockInventory.AssertNotCalled(t, "Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetLocationStock_Negative(t *testing.T) {
+	service := NewService(new(MockInventoryRepository), new(MockProductRepository), new(MockNotifier))
+
+	_, err := service.SetLocationStock(context.Background(), uuid.New(), uuid.New(), SetStockRequest{Quantity: -1})
+
+	assert.Equal(t, ErrInvalidQuantity, err)
+}
+
+func TestSetLocationStock_RecordsCycleCount(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID, locationID := uuid.New(), uuid.New()
+	note := "quarterly count"
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("GetLocation", ctx, locationID).Return(&models.StockLocation{ID: locationID, IsActive: true}, nil)
+	mockInventory.On("SetStock", ctx, locationID, productID, 7, models.MovementSource{Reason: models.MovementCycleCount, Note: &note}).Return(nil)
+	mockInventory.On("ListProductStock", ctx, productID).Return([]*models.LocationStock{}, nil)
+
+	_, err := service.SetLocationStock(ctx, productID, locationID, SetStockRequest{Quantity: 7, Note: &note})
+
+	assert.NoError(t, err)
+	mockInventory.AssertExpectations(t)
+}
+
+func TestAdjustStock_Success(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID, locationID := uuid.New(), uuid.New()
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("GetLocation", ctx, locationID).Return(&models.StockLocation{ID: locationID, IsActive: true}, nil)
+	mockInventory.On("AdjustStock", ctx, locationID, productID, 2, models.MovementSource{Reason: models.MovementReturn}).Return(nil)
+	mockInventory.On("ListProductStock", ctx, productID).Return([]*models.LocationStock{
+		{LocationID: locationID, IsActive: true, Quantity: 5},
+	}, nil)
+
+	resp, err := service.AdjustStock(ctx, productID, AdjustStockRequest{LocationID: locationID, Quantity: 2, Reason: models.MovementReturn})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, resp.Total)
+	mockInventory.AssertExpectations(t)
+}
+
+func TestAdjustStock_Validation(t *testing.T) {
+	service := NewService(new(MockInventoryRepository), new(MockProductRepository), new(MockNotifier))
+	ctx := context.Background()
+
+	_, err := service.AdjustStock(ctx, uuid.New(), AdjustStockRequest{LocationID: uuid.New(), Quantity: 0, Reason: models.MovementAdjustment})
+	assert.Equal(t, ErrZeroAdjustment, err)
+
+	// продаж та імпорт записуються тільки системою
+	_, err = service.AdjustStock(ctx, uuid.New(), AdjustStockRequest{LocationID: uuid.New(), Quantity: -1, Reason: models.MovementSale})
+	assert.Equal(t, ErrInvalidReason, err)
+}
+
+func TestAdjustStock_BundleRejected(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID, IsBundle: true}, nil)
+
+	_, err := service.AdjustStock(ctx, productID, AdjustStockRequest{LocationID: uuid.New(), Quantity: 5, Reason: models.MovementAdjustment})
+
+	assert.Equal(t, ErrBundleStock, err)
+	mockInventory.AssertNotCalled(t, "AdjustStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdjustStock_Insufficient(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID, locationID := uuid.New(), uuid.New()
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("GetLocation", ctx, locationID).Return(&models.StockLocation{ID: locationID, IsActive: true}, nil)
+	mockInventory.On("AdjustStock", ctx, locationID, productID, -10, mock.Anything).Return(sql.ErrNoRows)
+
+	_, err := service.AdjustStock(ctx, productID, AdjustStockRequest{LocationID: locationID, Quantity: -10, Reason: models.MovementAdjustment})
+
+	assert.Equal(t, ErrInsufficientStock, err)
+}
+
+func TestListMovements_DefaultsAndFilter(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("ListMovements", ctx, models.StockMovementFilter{
+		ProductID: productID,
+		Reason:    models.MovementSale,
+		Limit:     100,
+	}).Return(nil, nil)
+
+	resp, err := service.ListMovements(ctx, productID, MovementFilter{Reason: models.MovementSale, Limit: 500})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100, resp.Limit)
+	assert.NotNil(t, resp.Movements)
+	mockInventory.AssertExpectations(t)
+}
+
+func TestListMovements_InvalidReason(t *testing.T) {
+	service := NewService(new(MockInventoryRepository), new(MockProductRepository), new(MockNotifier))
+
+	_, err := service.ListMovements(context.Background(), uuid.New(), MovementFilter{Reason: "theft"})
+
+	assert.Equal(t, ErrInvalidReason, err)
+}
+
+func TestReconcileStock(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("Reconcile", ctx, productID).Return([]*models.StockReconciliation{
+		{LocationCode: "main", Quantity: 5, LedgerQuantity: 5},
+		{LocationCode: "warsaw", Quantity: 3, LedgerQuantity: 4, Difference: -1},
+	}, nil)
+
+	resp, err := service.ReconcileStock(ctx, productID)
+
+	assert.NoError(t, err)
+	assert.False(t, resp.Balanced)
+	assert.Len(t, resp.Locations, 2)
+}
+
+func TestUpdateReorderSettings_Success(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockProduct := new(MockProductRepository)
+	service := NewService(mockInventory, mockProduct, new(MockNotifier))
+	ctx := context.Background()
+	productID := uuid.New()
+	threshold := 5
+
+	mockProduct.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockInventory.On("UpdateReorderSettings", ctx, &models.ReorderSettings{ProductID: productID, LowStockThreshold: &threshold, ReorderQuantity: 20}).Return(nil)
+
+	settings, err := service.UpdateReorderSettings(ctx, productID, UpdateReorderSettingsRequest{LowStockThreshold: &threshold, ReorderQuantity: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, *settings.LowStockThreshold)
+	mockInventory.AssertExpectations(t)
+}
+
+func TestUpdateReorderSettings_Validation(t *testing.T) {
+	service := NewService(new(MockInventoryRepository), new(MockProductRepository), new(MockNotifier))
+	ctx := context.Background()
+	negative := -1
+
+	_, err := service.UpdateReorderSettings(ctx, uuid.New(), UpdateReorderSettingsRequest{LowStockThreshold: &negative})
+	assert.Equal(t, ErrInvalidThreshold, err)
+
+	_, err = service.UpdateReorderSettings(ctx, uuid.New(), UpdateReorderSettingsRequest{ReorderQuantity: -5})
+	assert.Equal(t, ErrInvalidReorderQuantity, err)
+}
+
+func TestListAlerts_InvalidStatus(t *testing.T) {
+	service := NewService(new(MockInventoryRepository), new(MockProductRepository), new(MockNotifier))
+
+	_, err := service.ListAlerts(context.Background(), AlertFilter{Status: "closed"})
+
+	assert.Equal(t, ErrInvalidAlertStatus, err)
+}
+
+func TestListAlerts_Defaults(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockInventory, new(MockProductRepository), new(MockNotifier))
+	ctx := context.Background()
+
+	mockInventory.On("ListAlerts", ctx, models.LowStockAlertFilter{Status: models.AlertStatusOpen, Limit: 50}).Return(nil, nil)
+
+	resp, err := service.ListAlerts(ctx, AlertFilter{Status: models.AlertStatusOpen})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp.Alerts)
+	assert.Equal(t, 50, resp.Limit)
+	mockInventory.AssertExpectations(t)
+}
+
+func TestDeliverAlerts_MarksDelivered(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockInventory, new(MockProductRepository), mockNotifier)
+	ctx := context.Background()
+	first := &models.LowStockAlert{ID: uuid.New(), ProductID: uuid.New(), Stock: 2, Threshold: 5}
+	second := &models.LowStockAlert{ID: uuid.New(), ProductID: uuid.New(), Stock: 0, Threshold: 3}
+
+	mockInventory.On("ListUndeliveredAlerts", ctx, alertDeliveryBatch).Return([]*models.LowStockAlert{first, second}, nil)
+	mockNotifier.On("Notify", ctx, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Type == notification.TypeLowStock && n.UserID == nil
+	})).Return(nil)
+	mockInventory.On("MarkAlertsNotified", ctx, []uuid.UUID{first.ID, second.ID}).Return(nil)
+
+	count, err := service.DeliverAlerts(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	mockNotifier.AssertNumberOfCalls(t, "Notify", 2)
+	mockInventory.AssertExpectations(t)
+}
+
+func TestDeliverAlerts_StopsOnFailure(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockInventory, new(MockProductRepository), mockNotifier)
+	ctx := context.Background()
+	first := &models.LowStockAlert{ID: uuid.New(), ProductID: uuid.New()}
+	second := &models.LowStockAlert{ID: uuid.New(), ProductID: uuid.New()}
+
+	mockInventory.On("ListUndeliveredAlerts", ctx, alertDeliveryBatch).Return([]*models.LowStockAlert{first, second}, nil)
+	mockNotifier.On("Notify", ctx, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Data["alert_id"] == first.ID
+	})).Return(nil)
+	mockNotifier.On("Notify", ctx, mock.Anything).Return(errors.New("webhook down"))
+	mockInventory.On("MarkAlertsNotified", ctx, []uuid.UUID{first.ID}).Return(nil)
+
+	count, err := service.DeliverAlerts(ctx)
+
+	// друге сповіщення залишається невідправленим і повториться наступного запуску
+	assert.Error(t, err)
+	assert.Equal(t, 1, count)
+	mockInventory.AssertExpectations(t)
+}
+
+func TestSendReorderDigest(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockInventory, new(MockProductRepository), mockNotifier)
+	ctx := context.Background()
+	items := []*models.ReorderItem{
+		{ProductID: uuid.New(), Name: "Mouse", Stock: 1, Threshold: 5, ReorderQuantity: 20},
+		{ProductID: uuid.New(), Name: "Keyboard", Stock: 3, Threshold: 3, ReorderQuantity: 10},
+	}
+
+	mockInventory.On("ListReorderNeeded", ctx).Return(items, nil)
+	mockNotifier.On("Notify", ctx, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Type == notification.TypeReorderDigest && n.Data["count"] == 2
+	})).Return(nil)
+
+	count, err := service.SendReorderDigest(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestSendReorderDigest_NothingToReorder(t *testing.T) {
+	mockInventory := new(MockInventoryRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockInventory, new(MockProductRepository), mockNotifier)
+	ctx := context.Background()
+
+	mockInventory.On("ListReorderNeeded", ctx).Return(nil, nil)
+
+	count, err := service.SendReorderDigest(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,16 @@
+package media
+
+import "io"
+
+// DTO структури для зображень продуктів
+
+type UploadImageRequest struct {
+	File      io.Reader
+	AltText   string
+	SortOrder *int
+}
+
+type UpdateImageRequest struct {
+	AltText   *string `json:"alt_text" validate:"omitempty,max=255"`
+	SortOrder *int    `json:"sort_order" validate:"omitempty,gte=0"`
+}
@@ -0,0 +1,18 @@
+package media
+
+import "errors"
+
+// помилки пов'язані з зображеннями продуктів
+var (
+	// Validation errors
+	ErrFileRequired      = errors.New("image file is required")
+	ErrImageTooLarge     = errors.New("image is too large")
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrAltTextTooLong    = errors.New("alt text must be at most 255 characters")
+	ErrInvalidSortOrder  = errors.New("sort order must be non-negative")
+	ErrNoFieldsToUpdate  = errors.New("no fields to update")
+
+	// Logic errors
+	ErrProductNotFound = errors.New("product not found")
+	ErrImageNotFound   = errors.New("image not found")
+)
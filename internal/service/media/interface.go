@@ -0,0 +1,18 @@
+package media
+
+import (
+	"context"
+	"io"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// MediaService інтерфейс для роботи з зображеннями продуктів
+type MediaService interface {
+	UploadImage(ctx context.Context, productID uuid.UUID, req UploadImageRequest) (*models.ProductImage, error)
+	ListImages(ctx context.Context, productID uuid.UUID) ([]*models.ProductImage, error)
+	UpdateImage(ctx context.Context, productID, imageID uuid.UUID, req UpdateImageRequest) (*models.ProductImage, error)
+	DeleteImage(ctx context.Context, productID, imageID uuid.UUID) error
+	OpenFile(ctx context.Context, key string) (io.ReadCloser, error)
+}
@@ -0,0 +1,369 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"strings"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/Xiancel/ecommerce/internal/storage"
+	"github.com/google/uuid"
+)
+
+// значення за замовчуванням для конфігурації
+const (
+	defaultMaxUploadBytes = 10 << 20
+	defaultMaxPixels      = 40_000_000
+	defaultJPEGQuality    = 85
+	maxAltTextLength      = 255
+)
+
+// розміри варіантів зображення (від більшого до меншого)
+var variantSizes = []struct {
+	name    string
+	maxSize int
+}{
+	{models.ImageVariantLarge, 1200},
+	{models.ImageVariantMedium, 600},
+	{models.ImageVariantThumbnail, 200},
+}
+
+// розширення оригінальних файлів за форматом
+var originalExtensions = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+	"gif":  ".gif",
+}
+
+// Config налаштування сервісу зображень
+type Config struct {
+	MaxUploadBytes int64
+	MaxPixels      int
+	// формати зменшених варіантів; перший використовується для головного зображення
+	Encoders []VariantEncoder
+}
+
+type service struct {
+	imageRepo   repository.ProductImageRepository
+	productRepo repository.ProductRepository
+	storage     storage.BlobStorage
+	cfg         Config
+}
+
+func NewService(imageRepo repository.ProductImageRepository, productRepo repository.ProductRepository, blobStorage storage.BlobStorage, cfg Config) MediaService {
+	if cfg.MaxUploadBytes <= 0 {
+		cfg.MaxUploadBytes = defaultMaxUploadBytes
+	}
+	if cfg.MaxPixels <= 0 {
+		cfg.MaxPixels = defaultMaxPixels
+	}
+	if len(cfg.Encoders) == 0 {
+		cfg.Encoders = []VariantEncoder{jpegEncoder{quality: defaultJPEGQuality}, webpEncoder{}}
+	}
+	return &service{imageRepo: imageRepo,
+		productRepo: productRepo,
+		storage:     blobStorage,
+		cfg:         cfg}
+}
+
+// UploadImage завантаження зображення продукту та генерація варіантів
+func (s *service) UploadImage(ctx context.Context, productID uuid.UUID, req UploadImageRequest) (*models.ProductImage, error) {
+	// валідація
+	if req.File == nil {
+		return nil, ErrFileRequired
+	}
+	altText := strings.TrimSpace(req.AltText)
+	if len(altText) > maxAltTextLength {
+		return nil, ErrAltTextTooLong
+	}
+	if req.SortOrder != nil && *req.SortOrder < 0 {
+		return nil, ErrInvalidSortOrder
+	}
+
+	// перевірка продукту на існування
+	if _, err := s.productRepo.GetById(ctx, productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	// читання файлу з обмеженням розміру
+	data, err := io.ReadAll(io.LimitReader(req.File, s.cfg.MaxUploadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, ErrFileRequired
+	}
+	if int64(len(data)) > s.cfg.MaxUploadBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	// перевірка формату та розмірів до повного декодування
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	ext, ok := originalExtensions[format]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+	if cfg.Width*cfg.Height > s.cfg.MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	productImage := &models.ProductImage{
+		ID:        uuid.New(),
+		ProductID: productID,
+		Width:     cfg.Width,
+		Height:    cfg.Height,
+	}
+	if altText != "" {
+		productImage.AltText = &altText
+	}
+
+	// порядок сортування: в кінець списку, якщо не вказано
+	if req.SortOrder != nil {
+		productImage.SortOrder = *req.SortOrder
+	} else {
+		existing, err := s.imageRepo.ListByProduct(ctx, productID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list images: %w", err)
+		}
+		productImage.SortOrder = len(existing)
+	}
+
+	// збереження оригіналу
+	prefix := fmt.Sprintf("products/%s/%s/", productID, productImage.ID)
+	original := models.ImageVariant{
+		Name:   models.ImageVariantOriginal,
+		Format: format,
+		Width:  cfg.Width,
+		Height: cfg.Height,
+		Key:    prefix + models.ImageVariantOriginal + ext,
+	}
+	if err := s.storage.Put(ctx, original.Key, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to store image: %w", err)
+	}
+	productImage.Variants = append(productImage.Variants, original)
+
+	// генерація зменшених варіантів
+	if err := s.storeVariants(ctx, productImage, prefix, img); err != nil {
+		s.deleteBlobs(ctx, productImage)
+		return nil, err
+	}
+
+	// створення запису
+	if err := s.imageRepo.Create(ctx, productImage); err != nil {
+		s.deleteBlobs(ctx, productImage)
+		return nil, fmt.Errorf("failed to create image: %w", err)
+	}
+
+	if err := s.syncPrimaryImage(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	s.fillURLs(productImage)
+	return productImage, nil
+}
+
+// ListImages повертає зображення продукту
+func (s *service) ListImages(ctx context.Context, productID uuid.UUID) ([]*models.ProductImage, error) {
+	images, err := s.imageRepo.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	if images == nil {
+		images = []*models.ProductImage{}
+	}
+	for _, img := range images {
+		s.fillURLs(img)
+	}
+	return images, nil
+}
+
+// UpdateImage оновлення alt тексту та порядку сортування зображення
+func (s *service) UpdateImage(ctx context.Context, productID, imageID uuid.UUID, req UpdateImageRequest) (*models.ProductImage, error) {
+	// валідація
+	if req.AltText == nil && req.SortOrder == nil {
+		return nil, ErrNoFieldsToUpdate
+	}
+	if req.SortOrder != nil && *req.SortOrder < 0 {
+		return nil, ErrInvalidSortOrder
+	}
+
+	// отримання зображення
+	productImage, err := s.imageRepo.GetByID(ctx, productID, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	if productImage == nil {
+		return nil, ErrImageNotFound
+	}
+
+	// оновлення полів
+	if req.AltText != nil {
+		altText := strings.TrimSpace(*req.AltText)
+		if len(altText) > maxAltTextLength {
+			return nil, ErrAltTextTooLong
+		}
+		if altText == "" {
+			productImage.AltText = nil
+		} else {
+			productImage.AltText = &altText
+		}
+	}
+	if req.SortOrder != nil {
+		productImage.SortOrder = *req.SortOrder
+	}
+
+	if err := s.imageRepo.Update(ctx, productImage); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to update image: %w", err)
+	}
+
+	// зміна порядку могла змінити головне зображення
+	if req.SortOrder != nil {
+		if err := s.syncPrimaryImage(ctx, productID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.fillURLs(productImage)
+	return productImage, nil
+}
+
+// DeleteImage видалення зображення та всіх його варіантів
+func (s *service) DeleteImage(ctx context.Context, productID, imageID uuid.UUID) error {
+	productImage, err := s.imageRepo.GetByID(ctx, productID, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to get image: %w", err)
+	}
+	if productImage == nil {
+		return ErrImageNotFound
+	}
+
+	if err := s.imageRepo.Delete(ctx, productID, imageID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrImageNotFound
+		}
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+
+	s.deleteBlobs(ctx, productImage)
+	return s.syncPrimaryImage(ctx, productID)
+}
+
+// OpenFile відкриває збережений файл зображення за ключем
+func (s *service) OpenFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	// через цей метод віддаються лише зображення продуктів
+	if !strings.HasPrefix(key, "products/") {
+		return nil, ErrImageNotFound
+	}
+	file, err := s.storage.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	return file, nil
+}
+
+// storeVariants генерує та зберігає зменшені варіанти зображення.
+// Кожен наступний варіант зменшується з попереднього, щоб не обробляти оригінал повторно.
+func (s *service) storeVariants(ctx context.Context, productImage *models.ProductImage, prefix string, img image.Image) error {
+	current := toRGBA(img)
+	for _, size := range variantSizes {
+		width, height := fitSize(current.Bounds().Dx(), current.Bounds().Dy(), size.maxSize)
+		current = resize(current, width, height)
+
+		// кожен розмір зберігається в усіх форматах
+		for _, encoder := range s.cfg.Encoders {
+			var buf bytes.Buffer
+			if err := encoder.Encode(&buf, current); err != nil {
+				return fmt.Errorf("failed to encode %s %s variant: %w", size.name, encoder.Format(), err)
+			}
+
+			variant := models.ImageVariant{
+				Name:   size.name,
+				Format: encoder.Format(),
+				Width:  width,
+				Height: height,
+				Key:    prefix + size.name + encoder.Extension(),
+			}
+			if err := s.storage.Put(ctx, variant.Key, &buf); err != nil {
+				return fmt.Errorf("failed to store %s %s variant: %w", size.name, encoder.Format(), err)
+			}
+			productImage.Variants = append(productImage.Variants, variant)
+		}
+	}
+	return nil
+}
+
+// syncPrimaryImage встановлює перше зображення продукту як головне (products.image_url)
+func (s *service) syncPrimaryImage(ctx context.Context, productID uuid.UUID) error {
+	images, err := s.imageRepo.ListByProduct(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to list images: %w", err)
+	}
+
+	var url *string
+	if len(images) > 0 {
+		key := primaryKey(images[0])
+		if key != "" {
+			u := s.storage.URL(key)
+			url = &u
+		}
+	}
+
+	if err := s.imageRepo.SetPrimaryURL(ctx, productID, url); err != nil {
+		return fmt.Errorf("failed to set primary image: %w", err)
+	}
+	return nil
+}
+
+// deleteBlobs видаляє файли всіх варіантів зображення (помилки лише логуються)
+func (s *service) deleteBlobs(ctx context.Context, productImage *models.ProductImage) {
+	for _, variant := range productImage.Variants {
+		if err := s.storage.Delete(ctx, variant.Key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("failed to delete image blob %s: %v", variant.Key, err)
+		}
+	}
+}
+
+// fillURLs заповнює публічні URL варіантів
+func (s *service) fillURLs(productImage *models.ProductImage) {
+	for i := range productImage.Variants {
+		productImage.Variants[i].URL = s.storage.URL(productImage.Variants[i].Key)
+	}
+}
+
+// primaryKey повертає ключ варіанту для головного зображення (перший medium, або оригінал)
+func primaryKey(productImage *models.ProductImage) string {
+	var key string
+	for _, variant := range productImage.Variants {
+		switch variant.Name {
+		case models.ImageVariantMedium:
+			return variant.Key
+		case models.ImageVariantOriginal:
+			key = variant.Key
+		}
+	}
+	return key
+}
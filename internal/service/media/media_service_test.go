@@ -0,0 +1,389 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	_ "golang.org/x/image/webp"
+)
+
+type MockProductImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductImageRepository) Create(ctx context.Context, image *models.ProductImage) error {
+	args := m.Called(ctx, image)
+	return args.Error(0)
+}
+
+func (m *MockProductImageRepository) GetByID(ctx context.Context, productID, id uuid.UUID) (*models.ProductImage, error) {
+	args := m.Called(ctx, productID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductImage), args.Error(1)
+}
+
+func (m *MockProductImageRepository) ListByProduct(ctx context.Context, productID uuid.UUID) ([]*models.ProductImage, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductImage), args.Error(1)
+}
+
+func (m *MockProductImageRepository) Update(ctx context.Context, image *models.ProductImage) error {
+	args := m.Called(ctx, image)
+	return args.Error(0)
+}
+
+func (m *MockProductImageRepository) Delete(ctx context.Context, productID, id uuid.UUID) error {
+	args := m.Called(ctx, productID, id)
+	return args.Error(0)
+}
+
+func (m *MockProductImageRepository) SetPrimaryURL(ctx context.Context, productID uuid.UUID, url *string) error {
+	args := m.Called(ctx, productID, url)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+// testPNG створює PNG зображення заданого розміру
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestStorage(t *testing.T) storage.BlobStorage {
+	blobStorage, err := storage.NewLocalStorage(t.TempDir(), "/images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blobStorage
+}
+
+func TestUploadImage_Success(t *testing.T) {
+	mockImageRepo := new(MockProductImageRepository)
+	mockProductRepo := new(MockProductRepository)
+	blobStorage := newTestStorage(t)
+	service := NewService(mockImageRepo, mockProductRepo, blobStorage, Config{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockImageRepo.On("ListByProduct", ctx, productID).Return([]*models.ProductImage{}, nil).Once()
+	created := &models.ProductImage{}
+	mockImageRepo.On("Create", ctx, mock.AnythingOfType("*models.ProductImage")).Return(nil).Run(func(args mock.Arguments) {
+		*created = *args.Get(1).(*models.ProductImage)
+	})
+	mockImageRepo.On("ListByProduct", ctx, productID).Return([]*models.ProductImage{created}, nil)
+	mockImageRepo.On("SetPrimaryURL", ctx, productID, mock.MatchedBy(func(url *string) bool {
+		return url != nil && strings.HasSuffix(*url, "/medium.jpg")
+	})).Return(nil)
+
+	img, err := service.UploadImage(ctx, productID, UploadImageRequest{
+		File:    bytes.NewReader(testPNG(t, 1600, 800)),
+		AltText: " Front view ",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Front view", *img.AltText)
+	assert.Equal(t, 0, img.SortOrder)
+	assert.Equal(t, 1600, img.Width)
+	// оригінал та JPEG і WebP для кожного з трьох розмірів
+	assert.Len(t, img.Variants, 7)
+
+	sizes := map[string][2]int{}
+	formats := map[string]int{}
+	for _, v := range img.Variants {
+		formats[v.Format]++
+		sizes[v.Name] = [2]int{v.Width, v.Height}
+		assert.True(t, strings.HasPrefix(v.URL, "/images/products/"+productID.String()))
+
+		// файл варіанту збережено та він декодується
+		f, err := blobStorage.Open(ctx, v.Key)
+		assert.NoError(t, err)
+		_, format, err := image.DecodeConfig(f)
+		f.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, v.Format, format)
+	}
+	assert.Equal(t, [2]int{1600, 800}, sizes[models.ImageVariantOriginal])
+	assert.Equal(t, [2]int{1200, 600}, sizes[models.ImageVariantLarge])
+	assert.Equal(t, [2]int{600, 300}, sizes[models.ImageVariantMedium])
+	assert.Equal(t, [2]int{200, 100}, sizes[models.ImageVariantThumbnail])
+	assert.Equal(t, 3, formats["webp"])
+	assert.Equal(t, 3, formats["jpeg"])
+	mockImageRepo.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestUploadImage_NoUpscale(t *testing.T) {
+	mockImageRepo := new(MockProductImageRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockImageRepo, mockProductRepo, newTestStorage(t), Config{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	sortOrder := 3
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockImageRepo.On("Create", ctx, mock.AnythingOfType("*models.ProductImage")).Return(nil)
+	mockImageRepo.On("ListByProduct", ctx, productID).Return([]*models.ProductImage{}, nil)
+	mockImageRepo.On("SetPrimaryURL", ctx, productID, (*string)(nil)).Return(nil)
+
+	img, err := service.UploadImage(ctx, productID, UploadImageRequest{
+		File:      bytes.NewReader(testPNG(t, 100, 50)),
+		SortOrder: &sortOrder,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, img.SortOrder)
+	assert.Nil(t, img.AltText)
+	for _, v := range img.Variants {
+		assert.Equal(t, 100, v.Width)
+		assert.Equal(t, 50, v.Height)
+	}
+}
+
+func TestUploadImage_TooLarge(t *testing.T) {
+	mockImageRepo := new(MockProductImageRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockImageRepo, mockProductRepo, newTestStorage(t), Config{MaxUploadBytes: 100})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+
+	img, err := service.UploadImage(ctx, productID, UploadImageRequest{
+		File: bytes.NewReader(testPNG(t, 64, 64)),
+	})
+
+	assert.Nil(t, img)
+	assert.Equal(t, ErrImageTooLarge, err)
+	mockImageRepo.AssertNotCalled(t, "Create")
+}
+
+func TestUploadImage_UnsupportedFormat(t *testing.T) {
+	mockImageRepo := new(MockProductImageRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockImageRepo, mockProductRepo, newTestStorage(t), Config{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+
+	img, err := service.UploadImage(ctx, productID, UploadImageRequest{
+		File: strings.NewReader("definitely not an image"),
+	})
+
+	assert.Nil(t, img)
+	assert.Equal(t, ErrUnsupportedFormat, err)
+	mockImageRepo.AssertNotCalled(t, "Create")
+}
+
+func TestUploadImage_ProductNotFound(t *testing.T) {
+	mockImageRepo := new(MockProductImageRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockImageRepo, mockProductRepo, newTestStorage(t), Config{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	mockProductRepo.On("GetById", ctx, productID).Return(nil, errors.New("not found"))
+
+	img, err := service.UploadImage(ctx, productID, UploadImageRequest{
+		File: bytes.NewReader(testPNG(t, 10, 10)),
+	})
+
+	assert.Nil(t, img)
+	assert.Equal(t, ErrProductNotFound, err)
+}
+
+func TestUpdateImage_NotFound(t *testing.T) {
+	mockImageRepo := new(MockProductImageRepository)
+	service := NewService(mockImageRepo, new(MockProductRepository), newTestStorage(t), Config{})
+	ctx := context.Background()
+
+	productID, imageID := uuid.New(), uuid.New()
+	altText := "new"
+	mockImageRepo.On("GetByID", ctx, productID, imageID).Return(nil, nil)
+
+	img, err := service.UpdateImage(ctx, productID, imageID, UpdateImageRequest{AltText: &altText})
+
+	assert.Nil(t, img)
+	assert.Equal(t, ErrImageNotFound, err)
+	mockImageRepo.AssertNotCalled(t, "Update")
+}
+
+func TestDeleteImage_Success(t *testing.T) {
+	mockImageRepo := new(MockProductImageRepository)
+	blobStorage := newTestStorage(t)
+	service := NewService(mockImageRepo, new(MockProductRepository), blobStorage, Config{})
+	ctx := context.Background()
+
+	productID, imageID := uuid.New(), uuid.New()
+	key := "products/" + productID.String() + "/" + imageID.String() + "/original.png"
+	assert.NoError(t, blobStorage.Put(ctx, key, strings.NewReader("data")))
+
+	existing := &models.ProductImage{
+		ID:        imageID,
+		ProductID: productID,
+		Variants:  []models.ImageVariant{{Name: models.ImageVariantOriginal, Key: key}},
+	}
+	mockImageRepo.On("GetByID", ctx, productID, imageID).Return(existing, nil)
+	mockImageRepo.On("Delete", ctx, productID, imageID).Return(nil)
+	mockImageRepo.On("ListByProduct", ctx, productID).Return([]*models.ProductImage{}, nil)
+	mockImageRepo.On("SetPrimaryURL", ctx, productID, (*string)(nil)).Return(nil)
+
+	err := service.DeleteImage(ctx, productID, imageID)
+
+	assert.NoError(t, err)
+	_, err = blobStorage.Open(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	mockImageRepo.AssertExpectations(t)
+}
+
+func TestDeleteImage_RepoNotFound(t *testing.T) {
+	mockImageRepo := new(MockProductImageRepository)
+	service := NewService(mockImageRepo, new(MockProductRepository), newTestStorage(t), Config{})
+	ctx := context.Background()
+
+	productID, imageID := uuid.New(), uuid.New()
+	mockImageRepo.On("GetByID", ctx, productID, imageID).Return(&models.ProductImage{ID: imageID, ProductID: productID}, nil)
+	mockImageRepo.On("Delete", ctx, productID, imageID).Return(sql.ErrNoRows)
+
+	err := service.DeleteImage(ctx, productID, imageID)
+
+	assert.Equal(t, ErrImageNotFound, err)
+}
+
+func TestOpenFile_RejectsForeignKeys(t *testing.T) {
+	service := NewService(new(MockProductImageRepository), new(MockProductRepository), newTestStorage(t), Config{})
+
+	file, err := service.OpenFile(context.Background(), "downloads/secret.zip")
+
+	assert.Nil(t, file)
+	assert.Equal(t, ErrImageNotFound, err)
+}
+
+func TestFitSize(t *testing.T) {
+	w, h := fitSize(4000, 3000, 1200)
+	assert.Equal(t, 1200, w)
+	assert.Equal(t, 900, h)
+
+	w, h = fitSize(300, 1200, 600)
+	assert.Equal(t, 150, w)
+	assert.Equal(t, 600, h)
+
+	w, h = fitSize(100, 80, 600)
+	assert.Equal(t, 100, w)
+	assert.Equal(t, 80, h)
+}
@@ -0,0 +1,109 @@
+package media
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+
+	"github.com/HugoSmits86/nativewebp"
+)
+
+// VariantEncoder кодує зменшені варіанти зображення у конкретний формат.
+// За замовчуванням кожен варіант зберігається у JPEG та WebP (Config.Encoders).
+type VariantEncoder interface {
+	Format() string
+	Extension() string
+	Encode(w io.Writer, img image.Image) error
+}
+
+// jpegEncoder кодувальник JPEG з білим фоном для прозорих зображень
+type jpegEncoder struct {
+	quality int
+}
+
+func (e jpegEncoder) Format() string    { return "jpeg" }
+func (e jpegEncoder) Extension() string { return ".jpg" }
+
+// Encode кодує зображення у JPEG, накладаючи його на білий фон
+func (e jpegEncoder) Encode(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	dst := image.NewRGBA(b)
+	draw.Draw(dst, b, &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(dst, b, img, b.Min, draw.Over)
+	return jpeg.Encode(w, dst, &jpeg.Options{Quality: e.quality})
+}
+
+// webpEncoder кодувальник WebP без втрат (VP8L) на чистому Go; прозорість зберігається
+type webpEncoder struct{}
+
+func (e webpEncoder) Format() string    { return "webp" }
+func (e webpEncoder) Extension() string { return ".webp" }
+
+// Encode кодує зображення у WebP
+func (e webpEncoder) Encode(w io.Writer, img image.Image) error {
+	return nativewebp.Encode(w, img, nil)
+}
+
+// fitSize обчислює розміри, що вписуються у квадрат maxSize зі збереженням пропорцій.
+// Зображення ніколи не збільшується.
+func fitSize(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, max(1, height*maxSize/width)
+	}
+	return max(1, width*maxSize/height), maxSize
+}
+
+// toRGBA перетворює зображення у RGBA з початком координат у (0, 0)
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return dst
+}
+
+// resize зменшує зображення до заданих розмірів усередненням пікселів (box filter)
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw == width && sh == height {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * sh / height
+		y1 := max((y+1)*sh/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * sw / width
+			x1 := max((x+1)*sw/width, x0+1)
+
+			// усереднення блоку вихідних пікселів
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				off := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(src.Pix[off])
+					g += uint32(src.Pix[off+1])
+					b += uint32(src.Pix[off+2])
+					a += uint32(src.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+
+			d := y*dst.Stride + x*4
+			dst.Pix[d] = uint8(r / n)
+			dst.Pix[d+1] = uint8(g / n)
+			dst.Pix[d+2] = uint8(b / n)
+			dst.Pix[d+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,19 @@
+package merchandising
+
+import "github.com/google/uuid"
+
+// DTO структури для правил мерчандайзингу
+
+type CreateSynonymSetRequest struct {
+	Terms []string `json:"terms" validate:"required,min=2,dive,required"`
+}
+
+type SetBoostRequest struct {
+	Factor float64 `json:"factor" validate:"required,gt=0,lte=100"`
+}
+
+type CreatePinRequest struct {
+	Query     string    `json:"query" validate:"required,max=255"`
+	ProductID uuid.UUID `json:"product_id" validate:"required,uuid"`
+	Position  int       `json:"position" validate:"omitempty,min=1"`
+}
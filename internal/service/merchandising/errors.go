@@ -0,0 +1,18 @@
+package merchandising
+
+import "errors"
+
+// помилки пов'язані з правилами мерчандайзингу
+var (
+	// Validation errors
+	ErrNotEnoughTerms  = errors.New("synonym set must contain at least two distinct terms")
+	ErrInvalidBoost    = errors.New("boost factor must be greater than 0 and at most 100")
+	ErrQueryRequired   = errors.New("pin query is required")
+	ErrInvalidPosition = errors.New("pin position must be greater than 0")
+
+	// Logic errors
+	ErrProductNotFound    = errors.New("product not found")
+	ErrSynonymSetNotFound = errors.New("synonym set not found")
+	ErrBoostNotFound      = errors.New("boost not found")
+	ErrPinNotFound        = errors.New("pin not found")
+)
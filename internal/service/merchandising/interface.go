@@ -0,0 +1,21 @@
+package merchandising
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// MerchandisingService інтерфейс для керування правилами релевантності пошуку
+type MerchandisingService interface {
+	CreateSynonymSet(ctx context.Context, req CreateSynonymSetRequest) (*models.SynonymSet, error)
+	ListSynonymSets(ctx context.Context) ([]*models.SynonymSet, error)
+	DeleteSynonymSet(ctx context.Context, id uuid.UUID) error
+	SetBoost(ctx context.Context, productID uuid.UUID, req SetBoostRequest) (*models.ProductBoost, error)
+	ListBoosts(ctx context.Context) ([]*models.ProductBoost, error)
+	DeleteBoost(ctx context.Context, productID uuid.UUID) error
+	CreatePin(ctx context.Context, req CreatePinRequest) (*models.SearchPin, error)
+	ListPins(ctx context.Context, query string) ([]*models.SearchPin, error)
+	DeletePin(ctx context.Context, id uuid.UUID) error
+}
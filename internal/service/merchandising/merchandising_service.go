@@ -0,0 +1,181 @@
+package merchandising
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/google/uuid"
+)
+
+// максимальний коефіцієнт підсилення
+const maxBoostFactor = 100
+
+type service struct {
+	searchRuleRepo repository.SearchRuleRepository
+	productRepo    repository.ProductRepository
+}
+
+func NewService(searchRuleRepo repository.SearchRuleRepository, productRepo repository.ProductRepository) MerchandisingService {
+	return &service{searchRuleRepo: searchRuleRepo,
+		productRepo: productRepo}
+}
+
+// CreateSynonymSet створення набору синонімів
+func (s *service) CreateSynonymSet(ctx context.Context, req CreateSynonymSetRequest) (*models.SynonymSet, error) {
+	// нормалізація та видалення дублікатів
+	seen := map[string]bool{}
+	terms := []string{}
+	for _, term := range req.Terms {
+		term = normalizeQuery(term)
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+
+	// валідація
+	if len(terms) < 2 {
+		return nil, ErrNotEnoughTerms
+	}
+
+	// створення набору
+	set := &models.SynonymSet{Terms: terms}
+	if err := s.searchRuleRepo.CreateSynonymSet(ctx, set); err != nil {
+		return nil, fmt.Errorf("failed to create synonym set: %w", err)
+	}
+	return set, nil
+}
+
+// ListSynonymSets повертає всі набори синонімів
+func (s *service) ListSynonymSets(ctx context.Context) ([]*models.SynonymSet, error) {
+	sets, err := s.searchRuleRepo.ListSynonymSets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list synonym sets: %w", err)
+	}
+	if sets == nil {
+		sets = []*models.SynonymSet{}
+	}
+	return sets, nil
+}
+
+// DeleteSynonymSet видалення набору синонімів
+func (s *service) DeleteSynonymSet(ctx context.Context, id uuid.UUID) error {
+	if err := s.searchRuleRepo.DeleteSynonymSet(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSynonymSetNotFound
+		}
+		return fmt.Errorf("failed to delete synonym set: %w", err)
+	}
+	return nil
+}
+
+// SetBoost встановлення коефіцієнта підсилення продукту
+func (s *service) SetBoost(ctx context.Context, productID uuid.UUID, req SetBoostRequest) (*models.ProductBoost, error) {
+	// валідація
+	if req.Factor <= 0 || req.Factor > maxBoostFactor {
+		return nil, ErrInvalidBoost
+	}
+
+	// перевірка продукту на існування
+	if _, err := s.productRepo.GetById(ctx, productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	// встановлення підсилення
+	boost := &models.ProductBoost{
+		ProductID: productID,
+		Factor:    req.Factor,
+	}
+	if err := s.searchRuleRepo.UpsertBoost(ctx, boost); err != nil {
+		return nil, fmt.Errorf("failed to set boost: %w", err)
+	}
+	return boost, nil
+}
+
+// ListBoosts повертає всі коефіцієнти підсилення
+func (s *service) ListBoosts(ctx context.Context) ([]*models.ProductBoost, error) {
+	boosts, err := s.searchRuleRepo.ListBoosts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list boosts: %w", err)
+	}
+	if boosts == nil {
+		boosts = []*models.ProductBoost{}
+	}
+	return boosts, nil
+}
+
+// DeleteBoost видалення коефіцієнта підсилення
+func (s *service) DeleteBoost(ctx context.Context, productID uuid.UUID) error {
+	if err := s.searchRuleRepo.DeleteBoost(ctx, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrBoostNotFound
+		}
+		return fmt.Errorf("failed to delete boost: %w", err)
+	}
+	return nil
+}
+
+// CreatePin закріплення продукту за пошуковим запитом
+func (s *service) CreatePin(ctx context.Context, req CreatePinRequest) (*models.SearchPin, error) {
+	// валідація
+	query := normalizeQuery(req.Query)
+	if query == "" {
+		return nil, ErrQueryRequired
+	}
+	if req.Position < 0 {
+		return nil, ErrInvalidPosition
+	}
+	if req.Position == 0 {
+		req.Position = 1
+	}
+
+	// перевірка продукту на існування
+	if _, err := s.productRepo.GetById(ctx, req.ProductID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	// створення закріплення
+	pin := &models.SearchPin{
+		Query:     query,
+		ProductID: req.ProductID,
+		Position:  req.Position,
+	}
+	if err := s.searchRuleRepo.CreatePin(ctx, pin); err != nil {
+		return nil, fmt.Errorf("failed to create pin: %w", err)
+	}
+	return pin, nil
+}
+
+// ListPins повертає закріплення для запиту (або всі)
+func (s *service) ListPins(ctx context.Context, query string) ([]*models.SearchPin, error) {
+	pins, err := s.searchRuleRepo.ListPins(ctx, normalizeQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pins: %w", err)
+	}
+	if pins == nil {
+		pins = []*models.SearchPin{}
+	}
+	return pins, nil
+}
+
+// DeletePin видалення закріплення
+func (s *service) DeletePin(ctx context.Context, id uuid.UUID) error {
+	if err := s.searchRuleRepo.DeletePin(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPinNotFound
+		}
+		return fmt.Errorf("failed to delete pin: %w", err)
+	}
+	return nil
+}
+
+// normalizeQuery приводить запит до вигляду, в якому він зберігається
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.TrimSpace(query))
+}
@@ -0,0 +1,244 @@
+package merchandising
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSearchRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRuleRepository) CreateSynonymSet(ctx context.Context, set *models.SynonymSet) error {
+	args := m.Called(ctx, set)
+	return args.Error(0)
+}
+
+func (m *MockSearchRuleRepository) ListSynonymSets(ctx context.Context) ([]*models.SynonymSet, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SynonymSet), args.Error(1)
+}
+
+func (m *MockSearchRuleRepository) DeleteSynonymSet(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSearchRuleRepository) GetSynonyms(ctx context.Context, term string) ([]string, error) {
+	args := m.Called(ctx, term)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSearchRuleRepository) UpsertBoost(ctx context.Context, boost *models.ProductBoost) error {
+	args := m.Called(ctx, boost)
+	return args.Error(0)
+}
+
+func (m *MockSearchRuleRepository) ListBoosts(ctx context.Context) ([]*models.ProductBoost, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductBoost), args.Error(1)
+}
+
+func (m *MockSearchRuleRepository) DeleteBoost(ctx context.Context, productID uuid.UUID) error {
+	args := m.Called(ctx, productID)
+	return args.Error(0)
+}
+
+func (m *MockSearchRuleRepository) CreatePin(ctx context.Context, pin *models.SearchPin) error {
+	args := m.Called(ctx, pin)
+	return args.Error(0)
+}
+
+func (m *MockSearchRuleRepository) ListPins(ctx context.Context, query string) ([]*models.SearchPin, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SearchPin), args.Error(1)
+}
+
+func (m *MockSearchRuleRepository) DeletePin(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+func TestCreateSynonymSet_Success(t *testing.T) {
+	mockRepo := new(MockSearchRuleRepository)
+	service := NewService(mockRepo, new(MockProductRepository))
+	ctx := context.Background()
+
+	mockRepo.On("CreateSynonymSet", ctx, mock.AnythingOfType("*models.SynonymSet")).Return(nil)
+
+	set, err := service.CreateSynonymSet(ctx, CreateSynonymSetRequest{
+		Terms: []string{" TV ", "television", "tv"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tv", "television"}, []string(set.Terms))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateSynonymSet_NotEnoughTerms(t *testing.T) {
+	mockRepo := new(MockSearchRuleRepository)
+	service := NewService(mockRepo, new(MockProductRepository))
+	ctx := context.Background()
+
+	set, err := service.CreateSynonymSet(ctx, CreateSynonymSetRequest{
+		Terms: []string{"tv", "TV"},
+	})
+
+	assert.Nil(t, set)
+	assert.Equal(t, ErrNotEnoughTerms, err)
+	mockRepo.AssertNotCalled(t, "CreateSynonymSet")
+}
+
+func TestSetBoost_InvalidFactor(t *testing.T) {
+	mockRepo := new(MockSearchRuleRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo)
+	ctx := context.Background()
+
+	boost, err := service.SetBoost(ctx, uuid.New(), SetBoostRequest{Factor: 0})
+
+	assert.Nil(t, boost)
+	assert.Equal(t, ErrInvalidBoost, err)
+	mockProductRepo.AssertNotCalled(t, "GetById")
+	mockRepo.AssertNotCalled(t, "UpsertBoost")
+}
+
+func TestCreatePin_Success(t *testing.T) {
+	mockRepo := new(MockSearchRuleRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo)
+	ctx := context.Background()
+
+	productID := uuid.New()
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	mockRepo.On("CreatePin", ctx, mock.AnythingOfType("*models.SearchPin")).Return(nil)
+
+	pin, err := service.CreatePin(ctx, CreatePinRequest{Query: " Laptop ", ProductID: productID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "laptop", pin.Query)
+	assert.Equal(t, 1, pin.Position)
+	mockRepo.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestDeletePin_NotFound(t *testing.T) {
+	mockRepo := new(MockSearchRuleRepository)
+	service := NewService(mockRepo, new(MockProductRepository))
+	ctx := context.Background()
+
+	pinID := uuid.New()
+	mockRepo.On("DeletePin", ctx, pinID).Return(sql.ErrNoRows)
+
+	err := service.DeletePin(ctx, pinID)
+
+	assert.Equal(t, ErrPinNotFound, err)
+	mockRepo.AssertExpectations(t)
+}
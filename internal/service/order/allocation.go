@@ -0,0 +1,94 @@
+package order
+
+import (
+	"sort"
+	"strings"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// allocateStock вирішує, з яких складів відвантажити позицію замовлення.
+// Склади впорядковуються так: спершу склади країни доставки, далі - з більшим залишком.
+// Якщо один склад може відвантажити всю позицію, обирається перший такий склад,
+// інакше позиція ділиться між складами в тому ж порядку.
+// Залишки в levels зменшуються, щоб наступні позиції бачили актуальну наявність.
+// Повертає nil, якщо загального залишку недостатньо
+func allocateStock(levels []*models.LocationStock, country string, itemID uuid.UUID, quantity int) []*models.StockAllocation {
+	ordered := make([]*models.LocationStock, 0, len(levels))
+	total := 0
+	for _, level := range levels {
+		if level.Quantity > 0 {
+			ordered = append(ordered, level)
+			total += level.Quantity
+		}
+	}
+	if total < quantity {
+		return nil
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		li, lj := isLocal(ordered[i], country), isLocal(ordered[j], country)
+		if li != lj {
+			return li
+		}
+		if ordered[i].Quantity != ordered[j].Quantity {
+			return ordered[i].Quantity > ordered[j].Quantity
+		}
+		return ordered[i].LocationCode < ordered[j].LocationCode
+	})
+
+	// один склад, що покриває всю позицію
+	for _, level := range ordered {
+		if level.Quantity >= quantity {
+			level.Quantity -= quantity
+			return []*models.StockAllocation{newAllocation(level, itemID, quantity)}
+		}
+	}
+
+	// розподіл між кількома складами
+	var allocations []*models.StockAllocation
+	remaining := quantity
+	for _, level := range ordered {
+		if remaining == 0 {
+			break
+		}
+		take := min(level.Quantity, remaining)
+		level.Quantity -= take
+		remaining -= take
+		allocations = append(allocations, newAllocation(level, itemID, take))
+	}
+	return allocations
+}
+
+// allocateAvailable розподіляє позицію, яку можна продати під замовлення:
+// якщо загального залишку недостатньо, відвантажується все наявне.
+// Повертає розподіл та відвантажену кількість
+func allocateAvailable(levels []*models.LocationStock, country string, itemID uuid.UUID, quantity int) ([]*models.StockAllocation, int) {
+	total := 0
+	for _, level := range levels {
+		if level.Quantity > 0 {
+			total += level.Quantity
+		}
+	}
+	allocated := min(total, quantity)
+	if allocated == 0 {
+		return nil, 0
+	}
+	return allocateStock(levels, country, itemID, allocated), allocated
+}
+
+// isLocal перевіряє, чи склад знаходиться в країні доставки
+func isLocal(level *models.LocationStock, country string) bool {
+	return level.Country != nil && strings.EqualFold(*level.Country, strings.TrimSpace(country))
+}
+
+func newAllocation(level *models.LocationStock, itemID uuid.UUID, quantity int) *models.StockAllocation {
+	return &models.StockAllocation{
+		OrderItemID:  itemID,
+		ProductID:    level.ProductID,
+		LocationID:   level.LocationID,
+		LocationCode: level.LocationCode,
+		Quantity:     quantity,
+	}
+}
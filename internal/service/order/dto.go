@@ -16,15 +16,24 @@ type CreateOrderRequest struct {
 	Items          []CreateOrderItemRequest `json:"items" validate:"required,dive"`
 	ShippingAdress models.ShippingAddress   `json:"shipping_address" validate:"required"`
 	PaymentMethod  string                   `json:"payment_method" validate:"required,oneof=card cash"`
+	// OrderID ID замовлення від внутрішнього викликача (підписки): замовлення з цим ID створюється
+	// лише один раз, повтор повертає вже створене
+	OrderID *uuid.UUID `json:"-"`
 }
 
 type UpdateOrderRequest struct {
-	Status string `json:"status" validate:"required,oneof=pending paid shipped canceled delivered"`
+	Status string `json:"status" validate:"required,oneof=pending confirmed paid shipped canceled cancelled delivered"`
 }
 
 type OrderListResponse struct {
 	Order []*models.Order `json:"order"`
-	Total int             `json:"total"`
+	// Total кількість усіх замовлень за фільтром (для великих наборів - оцінка)
+	Total          int    `json:"total"`
+	TotalEstimated bool   `json:"total_estimated,omitempty"`
+	Limit          int    `json:"limit"`
+	Offset         int    `json:"offset"`
+	NextCursor     string `json:"next_cursor,omitempty"`
+	PrevCursor     string `json:"prev_cursor,omitempty"`
 }
 
 type OrderFilter struct {
@@ -33,4 +42,6 @@ type OrderFilter struct {
 	Limit   int        `json:"limit" validate:"required,min=1,max=100"`
 	Offset  int        `json:"offset" validate:"gte=0"`
 	OrderBy string     `json:"order_by" validate:"omitempty,oneof=created_at_asc created_as_desc status_asc status_desc"`
+	// Cursor непрозорий токен сторінки з next_cursor/prev_cursor; має пріоритет над Offset
+	Cursor string `json:"cursor"`
 }
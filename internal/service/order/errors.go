@@ -16,6 +16,7 @@ var (
 	ErrInvalidPayment          = errors.New("invalid payment method")
 	ErrInvalidStatus           = errors.New("invalid order status")
 	ErrOrderEmpty              = errors.New("order has no items")
+	ErrInvalidCursor           = errors.New("invalid or expired page cursor")
 
 	//Order item errors
 	ErrOrderMustContainItem   = errors.New("order must contain at least one item")
@@ -27,9 +28,10 @@ var (
 	ErrCannotCancelDelivered = errors.New("cannot cancel a delivered order")
 
 	//logic errors
-	ErrOrderNotFound     = errors.New("order not found")
-	ErrProductNotFound   = errors.New("product not found")
-	ErrItemNotFound      = errors.New("cart item not found")
-	ErrInsufficientStock = errors.New("insufficient stock for product")
-	ErrCannotCancelPaid  = errors.New("cannot cancel a paid or shipped order")
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrProductNotFound    = errors.New("product not found")
+	ErrProductUnavailable = errors.New("product is not available for purchase")
+	ErrItemNotFound       = errors.New("cart item not found")
+	ErrInsufficientStock  = errors.New("insufficient stock for product")
+	ErrCannotCancelPaid   = errors.New("cannot cancel a paid or shipped order")
 )
@@ -2,22 +2,32 @@ package order
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/pagination"
 	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
 	"github.com/google/uuid"
 )
 
+// сортування сторінок замовлень (від нових до старих)
+const orderSort = "newest"
+
 type service struct {
-	orderRepo   repository.OrderRepository
-	productRepo repository.ProductRepository
+	orderRepo     repository.OrderRepository
+	productRepo   repository.ProductRepository
+	inventoryRepo repository.InventoryRepository
+	bundleRepo    repository.BundleRepository
 }
 
-func NewService(orderRepo repository.OrderRepository, productRepo repository.ProductRepository) OrderService {
+func NewService(orderRepo repository.OrderRepository, productRepo repository.ProductRepository, inventoryRepo repository.InventoryRepository, bundleRepo repository.BundleRepository) OrderService {
 	return &service{orderRepo: orderRepo,
-		productRepo: productRepo}
+		productRepo:   productRepo,
+		inventoryRepo: inventoryRepo,
+		bundleRepo:    bundleRepo}
 }
 
 // CancelOrder скасування замовлення
@@ -54,10 +64,6 @@ func (s *service) CreateOrder(ctx context.Context, userID uuid.UUID, req CreateO
 	if userID == uuid.Nil {
 		return nil, ErrUserIDRequired
 	}
-	if req.ShippingAdress.City == "" || req.ShippingAdress.Country == "" ||
-		req.ShippingAdress.PostalCode == "" || req.ShippingAdress.Street == "" {
-		return nil, ErrShippingAddressRequired
-	}
 	if req.PaymentMethod != "cash" && req.PaymentMethod != "card" {
 		return nil, ErrPaymentMethodInvalid
 	}
@@ -66,9 +72,25 @@ func (s *service) CreateOrder(ctx context.Context, userID uuid.UUID, req CreateO
 		return nil, ErrOrderMustContainItem
 	}
 
+	// повтор створення з тим самим ID повертає вже створене замовлення
+	orderID := uuid.New()
+	if req.OrderID != nil {
+		existing, err := s.orderRepo.GetById(ctx, *req.OrderID)
+		if err == nil {
+			if existing.UserID == nil || *existing.UserID != userID {
+				return nil, ErrOrderNotFound
+			}
+			return existing, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get order: %w", err)
+		}
+		orderID = *req.OrderID
+	}
+
 	// створення замовлення
 	order := &models.Order{
-		ID:              uuid.New(),
+		ID:              orderID,
 		UserID:          &userID,
 		Status:          "pending",
 		ShippingAddress: req.ShippingAdress,
@@ -77,35 +99,203 @@ func (s *service) CreateOrder(ctx context.Context, userID uuid.UUID, req CreateO
 
 	var total float64
 	items := make([]*models.OrderItem, len(req.Items))
+	products := make(map[uuid.UUID]*models.Product, len(req.Items))
+	var bundleIDs []uuid.UUID
+	physical := false
 
 	for i, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, ErrInvalidProductQuantity
+		}
 		// додавання товарув у замовлення
 		product, err := s.productRepo.GetById(ctx, item.ProductID)
-		if err != nil {
-			return nil, fmt.Errorf("product not found: %w", err)
+		if err != nil || product == nil {
+			return nil, ErrProductNotFound
+		}
+		// купити можна тільки опублікований товар
+		if !product.IsVisible() {
+			return nil, ErrProductUnavailable
+		}
+		products[product.ID] = product
+		if product.IsPhysical() {
+			physical = true
+		}
+		if product.IsBundle {
+			bundleIDs = append(bundleIDs, product.ID)
 		}
 
+		// ціна з урахуванням розпродажу
+		price := product.EffectivePrice(time.Now())
 		items[i] = &models.OrderItem{
 			ID:        uuid.New(),
 			OrderID:   order.ID,
 			ProductID: item.ProductID,
 			Quantity:  item.Quantity,
-			Price:     product.Price,
+			Price:     price,
 			CreatedAt: time.Now(),
 		}
-		total += product.Price * float64(item.Quantity)
+		total += price * float64(item.Quantity)
 	}
 	order.TotalAmount = total
 
+	// адреса доставки потрібна лише для фізичних товарів
+	if physical && (req.ShippingAdress.City == "" || req.ShippingAdress.Country == "" ||
+		req.ShippingAdress.PostalCode == "" || req.ShippingAdress.Street == "") {
+		return nil, ErrShippingAddressRequired
+	}
+
+	// склад комплектів
+	components, err := s.bundleComponents(ctx, items, bundleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// розподіл позицій по складах
+	allocations, err := s.allocate(ctx, items, products, components, req.ShippingAdress.Country)
+	if err != nil {
+		return nil, err
+	}
+
 	// створення заказу
-	if err := s.orderRepo.Create(ctx, order, items); err != nil {
+	if err := s.orderRepo.Create(ctx, order, items, allocations); err != nil {
+		// залишок змінився між розподілом та списанням
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInsufficientStock
+		}
 		fmt.Printf("Service lvl CreateOrder error: %+v\n", err)
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
+	setItems(order, items)
 	return order, nil
 }
 
+// bundleComponents повертає компоненти комплектів замовлення та записує склад комплекту в його позиції.
+// Комплект без компонентів або з видаленим компонентом купити не можна
+func (s *service) bundleComponents(ctx context.Context, items []*models.OrderItem, bundleIDs []uuid.UUID) (map[uuid.UUID][]*models.BundleComponent, error) {
+	if len(bundleIDs) == 0 {
+		return nil, nil
+	}
+
+	list, err := s.bundleRepo.ListComponents(ctx, bundleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	components := make(map[uuid.UUID][]*models.BundleComponent, len(bundleIDs))
+	for _, c := range list {
+		if c.DeletedAt != nil {
+			return nil, ErrProductUnavailable
+		}
+		components[c.BundleID] = append(components[c.BundleID], c)
+	}
+
+	for _, item := range items {
+		bundle, ok := components[item.ProductID]
+		if !ok {
+			continue
+		}
+		for _, c := range bundle {
+			item.Components = append(item.Components, models.OrderItemComponent{
+				ProductID:         c.ComponentID,
+				Name:              c.Name,
+				SKU:               c.SKU,
+				QuantityPerBundle: c.Quantity,
+				Quantity:          c.Quantity * item.Quantity,
+			})
+		}
+	}
+	for _, id := range bundleIDs {
+		if len(components[id]) == 0 {
+			return nil, ErrProductUnavailable
+		}
+	}
+	return components, nil
+}
+
+// allocate визначає склади для кожної позиції замовлення; цифрові позиції не розподіляються.
+// Для комплекту розподіляється кожен компонент, і комплект без повного набору компонентів не продається.
+// Якщо політика продукту дозволяє продаж під замовлення, відвантажується наявна кількість,
+// а решта позначається як backordered з очікуваною датою відвантаження
+func (s *service) allocate(ctx context.Context, items []*models.OrderItem, products map[uuid.UUID]*models.Product, components map[uuid.UUID][]*models.BundleComponent, country string) ([]*models.StockAllocation, error) {
+	productIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if bundle, ok := components[item.ProductID]; ok {
+			for _, c := range bundle {
+				productIDs = append(productIDs, c.ComponentID)
+			}
+			continue
+		}
+		if products[item.ProductID].IsPhysical() {
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	levels, err := s.inventoryRepo.ListStockLevels(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock levels: %w", err)
+	}
+	byProduct := make(map[uuid.UUID][]*models.LocationStock)
+	for _, level := range levels {
+		byProduct[level.ProductID] = append(byProduct[level.ProductID], level)
+	}
+
+	var allocations []*models.StockAllocation
+	for _, item := range items {
+		product := products[item.ProductID]
+		if bundle, ok := components[item.ProductID]; ok {
+			for _, c := range bundle {
+				itemAllocations := allocateStock(byProduct[c.ComponentID], country, item.ID, c.Quantity*item.Quantity)
+				if itemAllocations == nil {
+					return nil, ErrInsufficientStock
+				}
+				allocations = append(allocations, itemAllocations...)
+			}
+			continue
+		}
+		if !product.IsPhysical() {
+			continue
+		}
+		if !product.AllowsBackorder() {
+			itemAllocations := allocateStock(byProduct[item.ProductID], country, item.ID, item.Quantity)
+			if itemAllocations == nil {
+				return nil, ErrInsufficientStock
+			}
+			allocations = append(allocations, itemAllocations...)
+			continue
+		}
+
+		itemAllocations, allocated := allocateAvailable(byProduct[item.ProductID], country, item.ID, item.Quantity)
+		allocations = append(allocations, itemAllocations...)
+		if allocated < item.Quantity {
+			item.Backordered = true
+			item.BackorderedQuantity = item.Quantity - allocated
+			item.ExpectedShipDate = product.AvailableAt
+		}
+		// передзамовлення не відвантажується до дати релізу
+		if product.BackorderPolicy == models.BackorderPreorder && product.AvailableAt != nil && product.AvailableAt.After(time.Now()) {
+			item.ExpectedShipDate = product.AvailableAt
+		}
+	}
+	return allocations, nil
+}
+
+// setItems додає позиції до замовлення та обчислює найпізнішу очікувану дату відвантаження
+func setItems(order *models.Order, items []*models.OrderItem) {
+	order.Items = items
+	order.ExpectedShipDate = nil
+	for _, item := range items {
+		if item.ExpectedShipDate == nil {
+			continue
+		}
+		if order.ExpectedShipDate == nil || item.ExpectedShipDate.After(*order.ExpectedShipDate) {
+			order.ExpectedShipDate = item.ExpectedShipDate
+		}
+	}
+}
+
 // GetOrder отримання замовлення
 func (s *service) GetOrder(ctx context.Context, id uuid.UUID) (*models.Order, error) {
 	// валідація
@@ -119,10 +309,18 @@ func (s *service) GetOrder(ctx context.Context, id uuid.UUID) (*models.Order, er
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
 
+	// позиції з ознакою backordered та очікуваними датами відвантаження
+	items, err := s.orderRepo.GetOrderItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order items: %w", err)
+	}
+	setItems(order, items)
+
 	return order, nil
 }
 
-// ListOrder повертає список замовлень
+// ListOrder повертає список замовлень від нових до старих.
+// Фільтрація виконується в запиті, сторінки задаються курсором або зміщенням
 func (s *service) ListOrder(ctx context.Context, filter OrderFilter) (*OrderListResponse, error) {
 	// пагінація
 	if filter.Limit <= 0 {
@@ -135,39 +333,78 @@ func (s *service) ListOrder(ctx context.Context, filter OrderFilter) (*OrderList
 		filter.Offset = 0
 	}
 
-	var orders []*models.Order
-	var err error
+	// статус у фільтрі приймається в обох написаннях
+	status := filter.Status
+	if status == "canceled" {
+		status = "cancelled"
+	}
 
-	// якщо в фільтрах присутствує userID
-	if filter.UserID != nil {
-		// виводимо всі замовлення для користувача
-		orders, err = s.orderRepo.ListByUserID(ctx, *filter.UserID, filter.Limit, filter.Offset)
-	} else {
-		// виводимо всі замовлення
-		orders, err = s.orderRepo.ListAll(ctx, filter.Limit, filter.Offset)
+	repoFilter := models.OrderListFilter{
+		UserID: filter.UserID,
+		Status: status,
+		Limit:  filter.Limit + 1,
+		Offset: filter.Offset,
+	}
+	var cursor *pagination.Cursor
+	if filter.Cursor != "" {
+		var err error
+		cursor, err = pagination.Decode(filter.Cursor, orderSort)
+		if err != nil || !cursor.IsKeyset() {
+			return nil, ErrInvalidCursor
+		}
+		repoFilter.Cursor = cursor
+		repoFilter.Offset = 0
 	}
+
+	// отримання замовлень (на одне більше, щоб знати про наступну сторінку)
+	orders, err := s.orderRepo.List(ctx, repoFilter)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			return nil, ErrInvalidCursor
+		}
 		return nil, fmt.Errorf("failed to list order: %w", err)
 	}
+	orders, page := pagination.Trim(orders, filter.Limit, cursor)
+	if repoFilter.Offset > 0 {
+		page.HasPrev = true
+	}
 
-	// фільтрація замовлень за статусом
-	filtered := []*models.Order{}
-	for _, o := range orders {
-		if filter.Status != "" && o.Status != filter.Status {
-			continue
-		}
-		filtered = append(filtered, o)
+	total, estimated, err := s.orderRepo.Count(ctx, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orders: %w", err)
 	}
 
 	// формування відповіді
 	resp := &OrderListResponse{
-		Order: filtered,
-		Total: len(filtered),
+		Order:          orders,
+		Total:          total,
+		TotalEstimated: estimated,
+		Limit:          filter.Limit,
+		Offset:         repoFilter.Offset,
+	}
+	if len(orders) > 0 {
+		if page.HasNext {
+			last := orders[len(orders)-1]
+			resp.NextCursor = orderCursor(last, false)
+		}
+		if page.HasPrev {
+			resp.PrevCursor = orderCursor(orders[0], true)
+		}
 	}
 
 	return resp, nil
 }
 
+// orderCursor повертає токен сторінки після (або перед) замовленням
+func orderCursor(order *models.Order, backward bool) string {
+	return pagination.Cursor{
+		Sort:     orderSort,
+		Values:   []string{order.CreatedAt.Format(time.RFC3339Nano)},
+		ID:       order.ID.String(),
+		Backward: backward,
+	}.Encode()
+}
+
 // UpdateOrderStatus оновлення статусу замовлення
 func (s *service) UpdateOrderStatus(ctx context.Context, id uuid.UUID, req UpdateOrderRequest) (*models.Order, error) {
 	// валідація
@@ -190,14 +427,30 @@ func (s *service) UpdateOrderStatus(ctx context.Context, id uuid.UUID, req Updat
 	if !validStatus[req.Status] {
 		return nil, fmt.Errorf("invalid status: %s", req.Status)
 	}
+	// у базі скасоване замовлення має статус 'cancelled', від нього залежить повернення товару на склад
+	status := req.Status
+	if status == "canceled" {
+		status = "cancelled"
+	}
 
 	// отримання ID замовлення
 	order, err := s.orderRepo.GetById(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update status: %w", err)
 	}
+	// товар скасованого замовлення вже повернуто на склад, тому відновити замовлення не можна
+	if order != nil && order.Status == "cancelled" && status != "cancelled" {
+		return nil, ErrOrderAlreadyCanceled
+	}
 
 	// оновлення статусу замовлення
-	order.Status = req.Status
-	return order, nil
+	if err := s.orderRepo.UpdateStatus(ctx, id, status); err != nil {
+		// замовлення скасували паралельним запитом
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderAlreadyCanceled
+		}
+		return nil, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return s.orderRepo.GetById(ctx, id)
 }
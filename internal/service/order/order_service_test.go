@@ -2,9 +2,12 @@ package order
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/pagination"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,8 +17,8 @@ type MockOrderRepository struct {
 	mock.Mock
 }
 
-func (m *MockOrderRepository) Create(ctx context.Context, order *models.Order, items []*models.OrderItem) error {
-	args := m.Called(ctx, order, items)
+func (m *MockOrderRepository) Create(ctx context.Context, order *models.Order, items []*models.OrderItem, allocations []*models.StockAllocation) error {
+	args := m.Called(ctx, order, items, allocations)
 	return args.Error(0)
 }
 
@@ -33,22 +36,160 @@ func (m *MockOrderRepository) GetOrderItems(ctx context.Context, orderID uuid.UU
 	}
 	return args.Get(0).([]*models.OrderItem), args.Error(1)
 }
-func (m *MockOrderRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Order, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockOrderRepository) List(ctx context.Context, filter models.OrderListFilter) ([]*models.Order, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.Order), args.Error(1)
 }
-func (m *MockOrderRepository) ListAll(ctx context.Context, limit, offset int) ([]*models.Order, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockOrderRepository) Count(ctx context.Context, filter models.OrderListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockInventoryRepository) CreateLocation(ctx context.Context, location *models.StockLocation) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) GetLocation(ctx context.Context, id uuid.UUID) (*models.StockLocation, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*models.Order), args.Error(1)
+	return args.Get(0).(*models.StockLocation), args.Error(1)
 }
-func (m *MockOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
-	args := m.Called(ctx, id, status)
+func (m *MockInventoryRepository) GetLocationByCode(ctx context.Context, code string) (*models.StockLocation, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryRepository) ListLocations(ctx context.Context) ([]*models.StockLocation, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryRepository) UpdateLocation(ctx context.Context, location *models.StockLocation) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) ListProductStock(ctx context.Context, productID uuid.UUID) ([]*models.LocationStock, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LocationStock), args.Error(1)
+}
+func (m *MockInventoryRepository) ListStockLevels(ctx context.Context, productIDs []uuid.UUID) ([]*models.LocationStock, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LocationStock), args.Error(1)
+}
+func (m *MockInventoryRepository) SetStock(ctx context.Context, locationID, productID uuid.UUID, quantity int, src models.MovementSource) error {
+	args := m.Called(ctx, locationID, productID, quantity, src)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) AdjustStock(ctx context.Context, locationID, productID uuid.UUID, delta int, src models.MovementSource) error {
+	args := m.Called(ctx, locationID, productID, delta, src)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) Transfer(ctx context.Context, productID, fromID, toID uuid.UUID, quantity int, note *string) (uuid.UUID, error) {
+	args := m.Called(ctx, productID, fromID, toID, quantity, note)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+func (m *MockInventoryRepository) ListMovements(ctx context.Context, filter models.StockMovementFilter) ([]*models.StockMovement, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockMovement), args.Error(1)
+}
+func (m *MockInventoryRepository) Reconcile(ctx context.Context, productID uuid.UUID) ([]*models.StockReconciliation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockReconciliation), args.Error(1)
+}
+func (m *MockInventoryRepository) GetReorderSettings(ctx context.Context, productID uuid.UUID) (*models.ReorderSettings, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReorderSettings), args.Error(1)
+}
+func (m *MockInventoryRepository) UpdateReorderSettings(ctx context.Context, settings *models.ReorderSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) ListAlerts(ctx context.Context, filter models.LowStockAlertFilter) ([]*models.LowStockAlert, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LowStockAlert), args.Error(1)
+}
+func (m *MockInventoryRepository) ListUndeliveredAlerts(ctx context.Context, limit int) ([]*models.LowStockAlert, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LowStockAlert), args.Error(1)
+}
+func (m *MockInventoryRepository) MarkAlertsNotified(ctx context.Context, ids []uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) ListReorderNeeded(ctx context.Context) ([]*models.ReorderItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ReorderItem), args.Error(1)
+}
+func (m *MockInventoryRepository) ListOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*models.StockAllocation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockAllocation), args.Error(1)
+}
+
+type MockBundleRepository struct {
+	mock.Mock
+}
+
+func (m *MockBundleRepository) ListComponents(ctx context.Context, bundleIDs []uuid.UUID) ([]*models.BundleComponent, error) {
+	args := m.Called(ctx, bundleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.BundleComponent), args.Error(1)
+}
+func (m *MockBundleRepository) IsComponent(ctx context.Context, productID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, productID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockBundleRepository) SetComponents(ctx context.Context, bundleID uuid.UUID, components []*models.BundleComponent) error {
+	args := m.Called(ctx, bundleID, components)
+	return args.Error(0)
+}
+func (m *MockBundleRepository) Delete(ctx context.Context, bundleID uuid.UUID) error {
+	args := m.Called(ctx, bundleID)
 	return args.Error(0)
 }
 
@@ -77,13 +218,21 @@ func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilt
 	return args.Get(0).([]*models.Product), args.Error(1)
 }
 
-func (m *MockProductRepository) Update(ctx context.Context, product *models.Product) error {
-	args := m.Called(ctx, product)
-	return args.Error(0)
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
 }
 
-func (m *MockProductRepository) UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error {
-	args := m.Called(ctx, id, quantity)
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
 	return args.Error(0)
 }
 
@@ -92,10 +241,51 @@ func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
 func TestGetOrder_Success(t *testing.T) {
 	mockRepo := new(MockOrderRepository)
 	mockRepoProduct := new(MockProductRepository)
-	service := NewService(mockRepo, mockRepoProduct)
+	service := NewService(mockRepo, mockRepoProduct, new(MockInventoryRepository), new(MockBundleRepository))
 	ctx := context.Background()
 	orderID := uuid.New()
 
@@ -105,20 +295,29 @@ func TestGetOrder_Success(t *testing.T) {
 		TotalAmount: 100,
 	}
 
+	shipDate := time.Now().Add(72 * time.Hour)
+	items := []*models.OrderItem{
+		{ID: uuid.New(), OrderID: orderID, Quantity: 1},
+		{ID: uuid.New(), OrderID: orderID, Quantity: 2, Backordered: true, BackorderedQuantity: 2, ExpectedShipDate: &shipDate},
+	}
+
 	mockRepo.On("GetById", ctx, orderID).Return(order, nil)
+	mockRepo.On("GetOrderItems", ctx, orderID).Return(items, nil)
 
 	result, err := service.GetOrder(ctx, orderID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, order.ID, result.ID)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, &shipDate, result.ExpectedShipDate)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestGetOrder_NotFound(t *testing.T) {
 	mockRepo := new(MockOrderRepository)
 	mockRepoProduct := new(MockProductRepository)
-	service := NewService(mockRepo, mockRepoProduct)
+	service := NewService(mockRepo, mockRepoProduct, new(MockInventoryRepository), new(MockBundleRepository))
 	ctx := context.Background()
 	orderID := uuid.New()
 
@@ -134,7 +333,7 @@ func TestGetOrder_NotFound(t *testing.T) {
 func TestListOrder_Success(t *testing.T) {
 	mockRepo := new(MockOrderRepository)
 	mockRepoProduct := new(MockProductRepository)
-	service := NewService(mockRepo, mockRepoProduct)
+	service := NewService(mockRepo, mockRepoProduct, new(MockInventoryRepository), new(MockBundleRepository))
 	ctx := context.Background()
 
 	filter := OrderFilter{
@@ -147,19 +346,76 @@ func TestListOrder_Success(t *testing.T) {
 		{ID: uuid.New(), Status: "completed"},
 	}
 
-	mockRepo.On("ListAll", ctx, filter.Limit, filter.Offset).Return(orders, nil)
+	mockRepo.On("List", ctx, models.OrderListFilter{Limit: 11}).Return(orders, nil)
+	mockRepo.On("Count", ctx, models.OrderListFilter{Limit: 11}).Return(2, false, nil)
 
 	resp, err := service.ListOrder(ctx, filter)
 
 	assert.NoError(t, err)
 	assert.Len(t, resp.Order, 2)
+	assert.Equal(t, 2, resp.Total)
+	assert.Empty(t, resp.NextCursor)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestListOrder_StatusFilterAndNextCursor(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockInventoryRepository), new(MockBundleRepository))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// на одне замовлення більше за limit - є наступна сторінка
+	now := time.Now()
+	orders := []*models.Order{
+		{ID: uuid.New(), Status: "cancelled", CreatedAt: now},
+		{ID: uuid.New(), Status: "cancelled", CreatedAt: now.Add(-time.Hour)},
+		{ID: uuid.New(), Status: "cancelled", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	repoFilter := models.OrderListFilter{UserID: &userID, Status: "cancelled", Limit: 3}
+	mockRepo.On("List", ctx, repoFilter).Return(orders, nil)
+	mockRepo.On("Count", ctx, repoFilter).Return(25, false, nil)
+
+	resp, err := service.ListOrder(ctx, OrderFilter{UserID: &userID, Status: "canceled", Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Order, 2)
+	assert.Equal(t, 25, resp.Total)
+	assert.Empty(t, resp.PrevCursor)
+
+	// наступна сторінка починається після останнього показаного замовлення
+	cursor, err := pagination.Decode(resp.NextCursor, orderSort)
+	assert.NoError(t, err)
+	assert.Equal(t, orders[1].ID.String(), cursor.ID)
+	assert.False(t, cursor.Backward)
+
+	next := models.OrderListFilter{UserID: &userID, Status: "cancelled", Limit: 3, Cursor: cursor}
+	mockRepo.On("List", ctx, next).Return(orders[2:], nil)
+	mockRepo.On("Count", ctx, next).Return(25, false, nil)
+
+	resp, err = service.ListOrder(ctx, OrderFilter{UserID: &userID, Status: "canceled", Limit: 2, Cursor: resp.NextCursor})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Order, 1)
+	assert.Empty(t, resp.NextCursor)
+	assert.NotEmpty(t, resp.PrevCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListOrder_InvalidCursor(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockInventoryRepository), new(MockBundleRepository))
+
+	resp, err := service.ListOrder(context.Background(), OrderFilter{Limit: 10, Cursor: "not-a-cursor"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, ErrInvalidCursor, err)
+	mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
 func TestCancelOrder_Success(t *testing.T) {
 	mockRepo := new(MockOrderRepository)
 	mockRepoProduct := new(MockProductRepository)
-	service := NewService(mockRepo, mockRepoProduct)
+	service := NewService(mockRepo, mockRepoProduct, new(MockInventoryRepository), new(MockBundleRepository))
 	ctx := context.Background()
 	orderID := uuid.New()
 
@@ -176,3 +432,594 @@ func TestCancelOrder_Success(t *testing.T) {
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestCreateOrder_ArchivedProduct(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	service := NewService(mockRepo, mockRepoProduct, new(MockInventoryRepository), new(MockBundleRepository))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockRepoProduct.On("GetById", ctx, productID).Return(&models.Product{
+		ID:     productID,
+		Price:  10,
+		Stock:  5,
+		Status: models.ProductStatusArchived,
+	}, nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items: []CreateOrderItemRequest{{ProductID: productID, Quantity: 1}},
+		ShippingAdress: models.ShippingAddress{
+			Street:     "Main St 1",
+			City:       "Kyiv",
+			PostalCode: "01001",
+			Country:    "UA",
+		},
+		PaymentMethod: "card",
+	})
+
+	assert.Nil(t, order)
+	assert.Equal(t, ErrProductUnavailable, err)
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
+func TestCreateOrder_ExistingOrderIDReturnsExisting(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	service := NewService(mockRepo, mockRepoProduct, new(MockInventoryRepository), new(MockBundleRepository))
+	ctx := context.Background()
+	userID := uuid.New()
+	orderID := uuid.New()
+	existing := &models.Order{ID: orderID, UserID: &userID, Status: "pending", TotalAmount: 20}
+
+	// замовлення вже створене попередньою спробою
+	mockRepo.On("GetById", ctx, orderID).Return(existing, nil)
+
+	order, err := service.CreateOrder(ctx, userID, CreateOrderRequest{
+		Items:         []CreateOrderItemRequest{{ProductID: uuid.New(), Quantity: 2}},
+		PaymentMethod: "card",
+		OrderID:       &orderID,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, existing, order)
+	mockRepo.AssertNotCalled(t, "Create")
+	mockRepoProduct.AssertNotCalled(t, "GetById")
+}
+
+// stockLevel створює залишок товару на складі для тестів розподілу
+func stockLevel(code, country string, productID uuid.UUID, quantity int) *models.LocationStock {
+	level := &models.LocationStock{
+		LocationID:   uuid.New(),
+		LocationCode: code,
+		ProductID:    productID,
+		Quantity:     quantity,
+	}
+	if country != "" {
+		level.Country = &country
+	}
+	return level
+}
+
+func TestAllocateStock_PrefersShippingCountry(t *testing.T) {
+	productID, itemID := uuid.New(), uuid.New()
+	local := stockLevel("kyiv", "UA", productID, 3)
+	levels := []*models.LocationStock{stockLevel("warsaw", "PL", productID, 50), local}
+
+	allocations := allocateStock(levels, "ua", itemID, 2)
+
+	assert.Len(t, allocations, 1)
+	assert.Equal(t, local.LocationID, allocations[0].LocationID)
+	assert.Equal(t, 2, allocations[0].Quantity)
+	assert.Equal(t, 1, local.Quantity)
+}
+
+func TestAllocateStock_HighestStockWhenNoLocal(t *testing.T) {
+	productID, itemID := uuid.New(), uuid.New()
+	big := stockLevel("warsaw", "PL", productID, 10)
+	levels := []*models.LocationStock{stockLevel("kyiv", "UA", productID, 4), big}
+
+	allocations := allocateStock(levels, "DE", itemID, 3)
+
+	assert.Len(t, allocations, 1)
+	assert.Equal(t, big.LocationID, allocations[0].LocationID)
+}
+
+func TestAllocateStock_SingleLocationBeforeSplit(t *testing.T) {
+	productID, itemID := uuid.New(), uuid.New()
+	local := stockLevel("kyiv", "UA", productID, 2)
+	remote := stockLevel("warsaw", "PL", productID, 5)
+
+	allocations := allocateStock([]*models.LocationStock{local, remote}, "UA", itemID, 4)
+
+	assert.Len(t, allocations, 1)
+	assert.Equal(t, remote.LocationID, allocations[0].LocationID)
+}
+
+func TestAllocateStock_SplitsAcrossLocations(t *testing.T) {
+	productID, itemID := uuid.New(), uuid.New()
+	local := stockLevel("kyiv", "UA", productID, 2)
+	remote := stockLevel("warsaw", "PL", productID, 3)
+
+	allocations := allocateStock([]*models.LocationStock{remote, local}, "UA", itemID, 4)
+
+	assert.Len(t, allocations, 2)
+	assert.Equal(t, local.LocationID, allocations[0].LocationID)
+	assert.Equal(t, 2, allocations[0].Quantity)
+	assert.Equal(t, remote.LocationID, allocations[1].LocationID)
+	assert.Equal(t, 2, allocations[1].Quantity)
+}
+
+func TestAllocateStock_Insufficient(t *testing.T) {
+	productID := uuid.New()
+	levels := []*models.LocationStock{stockLevel("kyiv", "UA", productID, 1), stockLevel("warsaw", "PL", productID, 1)}
+
+	assert.Nil(t, allocateStock(levels, "UA", uuid.New(), 3))
+}
+
+func TestCreateOrder_AllocatesStock(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockRepo, mockRepoProduct, mockInventory, new(MockBundleRepository))
+	ctx := context.Background()
+	productID := uuid.New()
+	local := stockLevel("kyiv", "UA", productID, 5)
+
+	mockRepoProduct.On("GetById", ctx, productID).Return(&models.Product{
+		ID:     productID,
+		Price:  10,
+		Stock:  5,
+		Status: models.ProductStatusPublished,
+	}, nil)
+	mockInventory.On("ListStockLevels", ctx, []uuid.UUID{productID}).
+		Return([]*models.LocationStock{stockLevel("warsaw", "PL", productID, 20), local}, nil)
+	mockRepo.On("Create", ctx, mock.Anything, mock.Anything, mock.MatchedBy(func(allocations []*models.StockAllocation) bool {
+		return len(allocations) == 1 && allocations[0].LocationID == local.LocationID && allocations[0].Quantity == 2
+	})).Return(nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items: []CreateOrderItemRequest{{ProductID: productID, Quantity: 2}},
+		ShippingAdress: models.ShippingAddress{
+			Street:     "Main St 1",
+			City:       "Kyiv",
+			PostalCode: "01001",
+			Country:    "UA",
+		},
+		PaymentMethod: "card",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, order.TotalAmount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrder_UsesSalePrice(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockRepo, mockRepoProduct, mockInventory, new(MockBundleRepository))
+	ctx := context.Background()
+	productID := uuid.New()
+	salePrice := 7.5
+	ends := time.Now().Add(time.Hour)
+
+	mockRepoProduct.On("GetById", ctx, productID).Return(&models.Product{
+		ID:         productID,
+		Price:      10,
+		Stock:      5,
+		Status:     models.ProductStatusPublished,
+		SalePrice:  &salePrice,
+		SaleEndsAt: &ends,
+	}, nil)
+	mockInventory.On("ListStockLevels", ctx, []uuid.UUID{productID}).
+		Return([]*models.LocationStock{stockLevel("kyiv", "UA", productID, 5)}, nil)
+	mockRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(items []*models.OrderItem) bool {
+		return len(items) == 1 && items[0].Price == salePrice
+	}), mock.Anything).Return(nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items: []CreateOrderItemRequest{{ProductID: productID, Quantity: 2}},
+		ShippingAdress: models.ShippingAddress{
+			Street:     "Main St 1",
+			City:       "Kyiv",
+			PostalCode: "01001",
+			Country:    "UA",
+		},
+		PaymentMethod: "card",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, order.TotalAmount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrder_InsufficientStock(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockRepo, mockRepoProduct, mockInventory, new(MockBundleRepository))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockRepoProduct.On("GetById", ctx, productID).Return(&models.Product{
+		ID:     productID,
+		Price:  10,
+		Stock:  1,
+		Status: models.ProductStatusPublished,
+	}, nil)
+	mockInventory.On("ListStockLevels", ctx, []uuid.UUID{productID}).
+		Return([]*models.LocationStock{stockLevel("kyiv", "UA", productID, 1)}, nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items: []CreateOrderItemRequest{{ProductID: productID, Quantity: 2}},
+		ShippingAdress: models.ShippingAddress{
+			Street:     "Main St 1",
+			City:       "Kyiv",
+			PostalCode: "01001",
+			Country:    "UA",
+		},
+		PaymentMethod: "card",
+	})
+
+	assert.Nil(t, order)
+	assert.Equal(t, ErrInsufficientStock, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_BundleAllocatesComponents(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	mockInventory := new(MockInventoryRepository)
+	mockBundle := new(MockBundleRepository)
+	service := NewService(mockRepo, mockRepoProduct, mockInventory, mockBundle)
+	ctx := context.Background()
+	bundleID := uuid.New()
+	monitorID := uuid.New()
+	mouseID := uuid.New()
+
+	mockRepoProduct.On("GetById", ctx, bundleID).Return(&models.Product{
+		ID:       bundleID,
+		Price:    500,
+		Stock:    3,
+		Status:   models.ProductStatusPublished,
+		IsBundle: true,
+	}, nil)
+	mockBundle.On("ListComponents", ctx, []uuid.UUID{bundleID}).Return([]*models.BundleComponent{
+		{BundleID: bundleID, ComponentID: monitorID, Name: "Monitor", Quantity: 1},
+		{BundleID: bundleID, ComponentID: mouseID, Name: "Mouse", Quantity: 2},
+	}, nil)
+	mockInventory.On("ListStockLevels", ctx, []uuid.UUID{monitorID, mouseID}).Return([]*models.LocationStock{
+		stockLevel("kyiv", "UA", monitorID, 3),
+		stockLevel("kyiv", "UA", mouseID, 10),
+	}, nil)
+	mockRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(items []*models.OrderItem) bool {
+		return len(items) == 1 && items[0].ProductID == bundleID && len(items[0].Components) == 2 &&
+			items[0].Components[1].ProductID == mouseID && items[0].Components[1].QuantityPerBundle == 2 &&
+			items[0].Components[1].Quantity == 4
+	}), mock.MatchedBy(func(allocations []*models.StockAllocation) bool {
+		return len(allocations) == 2 &&
+			allocations[0].ProductID == monitorID && allocations[0].Quantity == 2 &&
+			allocations[1].ProductID == mouseID && allocations[1].Quantity == 4
+	})).Return(nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items: []CreateOrderItemRequest{{ProductID: bundleID, Quantity: 2}},
+		ShippingAdress: models.ShippingAddress{
+			Street:     "Main St 1",
+			City:       "Kyiv",
+			PostalCode: "01001",
+			Country:    "UA",
+		},
+		PaymentMethod: "card",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.0, order.TotalAmount)
+	assert.Len(t, order.Items[0].Components, 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrder_BundleComponentOutOfStock(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	mockInventory := new(MockInventoryRepository)
+	mockBundle := new(MockBundleRepository)
+	service := NewService(mockRepo, mockRepoProduct, mockInventory, mockBundle)
+	ctx := context.Background()
+	bundleID := uuid.New()
+	keyboardID := uuid.New()
+
+	// окремо куплена клавіатура забирає залишок, потрібний комплекту
+	mockRepoProduct.On("GetById", ctx, keyboardID).Return(&models.Product{
+		ID:     keyboardID,
+		Price:  50,
+		Stock:  2,
+		Status: models.ProductStatusPublished,
+	}, nil)
+	mockRepoProduct.On("GetById", ctx, bundleID).Return(&models.Product{
+		ID:       bundleID,
+		Price:    500,
+		Stock:    2,
+		Status:   models.ProductStatusPublished,
+		IsBundle: true,
+	}, nil)
+	mockBundle.On("ListComponents", ctx, []uuid.UUID{bundleID}).Return([]*models.BundleComponent{
+		{BundleID: bundleID, ComponentID: keyboardID, Name: "Keyboard", Quantity: 1},
+	}, nil)
+	mockInventory.On("ListStockLevels", ctx, []uuid.UUID{keyboardID, keyboardID}).
+		Return([]*models.LocationStock{stockLevel("kyiv", "UA", keyboardID, 2)}, nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items: []CreateOrderItemRequest{
+			{ProductID: keyboardID, Quantity: 1},
+			{ProductID: bundleID, Quantity: 2},
+		},
+		ShippingAdress: models.ShippingAddress{
+			Street:     "Main St 1",
+			City:       "Kyiv",
+			PostalCode: "01001",
+			Country:    "UA",
+		},
+		PaymentMethod: "card",
+	})
+
+	assert.Nil(t, order)
+	assert.Equal(t, ErrInsufficientStock, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_BundleWithoutComponents(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	mockBundle := new(MockBundleRepository)
+	service := NewService(mockRepo, mockRepoProduct, new(MockInventoryRepository), mockBundle)
+	ctx := context.Background()
+	bundleID := uuid.New()
+
+	mockRepoProduct.On("GetById", ctx, bundleID).Return(&models.Product{
+		ID:       bundleID,
+		Price:    500,
+		Status:   models.ProductStatusPublished,
+		IsBundle: true,
+	}, nil)
+	mockBundle.On("ListComponents", ctx, []uuid.UUID{bundleID}).Return([]*models.BundleComponent{}, nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items: []CreateOrderItemRequest{{ProductID: bundleID, Quantity: 1}},
+		ShippingAdress: models.ShippingAddress{
+			Street:     "Main St 1",
+			City:       "Kyiv",
+			PostalCode: "01001",
+			Country:    "UA",
+		},
+		PaymentMethod: "card",
+	})
+
+	assert.Nil(t, order)
+	assert.Equal(t, ErrProductUnavailable, err)
+}
+
+func TestAllocateAvailable_Partial(t *testing.T) {
+	productID := uuid.New()
+	levels := []*models.LocationStock{stockLevel("kyiv", "UA", productID, 1), stockLevel("warsaw", "PL", productID, 1)}
+
+	allocations, allocated := allocateAvailable(levels, "UA", uuid.New(), 5)
+
+	assert.Equal(t, 2, allocated)
+	assert.Len(t, allocations, 2)
+}
+
+func TestAllocateAvailable_NoStock(t *testing.T) {
+	allocations, allocated := allocateAvailable(nil, "UA", uuid.New(), 3)
+
+	assert.Nil(t, allocations)
+	assert.Equal(t, 0, allocated)
+}
+
+func TestCreateOrder_BackordersRemainder(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockRepo, mockRepoProduct, mockInventory, new(MockBundleRepository))
+	ctx := context.Background()
+	productID := uuid.New()
+	restock := time.Now().Add(7 * 24 * time.Hour)
+
+	mockRepoProduct.On("GetById", ctx, productID).Return(&models.Product{
+		ID:              productID,
+		Price:           10,
+		Stock:           1,
+		Status:          models.ProductStatusPublished,
+		BackorderPolicy: models.BackorderAllow,
+		AvailableAt:     &restock,
+	}, nil)
+	mockInventory.On("ListStockLevels", ctx, []uuid.UUID{productID}).
+		Return([]*models.LocationStock{stockLevel("kyiv", "UA", productID, 1)}, nil)
+	mockRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(items []*models.OrderItem) bool {
+		return len(items) == 1 && items[0].Backordered && items[0].BackorderedQuantity == 2
+	}), mock.MatchedBy(func(allocations []*models.StockAllocation) bool {
+		return len(allocations) == 1 && allocations[0].Quantity == 1
+	})).Return(nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items: []CreateOrderItemRequest{{ProductID: productID, Quantity: 3}},
+		ShippingAdress: models.ShippingAddress{
+			Street:     "Main St 1",
+			City:       "Kyiv",
+			PostalCode: "01001",
+			Country:    "UA",
+		},
+		PaymentMethod: "card",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, order.TotalAmount)
+	assert.Equal(t, &restock, order.Items[0].ExpectedShipDate)
+	assert.Equal(t, &restock, order.ExpectedShipDate)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrder_PreorderShipsOnRelease(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockRepo, mockRepoProduct, mockInventory, new(MockBundleRepository))
+	ctx := context.Background()
+	productID := uuid.New()
+	release := time.Now().Add(30 * 24 * time.Hour)
+
+	mockRepoProduct.On("GetById", ctx, productID).Return(&models.Product{
+		ID:              productID,
+		Price:           10,
+		Stock:           5,
+		Status:          models.ProductStatusPublished,
+		BackorderPolicy: models.BackorderPreorder,
+		AvailableAt:     &release,
+	}, nil)
+	mockInventory.On("ListStockLevels", ctx, []uuid.UUID{productID}).
+		Return([]*models.LocationStock{stockLevel("kyiv", "UA", productID, 5)}, nil)
+	mockRepo.On("Create", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items: []CreateOrderItemRequest{{ProductID: productID, Quantity: 2}},
+		ShippingAdress: models.ShippingAddress{
+			Street:     "Main St 1",
+			City:       "Kyiv",
+			PostalCode: "01001",
+			Country:    "UA",
+		},
+		PaymentMethod: "card",
+	})
+
+	// товар є на складі, але до дати релізу не відвантажується
+	assert.NoError(t, err)
+	assert.False(t, order.Items[0].Backordered)
+	assert.Equal(t, &release, order.Items[0].ExpectedShipDate)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrder_DigitalSkipsStockAndShipping(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	mockInventory := new(MockInventoryRepository)
+	service := NewService(mockRepo, mockRepoProduct, mockInventory, new(MockBundleRepository))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockRepoProduct.On("GetById", ctx, productID).Return(&models.Product{
+		ID:        productID,
+		Price:     15,
+		Status:    models.ProductStatusPublished,
+		IsDigital: true,
+	}, nil)
+	mockRepo.On("Create", ctx, mock.Anything, mock.Anything, mock.MatchedBy(func(allocations []*models.StockAllocation) bool {
+		return len(allocations) == 0
+	})).Return(nil)
+
+	// адреса доставки не потрібна, якщо всі позиції цифрові
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items:         []CreateOrderItemRequest{{ProductID: productID, Quantity: 1}},
+		PaymentMethod: "card",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, order.TotalAmount)
+	assert.False(t, order.Items[0].Backordered)
+	mockInventory.AssertNotCalled(t, "ListStockLevels", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrder_PhysicalRequiresShipping(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockRepoProduct := new(MockProductRepository)
+	service := NewService(mockRepo, mockRepoProduct, new(MockInventoryRepository), new(MockBundleRepository))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockRepoProduct.On("GetById", ctx, productID).Return(&models.Product{
+		ID:     productID,
+		Price:  10,
+		Stock:  5,
+		Status: models.ProductStatusPublished,
+	}, nil)
+
+	order, err := service.CreateOrder(ctx, uuid.New(), CreateOrderRequest{
+		Items:         []CreateOrderItemRequest{{ProductID: productID, Quantity: 1}},
+		PaymentMethod: "card",
+	})
+
+	assert.Nil(t, order)
+	assert.Equal(t, ErrShippingAddressRequired, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatus_Persists(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockInventoryRepository), new(MockBundleRepository))
+	ctx := context.Background()
+	orderID := uuid.New()
+	paidAt := time.Now()
+
+	mockRepo.On("GetById", ctx, orderID).Return(&models.Order{ID: orderID, Status: "pending"}, nil).Once()
+	mockRepo.On("UpdateStatus", ctx, orderID, "paid").Return(nil)
+	mockRepo.On("GetById", ctx, orderID).Return(&models.Order{ID: orderID, Status: "paid", PaidAt: &paidAt}, nil).Once()
+
+	order, err := service.UpdateOrderStatus(ctx, orderID, UpdateOrderRequest{Status: "paid"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+	assert.Equal(t, &paidAt, order.PaidAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatus_CanceledRestocks(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockInventoryRepository), new(MockBundleRepository))
+	ctx := context.Background()
+	orderID := uuid.New()
+
+	mockRepo.On("GetById", ctx, orderID).Return(&models.Order{ID: orderID, Status: "pending"}, nil)
+	mockRepo.On("UpdateStatus", ctx, orderID, "cancelled").Return(nil)
+
+	_, err := service.UpdateOrderStatus(ctx, orderID, UpdateOrderRequest{Status: "canceled"})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatus_CancelledCannotBeReopened(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockOrderRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockInventoryRepository), new(MockBundleRepository))
+	ctx := context.Background()
+	orderID := uuid.New()
+
+	mockRepo.On("GetById", ctx, orderID).Return(&models.Order{ID: orderID, Status: "cancelled"}, nil)
+
+	//Act
+	order, err := service.UpdateOrderStatus(ctx, orderID, UpdateOrderRequest{Status: "paid"})
+
+	//Assert
+	assert.Nil(t, order)
+	assert.Equal(t, ErrOrderAlreadyCanceled, err)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatus_CancelledConcurrently(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockOrderRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockInventoryRepository), new(MockBundleRepository))
+	ctx := context.Background()
+	orderID := uuid.New()
+
+	mockRepo.On("GetById", ctx, orderID).Return(&models.Order{ID: orderID, Status: "pending"}, nil)
+	mockRepo.On("UpdateStatus", ctx, orderID, "shipped").Return(sql.ErrNoRows)
+
+	//Act
+	order, err := service.UpdateOrderStatus(ctx, orderID, UpdateOrderRequest{Status: "shipped"})
+
+	//Assert
+	assert.Nil(t, order)
+	assert.Equal(t, ErrOrderAlreadyCanceled, err)
+}
@@ -0,0 +1,8 @@
+package pricealert
+
+// DTO структури для сповіщень про зниження ціни
+
+type SetPriceAlertRequest struct {
+	// TargetPrice сповістити, коли ціна (з урахуванням розпродажу) опуститься до цього значення
+	TargetPrice float64 `json:"target_price" validate:"required,gt=0"`
+}
@@ -0,0 +1,14 @@
+package pricealert
+
+import "errors"
+
+// помилки пов'язані зі сповіщеннями про зниження ціни
+var (
+	// Validate errors
+	ErrInvalidTargetPrice = errors.New("target price must be greater than 0")
+
+	// Logic errors
+	ErrProductNotFound = errors.New("product not found")
+	ErrTargetReached   = errors.New("product price is already at or below the target price")
+	ErrAlertNotFound   = errors.New("price alert not found")
+)
@@ -0,0 +1,20 @@
+package pricealert
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// PriceAlertService інтерфейс для сповіщень про зниження ціни
+type PriceAlertService interface {
+	SetAlert(ctx context.Context, userID, productID uuid.UUID, req SetPriceAlertRequest) (*models.PriceAlert, error)
+	GetAlert(ctx context.Context, userID, productID uuid.UUID) (*models.PriceAlert, error)
+	ListAlerts(ctx context.Context, userID uuid.UUID) ([]*models.PriceAlertWithProduct, error)
+	DeleteAlert(ctx context.Context, userID, productID uuid.UUID) error
+	// EvaluateSales ставить у чергу сповіщення продуктів, розпродаж яких почався; повертає кількість
+	EvaluateSales(ctx context.Context) (int, error)
+	// DeliverNotifications відправляє сповіщення з урахуванням ліміту на користувача; повертає кількість відправлених
+	DeliverNotifications(ctx context.Context) (int, error)
+}
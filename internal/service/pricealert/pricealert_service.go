@@ -0,0 +1,171 @@
+package pricealert
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/google/uuid"
+)
+
+// значення за замовчуванням
+const (
+	defaultBatchSize  = 100
+	defaultUserLimit  = 5
+	defaultUserWindow = 24 * time.Hour
+)
+
+// Config налаштування доставки сповіщень
+type Config struct {
+	// BatchSize кількість сповіщень за один запуск
+	BatchSize int
+	// UserLimit скільки сповіщень користувач може отримати за UserWindow; решта чекає
+	UserLimit  int
+	UserWindow time.Duration
+}
+
+type service struct {
+	alertRepo   repository.PriceAlertRepository
+	productRepo repository.ProductRepository
+	notifier    notification.Notifier
+	cfg         Config
+}
+
+func NewService(alertRepo repository.PriceAlertRepository, productRepo repository.ProductRepository, notifier notification.Notifier, cfg Config) PriceAlertService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.UserLimit <= 0 {
+		cfg.UserLimit = defaultUserLimit
+	}
+	if cfg.UserWindow <= 0 {
+		cfg.UserWindow = defaultUserWindow
+	}
+	return &service{alertRepo: alertRepo,
+		productRepo: productRepo,
+		notifier:    notifier,
+		cfg:         cfg}
+}
+
+// SetAlert встановлює цільову ціну продукту; повторний виклик змінює ціль активного сповіщення
+func (s *service) SetAlert(ctx context.Context, userID, productID uuid.UUID, req SetPriceAlertRequest) (*models.PriceAlert, error) {
+	// валідація
+	if req.TargetPrice <= 0 {
+		return nil, ErrInvalidTargetPrice
+	}
+
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || !product.IsVisible() {
+		return nil, ErrProductNotFound
+	}
+	// ціль уже досягнута - сповіщати нема про що
+	if product.EffectivePrice(time.Now()) <= req.TargetPrice {
+		return nil, ErrTargetReached
+	}
+
+	alert := &models.PriceAlert{
+		ProductID:   productID,
+		UserID:      userID,
+		TargetPrice: req.TargetPrice,
+	}
+	if err := s.alertRepo.Upsert(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to set price alert: %w", err)
+	}
+	return alert, nil
+}
+
+// GetAlert повертає активне сповіщення користувача на продукт
+func (s *service) GetAlert(ctx context.Context, userID, productID uuid.UUID) (*models.PriceAlert, error) {
+	alert, err := s.alertRepo.GetActive(ctx, productID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price alert: %w", err)
+	}
+	if alert == nil {
+		return nil, ErrAlertNotFound
+	}
+	return alert, nil
+}
+
+// ListAlerts повертає активні сповіщення користувача з поточною ціною продуктів
+func (s *service) ListAlerts(ctx context.Context, userID uuid.UUID) ([]*models.PriceAlertWithProduct, error) {
+	alerts, err := s.alertRepo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price alerts: %w", err)
+	}
+	if alerts == nil {
+		alerts = []*models.PriceAlertWithProduct{}
+	}
+	return alerts, nil
+}
+
+// DeleteAlert скасовує активне сповіщення користувача
+func (s *service) DeleteAlert(ctx context.Context, userID, productID uuid.UUID) error {
+	if err := s.alertRepo.Delete(ctx, productID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAlertNotFound
+		}
+		return fmt.Errorf("failed to delete price alert: %w", err)
+	}
+	return nil
+}
+
+// EvaluateSales перевіряє сповіщення продуктів з розпродажем, що вже діє.
+// Зміна ціни через UpdateProduct перевіряється одразу в репозиторії, а початок запланованого
+// розпродажу не змінює продукт, тому його підхоплює ця перевірка
+func (s *service) EvaluateSales(ctx context.Context) (int, error) {
+	triggered, err := s.alertRepo.TriggerActiveSales(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to evaluate sales: %w", err)
+	}
+	return int(triggered), nil
+}
+
+// DeliverNotifications відправляє сповіщення, ціна яких досягла цільової.
+// Сповіщення, ціна яких знову піднялась, повертаються в очікування; користувач отримує
+// не більше UserLimit сповіщень за UserWindow, решта відправляється в наступних запусках
+func (s *service) DeliverNotifications(ctx context.Context) (int, error) {
+	if _, err := s.alertRepo.RequeueRisen(ctx); err != nil {
+		return 0, fmt.Errorf("failed to requeue price alerts: %w", err)
+	}
+
+	since := time.Now().Add(-s.cfg.UserWindow)
+	alerts, err := s.alertRepo.ListTriggered(ctx, since, s.cfg.UserLimit, s.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list triggered price alerts: %w", err)
+	}
+
+	var delivered []uuid.UUID
+	var deliveryErr error
+	sent := make(map[uuid.UUID]int)
+	for _, alert := range alerts {
+		// ліміт користувача з урахуванням уже відправлених у цьому запуску
+		if alert.RecentNotifications+sent[alert.UserID] >= s.cfg.UserLimit {
+			continue
+		}
+
+		n := notification.New(notification.TypePriceDrop, &alert.UserID, map[string]interface{}{
+			"alert_id":     alert.ID,
+			"product_id":   alert.ProductID,
+			"product_name": alert.ProductName,
+			"product_slug": alert.ProductSlug,
+			"target_price": alert.TargetPrice,
+			"price":        alert.CurrentPrice,
+		})
+		if err := s.notifier.Notify(ctx, n); err != nil {
+			deliveryErr = fmt.Errorf("failed to send price drop notification: %w", err)
+			break
+		}
+		delivered = append(delivered, alert.ID)
+		sent[alert.UserID]++
+	}
+
+	if err := s.alertRepo.MarkNotified(ctx, delivered); err != nil {
+		return 0, fmt.Errorf("failed to mark price alerts notified: %w", err)
+	}
+	return len(delivered), deliveryErr
+}
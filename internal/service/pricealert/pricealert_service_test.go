@@ -0,0 +1,361 @@
+package pricealert
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPriceAlertRepository struct {
+	mock.Mock
+}
+
+func (m *MockPriceAlertRepository) Upsert(ctx context.Context, alert *models.PriceAlert) error {
+	args := m.Called(ctx, alert)
+	return args.Error(0)
+}
+func (m *MockPriceAlertRepository) GetActive(ctx context.Context, productID, userID uuid.UUID) (*models.PriceAlert, error) {
+	args := m.Called(ctx, productID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PriceAlert), args.Error(1)
+}
+func (m *MockPriceAlertRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*models.PriceAlertWithProduct, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PriceAlertWithProduct), args.Error(1)
+}
+func (m *MockPriceAlertRepository) Delete(ctx context.Context, productID, userID uuid.UUID) error {
+	args := m.Called(ctx, productID, userID)
+	return args.Error(0)
+}
+func (m *MockPriceAlertRepository) TriggerActiveSales(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockPriceAlertRepository) RequeueRisen(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockPriceAlertRepository) ListTriggered(ctx context.Context, since time.Time, perUserLimit, limit int) ([]*models.PriceAlertWithProduct, error) {
+	args := m.Called(ctx, since, perUserLimit, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PriceAlertWithProduct), args.Error(1)
+}
+func (m *MockPriceAlertRepository) MarkNotified(ctx context.Context, ids []uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, n notification.Notification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+func TestSetAlert_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockPriceAlertRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Price: 100, Status: models.ProductStatusPublished}, nil)
+	mockRepo.On("Upsert", ctx, mock.MatchedBy(func(a *models.PriceAlert) bool {
+		return a.UserID == userID && a.ProductID == productID && a.TargetPrice == 80
+	})).Return(nil)
+
+	//Act
+	alert, err := service.SetAlert(ctx, userID, productID, SetPriceAlertRequest{TargetPrice: 80})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 80.0, alert.TargetPrice)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetAlert_TargetReachedBySale(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockPriceAlertRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockNotifier), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+	salePrice := 75.0
+	started := time.Now().Add(-time.Hour)
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{
+		ID: productID, Price: 100, Status: models.ProductStatusPublished,
+		SalePrice: &salePrice, SaleStartsAt: &started,
+	}, nil)
+
+	//Act
+	alert, err := service.SetAlert(ctx, uuid.New(), productID, SetPriceAlertRequest{TargetPrice: 80})
+
+	//Assert
+	assert.Nil(t, alert)
+	assert.Equal(t, ErrTargetReached, err)
+	mockRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
+
+func TestSetAlert_ScheduledSaleIgnored(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockPriceAlertRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockNotifier), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+	salePrice := 75.0
+	starts := time.Now().Add(time.Hour)
+
+	// розпродаж ще не почався, тому діє звичайна ціна
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{
+		ID: productID, Price: 100, Status: models.ProductStatusPublished,
+		SalePrice: &salePrice, SaleStartsAt: &starts,
+	}, nil)
+	mockRepo.On("Upsert", ctx, mock.AnythingOfType("*models.PriceAlert")).Return(nil)
+
+	//Act
+	_, err := service.SetAlert(ctx, uuid.New(), productID, SetPriceAlertRequest{TargetPrice: 80})
+
+	//Assert
+	assert.NoError(t, err)
+}
+
+func TestSetAlert_Validation(t *testing.T) {
+	//Arrange
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(new(MockPriceAlertRepository), mockProductRepo, new(MockNotifier), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+
+	//Act
+	_, err := service.SetAlert(ctx, uuid.New(), productID, SetPriceAlertRequest{TargetPrice: 0})
+
+	//Assert
+	assert.Equal(t, ErrInvalidTargetPrice, err)
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Price: 100, Status: models.ProductStatusDraft}, nil)
+	_, err = service.SetAlert(ctx, uuid.New(), productID, SetPriceAlertRequest{TargetPrice: 50})
+	assert.Equal(t, ErrProductNotFound, err)
+}
+
+func TestGetAlert_NotFound(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockPriceAlertRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockRepo.On("GetActive", ctx, productID, userID).Return(nil, nil)
+
+	//Act
+	alert, err := service.GetAlert(ctx, userID, productID)
+
+	//Assert
+	assert.Nil(t, alert)
+	assert.Equal(t, ErrAlertNotFound, err)
+}
+
+func TestDeleteAlert_NotFound(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockPriceAlertRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockRepo.On("Delete", ctx, productID, userID).Return(sql.ErrNoRows)
+
+	//Act
+	err := service.DeleteAlert(ctx, userID, productID)
+
+	//Assert
+	assert.Equal(t, ErrAlertNotFound, err)
+}
+
+func TestEvaluateSales(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockPriceAlertRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockNotifier), Config{})
+	ctx := context.Background()
+
+	mockRepo.On("TriggerActiveSales", ctx).Return(int64(3), nil)
+
+	//Act
+	count, err := service.EvaluateSales(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestDeliverNotifications_UserLimit(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockPriceAlertRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockNotifier, Config{BatchSize: 10, UserLimit: 2, UserWindow: time.Hour})
+	ctx := context.Background()
+	busyUser, otherUser := uuid.New(), uuid.New()
+	newAlert := func(userID uuid.UUID, recent int) *models.PriceAlertWithProduct {
+		return &models.PriceAlertWithProduct{
+			PriceAlert:          models.PriceAlert{ID: uuid.New(), UserID: userID, ProductID: uuid.New(), TargetPrice: 50},
+			CurrentPrice:        45,
+			RecentNotifications: recent,
+		}
+	}
+	// користувач уже отримав одне сповіщення за період, тому з двох нових відправляється одне
+	first, second := newAlert(busyUser, 1), newAlert(busyUser, 1)
+	third := newAlert(otherUser, 0)
+
+	mockRepo.On("RequeueRisen", ctx).Return(int64(0), nil)
+	mockRepo.On("ListTriggered", ctx, mock.AnythingOfType("time.Time"), 2, 10).
+		Return([]*models.PriceAlertWithProduct{first, second, third}, nil)
+	mockNotifier.On("Notify", ctx, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Type == notification.TypePriceDrop && n.Data["price"] == 45.0
+	})).Return(nil)
+	mockRepo.On("MarkNotified", ctx, []uuid.UUID{first.ID, third.ID}).Return(nil)
+
+	//Act
+	count, err := service.DeliverNotifications(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	mockNotifier.AssertNumberOfCalls(t, "Notify", 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeliverNotifications_StopsOnFailure(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockPriceAlertRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockNotifier, Config{})
+	ctx := context.Background()
+	first := &models.PriceAlertWithProduct{PriceAlert: models.PriceAlert{ID: uuid.New(), UserID: uuid.New()}}
+	second := &models.PriceAlertWithProduct{PriceAlert: models.PriceAlert{ID: uuid.New(), UserID: uuid.New()}}
+
+	mockRepo.On("RequeueRisen", ctx).Return(int64(0), nil)
+	mockRepo.On("ListTriggered", ctx, mock.AnythingOfType("time.Time"), defaultUserLimit, defaultBatchSize).
+		Return([]*models.PriceAlertWithProduct{first, second}, nil)
+	mockNotifier.On("Notify", ctx, mock.MatchedBy(func(n notification.Notification) bool {
+		return *n.UserID == first.UserID
+	})).Return(nil)
+	mockNotifier.On("Notify", ctx, mock.Anything).Return(errors.New("webhook down"))
+	mockRepo.On("MarkNotified", ctx, []uuid.UUID{first.ID}).Return(nil)
+
+	//Act
+	count, err := service.DeliverNotifications(ctx)
+
+	// друге сповіщення залишається в черзі
+
+	//Assert
+	assert.Error(t, err)
+	assert.Equal(t, 1, count)
+	mockRepo.AssertExpectations(t)
+}
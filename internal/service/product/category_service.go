@@ -0,0 +1,106 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/slug"
+)
+
+// максимальна довжина назви категорії
+const maxCategoryNameLength = 100
+
+// ListCategories повертає всі категорії
+func (s *service) ListCategories(ctx context.Context) ([]*models.Category, error) {
+	categories, err := s.categoryRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	if categories == nil {
+		categories = []*models.Category{}
+	}
+	if err := s.localizeCategories(ctx, categories...); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetCategoryBySlug повертає категорію за slug мови запиту або базовим slug
+func (s *service) GetCategoryBySlug(ctx context.Context, categorySlug string) (*models.Category, error) {
+	// спочатку шукаємо локалізований slug
+	if locale := s.localizer.locale(ctx); locale != "" {
+		translation, err := s.translationRepo.GetCategoryTranslationBySlug(ctx, locale, categorySlug)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		if translation != nil {
+			category, err := s.categoryRepo.GetById(ctx, translation.CategoryID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get category: %w", err)
+			}
+			if category == nil {
+				return nil, ErrCategoryNotFound
+			}
+			applyCategoryTranslation(category, translation)
+			return category, nil
+		}
+	}
+
+	category, err := s.categoryRepo.GetBySlug(ctx, categorySlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+	if err := s.localizeCategories(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// CreateCategory створює категорію з унікальним slug, згенерованим з назви
+func (s *service) CreateCategory(ctx context.Context, req CreateCategoryRequest) (*models.Category, error) {
+	// валідація
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrCategoryNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxCategoryNameLength {
+		return nil, ErrCategoryNameTooLong
+	}
+
+	// назва категорії унікальна
+	existing, err := s.categoryRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check category: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrCategoryExists
+	}
+
+	base := slug.Make(name)
+	if base == "" {
+		base = "category"
+	}
+	categorySlug, err := slug.Unique(ctx, base, s.categoryRepo.SlugTaken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate slug: %w", err)
+	}
+
+	category := &models.Category{
+		Name: name,
+		Slug: categorySlug,
+	}
+	if description := strings.TrimSpace(req.Description); description != "" {
+		category.Description = &description
+	}
+
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+	return category, nil
+}
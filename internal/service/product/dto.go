@@ -1,46 +1,108 @@
 package product
 
 import (
+	"time"
+
 	models "github.com/Xiancel/ecommerce/internal/domain"
 	"github.com/google/uuid"
 )
 
 // CreateProductRequest is the DTO for creating a product
 type CreateProductRequest struct {
+	SKU         string     `json:"sku" validate:"omitempty,max=64"`
 	Name        string     `json:"name" validate:"required,min=3,max=255"`
 	Description string     `json:"description" validate:"max=1000"`
 	Price       float64    `json:"price" validate:"required,gt=0"`
 	Stock       int        `json:"stock" validate:"required,gte=0"`
 	CategoryID  *uuid.UUID `json:"category_id" validate:"omitempty,uuid"`
 	ImageURL    string     `json:"image_url" validate:"omitempty,url"`
+	Tags        []string   `json:"tags" validate:"omitempty,max=20"`
+	Status      string     `json:"status" validate:"omitempty,oneof=draft published archived"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	// BackorderPolicy deny (default), allow or preorder
+	BackorderPolicy string `json:"backorder_policy" validate:"omitempty,oneof=deny allow preorder"`
+	// AvailableAt expected restock date; required for preorders (release date)
+	AvailableAt *time.Time `json:"available_at"`
+	// IsDigital downloadable product without stock and shipping
+	IsDigital bool `json:"is_digital"`
+	// SalePrice discounted price between SaleStartsAt and SaleEndsAt (both optional)
+	SalePrice    *float64   `json:"sale_price" validate:"omitempty,gt=0"`
+	SaleStartsAt *time.Time `json:"sale_starts_at"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at"`
 }
 
 // UpdateProductRequest is the DTO for updating a product
 type UpdateProductRequest struct {
+	SKU         *string    `json:"sku" validate:"omitempty,max=64"`
 	Name        *string    `json:"name" validate:"omitempty,min=3,max=255"`
 	Description *string    `json:"description" validate:"omitempty,max=1000"`
 	Price       *float64   `json:"price" validate:"omitempty,gt=0"`
 	Stock       *int       `json:"stock" validate:"omitempty,gte=0"`
 	CategoryID  *uuid.UUID `json:"category_id" validate:"omitempty,uuid"`
 	ImageURL    *string    `json:"image_url" validate:"omitempty,url"`
+	Tags        []string   `json:"tags" validate:"omitempty,max=20"`
+	Status      *string    `json:"status" validate:"omitempty,oneof=draft published archived"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	// BackorderPolicy deny, allow or preorder
+	BackorderPolicy *string    `json:"backorder_policy" validate:"omitempty,oneof=deny allow preorder"`
+	AvailableAt     *time.Time `json:"available_at"`
+	IsDigital       *bool      `json:"is_digital"`
+	// SalePrice 0 removes the sale together with its dates
+	SalePrice    *float64   `json:"sale_price" validate:"omitempty,gte=0"`
+	SaleStartsAt *time.Time `json:"sale_starts_at"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at"`
+	// StockLocationID stock location the stock change is applied to (default location if empty)
+	StockLocationID *uuid.UUID `json:"stock_location_id"`
+	// StockReason adjustment (default) or return
+	StockReason *string `json:"stock_reason" validate:"omitempty,oneof=adjustment return"`
+	StockNote   *string `json:"stock_note"`
 }
 
-// ProductFilter is the DTO for filtering products
+// StatusAll disables the status filter (admin listing only)
+const StatusAll = "all"
+
+// ProductFilter is the DTO for filtering products.
+// An empty Status means published products only.
 type ProductFilter struct {
 	CategoryID *uuid.UUID `json:"category_id"`
 	MinPrice   *float64   `json:"min_price" validate:"omitempty,gte=0"`
 	MaxPrice   *float64   `json:"max_price" validate:"omitempty,gte=0"`
+	MinRating  *float64   `json:"min_rating" validate:"omitempty,gte=0,lte=5"`
 	Search     string     `json:"search"`
+	Tag        string     `json:"tag"`
+	Status     string     `json:"status" validate:"omitempty,oneof=all draft published archived"`
 	InStock    *bool      `json:"in_stock"`
-	OrderBy    string     `json:"order_by" validate:"omitempty,oneof=price_asc price_desc name_asc name_desc created_at_asc created_at_desc"`
+	OrderBy    string     `json:"order_by" validate:"omitempty,oneof=price_asc price_desc name_asc name_desc rating_asc rating_desc created_at_asc created_at_desc"`
 	Limit      int        `json:"limit" validate:"required,min=1,max=100"`
 	Offset     int        `json:"offset" validate:"gte=0"`
+	// Cursor непрозорий токен сторінки з next_cursor/prev_cursor; має пріоритет над Offset
+	Cursor string `json:"cursor"`
+	Debug  bool   `json:"debug"`
 }
 
 // ProductListResponse contains paginated products and metadata
 type ProductListResponse struct {
 	Products []*models.Product `json:"products"`
-	Total    int               `json:"total"`
-	Limit    int               `json:"limit"`
-	Offset   int               `json:"offset"`
+	// Total кількість усіх продуктів за фільтром (для великих наборів - оцінка)
+	Total          int                         `json:"total"`
+	TotalEstimated bool                        `json:"total_estimated,omitempty"`
+	Limit          int                         `json:"limit"`
+	Offset         int                         `json:"offset"`
+	NextCursor     string                      `json:"next_cursor,omitempty"`
+	PrevCursor     string                      `json:"prev_cursor,omitempty"`
+	Explanations   []*models.SearchExplanation `json:"explanations,omitempty"`
+}
+
+// SuggestResponse contains autocomplete suggestions for a query
+type SuggestResponse struct {
+	Query       string               `json:"query"`
+	Suggestions []*models.Suggestion `json:"suggestions"`
+}
+
+// CreateCategoryRequest is the DTO for creating a category
+type CreateCategoryRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=1000"`
 }
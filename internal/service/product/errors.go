@@ -8,12 +8,33 @@ var (
 	ErrProductNotFound     = errors.New("product not found")
 	ErrProductNameRequired = errors.New("product name is required")
 	ErrProductNotAvailable = errors.New("product is not available")
+	ErrSKUExists           = errors.New("product with this sku already exists")
 
 	// Validation errors
-	ErrInvalidPrice    = errors.New("price must be greater than 0")
-	ErrInvalidStock    = errors.New("stock must be non-negative")
-	ErrInvalidQuantity = errors.New("quantity must be greater than 0")
+	ErrInvalidPrice           = errors.New("price must be greater than 0")
+	ErrInvalidStock           = errors.New("stock must be non-negative")
+	ErrInvalidQuantity        = errors.New("quantity must be greater than 0")
+	ErrInvalidStatus          = errors.New("invalid product status")
+	ErrInvalidSchedule        = errors.New("unpublish time must be after publish time")
+	ErrInvalidSKU             = errors.New("sku must be at most 64 characters")
+	ErrInvalidRating          = errors.New("rating filter must be between 0 and 5")
+	ErrInvalidTag             = errors.New("product can have at most 20 tags of at most 50 characters")
+	ErrInvalidBackorderPolicy = errors.New("backorder policy must be deny, allow or preorder")
+	ErrReleaseDateRequired    = errors.New("preorder requires an available_at release date")
+	ErrInvalidCursor          = errors.New("invalid or expired page cursor")
+	ErrInvalidSalePrice       = errors.New("sale price must be greater than 0 and less than price")
+	ErrInvalidSaleSchedule    = errors.New("sale requires a sale price and must end after it starts")
+	ErrInvalidStockReason     = errors.New("stock reason must be adjustment or return")
+
+	// Category errors
+	ErrCategoryNotFound     = errors.New("category not found")
+	ErrCategoryNameRequired = errors.New("category name is required")
+	ErrCategoryNameTooLong  = errors.New("category name must be at most 100 characters")
+	ErrCategoryExists       = errors.New("category with this name already exists")
 
 	// Stock errors
-	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrStockLocationNotFound = errors.New("stock location not found")
+	ErrInsufficientStock     = errors.New("insufficient stock")
+	ErrBundleStock           = errors.New("bundle stock is computed from its components")
+	ErrInvalidBundle         = errors.New("bundle must be a physical product without backorders")
 )
@@ -11,10 +11,15 @@ import (
 type ProductService interface {
 	CreateProduct(ctx context.Context, req CreateProductRequest) (*models.Product, error)
 	GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (*models.Product, error)
 	ListProduct(ctx context.Context, filter ProductFilter) (*ProductListResponse, error)
 	SearchProduct(ctx context.Context, query string, limit, offset int) ([]*models.Product, error)
+	SuggestProduct(ctx context.Context, query string, limit int) (*SuggestResponse, error)
 	UpdateProduct(ctx context.Context, id uuid.UUID, req UpdateProductRequest) (*models.Product, error)
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	ApplySchedule(ctx context.Context) (published int, unpublished int, err error)
 	CheckAvailability(ctx context.Context, id uuid.UUID, quantity int) (bool, error)
-	ReserveStock(ctx context.Context, id uuid.UUID, quantity int) error
-	ReleaseStock(ctx context.Context, id uuid.UUID, quantity int) error
+	ListCategories(ctx context.Context) ([]*models.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (*models.Category, error)
+	CreateCategory(ctx context.Context, req CreateCategoryRequest) (*models.Category, error)
 }
@@ -0,0 +1,130 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/i18n"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/google/uuid"
+)
+
+// Localizer підставляє переклади продуктів мовою запиту. Спільний для сервісів, що віддають
+// продукти поза каталогом: колекцій, рекомендацій, списків бажань та комплектів
+type Localizer struct {
+	translationRepo repository.TranslationRepository
+	defaultLocale   string
+}
+
+func NewLocalizer(translationRepo repository.TranslationRepository, defaultLocale string) *Localizer {
+	if defaultLocale == "" {
+		defaultLocale = i18n.DefaultLocale
+	}
+	return &Localizer{translationRepo: translationRepo, defaultLocale: defaultLocale}
+}
+
+// locale повертає мову, для якої потрібен переклад; порожній рядок - базовий вміст
+func (l *Localizer) locale(ctx context.Context) string {
+	locale := models.LocaleFromContext(ctx)
+	if locale == l.defaultLocale {
+		return ""
+	}
+	return locale
+}
+
+// Products підставляє перекладені назву та опис; без перекладу лишається базовий вміст
+func (l *Localizer) Products(ctx context.Context, products ...*models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	translations, err := l.translations(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range products {
+		t, ok := translations[p.ID]
+		if !ok {
+			continue
+		}
+		p.Name = t.Name
+		// опис без перекладу береться з мови за замовчуванням
+		if t.Description != nil {
+			p.Description = t.Description
+		}
+	}
+	return nil
+}
+
+// Names повертає перекладені назви продуктів; продукту без перекладу в результаті немає
+func (l *Localizer) Names(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]string, error) {
+	translations, err := l.translations(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uuid.UUID]string, len(translations))
+	for id, t := range translations {
+		names[id] = t.Name
+	}
+	return names, nil
+}
+
+// translations повертає переклади продуктів мовою запиту за ID продукту
+func (l *Localizer) translations(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.ProductTranslation, error) {
+	locale := l.locale(ctx)
+	if locale == "" || len(ids) == 0 {
+		return nil, nil
+	}
+
+	translations, err := l.translationRepo.GetProductTranslations(ctx, locale, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product translations: %w", err)
+	}
+
+	byProduct := make(map[uuid.UUID]*models.ProductTranslation, len(translations))
+	for _, t := range translations {
+		byProduct[t.ProductID] = t
+	}
+	return byProduct, nil
+}
+
+// localizeCategories підставляє перекладені назву, slug та опис категорій
+func (s *service) localizeCategories(ctx context.Context, categories ...*models.Category) error {
+	locale := s.localizer.locale(ctx)
+	if locale == "" || len(categories) == 0 {
+		return nil
+	}
+
+	translations, err := s.translationRepo.GetCategoryTranslations(ctx, locale)
+	if err != nil {
+		return fmt.Errorf("failed to get category translations: %w", err)
+	}
+
+	byCategory := make(map[uuid.UUID]*models.CategoryTranslation, len(translations))
+	for _, t := range translations {
+		byCategory[t.CategoryID] = t
+	}
+	for _, c := range categories {
+		if t, ok := byCategory[c.ID]; ok {
+			applyCategoryTranslation(c, t)
+		}
+	}
+	return nil
+}
+
+// applyCategoryTranslation замінює вміст категорії перекладом
+func applyCategoryTranslation(category *models.Category, t *models.CategoryTranslation) {
+	category.Name = t.Name
+	category.Slug = t.Slug
+	// опис без перекладу береться з мови за замовчуванням
+	if t.Description != nil {
+		category.Description = t.Description
+	}
+}
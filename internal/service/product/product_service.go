@@ -2,19 +2,68 @@ package product
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/i18n"
+	"github.com/Xiancel/ecommerce/internal/pagination"
 	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/Xiancel/ecommerce/internal/slug"
 	"github.com/google/uuid"
 )
 
+// мінімальна довжина запиту для підказок
+const minSuggestQueryLen = 2
+
+// максимальна довжина SKU
+const maxSKULength = 64
+
+// обмеження тегів продукту
+const (
+	maxTags      = 20
+	maxTagLength = 50
+)
+
+// Config налаштування сервісу продуктів
+type Config struct {
+	SuggestMinSimilarity float64 // мінімальна триграмна схожість для підказок
+	SuggestLimit         int     // максимальна кількість підказок
+	DefaultLocale        string  // мова базового вмісту; для неї переклади не застосовуються
+}
+
 type service struct {
-	productRepo repository.ProductRepository
+	productRepo     repository.ProductRepository
+	searchRuleRepo  repository.SearchRuleRepository
+	categoryRepo    repository.CategoryRepository
+	translationRepo repository.TranslationRepository
+	inventoryRepo   repository.InventoryRepository
+	localizer       *Localizer
+	cfg             Config
 }
 
-func NewService(productRepo repository.ProductRepository) ProductService {
-	return &service{productRepo: productRepo}
+func NewService(productRepo repository.ProductRepository, searchRuleRepo repository.SearchRuleRepository, categoryRepo repository.CategoryRepository, translationRepo repository.TranslationRepository, inventoryRepo repository.InventoryRepository, cfg Config) ProductService {
+	// значення за замовчуванням
+	if cfg.SuggestMinSimilarity <= 0 || cfg.SuggestMinSimilarity > 1 {
+		cfg.SuggestMinSimilarity = 0.3
+	}
+	if cfg.SuggestLimit <= 0 {
+		cfg.SuggestLimit = 10
+	}
+	if cfg.DefaultLocale == "" {
+		cfg.DefaultLocale = i18n.DefaultLocale
+	}
+	return &service{productRepo: productRepo,
+		searchRuleRepo:  searchRuleRepo,
+		categoryRepo:    categoryRepo,
+		translationRepo: translationRepo,
+		inventoryRepo:   inventoryRepo,
+		localizer:       NewLocalizer(translationRepo, cfg.DefaultLocale),
+		cfg:             cfg}
 }
 
 // CheckAvailability перевірка наявність товару
@@ -28,11 +77,91 @@ func (s *service) CheckAvailability(ctx context.Context, id uuid.UUID, quantity
 	if err != nil {
 		return false, nil
 	}
+	// неопублікований або видалений товар недоступний для покупки
+	if !product.IsVisible() {
+		return false, nil
+	}
+	// цифровий товар, товар під замовлення чи передзамовлення можна купити понад залишок
+	if product.IsDigital || product.AllowsBackorder() {
+		return true, nil
+	}
 	return product.Stock >= quantity, nil
 }
 
 // CreateProduct створення товару
 func (s *service) CreateProduct(ctx context.Context, req CreateProductRequest) (*models.Product, error) {
+	// валідація
+	product, err := BuildProduct(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// перевірка SKU на унікальність
+	if product.SKU != nil {
+		existing, err := s.productRepo.GetBySKU(ctx, *product.SKU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check sku: %w", err)
+		}
+		if existing != nil {
+			return nil, ErrSKUExists
+		}
+	}
+
+	// генерація унікального slug з назви
+	product.Slug, err = UniqueSlug(ctx, s.productRepo, product.Name, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// створення товару
+	if err := s.productRepo.Create(ctx, product); err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+
+	return product, nil
+}
+
+// UniqueSlug генерує з назви slug, не зайнятий іншими продуктами (поточними чи старими slug).
+// productID - продукт, для якого генерується slug (uuid.Nil для нового)
+func UniqueSlug(ctx context.Context, productRepo repository.ProductRepository, name string, productID uuid.UUID) (string, error) {
+	base := slug.Make(name)
+	if base == "" {
+		base = "product"
+	}
+
+	result, err := slug.Unique(ctx, base, func(ctx context.Context, candidate string) (bool, error) {
+		return productRepo.SlugTaken(ctx, candidate, productID)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate slug: %w", err)
+	}
+	return result, nil
+}
+
+// normalizeTags приводить теги до нижнього регістру та прибирає порожні й дублікати
+func normalizeTags(tags []string) ([]string, error) {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, ErrInvalidTag
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	if len(result) > maxTags {
+		return nil, ErrInvalidTag
+	}
+	return result, nil
+}
+
+// BuildProduct валідує запит на створення та формує продукт.
+// Використовується також імпортом, щоб правила були однаковими.
+func BuildProduct(req CreateProductRequest) (*models.Product, error) {
 	// валідація
 	if req.Name == "" {
 		return nil, ErrProductNameRequired
@@ -46,6 +175,14 @@ func (s *service) CreateProduct(ctx context.Context, req CreateProductRequest) (
 		return nil, ErrInvalidStock
 	}
 
+	var sku *string
+	if req.SKU != "" {
+		if len(req.SKU) > maxSKULength {
+			return nil, ErrInvalidSKU
+		}
+		sku = &req.SKU
+	}
+
 	var description *string
 	if req.Description != "" {
 		description = &req.Description
@@ -56,21 +193,57 @@ func (s *service) CreateProduct(ctx context.Context, req CreateProductRequest) (
 		imageURL = &req.ImageURL
 	}
 
-	// створення товару
-	product := &models.Product{
-		Name:        req.Name,
-		Description: description,
-		Price:       req.Price,
-		Stock:       req.Stock,
-		CategoryID:  req.CategoryID,
-		ImageURL:    imageURL,
+	// статус: запланована публікація створює чернетку
+	status := req.Status
+	if status == "" {
+		status = models.ProductStatusPublished
+		if req.PublishAt != nil && req.PublishAt.After(time.Now()) {
+			status = models.ProductStatusDraft
+		}
 	}
-
-	if err := s.productRepo.Create(ctx, product); err != nil {
-		return nil, fmt.Errorf("failed to create product: %w", err)
+	if !isValidStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+	if !isValidSchedule(req.PublishAt, req.UnpublishAt) {
+		return nil, ErrInvalidSchedule
 	}
 
-	return product, nil
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// за замовчуванням товар не продається при нульовому залишку
+	policy := req.BackorderPolicy
+	if policy == "" {
+		policy = models.BackorderDeny
+	}
+	if err := validateBackorder(policy, req.AvailableAt); err != nil {
+		return nil, err
+	}
+	if err := validateSale(req.Price, req.SalePrice, req.SaleStartsAt, req.SaleEndsAt); err != nil {
+		return nil, err
+	}
+
+	return &models.Product{
+		SKU:             sku,
+		Name:            req.Name,
+		Description:     description,
+		Price:           req.Price,
+		Stock:           req.Stock,
+		CategoryID:      req.CategoryID,
+		ImageURL:        imageURL,
+		Tags:            tags,
+		Status:          status,
+		PublishAt:       req.PublishAt,
+		UnpublishAt:     req.UnpublishAt,
+		BackorderPolicy: policy,
+		AvailableAt:     req.AvailableAt,
+		IsDigital:       req.IsDigital,
+		SalePrice:       req.SalePrice,
+		SaleStartsAt:    req.SaleStartsAt,
+		SaleEndsAt:      req.SaleEndsAt,
+	}, nil
 }
 
 // GetProduct получення продукта
@@ -81,6 +254,30 @@ func (s *service) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product
 		return nil, ErrProductNotFound
 	}
 
+	// чернетки, архівні та видалені продукти не показуються покупцям
+	if !product.IsVisible() {
+		return nil, ErrProductNotFound
+	}
+
+	if err := s.localizer.Products(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// GetProductBySlug повертає видимий продукт за поточним або старим slug.
+// Якщо slug старий, Slug продукту відрізнятиметься від запитаного
+func (s *service) GetProductBySlug(ctx context.Context, productSlug string) (*models.Product, error) {
+	product, err := s.productRepo.GetBySlug(ctx, productSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil || !product.IsVisible() {
+		return nil, ErrProductNotFound
+	}
+	if err := s.localizer.Products(ctx, product); err != nil {
+		return nil, err
+	}
 	return product, nil
 }
 
@@ -105,67 +302,149 @@ func (s *service) ListProduct(ctx context.Context, filter ProductFilter) (*Produ
 		return nil, ErrInvalidPrice
 	}
 
-	// отримання списка товарів
+	if filter.MinRating != nil && (*filter.MinRating < 0 || *filter.MinRating > 5) {
+		return nil, ErrInvalidRating
+	}
+
+	// за замовчуванням показуються тільки опубліковані продукти
+	status := filter.Status
+	switch {
+	case status == "":
+		status = models.ProductStatusPublished
+	case status == StatusAll:
+		status = ""
+	case !isValidStatus(status):
+		return nil, ErrInvalidStatus
+	}
+
+	// позиція сторінки: курсор має пріоритет над зміщенням
+	sort := productSort(filter)
+	var cursor *pagination.Cursor
+	if filter.Cursor != "" {
+		var err error
+		cursor, err = pagination.Decode(filter.Cursor, sort)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		filter.Offset = 0
+		if !cursor.IsKeyset() {
+			filter.Offset = cursor.Offset
+		}
+	}
+
+	// отримання списка товарів (на один більше, щоб знати про наступну сторінку)
 	repoFilter := models.ListFilter{
 		CategoryID: filter.CategoryID,
 		MinPrice:   filter.MinPrice,
 		MaxPrice:   filter.MaxPrice,
+		MinRating:  filter.MinRating,
 		Search:     filter.Search,
-		Limit:      filter.Limit,
+		Tag:        strings.ToLower(strings.TrimSpace(filter.Tag)),
+		Status:     status,
+		InStock:    filter.InStock != nil && *filter.InStock,
+		Limit:      filter.Limit + 1,
 		Offset:     filter.Offset,
 		OrderBy:    filter.OrderBy,
 	}
+	if cursor != nil && cursor.IsKeyset() {
+		repoFilter.Cursor = cursor
+	}
 
-	products, err := s.productRepo.List(ctx, repoFilter)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list products: %w", err)
-	}
-	// якщо потрібні тільки продукти в наявность, фільтруємо
-	if filter.InStock != nil && *filter.InStock {
-		filteredProducts := make([]*models.Product, 0)
-		for _, p := range products {
-			if p.Stock > 0 {
-				filteredProducts = append(filteredProducts, p)
-			}
+	var products []*models.Product
+	var explanations []*models.SearchExplanation
+	var page pagination.Page
+	if filter.Search != "" {
+		// пошук з урахуванням правил мерчандайзингу
+		hits, terms, err := s.searchHits(ctx, repoFilter)
+		if err != nil {
+			return nil, listError(err)
+		}
+		repoFilter.SearchTerms = terms
+		hits, page = pagination.Trim(hits, filter.Limit, cursor)
+		products = hitsToProducts(hits)
+		if filter.Debug {
+			explanations = explainHits(hits, terms)
 		}
-		products = filteredProducts
+	} else {
+		var err error
+		products, err = s.productRepo.List(ctx, repoFilter)
+		if err != nil {
+			return nil, listError(err)
+		}
+		products, page = pagination.Trim(products, filter.Limit, cursor)
+	}
+	if filter.Offset > 0 {
+		page.HasPrev = true
 	}
-	return &ProductListResponse{
-		Products: products,
-		Total:    len(products),
-		Limit:    filter.Limit,
-		Offset:   filter.Offset,
-	}, nil
-}
 
-// ReleaseStock повернення товару на склад
-func (s *service) ReleaseStock(ctx context.Context, id uuid.UUID, quantity int) error {
-	// отримання товару за його ID
-	product, err := s.productRepo.GetById(ctx, id)
+	total, estimated, err := s.productRepo.Count(ctx, repoFilter)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to count products: %w", err)
 	}
 
-	product.Stock += quantity
-	return s.productRepo.Update(ctx, product)
+	// курсори формуються до локалізації: сортування за назвою йде за базовою назвою
+	response := &ProductListResponse{
+		Products:       products,
+		Total:          total,
+		TotalEstimated: estimated,
+		Limit:          filter.Limit,
+		Offset:         filter.Offset,
+		Explanations:   explanations,
+	}
+	response.NextCursor, response.PrevCursor = productCursors(products, sort, filter.Limit, filter.Offset, page)
+
+	if err := s.localizer.Products(ctx, products...); err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
-// ReserveStock забирання товару з склада
-func (s *service) ReserveStock(ctx context.Context, id uuid.UUID, quantity int) error {
-	// отримання товару за ID
-	product, err := s.productRepo.GetById(ctx, id)
-	if err != nil {
-		return err
+// productSort повертає сортування сторінок: явне, релевантність для пошуку або новизна
+func productSort(filter ProductFilter) string {
+	switch filter.OrderBy {
+	case "price_asc", "price_desc", "name_asc", "name_desc", "rating_asc", "rating_desc":
+		return filter.OrderBy
+	}
+	if filter.Search != "" {
+		return models.ProductSortRelevance
 	}
+	return models.ProductSortNewest
+}
 
-	// валідація
-	if product.Stock < quantity {
-		return ErrInvalidStock
+// productCursors формує токени сусідніх сторінок. Релевантність не має стабільного ключа,
+// тому її сторінки задаються зміщенням, решта сортувань - крайніми записами сторінки
+func productCursors(products []*models.Product, sort string, limit, offset int, page pagination.Page) (string, string) {
+	var next, prev string
+	if sort == models.ProductSortRelevance {
+		if page.HasNext {
+			next = pagination.Cursor{Sort: sort, Offset: offset + limit}.Encode()
+		}
+		if page.HasPrev {
+			prev = pagination.Cursor{Sort: sort, Offset: max(offset-limit, 0)}.Encode()
+		}
+		return next, prev
 	}
 
-	product.Stock -= quantity
+	if len(products) == 0 {
+		return "", ""
+	}
+	if page.HasNext {
+		last := products[len(products)-1]
+		next = pagination.Cursor{Sort: sort, Values: models.ProductSortValues(last, sort), ID: last.ID.String()}.Encode()
+	}
+	if page.HasPrev {
+		first := products[0]
+		prev = pagination.Cursor{Sort: sort, Values: models.ProductSortValues(first, sort), ID: first.ID.String(), Backward: true}.Encode()
+	}
+	return next, prev
+}
 
-	return s.productRepo.Update(ctx, product)
+// listError перетворює помилку некоректного курсора на помилку сервісу
+func listError(err error) error {
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		return ErrInvalidCursor
+	}
+	return fmt.Errorf("failed to list products: %w", err)
 }
 
 // SearchProduct пошук продука
@@ -188,24 +467,132 @@ func (s *service) SearchProduct(ctx context.Context, query string, limit int, of
 
 	filter := models.ListFilter{
 		Search: query,
+		Status: models.ProductStatusPublished,
 		Limit:  limit,
 		Offset: offset,
 	}
 
-	// повернення товарів по фільтрам пошуку
-	products, err := s.productRepo.List(ctx, filter)
+	// повернення товарів по фільтрам пошуку з урахуванням правил мерчандайзингу
+	hits, _, err := s.searchHits(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search product: %w", err)
 	}
 
+	products := hitsToProducts(hits)
+	if err := s.localizer.Products(ctx, products...); err != nil {
+		return nil, err
+	}
 	return products, nil
 }
 
+// searchHits розширює запит синонімами та виконує пошук з підсиленнями і закріпленнями
+func (s *service) searchHits(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, []string, error) {
+	terms, err := s.expandTerms(ctx, filter.Search)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter.SearchTerms = terms
+
+	hits, err := s.productRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return hits, terms, nil
+}
+
+// expandTerms повертає пошуковий термін разом з його синонімами
+func (s *service) expandTerms(ctx context.Context, query string) ([]string, error) {
+	term := strings.ToLower(strings.TrimSpace(query))
+	terms := []string{term}
+
+	synonyms, err := s.searchRuleRepo.GetSynonyms(ctx, term)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get synonyms: %w", err)
+	}
+	for _, synonym := range synonyms {
+		if synonym != term {
+			terms = append(terms, synonym)
+		}
+	}
+	return terms, nil
+}
+
+// hitsToProducts повертає продукти з результатів пошуку
+func hitsToProducts(hits []*models.ProductSearchHit) []*models.Product {
+	products := make([]*models.Product, len(hits))
+	for i, hit := range hits {
+		product := hit.Product
+		products[i] = &product
+	}
+	return products
+}
+
+// explainHits формує пояснення оцінки кожного результату пошуку
+func explainHits(hits []*models.ProductSearchHit, terms []string) []*models.SearchExplanation {
+	explanations := make([]*models.SearchExplanation, len(hits))
+	for i, hit := range hits {
+		// визначення термінів, які збіглися з назвою або описом
+		matched := []string{}
+		name := strings.ToLower(hit.Name)
+		description := ""
+		if hit.Description != nil {
+			description = strings.ToLower(*hit.Description)
+		}
+		for _, term := range terms {
+			if strings.Contains(name, term) || strings.Contains(description, term) {
+				matched = append(matched, term)
+			}
+		}
+
+		explanations[i] = &models.SearchExplanation{
+			ProductID:    hit.ID,
+			Name:         hit.Name,
+			MatchedTerms: matched,
+			TextScore:    hit.TextScore,
+			Boost:        hit.Boost,
+			Pinned:       hit.PinPosition != nil,
+			PinPosition:  hit.PinPosition,
+			Score:        hit.Score,
+		}
+	}
+	return explanations
+}
+
+// SuggestProduct повертає підказки автодоповнення з урахуванням помилок у запиті
+func (s *service) SuggestProduct(ctx context.Context, query string, limit int) (*SuggestResponse, error) {
+	query = strings.TrimSpace(query)
+	resp := &SuggestResponse{
+		Query:       query,
+		Suggestions: []*models.Suggestion{},
+	}
+
+	// занадто короткий запит не дає корисних триграм
+	if utf8.RuneCountInString(query) < minSuggestQueryLen {
+		return resp, nil
+	}
+
+	// ліміт не може перевищувати налаштований
+	if limit <= 0 || limit > s.cfg.SuggestLimit {
+		limit = s.cfg.SuggestLimit
+	}
+
+	// отримання підказок
+	suggestions, err := s.productRepo.Suggest(ctx, query, s.cfg.SuggestMinSimilarity, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest products: %w", err)
+	}
+	if suggestions != nil {
+		resp.Suggestions = suggestions
+	}
+
+	return resp, nil
+}
+
 // UpdateProduct оновлення товару
 func (s *service) UpdateProduct(ctx context.Context, id uuid.UUID, req UpdateProductRequest) (*models.Product, error) {
 	// получення товару за ID
 	product, err := s.productRepo.GetById(ctx, id)
-	if err != nil {
+	if err != nil || product.DeletedAt != nil {
 		return nil, ErrProductNotFound
 	}
 
@@ -214,6 +601,13 @@ func (s *service) UpdateProduct(ctx context.Context, id uuid.UUID, req UpdatePro
 		if *req.Name == "" {
 			return nil, ErrProductNameRequired
 		}
+		// при перейменуванні slug генерується заново, а старий стає редиректом
+		if *req.Name != product.Name {
+			product.Slug, err = UniqueSlug(ctx, s.productRepo, *req.Name, product.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
 		product.Name = *req.Name
 	}
 	if req.Description != nil {
@@ -225,10 +619,20 @@ func (s *service) UpdateProduct(ctx context.Context, id uuid.UUID, req UpdatePro
 		}
 		product.Price = *req.Price
 	}
+	// зміна залишку - рух складу з причиною, як коригування через склади
+	var stock *models.StockChange
 	if req.Stock != nil {
-		if *req.Stock <= 0 {
+		// залишок комплекту обчислюється із залишків компонентів
+		if product.IsBundle {
+			return nil, ErrBundleStock
+		}
+		if *req.Stock < 0 {
 			return nil, ErrInvalidStock
 		}
+		stock, err = s.stockChange(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 		product.Stock = *req.Stock
 	}
 
@@ -240,10 +644,197 @@ func (s *service) UpdateProduct(ctx context.Context, id uuid.UUID, req UpdatePro
 		product.ImageURL = req.ImageURL
 	}
 
-	// оновлення товару
-	if err := s.productRepo.Update(ctx, product); err != nil {
+	// nil - теги не змінюються, порожній список - видалення всіх тегів
+	if req.Tags != nil {
+		product.Tags, err = normalizeTags(req.Tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.SKU != nil && (product.SKU == nil || *product.SKU != *req.SKU) {
+		if *req.SKU == "" {
+			product.SKU = nil
+		} else {
+			if len(*req.SKU) > maxSKULength {
+				return nil, ErrInvalidSKU
+			}
+			// перевірка SKU на унікальність
+			existing, err := s.productRepo.GetBySKU(ctx, *req.SKU)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check sku: %w", err)
+			}
+			if existing != nil && existing.ID != product.ID {
+				return nil, ErrSKUExists
+			}
+			product.SKU = req.SKU
+		}
+	}
+
+	// статус та розклад публікації
+	if req.Status != nil {
+		if !isValidStatus(*req.Status) {
+			return nil, ErrInvalidStatus
+		}
+		product.Status = *req.Status
+	}
+	if req.PublishAt != nil {
+		product.PublishAt = req.PublishAt
+	}
+	if req.UnpublishAt != nil {
+		product.UnpublishAt = req.UnpublishAt
+	}
+	if !isValidSchedule(product.PublishAt, product.UnpublishAt) {
+		return nil, ErrInvalidSchedule
+	}
+
+	// продаж при нульовому залишку
+	if req.IsDigital != nil {
+		product.IsDigital = *req.IsDigital
+	}
+	if req.BackorderPolicy != nil || req.AvailableAt != nil {
+		if req.BackorderPolicy != nil {
+			product.BackorderPolicy = *req.BackorderPolicy
+		}
+		if req.AvailableAt != nil {
+			product.AvailableAt = req.AvailableAt
+		}
+		if err := validateBackorder(product.BackorderPolicy, product.AvailableAt); err != nil {
+			return nil, err
+		}
+	}
+	if product.IsBundle && (product.IsDigital || product.AllowsBackorder()) {
+		return nil, ErrInvalidBundle
+	}
+
+	// розпродаж: нульова ціна знімає розпродаж разом з датами
+	if req.SalePrice != nil && *req.SalePrice == 0 {
+		product.SalePrice, product.SaleStartsAt, product.SaleEndsAt = nil, nil, nil
+	} else {
+		if req.SalePrice != nil {
+			product.SalePrice = req.SalePrice
+		}
+		if req.SaleStartsAt != nil {
+			product.SaleStartsAt = req.SaleStartsAt
+		}
+		if req.SaleEndsAt != nil {
+			product.SaleEndsAt = req.SaleEndsAt
+		}
+	}
+	if err := validateSale(product.Price, product.SalePrice, product.SaleStartsAt, product.SaleEndsAt); err != nil {
+		return nil, err
+	}
+
+	// оновлення товару разом зі зміною залишку; зміна ціни перевіряє сповіщення про зниження ціни
+	if err := s.productRepo.Update(ctx, product, stock); err != nil {
+		// на складі менше товару, ніж потрібно списати
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInsufficientStock
+		}
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 
 	return product, nil
 }
+
+// stockChange перевіряє склад та причину зміни залишку з запиту на оновлення продукту.
+// Без складу зміна застосовується до складу за замовчуванням, без причини - це коригування
+func (s *service) stockChange(ctx context.Context, req UpdateProductRequest) (*models.StockChange, error) {
+	reason := models.MovementAdjustment
+	if req.StockReason != nil {
+		reason = *req.StockReason
+	}
+	if reason != models.MovementAdjustment && reason != models.MovementReturn {
+		return nil, ErrInvalidStockReason
+	}
+
+	if req.StockLocationID != nil {
+		location, err := s.inventoryRepo.GetLocation(ctx, *req.StockLocationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stock location: %w", err)
+		}
+		// залишок неактивного складу не входить у stock продукту
+		if location == nil || !location.IsActive {
+			return nil, ErrStockLocationNotFound
+		}
+	}
+
+	return &models.StockChange{
+		Stock:      *req.Stock,
+		LocationID: req.StockLocationID,
+		Source:     models.MovementSource{Reason: reason, Note: req.StockNote},
+	}, nil
+}
+
+// DeleteProduct м'яке видалення товару
+func (s *service) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	// перевірка товару на існування
+	product, err := s.productRepo.GetById(ctx, id)
+	if err != nil || product.DeletedAt != nil {
+		return ErrProductNotFound
+	}
+
+	// рядок лишається в базі, тому order_items та історія замовлень не ламаються
+	if err := s.productRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	return nil
+}
+
+// ApplySchedule застосовує заплановані публікації та зняття з публікації
+func (s *service) ApplySchedule(ctx context.Context) (int, int, error) {
+	published, unpublished, err := s.productRepo.ApplySchedule(ctx)
+	if err != nil {
+		return published, unpublished, fmt.Errorf("failed to apply product schedule: %w", err)
+	}
+	return published, unpublished, nil
+}
+
+// isValidStatus перевіряє статус продукту
+func isValidStatus(status string) bool {
+	switch status {
+	case models.ProductStatusDraft, models.ProductStatusPublished, models.ProductStatusArchived:
+		return true
+	}
+	return false
+}
+
+// validateBackorder перевіряє політику продажу при нульовому залишку;
+// передзамовлення потребує дати релізу
+func validateBackorder(policy string, availableAt *time.Time) error {
+	switch policy {
+	case models.BackorderDeny, models.BackorderAllow:
+		return nil
+	case models.BackorderPreorder:
+		if availableAt == nil {
+			return ErrReleaseDateRequired
+		}
+		return nil
+	}
+	return ErrInvalidBackorderPolicy
+}
+
+// isValidSchedule перевіряє, що зняття з публікації настає після публікації
+func isValidSchedule(publishAt, unpublishAt *time.Time) bool {
+	if publishAt == nil || unpublishAt == nil {
+		return true
+	}
+	return unpublishAt.After(*publishAt)
+}
+
+// validateSale перевіряє розпродаж: ціна нижча за звичайну, дати лише разом з ціною
+func validateSale(price float64, salePrice *float64, startsAt, endsAt *time.Time) error {
+	if salePrice == nil {
+		if startsAt != nil || endsAt != nil {
+			return ErrInvalidSaleSchedule
+		}
+		return nil
+	}
+	if *salePrice <= 0 || *salePrice >= price {
+		return ErrInvalidSalePrice
+	}
+	if !isValidSchedule(startsAt, endsAt) {
+		return ErrInvalidSaleSchedule
+	}
+	return nil
+}
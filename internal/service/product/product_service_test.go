@@ -2,9 +2,13 @@ package product
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 	"testing"
+	"time"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/pagination"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -35,25 +39,376 @@ func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilt
 	return args.Get(0).([]*models.Product), args.Error(1)
 }
 
-func (m *MockProductRepository) Update(ctx context.Context, product *models.Product) error {
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
 	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
 	return args.Error(0)
 }
 
-func (m *MockProductRepository) UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error {
-	args := m.Called(ctx, id, quantity)
+type MockSearchRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRuleRepository) CreateSynonymSet(ctx context.Context, set *models.SynonymSet) error {
+	args := m.Called(ctx, set)
 	return args.Error(0)
 }
 
-func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockSearchRuleRepository) ListSynonymSets(ctx context.Context) ([]*models.SynonymSet, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SynonymSet), args.Error(1)
+}
+
+func (m *MockSearchRuleRepository) DeleteSynonymSet(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSearchRuleRepository) GetSynonyms(ctx context.Context, term string) ([]string, error) {
+	args := m.Called(ctx, term)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSearchRuleRepository) UpsertBoost(ctx context.Context, boost *models.ProductBoost) error {
+	args := m.Called(ctx, boost)
+	return args.Error(0)
+}
+
+func (m *MockSearchRuleRepository) ListBoosts(ctx context.Context) ([]*models.ProductBoost, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductBoost), args.Error(1)
+}
+
+func (m *MockSearchRuleRepository) DeleteBoost(ctx context.Context, productID uuid.UUID) error {
+	args := m.Called(ctx, productID)
+	return args.Error(0)
+}
+
+func (m *MockSearchRuleRepository) CreatePin(ctx context.Context, pin *models.SearchPin) error {
+	args := m.Called(ctx, pin)
+	return args.Error(0)
+}
+
+func (m *MockSearchRuleRepository) ListPins(ctx context.Context, query string) ([]*models.SearchPin, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SearchPin), args.Error(1)
+}
+
+func (m *MockSearchRuleRepository) DeletePin(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) Create(ctx context.Context, category *models.Category) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+func (m *MockCategoryRepository) List(ctx context.Context) ([]*models.Category, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Category), args.Error(1)
+}
+func (m *MockCategoryRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+func (m *MockCategoryRepository) GetByName(ctx context.Context, name string) (*models.Category, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+func (m *MockCategoryRepository) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+func (m *MockCategoryRepository) SlugTaken(ctx context.Context, slug string) (bool, error) {
+	args := m.Called(ctx, slug)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranslationRepository) ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetProductTranslations(ctx context.Context, locale string, productIDs []uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, locale, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertProductTranslation(ctx context.Context, translation *models.ProductTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	args := m.Called(ctx, productID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslations(ctx context.Context, locale string) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslationBySlug(ctx context.Context, locale, slug string) (*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CategorySlugTaken(ctx context.Context, locale, slug string, categoryID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, locale, slug, categoryID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertCategoryTranslation(ctx context.Context, translation *models.CategoryTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	args := m.Called(ctx, categoryID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListMissingProducts(ctx context.Context, locale string, limit, offset int) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) ListMissingCategories(ctx context.Context, locale string) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CountMissingProducts(ctx context.Context, locale string) (int, error) {
+	args := m.Called(ctx, locale)
+	return args.Int(0), args.Error(1)
+}
+
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockInventoryRepository) CreateLocation(ctx context.Context, location *models.StockLocation) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) GetLocation(ctx context.Context, id uuid.UUID) (*models.StockLocation, error) {
 	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryRepository) GetLocationByCode(ctx context.Context, code string) (*models.StockLocation, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryRepository) ListLocations(ctx context.Context) ([]*models.StockLocation, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockLocation), args.Error(1)
+}
+func (m *MockInventoryRepository) UpdateLocation(ctx context.Context, location *models.StockLocation) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) ListProductStock(ctx context.Context, productID uuid.UUID) ([]*models.LocationStock, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LocationStock), args.Error(1)
+}
+func (m *MockInventoryRepository) ListStockLevels(ctx context.Context, productIDs []uuid.UUID) ([]*models.LocationStock, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LocationStock), args.Error(1)
+}
+func (m *MockInventoryRepository) SetStock(ctx context.Context, locationID, productID uuid.UUID, quantity int, src models.MovementSource) error {
+	args := m.Called(ctx, locationID, productID, quantity, src)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) AdjustStock(ctx context.Context, locationID, productID uuid.UUID, delta int, src models.MovementSource) error {
+	args := m.Called(ctx, locationID, productID, delta, src)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) Transfer(ctx context.Context, productID, fromID, toID uuid.UUID, quantity int, note *string) (uuid.UUID, error) {
+	args := m.Called(ctx, productID, fromID, toID, quantity, note)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+func (m *MockInventoryRepository) ListMovements(ctx context.Context, filter models.StockMovementFilter) ([]*models.StockMovement, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockMovement), args.Error(1)
+}
+func (m *MockInventoryRepository) Reconcile(ctx context.Context, productID uuid.UUID) ([]*models.StockReconciliation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockReconciliation), args.Error(1)
+}
+func (m *MockInventoryRepository) GetReorderSettings(ctx context.Context, productID uuid.UUID) (*models.ReorderSettings, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReorderSettings), args.Error(1)
+}
+func (m *MockInventoryRepository) UpdateReorderSettings(ctx context.Context, settings *models.ReorderSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+func (m *MockInventoryRepository) ListAlerts(ctx context.Context, filter models.LowStockAlertFilter) ([]*models.LowStockAlert, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LowStockAlert), args.Error(1)
+}
+func (m *MockInventoryRepository) ListUndeliveredAlerts(ctx context.Context, limit int) ([]*models.LowStockAlert, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LowStockAlert), args.Error(1)
+}
+func (m *MockInventoryRepository) MarkAlertsNotified(ctx context.Context, ids []uuid.UUID) error {
+	args := m.Called(ctx, ids)
 	return args.Error(0)
 }
+func (m *MockInventoryRepository) ListReorderNeeded(ctx context.Context) ([]*models.ReorderItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ReorderItem), args.Error(1)
+}
+func (m *MockInventoryRepository) ListOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*models.StockAllocation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StockAllocation), args.Error(1)
+}
 
 func TestCreateProduct_Success(t *testing.T) {
 	//Arrange
 	mockRepo := new(MockProductRepository)
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
 	ctx := context.Background()
 	req := CreateProductRequest{
 		Name:        "Test Product",
@@ -62,6 +417,7 @@ func TestCreateProduct_Success(t *testing.T) {
 		Stock:       10,
 	}
 
+	mockRepo.On("SlugTaken", ctx, "test-product", uuid.Nil).Return(false, nil)
 	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Product")).Return(nil)
 	//Act
 	product, err := service.CreateProduct(ctx, req)
@@ -78,7 +434,7 @@ func TestCreateProduct_Success(t *testing.T) {
 func TestCreateProduct_EmptyName(t *testing.T) {
 	//Arrange
 	mockRepo := new(MockProductRepository)
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
 	ctx := context.Background()
 	req := CreateProductRequest{
 		Name:  "",
@@ -99,7 +455,7 @@ func TestCreateProduct_EmptyName(t *testing.T) {
 func TestCreateProduct_InvalidPrice(t *testing.T) {
 	//Arrange
 	mockRepo := new(MockProductRepository)
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
 	ctx := context.Background()
 	req := CreateProductRequest{
 		Name:  "Test Product",
@@ -120,7 +476,7 @@ func TestCreateProduct_InvalidPrice(t *testing.T) {
 func TestCreateProduct_NotAvailable(t *testing.T) {
 	//Arrange
 	mockRepo := new(MockProductRepository)
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
 	ctx := context.Background()
 
 	productID := uuid.New()
@@ -144,7 +500,7 @@ func TestCreateProduct_NotAvailable(t *testing.T) {
 func TestCreateProduct_NotQuantity(t *testing.T) {
 	//Arrange
 	mockRepo := new(MockProductRepository)
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
 	ctx := context.Background()
 
 	productID := uuid.New()
@@ -159,3 +515,968 @@ func TestCreateProduct_NotQuantity(t *testing.T) {
 
 	mockRepo.AssertNotCalled(t, "GetById")
 }
+
+func TestSuggestProduct_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{SuggestMinSimilarity: 0.4, SuggestLimit: 5})
+	ctx := context.Background()
+
+	suggestions := []*models.Suggestion{
+		{Type: models.SuggestionTypeProduct, ID: uuid.New(), Text: "Headphones", Score: 0.8},
+	}
+
+	mockRepo.On("Suggest", ctx, "hedphones", 0.4, 5).Return(suggestions, nil)
+	//Act
+	resp, err := service.SuggestProduct(ctx, " hedphones ", 50)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "hedphones", resp.Query)
+	assert.Len(t, resp.Suggestions, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSuggestProduct_ShortQuery(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	//Act
+	resp, err := service.SuggestProduct(ctx, "h", 10)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Suggestions)
+	mockRepo.AssertNotCalled(t, "Suggest")
+}
+
+func TestListProduct_SearchWithSynonymsAndDebug(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	mockRuleRepo := new(MockSearchRuleRepository)
+	service := NewService(mockRepo, mockRuleRepo, new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	pinPosition := 1
+	hits := []*models.ProductSearchHit{
+		{Product: models.Product{ID: uuid.New(), Name: "Samsung Television"}, TextScore: 2, Boost: 1.5, Score: 3, PinPosition: &pinPosition},
+		{Product: models.Product{ID: uuid.New(), Name: "TV Stand"}, TextScore: 2, Boost: 1, Score: 2},
+	}
+
+	mockRuleRepo.On("GetSynonyms", ctx, "tv").Return([]string{"tv", "television"}, nil)
+	mockRepo.On("Search", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.Search == "TV" && len(f.SearchTerms) == 2 && f.SearchTerms[1] == "television"
+	})).Return(hits, nil)
+	mockRepo.On("Count", ctx, mock.Anything).Return(2, false, nil)
+
+	//Act
+	resp, err := service.ListProduct(ctx, ProductFilter{Search: "TV", Limit: 20, Debug: true})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Len(t, resp.Products, 2)
+	assert.Len(t, resp.Explanations, 2)
+	assert.True(t, resp.Explanations[0].Pinned)
+	assert.Equal(t, []string{"television"}, resp.Explanations[0].MatchedTerms)
+	assert.Equal(t, []string{"tv"}, resp.Explanations[1].MatchedTerms)
+	mockRepo.AssertExpectations(t)
+	mockRuleRepo.AssertExpectations(t)
+}
+
+func TestCreateProduct_ScheduledPublishCreatesDraft(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	publishAt := time.Now().Add(time.Hour)
+	req := CreateProductRequest{
+		Name:      "Test Product",
+		Price:     10,
+		Stock:     1,
+		PublishAt: &publishAt,
+	}
+
+	mockRepo.On("SlugTaken", ctx, "test-product", uuid.Nil).Return(false, nil)
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Product")).Return(nil)
+	//Act
+	product, err := service.CreateProduct(ctx, req)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.ProductStatusDraft, product.Status)
+	assert.Equal(t, &publishAt, product.PublishAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateProduct_InvalidSchedule(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	publishAt := time.Now().Add(time.Hour)
+	unpublishAt := publishAt.Add(-time.Minute)
+
+	//Act
+	product, err := service.CreateProduct(ctx, CreateProductRequest{
+		Name:        "Test Product",
+		Price:       10,
+		PublishAt:   &publishAt,
+		UnpublishAt: &unpublishAt,
+	})
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrInvalidSchedule, err)
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
+func TestGetProduct_HidesDraft(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusDraft}, nil)
+
+	//Act
+	product, err := service.GetProduct(ctx, productID)
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrProductNotFound, err)
+}
+
+func TestListProduct_DefaultsToPublished(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	mockRepo.On("List", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.Status == models.ProductStatusPublished
+	})).Return([]*models.Product{}, nil)
+	mockRepo.On("Count", ctx, mock.Anything).Return(0, false, nil)
+
+	//Act
+	_, err := service.ListProduct(ctx, ProductFilter{Limit: 20})
+
+	//Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListProduct_AllStatuses(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	mockRepo.On("List", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.Status == ""
+	})).Return([]*models.Product{}, nil)
+	mockRepo.On("Count", ctx, mock.Anything).Return(0, false, nil)
+
+	//Act
+	_, err := service.ListProduct(ctx, ProductFilter{Status: StatusAll, Limit: 20})
+
+	//Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListProduct_InvalidStatus(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+
+	//Act
+	resp, err := service.ListProduct(context.Background(), ProductFilter{Status: "deleted"})
+
+	//Assert
+	assert.Nil(t, resp)
+	assert.Equal(t, ErrInvalidStatus, err)
+	mockRepo.AssertNotCalled(t, "List")
+}
+
+func TestDeleteProduct_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+	mockRepo.On("Delete", ctx, productID).Return(nil)
+
+	//Act
+	err := service.DeleteProduct(ctx, productID)
+
+	//Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteProduct_AlreadyDeleted(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	deletedAt := time.Now()
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, DeletedAt: &deletedAt}, nil)
+
+	//Act
+	err := service.DeleteProduct(ctx, productID)
+
+	//Assert
+	assert.Equal(t, ErrProductNotFound, err)
+	mockRepo.AssertNotCalled(t, "Delete")
+}
+
+func TestCreateProduct_SKUExists(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	sku := "SKU-1"
+	req := CreateProductRequest{
+		SKU:   sku,
+		Name:  "Test Product",
+		Price: 99.99,
+		Stock: 10,
+	}
+
+	mockRepo.On("GetBySKU", ctx, sku).Return(&models.Product{ID: uuid.New(), SKU: &sku}, nil)
+
+	//Act
+	product, err := service.CreateProduct(ctx, req)
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrSKUExists, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListProduct_MinRatingAndSort(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	minRating := 4.0
+
+	mockRepo.On("List", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.MinRating != nil && *f.MinRating == 4.0 && f.OrderBy == "rating_desc"
+	})).Return([]*models.Product{{Name: "Top", RatingAvg: 4.8, RatingCount: 12}}, nil)
+	mockRepo.On("Count", ctx, mock.Anything).Return(1, false, nil)
+
+	//Act
+	resp, err := service.ListProduct(ctx, ProductFilter{MinRating: &minRating, OrderBy: "rating_desc", Limit: 10})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Len(t, resp.Products, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListProduct_InvalidMinRating(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	minRating := 6.0
+
+	//Act
+	resp, err := service.ListProduct(context.Background(), ProductFilter{MinRating: &minRating})
+
+	//Assert
+	assert.Nil(t, resp)
+	assert.Equal(t, ErrInvalidRating, err)
+}
+
+func TestCreateProduct_SlugCollision(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	req := CreateProductRequest{Name: "Навушники Pro", Price: 10, Stock: 1}
+
+	mockRepo.On("SlugTaken", ctx, "navushnyky-pro", uuid.Nil).Return(true, nil)
+	mockRepo.On("SlugTaken", ctx, "navushnyky-pro-2", uuid.Nil).Return(false, nil)
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Product")).Return(nil)
+
+	product, err := service.CreateProduct(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "navushnyky-pro-2", product.Slug)
+}
+
+func TestUpdateProduct_RenameRegeneratesSlug(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	existing := &models.Product{ID: uuid.New(), Name: "Old Name", Slug: "old-name", Price: 10, Status: models.ProductStatusPublished}
+	newName := "New Name"
+
+	mockRepo.On("GetById", ctx, existing.ID).Return(existing, nil)
+	mockRepo.On("SlugTaken", ctx, "new-name", existing.ID).Return(false, nil)
+	mockRepo.On("Update", ctx, mock.MatchedBy(func(p *models.Product) bool {
+		return p.Slug == "new-name" && p.Name == newName
+	}), mock.Anything).Return(nil)
+
+	product, err := service.UpdateProduct(ctx, existing.ID, UpdateProductRequest{Name: &newName})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new-name", product.Slug)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateProduct_StockSetExplicitly(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	existing := &models.Product{ID: uuid.New(), Name: "Навушники", Slug: "navushnyky", Price: 10, Stock: 5, Status: models.ProductStatusPublished}
+	stock := 20
+	note := "поставка"
+
+	mockRepo.On("GetById", ctx, existing.ID).Return(existing, nil)
+	// залишок змінюється в тій самій транзакції, що й поля продукту
+	mockRepo.On("Update", ctx, existing, &models.StockChange{
+		Stock:  20,
+		Source: models.MovementSource{Reason: models.MovementAdjustment, Note: &note},
+	}).Return(nil)
+
+	//Act
+	product, err := service.UpdateProduct(ctx, existing.ID, UpdateProductRequest{Stock: &stock, StockNote: &note})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 20, product.Stock)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateProduct_StockAtLocation(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	mockInventoryRepo := new(MockInventoryRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), mockInventoryRepo, Config{})
+	ctx := context.Background()
+	existing := &models.Product{ID: uuid.New(), Name: "Навушники", Slug: "navushnyky", Price: 10, Stock: 5, Status: models.ProductStatusPublished}
+	locationID := uuid.New()
+	stock := 8
+	reason := models.MovementReturn
+
+	mockRepo.On("GetById", ctx, existing.ID).Return(existing, nil)
+	mockInventoryRepo.On("GetLocation", ctx, locationID).Return(&models.StockLocation{ID: locationID, IsActive: true}, nil)
+	mockRepo.On("Update", ctx, existing, &models.StockChange{
+		Stock:      8,
+		LocationID: &locationID,
+		Source:     models.MovementSource{Reason: models.MovementReturn},
+	}).Return(nil)
+
+	//Act
+	_, err := service.UpdateProduct(ctx, existing.ID, UpdateProductRequest{Stock: &stock, StockLocationID: &locationID, StockReason: &reason})
+
+	//Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateProduct_StockLocationNotFound(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	mockInventoryRepo := new(MockInventoryRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), mockInventoryRepo, Config{})
+	ctx := context.Background()
+	existing := &models.Product{ID: uuid.New(), Name: "Навушники", Slug: "navushnyky", Price: 10, Stock: 5, Status: models.ProductStatusPublished}
+	locationID := uuid.New()
+	stock := 8
+
+	mockRepo.On("GetById", ctx, existing.ID).Return(existing, nil)
+	mockInventoryRepo.On("GetLocation", ctx, locationID).Return(nil, nil)
+
+	//Act
+	product, err := service.UpdateProduct(ctx, existing.ID, UpdateProductRequest{Stock: &stock, StockLocationID: &locationID})
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrStockLocationNotFound, err)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateProduct_StockDecreaseExceedsLocation(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	existing := &models.Product{ID: uuid.New(), Name: "Навушники", Slug: "navushnyky", Price: 10, Stock: 5, Status: models.ProductStatusPublished}
+	stock := 1
+
+	mockRepo.On("GetById", ctx, existing.ID).Return(existing, nil)
+	// товар лежить на іншому складі, а списання йде зі складу за замовчуванням
+	mockRepo.On("Update", ctx, existing, mock.Anything).Return(sql.ErrNoRows)
+
+	//Act
+	product, err := service.UpdateProduct(ctx, existing.ID, UpdateProductRequest{Stock: &stock})
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrInsufficientStock, err)
+}
+
+func TestUpdateProduct_WithoutStockDoesNotAdjustStock(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	existing := &models.Product{ID: uuid.New(), Name: "Навушники", Slug: "navushnyky", Price: 10, Stock: 5, Status: models.ProductStatusPublished}
+	price := 12.5
+
+	// залишок, прочитаний без блокування, не повинен перезаписувати продажі, що відбулися паралельно
+	mockRepo.On("GetById", ctx, existing.ID).Return(existing, nil)
+	mockRepo.On("Update", ctx, existing, (*models.StockChange)(nil)).Return(nil)
+
+	//Act
+	_, err := service.UpdateProduct(ctx, existing.ID, UpdateProductRequest{Price: &price})
+
+	//Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateProduct_SameNameKeepsSlug(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	existing := &models.Product{ID: uuid.New(), Name: "Phone", Slug: "phone-2", Price: 10, Status: models.ProductStatusPublished}
+	name := "Phone"
+
+	mockRepo.On("GetById", ctx, existing.ID).Return(existing, nil)
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Product"), mock.Anything).Return(nil)
+
+	product, err := service.UpdateProduct(ctx, existing.ID, UpdateProductRequest{Name: &name})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "phone-2", product.Slug)
+	mockRepo.AssertNotCalled(t, "SlugTaken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetProductBySlug(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	product := &models.Product{ID: uuid.New(), Slug: "new-name", Status: models.ProductStatusPublished}
+
+	mockRepo.On("GetBySlug", ctx, "old-name").Return(product, nil)
+	mockRepo.On("GetBySlug", ctx, "missing").Return(nil, nil)
+
+	found, err := service.GetProductBySlug(ctx, "old-name")
+	assert.NoError(t, err)
+	assert.Equal(t, "new-name", found.Slug)
+
+	_, err = service.GetProductBySlug(ctx, "missing")
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestGetProductBySlug_HidesDraft(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	mockRepo.On("GetBySlug", ctx, "draft").Return(&models.Product{ID: uuid.New(), Slug: "draft", Status: models.ProductStatusDraft}, nil)
+
+	product, err := service.GetProductBySlug(ctx, "draft")
+
+	assert.ErrorIs(t, err, ErrProductNotFound)
+	assert.Nil(t, product)
+}
+
+func TestCreateCategory_Success(t *testing.T) {
+	mockCategoryRepo := new(MockCategoryRepository)
+	service := NewService(new(MockProductRepository), new(MockSearchRuleRepository), mockCategoryRepo, new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByName", ctx, "Дитячі іграшки").Return(nil, nil)
+	mockCategoryRepo.On("SlugTaken", ctx, "dytiachi-ihrashky").Return(false, nil)
+	mockCategoryRepo.On("Create", ctx, mock.AnythingOfType("*models.Category")).Return(nil)
+
+	category, err := service.CreateCategory(ctx, CreateCategoryRequest{Name: " Дитячі іграшки "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Дитячі іграшки", category.Name)
+	assert.Equal(t, "dytiachi-ihrashky", category.Slug)
+	assert.Nil(t, category.Description)
+}
+
+func TestCreateCategory_Exists(t *testing.T) {
+	mockCategoryRepo := new(MockCategoryRepository)
+	service := NewService(new(MockProductRepository), new(MockSearchRuleRepository), mockCategoryRepo, new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByName", ctx, "Books").Return(&models.Category{ID: uuid.New(), Name: "Books"}, nil)
+
+	category, err := service.CreateCategory(ctx, CreateCategoryRequest{Name: "Books"})
+
+	assert.ErrorIs(t, err, ErrCategoryExists)
+	assert.Nil(t, category)
+	mockCategoryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateCategory_EmptyName(t *testing.T) {
+	service := NewService(new(MockProductRepository), new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+
+	_, err := service.CreateCategory(context.Background(), CreateCategoryRequest{Name: "  "})
+
+	assert.ErrorIs(t, err, ErrCategoryNameRequired)
+}
+
+func TestGetCategoryBySlug_NotFound(t *testing.T) {
+	mockCategoryRepo := new(MockCategoryRepository)
+	service := NewService(new(MockProductRepository), new(MockSearchRuleRepository), mockCategoryRepo, new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetBySlug", ctx, "missing").Return(nil, nil)
+
+	category, err := service.GetCategoryBySlug(ctx, "missing")
+
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+	assert.Nil(t, category)
+}
+
+func TestListCategories_Empty(t *testing.T) {
+	mockCategoryRepo := new(MockCategoryRepository)
+	service := NewService(new(MockProductRepository), new(MockSearchRuleRepository), mockCategoryRepo, new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	mockCategoryRepo.On("List", ctx).Return(nil, nil)
+
+	categories, err := service.ListCategories(ctx)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, categories)
+	assert.Empty(t, categories)
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := normalizeTags([]string{" Summer ", "summer", "", "SALE"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"summer", "sale"}, tags)
+
+	_, err = normalizeTags([]string{strings.Repeat("a", maxTagLength+1)})
+	assert.Equal(t, ErrInvalidTag, err)
+}
+
+func TestCheckAvailability_Backorder(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{
+		ID:              productID,
+		Status:          models.ProductStatusPublished,
+		BackorderPolicy: models.BackorderAllow,
+	}, nil)
+	//Act
+	available, err := service.CheckAvailability(ctx, productID, 5)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.True(t, available)
+}
+
+func TestCreateProduct_DefaultBackorderPolicy(t *testing.T) {
+	//Arrange
+	product, err := BuildProduct(CreateProductRequest{Name: "Test Product", Price: 10})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.BackorderDeny, product.BackorderPolicy)
+}
+
+func TestCreateProduct_PreorderRequiresReleaseDate(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+
+	//Act
+	product, err := service.CreateProduct(context.Background(), CreateProductRequest{
+		Name:            "Test Product",
+		Price:           10,
+		BackorderPolicy: models.BackorderPreorder,
+	})
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrReleaseDateRequired, err)
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
+func TestUpdateProduct_InvalidBackorderPolicy(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+	policy := "always"
+
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Name: "Test Product", BackorderPolicy: models.BackorderDeny}, nil)
+	//Act
+	product, err := service.UpdateProduct(ctx, productID, UpdateProductRequest{BackorderPolicy: &policy})
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrInvalidBackorderPolicy, err)
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestUpdateProduct_BundleStockRejected(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+	stock := 10
+
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Name: "Desk setup", IsBundle: true}, nil)
+	//Act
+	product, err := service.UpdateProduct(ctx, productID, UpdateProductRequest{Stock: &stock})
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrBundleStock, err)
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestUpdateProduct_BundleBackorderRejected(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+	policy := models.BackorderAllow
+
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Name: "Desk setup", IsBundle: true, BackorderPolicy: models.BackorderDeny}, nil)
+	//Act
+	product, err := service.UpdateProduct(ctx, productID, UpdateProductRequest{BackorderPolicy: &policy})
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrInvalidBundle, err)
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestCreateProduct_SalePriceAbovePrice(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	salePrice := 12.0
+
+	//Act
+	product, err := service.CreateProduct(context.Background(), CreateProductRequest{
+		Name:      "Test Product",
+		Price:     10,
+		SalePrice: &salePrice,
+	})
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrInvalidSalePrice, err)
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
+func TestUpdateProduct_ScheduleSale(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+	salePrice := 7.5
+	starts := time.Now().Add(24 * time.Hour)
+	ends := starts.Add(48 * time.Hour)
+
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Name: "Test Product", Price: 10}, nil)
+	mockRepo.On("Update", ctx, mock.MatchedBy(func(p *models.Product) bool {
+		return *p.SalePrice == salePrice && p.SaleStartsAt.Equal(starts) && p.SaleEndsAt.Equal(ends)
+	}), mock.Anything).Return(nil)
+
+	//Act
+	product, err := service.UpdateProduct(ctx, productID, UpdateProductRequest{SalePrice: &salePrice, SaleStartsAt: &starts, SaleEndsAt: &ends})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.False(t, product.SaleActive(time.Now()))
+	assert.Equal(t, 10.0, product.EffectivePrice(time.Now()))
+	assert.Equal(t, salePrice, product.EffectivePrice(starts.Add(time.Hour)))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateProduct_RemoveSale(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+	salePrice := 7.5
+	ends := time.Now().Add(time.Hour)
+	zero := 0.0
+
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Name: "Test Product", Price: 10, SalePrice: &salePrice, SaleEndsAt: &ends}, nil)
+	mockRepo.On("Update", ctx, mock.MatchedBy(func(p *models.Product) bool {
+		return p.SalePrice == nil && p.SaleEndsAt == nil
+	}), mock.Anything).Return(nil)
+
+	//Act
+	product, err := service.UpdateProduct(ctx, productID, UpdateProductRequest{SalePrice: &zero})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, product.EffectivePrice(time.Now()))
+}
+
+func TestUpdateProduct_PriceBelowSale(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+	salePrice := 7.5
+	price := 7.0
+
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Name: "Test Product", Price: 10, SalePrice: &salePrice}, nil)
+
+	//Act
+	product, err := service.UpdateProduct(ctx, productID, UpdateProductRequest{Price: &price})
+
+	//Assert
+	assert.Nil(t, product)
+	assert.Equal(t, ErrInvalidSalePrice, err)
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestGetProduct_Localized(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	mockTranslationRepo := new(MockTranslationRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), mockTranslationRepo, new(MockInventoryRepository), Config{})
+	ctx := models.ContextWithLocale(context.Background(), "en")
+	description := "Опис"
+	productID := uuid.New()
+
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Name: "Навушники", Description: &description, Status: models.ProductStatusPublished}, nil)
+	mockTranslationRepo.On("GetProductTranslations", ctx, "en", []uuid.UUID{productID}).Return([]*models.ProductTranslation{
+		{ProductID: productID, Locale: "en", Name: "Headphones"},
+	}, nil)
+
+	product, err := service.GetProduct(ctx, productID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Headphones", product.Name)
+	// опис без перекладу лишається базовим
+	assert.Equal(t, "Опис", *product.Description)
+}
+
+func TestGetProduct_DefaultLocaleSkipsTranslations(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	mockTranslationRepo := new(MockTranslationRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), mockTranslationRepo, new(MockInventoryRepository), Config{})
+	ctx := models.ContextWithLocale(context.Background(), "uk")
+	productID := uuid.New()
+
+	mockRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Name: "Навушники", Status: models.ProductStatusPublished}, nil)
+
+	product, err := service.GetProduct(ctx, productID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Навушники", product.Name)
+	mockTranslationRepo.AssertNotCalled(t, "GetProductTranslations", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListCategories_Localized(t *testing.T) {
+	mockCategoryRepo := new(MockCategoryRepository)
+	mockTranslationRepo := new(MockTranslationRepository)
+	service := NewService(new(MockProductRepository), new(MockSearchRuleRepository), mockCategoryRepo, mockTranslationRepo, new(MockInventoryRepository), Config{})
+	ctx := models.ContextWithLocale(context.Background(), "en")
+	books := &models.Category{ID: uuid.New(), Name: "Книги", Slug: "knyhy"}
+	toys := &models.Category{ID: uuid.New(), Name: "Іграшки", Slug: "ihrashky"}
+
+	mockCategoryRepo.On("List", ctx).Return([]*models.Category{books, toys}, nil)
+	mockTranslationRepo.On("GetCategoryTranslations", ctx, "en").Return([]*models.CategoryTranslation{
+		{CategoryID: books.ID, Locale: "en", Name: "Books", Slug: "books"},
+	}, nil)
+
+	categories, err := service.ListCategories(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Books", categories[0].Name)
+	assert.Equal(t, "books", categories[0].Slug)
+	assert.Equal(t, "Іграшки", categories[1].Name)
+}
+
+func TestGetCategoryBySlug_LocalizedSlug(t *testing.T) {
+	mockCategoryRepo := new(MockCategoryRepository)
+	mockTranslationRepo := new(MockTranslationRepository)
+	service := NewService(new(MockProductRepository), new(MockSearchRuleRepository), mockCategoryRepo, mockTranslationRepo, new(MockInventoryRepository), Config{})
+	ctx := models.ContextWithLocale(context.Background(), "en")
+	categoryID := uuid.New()
+
+	mockTranslationRepo.On("GetCategoryTranslationBySlug", ctx, "en", "books").Return(&models.CategoryTranslation{
+		CategoryID: categoryID, Locale: "en", Name: "Books", Slug: "books",
+	}, nil)
+	mockCategoryRepo.On("GetById", ctx, categoryID).Return(&models.Category{ID: categoryID, Name: "Книги", Slug: "knyhy"}, nil)
+
+	category, err := service.GetCategoryBySlug(ctx, "books")
+
+	assert.NoError(t, err)
+	assert.Equal(t, categoryID, category.ID)
+	assert.Equal(t, "Books", category.Name)
+	assert.Equal(t, "books", category.Slug)
+	mockCategoryRepo.AssertNotCalled(t, "GetBySlug", mock.Anything, mock.Anything)
+}
+
+func TestGetCategoryBySlug_BaseSlugInOtherLocale(t *testing.T) {
+	mockCategoryRepo := new(MockCategoryRepository)
+	mockTranslationRepo := new(MockTranslationRepository)
+	service := NewService(new(MockProductRepository), new(MockSearchRuleRepository), mockCategoryRepo, mockTranslationRepo, new(MockInventoryRepository), Config{})
+	ctx := models.ContextWithLocale(context.Background(), "en")
+	categoryID := uuid.New()
+
+	mockTranslationRepo.On("GetCategoryTranslationBySlug", ctx, "en", "knyhy").Return(nil, nil)
+	mockCategoryRepo.On("GetBySlug", ctx, "knyhy").Return(&models.Category{ID: categoryID, Name: "Книги", Slug: "knyhy"}, nil)
+	mockTranslationRepo.On("GetCategoryTranslations", ctx, "en").Return([]*models.CategoryTranslation{
+		{CategoryID: categoryID, Locale: "en", Name: "Books", Slug: "books"},
+	}, nil)
+
+	category, err := service.GetCategoryBySlug(ctx, "knyhy")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "books", category.Slug)
+}
+
+func TestListProduct_InStockFilteredInQuery(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+	inStock := true
+
+	// фільтр наявності передається в запит, а не застосовується до сторінки
+	mockRepo.On("List", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.InStock && f.Limit == 3
+	})).Return([]*models.Product{{ID: uuid.New(), Stock: 5}, {ID: uuid.New(), Stock: 1}}, nil)
+	mockRepo.On("Count", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.InStock
+	})).Return(40, false, nil)
+
+	//Act
+	resp, err := service.ListProduct(ctx, ProductFilter{InStock: &inStock, Limit: 2})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Len(t, resp.Products, 2)
+	assert.Equal(t, 40, resp.Total)
+	assert.Empty(t, resp.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListProduct_KeysetCursors(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	products := []*models.Product{
+		{ID: uuid.New(), Price: 10},
+		{ID: uuid.New(), Price: 20},
+		{ID: uuid.New(), Price: 30},
+	}
+	mockRepo.On("List", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.Cursor == nil
+	})).Return(products, nil).Once()
+	mockRepo.On("Count", ctx, mock.Anything).Return(12000, true, nil)
+
+	//Act
+	resp, err := service.ListProduct(ctx, ProductFilter{OrderBy: "price_asc", Limit: 2})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Len(t, resp.Products, 2)
+	assert.Equal(t, 12000, resp.Total)
+	assert.True(t, resp.TotalEstimated)
+	assert.Empty(t, resp.PrevCursor)
+
+	// курсор вказує на останній продукт сторінки
+	cursor, err := pagination.Decode(resp.NextCursor, "price_asc")
+	assert.NoError(t, err)
+	assert.Equal(t, products[1].ID.String(), cursor.ID)
+	assert.Equal(t, []string{"20"}, cursor.Values)
+
+	mockRepo.On("List", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.Cursor != nil && f.Cursor.ID == products[1].ID.String() && f.Offset == 0
+	})).Return(products[2:], nil).Once()
+
+	resp, err = service.ListProduct(ctx, ProductFilter{OrderBy: "price_asc", Limit: 2, Offset: 40, Cursor: resp.NextCursor})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Products, 1)
+	assert.Empty(t, resp.NextCursor)
+	prev, err := pagination.Decode(resp.PrevCursor, "price_asc")
+	assert.NoError(t, err)
+	assert.True(t, prev.Backward)
+	assert.Equal(t, products[2].ID.String(), prev.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListProduct_RelevanceCursorUsesOffset(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	mockRuleRepo := new(MockSearchRuleRepository)
+	service := NewService(mockRepo, mockRuleRepo, new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	ctx := context.Background()
+
+	hits := []*models.ProductSearchHit{
+		{Product: models.Product{ID: uuid.New()}},
+		{Product: models.Product{ID: uuid.New()}},
+		{Product: models.Product{ID: uuid.New()}},
+	}
+	mockRuleRepo.On("GetSynonyms", ctx, "tv").Return([]string{}, nil)
+	mockRepo.On("Search", ctx, mock.MatchedBy(func(f models.ListFilter) bool {
+		return f.Offset == 4 && f.Cursor == nil
+	})).Return(hits, nil)
+	mockRepo.On("Count", ctx, mock.Anything).Return(9, false, nil)
+
+	cursor := pagination.Cursor{Sort: models.ProductSortRelevance, Offset: 4}.Encode()
+
+	//Act
+	resp, err := service.ListProduct(ctx, ProductFilter{Search: "tv", Limit: 2, Cursor: cursor})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Len(t, resp.Products, 2)
+	assert.Equal(t, 4, resp.Offset)
+	next, err := pagination.Decode(resp.NextCursor, models.ProductSortRelevance)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, next.Offset)
+	prev, err := pagination.Decode(resp.PrevCursor, models.ProductSortRelevance)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, prev.Offset)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListProduct_CursorForOtherSort(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockProductRepository)
+	service := NewService(mockRepo, new(MockSearchRuleRepository), new(MockCategoryRepository), new(MockTranslationRepository), new(MockInventoryRepository), Config{})
+	cursor := pagination.Cursor{Sort: "price_asc", Values: []string{"10"}, ID: uuid.NewString()}.Encode()
+
+	//Act
+	resp, err := service.ListProduct(context.Background(), ProductFilter{OrderBy: "name_asc", Limit: 2, Cursor: cursor})
+
+	//Assert
+	assert.Nil(t, resp)
+	assert.Equal(t, ErrInvalidCursor, err)
+	mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,47 @@
+package question
+
+import (
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DTO структури для питань та відповідей
+
+type AskQuestionRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+type AnswerQuestionRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+type VoteAnswerRequest struct {
+	Helpful bool `json:"helpful"`
+}
+
+type ModerateRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending approved rejected"`
+}
+
+// Author автор відповіді
+type Author struct {
+	UserID  uuid.UUID
+	IsAdmin bool
+}
+
+// QuestionFilter фільтр питань; для публічного списку статус завжди approved
+type QuestionFilter struct {
+	Status string `json:"status" validate:"omitempty,oneof=pending approved rejected"`
+	Limit  int    `json:"limit" validate:"min=1,max=100"`
+	Offset int    `json:"offset" validate:"gte=0"`
+}
+
+// QuestionListResponse сторінка питань
+type QuestionListResponse struct {
+	Questions []*models.ProductQuestion `json:"questions"`
+	// Total кількість усіх питань за фільтром (для великих наборів - оцінка)
+	Total          int  `json:"total"`
+	TotalEstimated bool `json:"total_estimated,omitempty"`
+	Limit          int  `json:"limit"`
+	Offset         int  `json:"offset"`
+}
@@ -0,0 +1,18 @@
+package question
+
+import "errors"
+
+// помилки пов'язані з питаннями та відповідями
+var (
+	// Validation errors
+	ErrBodyRequired  = errors.New("text is required")
+	ErrBodyTooLong   = errors.New("text is too long")
+	ErrInvalidStatus = errors.New("invalid status, use pending, approved or rejected")
+
+	// Logic errors
+	ErrProductNotFound     = errors.New("product not found")
+	ErrQuestionNotFound    = errors.New("question not found")
+	ErrAnswerNotFound      = errors.New("answer not found")
+	ErrNotAllowedToAnswer  = errors.New("only admins and verified buyers can answer questions")
+	ErrCannotVoteOwnAnswer = errors.New("you cannot vote for your own answer")
+)
@@ -0,0 +1,25 @@
+package question
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// QuestionService інтерфейс для питань та відповідей про продукти
+type QuestionService interface {
+	AskQuestion(ctx context.Context, userID, productID uuid.UUID, req AskQuestionRequest) (*models.ProductQuestion, error)
+	ListProductQuestions(ctx context.Context, productID uuid.UUID, filter QuestionFilter) (*QuestionListResponse, error)
+	AnswerQuestion(ctx context.Context, author Author, questionID uuid.UUID, req AnswerQuestionRequest) (*models.ProductAnswer, error)
+	VoteAnswer(ctx context.Context, userID, answerID uuid.UUID, req VoteAnswerRequest) (*models.ProductAnswer, error)
+	ListQuestions(ctx context.Context, filter QuestionFilter) (*QuestionListResponse, error)
+	ModerateQuestion(ctx context.Context, id uuid.UUID, req ModerateRequest) (*models.ProductQuestion, error)
+	ListAnswers(ctx context.Context, filter QuestionFilter) ([]*models.ProductAnswer, error)
+	ModerateAnswer(ctx context.Context, id uuid.UUID, req ModerateRequest) (*models.ProductAnswer, error)
+}
+
+// PurchaseChecker перевіряє, чи отримав користувач доставлене замовлення з продуктом
+type PurchaseChecker interface {
+	HasDeliveredPurchase(ctx context.Context, userID, productID uuid.UUID) (bool, error)
+}
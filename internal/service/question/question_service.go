@@ -0,0 +1,356 @@
+package question
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/google/uuid"
+)
+
+// обмеження довжини тексту
+const (
+	maxQuestionLength = 2000
+	maxAnswerLength   = 5000
+)
+
+type service struct {
+	questionRepo repository.QuestionRepository
+	productRepo  repository.ProductRepository
+	purchases    PurchaseChecker
+	notifier     notification.Notifier
+}
+
+func NewService(questionRepo repository.QuestionRepository, productRepo repository.ProductRepository, purchases PurchaseChecker, notifier notification.Notifier) QuestionService {
+	return &service{questionRepo: questionRepo,
+		productRepo: productRepo,
+		purchases:   purchases,
+		notifier:    notifier}
+}
+
+// AskQuestion створює питання, яке з'явиться після модерації
+func (s *service) AskQuestion(ctx context.Context, userID, productID uuid.UUID, req AskQuestionRequest) (*models.ProductQuestion, error) {
+	// валідація
+	body, err := validateBody(req.Body, maxQuestionLength)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	question := &models.ProductQuestion{
+		ProductID: productID,
+		UserID:    userID,
+		Body:      body,
+		Status:    models.QAStatusPending,
+	}
+	if err := s.questionRepo.CreateQuestion(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to create question: %w", err)
+	}
+	return question, nil
+}
+
+// ListProductQuestions повертає схвалені питання продукту разом зі схваленими відповідями
+func (s *service) ListProductQuestions(ctx context.Context, productID uuid.UUID, filter QuestionFilter) (*QuestionListResponse, error) {
+	if err := s.checkProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	// покупцям показуються тільки схвалені питання
+	filter.Status = models.QAStatusApproved
+	response, err := s.listQuestions(ctx, &productID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Questions) == 0 {
+		return response, nil
+	}
+
+	// відповіді для всіх питань сторінки одним запитом
+	ids := make([]uuid.UUID, len(response.Questions))
+	byID := make(map[uuid.UUID]*models.ProductQuestion, len(response.Questions))
+	for i, q := range response.Questions {
+		ids[i] = q.ID
+		byID[q.ID] = q
+		q.Answers = []*models.ProductAnswer{}
+	}
+	answers, err := s.questionRepo.ListAnswers(ctx, models.AnswerFilter{
+		QuestionIDs: ids,
+		Status:      models.QAStatusApproved,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list answers: %w", err)
+	}
+	for _, a := range answers {
+		if q, ok := byID[a.QuestionID]; ok {
+			q.Answers = append(q.Answers, a)
+		}
+	}
+	return response, nil
+}
+
+// AnswerQuestion створює відповідь. Відповіді адміністратора публікуються одразу,
+// відповіді покупців (тільки з перевіреною покупкою) проходять модерацію.
+func (s *service) AnswerQuestion(ctx context.Context, author Author, questionID uuid.UUID, req AnswerQuestionRequest) (*models.ProductAnswer, error) {
+	// валідація
+	body, err := validateBody(req.Body, maxAnswerLength)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := s.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	// покупці бачать і відповідають тільки на схвалені питання
+	if question == nil || (!author.IsAdmin && question.Status != models.QAStatusApproved) {
+		return nil, ErrQuestionNotFound
+	}
+
+	answer := &models.ProductAnswer{
+		QuestionID: questionID,
+		UserID:     author.UserID,
+		Body:       body,
+		IsAdmin:    author.IsAdmin,
+		Status:     models.QAStatusPending,
+	}
+
+	// право відповідати
+	verified, err := s.purchases.HasDeliveredPurchase(ctx, author.UserID, question.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check purchase: %w", err)
+	}
+	answer.VerifiedPurchase = verified
+	if !author.IsAdmin && !verified {
+		return nil, ErrNotAllowedToAnswer
+	}
+	if author.IsAdmin {
+		answer.Status = models.QAStatusApproved
+	}
+
+	if err := s.questionRepo.CreateAnswer(ctx, answer); err != nil {
+		return nil, fmt.Errorf("failed to create answer: %w", err)
+	}
+
+	// відповідь адміністратора на питання, що очікує, схвалює і саме питання
+	if author.IsAdmin && question.Status == models.QAStatusPending {
+		if _, err := s.questionRepo.UpdateQuestionStatus(ctx, question.ID, models.QAStatusApproved); err != nil {
+			return nil, fmt.Errorf("failed to approve question: %w", err)
+		}
+	}
+
+	if answer.Status == models.QAStatusApproved {
+		s.notifyAnswered(ctx, question, answer)
+	}
+	return answer, nil
+}
+
+// VoteAnswer зберігає голос за корисність відповіді
+func (s *service) VoteAnswer(ctx context.Context, userID, answerID uuid.UUID, req VoteAnswerRequest) (*models.ProductAnswer, error) {
+	answer, err := s.questionRepo.GetAnswer(ctx, answerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+	if answer == nil || answer.Status != models.QAStatusApproved {
+		return nil, ErrAnswerNotFound
+	}
+	if answer.UserID == userID {
+		return nil, ErrCannotVoteOwnAnswer
+	}
+
+	answer, err = s.questionRepo.VoteAnswer(ctx, answerID, userID, req.Helpful)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAnswerNotFound
+		}
+		return nil, fmt.Errorf("failed to vote answer: %w", err)
+	}
+	return answer, nil
+}
+
+// ListQuestions повертає питання для модерації (за замовчуванням ті, що очікують)
+func (s *service) ListQuestions(ctx context.Context, filter QuestionFilter) (*QuestionListResponse, error) {
+	if filter.Status == "" {
+		filter.Status = models.QAStatusPending
+	}
+	return s.listQuestions(ctx, nil, filter)
+}
+
+// ModerateQuestion змінює статус питання
+func (s *service) ModerateQuestion(ctx context.Context, id uuid.UUID, req ModerateRequest) (*models.ProductQuestion, error) {
+	if !isValidStatus(req.Status) {
+		return nil, ErrInvalidStatus
+	}
+
+	question, err := s.questionRepo.UpdateQuestionStatus(ctx, id, req.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate question: %w", err)
+	}
+	return question, nil
+}
+
+// ListAnswers повертає відповіді для модерації (за замовчуванням ті, що очікують)
+func (s *service) ListAnswers(ctx context.Context, filter QuestionFilter) ([]*models.ProductAnswer, error) {
+	if filter.Status == "" {
+		filter.Status = models.QAStatusPending
+	}
+	if !isValidStatus(filter.Status) {
+		return nil, ErrInvalidStatus
+	}
+	limit, offset := paginate(filter.Limit, filter.Offset)
+
+	answers, err := s.questionRepo.ListAnswers(ctx, models.AnswerFilter{
+		Status: filter.Status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list answers: %w", err)
+	}
+	if answers == nil {
+		answers = []*models.ProductAnswer{}
+	}
+	return answers, nil
+}
+
+// ModerateAnswer змінює статус відповіді; автор питання отримує сповіщення при схваленні
+func (s *service) ModerateAnswer(ctx context.Context, id uuid.UUID, req ModerateRequest) (*models.ProductAnswer, error) {
+	if !isValidStatus(req.Status) {
+		return nil, ErrInvalidStatus
+	}
+
+	previous, err := s.questionRepo.GetAnswer(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+	if previous == nil {
+		return nil, ErrAnswerNotFound
+	}
+
+	answer, err := s.questionRepo.UpdateAnswerStatus(ctx, id, req.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAnswerNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate answer: %w", err)
+	}
+
+	// сповіщення тільки при першому схваленні
+	if previous.Status != models.QAStatusApproved && answer.Status == models.QAStatusApproved {
+		question, err := s.questionRepo.GetQuestion(ctx, answer.QuestionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get question: %w", err)
+		}
+		if question != nil {
+			s.notifyAnswered(ctx, question, answer)
+		}
+	}
+	return answer, nil
+}
+
+// listQuestions валідує фільтр та повертає сторінку питань
+func (s *service) listQuestions(ctx context.Context, productID *uuid.UUID, filter QuestionFilter) (*QuestionListResponse, error) {
+	if !isValidStatus(filter.Status) {
+		return nil, ErrInvalidStatus
+	}
+	limit, offset := paginate(filter.Limit, filter.Offset)
+
+	repoFilter := models.QuestionFilter{
+		ProductID: productID,
+		Status:    filter.Status,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	questions, err := s.questionRepo.ListQuestions(ctx, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+	if questions == nil {
+		questions = []*models.ProductQuestion{}
+	}
+
+	total, estimated, err := s.questionRepo.CountQuestions(ctx, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count questions: %w", err)
+	}
+	return &QuestionListResponse{
+		Questions:      questions,
+		Total:          total,
+		TotalEstimated: estimated,
+		Limit:          limit,
+		Offset:         offset,
+	}, nil
+}
+
+// checkProduct перевіряє, що продукт існує і доступний покупцям
+func (s *service) checkProduct(ctx context.Context, productID uuid.UUID) error {
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || !product.IsVisible() {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+// notifyAnswered сповіщає автора питання про нову відповідь.
+// Помилка доставки не скасовує відповідь, тому лише логується.
+func (s *service) notifyAnswered(ctx context.Context, question *models.ProductQuestion, answer *models.ProductAnswer) {
+	if question.UserID == answer.UserID {
+		return
+	}
+	n := notification.New(notification.TypeQuestionAnswered, &question.UserID, map[string]interface{}{
+		"product_id":  question.ProductID,
+		"question_id": question.ID,
+		"answer_id":   answer.ID,
+		"question":    question.Body,
+		"answer":      answer.Body,
+	})
+	if err := s.notifier.Notify(ctx, n); err != nil {
+		log.Printf("failed to send %s notification: %v", n.Type, err)
+	}
+}
+
+// validateBody обрізає пробіли та перевіряє довжину тексту
+func validateBody(body string, max int) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrBodyRequired
+	}
+	if utf8.RuneCountInString(body) > max {
+		return "", ErrBodyTooLong
+	}
+	return body, nil
+}
+
+// paginate нормалізує параметри пагінації
+func paginate(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// isValidStatus перевіряє статус модерації
+func isValidStatus(status string) bool {
+	switch status {
+	case models.QAStatusPending, models.QAStatusApproved, models.QAStatusRejected:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,387 @@
+package question
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockQuestionRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuestionRepository) CreateQuestion(ctx context.Context, question *models.ProductQuestion) error {
+	args := m.Called(ctx, question)
+	return args.Error(0)
+}
+func (m *MockQuestionRepository) GetQuestion(ctx context.Context, id uuid.UUID) (*models.ProductQuestion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductQuestion), args.Error(1)
+}
+func (m *MockQuestionRepository) ListQuestions(ctx context.Context, filter models.QuestionFilter) ([]*models.ProductQuestion, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductQuestion), args.Error(1)
+}
+func (m *MockQuestionRepository) CountQuestions(ctx context.Context, filter models.QuestionFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+func (m *MockQuestionRepository) UpdateQuestionStatus(ctx context.Context, id uuid.UUID, status string) (*models.ProductQuestion, error) {
+	args := m.Called(ctx, id, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductQuestion), args.Error(1)
+}
+func (m *MockQuestionRepository) CreateAnswer(ctx context.Context, answer *models.ProductAnswer) error {
+	args := m.Called(ctx, answer)
+	return args.Error(0)
+}
+func (m *MockQuestionRepository) GetAnswer(ctx context.Context, id uuid.UUID) (*models.ProductAnswer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductAnswer), args.Error(1)
+}
+func (m *MockQuestionRepository) ListAnswers(ctx context.Context, filter models.AnswerFilter) ([]*models.ProductAnswer, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductAnswer), args.Error(1)
+}
+func (m *MockQuestionRepository) UpdateAnswerStatus(ctx context.Context, id uuid.UUID, status string) (*models.ProductAnswer, error) {
+	args := m.Called(ctx, id, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductAnswer), args.Error(1)
+}
+func (m *MockQuestionRepository) VoteAnswer(ctx context.Context, answerID, userID uuid.UUID, helpful bool) (*models.ProductAnswer, error) {
+	args := m.Called(ctx, answerID, userID, helpful)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductAnswer), args.Error(1)
+}
+
+type MockPurchaseChecker struct {
+	mock.Mock
+}
+
+func (m *MockPurchaseChecker) HasDeliveredPurchase(ctx context.Context, userID, productID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, productID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, n notification.Notification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+func TestAskQuestion_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockQuestionRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockPurchaseChecker), new(MockNotifier))
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+	mockRepo.On("CreateQuestion", ctx, mock.MatchedBy(func(q *models.ProductQuestion) bool {
+		return q.Body == "Is it waterproof?" && q.Status == models.QAStatusPending && q.UserID == userID
+	})).Return(nil)
+
+	//Act
+	question, err := service.AskQuestion(ctx, userID, productID, AskQuestionRequest{Body: " Is it waterproof? "})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.QAStatusPending, question.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAskQuestion_EmptyBody(t *testing.T) {
+	//Arrange
+	service := NewService(new(MockQuestionRepository), new(MockProductRepository), new(MockPurchaseChecker), new(MockNotifier))
+
+	//Act
+	question, err := service.AskQuestion(context.Background(), uuid.New(), uuid.New(), AskQuestionRequest{Body: "  "})
+
+	//Assert
+	assert.Nil(t, question)
+	assert.Equal(t, ErrBodyRequired, err)
+}
+
+func TestListProductQuestions_GroupsAnswers(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockQuestionRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockPurchaseChecker), new(MockNotifier))
+	ctx := context.Background()
+	productID := uuid.New()
+	q1 := &models.ProductQuestion{ID: uuid.New(), ProductID: productID, Status: models.QAStatusApproved}
+	q2 := &models.ProductQuestion{ID: uuid.New(), ProductID: productID, Status: models.QAStatusApproved}
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+	filter := models.QuestionFilter{ProductID: &productID, Status: models.QAStatusApproved, Limit: 20}
+	mockRepo.On("ListQuestions", ctx, filter).Return([]*models.ProductQuestion{q1, q2}, nil)
+	mockRepo.On("CountQuestions", ctx, filter).Return(27, false, nil)
+	mockRepo.On("ListAnswers", ctx, models.AnswerFilter{
+		QuestionIDs: []uuid.UUID{q1.ID, q2.ID}, Status: models.QAStatusApproved,
+	}).Return([]*models.ProductAnswer{{ID: uuid.New(), QuestionID: q1.ID}, {ID: uuid.New(), QuestionID: q1.ID}}, nil)
+
+	//Act
+	response, err := service.ListProductQuestions(ctx, productID, QuestionFilter{})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Questions, 2)
+	assert.Equal(t, 27, response.Total)
+	assert.Len(t, response.Questions[0].Answers, 2)
+	assert.NotNil(t, response.Questions[1].Answers)
+	assert.Len(t, response.Questions[1].Answers, 0)
+}
+
+func TestAnswerQuestion_AdminPublishesAndNotifies(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockQuestionRepository)
+	mockPurchases := new(MockPurchaseChecker)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockPurchases, mockNotifier)
+	ctx := context.Background()
+	adminID, askerID := uuid.New(), uuid.New()
+	question := &models.ProductQuestion{ID: uuid.New(), ProductID: uuid.New(), UserID: askerID, Status: models.QAStatusPending}
+
+	mockRepo.On("GetQuestion", ctx, question.ID).Return(question, nil)
+	mockPurchases.On("HasDeliveredPurchase", ctx, adminID, question.ProductID).Return(false, nil)
+	mockRepo.On("CreateAnswer", ctx, mock.MatchedBy(func(a *models.ProductAnswer) bool {
+		return a.IsAdmin && a.Status == models.QAStatusApproved
+	})).Return(nil)
+	mockRepo.On("UpdateQuestionStatus", ctx, question.ID, models.QAStatusApproved).Return(question, nil)
+	mockNotifier.On("Notify", ctx, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Type == notification.TypeQuestionAnswered && *n.UserID == askerID && n.Data["question_id"] == question.ID
+	})).Return(nil)
+
+	//Act
+	answer, err := service.AnswerQuestion(ctx, Author{UserID: adminID, IsAdmin: true}, question.ID, AnswerQuestionRequest{Body: "Yes"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.QAStatusApproved, answer.Status)
+	mockRepo.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestAnswerQuestion_BuyerAnswerPendingWithoutNotification(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockQuestionRepository)
+	mockPurchases := new(MockPurchaseChecker)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockPurchases, mockNotifier)
+	ctx := context.Background()
+	buyerID := uuid.New()
+	question := &models.ProductQuestion{ID: uuid.New(), ProductID: uuid.New(), UserID: uuid.New(), Status: models.QAStatusApproved}
+
+	mockRepo.On("GetQuestion", ctx, question.ID).Return(question, nil)
+	mockPurchases.On("HasDeliveredPurchase", ctx, buyerID, question.ProductID).Return(true, nil)
+	mockRepo.On("CreateAnswer", ctx, mock.MatchedBy(func(a *models.ProductAnswer) bool {
+		return !a.IsAdmin && a.VerifiedPurchase && a.Status == models.QAStatusPending
+	})).Return(nil)
+
+	//Act
+	answer, err := service.AnswerQuestion(ctx, Author{UserID: buyerID}, question.ID, AnswerQuestionRequest{Body: "Works great"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.QAStatusPending, answer.Status)
+	mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func TestAnswerQuestion_NotVerifiedBuyer(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockQuestionRepository)
+	mockPurchases := new(MockPurchaseChecker)
+	service := NewService(mockRepo, new(MockProductRepository), mockPurchases, new(MockNotifier))
+	ctx := context.Background()
+	userID := uuid.New()
+	question := &models.ProductQuestion{ID: uuid.New(), ProductID: uuid.New(), Status: models.QAStatusApproved}
+
+	mockRepo.On("GetQuestion", ctx, question.ID).Return(question, nil)
+	mockPurchases.On("HasDeliveredPurchase", ctx, userID, question.ProductID).Return(false, nil)
+
+	//Act
+	answer, err := service.AnswerQuestion(ctx, Author{UserID: userID}, question.ID, AnswerQuestionRequest{Body: "Maybe"})
+
+	//Assert
+	assert.Nil(t, answer)
+	assert.Equal(t, ErrNotAllowedToAnswer, err)
+	mockRepo.AssertNotCalled(t, "CreateAnswer", mock.Anything, mock.Anything)
+}
+
+func TestModerateAnswer_ApprovalNotifiesOnce(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockQuestionRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockPurchaseChecker), mockNotifier)
+	ctx := context.Background()
+	question := &models.ProductQuestion{ID: uuid.New(), UserID: uuid.New()}
+	answerID := uuid.New()
+	approved := &models.ProductAnswer{ID: answerID, QuestionID: question.ID, UserID: uuid.New(), Status: models.QAStatusApproved}
+
+	mockRepo.On("GetAnswer", ctx, answerID).Return(&models.ProductAnswer{ID: answerID, Status: models.QAStatusPending}, nil)
+	mockRepo.On("UpdateAnswerStatus", ctx, answerID, models.QAStatusApproved).Return(approved, nil)
+	mockRepo.On("GetQuestion", ctx, question.ID).Return(question, nil)
+	// помилка доставки не впливає на результат модерації
+	mockNotifier.On("Notify", ctx, mock.Anything).Return(errors.New("webhook down")).Once()
+
+	//Act
+	answer, err := service.ModerateAnswer(ctx, answerID, ModerateRequest{Status: models.QAStatusApproved})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.QAStatusApproved, answer.Status)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestVoteAnswer_OwnAnswer(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockQuestionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockPurchaseChecker), new(MockNotifier))
+	ctx := context.Background()
+	userID, answerID := uuid.New(), uuid.New()
+
+	mockRepo.On("GetAnswer", ctx, answerID).Return(&models.ProductAnswer{ID: answerID, UserID: userID, Status: models.QAStatusApproved}, nil)
+
+	//Act
+	answer, err := service.VoteAnswer(ctx, userID, answerID, VoteAnswerRequest{Helpful: true})
+
+	//Assert
+	assert.Nil(t, answer)
+	assert.Equal(t, ErrCannotVoteOwnAnswer, err)
+}
+
+func TestVoteAnswer_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockQuestionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockPurchaseChecker), new(MockNotifier))
+	ctx := context.Background()
+	userID, answerID := uuid.New(), uuid.New()
+
+	mockRepo.On("GetAnswer", ctx, answerID).Return(&models.ProductAnswer{ID: answerID, UserID: uuid.New(), Status: models.QAStatusApproved}, nil)
+	mockRepo.On("VoteAnswer", ctx, answerID, userID, true).Return(&models.ProductAnswer{ID: answerID, HelpfulCount: 3}, nil)
+
+	//Act
+	answer, err := service.VoteAnswer(ctx, userID, answerID, VoteAnswerRequest{Helpful: true})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, answer.HelpfulCount)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,24 @@
+package recommendation
+
+import (
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DTO структури для рекомендацій
+
+// Config налаштування розрахунку рекомендацій
+type Config struct {
+	// MinSupport мінімальна кількість спільних замовлень для пари продуктів
+	MinSupport int
+	// MaxPerProduct скільки рекомендацій зберігати для кожного продукту
+	MaxPerProduct int
+}
+
+type ProductRecommendationsResponse struct {
+	ProductID uuid.UUID `json:"product_id"`
+	// BoughtTogether продукти, які купують разом з цим продуктом
+	BoughtTogether []*models.Product `json:"bought_together"`
+	// Related бестселери тієї ж категорії, якщо спільних покупок недостатньо
+	Related []*models.Product `json:"related"`
+}
@@ -0,0 +1,12 @@
+package recommendation
+
+import "errors"
+
+// помилки пов'язані з рекомендаціями
+var (
+	// Validation errors
+	ErrInvalidLimit = errors.New("limit must be positive")
+
+	// Logic errors
+	ErrProductNotFound = errors.New("product not found")
+)
@@ -0,0 +1,15 @@
+package recommendation
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// RecommendationService інтерфейс для рекомендацій продуктів
+type RecommendationService interface {
+	GetProductRecommendations(ctx context.Context, productID uuid.UUID, limit int) (*ProductRecommendationsResponse, error)
+	CartRecommendations(ctx context.Context, productIDs []uuid.UUID, limit int) ([]*models.Product, error)
+	Recompute(ctx context.Context) (int, error)
+}
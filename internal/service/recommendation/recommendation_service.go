@@ -0,0 +1,133 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/google/uuid"
+)
+
+// обмеження кількості рекомендацій
+const (
+	defaultLimit = 10
+	maxLimit     = 50
+)
+
+type service struct {
+	recommendationRepo repository.RecommendationRepository
+	productRepo        repository.ProductRepository
+	localizer          *productSrv.Localizer
+	cfg                Config
+}
+
+func NewService(recommendationRepo repository.RecommendationRepository, productRepo repository.ProductRepository, localizer *productSrv.Localizer, cfg Config) RecommendationService {
+	if cfg.MinSupport <= 0 {
+		cfg.MinSupport = 2
+	}
+	if cfg.MaxPerProduct <= 0 {
+		cfg.MaxPerProduct = 20
+	}
+	return &service{recommendationRepo: recommendationRepo,
+		productRepo: productRepo,
+		localizer:   localizer,
+		cfg:         cfg}
+}
+
+// GetProductRecommendations повертає продукти, які купують разом з продуктом.
+// Якщо спільних покупок недостатньо, список доповнюється бестселерами тієї ж категорії
+func (s *service) GetProductRecommendations(ctx context.Context, productID uuid.UUID, limit int) (*ProductRecommendationsResponse, error) {
+	limit, err := normalizeLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// перевірка продукту
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || !product.IsVisible() {
+		return nil, ErrProductNotFound
+	}
+
+	boughtTogether, err := s.recommendationRepo.ListForProduct(ctx, productID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommendations: %w", err)
+	}
+
+	resp := &ProductRecommendationsResponse{
+		ProductID:      productID,
+		BoughtTogether: boughtTogether,
+		Related:        []*models.Product{},
+	}
+	if resp.BoughtTogether == nil {
+		resp.BoughtTogether = []*models.Product{}
+	}
+
+	// доповнення бестселерами категорії
+	if missing := limit - len(boughtTogether); missing > 0 {
+		exclude := []uuid.UUID{productID}
+		for _, p := range boughtTogether {
+			exclude = append(exclude, p.ID)
+		}
+
+		related, err := s.recommendationRepo.CategoryBestsellers(ctx, product.CategoryID, exclude, missing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bestsellers: %w", err)
+		}
+		if related != nil {
+			resp.Related = related
+		}
+	}
+
+	products := append(append([]*models.Product{}, resp.BoughtTogether...), resp.Related...)
+	if err := s.localizer.Products(ctx, products...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CartRecommendations повертає "разом з цим купують" для товарів кошика
+func (s *service) CartRecommendations(ctx context.Context, productIDs []uuid.UUID, limit int) ([]*models.Product, error) {
+	if len(productIDs) == 0 {
+		return []*models.Product{}, nil
+	}
+	limit, err := normalizeLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.recommendationRepo.ListForProducts(ctx, productIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart recommendations: %w", err)
+	}
+	if products == nil {
+		products = []*models.Product{}
+	}
+	if err := s.localizer.Products(ctx, products...); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// Recompute перераховує рекомендації за історією замовлень (запускається фоновою задачею)
+func (s *service) Recompute(ctx context.Context) (int, error) {
+	count, err := s.recommendationRepo.Recompute(ctx, s.cfg.MinSupport, s.cfg.MaxPerProduct)
+	if err != nil {
+		return 0, fmt.Errorf("failed to recompute recommendations: %w", err)
+	}
+	return count, nil
+}
+
+// normalizeLimit встановлює ліміт за замовчуванням та обмежує максимальний
+func normalizeLimit(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, ErrInvalidLimit
+	case limit == 0:
+		return defaultLimit, nil
+	case limit > maxLimit:
+		return maxLimit, nil
+	}
+	return limit, nil
+}
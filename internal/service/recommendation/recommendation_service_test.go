@@ -0,0 +1,347 @@
+package recommendation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecommendationRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationRepository) Recompute(ctx context.Context, minSupport, maxPerProduct int) (int, error) {
+	args := m.Called(ctx, minSupport, maxPerProduct)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockRecommendationRepository) ListForProduct(ctx context.Context, productID uuid.UUID, limit int) ([]*models.Product, error) {
+	args := m.Called(ctx, productID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+func (m *MockRecommendationRepository) ListForProducts(ctx context.Context, productIDs []uuid.UUID, limit int) ([]*models.Product, error) {
+	args := m.Called(ctx, productIDs, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+func (m *MockRecommendationRepository) CategoryBestsellers(ctx context.Context, categoryID *uuid.UUID, excludeIDs []uuid.UUID, limit int) ([]*models.Product, error) {
+	args := m.Called(ctx, categoryID, excludeIDs, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+func publishedProduct(categoryID *uuid.UUID) *models.Product {
+	return &models.Product{ID: uuid.New(), Name: "Phone", Status: models.ProductStatusPublished, Stock: 5, CategoryID: categoryID}
+}
+
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranslationRepository) ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetProductTranslations(ctx context.Context, locale string, productIDs []uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, locale, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertProductTranslation(ctx context.Context, translation *models.ProductTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	args := m.Called(ctx, productID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslations(ctx context.Context, locale string) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslationBySlug(ctx context.Context, locale, slug string) (*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CategorySlugTaken(ctx context.Context, locale, slug string, categoryID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, locale, slug, categoryID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertCategoryTranslation(ctx context.Context, translation *models.CategoryTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	args := m.Called(ctx, categoryID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListMissingProducts(ctx context.Context, locale string, limit, offset int) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) ListMissingCategories(ctx context.Context, locale string) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CountMissingProducts(ctx context.Context, locale string) (int, error) {
+	args := m.Called(ctx, locale)
+	return args.Int(0), args.Error(1)
+}
+
+func TestGetProductRecommendations_BoughtTogetherOnly(t *testing.T) {
+	recRepo := new(MockRecommendationRepository)
+	productRepo := new(MockProductRepository)
+	srv := NewService(recRepo, productRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""), Config{})
+
+	product := publishedProduct(nil)
+	recs := []*models.Product{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	productRepo.On("GetById", mock.Anything, product.ID).Return(product, nil)
+	recRepo.On("ListForProduct", mock.Anything, product.ID, 2).Return(recs, nil)
+
+	resp, err := srv.GetProductRecommendations(context.Background(), product.ID, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, recs, resp.BoughtTogether)
+	assert.Empty(t, resp.Related)
+	recRepo.AssertNotCalled(t, "CategoryBestsellers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetProductRecommendations_FallbackToCategoryBestsellers(t *testing.T) {
+	recRepo := new(MockRecommendationRepository)
+	productRepo := new(MockProductRepository)
+	srv := NewService(recRepo, productRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""), Config{})
+
+	categoryID := uuid.New()
+	product := publishedProduct(&categoryID)
+	rec := &models.Product{ID: uuid.New()}
+	bestsellers := []*models.Product{{ID: uuid.New()}}
+
+	productRepo.On("GetById", mock.Anything, product.ID).Return(product, nil)
+	recRepo.On("ListForProduct", mock.Anything, product.ID, defaultLimit).Return([]*models.Product{rec}, nil)
+	recRepo.On("CategoryBestsellers", mock.Anything, &categoryID, []uuid.UUID{product.ID, rec.ID}, defaultLimit-1).Return(bestsellers, nil)
+
+	resp, err := srv.GetProductRecommendations(context.Background(), product.ID, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.BoughtTogether, 1)
+	assert.Equal(t, bestsellers, resp.Related)
+	recRepo.AssertExpectations(t)
+}
+
+func TestGetProductRecommendations_ProductNotVisible(t *testing.T) {
+	recRepo := new(MockRecommendationRepository)
+	productRepo := new(MockProductRepository)
+	srv := NewService(recRepo, productRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""), Config{})
+
+	product := publishedProduct(nil)
+	product.Status = models.ProductStatusDraft
+	productRepo.On("GetById", mock.Anything, product.ID).Return(product, nil)
+
+	resp, err := srv.GetProductRecommendations(context.Background(), product.ID, 5)
+
+	assert.ErrorIs(t, err, ErrProductNotFound)
+	assert.Nil(t, resp)
+}
+
+func TestGetProductRecommendations_InvalidLimit(t *testing.T) {
+	srv := NewService(new(MockRecommendationRepository), new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""), Config{})
+
+	resp, err := srv.GetProductRecommendations(context.Background(), uuid.New(), -1)
+
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+	assert.Nil(t, resp)
+}
+
+func TestCartRecommendations(t *testing.T) {
+	recRepo := new(MockRecommendationRepository)
+	srv := NewService(recRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""), Config{})
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	recs := []*models.Product{{ID: uuid.New()}}
+	recRepo.On("ListForProducts", mock.Anything, ids, maxLimit).Return(recs, nil)
+
+	products, err := srv.CartRecommendations(context.Background(), ids, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, recs, products)
+}
+
+func TestCartRecommendations_Localized(t *testing.T) {
+	recRepo := new(MockRecommendationRepository)
+	translationRepo := new(MockTranslationRepository)
+	srv := NewService(recRepo, new(MockProductRepository), productSrv.NewLocalizer(translationRepo, "uk"), Config{})
+	ctx := models.ContextWithLocale(context.Background(), "en")
+
+	ids := []uuid.UUID{uuid.New()}
+	rec := &models.Product{ID: uuid.New(), Name: "Чохол"}
+	recRepo.On("ListForProducts", ctx, ids, defaultLimit).Return([]*models.Product{rec}, nil)
+	translationRepo.On("GetProductTranslations", ctx, "en", []uuid.UUID{rec.ID}).Return([]*models.ProductTranslation{
+		{ProductID: rec.ID, Locale: "en", Name: "Case"},
+	}, nil)
+
+	products, err := srv.CartRecommendations(ctx, ids, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Case", products[0].Name)
+}
+
+func TestCartRecommendations_EmptyCart(t *testing.T) {
+	recRepo := new(MockRecommendationRepository)
+	srv := NewService(recRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""), Config{})
+
+	products, err := srv.CartRecommendations(context.Background(), nil, 5)
+
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+	recRepo.AssertNotCalled(t, "ListForProducts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecompute(t *testing.T) {
+	recRepo := new(MockRecommendationRepository)
+	srv := NewService(recRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""), Config{MinSupport: 3})
+
+	recRepo.On("Recompute", mock.Anything, 3, 20).Return(42, nil)
+
+	count, err := srv.Recompute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, count)
+}
+
+func TestRecompute_Error(t *testing.T) {
+	recRepo := new(MockRecommendationRepository)
+	srv := NewService(recRepo, new(MockProductRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""), Config{})
+
+	recRepo.On("Recompute", mock.Anything, 2, 20).Return(0, errors.New("db down"))
+
+	count, err := srv.Recompute(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+}
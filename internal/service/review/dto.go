@@ -0,0 +1,37 @@
+package review
+
+import models "github.com/Xiancel/ecommerce/internal/domain"
+
+// DTO структури для відгуків
+
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Title  string `json:"title" validate:"max=255"`
+	Body   string `json:"body" validate:"max=5000"`
+}
+
+type ModerateReviewRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending approved rejected"`
+}
+
+// ReviewFilter фільтр відгуків; для публічного списку статус завжди approved
+type ReviewFilter struct {
+	Status       string `json:"status" validate:"omitempty,oneof=pending approved rejected"`
+	Rating       int    `json:"rating" validate:"omitempty,min=1,max=5"`
+	VerifiedOnly bool   `json:"verified_only"`
+	OrderBy      string `json:"order_by" validate:"omitempty,oneof=newest oldest rating_desc rating_asc"`
+	Limit        int    `json:"limit" validate:"min=1,max=100"`
+	Offset       int    `json:"offset" validate:"gte=0"`
+}
+
+// ReviewListResponse відгуки з агрегованим рейтингом продукту
+type ReviewListResponse struct {
+	Reviews     []*models.Review `json:"reviews"`
+	RatingAvg   *float64         `json:"rating_avg,omitempty"`
+	RatingCount *int             `json:"rating_count,omitempty"`
+	// Total кількість усіх відгуків за фільтром (для великих наборів - оцінка)
+	Total          int  `json:"total"`
+	TotalEstimated bool `json:"total_estimated,omitempty"`
+	Limit          int  `json:"limit"`
+	Offset         int  `json:"offset"`
+}
@@ -0,0 +1,18 @@
+package review
+
+import "errors"
+
+// помилки пов'язані з відгуками
+var (
+	// Validation errors
+	ErrInvalidRating  = errors.New("rating must be between 1 and 5")
+	ErrTitleTooLong   = errors.New("review title must be at most 255 characters")
+	ErrBodyTooLong    = errors.New("review text must be at most 5000 characters")
+	ErrInvalidStatus  = errors.New("invalid review status, use pending, approved or rejected")
+	ErrInvalidOrderBy = errors.New("invalid review sort, use newest, oldest, rating_desc or rating_asc")
+
+	// Logic errors
+	ErrProductNotFound = errors.New("product not found")
+	ErrReviewNotFound  = errors.New("review not found")
+	ErrReviewExists    = errors.New("you have already reviewed this product")
+)
@@ -0,0 +1,17 @@
+package review
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ReviewService інтерфейс для роботи з відгуками та рейтингами
+type ReviewService interface {
+	CreateReview(ctx context.Context, userID, productID uuid.UUID, req CreateReviewRequest) (*models.Review, error)
+	ListProductReviews(ctx context.Context, productID uuid.UUID, filter ReviewFilter) (*ReviewListResponse, error)
+	ListReviews(ctx context.Context, filter ReviewFilter) (*ReviewListResponse, error)
+	ModerateReview(ctx context.Context, id uuid.UUID, req ModerateReviewRequest) (*models.Review, error)
+	DeleteReview(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,203 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/google/uuid"
+)
+
+// обмеження довжини відгуку
+const (
+	maxTitleLength = 255
+	maxBodyLength  = 5000
+)
+
+type service struct {
+	reviewRepo  repository.ReviewRepository
+	productRepo repository.ProductRepository
+}
+
+func NewService(reviewRepo repository.ReviewRepository, productRepo repository.ProductRepository) ReviewService {
+	return &service{reviewRepo: reviewRepo,
+		productRepo: productRepo}
+}
+
+// CreateReview створює відгук, який з'явиться після модерації.
+// Відгук позначається як "перевірена покупка", якщо користувач отримав доставлене замовлення з продуктом.
+func (s *service) CreateReview(ctx context.Context, userID, productID uuid.UUID, req CreateReviewRequest) (*models.Review, error) {
+	// валідація
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, ErrInvalidRating
+	}
+	title := strings.TrimSpace(req.Title)
+	body := strings.TrimSpace(req.Body)
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return nil, ErrTitleTooLong
+	}
+	if utf8.RuneCountInString(body) > maxBodyLength {
+		return nil, ErrBodyTooLong
+	}
+
+	// перевірка продукту
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || !product.IsVisible() {
+		return nil, ErrProductNotFound
+	}
+
+	// один відгук на продукт від користувача
+	existing, err := s.reviewRepo.GetByUserAndProduct(ctx, userID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check review: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrReviewExists
+	}
+
+	verified, err := s.reviewRepo.HasDeliveredPurchase(ctx, userID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check purchase: %w", err)
+	}
+
+	// створення відгуку
+	review := &models.Review{
+		ProductID:        productID,
+		UserID:           userID,
+		Rating:           req.Rating,
+		VerifiedPurchase: verified,
+		Status:           models.ReviewStatusPending,
+	}
+	if title != "" {
+		review.Title = &title
+	}
+	if body != "" {
+		review.Body = &body
+	}
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+	return review, nil
+}
+
+// ListProductReviews повертає схвалені відгуки продукту з агрегованим рейтингом
+func (s *service) ListProductReviews(ctx context.Context, productID uuid.UUID, filter ReviewFilter) (*ReviewListResponse, error) {
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || !product.IsVisible() {
+		return nil, ErrProductNotFound
+	}
+
+	// покупцям показуються тільки схвалені відгуки
+	filter.Status = models.ReviewStatusApproved
+	response, err := s.list(ctx, &productID, filter)
+	if err != nil {
+		return nil, err
+	}
+	response.RatingAvg = &product.RatingAvg
+	response.RatingCount = &product.RatingCount
+	return response, nil
+}
+
+// ListReviews повертає відгуки для модерації (за замовчуванням ті, що очікують)
+func (s *service) ListReviews(ctx context.Context, filter ReviewFilter) (*ReviewListResponse, error) {
+	if filter.Status == "" {
+		filter.Status = models.ReviewStatusPending
+	}
+	return s.list(ctx, nil, filter)
+}
+
+// ModerateReview змінює статус відгуку; рейтинг продукту перераховується
+func (s *service) ModerateReview(ctx context.Context, id uuid.UUID, req ModerateReviewRequest) (*models.Review, error) {
+	if !isValidStatus(req.Status) {
+		return nil, ErrInvalidStatus
+	}
+
+	review, err := s.reviewRepo.UpdateStatus(ctx, id, req.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+	return review, nil
+}
+
+// DeleteReview видаляє відгук
+func (s *service) DeleteReview(ctx context.Context, id uuid.UUID) error {
+	if err := s.reviewRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrReviewNotFound
+		}
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+	return nil
+}
+
+// list валідує фільтр та повертає сторінку відгуків
+func (s *service) list(ctx context.Context, productID *uuid.UUID, filter ReviewFilter) (*ReviewListResponse, error) {
+	// пагінація
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	// валідація
+	if !isValidStatus(filter.Status) {
+		return nil, ErrInvalidStatus
+	}
+	if filter.Rating != 0 && (filter.Rating < 1 || filter.Rating > 5) {
+		return nil, ErrInvalidRating
+	}
+	switch filter.OrderBy {
+	case "", "newest", "oldest", "rating_desc", "rating_asc":
+	default:
+		return nil, ErrInvalidOrderBy
+	}
+
+	repoFilter := models.ReviewFilter{
+		ProductID:    productID,
+		Status:       filter.Status,
+		Rating:       filter.Rating,
+		VerifiedOnly: filter.VerifiedOnly,
+		Limit:        filter.Limit,
+		Offset:       filter.Offset,
+		OrderBy:      filter.OrderBy,
+	}
+	reviews, err := s.reviewRepo.List(ctx, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	if reviews == nil {
+		reviews = []*models.Review{}
+	}
+
+	total, estimated, err := s.reviewRepo.Count(ctx, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reviews: %w", err)
+	}
+	return &ReviewListResponse{
+		Reviews:        reviews,
+		Total:          total,
+		TotalEstimated: estimated,
+		Limit:          filter.Limit,
+		Offset:         filter.Offset,
+	}, nil
+}
+
+// isValidStatus перевіряє статус модерації
+func isValidStatus(status string) bool {
+	switch status {
+	case models.ReviewStatusPending, models.ReviewStatusApproved, models.ReviewStatusRejected:
+		return true
+	}
+	return false
+}
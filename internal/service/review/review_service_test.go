@@ -0,0 +1,318 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewRepository) Create(ctx context.Context, review *models.Review) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+func (m *MockReviewRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Review, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Review), args.Error(1)
+}
+func (m *MockReviewRepository) GetByUserAndProduct(ctx context.Context, userID, productID uuid.UUID) (*models.Review, error) {
+	args := m.Called(ctx, userID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Review), args.Error(1)
+}
+func (m *MockReviewRepository) List(ctx context.Context, filter models.ReviewFilter) ([]*models.Review, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Review), args.Error(1)
+}
+func (m *MockReviewRepository) Count(ctx context.Context, filter models.ReviewFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+func (m *MockReviewRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) (*models.Review, error) {
+	args := m.Called(ctx, id, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Review), args.Error(1)
+}
+func (m *MockReviewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockReviewRepository) HasDeliveredPurchase(ctx context.Context, userID, productID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, productID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+func TestCreateReview_VerifiedPurchase(t *testing.T) {
+	//Arrange
+	mockReviewRepo := new(MockReviewRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockReviewRepo, mockProductRepo)
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+	mockReviewRepo.On("GetByUserAndProduct", ctx, userID, productID).Return(nil, nil)
+	mockReviewRepo.On("HasDeliveredPurchase", ctx, userID, productID).Return(true, nil)
+	mockReviewRepo.On("Create", ctx, mock.MatchedBy(func(r *models.Review) bool {
+		return r.Rating == 5 && r.VerifiedPurchase && r.Status == models.ReviewStatusPending &&
+			r.Title == nil && *r.Body == "Great"
+	})).Return(nil)
+
+	//Act
+	review, err := service.CreateReview(ctx, userID, productID, CreateReviewRequest{Rating: 5, Body: "  Great "})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.True(t, review.VerifiedPurchase)
+	mockReviewRepo.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestCreateReview_InvalidRating(t *testing.T) {
+	//Arrange
+	service := NewService(new(MockReviewRepository), new(MockProductRepository))
+
+	//Act
+	review, err := service.CreateReview(context.Background(), uuid.New(), uuid.New(), CreateReviewRequest{Rating: 6})
+
+	//Assert
+	assert.Nil(t, review)
+	assert.Equal(t, ErrInvalidRating, err)
+}
+
+func TestCreateReview_AlreadyReviewed(t *testing.T) {
+	//Arrange
+	mockReviewRepo := new(MockReviewRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockReviewRepo, mockProductRepo)
+	ctx := context.Background()
+	userID, productID := uuid.New(), uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+	mockReviewRepo.On("GetByUserAndProduct", ctx, userID, productID).Return(&models.Review{ID: uuid.New()}, nil)
+
+	//Act
+	review, err := service.CreateReview(ctx, userID, productID, CreateReviewRequest{Rating: 4})
+
+	//Assert
+	assert.Nil(t, review)
+	assert.Equal(t, ErrReviewExists, err)
+	mockReviewRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateReview_ProductNotVisible(t *testing.T) {
+	//Arrange
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(new(MockReviewRepository), mockProductRepo)
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusDraft}, nil)
+
+	//Act
+	review, err := service.CreateReview(ctx, uuid.New(), productID, CreateReviewRequest{Rating: 4})
+
+	//Assert
+	assert.Nil(t, review)
+	assert.Equal(t, ErrProductNotFound, err)
+}
+
+func TestListProductReviews_OnlyApproved(t *testing.T) {
+	//Arrange
+	mockReviewRepo := new(MockReviewRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockReviewRepo, mockProductRepo)
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{
+		ID: productID, Status: models.ProductStatusPublished, RatingAvg: 4.5, RatingCount: 2,
+	}, nil)
+	filter := models.ReviewFilter{
+		ProductID: &productID,
+		Status:    models.ReviewStatusApproved,
+		Limit:     20,
+		OrderBy:   "rating_desc",
+	}
+	mockReviewRepo.On("List", ctx, filter).Return([]*models.Review{{Rating: 5}, {Rating: 4}}, nil)
+	mockReviewRepo.On("Count", ctx, filter).Return(35, false, nil)
+
+	//Act
+	response, err := service.ListProductReviews(ctx, productID, ReviewFilter{Status: models.ReviewStatusPending, OrderBy: "rating_desc"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Reviews, 2)
+	// загальна кількість за фільтром, а не розмір сторінки
+	assert.Equal(t, 35, response.Total)
+	assert.Equal(t, 4.5, *response.RatingAvg)
+	assert.Equal(t, 2, *response.RatingCount)
+	mockReviewRepo.AssertExpectations(t)
+}
+
+func TestListReviews_InvalidOrderBy(t *testing.T) {
+	//Arrange
+	service := NewService(new(MockReviewRepository), new(MockProductRepository))
+
+	//Act
+	response, err := service.ListReviews(context.Background(), ReviewFilter{OrderBy: "price"})
+
+	//Assert
+	assert.Nil(t, response)
+	assert.Equal(t, ErrInvalidOrderBy, err)
+}
+
+func TestModerateReview_Success(t *testing.T) {
+	//Arrange
+	mockReviewRepo := new(MockReviewRepository)
+	service := NewService(mockReviewRepo, new(MockProductRepository))
+	ctx := context.Background()
+	reviewID := uuid.New()
+
+	mockReviewRepo.On("UpdateStatus", ctx, reviewID, models.ReviewStatusApproved).
+		Return(&models.Review{ID: reviewID, Status: models.ReviewStatusApproved}, nil)
+
+	//Act
+	review, err := service.ModerateReview(ctx, reviewID, ModerateReviewRequest{Status: models.ReviewStatusApproved})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReviewStatusApproved, review.Status)
+	mockReviewRepo.AssertExpectations(t)
+}
+
+func TestModerateReview_NotFound(t *testing.T) {
+	//Arrange
+	mockReviewRepo := new(MockReviewRepository)
+	service := NewService(mockReviewRepo, new(MockProductRepository))
+	ctx := context.Background()
+	reviewID := uuid.New()
+
+	mockReviewRepo.On("UpdateStatus", ctx, reviewID, models.ReviewStatusRejected).Return(nil, sql.ErrNoRows)
+
+	//Act
+	review, err := service.ModerateReview(ctx, reviewID, ModerateReviewRequest{Status: models.ReviewStatusRejected})
+
+	//Assert
+	assert.Nil(t, review)
+	assert.Equal(t, ErrReviewNotFound, err)
+}
+
+func TestModerateReview_InvalidStatus(t *testing.T) {
+	//Arrange
+	service := NewService(new(MockReviewRepository), new(MockProductRepository))
+
+	//Act
+	review, err := service.ModerateReview(context.Background(), uuid.New(), ModerateReviewRequest{Status: "spam"})
+
+	//Assert
+	assert.Nil(t, review)
+	assert.Equal(t, ErrInvalidStatus, err)
+}
@@ -0,0 +1,42 @@
+package subscription
+
+import (
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DTO структури для підписок
+
+type SubscriptionItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1,max=100"`
+}
+
+type CreateSubscriptionRequest struct {
+	Items []SubscriptionItemRequest `json:"items" validate:"required,min=1,max=20,dive"`
+	// IntervalWeeks доставка кожні N тижнів
+	IntervalWeeks   int                    `json:"interval_weeks" validate:"required,min=1,max=52"`
+	ShippingAddress models.ShippingAddress `json:"shipping_address" validate:"required"`
+	PaymentMethod   string                 `json:"payment_method" validate:"required,oneof=card cash"`
+	// PaymentReference токен збереженої картки; обов'язковий для card
+	PaymentReference string `json:"payment_reference"`
+	// StartAt дата першої доставки (за замовчуванням - одразу)
+	StartAt *time.Time `json:"start_at"`
+}
+
+// UpdateSubscriptionRequest nil - поле не змінюється; Items замінює весь список товарів
+type UpdateSubscriptionRequest struct {
+	Items            []SubscriptionItemRequest `json:"items" validate:"omitempty,min=1,max=20,dive"`
+	IntervalWeeks    *int                      `json:"interval_weeks" validate:"omitempty,min=1,max=52"`
+	ShippingAddress  *models.ShippingAddress   `json:"shipping_address"`
+	PaymentMethod    *string                   `json:"payment_method" validate:"omitempty,oneof=card cash"`
+	PaymentReference *string                   `json:"payment_reference"`
+}
+
+type SubscriptionResponse struct {
+	models.Subscription
+	// Orders останні замовлення, створені підпискою
+	Orders []*models.SubscriptionOrder `json:"orders"`
+}
@@ -0,0 +1,26 @@
+package subscription
+
+import "errors"
+
+// помилки пов'язані з підписками
+var (
+	// Validate errors
+	ErrItemsRequired            = errors.New("subscription must contain at least one item")
+	ErrTooManyItems             = errors.New("subscription can contain at most 20 items")
+	ErrInvalidQuantity          = errors.New("item quantity must be between 1 and 100")
+	ErrDuplicateItem            = errors.New("product is listed more than once")
+	ErrInvalidInterval          = errors.New("interval must be between 1 and 52 weeks")
+	ErrShippingAddressRequired  = errors.New("shipping address is required")
+	ErrInvalidPaymentMethod     = errors.New("payment method must be card or cash")
+	ErrPaymentReferenceRequired = errors.New("card payment requires a payment reference")
+
+	// Logic errors
+	ErrProductNotFound       = errors.New("product not found")
+	ErrProductUnavailable    = errors.New("product is not available for subscription")
+	ErrSubscriptionNotFound  = errors.New("subscription not found")
+	ErrSubscriptionLimit     = errors.New("subscription limit reached")
+	ErrSubscriptionCancelled = errors.New("subscription is cancelled")
+	ErrInvalidStatus         = errors.New("action is not allowed in the current subscription status")
+	ErrSubscriptionBusy      = errors.New("subscription order is being processed, try again later")
+	ErrSubscriptionChanged   = errors.New("subscription was changed by another request, reload and try again")
+)
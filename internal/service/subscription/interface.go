@@ -0,0 +1,32 @@
+package subscription
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	orderSrv "github.com/Xiancel/ecommerce/internal/service/order"
+	"github.com/google/uuid"
+)
+
+// SubscriptionService інтерфейс для роботи з підписками на регулярну доставку
+type SubscriptionService interface {
+	CreateSubscription(ctx context.Context, userID uuid.UUID, req CreateSubscriptionRequest) (*models.Subscription, error)
+	GetSubscription(ctx context.Context, userID, id uuid.UUID) (*SubscriptionResponse, error)
+	ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error)
+	UpdateSubscription(ctx context.Context, userID, id uuid.UUID, req UpdateSubscriptionRequest) (*models.Subscription, error)
+	PauseSubscription(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error)
+	ResumeSubscription(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error)
+	SkipNext(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error)
+	CancelSubscription(ctx context.Context, userID, id uuid.UUID) error
+	// ProcessDue створює замовлення підписок, для яких настав час, та повторює невдалі оплати;
+	// повертає кількість оброблених підписок
+	ProcessDue(ctx context.Context) (int, error)
+}
+
+// OrderPlacer частина сервісу замовлень, через яку підписка створює та оплачує замовлення
+type OrderPlacer interface {
+	CreateOrder(ctx context.Context, userID uuid.UUID, req orderSrv.CreateOrderRequest) (*models.Order, error)
+	GetOrder(ctx context.Context, id uuid.UUID) (*models.Order, error)
+	UpdateOrderStatus(ctx context.Context, id uuid.UUID, req orderSrv.UpdateOrderRequest) (*models.Order, error)
+	CancelOrder(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,705 @@
+package subscription
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	"github.com/Xiancel/ecommerce/internal/payment"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	orderSrv "github.com/Xiancel/ecommerce/internal/service/order"
+	"github.com/google/uuid"
+)
+
+// обмеження підписок
+const (
+	maxSubscriptions = 10
+	maxItems         = 20
+	maxItemQuantity  = 100
+	maxIntervalWeeks = 52
+	// кількість останніх замовлень у відповіді підписки
+	recentOrdersLimit = 10
+)
+
+// значення за замовчуванням
+const (
+	defaultBatchSize     = 50
+	defaultMaxAttempts   = 3
+	defaultRetryInterval = 24 * time.Hour
+	defaultLease         = 10 * time.Minute
+)
+
+// Config налаштування планувальника підписок
+type Config struct {
+	// BatchSize кількість підписок за один запуск
+	BatchSize int
+	// MaxAttempts кількість спроб оплати циклу, після якої підписка призупиняється (suspended)
+	MaxAttempts int
+	// RetryInterval затримка перед повторною оплатою; зростає з кожною спробою
+	RetryInterval time.Duration
+	// Lease час, на який підписка блокується для інших екземплярів планувальника
+	Lease time.Duration
+}
+
+type service struct {
+	subscriptionRepo repository.SubscriptionRepository
+	productRepo      repository.ProductRepository
+	orders           OrderPlacer
+	gateway          payment.Gateway
+	notifier         notification.Notifier
+	cfg              Config
+}
+
+func NewService(subscriptionRepo repository.SubscriptionRepository, productRepo repository.ProductRepository, orders OrderPlacer, gateway payment.Gateway, notifier notification.Notifier, cfg Config) SubscriptionService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = defaultRetryInterval
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultLease
+	}
+	return &service{subscriptionRepo: subscriptionRepo,
+		productRepo: productRepo,
+		orders:      orders,
+		gateway:     gateway,
+		notifier:    notifier,
+		cfg:         cfg}
+}
+
+// CreateSubscription створює підписку; перше замовлення створюється планувальником у StartAt (або одразу)
+func (s *service) CreateSubscription(ctx context.Context, userID uuid.UUID, req CreateSubscriptionRequest) (*models.Subscription, error) {
+	// валідація
+	if req.IntervalWeeks < 1 || req.IntervalWeeks > maxIntervalWeeks {
+		return nil, ErrInvalidInterval
+	}
+	if !validAddress(req.ShippingAddress) {
+		return nil, ErrShippingAddressRequired
+	}
+	reference, err := validatePayment(req.PaymentMethod, req.PaymentReference)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.validateItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.subscriptionRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count subscriptions: %w", err)
+	}
+	if count >= maxSubscriptions {
+		return nil, ErrSubscriptionLimit
+	}
+
+	nextRunAt := time.Now()
+	if req.StartAt != nil && req.StartAt.After(nextRunAt) {
+		nextRunAt = *req.StartAt
+	}
+	subscription := &models.Subscription{
+		ID:               uuid.New(),
+		UserID:           userID,
+		Status:           models.SubscriptionActive,
+		IntervalWeeks:    req.IntervalWeeks,
+		NextRunAt:        nextRunAt,
+		ShippingAddress:  req.ShippingAddress,
+		PaymentMethod:    req.PaymentMethod,
+		PaymentReference: reference,
+	}
+	for _, item := range items {
+		item.SubscriptionID = subscription.ID
+	}
+	if err := s.subscriptionRepo.Create(ctx, subscription, items); err != nil {
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+	subscription.Items = items
+	return subscription, nil
+}
+
+// GetSubscription повертає підписку користувача з товарами та останніми замовленнями
+func (s *service) GetSubscription(ctx context.Context, userID, id uuid.UUID) (*SubscriptionResponse, error) {
+	subscription, err := s.own(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.setItems(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	orders, err := s.subscriptionRepo.ListOrders(ctx, id, recentOrdersLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscription orders: %w", err)
+	}
+	if orders == nil {
+		orders = []*models.SubscriptionOrder{}
+	}
+	return &SubscriptionResponse{Subscription: *subscription, Orders: orders}, nil
+}
+
+// ListSubscriptions повертає підписки користувача з товарами
+func (s *service) ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error) {
+	subscriptions, err := s.subscriptionRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return []*models.Subscription{}, nil
+	}
+
+	ids := make([]uuid.UUID, len(subscriptions))
+	byID := make(map[uuid.UUID]*models.Subscription, len(subscriptions))
+	for i, subscription := range subscriptions {
+		ids[i] = subscription.ID
+		byID[subscription.ID] = subscription
+	}
+	items, err := s.subscriptionRepo.ListItems(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscription items: %w", err)
+	}
+	for _, item := range items {
+		if subscription, ok := byID[item.SubscriptionID]; ok {
+			subscription.Items = append(subscription.Items, item)
+		}
+	}
+	return subscriptions, nil
+}
+
+// UpdateSubscription змінює товари, інтервал, адресу або платіжний засіб.
+// Новий платіжний засіб одразу повторює оплату (past_due) або відновлює призупинену підписку (suspended)
+func (s *service) UpdateSubscription(ctx context.Context, userID, id uuid.UUID, req UpdateSubscriptionRequest) (*models.Subscription, error) {
+	subscription, err := s.editable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Status == models.SubscriptionCancelled {
+		return nil, ErrSubscriptionCancelled
+	}
+
+	// валідація
+	if req.IntervalWeeks != nil {
+		if *req.IntervalWeeks < 1 || *req.IntervalWeeks > maxIntervalWeeks {
+			return nil, ErrInvalidInterval
+		}
+		subscription.IntervalWeeks = *req.IntervalWeeks
+	}
+	if req.ShippingAddress != nil {
+		if !validAddress(*req.ShippingAddress) {
+			return nil, ErrShippingAddressRequired
+		}
+		subscription.ShippingAddress = *req.ShippingAddress
+	}
+	paymentChanged := req.PaymentMethod != nil || req.PaymentReference != nil
+	if paymentChanged {
+		method := subscription.PaymentMethod
+		if req.PaymentMethod != nil {
+			method = *req.PaymentMethod
+		}
+		reference := ""
+		if req.PaymentReference != nil {
+			reference = *req.PaymentReference
+		} else if subscription.PaymentReference != nil {
+			reference = *subscription.PaymentReference
+		}
+		subscription.PaymentReference, err = validatePayment(method, reference)
+		if err != nil {
+			return nil, err
+		}
+		subscription.PaymentMethod = method
+	}
+	var items []*models.SubscriptionItem
+	if req.Items != nil {
+		if items, err = s.validateItems(ctx, req.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	// новий платіжний засіб - нова спроба оплати
+	now := time.Now()
+	if paymentChanged {
+		switch subscription.Status {
+		case models.SubscriptionPastDue:
+			subscription.RetryAt = &now
+		case models.SubscriptionSuspended:
+			subscription.Status = models.SubscriptionActive
+			subscription.NextRunAt = now
+			subscription.FailedAttempts = 0
+			subscription.LastError = nil
+		}
+	}
+
+	if items != nil {
+		if err := s.subscriptionRepo.ReplaceItems(ctx, subscription, items); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrSubscriptionChanged
+			}
+			return nil, fmt.Errorf("failed to update subscription items: %w", err)
+		}
+	}
+	if err := s.save(ctx, subscription); err != nil {
+		return nil, err
+	}
+	if err := s.setItems(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// PauseSubscription призупиняє доставки до відновлення
+func (s *service) PauseSubscription(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error) {
+	subscription, err := s.editable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch subscription.Status {
+	case models.SubscriptionPaused:
+		return subscription, nil
+	case models.SubscriptionCancelled:
+		return nil, ErrSubscriptionCancelled
+	case models.SubscriptionActive:
+	default:
+		// неоплачене замовлення потрібно спершу оплатити або скасувати підписку
+		return nil, ErrInvalidStatus
+	}
+
+	now := time.Now()
+	subscription.Status = models.SubscriptionPaused
+	subscription.PausedAt = &now
+	if err := s.save(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// ResumeSubscription відновлює призупинену підписку; пропущена дата доставки переноситься на зараз
+func (s *service) ResumeSubscription(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error) {
+	subscription, err := s.editable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch subscription.Status {
+	case models.SubscriptionActive:
+		return subscription, nil
+	case models.SubscriptionCancelled:
+		return nil, ErrSubscriptionCancelled
+	case models.SubscriptionPaused:
+	default:
+		return nil, ErrInvalidStatus
+	}
+
+	now := time.Now()
+	subscription.Status = models.SubscriptionActive
+	subscription.PausedAt = nil
+	if subscription.NextRunAt.Before(now) {
+		subscription.NextRunAt = now
+	}
+	if err := s.save(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// SkipNext пропускає найближчу доставку
+func (s *service) SkipNext(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error) {
+	subscription, err := s.editable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch subscription.Status {
+	case models.SubscriptionCancelled:
+		return nil, ErrSubscriptionCancelled
+	case models.SubscriptionActive, models.SubscriptionPaused:
+	default:
+		return nil, ErrInvalidStatus
+	}
+
+	subscription.NextRunAt = subscription.NextRunAt.AddDate(0, 0, 7*subscription.IntervalWeeks)
+	if err := s.save(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// CancelSubscription скасовує підписку; неоплачене замовлення поточного циклу також скасовується
+func (s *service) CancelSubscription(ctx context.Context, userID, id uuid.UUID) error {
+	subscription, err := s.editable(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if subscription.Status == models.SubscriptionCancelled {
+		return ErrSubscriptionCancelled
+	}
+
+	if subscription.Status == models.SubscriptionPastDue && subscription.LastOrderID != nil {
+		if err := s.orders.CancelOrder(ctx, *subscription.LastOrderID); err != nil && !errors.Is(err, orderSrv.ErrOrderAlreadyCanceled) {
+			return fmt.Errorf("failed to cancel unpaid order: %w", err)
+		}
+	}
+
+	now := time.Now()
+	subscription.Status = models.SubscriptionCancelled
+	subscription.CancelledAt = &now
+	subscription.RetryAt = nil
+	return s.save(ctx, subscription)
+}
+
+// ProcessDue обробляє підписки, для яких настав час замовлення або повторної оплати.
+// Зупиняється на першій помилці інфраструктури; підписка буде оброблена знову після закінчення блокування
+func (s *service) ProcessDue(ctx context.Context) (int, error) {
+	subscriptions, err := s.subscriptionRepo.ClaimDue(ctx, s.cfg.Lease, s.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due subscriptions: %w", err)
+	}
+
+	processed := 0
+	for _, subscription := range subscriptions {
+		if err := s.process(ctx, subscription); err != nil {
+			// блокування закінчилось і підписку змінили - стан не перезаписується
+			if errors.Is(err, ErrSubscriptionChanged) {
+				log.Printf("subscription %s: changed during processing, skipped", subscription.ID)
+				continue
+			}
+			return processed, err
+		}
+		if err := s.subscriptionRepo.Unlock(ctx, subscription.ID); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// process створює замовлення циклу через OrderService.CreateOrder та оплачує його;
+// для past_due повторює оплату вже створеного замовлення
+func (s *service) process(ctx context.Context, subscription *models.Subscription) error {
+	if subscription.Status == models.SubscriptionPastDue && subscription.LastOrderID != nil {
+		order, err := s.orders.GetOrder(ctx, *subscription.LastOrderID)
+		if err != nil {
+			return fmt.Errorf("failed to get unpaid order: %w", err)
+		}
+		switch order.Status {
+		case "pending":
+			record, err := s.subscriptionRepo.GetOrder(ctx, subscription.ID, order.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get subscription order: %w", err)
+			}
+			// кошти вже списано, але замовлення не позначено оплаченим - повторно не списуємо
+			if record != nil && record.TransactionID != nil {
+				return s.markPaid(ctx, subscription, order)
+			}
+			return s.charge(ctx, subscription, order)
+		case "cancelled":
+			// замовлення скасовано вручну - цикл пропускається
+			return s.skip(ctx, subscription, "unpaid order was cancelled")
+		default:
+			// замовлення оплачено іншим способом
+			return s.complete(ctx, subscription, order)
+		}
+	}
+
+	items, err := s.subscriptionRepo.ListItems(ctx, []uuid.UUID{subscription.ID})
+	if err != nil {
+		return fmt.Errorf("failed to list subscription items: %w", err)
+	}
+	// ID замовлення закріплюється за циклом до створення: якщо обробка перерветься після
+	// CreateOrder, наступний запуск отримає те саме замовлення, а не створить друге
+	orderID, err := s.subscriptionRepo.ReserveCycle(ctx, subscription.ID, subscription.NextRunAt, uuid.New())
+	if err != nil {
+		return err
+	}
+	req := orderSrv.CreateOrderRequest{
+		Items:          make([]orderSrv.CreateOrderItemRequest, len(items)),
+		ShippingAdress: subscription.ShippingAddress,
+		PaymentMethod:  subscription.PaymentMethod,
+		OrderID:        &orderID,
+	}
+	for i, item := range items {
+		req.Items[i] = orderSrv.CreateOrderItemRequest{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+
+	order, err := s.orders.CreateOrder(ctx, subscription.UserID, req)
+	if err != nil {
+		// товар недоступний або закінчився - доставка цього циклу пропускається
+		if isOrderRejected(err) {
+			return s.skip(ctx, subscription, err.Error())
+		}
+		return fmt.Errorf("failed to create subscription order: %w", err)
+	}
+	if err := s.subscriptionRepo.AddOrder(ctx, &models.SubscriptionOrder{
+		SubscriptionID: subscription.ID,
+		OrderID:        order.ID,
+		CycleAt:        subscription.NextRunAt,
+	}); err != nil {
+		return err
+	}
+	// замовлення циклу, створене перерваною обробкою, могли вже скасувати або оплатити
+	switch order.Status {
+	case "pending":
+	case "cancelled":
+		return s.skip(ctx, subscription, "subscription order was cancelled")
+	default:
+		return s.complete(ctx, subscription, order)
+	}
+	s.notify(ctx, notification.TypeSubscriptionOrder, subscription, map[string]interface{}{
+		"order_id":     order.ID,
+		"total_amount": order.TotalAmount,
+	})
+
+	if subscription.PaymentMethod != "card" {
+		return s.complete(ctx, subscription, order)
+	}
+
+	// замовлення чекає оплати: якщо процес перерветься, наступний запуск повторить оплату
+	// цього ж замовлення, а не створить нове
+	now := time.Now()
+	subscription.Status = models.SubscriptionPastDue
+	subscription.LastOrderID = &order.ID
+	subscription.RetryAt = &now
+	if err := s.save(ctx, subscription); err != nil {
+		return err
+	}
+	return s.charge(ctx, subscription, order)
+}
+
+// charge списує оплату замовлення збереженою карткою.
+// ID замовлення - ключ ідемпотентності: повтор того ж платежу провайдер не списує вдруге
+func (s *service) charge(ctx context.Context, subscription *models.Subscription, order *models.Order) error {
+	reference := ""
+	if subscription.PaymentReference != nil {
+		reference = *subscription.PaymentReference
+	}
+	transactionID, err := s.gateway.Charge(ctx, payment.Charge{
+		OrderID:        order.ID,
+		UserID:         subscription.UserID,
+		Amount:         order.TotalAmount,
+		Reference:      reference,
+		IdempotencyKey: order.ID.String(),
+	})
+	if err != nil {
+		return s.paymentFailed(ctx, subscription, order, err)
+	}
+
+	// транзакція записується до зміни статусу замовлення, щоб збій після списання
+	// не призвів до повторної оплати
+	if err := s.subscriptionRepo.SetTransaction(ctx, subscription.ID, order.ID, transactionID); err != nil {
+		return fmt.Errorf("failed to record subscription payment: %w", err)
+	}
+	return s.markPaid(ctx, subscription, order)
+}
+
+// markPaid позначає оплачене замовлення та завершує цикл
+func (s *service) markPaid(ctx context.Context, subscription *models.Subscription, order *models.Order) error {
+	if _, err := s.orders.UpdateOrderStatus(ctx, order.ID, orderSrv.UpdateOrderRequest{Status: "paid"}); err != nil {
+		return fmt.Errorf("failed to mark subscription order paid: %w", err)
+	}
+	return s.complete(ctx, subscription, order)
+}
+
+// paymentFailed планує повторну оплату; після MaxAttempts спроб замовлення скасовується,
+// а підписка призупиняється до зміни платіжного засобу
+func (s *service) paymentFailed(ctx context.Context, subscription *models.Subscription, order *models.Order, cause error) error {
+	now := time.Now()
+	message := cause.Error()
+	subscription.FailedAttempts++
+	subscription.LastError = &message
+	subscription.LastOrderID = &order.ID
+
+	data := map[string]interface{}{
+		"order_id": order.ID,
+		"attempt":  subscription.FailedAttempts,
+	}
+	if subscription.FailedAttempts >= s.cfg.MaxAttempts {
+		if err := s.orders.CancelOrder(ctx, order.ID); err != nil && !errors.Is(err, orderSrv.ErrOrderAlreadyCanceled) {
+			return fmt.Errorf("failed to cancel unpaid order: %w", err)
+		}
+		subscription.Status = models.SubscriptionSuspended
+		subscription.RetryAt = nil
+		data["suspended"] = true
+	} else {
+		retryAt := now.Add(s.cfg.RetryInterval * time.Duration(subscription.FailedAttempts))
+		subscription.Status = models.SubscriptionPastDue
+		subscription.RetryAt = &retryAt
+		data["retry_at"] = retryAt
+	}
+
+	if err := s.save(ctx, subscription); err != nil {
+		return err
+	}
+	s.notify(ctx, notification.TypeSubscriptionPaymentFailed, subscription, data)
+	return nil
+}
+
+// complete завершує цикл і переносить наступну доставку
+func (s *service) complete(ctx context.Context, subscription *models.Subscription, order *models.Order) error {
+	subscription.Status = models.SubscriptionActive
+	subscription.FailedAttempts = 0
+	subscription.RetryAt = nil
+	subscription.LastError = nil
+	subscription.LastOrderID = &order.ID
+	advance(subscription, time.Now())
+	return s.save(ctx, subscription)
+}
+
+// skip пропускає доставку циклу, який неможливо виконати
+func (s *service) skip(ctx context.Context, subscription *models.Subscription, reason string) error {
+	cycleAt := subscription.NextRunAt
+	subscription.Status = models.SubscriptionActive
+	subscription.FailedAttempts = 0
+	subscription.RetryAt = nil
+	subscription.LastError = &reason
+	advance(subscription, time.Now())
+
+	if err := s.save(ctx, subscription); err != nil {
+		return err
+	}
+	s.notify(ctx, notification.TypeSubscriptionSkipped, subscription, map[string]interface{}{
+		"cycle_at": cycleAt,
+		"reason":   reason,
+	})
+	return nil
+}
+
+// advance переносить наступну доставку на найближчу майбутню дату розкладу
+func advance(subscription *models.Subscription, now time.Time) {
+	next := subscription.NextRunAt.AddDate(0, 0, 7*subscription.IntervalWeeks)
+	for !next.After(now) {
+		next = next.AddDate(0, 0, 7*subscription.IntervalWeeks)
+	}
+	subscription.NextRunAt = next
+}
+
+// isOrderRejected повертає true для помилок замовлення, пов'язаних з товаром, а не з інфраструктурою
+func isOrderRejected(err error) bool {
+	switch {
+	case errors.Is(err, orderSrv.ErrInsufficientStock),
+		errors.Is(err, orderSrv.ErrProductUnavailable),
+		errors.Is(err, orderSrv.ErrProductNotFound),
+		errors.Is(err, orderSrv.ErrOrderMustContainItem),
+		errors.Is(err, orderSrv.ErrShippingAddressRequired):
+		return true
+	}
+	return false
+}
+
+// notify відправляє сповіщення власнику підписки; помилка доставки не зупиняє обробку
+func (s *service) notify(ctx context.Context, notificationType string, subscription *models.Subscription, data map[string]interface{}) {
+	data["subscription_id"] = subscription.ID
+	data["status"] = subscription.Status
+	data["next_run_at"] = subscription.NextRunAt
+	userID := subscription.UserID
+	if err := s.notifier.Notify(ctx, notification.New(notificationType, &userID, data)); err != nil {
+		log.Printf("subscription %s: failed to send %s notification: %v", subscription.ID, notificationType, err)
+	}
+}
+
+// own повертає підписку користувача або ErrSubscriptionNotFound
+func (s *service) own(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error) {
+	subscription, err := s.subscriptionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if subscription == nil || subscription.UserID != userID {
+		return nil, ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// editable повертає підписку користувача для зміни; поки планувальник обробляє підписку,
+// зміни відхиляються, щоб не перезаписати замовлення чи оплату, що виконуються
+func (s *service) editable(ctx context.Context, userID, id uuid.UUID) (*models.Subscription, error) {
+	subscription, err := s.own(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.LockedUntil != nil && subscription.LockedUntil.After(time.Now()) {
+		return nil, ErrSubscriptionBusy
+	}
+	return subscription, nil
+}
+
+// save зберігає стан підписки, якщо її не змінили після читання
+func (s *service) save(ctx context.Context, subscription *models.Subscription) error {
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSubscriptionChanged
+		}
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+	return nil
+}
+
+// setItems додає до підписки товари з поточними цінами
+func (s *service) setItems(ctx context.Context, subscription *models.Subscription) error {
+	items, err := s.subscriptionRepo.ListItems(ctx, []uuid.UUID{subscription.ID})
+	if err != nil {
+		return fmt.Errorf("failed to list subscription items: %w", err)
+	}
+	subscription.Items = items
+	return nil
+}
+
+// validateItems перевіряє товари підписки: опубліковані фізичні продукти без повторів
+func (s *service) validateItems(ctx context.Context, req []SubscriptionItemRequest) ([]*models.SubscriptionItem, error) {
+	if len(req) == 0 {
+		return nil, ErrItemsRequired
+	}
+	if len(req) > maxItems {
+		return nil, ErrTooManyItems
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req))
+	items := make([]*models.SubscriptionItem, 0, len(req))
+	for _, item := range req {
+		if item.Quantity < 1 || item.Quantity > maxItemQuantity {
+			return nil, ErrInvalidQuantity
+		}
+		if seen[item.ProductID] {
+			return nil, ErrDuplicateItem
+		}
+		seen[item.ProductID] = true
+
+		product, err := s.productRepo.GetById(ctx, item.ProductID)
+		if err != nil || product == nil {
+			return nil, ErrProductNotFound
+		}
+		// цифровий товар купується один раз, регулярна доставка для нього не має сенсу
+		if !product.IsVisible() || product.IsDigital {
+			return nil, ErrProductUnavailable
+		}
+		items = append(items, &models.SubscriptionItem{
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			ProductName: product.Name,
+			Price:       product.EffectivePrice(time.Now()),
+		})
+	}
+	return items, nil
+}
+
+// validatePayment перевіряє спосіб оплати; для картки потрібен токен збереженої картки
+func validatePayment(method, reference string) (*string, error) {
+	switch method {
+	case "card":
+		if reference == "" {
+			return nil, ErrPaymentReferenceRequired
+		}
+		return &reference, nil
+	case "cash":
+		return nil, nil
+	}
+	return nil, ErrInvalidPaymentMethod
+}
+
+// validAddress перевіряє, що всі поля адреси доставки заповнені
+func validAddress(address models.ShippingAddress) bool {
+	return address.Street != "" && address.City != "" && address.PostalCode != "" && address.Country != ""
+}
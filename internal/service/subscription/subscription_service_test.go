@@ -0,0 +1,975 @@
+package subscription
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/notification"
+	"github.com/Xiancel/ecommerce/internal/payment"
+	orderSrv "github.com/Xiancel/ecommerce/internal/service/order"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionRepository) Create(ctx context.Context, subscription *models.Subscription, items []*models.SubscriptionItem) error {
+	args := m.Called(ctx, subscription, items)
+	return args.Error(0)
+}
+func (m *MockSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Subscription), args.Error(1)
+}
+func (m *MockSubscriptionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Subscription), args.Error(1)
+}
+func (m *MockSubscriptionRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockSubscriptionRepository) ListItems(ctx context.Context, subscriptionIDs []uuid.UUID) ([]*models.SubscriptionItem, error) {
+	args := m.Called(ctx, subscriptionIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SubscriptionItem), args.Error(1)
+}
+func (m *MockSubscriptionRepository) Update(ctx context.Context, subscription *models.Subscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+func (m *MockSubscriptionRepository) ReplaceItems(ctx context.Context, subscription *models.Subscription, items []*models.SubscriptionItem) error {
+	args := m.Called(ctx, subscription, items)
+	return args.Error(0)
+}
+func (m *MockSubscriptionRepository) Unlock(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockSubscriptionRepository) ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]*models.Subscription, error) {
+	args := m.Called(ctx, lease, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Subscription), args.Error(1)
+}
+func (m *MockSubscriptionRepository) ReserveCycle(ctx context.Context, subscriptionID uuid.UUID, cycleAt time.Time, orderID uuid.UUID) (uuid.UUID, error) {
+	args := m.Called(ctx, subscriptionID, cycleAt, orderID)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+func (m *MockSubscriptionRepository) AddOrder(ctx context.Context, order *models.SubscriptionOrder) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+func (m *MockSubscriptionRepository) GetOrder(ctx context.Context, subscriptionID, orderID uuid.UUID) (*models.SubscriptionOrder, error) {
+	args := m.Called(ctx, subscriptionID, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SubscriptionOrder), args.Error(1)
+}
+func (m *MockSubscriptionRepository) SetTransaction(ctx context.Context, subscriptionID, orderID uuid.UUID, transactionID string) error {
+	args := m.Called(ctx, subscriptionID, orderID, transactionID)
+	return args.Error(0)
+}
+func (m *MockSubscriptionRepository) ListOrders(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.SubscriptionOrder, error) {
+	args := m.Called(ctx, subscriptionID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SubscriptionOrder), args.Error(1)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+type MockOrderPlacer struct {
+	mock.Mock
+}
+
+func (m *MockOrderPlacer) CreateOrder(ctx context.Context, userID uuid.UUID, req orderSrv.CreateOrderRequest) (*models.Order, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+func (m *MockOrderPlacer) GetOrder(ctx context.Context, id uuid.UUID) (*models.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+func (m *MockOrderPlacer) UpdateOrderStatus(ctx context.Context, id uuid.UUID, req orderSrv.UpdateOrderRequest) (*models.Order, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+func (m *MockOrderPlacer) CancelOrder(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockGateway struct {
+	mock.Mock
+}
+
+func (m *MockGateway) Charge(ctx context.Context, c payment.Charge) (string, error) {
+	args := m.Called(ctx, c)
+	return args.String(0), args.Error(1)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, n notification.Notification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+func testAddress() models.ShippingAddress {
+	return models.ShippingAddress{Street: "Main 1", City: "Kyiv", PostalCode: "01001", Country: "UA"}
+}
+
+func testSubscription(userID uuid.UUID, status string) *models.Subscription {
+	reference := "card_tok"
+	return &models.Subscription{
+		ID:               uuid.New(),
+		UserID:           userID,
+		Status:           status,
+		IntervalWeeks:    2,
+		NextRunAt:        time.Now().Add(-time.Minute),
+		ShippingAddress:  testAddress(),
+		PaymentMethod:    "card",
+		PaymentReference: &reference,
+	}
+}
+
+func notificationOfType(notificationType string) interface{} {
+	return mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Type == notificationType
+	})
+}
+
+func TestCreateSubscription_Success(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Name: "Coffee", Price: 10, Status: models.ProductStatusPublished}, nil)
+	mockRepo.On("CountByUser", ctx, userID).Return(0, nil)
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Subscription"), mock.Anything).Return(nil)
+
+	//Act
+	sub, err := service.CreateSubscription(ctx, userID, CreateSubscriptionRequest{
+		Items:            []SubscriptionItemRequest{{ProductID: productID, Quantity: 2}},
+		IntervalWeeks:    4,
+		ShippingAddress:  testAddress(),
+		PaymentMethod:    "card",
+		PaymentReference: "card_tok",
+	})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.SubscriptionActive, sub.Status)
+	assert.Len(t, sub.Items, 1)
+	assert.Equal(t, sub.ID, sub.Items[0].SubscriptionID)
+	assert.Equal(t, "card_tok", *sub.PaymentReference)
+	assert.WithinDuration(t, time.Now(), sub.NextRunAt, time.Minute)
+}
+
+func TestCreateSubscription_Validation(t *testing.T) {
+	//Arrange
+	productID := uuid.New()
+	valid := func() CreateSubscriptionRequest {
+		return CreateSubscriptionRequest{
+			Items:           []SubscriptionItemRequest{{ProductID: productID, Quantity: 1}},
+			IntervalWeeks:   1,
+			ShippingAddress: testAddress(),
+			PaymentMethod:   "cash",
+		}
+	}
+	tests := []struct {
+		name   string
+		modify func(r *CreateSubscriptionRequest)
+		err    error
+	}{
+		{"interval", func(r *CreateSubscriptionRequest) { r.IntervalWeeks = 0 }, ErrInvalidInterval},
+		{"address", func(r *CreateSubscriptionRequest) { r.ShippingAddress.City = "" }, ErrShippingAddressRequired},
+		{"payment method", func(r *CreateSubscriptionRequest) { r.PaymentMethod = "crypto" }, ErrInvalidPaymentMethod},
+		{"card reference", func(r *CreateSubscriptionRequest) { r.PaymentMethod = "card" }, ErrPaymentReferenceRequired},
+		{"no items", func(r *CreateSubscriptionRequest) { r.Items = nil }, ErrItemsRequired},
+		{"quantity", func(r *CreateSubscriptionRequest) { r.Items[0].Quantity = 101 }, ErrInvalidQuantity},
+		{"duplicate", func(r *CreateSubscriptionRequest) { r.Items = append(r.Items, r.Items[0]) }, ErrDuplicateItem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSubscriptionRepository)
+			mockProductRepo := new(MockProductRepository)
+			service := NewService(mockRepo, mockProductRepo, new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+			mockProductRepo.On("GetById", mock.Anything, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+			req := valid()
+			tt.modify(&req)
+
+			//Act
+			sub, err := service.CreateSubscription(context.Background(), uuid.New(), req)
+
+			//Assert
+			assert.ErrorIs(t, err, tt.err)
+			assert.Nil(t, sub)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateSubscription_DigitalProduct(t *testing.T) {
+	//Arrange
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(new(MockSubscriptionRepository), mockProductRepo, new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished, IsDigital: true}, nil)
+
+	//Act
+	_, err := service.CreateSubscription(ctx, uuid.New(), CreateSubscriptionRequest{
+		Items:           []SubscriptionItemRequest{{ProductID: productID, Quantity: 1}},
+		IntervalWeeks:   1,
+		ShippingAddress: testAddress(),
+		PaymentMethod:   "cash",
+	})
+
+	//Assert
+	assert.ErrorIs(t, err, ErrProductUnavailable)
+}
+
+func TestCreateSubscription_Limit(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockProductRepo := new(MockProductRepository)
+	service := NewService(mockRepo, mockProductRepo, new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	productID := uuid.New()
+
+	mockProductRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+	mockRepo.On("CountByUser", ctx, userID).Return(maxSubscriptions, nil)
+
+	//Act
+	_, err := service.CreateSubscription(ctx, userID, CreateSubscriptionRequest{
+		Items:           []SubscriptionItemRequest{{ProductID: productID, Quantity: 1}},
+		IntervalWeeks:   1,
+		ShippingAddress: testAddress(),
+		PaymentMethod:   "cash",
+	})
+
+	//Assert
+	assert.ErrorIs(t, err, ErrSubscriptionLimit)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetSubscription_OtherUser(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	sub := testSubscription(uuid.New(), models.SubscriptionActive)
+
+	mockRepo.On("GetByID", ctx, sub.ID).Return(sub, nil)
+
+	//Act
+	resp, err := service.GetSubscription(ctx, uuid.New(), sub.ID)
+
+	//Assert
+	assert.ErrorIs(t, err, ErrSubscriptionNotFound)
+	assert.Nil(t, resp)
+}
+
+func TestPauseResumeSubscription(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	sub := testSubscription(userID, models.SubscriptionActive)
+	sub.NextRunAt = time.Now().Add(-48 * time.Hour)
+
+	mockRepo.On("GetByID", ctx, sub.ID).Return(sub, nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+
+	//Act
+	paused, err := service.PauseSubscription(ctx, userID, sub.ID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.SubscriptionPaused, paused.Status)
+	assert.NotNil(t, paused.PausedAt)
+
+	resumed, err := service.ResumeSubscription(ctx, userID, sub.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SubscriptionActive, resumed.Status)
+	assert.Nil(t, resumed.PausedAt)
+	// пропущена дата доставки переноситься на зараз
+	assert.WithinDuration(t, time.Now(), resumed.NextRunAt, time.Minute)
+}
+
+func TestPauseSubscription_PastDue(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	sub := testSubscription(userID, models.SubscriptionPastDue)
+
+	mockRepo.On("GetByID", ctx, sub.ID).Return(sub, nil)
+
+	//Act
+	_, err := service.PauseSubscription(ctx, userID, sub.ID)
+
+	//Assert
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSkipNext(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	sub := testSubscription(userID, models.SubscriptionActive)
+	next := sub.NextRunAt
+
+	mockRepo.On("GetByID", ctx, sub.ID).Return(sub, nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+
+	//Act
+	skipped, err := service.SkipNext(ctx, userID, sub.ID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, next.AddDate(0, 0, 14), skipped.NextRunAt)
+}
+
+func TestUpdateSubscription_NewCardResumesSuspended(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	sub := testSubscription(userID, models.SubscriptionSuspended)
+	sub.FailedAttempts = 3
+	sub.NextRunAt = time.Now().Add(24 * time.Hour)
+	reference := "new_tok"
+
+	mockRepo.On("GetByID", ctx, sub.ID).Return(sub, nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+	mockRepo.On("ListItems", ctx, []uuid.UUID{sub.ID}).Return([]*models.SubscriptionItem{}, nil)
+
+	//Act
+	updated, err := service.UpdateSubscription(ctx, userID, sub.ID, UpdateSubscriptionRequest{PaymentReference: &reference})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.SubscriptionActive, updated.Status)
+	assert.Equal(t, 0, updated.FailedAttempts)
+	assert.Equal(t, "new_tok", *updated.PaymentReference)
+	assert.WithinDuration(t, time.Now(), updated.NextRunAt, time.Minute)
+}
+
+func TestUpdateSubscription_Cancelled(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	sub := testSubscription(userID, models.SubscriptionCancelled)
+	interval := 3
+
+	mockRepo.On("GetByID", ctx, sub.ID).Return(sub, nil)
+
+	//Act
+	_, err := service.UpdateSubscription(ctx, userID, sub.ID, UpdateSubscriptionRequest{IntervalWeeks: &interval})
+
+	//Assert
+	assert.ErrorIs(t, err, ErrSubscriptionCancelled)
+}
+
+func TestCancelSubscription_CancelsUnpaidOrder(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	orderID := uuid.New()
+	sub := testSubscription(userID, models.SubscriptionPastDue)
+	sub.LastOrderID = &orderID
+
+	mockRepo.On("GetByID", ctx, sub.ID).Return(sub, nil)
+	mockOrders.On("CancelOrder", ctx, orderID).Return(nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+
+	//Act
+	err := service.CancelSubscription(ctx, userID, sub.ID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.SubscriptionCancelled, sub.Status)
+	assert.NotNil(t, sub.CancelledAt)
+	mockOrders.AssertExpectations(t)
+}
+
+func TestProcessDue_CardPaid(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockGateway := new(MockGateway)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, mockGateway, mockNotifier, Config{})
+	ctx := context.Background()
+	sub := testSubscription(uuid.New(), models.SubscriptionActive)
+	cycleAt := sub.NextRunAt
+	productID := uuid.New()
+	order := &models.Order{ID: uuid.New(), TotalAmount: 40, Status: "pending"}
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockRepo.On("ListItems", ctx, []uuid.UUID{sub.ID}).Return([]*models.SubscriptionItem{{SubscriptionID: sub.ID, ProductID: productID, Quantity: 2}}, nil)
+	mockRepo.On("ReserveCycle", ctx, sub.ID, cycleAt, mock.Anything).Return(order.ID, nil)
+	mockOrders.On("CreateOrder", ctx, sub.UserID, orderSrv.CreateOrderRequest{
+		Items:          []orderSrv.CreateOrderItemRequest{{ProductID: productID, Quantity: 2}},
+		ShippingAdress: sub.ShippingAddress,
+		PaymentMethod:  "card",
+		OrderID:        &order.ID,
+	}).Return(order, nil)
+	mockRepo.On("AddOrder", ctx, &models.SubscriptionOrder{SubscriptionID: sub.ID, OrderID: order.ID, CycleAt: cycleAt}).Return(nil)
+	mockNotifier.On("Notify", ctx, notificationOfType(notification.TypeSubscriptionOrder)).Return(nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+	mockGateway.On("Charge", ctx, payment.Charge{OrderID: order.ID, UserID: sub.UserID, Amount: 40, Reference: "card_tok", IdempotencyKey: order.ID.String()}).Return("tx_1", nil)
+	mockRepo.On("SetTransaction", ctx, sub.ID, order.ID, "tx_1").Return(nil)
+	mockOrders.On("UpdateOrderStatus", ctx, order.ID, orderSrv.UpdateOrderRequest{Status: "paid"}).Return(order, nil)
+
+	mockRepo.On("Unlock", ctx, sub.ID).Return(nil)
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, models.SubscriptionActive, sub.Status)
+	assert.Equal(t, order.ID, *sub.LastOrderID)
+	assert.Nil(t, sub.RetryAt)
+	assert.True(t, sub.NextRunAt.After(time.Now()))
+	// замовлення зберігається до списання оплати та після завершення циклу
+	mockRepo.AssertNumberOfCalls(t, "Update", 2)
+	mockOrders.AssertExpectations(t)
+	mockGateway.AssertExpectations(t)
+}
+
+func TestProcessDue_CashSkipsCharge(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockGateway := new(MockGateway)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, mockGateway, mockNotifier, Config{})
+	ctx := context.Background()
+	sub := testSubscription(uuid.New(), models.SubscriptionActive)
+	sub.PaymentMethod = "cash"
+	sub.PaymentReference = nil
+	order := &models.Order{ID: uuid.New(), Status: "pending"}
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockRepo.On("ListItems", ctx, []uuid.UUID{sub.ID}).Return([]*models.SubscriptionItem{{ProductID: uuid.New(), Quantity: 1}}, nil)
+	mockRepo.On("ReserveCycle", ctx, mock.Anything, mock.Anything, mock.Anything).Return(uuid.New(), nil)
+	mockOrders.On("CreateOrder", ctx, sub.UserID, mock.Anything).Return(order, nil)
+	mockRepo.On("AddOrder", ctx, mock.Anything).Return(nil)
+	mockNotifier.On("Notify", ctx, mock.Anything).Return(nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+
+	mockRepo.On("Unlock", ctx, sub.ID).Return(nil)
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, models.SubscriptionActive, sub.Status)
+	mockGateway.AssertNotCalled(t, "Charge", mock.Anything, mock.Anything)
+	mockOrders.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessDue_OutOfStockSkipsCycle(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, new(MockGateway), mockNotifier, Config{})
+	ctx := context.Background()
+	sub := testSubscription(uuid.New(), models.SubscriptionActive)
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockRepo.On("ListItems", ctx, []uuid.UUID{sub.ID}).Return([]*models.SubscriptionItem{{ProductID: uuid.New(), Quantity: 1}}, nil)
+	mockRepo.On("ReserveCycle", ctx, mock.Anything, mock.Anything, mock.Anything).Return(uuid.New(), nil)
+	mockOrders.On("CreateOrder", ctx, sub.UserID, mock.Anything).Return(nil, orderSrv.ErrInsufficientStock)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+	mockNotifier.On("Notify", ctx, notificationOfType(notification.TypeSubscriptionSkipped)).Return(nil)
+
+	mockRepo.On("Unlock", ctx, sub.ID).Return(nil)
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, models.SubscriptionActive, sub.Status)
+	assert.NotNil(t, sub.LastError)
+	assert.True(t, sub.NextRunAt.After(time.Now()))
+	mockRepo.AssertNotCalled(t, "AddOrder", mock.Anything, mock.Anything)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestProcessDue_PaymentDeclinedSchedulesRetry(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockGateway := new(MockGateway)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, mockGateway, mockNotifier, Config{})
+	ctx := context.Background()
+	sub := testSubscription(uuid.New(), models.SubscriptionActive)
+	next := sub.NextRunAt
+	order := &models.Order{ID: uuid.New(), TotalAmount: 10, Status: "pending"}
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockRepo.On("ListItems", ctx, []uuid.UUID{sub.ID}).Return([]*models.SubscriptionItem{{ProductID: uuid.New(), Quantity: 1}}, nil)
+	mockRepo.On("ReserveCycle", ctx, mock.Anything, mock.Anything, mock.Anything).Return(uuid.New(), nil)
+	mockOrders.On("CreateOrder", ctx, sub.UserID, mock.Anything).Return(order, nil)
+	mockRepo.On("AddOrder", ctx, mock.Anything).Return(nil)
+	mockNotifier.On("Notify", ctx, notificationOfType(notification.TypeSubscriptionOrder)).Return(nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+	mockGateway.On("Charge", ctx, mock.Anything).Return("", payment.ErrDeclined)
+	mockNotifier.On("Notify", ctx, notificationOfType(notification.TypeSubscriptionPaymentFailed)).Return(nil)
+
+	mockRepo.On("Unlock", ctx, sub.ID).Return(nil)
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, models.SubscriptionPastDue, sub.Status)
+	assert.Equal(t, 1, sub.FailedAttempts)
+	assert.Equal(t, order.ID, *sub.LastOrderID)
+	assert.WithinDuration(t, time.Now().Add(defaultRetryInterval), *sub.RetryAt, time.Minute)
+	// цикл не завершено - дата наступної доставки не змінюється
+	assert.Equal(t, next, sub.NextRunAt)
+	mockOrders.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestProcessDue_RetryPaysExistingOrder(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockGateway := new(MockGateway)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, mockGateway, new(MockNotifier), Config{})
+	ctx := context.Background()
+	orderID := uuid.New()
+	sub := testSubscription(uuid.New(), models.SubscriptionPastDue)
+	sub.LastOrderID = &orderID
+	sub.FailedAttempts = 1
+	order := &models.Order{ID: orderID, TotalAmount: 10, Status: "pending"}
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockOrders.On("GetOrder", ctx, orderID).Return(order, nil)
+	mockRepo.On("GetOrder", ctx, sub.ID, orderID).Return(&models.SubscriptionOrder{SubscriptionID: sub.ID, OrderID: orderID}, nil)
+	mockGateway.On("Charge", ctx, mock.Anything).Return("tx_2", nil)
+	mockRepo.On("SetTransaction", ctx, sub.ID, orderID, "tx_2").Return(nil)
+	mockOrders.On("UpdateOrderStatus", ctx, orderID, orderSrv.UpdateOrderRequest{Status: "paid"}).Return(order, nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+
+	mockRepo.On("Unlock", ctx, sub.ID).Return(nil)
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, models.SubscriptionActive, sub.Status)
+	assert.Equal(t, 0, sub.FailedAttempts)
+	mockOrders.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessDue_ChargedOrderIsNotChargedAgain(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockGateway := new(MockGateway)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, mockGateway, new(MockNotifier), Config{})
+	ctx := context.Background()
+	orderID := uuid.New()
+	transactionID := "tx_1"
+	sub := testSubscription(uuid.New(), models.SubscriptionPastDue)
+	sub.LastOrderID = &orderID
+	order := &models.Order{ID: orderID, TotalAmount: 10, Status: "pending"}
+
+	// попередній запуск списав кошти, але не зміг позначити замовлення оплаченим
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockOrders.On("GetOrder", ctx, orderID).Return(order, nil)
+	mockRepo.On("GetOrder", ctx, sub.ID, orderID).Return(&models.SubscriptionOrder{SubscriptionID: sub.ID, OrderID: orderID, TransactionID: &transactionID}, nil)
+	mockOrders.On("UpdateOrderStatus", ctx, orderID, orderSrv.UpdateOrderRequest{Status: "paid"}).Return(order, nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+	mockRepo.On("Unlock", ctx, sub.ID).Return(nil)
+
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, models.SubscriptionActive, sub.Status)
+	mockGateway.AssertNotCalled(t, "Charge", mock.Anything, mock.Anything)
+	mockOrders.AssertExpectations(t)
+}
+
+func TestProcessDue_MarkPaidFailureKeepsTransaction(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockGateway := new(MockGateway)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, mockGateway, new(MockNotifier), Config{})
+	ctx := context.Background()
+	orderID := uuid.New()
+	sub := testSubscription(uuid.New(), models.SubscriptionPastDue)
+	sub.LastOrderID = &orderID
+	order := &models.Order{ID: orderID, TotalAmount: 10, Status: "pending"}
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockOrders.On("GetOrder", ctx, orderID).Return(order, nil)
+	mockRepo.On("GetOrder", ctx, sub.ID, orderID).Return(&models.SubscriptionOrder{SubscriptionID: sub.ID, OrderID: orderID}, nil)
+	mockGateway.On("Charge", ctx, mock.Anything).Return("tx_3", nil)
+	mockRepo.On("SetTransaction", ctx, sub.ID, orderID, "tx_3").Return(nil)
+	mockOrders.On("UpdateOrderStatus", ctx, orderID, orderSrv.UpdateOrderRequest{Status: "paid"}).Return(nil, errors.New("db down"))
+
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.Error(t, err)
+	assert.Equal(t, 0, processed)
+	// транзакцію записано до зміни статусу - наступний запуск не списуватиме повторно
+	mockRepo.AssertCalled(t, "SetTransaction", ctx, sub.ID, orderID, "tx_3")
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestProcessDue_LastAttemptSuspends(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockGateway := new(MockGateway)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, mockGateway, mockNotifier, Config{})
+	ctx := context.Background()
+	orderID := uuid.New()
+	sub := testSubscription(uuid.New(), models.SubscriptionPastDue)
+	sub.LastOrderID = &orderID
+	sub.FailedAttempts = defaultMaxAttempts - 1
+	order := &models.Order{ID: orderID, TotalAmount: 10, Status: "pending"}
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockOrders.On("GetOrder", ctx, orderID).Return(order, nil)
+	mockRepo.On("GetOrder", ctx, sub.ID, orderID).Return(&models.SubscriptionOrder{SubscriptionID: sub.ID, OrderID: orderID}, nil)
+	mockGateway.On("Charge", ctx, mock.Anything).Return("", payment.ErrDeclined)
+	mockOrders.On("CancelOrder", ctx, orderID).Return(nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+	mockNotifier.On("Notify", ctx, notificationOfType(notification.TypeSubscriptionPaymentFailed)).Return(nil)
+
+	mockRepo.On("Unlock", ctx, sub.ID).Return(nil)
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, models.SubscriptionSuspended, sub.Status)
+	assert.Nil(t, sub.RetryAt)
+	mockOrders.AssertExpectations(t)
+}
+
+func TestPauseSubscription_LockedByScheduler(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	sub := testSubscription(userID, models.SubscriptionActive)
+	lockedUntil := time.Now().Add(time.Minute)
+	sub.LockedUntil = &lockedUntil
+
+	mockRepo.On("GetByID", ctx, sub.ID).Return(sub, nil)
+
+	//Act
+	_, err := service.PauseSubscription(ctx, userID, sub.ID)
+
+	//Assert
+	assert.ErrorIs(t, err, ErrSubscriptionBusy)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCancelSubscription_ChangedConcurrently(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	userID := uuid.New()
+	sub := testSubscription(userID, models.SubscriptionActive)
+
+	mockRepo.On("GetByID", ctx, sub.ID).Return(sub, nil)
+	// планувальник заблокував підписку після читання - версія змінилась
+	mockRepo.On("Update", ctx, sub).Return(sql.ErrNoRows)
+
+	//Act
+	err := service.CancelSubscription(ctx, userID, sub.ID)
+
+	//Assert
+	assert.ErrorIs(t, err, ErrSubscriptionChanged)
+}
+
+func TestProcessDue_ChangedSubscriptionIsNotOverwritten(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, new(MockGateway), mockNotifier, Config{})
+	ctx := context.Background()
+	changed := testSubscription(uuid.New(), models.SubscriptionActive)
+	changed.PaymentMethod = "cash"
+	next := testSubscription(uuid.New(), models.SubscriptionActive)
+	next.PaymentMethod = "cash"
+	order := &models.Order{ID: uuid.New(), Status: "pending"}
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{changed, next}, nil)
+	mockRepo.On("ListItems", ctx, mock.Anything).Return([]*models.SubscriptionItem{{ProductID: uuid.New(), Quantity: 1}}, nil)
+	mockRepo.On("ReserveCycle", ctx, mock.Anything, mock.Anything, mock.Anything).Return(uuid.New(), nil)
+	mockOrders.On("CreateOrder", ctx, mock.Anything, mock.Anything).Return(order, nil)
+	mockRepo.On("AddOrder", ctx, mock.Anything).Return(nil)
+	mockNotifier.On("Notify", ctx, mock.Anything).Return(nil)
+	mockRepo.On("Update", ctx, changed).Return(sql.ErrNoRows)
+	mockRepo.On("Update", ctx, next).Return(nil)
+	mockRepo.On("Unlock", ctx, next.ID).Return(nil)
+
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	mockRepo.AssertNotCalled(t, "Unlock", ctx, changed.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProcessDue_RetryReusesReservedOrder(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockGateway := new(MockGateway)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, mockGateway, mockNotifier, Config{})
+	ctx := context.Background()
+	sub := testSubscription(uuid.New(), models.SubscriptionActive)
+	reservedID := uuid.New()
+	order := &models.Order{ID: reservedID, TotalAmount: 40, Status: "pending"}
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockRepo.On("ListItems", ctx, []uuid.UUID{sub.ID}).Return([]*models.SubscriptionItem{{ProductID: uuid.New(), Quantity: 1}}, nil)
+	// обидва запуски отримують той самий зарезервований ID замовлення циклу
+	mockRepo.On("ReserveCycle", ctx, sub.ID, sub.NextRunAt, mock.Anything).Return(reservedID, nil)
+	mockOrders.On("CreateOrder", ctx, sub.UserID, mock.MatchedBy(func(req orderSrv.CreateOrderRequest) bool {
+		return req.OrderID != nil && *req.OrderID == reservedID
+	})).Return(order, nil)
+	// перша обробка падає після створення замовлення
+	mockRepo.On("AddOrder", ctx, mock.Anything).Return(errors.New("db down")).Once()
+	mockRepo.On("AddOrder", ctx, mock.Anything).Return(nil)
+	mockNotifier.On("Notify", ctx, notificationOfType(notification.TypeSubscriptionOrder)).Return(nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+	mockGateway.On("Charge", ctx, mock.MatchedBy(func(c payment.Charge) bool {
+		return c.IdempotencyKey == reservedID.String()
+	})).Return("tx_1", nil)
+	mockRepo.On("SetTransaction", ctx, sub.ID, reservedID, "tx_1").Return(nil)
+	mockOrders.On("UpdateOrderStatus", ctx, reservedID, orderSrv.UpdateOrderRequest{Status: "paid"}).Return(order, nil)
+	mockRepo.On("Unlock", ctx, sub.ID).Return(nil)
+
+	//Act
+	_, firstErr := service.ProcessDue(ctx)
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.Error(t, firstErr)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	// повтор створює замовлення з тим самим ID, тому OrderService повертає вже створене
+	mockOrders.AssertNumberOfCalls(t, "CreateOrder", 2)
+	mockGateway.AssertNumberOfCalls(t, "Charge", 1)
+}
+
+func TestProcessDue_ReservedOrderCancelledSkipsCycle(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	mockOrders := new(MockOrderPlacer)
+	mockGateway := new(MockGateway)
+	mockNotifier := new(MockNotifier)
+	service := NewService(mockRepo, new(MockProductRepository), mockOrders, mockGateway, mockNotifier, Config{})
+	ctx := context.Background()
+	sub := testSubscription(uuid.New(), models.SubscriptionActive)
+	order := &models.Order{ID: uuid.New(), Status: "cancelled"}
+
+	// замовлення, створене перерваною обробкою, скасували до повтору
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{sub}, nil)
+	mockRepo.On("ListItems", ctx, []uuid.UUID{sub.ID}).Return([]*models.SubscriptionItem{{ProductID: uuid.New(), Quantity: 1}}, nil)
+	mockRepo.On("ReserveCycle", ctx, sub.ID, sub.NextRunAt, mock.Anything).Return(order.ID, nil)
+	mockOrders.On("CreateOrder", ctx, sub.UserID, mock.Anything).Return(order, nil)
+	mockRepo.On("AddOrder", ctx, mock.Anything).Return(nil)
+	mockRepo.On("Update", ctx, sub).Return(nil)
+	mockNotifier.On("Notify", ctx, notificationOfType(notification.TypeSubscriptionSkipped)).Return(nil)
+	mockRepo.On("Unlock", ctx, sub.ID).Return(nil)
+
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.True(t, sub.NextRunAt.After(time.Now()))
+	mockGateway.AssertNotCalled(t, "Charge", mock.Anything, mock.Anything)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestProcessDue_StopsOnInfrastructureError(t *testing.T) {
+	//Arrange
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewService(mockRepo, new(MockProductRepository), new(MockOrderPlacer), new(MockGateway), new(MockNotifier), Config{})
+	ctx := context.Background()
+	first := testSubscription(uuid.New(), models.SubscriptionActive)
+	second := testSubscription(uuid.New(), models.SubscriptionActive)
+
+	mockRepo.On("ClaimDue", ctx, defaultLease, defaultBatchSize).Return([]*models.Subscription{first, second}, nil)
+	mockRepo.On("ListItems", ctx, []uuid.UUID{first.ID}).Return(nil, errors.New("db down"))
+
+	//Act
+	processed, err := service.ProcessDue(ctx)
+
+	//Assert
+	assert.Error(t, err)
+	assert.Equal(t, 0, processed)
+	mockRepo.AssertNotCalled(t, "ListItems", ctx, []uuid.UUID{second.ID})
+}
@@ -0,0 +1,36 @@
+package translation
+
+import models "github.com/Xiancel/ecommerce/internal/domain"
+
+// DTO структури для перекладів
+
+// ProductTranslationRequest is the DTO for setting a product translation
+type ProductTranslationRequest struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	Description *string `json:"description"`
+}
+
+// CategoryTranslationRequest is the DTO for setting a category translation.
+// Slug is generated from the name when empty
+type CategoryTranslationRequest struct {
+	Name        string  `json:"name" validate:"required,max=100"`
+	Slug        string  `json:"slug"`
+	Description *string `json:"description"`
+}
+
+// LocalesResponse contains supported locales
+type LocalesResponse struct {
+	Default   string   `json:"default"`
+	Supported []string `json:"supported"`
+}
+
+// MissingTranslationsResponse is the report of content without translation
+type MissingTranslationsResponse struct {
+	Locale          string                       `json:"locale"`
+	Products        []*models.MissingTranslation `json:"products"`
+	ProductsTotal   int                          `json:"products_total"`
+	Categories      []*models.MissingTranslation `json:"categories"`
+	CategoriesTotal int                          `json:"categories_total"`
+	Limit           int                          `json:"limit"`
+	Offset          int                          `json:"offset"`
+}
@@ -0,0 +1,19 @@
+package translation
+
+import "errors"
+
+// помилки пов'язані з перекладами
+var (
+	// Validation errors
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+	ErrDefaultLocale     = errors.New("default locale content is edited on the product or category itself")
+	ErrNameRequired      = errors.New("name is required")
+	ErrNameTooLong       = errors.New("name is too long")
+	ErrInvalidSlug       = errors.New("slug must contain letters or digits")
+
+	// Logic errors
+	ErrProductNotFound     = errors.New("product not found")
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrTranslationNotFound = errors.New("translation not found")
+	ErrSlugTaken           = errors.New("slug is already used by another category in this locale")
+)
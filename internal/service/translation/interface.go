@@ -0,0 +1,25 @@
+package translation
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// TranslationService інтерфейс для керування перекладами продуктів та категорій
+type TranslationService interface {
+	// Locales повертає підтримувані мови та мову за замовчуванням
+	Locales() LocalesResponse
+
+	ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error)
+	SetProductTranslation(ctx context.Context, productID uuid.UUID, locale string, req ProductTranslationRequest) (*models.ProductTranslation, error)
+	DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error
+
+	ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error)
+	SetCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string, req CategoryTranslationRequest) (*models.CategoryTranslation, error)
+	DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error
+
+	// MissingTranslations повертає продукти та категорії без перекладу вказаною мовою
+	MissingTranslations(ctx context.Context, locale string, limit, offset int) (*MissingTranslationsResponse, error)
+}
@@ -0,0 +1,302 @@
+package translation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/i18n"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	"github.com/Xiancel/ecommerce/internal/slug"
+	"github.com/google/uuid"
+)
+
+// обмеження довжини перекладених назв (як у базових таблицях)
+const (
+	maxProductNameLength  = 255
+	maxCategoryNameLength = 100
+)
+
+type service struct {
+	translationRepo repository.TranslationRepository
+	productRepo     repository.ProductRepository
+	categoryRepo    repository.CategoryRepository
+	locales         i18n.Locales
+}
+
+func NewService(translationRepo repository.TranslationRepository, productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository, locales i18n.Locales) TranslationService {
+	// значення за замовчуванням
+	if locales.Default == "" {
+		locales = i18n.NewLocales("", locales.Supported)
+	}
+	return &service{translationRepo: translationRepo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		locales:      locales}
+}
+
+// Locales повертає підтримувані мови та мову за замовчуванням
+func (s *service) Locales() LocalesResponse {
+	return LocalesResponse{
+		Default:   s.locales.Default,
+		Supported: s.locales.Supported,
+	}
+}
+
+// ListProductTranslations повертає всі переклади продукту
+func (s *service) ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error) {
+	if err := s.checkProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	translations, err := s.translationRepo.ListProductTranslations(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product translations: %w", err)
+	}
+	if translations == nil {
+		translations = []*models.ProductTranslation{}
+	}
+	return translations, nil
+}
+
+// SetProductTranslation створює або замінює переклад продукту
+func (s *service) SetProductTranslation(ctx context.Context, productID uuid.UUID, locale string, req ProductTranslationRequest) (*models.ProductTranslation, error) {
+	// валідація
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	name, err := validateName(req.Name, maxProductNameLength)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	translation := &models.ProductTranslation{
+		ProductID:   productID,
+		Locale:      locale,
+		Name:        name,
+		Description: trimOptional(req.Description),
+	}
+	if err := s.translationRepo.UpsertProductTranslation(ctx, translation); err != nil {
+		return nil, fmt.Errorf("failed to save product translation: %w", err)
+	}
+	return translation, nil
+}
+
+// DeleteProductTranslation видаляє переклад продукту
+func (s *service) DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	if err := s.translationRepo.DeleteProductTranslation(ctx, productID, locale); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTranslationNotFound
+		}
+		return fmt.Errorf("failed to delete product translation: %w", err)
+	}
+	return nil
+}
+
+// ListCategoryTranslations повертає всі переклади категорії
+func (s *service) ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error) {
+	if err := s.checkCategory(ctx, categoryID); err != nil {
+		return nil, err
+	}
+
+	translations, err := s.translationRepo.ListCategoryTranslations(ctx, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list category translations: %w", err)
+	}
+	if translations == nil {
+		translations = []*models.CategoryTranslation{}
+	}
+	return translations, nil
+}
+
+// SetCategoryTranslation створює або замінює переклад категорії.
+// Якщо slug не вказано, він генерується з перекладеної назви
+func (s *service) SetCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string, req CategoryTranslationRequest) (*models.CategoryTranslation, error) {
+	// валідація
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	name, err := validateName(req.Name, maxCategoryNameLength)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCategory(ctx, categoryID); err != nil {
+		return nil, err
+	}
+
+	taken := func(ctx context.Context, candidate string) (bool, error) {
+		return s.translationRepo.CategorySlugTaken(ctx, locale, candidate, categoryID)
+	}
+
+	var categorySlug string
+	if strings.TrimSpace(req.Slug) != "" {
+		// явно вказаний slug не змінюється суфіксом, тому має бути вільним
+		categorySlug = slug.Make(req.Slug)
+		if categorySlug == "" {
+			return nil, ErrInvalidSlug
+		}
+		exists, err := taken(ctx, categorySlug)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check slug: %w", err)
+		}
+		if exists {
+			return nil, ErrSlugTaken
+		}
+	} else {
+		base := slug.Make(name)
+		if base == "" {
+			base = "category"
+		}
+		categorySlug, err = slug.Unique(ctx, base, taken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate slug: %w", err)
+		}
+	}
+
+	translation := &models.CategoryTranslation{
+		CategoryID:  categoryID,
+		Locale:      locale,
+		Name:        name,
+		Slug:        categorySlug,
+		Description: trimOptional(req.Description),
+	}
+	if err := s.translationRepo.UpsertCategoryTranslation(ctx, translation); err != nil {
+		return nil, fmt.Errorf("failed to save category translation: %w", err)
+	}
+	return translation, nil
+}
+
+// DeleteCategoryTranslation видаляє переклад категорії
+func (s *service) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	if err := s.translationRepo.DeleteCategoryTranslation(ctx, categoryID, locale); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTranslationNotFound
+		}
+		return fmt.Errorf("failed to delete category translation: %w", err)
+	}
+	return nil
+}
+
+// MissingTranslations повертає продукти (з пагінацією) та категорії без перекладу вказаною мовою
+func (s *service) MissingTranslations(ctx context.Context, locale string, limit, offset int) (*MissingTranslationsResponse, error) {
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	// пагінація
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	products, err := s.translationRepo.ListMissingProducts(ctx, locale, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list missing translations: %w", err)
+	}
+	productsTotal, err := s.translationRepo.CountMissingProducts(ctx, locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count missing translations: %w", err)
+	}
+	categories, err := s.translationRepo.ListMissingCategories(ctx, locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list missing translations: %w", err)
+	}
+
+	if products == nil {
+		products = []*models.MissingTranslation{}
+	}
+	if categories == nil {
+		categories = []*models.MissingTranslation{}
+	}
+	return &MissingTranslationsResponse{
+		Locale:          locale,
+		Products:        products,
+		ProductsTotal:   productsTotal,
+		Categories:      categories,
+		CategoriesTotal: len(categories),
+		Limit:           limit,
+		Offset:          offset,
+	}, nil
+}
+
+// translationLocale нормалізує мову перекладу; мова за замовчуванням зберігається в базових таблицях
+func (s *service) translationLocale(locale string) (string, error) {
+	locale = i18n.Normalize(locale)
+	if !s.locales.IsSupported(locale) {
+		return "", ErrUnsupportedLocale
+	}
+	if locale == s.locales.Default {
+		return "", ErrDefaultLocale
+	}
+	return locale, nil
+}
+
+// checkProduct перевіряє, що продукт існує і не видалений
+func (s *service) checkProduct(ctx context.Context, productID uuid.UUID) error {
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || product.DeletedAt != nil {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+// checkCategory перевіряє, що категорія існує
+func (s *service) checkCategory(ctx context.Context, categoryID uuid.UUID) error {
+	category, err := s.categoryRepo.GetById(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
+// validateName обрізає пробіли та перевіряє довжину назви
+func validateName(name string, maxLength int) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxLength {
+		return "", ErrNameTooLong
+	}
+	return name, nil
+}
+
+// trimOptional обрізає пробіли; порожній рядок означає відсутність значення
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,403 @@
+package translation
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/i18n"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranslationRepository) ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetProductTranslations(ctx context.Context, locale string, productIDs []uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, locale, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertProductTranslation(ctx context.Context, translation *models.ProductTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	args := m.Called(ctx, productID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslations(ctx context.Context, locale string) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslationBySlug(ctx context.Context, locale, slug string) (*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CategorySlugTaken(ctx context.Context, locale, slug string, categoryID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, locale, slug, categoryID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertCategoryTranslation(ctx context.Context, translation *models.CategoryTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	args := m.Called(ctx, categoryID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListMissingProducts(ctx context.Context, locale string, limit, offset int) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) ListMissingCategories(ctx context.Context, locale string) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CountMissingProducts(ctx context.Context, locale string) (int, error) {
+	args := m.Called(ctx, locale)
+	return args.Int(0), args.Error(1)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) Create(ctx context.Context, category *models.Category) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+func (m *MockCategoryRepository) List(ctx context.Context) ([]*models.Category, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Category), args.Error(1)
+}
+func (m *MockCategoryRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+func (m *MockCategoryRepository) GetByName(ctx context.Context, name string) (*models.Category, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+func (m *MockCategoryRepository) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+func (m *MockCategoryRepository) SlugTaken(ctx context.Context, slug string) (bool, error) {
+	args := m.Called(ctx, slug)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestSetProductTranslation_Success(t *testing.T) {
+	//Arrange
+	translationRepo := new(MockTranslationRepository)
+	productRepo := new(MockProductRepository)
+	service := NewService(translationRepo, productRepo, new(MockCategoryRepository), i18n.NewLocales("uk", []string{"en"}))
+	ctx := context.Background()
+	productID := uuid.New()
+	description := "  Wireless headphones  "
+
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID}, nil)
+	translationRepo.On("UpsertProductTranslation", ctx, mock.AnythingOfType("*models.ProductTranslation")).Return(nil)
+
+	//Act
+	translation, err := service.SetProductTranslation(ctx, productID, "EN-us", ProductTranslationRequest{
+		Name:        " Headphones ",
+		Description: &description,
+	})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "en", translation.Locale)
+	assert.Equal(t, "Headphones", translation.Name)
+	assert.Equal(t, "Wireless headphones", *translation.Description)
+}
+
+func TestSetProductTranslation_InvalidLocale(t *testing.T) {
+	//Arrange
+	translationRepo := new(MockTranslationRepository)
+	service := NewService(translationRepo, new(MockProductRepository), new(MockCategoryRepository), i18n.NewLocales("uk", []string{"en"}))
+	ctx := context.Background()
+
+	//Act
+	_, err := service.SetProductTranslation(ctx, uuid.New(), "de", ProductTranslationRequest{Name: "Kopfhörer"})
+
+	//Assert
+	assert.ErrorIs(t, err, ErrUnsupportedLocale)
+
+	// базовий вміст редагується на самому продукті
+	_, err = service.SetProductTranslation(ctx, uuid.New(), "uk", ProductTranslationRequest{Name: "Навушники"})
+	assert.ErrorIs(t, err, ErrDefaultLocale)
+
+	translationRepo.AssertNotCalled(t, "UpsertProductTranslation", mock.Anything, mock.Anything)
+}
+
+func TestSetProductTranslation_DeletedProduct(t *testing.T) {
+	//Arrange
+	productRepo := new(MockProductRepository)
+	service := NewService(new(MockTranslationRepository), productRepo, new(MockCategoryRepository), i18n.NewLocales("uk", []string{"en"}))
+	ctx := context.Background()
+	productID := uuid.New()
+	deletedAt := time.Now()
+
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, DeletedAt: &deletedAt}, nil)
+
+	//Act
+	_, err := service.SetProductTranslation(ctx, productID, "en", ProductTranslationRequest{Name: "Headphones"})
+
+	//Assert
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestSetProductTranslation_NameRequired(t *testing.T) {
+	//Arrange
+	service := NewService(new(MockTranslationRepository), new(MockProductRepository), new(MockCategoryRepository), i18n.NewLocales("uk", []string{"en"}))
+
+	//Act
+	_, err := service.SetProductTranslation(context.Background(), uuid.New(), "en", ProductTranslationRequest{Name: "  "})
+
+	//Assert
+	assert.ErrorIs(t, err, ErrNameRequired)
+}
+
+func TestSetCategoryTranslation_GeneratesUniqueSlug(t *testing.T) {
+	//Arrange
+	translationRepo := new(MockTranslationRepository)
+	categoryRepo := new(MockCategoryRepository)
+	service := NewService(translationRepo, new(MockProductRepository), categoryRepo, i18n.NewLocales("uk", []string{"en"}))
+	ctx := context.Background()
+	categoryID := uuid.New()
+
+	categoryRepo.On("GetById", ctx, categoryID).Return(&models.Category{ID: categoryID}, nil)
+	translationRepo.On("CategorySlugTaken", ctx, "en", "books", categoryID).Return(true, nil)
+	translationRepo.On("CategorySlugTaken", ctx, "en", "books-2", categoryID).Return(false, nil)
+	translationRepo.On("UpsertCategoryTranslation", ctx, mock.AnythingOfType("*models.CategoryTranslation")).Return(nil)
+
+	//Act
+	translation, err := service.SetCategoryTranslation(ctx, categoryID, "en", CategoryTranslationRequest{Name: "Books"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "books-2", translation.Slug)
+}
+
+func TestSetCategoryTranslation_ExplicitSlugTaken(t *testing.T) {
+	//Arrange
+	translationRepo := new(MockTranslationRepository)
+	categoryRepo := new(MockCategoryRepository)
+	service := NewService(translationRepo, new(MockProductRepository), categoryRepo, i18n.NewLocales("uk", []string{"en"}))
+	ctx := context.Background()
+	categoryID := uuid.New()
+
+	categoryRepo.On("GetById", ctx, categoryID).Return(&models.Category{ID: categoryID}, nil)
+	translationRepo.On("CategorySlugTaken", ctx, "en", "kids-books", categoryID).Return(true, nil)
+
+	//Act
+	_, err := service.SetCategoryTranslation(ctx, categoryID, "en", CategoryTranslationRequest{Name: "Books", Slug: "Kids Books"})
+
+	//Assert
+	assert.ErrorIs(t, err, ErrSlugTaken)
+	translationRepo.AssertNotCalled(t, "UpsertCategoryTranslation", mock.Anything, mock.Anything)
+}
+
+func TestSetCategoryTranslation_CategoryNotFound(t *testing.T) {
+	//Arrange
+	categoryRepo := new(MockCategoryRepository)
+	service := NewService(new(MockTranslationRepository), new(MockProductRepository), categoryRepo, i18n.NewLocales("uk", []string{"en"}))
+	ctx := context.Background()
+	categoryID := uuid.New()
+
+	categoryRepo.On("GetById", ctx, categoryID).Return(nil, nil)
+
+	//Act
+	_, err := service.SetCategoryTranslation(ctx, categoryID, "en", CategoryTranslationRequest{Name: "Books"})
+
+	//Assert
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+}
+
+func TestDeleteProductTranslation_NotFound(t *testing.T) {
+	//Arrange
+	translationRepo := new(MockTranslationRepository)
+	service := NewService(translationRepo, new(MockProductRepository), new(MockCategoryRepository), i18n.NewLocales("uk", []string{"en"}))
+	ctx := context.Background()
+	productID := uuid.New()
+
+	translationRepo.On("DeleteProductTranslation", ctx, productID, "en").Return(sql.ErrNoRows)
+
+	//Act
+	err := service.DeleteProductTranslation(ctx, productID, "en")
+
+	//Assert
+	assert.ErrorIs(t, err, ErrTranslationNotFound)
+}
+
+func TestMissingTranslations(t *testing.T) {
+	//Arrange
+	translationRepo := new(MockTranslationRepository)
+	service := NewService(translationRepo, new(MockProductRepository), new(MockCategoryRepository), i18n.NewLocales("uk", []string{"en"}))
+	ctx := context.Background()
+	missing := []*models.MissingTranslation{{ID: uuid.New(), Name: "Навушники", Slug: "navushnyky"}}
+
+	translationRepo.On("ListMissingProducts", ctx, "en", 200, 0).Return(missing, nil)
+	translationRepo.On("CountMissingProducts", ctx, "en").Return(7, nil)
+	translationRepo.On("ListMissingCategories", ctx, "en").Return(nil, nil)
+
+	//Act
+	report, err := service.MissingTranslations(ctx, "en", 1000, -5)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "en", report.Locale)
+	assert.Len(t, report.Products, 1)
+	assert.Equal(t, 7, report.ProductsTotal)
+	assert.Empty(t, report.Categories)
+	assert.Equal(t, 0, report.CategoriesTotal)
+	assert.Equal(t, 200, report.Limit)
+	assert.Equal(t, 0, report.Offset)
+}
+
+func TestLocales(t *testing.T) {
+	//Arrange
+	service := NewService(new(MockTranslationRepository), new(MockProductRepository), new(MockCategoryRepository), i18n.NewLocales("uk", []string{"en"}))
+
+	//Act
+	locales := service.Locales()
+
+	//Assert
+	assert.Equal(t, "uk", locales.Default)
+	assert.Equal(t, []string{"uk", "en"}, locales.Supported)
+}
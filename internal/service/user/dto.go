@@ -24,10 +24,16 @@ type UserFilter struct {
 	Limit   int     `json:"limit" validate:"required,min=1,max=100"`
 	Offset  int     `json:"offset" validate:"gte=0"`
 	OrderBy string  `json:"order_by" validate:"omitempty,oneof=created_at_asc created_at_desc name_asc name_desc"`
+	// Cursor непрозорий токен сторінки з next_cursor/prev_cursor; має пріоритет над Offset
+	Cursor string `json:"cursor"`
 }
 type UserListResponse struct {
-	Users  []*models.User `json:"users"`
-	Total  int            `json:"total"`
-	Limit  int            `json:"limit"`
-	Offset int            `json:"offset"`
+	Users []*models.User `json:"users"`
+	// Total кількість усіх користувачів за фільтром (для великих наборів - оцінка)
+	Total          int    `json:"total"`
+	TotalEstimated bool   `json:"total_estimated,omitempty"`
+	Limit          int    `json:"limit"`
+	Offset         int    `json:"offset"`
+	NextCursor     string `json:"next_cursor,omitempty"`
+	PrevCursor     string `json:"prev_cursor,omitempty"`
 }
@@ -8,6 +8,7 @@ var (
 	ErrUserIDRequired = errors.New("user id is required")
 	ErrInvalidEmail   = errors.New("invalid email format")
 	ErrInvalidRole    = errors.New("invalid role value")
+	ErrInvalidCursor  = errors.New("invalid or expired page cursor")
 
 	ErrNoFields = errors.New("no fields to update")
 
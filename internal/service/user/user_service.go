@@ -2,15 +2,21 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/Xiancel/ecommerce/internal/pagination"
 	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// сортування сторінок користувачів (від нових до старих)
+const userSort = "newest"
+
 type service struct {
 	userRepo repository.UserRepository
 }
@@ -51,7 +57,8 @@ func (s *service) GetUser(ctx context.Context, id uuid.UUID) (*models.User, erro
 	return user, nil
 }
 
-// ListUser повертаж список користувачів
+// ListUser повертаж список користувачів від нових до старих.
+// Фільтрація виконується в запиті, сторінки задаються курсором або зміщенням
 func (s *service) ListUser(ctx context.Context, filter UserFilter) (*UserListResponse, error) {
 	// пагінація
 	if filter.Limit <= 0 {
@@ -63,33 +70,74 @@ func (s *service) ListUser(ctx context.Context, filter UserFilter) (*UserListRes
 	if filter.Offset < 0 {
 		filter.Offset = 0
 	}
-	// отримання списку користувачів
-	users, err := s.userRepo.List(ctx, filter.Limit, filter.Offset)
+
+	repoFilter := models.UserListFilter{
+		Search: strings.TrimSpace(filter.Search),
+		Limit:  filter.Limit + 1,
+		Offset: filter.Offset,
+	}
+	if filter.Role != nil {
+		repoFilter.Role = *filter.Role
+	}
+	var cursor *pagination.Cursor
+	if filter.Cursor != "" {
+		var err error
+		cursor, err = pagination.Decode(filter.Cursor, userSort)
+		if err != nil || !cursor.IsKeyset() {
+			return nil, ErrInvalidCursor
+		}
+		repoFilter.Cursor = cursor
+		repoFilter.Offset = 0
+	}
+
+	// отримання списку користувачів (на одного більше, щоб знати про наступну сторінку)
+	users, err := s.userRepo.List(ctx, repoFilter)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			return nil, ErrInvalidCursor
+		}
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
+	users, page := pagination.Trim(users, filter.Limit, cursor)
+	if repoFilter.Offset > 0 {
+		page.HasPrev = true
+	}
 
-	filtered := []*models.User{}
-	// отримання користувачів по фільтрам
-	for _, u := range users {
-		if filter.Role != nil && u.Role != *filter.Role {
-			continue
-		}
-		if filter.Search != "" && !strings.Contains(strings.ToLower(u.FirstName),
-			strings.ToLower(filter.Search)) && !strings.Contains(strings.ToLower(u.LastName), strings.ToLower(filter.Search)) {
-			continue
-		}
-		filtered = append(filtered, u)
+	total, estimated, err := s.userRepo.Count(ctx, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
 	}
+
 	// формування відповіді
 	resp := &UserListResponse{
-		Users: filtered,
-		Total: len(filtered),
+		Users:          users,
+		Total:          total,
+		TotalEstimated: estimated,
+		Limit:          filter.Limit,
+		Offset:         repoFilter.Offset,
+	}
+	if len(users) > 0 {
+		if page.HasNext {
+			resp.NextCursor = userCursor(users[len(users)-1], false)
+		}
+		if page.HasPrev {
+			resp.PrevCursor = userCursor(users[0], true)
+		}
 	}
 
 	return resp, nil
 }
 
+// userCursor повертає токен сторінки після (або перед) користувачем
+func userCursor(user *models.User, backward bool) string {
+	return pagination.Cursor{
+		Sort:     userSort,
+		Values:   []string{user.CreatedAt.Format(time.RFC3339Nano)},
+		ID:       user.ID.String(),
+		Backward: backward,
+	}.Encode()
+}
+
 // UpdateUser новлення данних користувача
 func (s *service) UpdateUser(ctx context.Context, id uuid.UUID, req UpdateUserRequest, isAdmin bool) (*models.User, error) {
 	// перевірка на наявність користувача
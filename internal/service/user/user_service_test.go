@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"testing"
+	"time"
 
 	models "github.com/Xiancel/ecommerce/internal/domain"
 	"github.com/google/uuid"
@@ -43,14 +44,19 @@ func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
-func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockUserRepository) List(ctx context.Context, filter models.UserListFilter) ([]*models.User, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) Count(ctx context.Context, filter models.UserListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
 func TestGetUser_Success(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	service := NewService(mockRepo)
@@ -103,15 +109,54 @@ func TestListUser_Success(t *testing.T) {
 		Limit:  20,
 		Offset: 0,
 	}
-	mockRepo.On("List", ctx, filter.Limit, filter.Offset).Return(users, nil)
+	mockRepo.On("List", ctx, models.UserListFilter{Limit: 21}).Return(users, nil)
+	mockRepo.On("Count", ctx, models.UserListFilter{Limit: 21}).Return(2, false, nil)
 
 	resp, err := service.ListUser(ctx, filter)
 
 	assert.NoError(t, err)
 	assert.Len(t, resp.Users, 2)
+	assert.Equal(t, 2, resp.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListUser_FiltersInQueryWithRealTotal(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewService(mockRepo)
+	ctx := context.Background()
+	role := "admin"
+
+	users := []*models.User{
+		{ID: uuid.New(), FirstName: "Anna", Role: "admin", CreatedAt: time.Now()},
+		{ID: uuid.New(), FirstName: "Andrii", Role: "admin", CreatedAt: time.Now().Add(-time.Hour)},
+	}
+	repoFilter := models.UserListFilter{Search: "an", Role: "admin", Limit: 2, Offset: 10}
+	mockRepo.On("List", ctx, repoFilter).Return(users, nil)
+	mockRepo.On("Count", ctx, repoFilter).Return(50000, true, nil)
+
+	resp, err := service.ListUser(ctx, UserFilter{Search: " an ", Role: &role, Limit: 1, Offset: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Users, 1)
+	assert.Equal(t, 50000, resp.Total)
+	assert.True(t, resp.TotalEstimated)
+	assert.NotEmpty(t, resp.NextCursor)
+	// зміщення 10 - попередня сторінка існує
+	assert.NotEmpty(t, resp.PrevCursor)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestListUser_InvalidCursor(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewService(mockRepo)
+
+	resp, err := service.ListUser(context.Background(), UserFilter{Limit: 10, Cursor: "%%%"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, ErrInvalidCursor, err)
+	mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
 func TestListUser_Empty(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	service := NewService(mockRepo)
@@ -121,7 +166,8 @@ func TestListUser_Empty(t *testing.T) {
 		Limit:  20,
 		Offset: 0,
 	}
-	mockRepo.On("List", ctx, filter.Limit, filter.Offset).Return([]*models.User{}, nil)
+	mockRepo.On("List", ctx, models.UserListFilter{Limit: 21}).Return([]*models.User{}, nil)
+	mockRepo.On("Count", ctx, models.UserListFilter{Limit: 21}).Return(0, false, nil)
 
 	resp, err := service.ListUser(ctx, filter)
 
@@ -0,0 +1,44 @@
+package wishlist
+
+import (
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DTO структури для списків бажань
+
+type CreateWishlistRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	IsPublic bool   `json:"is_public"`
+}
+
+type UpdateWishlistRequest struct {
+	Name     *string `json:"name" validate:"omitempty,max=100"`
+	IsPublic *bool   `json:"is_public"`
+}
+
+type AddWishlistItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required,uuid"`
+}
+
+type MoveWishlistItemRequest struct {
+	// WishlistID список, у який переноситься товар
+	WishlistID uuid.UUID `json:"wishlist_id" validate:"required,uuid"`
+}
+
+type MoveToCartRequest struct {
+	// Quantity кількість у кошику (за замовчуванням 1)
+	Quantity int `json:"quantity" validate:"omitempty,gt=0"`
+}
+
+type MoveFromCartRequest struct {
+	CartItemID uuid.UUID `json:"cart_item_id" validate:"required,uuid"`
+}
+
+// WishlistResponse список бажань з товарами, їх поточною ціною та наявністю
+type WishlistResponse struct {
+	*models.Wishlist
+	Items []*models.WishlistItemWithProduct `json:"items"`
+	// PriceDrops кількість товарів, ціна яких знизилась після додавання
+	PriceDrops int `json:"price_drops"`
+}
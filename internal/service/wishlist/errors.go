@@ -0,0 +1,23 @@
+package wishlist
+
+import "errors"
+
+// помилки пов'язані зі списками бажань
+var (
+	// Validate errors
+	ErrNameRequired    = errors.New("wishlist name is required")
+	ErrNameTooLong     = errors.New("wishlist name must be at most 100 characters")
+	ErrInvalidQuantity = errors.New("quantity must be greater than 0")
+	ErrSameWishlist    = errors.New("item is already in this wishlist")
+
+	// Logic errors
+	ErrWishlistNotFound    = errors.New("wishlist not found")
+	ErrNameTaken           = errors.New("wishlist with this name already exists")
+	ErrTooManyWishlists    = errors.New("too many wishlists")
+	ErrItemNotFound        = errors.New("wishlist item not found")
+	ErrItemExists          = errors.New("product is already in this wishlist")
+	ErrCartItemNotFound    = errors.New("cart item not found")
+	ErrProductNotFound     = errors.New("product not found")
+	ErrProductNotAvailable = errors.New("product is not available for purchase")
+	ErrInsufficientStock   = errors.New("insufficient stock")
+)
@@ -0,0 +1,25 @@
+package wishlist
+
+import (
+	"context"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	"github.com/google/uuid"
+)
+
+// WishlistService інтерфейс для роботи зі списками бажань
+type WishlistService interface {
+	ListWishlists(ctx context.Context, userID uuid.UUID) ([]*models.Wishlist, error)
+	CreateWishlist(ctx context.Context, userID uuid.UUID, req CreateWishlistRequest) (*models.Wishlist, error)
+	GetWishlist(ctx context.Context, userID, wishlistID uuid.UUID) (*WishlistResponse, error)
+	UpdateWishlist(ctx context.Context, userID, wishlistID uuid.UUID, req UpdateWishlistRequest) (*models.Wishlist, error)
+	DeleteWishlist(ctx context.Context, userID, wishlistID uuid.UUID) error
+	// GetSharedWishlist повертає публічний список за токеном посилання
+	GetSharedWishlist(ctx context.Context, token string) (*WishlistResponse, error)
+
+	AddItem(ctx context.Context, userID, wishlistID uuid.UUID, req AddWishlistItemRequest) (*models.WishlistItem, error)
+	RemoveItem(ctx context.Context, userID, wishlistID, itemID uuid.UUID) error
+	MoveItem(ctx context.Context, userID, wishlistID, itemID uuid.UUID, req MoveWishlistItemRequest) error
+	MoveItemToCart(ctx context.Context, userID, wishlistID, itemID uuid.UUID, req MoveToCartRequest) error
+	MoveCartItem(ctx context.Context, userID, wishlistID uuid.UUID, req MoveFromCartRequest) (*models.WishlistItem, error)
+}
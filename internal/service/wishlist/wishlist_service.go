@@ -0,0 +1,466 @@
+package wishlist
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	repository "github.com/Xiancel/ecommerce/internal/repository/postgres"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/google/uuid"
+)
+
+// обмеження списків бажань користувача
+const (
+	maxWishlists    = 20
+	maxNameLength   = 100
+	shareTokenBytes = 24
+)
+
+type service struct {
+	wishlistRepo repository.WishlistRepository
+	productRepo  repository.ProductRepository
+	cartRepo     repository.CartRepository
+	localizer    *productSrv.Localizer
+}
+
+func NewService(wishlistRepo repository.WishlistRepository, productRepo repository.ProductRepository, cartRepo repository.CartRepository, localizer *productSrv.Localizer) WishlistService {
+	return &service{wishlistRepo: wishlistRepo,
+		productRepo: productRepo,
+		cartRepo:    cartRepo,
+		localizer:   localizer}
+}
+
+// ListWishlists повертає списки бажань користувача
+func (s *service) ListWishlists(ctx context.Context, userID uuid.UUID) ([]*models.Wishlist, error) {
+	wishlists, err := s.wishlistRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wishlists: %w", err)
+	}
+	if wishlists == nil {
+		wishlists = []*models.Wishlist{}
+	}
+	return wishlists, nil
+}
+
+// CreateWishlist створює іменований список бажань; публічний список отримує токен посилання
+func (s *service) CreateWishlist(ctx context.Context, userID uuid.UUID, req CreateWishlistRequest) (*models.Wishlist, error) {
+	// валідація
+	name, err := validateName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.wishlistRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wishlists: %w", err)
+	}
+	if len(existing) >= maxWishlists {
+		return nil, ErrTooManyWishlists
+	}
+
+	wishlist := &models.Wishlist{
+		UserID:   userID,
+		Name:     name,
+		IsPublic: req.IsPublic,
+	}
+	if req.IsPublic {
+		token, err := newShareToken()
+		if err != nil {
+			return nil, err
+		}
+		wishlist.ShareToken = &token
+	}
+
+	if err := s.wishlistRepo.Create(ctx, wishlist); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNameTaken
+		}
+		return nil, fmt.Errorf("failed to create wishlist: %w", err)
+	}
+	return wishlist, nil
+}
+
+// GetWishlist повертає список користувача з товарами
+func (s *service) GetWishlist(ctx context.Context, userID, wishlistID uuid.UUID) (*WishlistResponse, error) {
+	wishlist, err := s.ownWishlist(ctx, userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	return s.wishlistResponse(ctx, wishlist)
+}
+
+// UpdateWishlist перейменовує список або змінює доступ до нього.
+// Закритий список втрачає токен, тому старі посилання перестають працювати
+func (s *service) UpdateWishlist(ctx context.Context, userID, wishlistID uuid.UUID, req UpdateWishlistRequest) (*models.Wishlist, error) {
+	wishlist, err := s.ownWishlist(ctx, userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name, err := validateName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		if name != wishlist.Name {
+			taken, err := s.nameTaken(ctx, userID, name)
+			if err != nil {
+				return nil, err
+			}
+			if taken {
+				return nil, ErrNameTaken
+			}
+			wishlist.Name = name
+		}
+	}
+
+	if req.IsPublic != nil {
+		wishlist.IsPublic = *req.IsPublic
+		switch {
+		case !wishlist.IsPublic:
+			wishlist.ShareToken = nil
+		case wishlist.ShareToken == nil:
+			token, err := newShareToken()
+			if err != nil {
+				return nil, err
+			}
+			wishlist.ShareToken = &token
+		}
+	}
+
+	if err := s.wishlistRepo.Update(ctx, wishlist); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWishlistNotFound
+		}
+		return nil, fmt.Errorf("failed to update wishlist: %w", err)
+	}
+	return wishlist, nil
+}
+
+// DeleteWishlist видаляє список разом з товарами
+func (s *service) DeleteWishlist(ctx context.Context, userID, wishlistID uuid.UUID) error {
+	if _, err := s.ownWishlist(ctx, userID, wishlistID); err != nil {
+		return err
+	}
+
+	if err := s.wishlistRepo.Delete(ctx, wishlistID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrWishlistNotFound
+		}
+		return fmt.Errorf("failed to delete wishlist: %w", err)
+	}
+	return nil
+}
+
+// GetSharedWishlist повертає публічний список за токеном без даних власника
+func (s *service) GetSharedWishlist(ctx context.Context, token string) (*WishlistResponse, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrWishlistNotFound
+	}
+
+	wishlist, err := s.wishlistRepo.GetByShareToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist: %w", err)
+	}
+	if wishlist == nil {
+		return nil, ErrWishlistNotFound
+	}
+
+	resp, err := s.wishlistResponse(ctx, wishlist)
+	if err != nil {
+		return nil, err
+	}
+	// приховані товари не показуються стороннім
+	visible := make([]*models.WishlistItemWithProduct, 0, len(resp.Items))
+	resp.PriceDrops = 0
+	for _, item := range resp.Items {
+		if item.Visible {
+			visible = append(visible, item)
+			if item.PriceDropped {
+				resp.PriceDrops++
+			}
+		}
+	}
+	resp.Items = visible
+
+	shared := *wishlist
+	shared.UserID = uuid.Nil
+	shared.ItemCount = len(visible)
+	resp.Wishlist = &shared
+	return resp, nil
+}
+
+// AddItem додає продукт у список за поточною ціною
+func (s *service) AddItem(ctx context.Context, userID, wishlistID uuid.UUID, req AddWishlistItemRequest) (*models.WishlistItem, error) {
+	if _, err := s.ownWishlist(ctx, userID, wishlistID); err != nil {
+		return nil, err
+	}
+
+	product, err := s.visibleProduct(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &models.WishlistItem{
+		WishlistID: wishlistID,
+		ProductID:  product.ID,
+		PriceAtAdd: product.EffectivePrice(time.Now()),
+	}
+	if err := s.wishlistRepo.AddItem(ctx, item); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemExists
+		}
+		return nil, fmt.Errorf("failed to add wishlist item: %w", err)
+	}
+	return item, nil
+}
+
+// RemoveItem видаляє товар зі списку
+func (s *service) RemoveItem(ctx context.Context, userID, wishlistID, itemID uuid.UUID) error {
+	if _, err := s.ownWishlist(ctx, userID, wishlistID); err != nil {
+		return err
+	}
+
+	if err := s.wishlistRepo.RemoveItem(ctx, wishlistID, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		return fmt.Errorf("failed to remove wishlist item: %w", err)
+	}
+	return nil
+}
+
+// MoveItem переносить товар в інший список користувача
+func (s *service) MoveItem(ctx context.Context, userID, wishlistID, itemID uuid.UUID, req MoveWishlistItemRequest) error {
+	if req.WishlistID == wishlistID {
+		return ErrSameWishlist
+	}
+	item, err := s.ownItem(ctx, userID, wishlistID, itemID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.ownWishlist(ctx, userID, req.WishlistID); err != nil {
+		return err
+	}
+
+	if err := s.wishlistRepo.MoveItem(ctx, item, req.WishlistID); err != nil {
+		return fmt.Errorf("failed to move wishlist item: %w", err)
+	}
+	return nil
+}
+
+// MoveItemToCart переносить товар у кошик; товар має бути доступним для покупки
+func (s *service) MoveItemToCart(ctx context.Context, userID, wishlistID, itemID uuid.UUID, req MoveToCartRequest) error {
+	// кількість за замовчуванням
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+	if req.Quantity < 0 {
+		return ErrInvalidQuantity
+	}
+
+	item, err := s.ownItem(ctx, userID, wishlistID, itemID)
+	if err != nil {
+		return err
+	}
+
+	product, err := s.visibleProduct(ctx, item.ProductID)
+	if err != nil {
+		return err
+	}
+	if product.Availability() == "out_of_stock" {
+		return ErrProductNotAvailable
+	}
+
+	// як і при додаванні в кошик, залишку має вистачити разом з тим, що вже є в кошику
+	if !product.IsDigital && !product.AllowsBackorder() {
+		quantity := req.Quantity
+		cartItem, err := s.cartRepo.GetItem(ctx, userID, product.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get cart item: %w", err)
+		}
+		if cartItem != nil {
+			quantity += cartItem.Quantity
+		}
+		if quantity > product.Stock {
+			return ErrInsufficientStock
+		}
+	}
+
+	if err := s.wishlistRepo.MoveToCart(ctx, item, userID, req.Quantity); err != nil {
+		return fmt.Errorf("failed to move item to cart: %w", err)
+	}
+	return nil
+}
+
+// MoveCartItem переносить товар з кошика у список за поточною ціною
+func (s *service) MoveCartItem(ctx context.Context, userID, wishlistID uuid.UUID, req MoveFromCartRequest) (*models.WishlistItem, error) {
+	if _, err := s.ownWishlist(ctx, userID, wishlistID); err != nil {
+		return nil, err
+	}
+
+	cartItem, err := s.cartRepo.GetItemByID(ctx, userID, req.CartItemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart item: %w", err)
+	}
+	if cartItem == nil {
+		return nil, ErrCartItemNotFound
+	}
+
+	product, err := s.productRepo.GetById(ctx, cartItem.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, ErrProductNotFound
+	}
+
+	item := &models.WishlistItem{
+		WishlistID: wishlistID,
+		ProductID:  product.ID,
+		PriceAtAdd: product.EffectivePrice(time.Now()),
+	}
+	if err := s.wishlistRepo.MoveFromCart(ctx, cartItem, item); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCartItemNotFound
+		}
+		return nil, fmt.Errorf("failed to move cart item: %w", err)
+	}
+	return item, nil
+}
+
+// ownWishlist повертає список, якщо він належить користувачу
+func (s *service) ownWishlist(ctx context.Context, userID, wishlistID uuid.UUID) (*models.Wishlist, error) {
+	wishlist, err := s.wishlistRepo.GetByID(ctx, wishlistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist: %w", err)
+	}
+	// чужий список не розкривається
+	if wishlist == nil || wishlist.UserID != userID {
+		return nil, ErrWishlistNotFound
+	}
+	return wishlist, nil
+}
+
+// ownItem повертає товар зі списку користувача
+func (s *service) ownItem(ctx context.Context, userID, wishlistID, itemID uuid.UUID) (*models.WishlistItem, error) {
+	if _, err := s.ownWishlist(ctx, userID, wishlistID); err != nil {
+		return nil, err
+	}
+
+	item, err := s.wishlistRepo.GetItem(ctx, wishlistID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist item: %w", err)
+	}
+	if item == nil {
+		return nil, ErrItemNotFound
+	}
+	return item, nil
+}
+
+// visibleProduct повертає опублікований продукт
+func (s *service) visibleProduct(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	if productID == uuid.Nil {
+		return nil, ErrProductNotFound
+	}
+	product, err := s.productRepo.GetById(ctx, productID)
+	if err != nil || product == nil || !product.IsVisible() {
+		return nil, ErrProductNotFound
+	}
+	return product, nil
+}
+
+// nameTaken перевіряє, чи є у користувача список з такою назвою
+func (s *service) nameTaken(ctx context.Context, userID uuid.UUID, name string) (bool, error) {
+	wishlists, err := s.wishlistRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list wishlists: %w", err)
+	}
+	for _, wishlist := range wishlists {
+		if wishlist.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// wishlistResponse додає до списку товари з наявністю та позначкою зниження ціни
+func (s *service) wishlistResponse(ctx context.Context, wishlist *models.Wishlist) (*WishlistResponse, error) {
+	items, err := s.wishlistRepo.ListItems(ctx, wishlist.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wishlist items: %w", err)
+	}
+	if items == nil {
+		items = []*models.WishlistItemWithProduct{}
+	}
+
+	// назви товарів мовою запиту
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ProductID
+	}
+	names, err := s.localizer.Names(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &WishlistResponse{Wishlist: wishlist, Items: items}
+	for _, item := range items {
+		if name, ok := names[item.ProductID]; ok {
+			item.ProductName = name
+		}
+		decorateItem(item)
+		if item.PriceDropped {
+			resp.PriceDrops++
+		}
+	}
+	return resp, nil
+}
+
+// decorateItem визначає наявність товару та зниження ціни з моменту додавання
+func decorateItem(item *models.WishlistItemWithProduct) {
+	item.Availability = "out_of_stock"
+	if item.Visible {
+		product := models.Product{Stock: item.Stock, BackorderPolicy: item.BackorderPolicy, IsDigital: item.IsDigital}
+		item.Availability = product.Availability()
+	}
+
+	// порівняння в копійках, щоб уникнути похибок float
+	drop := math.Round((item.PriceAtAdd-item.Price)*100) / 100
+	item.PriceDropped = drop > 0
+	item.PriceDrop = 0
+	if item.PriceDropped {
+		item.PriceDrop = drop
+	}
+}
+
+// validateName обрізає пробіли та перевіряє довжину назви
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return "", ErrNameTooLong
+	}
+	return name, nil
+}
+
+// newShareToken генерує випадковий токен публічного посилання
+func newShareToken() (string, error) {
+	buf := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
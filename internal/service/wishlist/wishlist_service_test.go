@@ -0,0 +1,782 @@
+package wishlist
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	models "github.com/Xiancel/ecommerce/internal/domain"
+	productSrv "github.com/Xiancel/ecommerce/internal/service/product"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWishlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWishlistRepository) Create(ctx context.Context, wishlist *models.Wishlist) error {
+	args := m.Called(ctx, wishlist)
+	return args.Error(0)
+}
+func (m *MockWishlistRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Wishlist, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wishlist), args.Error(1)
+}
+func (m *MockWishlistRepository) GetByShareToken(ctx context.Context, token string) (*models.Wishlist, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wishlist), args.Error(1)
+}
+func (m *MockWishlistRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Wishlist, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Wishlist), args.Error(1)
+}
+func (m *MockWishlistRepository) Update(ctx context.Context, wishlist *models.Wishlist) error {
+	args := m.Called(ctx, wishlist)
+	return args.Error(0)
+}
+func (m *MockWishlistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockWishlistRepository) ListItems(ctx context.Context, wishlistID uuid.UUID) ([]*models.WishlistItemWithProduct, error) {
+	args := m.Called(ctx, wishlistID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WishlistItemWithProduct), args.Error(1)
+}
+func (m *MockWishlistRepository) GetItem(ctx context.Context, wishlistID, itemID uuid.UUID) (*models.WishlistItem, error) {
+	args := m.Called(ctx, wishlistID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WishlistItem), args.Error(1)
+}
+func (m *MockWishlistRepository) AddItem(ctx context.Context, item *models.WishlistItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+func (m *MockWishlistRepository) RemoveItem(ctx context.Context, wishlistID, itemID uuid.UUID) error {
+	args := m.Called(ctx, wishlistID, itemID)
+	return args.Error(0)
+}
+func (m *MockWishlistRepository) MoveItem(ctx context.Context, item *models.WishlistItem, targetWishlistID uuid.UUID) error {
+	args := m.Called(ctx, item, targetWishlistID)
+	return args.Error(0)
+}
+func (m *MockWishlistRepository) MoveToCart(ctx context.Context, item *models.WishlistItem, userID uuid.UUID, quantity int) error {
+	args := m.Called(ctx, item, userID, quantity)
+	return args.Error(0)
+}
+func (m *MockWishlistRepository) MoveFromCart(ctx context.Context, cartItem *models.CartItem, item *models.WishlistItem) error {
+	args := m.Called(ctx, cartItem, item)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetById(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter models.ListFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Count(ctx context.Context, filter models.ListFilter) (int, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, filter models.ListFilter) ([]*models.ProductSearchHit, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductSearchHit), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *models.Product, stock *models.StockChange) error {
+	args := m.Called(ctx, product, stock)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, query string, minSimilarity float64, limit int) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, query, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
+
+func (m *MockProductRepository) ApplySchedule(ctx context.Context) (int, int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, product *models.Product) (bool, error) {
+	args := m.Called(ctx, product)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+func (m *MockProductRepository) SlugTaken(ctx context.Context, slug string, excludeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockProductRepository) ExportProducts(ctx context.Context, status string, fn func(*models.ExportProduct) error) error {
+	args := m.Called(ctx, status, fn)
+	return args.Error(0)
+}
+
+type MockCartRepository struct {
+	mock.Mock
+}
+
+func (m *MockCartRepository) AddItem(ctx context.Context, item *models.CartItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+func (m *MockCartRepository) GetByUserId(ctx context.Context, userID uuid.UUID) ([]*models.CartItemWithProduct, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CartItemWithProduct), args.Error(1)
+}
+func (m *MockCartRepository) UpdateQuantity(ctx context.Context, id uuid.UUID, quantity int) error {
+	args := m.Called(ctx, id, quantity)
+	return args.Error(0)
+}
+func (m *MockCartRepository) RemoveItem(ctx context.Context, userID, id uuid.UUID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+func (m *MockCartRepository) Clear(ctx context.Context, userId uuid.UUID) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+func (m *MockCartRepository) GetItem(ctx context.Context, userId, productId uuid.UUID) (*models.CartItem, error) {
+	args := m.Called(ctx, userId, productId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CartItem), args.Error(1)
+}
+func (m *MockCartRepository) GetItemByID(ctx context.Context, userID, itemID uuid.UUID) (*models.CartItem, error) {
+	args := m.Called(ctx, userID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CartItem), args.Error(1)
+}
+
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranslationRepository) ListProductTranslations(ctx context.Context, productID uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetProductTranslations(ctx context.Context, locale string, productIDs []uuid.UUID) ([]*models.ProductTranslation, error) {
+	args := m.Called(ctx, locale, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProductTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertProductTranslation(ctx context.Context, translation *models.ProductTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteProductTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	args := m.Called(ctx, productID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslations(ctx context.Context, locale string) ([]*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) GetCategoryTranslationBySlug(ctx context.Context, locale, slug string) (*models.CategoryTranslation, error) {
+	args := m.Called(ctx, locale, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CategorySlugTaken(ctx context.Context, locale, slug string, categoryID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, locale, slug, categoryID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockTranslationRepository) UpsertCategoryTranslation(ctx context.Context, translation *models.CategoryTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	args := m.Called(ctx, categoryID, locale)
+	return args.Error(0)
+}
+func (m *MockTranslationRepository) ListMissingProducts(ctx context.Context, locale string, limit, offset int) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) ListMissingCategories(ctx context.Context, locale string) ([]*models.MissingTranslation, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MissingTranslation), args.Error(1)
+}
+func (m *MockTranslationRepository) CountMissingProducts(ctx context.Context, locale string) (int, error) {
+	args := m.Called(ctx, locale)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCreateWishlist_Public(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	wishlistRepo.On("ListByUser", ctx, userID).Return([]*models.Wishlist{}, nil)
+	wishlistRepo.On("Create", ctx, mock.AnythingOfType("*models.Wishlist")).Return(nil)
+
+	//Act
+	wishlist, err := service.CreateWishlist(ctx, userID, CreateWishlistRequest{Name: "  Birthday ", IsPublic: true})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Birthday", wishlist.Name)
+	assert.Equal(t, userID, wishlist.UserID)
+	assert.NotNil(t, wishlist.ShareToken)
+	wishlistRepo.AssertExpectations(t)
+}
+
+func TestCreateWishlist_Validation(t *testing.T) {
+	//Arrange
+	service := NewService(new(MockWishlistRepository), new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+
+	//Act
+	_, err := service.CreateWishlist(ctx, uuid.New(), CreateWishlistRequest{Name: "   "})
+
+	//Assert
+	assert.Equal(t, ErrNameRequired, err)
+
+	long := make([]rune, maxNameLength+1)
+	for i := range long {
+		long[i] = 'я'
+	}
+	_, err = service.CreateWishlist(ctx, uuid.New(), CreateWishlistRequest{Name: string(long)})
+	assert.Equal(t, ErrNameTooLong, err)
+}
+
+func TestCreateWishlist_NameTaken(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	wishlistRepo.On("ListByUser", ctx, userID).Return([]*models.Wishlist{}, nil)
+	wishlistRepo.On("Create", ctx, mock.AnythingOfType("*models.Wishlist")).Return(sql.ErrNoRows)
+
+	//Act
+	wishlist, err := service.CreateWishlist(ctx, userID, CreateWishlistRequest{Name: "Birthday"})
+
+	//Assert
+	assert.Nil(t, wishlist)
+	assert.Equal(t, ErrNameTaken, err)
+}
+
+func TestCreateWishlist_TooMany(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	existing := make([]*models.Wishlist, maxWishlists)
+	wishlistRepo.On("ListByUser", ctx, userID).Return(existing, nil)
+
+	//Act
+	wishlist, err := service.CreateWishlist(ctx, userID, CreateWishlistRequest{Name: "One more"})
+
+	//Assert
+	assert.Nil(t, wishlist)
+	assert.Equal(t, ErrTooManyWishlists, err)
+	wishlistRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestGetWishlist_PriceDropAndAvailability(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+
+	items := []*models.WishlistItemWithProduct{
+		{WishlistItem: models.WishlistItem{PriceAtAdd: 100}, Price: 79.9, Stock: 3, Visible: true},
+		{WishlistItem: models.WishlistItem{PriceAtAdd: 50}, Price: 50, BackorderPolicy: models.BackorderAllow, Visible: true},
+		{WishlistItem: models.WishlistItem{PriceAtAdd: 20}, Price: 25, Stock: 5, Visible: false},
+	}
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	wishlistRepo.On("ListItems", ctx, wishlistID).Return(items, nil)
+
+	//Act
+	resp, err := service.GetWishlist(ctx, userID, wishlistID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.PriceDrops)
+	assert.True(t, resp.Items[0].PriceDropped)
+	assert.Equal(t, 20.1, resp.Items[0].PriceDrop)
+	assert.Equal(t, "in_stock", resp.Items[0].Availability)
+	assert.False(t, resp.Items[1].PriceDropped)
+	assert.Equal(t, "backorder", resp.Items[1].Availability)
+	assert.Equal(t, "out_of_stock", resp.Items[2].Availability)
+}
+
+func TestGetWishlist_LocalizedNames(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	translationRepo := new(MockTranslationRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(translationRepo, "uk"))
+	ctx := models.ContextWithLocale(context.Background(), "en")
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	translated, untranslated := uuid.New(), uuid.New()
+
+	items := []*models.WishlistItemWithProduct{
+		{WishlistItem: models.WishlistItem{ProductID: translated}, ProductName: "Навушники", Visible: true},
+		{WishlistItem: models.WishlistItem{ProductID: untranslated}, ProductName: "Чохол", Visible: true},
+	}
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	wishlistRepo.On("ListItems", ctx, wishlistID).Return(items, nil)
+	translationRepo.On("GetProductTranslations", ctx, "en", []uuid.UUID{translated, untranslated}).Return([]*models.ProductTranslation{
+		{ProductID: translated, Locale: "en", Name: "Headphones"},
+	}, nil)
+
+	//Act
+	resp, err := service.GetWishlist(ctx, userID, wishlistID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Headphones", resp.Items[0].ProductName)
+	// без перекладу лишається назва мовою за замовчуванням
+	assert.Equal(t, "Чохол", resp.Items[1].ProductName)
+}
+
+func TestGetWishlist_OtherUser(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	wishlistID := uuid.New()
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: uuid.New()}, nil)
+
+	//Act
+	resp, err := service.GetWishlist(ctx, uuid.New(), wishlistID)
+
+	//Assert
+	assert.Nil(t, resp)
+	assert.Equal(t, ErrWishlistNotFound, err)
+	wishlistRepo.AssertNotCalled(t, "ListItems", mock.Anything, mock.Anything)
+}
+
+func TestUpdateWishlist_MakePrivateClearsToken(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	token := "old-token"
+	private := false
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID, Name: "Gifts", IsPublic: true, ShareToken: &token}, nil)
+	wishlistRepo.On("Update", ctx, mock.AnythingOfType("*models.Wishlist")).Return(nil)
+
+	//Act
+	wishlist, err := service.UpdateWishlist(ctx, userID, wishlistID, UpdateWishlistRequest{IsPublic: &private})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.False(t, wishlist.IsPublic)
+	assert.Nil(t, wishlist.ShareToken)
+}
+
+func TestUpdateWishlist_RenameTaken(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	name := "Later"
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID, Name: "Gifts"}, nil)
+	wishlistRepo.On("ListByUser", ctx, userID).Return([]*models.Wishlist{{Name: "Gifts"}, {Name: "Later"}}, nil)
+
+	//Act
+	wishlist, err := service.UpdateWishlist(ctx, userID, wishlistID, UpdateWishlistRequest{Name: &name})
+
+	//Assert
+	assert.Nil(t, wishlist)
+	assert.Equal(t, ErrNameTaken, err)
+	wishlistRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestGetSharedWishlist_HidesOwnerAndHiddenItems(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	wishlistID := uuid.New()
+	token := "share-token"
+
+	items := []*models.WishlistItemWithProduct{
+		{WishlistItem: models.WishlistItem{PriceAtAdd: 10}, Price: 10, Stock: 1, Visible: true},
+		{WishlistItem: models.WishlistItem{PriceAtAdd: 30}, Price: 20, Stock: 1, Visible: false},
+	}
+	wishlistRepo.On("GetByShareToken", ctx, token).Return(&models.Wishlist{ID: wishlistID, UserID: uuid.New(), IsPublic: true, ShareToken: &token}, nil)
+	wishlistRepo.On("ListItems", ctx, wishlistID).Return(items, nil)
+
+	//Act
+	resp, err := service.GetSharedWishlist(ctx, token)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, resp.UserID)
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, 1, resp.ItemCount)
+	assert.Equal(t, 0, resp.PriceDrops)
+}
+
+func TestGetSharedWishlist_NotFound(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+
+	wishlistRepo.On("GetByShareToken", ctx, "missing").Return(nil, nil)
+
+	//Act
+	resp, err := service.GetSharedWishlist(ctx, "missing")
+
+	//Assert
+	assert.Nil(t, resp)
+	assert.Equal(t, ErrWishlistNotFound, err)
+}
+
+func TestAddItem_Success(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	productRepo := new(MockProductRepository)
+	service := NewService(wishlistRepo, productRepo, new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	productID := uuid.New()
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Price: 42.5, Status: models.ProductStatusPublished}, nil)
+	wishlistRepo.On("AddItem", ctx, mock.AnythingOfType("*models.WishlistItem")).Return(nil)
+
+	//Act
+	item, err := service.AddItem(ctx, userID, wishlistID, AddWishlistItemRequest{ProductID: productID})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 42.5, item.PriceAtAdd)
+	assert.Equal(t, wishlistID, item.WishlistID)
+}
+
+func TestAddItem_AlreadyExists(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	productRepo := new(MockProductRepository)
+	service := NewService(wishlistRepo, productRepo, new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	productID := uuid.New()
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+	wishlistRepo.On("AddItem", ctx, mock.AnythingOfType("*models.WishlistItem")).Return(sql.ErrNoRows)
+
+	//Act
+	item, err := service.AddItem(ctx, userID, wishlistID, AddWishlistItemRequest{ProductID: productID})
+
+	//Assert
+	assert.Nil(t, item)
+	assert.Equal(t, ErrItemExists, err)
+}
+
+func TestAddItem_DraftProduct(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	productRepo := new(MockProductRepository)
+	service := NewService(wishlistRepo, productRepo, new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	productID := uuid.New()
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusDraft}, nil)
+
+	//Act
+	item, err := service.AddItem(ctx, userID, wishlistID, AddWishlistItemRequest{ProductID: productID})
+
+	//Assert
+	assert.Nil(t, item)
+	assert.Equal(t, ErrProductNotFound, err)
+}
+
+func TestMoveItem_Success(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	itemID := uuid.New()
+	item := &models.WishlistItem{ID: itemID, WishlistID: sourceID}
+
+	wishlistRepo.On("GetByID", ctx, sourceID).Return(&models.Wishlist{ID: sourceID, UserID: userID}, nil)
+	wishlistRepo.On("GetByID", ctx, targetID).Return(&models.Wishlist{ID: targetID, UserID: userID}, nil)
+	wishlistRepo.On("GetItem", ctx, sourceID, itemID).Return(item, nil)
+	wishlistRepo.On("MoveItem", ctx, item, targetID).Return(nil)
+
+	//Act
+	err := service.MoveItem(ctx, userID, sourceID, itemID, MoveWishlistItemRequest{WishlistID: targetID})
+
+	//Assert
+	assert.NoError(t, err)
+	wishlistRepo.AssertExpectations(t)
+}
+
+func TestMoveItem_ForeignTarget(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	itemID := uuid.New()
+
+	wishlistRepo.On("GetByID", ctx, sourceID).Return(&models.Wishlist{ID: sourceID, UserID: userID}, nil)
+	wishlistRepo.On("GetByID", ctx, targetID).Return(&models.Wishlist{ID: targetID, UserID: uuid.New()}, nil)
+	wishlistRepo.On("GetItem", ctx, sourceID, itemID).Return(&models.WishlistItem{ID: itemID}, nil)
+
+	//Act
+	err := service.MoveItem(ctx, userID, sourceID, itemID, MoveWishlistItemRequest{WishlistID: targetID})
+
+	//Assert
+	assert.Equal(t, ErrWishlistNotFound, err)
+	wishlistRepo.AssertNotCalled(t, "MoveItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMoveItem_SameWishlist(t *testing.T) {
+	//Arrange
+	service := NewService(new(MockWishlistRepository), new(MockProductRepository), new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	wishlistID := uuid.New()
+
+	//Act
+	err := service.MoveItem(context.Background(), uuid.New(), wishlistID, uuid.New(), MoveWishlistItemRequest{WishlistID: wishlistID})
+
+	//Assert
+	assert.Equal(t, ErrSameWishlist, err)
+}
+
+func TestMoveItemToCart_Success(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	productRepo := new(MockProductRepository)
+	cartRepo := new(MockCartRepository)
+	service := NewService(wishlistRepo, productRepo, cartRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	itemID := uuid.New()
+	productID := uuid.New()
+	item := &models.WishlistItem{ID: itemID, WishlistID: wishlistID, ProductID: productID}
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	wishlistRepo.On("GetItem", ctx, wishlistID, itemID).Return(item, nil)
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Stock: 4, Status: models.ProductStatusPublished}, nil)
+	cartRepo.On("GetItem", ctx, userID, productID).Return(nil, nil)
+	wishlistRepo.On("MoveToCart", ctx, item, userID, 1).Return(nil)
+
+	//Act
+	err := service.MoveItemToCart(ctx, userID, wishlistID, itemID, MoveToCartRequest{})
+
+	//Assert
+	assert.NoError(t, err)
+	wishlistRepo.AssertExpectations(t)
+}
+
+func TestMoveItemToCart_ExceedsStockWithCart(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	productRepo := new(MockProductRepository)
+	cartRepo := new(MockCartRepository)
+	service := NewService(wishlistRepo, productRepo, cartRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	itemID := uuid.New()
+	productID := uuid.New()
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	wishlistRepo.On("GetItem", ctx, wishlistID, itemID).Return(&models.WishlistItem{ID: itemID, ProductID: productID}, nil)
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Stock: 4, Status: models.ProductStatusPublished}, nil)
+	// у кошику вже 3 одиниці, разом з 2 перевищує залишок
+	cartRepo.On("GetItem", ctx, userID, productID).Return(&models.CartItem{ProductID: productID, Quantity: 3}, nil)
+
+	//Act
+	err := service.MoveItemToCart(ctx, userID, wishlistID, itemID, MoveToCartRequest{Quantity: 2})
+
+	//Assert
+	assert.Equal(t, ErrInsufficientStock, err)
+	wishlistRepo.AssertNotCalled(t, "MoveToCart", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMoveItemToCart_OutOfStock(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	productRepo := new(MockProductRepository)
+	service := NewService(wishlistRepo, productRepo, new(MockCartRepository), productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	itemID := uuid.New()
+	productID := uuid.New()
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	wishlistRepo.On("GetItem", ctx, wishlistID, itemID).Return(&models.WishlistItem{ID: itemID, ProductID: productID}, nil)
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Status: models.ProductStatusPublished}, nil)
+
+	//Act
+	err := service.MoveItemToCart(ctx, userID, wishlistID, itemID, MoveToCartRequest{Quantity: 2})
+
+	//Assert
+	assert.Equal(t, ErrProductNotAvailable, err)
+	wishlistRepo.AssertNotCalled(t, "MoveToCart", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMoveCartItem_Success(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	productRepo := new(MockProductRepository)
+	cartRepo := new(MockCartRepository)
+	service := NewService(wishlistRepo, productRepo, cartRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	cartItemID := uuid.New()
+	productID := uuid.New()
+	cartItem := &models.CartItem{ID: cartItemID, UserID: userID, ProductID: productID}
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	cartRepo.On("GetItemByID", ctx, userID, cartItemID).Return(cartItem, nil)
+	productRepo.On("GetById", ctx, productID).Return(&models.Product{ID: productID, Price: 15}, nil)
+	wishlistRepo.On("MoveFromCart", ctx, cartItem, mock.AnythingOfType("*models.WishlistItem")).Return(nil)
+
+	//Act
+	item, err := service.MoveCartItem(ctx, userID, wishlistID, MoveFromCartRequest{CartItemID: cartItemID})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, item.PriceAtAdd)
+	assert.Equal(t, productID, item.ProductID)
+}
+
+func TestMoveCartItem_NotFound(t *testing.T) {
+	//Arrange
+	wishlistRepo := new(MockWishlistRepository)
+	cartRepo := new(MockCartRepository)
+	service := NewService(wishlistRepo, new(MockProductRepository), cartRepo, productSrv.NewLocalizer(new(MockTranslationRepository), ""))
+	ctx := context.Background()
+	userID := uuid.New()
+	wishlistID := uuid.New()
+	cartItemID := uuid.New()
+
+	wishlistRepo.On("GetByID", ctx, wishlistID).Return(&models.Wishlist{ID: wishlistID, UserID: userID}, nil)
+	cartRepo.On("GetItemByID", ctx, userID, cartItemID).Return(nil, nil)
+
+	//Act
+	item, err := service.MoveCartItem(ctx, userID, wishlistID, MoveFromCartRequest{CartItemID: cartItemID})
+
+	//Assert
+	assert.Nil(t, item)
+	assert.Equal(t, ErrCartItemNotFound, err)
+}
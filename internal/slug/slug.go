@@ -0,0 +1,67 @@
+// Package slug формує читабельні ідентифікатори для URL з назв (з транслітерацією кирилиці)
+package slug
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxLength максимальна довжина slug (без суфікса унікальності)
+const MaxLength = 200
+
+// translit таблиця транслітерації української та російської кирилиці (нижній регістр)
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g", 'д': "d", 'е': "e", 'є': "ie",
+	'ж': "zh", 'з': "z", 'и': "y", 'і': "i", 'ї': "i", 'й': "i", 'к': "k", 'л': "l",
+	'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ь': "", 'ю': "iu",
+	'я': "ia", 'ё': "io", 'ъ': "", 'ы': "y", 'э': "e", '\'': "", '’': "", 'ʼ': "",
+}
+
+// Make перетворює назву на slug: латиниця в нижньому регістрі, цифри та дефіси.
+// Повертає порожній рядок, якщо в назві немає літер чи цифр
+func Make(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if t, ok := translit[r]; ok {
+			b.WriteString(t)
+			dash = false
+			continue
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		// усі інші символи стають одним розділювачем
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	s := strings.TrimRight(b.String(), "-")
+	if len(s) > MaxLength {
+		s = strings.TrimRight(s[:MaxLength], "-")
+	}
+	return s
+}
+
+// Unique повертає перший вільний варіант base, base-2, base-3, ...
+// taken перевіряє, чи зайнятий slug
+func Unique(ctx context.Context, base string, taken func(ctx context.Context, slug string) (bool, error)) (string, error) {
+	candidate := base
+	for i := 2; ; i++ {
+		exists, err := taken(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = base + "-" + strconv.Itoa(i)
+	}
+}
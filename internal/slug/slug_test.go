@@ -0,0 +1,59 @@
+package slug
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"latin", "Laptop Pro 15", "laptop-pro-15"},
+		{"punctuation", "  USB-C Hub (7-in-1)!  ", "usb-c-hub-7-in-1"},
+		{"ukrainian", "Навушники Жовті", "navushnyky-zhovti"},
+		{"apostrophe", "М'ясорубка", "miasorubka"},
+		{"russian", "Щётка Эко", "shchiotka-eko"},
+		{"mixed", "Чохол для iPhone 15", "chokhol-dlia-iphone-15"},
+		{"only symbols", "!!!", ""},
+		{"non latin letters dropped", "日本 Tea", "tea"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Make(tt.in))
+		})
+	}
+}
+
+func TestMake_TruncatesLongNames(t *testing.T) {
+	s := Make(strings.Repeat("ab ", 200))
+
+	assert.LessOrEqual(t, len(s), MaxLength)
+	assert.False(t, strings.HasSuffix(s, "-"))
+}
+
+func TestUnique(t *testing.T) {
+	taken := map[string]bool{"phone": true, "phone-2": true}
+
+	s, err := Unique(context.Background(), "phone", func(_ context.Context, slug string) (bool, error) {
+		return taken[slug], nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "phone-3", s)
+}
+
+func TestUnique_Error(t *testing.T) {
+	_, err := Unique(context.Background(), "phone", func(context.Context, string) (bool, error) {
+		return false, errors.New("db error")
+	})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage_PutOpenDelete(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir(), "/api/v1/files/")
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	err = store.Put(ctx, "products/1/thumbnail.jpg", strings.NewReader("data"))
+	assert.NoError(t, err)
+
+	rc, err := store.Open(ctx, "products/1/thumbnail.jpg")
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, "data", string(content))
+	assert.Equal(t, "/api/v1/files/products/1/thumbnail.jpg", store.URL("products/1/thumbnail.jpg"))
+
+	assert.NoError(t, store.Delete(ctx, "products/1/thumbnail.jpg"))
+	_, err = store.Open(ctx, "products/1/thumbnail.jpg")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestLocalStorage_RejectsTraversal(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir(), "/files")
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	for _, key := range []string{"../secret", "/etc/passwd", "a/../../b", "a//b", ""} {
+		_, err := store.Open(ctx, key)
+		assert.Equal(t, ErrInvalidKey, err, key)
+	}
+}
@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Task фонова задача, що виконується періодично
+type Task func(ctx context.Context) error
+
+// Run виконує задачу одразу та далі з інтервалом, доки контекст не завершиться.
+// Помилки задачі логуються і не зупиняють виконання.
+func Run(ctx context.Context, name string, interval time.Duration, task Task) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := task(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("worker %s failed: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
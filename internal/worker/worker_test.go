@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun_RepeatsUntilCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+
+	done := make(chan struct{})
+	go func() {
+		Run(ctx, "test", time.Millisecond, func(ctx context.Context) error {
+			if calls.Add(1) == 3 {
+				cancel()
+			}
+			return errors.New("task error")
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after cancel")
+	}
+	assert.Equal(t, int32(3), calls.Load())
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS search_pins;
+DROP TABLE IF EXISTS product_boosts;
+DROP TABLE IF EXISTS search_synonyms;
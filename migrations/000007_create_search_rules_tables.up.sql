@@ -0,0 +1,27 @@
+-- Набори синонімів для пошуку ("tv" = "television")
+CREATE TABLE IF NOT EXISTS search_synonyms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    terms TEXT[] NOT NULL CHECK (cardinality(terms) >= 2),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Коефіцієнти підсилення релевантності для продуктів
+CREATE TABLE IF NOT EXISTS product_boosts (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    factor DECIMAL(6, 3) NOT NULL CHECK (factor > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Закріплені продукти для конкретних пошукових запитів
+CREATE TABLE IF NOT EXISTS search_pins (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    query VARCHAR(255) NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 1 CHECK (position > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(query, product_id)
+);
+
+CREATE INDEX idx_search_synonyms_terms ON search_synonyms USING GIN (terms);
+CREATE INDEX idx_search_pins_query ON search_pins(query);
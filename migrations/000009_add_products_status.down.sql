@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_products_unpublish_at;
+DROP INDEX IF EXISTS idx_products_publish_at;
+DROP INDEX IF EXISTS idx_products_status;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS unpublish_at,
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,10 @@
+ALTER TABLE products
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'published', 'archived')),
+    ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN unpublish_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_products_status ON products(status) WHERE deleted_at IS NULL;
+CREATE INDEX idx_products_publish_at ON products(publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX idx_products_unpublish_at ON products(unpublish_at) WHERE unpublish_at IS NOT NULL;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_order_items_product;
+DROP INDEX IF EXISTS idx_products_rating;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS rating_avg;
+
+DROP TABLE IF EXISTS reviews;
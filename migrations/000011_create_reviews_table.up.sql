@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title VARCHAR(255),
+    body TEXT,
+    verified_purchase BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (product_id, user_id)
+);
+
+CREATE INDEX idx_reviews_product_status ON reviews(product_id, status, created_at DESC);
+CREATE INDEX idx_reviews_status ON reviews(status, created_at);
+
+-- агрегований рейтинг (тільки схвалені відгуки)
+ALTER TABLE products
+    ADD COLUMN rating_avg NUMERIC(3, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_products_rating ON products(rating_avg DESC, rating_count DESC) WHERE deleted_at IS NULL;
+
+-- перевірка покупки за доставленими замовленнями
+CREATE INDEX IF NOT EXISTS idx_order_items_product ON order_items(product_id);
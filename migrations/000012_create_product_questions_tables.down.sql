@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS product_answer_votes;
+DROP TABLE IF EXISTS product_answers;
+DROP TABLE IF EXISTS product_questions;
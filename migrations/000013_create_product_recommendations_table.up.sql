@@ -0,0 +1,12 @@
+-- Попередньо пораховані рекомендації "разом з цим купують"
+CREATE TABLE IF NOT EXISTS product_recommendations (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    recommended_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    co_count INTEGER NOT NULL,
+    computed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (product_id, recommended_id),
+    CHECK (product_id <> recommended_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_recommendations_score ON product_recommendations(product_id, score DESC);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_categories_slug;
+ALTER TABLE categories DROP COLUMN IF EXISTS slug;
+
+DROP TABLE IF EXISTS product_slug_redirects;
+
+DROP INDEX IF EXISTS idx_products_slug;
+ALTER TABLE products DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,49 @@
+-- Тимчасова функція для заповнення slug існуючих записів (транслітерація як у internal/slug)
+CREATE FUNCTION pg_temp.slugify(value TEXT) RETURNS TEXT AS $$
+    SELECT left(trim(BOTH '-' FROM regexp_replace(
+        translate(
+            replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(
+                lower(value),
+                'щ', 'shch'), 'ж', 'zh'), 'х', 'kh'), 'ц', 'ts'), 'ч', 'ch'),
+                'ш', 'sh'), 'є', 'ie'), 'ю', 'iu'), 'я', 'ia'), 'ё', 'io'),
+            'абвгґдезиіїйклмнопрстуфыэьъ''’ʼ', 'abvhgdezyiiiklmnoprstufye'),
+        '[^a-z0-9]+', '-', 'g')), 200)
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Slug продуктів
+ALTER TABLE products ADD COLUMN IF NOT EXISTS slug VARCHAR(255);
+
+-- дублікати отримують суфікс з ID
+UPDATE products p
+SET slug = CASE WHEN s.n = 1 THEN s.base ELSE s.base || '-' || left(p.id::text, 8) END
+FROM (
+    SELECT id, base, ROW_NUMBER() OVER (PARTITION BY base ORDER BY created_at, id) AS n
+    FROM (SELECT id, created_at, COALESCE(NULLIF(pg_temp.slugify(name), ''), 'product') AS base FROM products) b
+) s
+WHERE s.id = p.id;
+
+ALTER TABLE products ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_slug ON products(slug);
+
+-- Старі slug перейменованих продуктів (для редиректів)
+CREATE TABLE IF NOT EXISTS product_slug_redirects (
+    slug VARCHAR(255) PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_slug_redirects_product ON product_slug_redirects(product_id);
+
+-- Slug категорій
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS slug VARCHAR(255);
+
+UPDATE categories c
+SET slug = CASE WHEN s.n = 1 THEN s.base ELSE s.base || '-' || left(c.id::text, 8) END
+FROM (
+    SELECT id, base, ROW_NUMBER() OVER (PARTITION BY base ORDER BY created_at, id) AS n
+    FROM (SELECT id, created_at, COALESCE(NULLIF(pg_temp.slugify(name), ''), 'category') AS base FROM categories) b
+) s
+WHERE s.id = c.id;
+
+ALTER TABLE categories ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_slug ON categories(slug);
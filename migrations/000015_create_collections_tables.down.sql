@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS collection_products;
+DROP TABLE IF EXISTS collections;
+
+DROP INDEX IF EXISTS idx_products_tags;
+ALTER TABLE products DROP COLUMN IF EXISTS tags;
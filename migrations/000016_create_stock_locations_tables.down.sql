@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_item_allocations;
+DROP TABLE IF EXISTS location_stock;
+DROP TABLE IF EXISTS stock_locations;
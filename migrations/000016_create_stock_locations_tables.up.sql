@@ -0,0 +1,45 @@
+-- Склади (локації зберігання товару)
+CREATE TABLE IF NOT EXISTS stock_locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    country CHAR(2),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- склад за замовчуванням може бути лише один
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_locations_default ON stock_locations(is_default) WHERE is_default;
+
+-- Залишки товару по складах; products.stock - сума по активних складах
+CREATE TABLE IF NOT EXISTS location_stock (
+    location_id UUID NOT NULL REFERENCES stock_locations(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (location_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_location_stock_product ON location_stock(product_id);
+
+-- З якого складу відвантажується кожна позиція замовлення
+CREATE TABLE IF NOT EXISTS order_item_allocations (
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    location_id UUID NOT NULL REFERENCES stock_locations(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (order_item_id, location_id)
+);
+
+-- поточні залишки переносяться на основний склад
+INSERT INTO stock_locations (code, name, is_default)
+VALUES ('main', 'Основний склад', TRUE)
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO location_stock (location_id, product_id, quantity)
+SELECT l.id, p.id, p.stock
+FROM products p
+CROSS JOIN stock_locations l
+WHERE l.is_default AND p.stock > 0
+ON CONFLICT (location_id, product_id) DO NOTHING;
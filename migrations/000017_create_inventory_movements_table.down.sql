@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS inventory_movements;
+DROP FUNCTION IF EXISTS inventory_movements_append_only();
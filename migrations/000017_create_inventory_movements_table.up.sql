@@ -0,0 +1,36 @@
+-- Журнал руху товару: кожна зміна залишку на складі записується окремим рядком
+CREATE TABLE IF NOT EXISTS inventory_movements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    location_id UUID NOT NULL REFERENCES stock_locations(id),
+    quantity_change INTEGER NOT NULL CHECK (quantity_change <> 0),
+    quantity_after INTEGER NOT NULL CHECK (quantity_after >= 0),
+    reason VARCHAR(30) NOT NULL
+        CHECK (reason IN ('opening_balance', 'sale', 'cancellation', 'return', 'adjustment',
+                          'cycle_count', 'import', 'transfer_in', 'transfer_out')),
+    -- без зовнішнього ключа: видалення користувача не повинно змінювати журнал
+    actor_id UUID,
+    reference_id UUID,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_product ON inventory_movements(product_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_reference ON inventory_movements(reference_id);
+
+-- журнал тільки доповнюється: зміна та видалення записів заборонені
+CREATE OR REPLACE FUNCTION inventory_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'inventory_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER inventory_movements_no_update
+    BEFORE UPDATE OR DELETE ON inventory_movements
+    FOR EACH ROW EXECUTE FUNCTION inventory_movements_append_only();
+
+-- початкові залишки, щоб журнал сходився з поточним станом складів
+INSERT INTO inventory_movements (product_id, location_id, quantity_change, quantity_after, reason, note)
+SELECT product_id, location_id, quantity, quantity, 'opening_balance', 'initial ledger balance'
+FROM location_stock
+WHERE quantity > 0;
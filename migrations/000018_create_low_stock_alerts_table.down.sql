@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS low_stock_alerts;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS reorder_quantity,
+    DROP COLUMN IF EXISTS low_stock_threshold;
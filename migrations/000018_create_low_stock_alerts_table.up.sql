@@ -0,0 +1,22 @@
+-- Поріг низького залишку (NULL - сповіщення вимкнені) та кількість для дозамовлення
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS low_stock_threshold INTEGER CHECK (low_stock_threshold >= 0),
+    ADD COLUMN IF NOT EXISTS reorder_quantity INTEGER NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0);
+
+-- Сповіщення про низький залишок: відкрите, доки залишок не підніметься вище порогу
+CREATE TABLE IF NOT EXISTS low_stock_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    stock INTEGER NOT NULL,
+    threshold INTEGER NOT NULL,
+    reorder_quantity INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved')),
+    notified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+-- не більше одного відкритого сповіщення на продукт
+CREATE UNIQUE INDEX IF NOT EXISTS idx_low_stock_alerts_open ON low_stock_alerts(product_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_low_stock_alerts_created ON low_stock_alerts(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_low_stock_alerts_undelivered ON low_stock_alerts(created_at) WHERE notified_at IS NULL;
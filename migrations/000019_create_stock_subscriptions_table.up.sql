@@ -0,0 +1,14 @@
+-- Підписки на сповіщення про надходження товару
+CREATE TABLE IF NOT EXISTS stock_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- товар з'явився в наявності, підписник чекає на сповіщення
+    ready_at TIMESTAMP WITH TIME ZONE,
+    notified_at TIMESTAMP WITH TIME ZONE
+);
+
+-- одна активна підписка користувача на продукт
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_subscriptions_active ON stock_subscriptions(product_id, user_id) WHERE notified_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_stock_subscriptions_ready ON stock_subscriptions(product_id, created_at) WHERE ready_at IS NOT NULL AND notified_at IS NULL;
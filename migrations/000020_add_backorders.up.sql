@@ -0,0 +1,13 @@
+-- Політика продажу при нульовому залишку та очікувана дата надходження (для передзамовлення - дата релізу)
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS backorder_policy VARCHAR(20) NOT NULL DEFAULT 'deny' CHECK (backorder_policy IN ('deny', 'allow', 'preorder')),
+    ADD COLUMN IF NOT EXISTS available_at TIMESTAMP WITH TIME ZONE;
+
+-- Позиції під замовлення: backordered залишається ознакою позиції,
+-- backordered_quantity - кількість, яка ще чекає на надходження товару
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS backordered BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS backordered_quantity INTEGER NOT NULL DEFAULT 0 CHECK (backordered_quantity >= 0),
+    ADD COLUMN IF NOT EXISTS expected_ship_date TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_order_items_backordered ON order_items(product_id, created_at) WHERE backordered_quantity > 0;
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_download_grants_order;
+DROP TABLE IF EXISTS download_grants;
+DROP TABLE IF EXISTS product_files;
+
+UPDATE orders SET status = 'confirmed' WHERE status = 'paid';
+ALTER TABLE orders DROP COLUMN IF EXISTS paid_at;
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders
+    ADD CONSTRAINT orders_status_check
+        CHECK (status IN ('pending', 'confirmed', 'shipped', 'delivered', 'cancelled'));
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS is_digital;
@@ -0,0 +1,36 @@
+-- Цифрові товари (електронні книги тощо): без складу та доставки
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS is_digital BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Статус 'paid' та час оплати: з цього моменту відлічується термін дії посилань на завантаження
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders
+    ADD CONSTRAINT orders_status_check
+        CHECK (status IN ('pending', 'confirmed', 'paid', 'shipped', 'delivered', 'cancelled')),
+    ADD COLUMN IF NOT EXISTS paid_at TIMESTAMP WITH TIME ZONE;
+
+-- Файл цифрового товару у сховищі (один на продукт)
+CREATE TABLE IF NOT EXISTS product_files (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    storage_key VARCHAR(500) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Право на завантаження цифрової позиції оплаченого замовлення
+CREATE TABLE IF NOT EXISTS download_grants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL UNIQUE REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    max_downloads INTEGER NOT NULL CHECK (max_downloads > 0),
+    download_count INTEGER NOT NULL DEFAULT 0 CHECK (download_count >= 0),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_downloaded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_download_grants_order ON download_grants(order_id);
@@ -0,0 +1,23 @@
+-- Переклади назви та опису продукту; базовий вміст у products відповідає мові за замовчуванням
+CREATE TABLE IF NOT EXISTS product_translations (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (product_id, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_translations_locale ON product_translations(locale);
+
+-- Переклади категорій; slug унікальний у межах мови
+CREATE TABLE IF NOT EXISTS category_translations (
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    description TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (category_id, locale),
+    UNIQUE (locale, slug)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_created_id;
+DROP INDEX IF EXISTS idx_orders_user_created_id;
+DROP INDEX IF EXISTS idx_orders_created_id;
+DROP INDEX IF EXISTS idx_products_price_id;
+DROP INDEX IF EXISTS idx_products_created_id;
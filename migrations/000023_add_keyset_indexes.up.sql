@@ -0,0 +1,6 @@
+-- Індекси для keyset пагінації: ключ сортування + id як розрізнювач
+CREATE INDEX IF NOT EXISTS idx_products_created_id ON products(created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_products_price_id ON products(price, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_created_id ON orders(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_user_created_id ON orders(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_users_created_id ON users(created_at DESC, id DESC);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS price_alerts;
+
+DROP INDEX IF EXISTS idx_products_sale;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS sale_ends_at,
+    DROP COLUMN IF EXISTS sale_starts_at,
+    DROP COLUMN IF EXISTS sale_price;
@@ -0,0 +1,27 @@
+-- Розпродаж: ціна діє з sale_starts_at (NULL - одразу) до sale_ends_at (NULL - без обмеження)
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS sale_price DECIMAL(10, 2) CHECK (sale_price > 0),
+    ADD COLUMN IF NOT EXISTS sale_starts_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS sale_ends_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_products_sale ON products(sale_starts_at) WHERE sale_price IS NOT NULL;
+
+-- Сповіщення про зниження ціни до цільової
+CREATE TABLE IF NOT EXISTS price_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_price DECIMAL(10, 2) NOT NULL CHECK (target_price > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- ціна досягла цільової, сповіщення чекає на відправку
+    triggered_at TIMESTAMP WITH TIME ZONE,
+    triggered_price DECIMAL(10, 2),
+    notified_at TIMESTAMP WITH TIME ZONE
+);
+
+-- одне активне сповіщення користувача на продукт
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_alerts_active ON price_alerts(product_id, user_id) WHERE notified_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_price_alerts_triggered ON price_alerts(triggered_at) WHERE triggered_at IS NOT NULL AND notified_at IS NULL;
+-- ліміт сповіщень користувача за період
+CREATE INDEX IF NOT EXISTS idx_price_alerts_user_notified ON price_alerts(user_id, notified_at) WHERE notified_at IS NOT NULL;
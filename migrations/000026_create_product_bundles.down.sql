@@ -0,0 +1,17 @@
+-- розподіл компонентів комплектів не можна зберегти в старому ключі
+DELETE FROM order_item_allocations a
+USING order_items oi
+WHERE oi.id = a.order_item_id AND a.product_id <> oi.product_id;
+
+ALTER TABLE order_item_allocations
+    DROP CONSTRAINT IF EXISTS order_item_allocations_pkey,
+    ADD PRIMARY KEY (order_item_id, location_id),
+    DROP COLUMN IF EXISTS product_id;
+
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS components;
+
+DROP TABLE IF EXISTS product_bundle_items;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS is_bundle;
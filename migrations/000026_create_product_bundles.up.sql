@@ -0,0 +1,32 @@
+-- Комплекти (бандли): продукт зі своєю ціною, що складається з інших продуктів.
+-- Залишок комплекту не зберігається на складах, а обчислюється із залишків компонентів
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS is_bundle BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS product_bundle_items (
+    bundle_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    component_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (bundle_id, component_id),
+    CHECK (bundle_id <> component_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_bundle_items_component ON product_bundle_items(component_id);
+
+-- Склад позиції комплекту на момент замовлення
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS components JSONB;
+
+-- Розподіл по складах зберігає продукт, що списується (для комплекту - компонент)
+ALTER TABLE order_item_allocations
+    ADD COLUMN IF NOT EXISTS product_id UUID REFERENCES products(id);
+
+UPDATE order_item_allocations a
+SET product_id = oi.product_id
+FROM order_items oi
+WHERE oi.id = a.order_item_id AND a.product_id IS NULL;
+
+ALTER TABLE order_item_allocations
+    ALTER COLUMN product_id SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS order_item_allocations_pkey,
+    ADD PRIMARY KEY (order_item_id, product_id, location_id);
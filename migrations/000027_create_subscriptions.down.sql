@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS subscription_orders;
+DROP TABLE IF EXISTS subscription_items;
+DROP TABLE IF EXISTS subscriptions;
@@ -0,0 +1,47 @@
+-- Підписки на регулярну доставку (кожні interval_weeks тижнів)
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- past_due: оплата не пройшла, чекає повторної спроби; suspended: спроби вичерпано
+    status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'paused', 'past_due', 'suspended', 'cancelled')),
+    interval_weeks INTEGER NOT NULL CHECK (interval_weeks BETWEEN 1 AND 52),
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    shipping_address JSONB NOT NULL,
+    payment_method VARCHAR(20) NOT NULL CHECK (payment_method IN ('card', 'cash')),
+    -- токен збереженої картки у платіжного провайдера
+    payment_reference VARCHAR(255),
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    retry_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    last_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    -- планувальник обробляє підписку до цього часу (захист від повторного замовлення)
+    locked_until TIMESTAMP WITH TIME ZONE,
+    paused_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user ON subscriptions(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_due ON subscriptions(next_run_at) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_subscriptions_retry ON subscriptions(retry_at) WHERE status = 'past_due';
+
+CREATE TABLE IF NOT EXISTS subscription_items (
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (subscription_id, product_id)
+);
+
+-- Замовлення, створені підпискою
+CREATE TABLE IF NOT EXISTS subscription_orders (
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    -- дата циклу, за який створено замовлення
+    cycle_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (subscription_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_orders_created ON subscription_orders(subscription_id, created_at DESC);
@@ -0,0 +1,19 @@
+-- Кошики гостей: ідентифікуються підписаним токеном, зливаються з кошиком користувача при вході
+CREATE TABLE IF NOT EXISTS guest_carts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- продовжується при кожному зверненні; прострочені кошики видаляються фоновою задачею
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_guest_carts_expires ON guest_carts(expires_at);
+
+CREATE TABLE IF NOT EXISTS guest_cart_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cart_id UUID NOT NULL REFERENCES guest_carts(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(cart_id, product_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE guest_cart_items DROP COLUMN IF EXISTS price_at_add;
+ALTER TABLE cart_items DROP COLUMN IF EXISTS price_at_add;
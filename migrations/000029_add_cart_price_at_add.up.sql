@@ -0,0 +1,26 @@
+-- Ціна товару на момент додавання в кошик (для попереджень про зміну ціни)
+ALTER TABLE cart_items
+    ADD COLUMN IF NOT EXISTS price_at_add DECIMAL(10, 2) CHECK (price_at_add >= 0);
+
+ALTER TABLE guest_cart_items
+    ADD COLUMN IF NOT EXISTS price_at_add DECIMAL(10, 2) CHECK (price_at_add >= 0);
+
+-- наявні позиції отримують поточну ціну з урахуванням розпродажу
+UPDATE cart_items ci
+SET price_at_add = CASE WHEN p.sale_price IS NOT NULL AND p.sale_price < p.price
+        AND (p.sale_starts_at IS NULL OR p.sale_starts_at <= NOW())
+        AND (p.sale_ends_at IS NULL OR p.sale_ends_at > NOW())
+    THEN p.sale_price ELSE p.price END
+FROM products p
+WHERE p.id = ci.product_id;
+
+UPDATE guest_cart_items gi
+SET price_at_add = CASE WHEN p.sale_price IS NOT NULL AND p.sale_price < p.price
+        AND (p.sale_starts_at IS NULL OR p.sale_starts_at <= NOW())
+        AND (p.sale_ends_at IS NULL OR p.sale_ends_at > NOW())
+    THEN p.sale_price ELSE p.price END
+FROM products p
+WHERE p.id = gi.product_id;
+
+ALTER TABLE cart_items ALTER COLUMN price_at_add SET NOT NULL;
+ALTER TABLE guest_cart_items ALTER COLUMN price_at_add SET NOT NULL;
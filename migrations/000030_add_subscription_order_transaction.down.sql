@@ -0,0 +1 @@
+ALTER TABLE subscription_orders DROP COLUMN IF EXISTS transaction_id;
@@ -0,0 +1,4 @@
+-- ID транзакції платіжного провайдера: записується одразу після списання,
+-- щоб повторна обробка не списувала кошти за замовлення ще раз
+ALTER TABLE subscription_orders
+    ADD COLUMN IF NOT EXISTS transaction_id VARCHAR(255);
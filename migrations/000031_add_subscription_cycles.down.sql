@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_subscription_orders_cycle;
+DROP TABLE IF EXISTS subscription_cycles;
@@ -0,0 +1,12 @@
+-- резерв циклу підписки: ID замовлення циклу фіксується до створення замовлення,
+-- тому повторна обробка після збою використовує те саме замовлення, а не створює нове
+CREATE TABLE IF NOT EXISTS subscription_cycles (
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    cycle_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    order_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (subscription_id, cycle_at)
+);
+
+-- не більше одного замовлення на цикл
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subscription_orders_cycle ON subscription_orders(subscription_id, cycle_at);
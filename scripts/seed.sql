@@ -15,39 +15,39 @@ INSERT INTO users (id, email, password_hash, first_name, last_name, role) VALUES
 -- ============================================
 -- Категорії
 -- ============================================
-INSERT INTO categories (id, name, description) VALUES
-('c1111111-1111-1111-1111-111111111111', 'Electronics', 'Electronic devices'),
-('c2222222-2222-2222-2222-222222222222', 'Books', 'Books and e-books'),
-('c3333333-3333-3333-3333-333333333333', 'Clothing', 'Fashion and apparel');
+INSERT INTO categories (id, name, slug, description) VALUES
+('c1111111-1111-1111-1111-111111111111', 'Electronics', 'electronics', 'Electronic devices'),
+('c2222222-2222-2222-2222-222222222222', 'Books', 'books', 'Books and e-books'),
+('c3333333-3333-3333-3333-333333333333', 'Clothing', 'clothing', 'Fashion and apparel');
 
 -- ============================================
 -- Товари (мінімум 20)
 -- ============================================
-INSERT INTO products (name, description, price, stock, category_id) VALUES
+INSERT INTO products (name, slug, description, price, stock, category_id) VALUES
 -- Electronics
-('Laptop Pro', 'High-performance laptop', 1299.99, 50, 'c1111111-1111-1111-1111-111111111111'),
-('Wireless Mouse', 'Ergonomic mouse', 29.99, 200, 'c1111111-1111-1111-1111-111111111111'),
-('USB-C Hub', '7-in-1 hub', 49.99, 150, 'c1111111-1111-1111-1111-111111111111'),
-('Keyboard', 'Mechanical keyboard', 89.99, 100, 'c1111111-1111-1111-1111-111111111111'),
-('Webcam', '1080p webcam', 59.99, 80, 'c1111111-1111-1111-1111-111111111111'),
-('Headphones', 'Wireless headphones', 149.99, 120, 'c1111111-1111-1111-1111-111111111111'),
-('SSD 1TB', 'Portable SSD', 119.99, 90, 'c1111111-1111-1111-1111-111111111111'),
-('Monitor 27"', '4K monitor', 399.99, 60, 'c1111111-1111-1111-1111-111111111111'),
+('Laptop Pro', 'laptop-pro', 'High-performance laptop', 1299.99, 50, 'c1111111-1111-1111-1111-111111111111'),
+('Wireless Mouse', 'wireless-mouse', 'Ergonomic mouse', 29.99, 200, 'c1111111-1111-1111-1111-111111111111'),
+('USB-C Hub', 'usb-c-hub', '7-in-1 hub', 49.99, 150, 'c1111111-1111-1111-1111-111111111111'),
+('Keyboard', 'keyboard', 'Mechanical keyboard', 89.99, 100, 'c1111111-1111-1111-1111-111111111111'),
+('Webcam', 'webcam', '1080p webcam', 59.99, 80, 'c1111111-1111-1111-1111-111111111111'),
+('Headphones', 'headphones', 'Wireless headphones', 149.99, 120, 'c1111111-1111-1111-1111-111111111111'),
+('SSD 1TB', 'ssd-1tb', 'Portable SSD', 119.99, 90, 'c1111111-1111-1111-1111-111111111111'),
+('Monitor 27"', 'monitor-27', '4K monitor', 399.99, 60, 'c1111111-1111-1111-1111-111111111111'),
 
 -- Books
-('Go Programming', 'Learn Go', 39.99, 300, 'c2222222-2222-2222-2222-222222222222'),
-('Clean Architecture', 'Design patterns', 44.99, 250, 'c2222222-2222-2222-2222-222222222222'),
-('Docker Deep Dive', 'Container guide', 49.99, 200, 'c2222222-2222-2222-2222-222222222222'),
-('PostgreSQL Guide', 'Database book', 54.99, 180, 'c2222222-2222-2222-2222-222222222222'),
-('System Design', 'Interview prep', 42.99, 220, 'c2222222-2222-2222-2222-222222222222'),
+('Go Programming', 'go-programming', 'Learn Go', 39.99, 300, 'c2222222-2222-2222-2222-222222222222'),
+('Clean Architecture', 'clean-architecture', 'Design patterns', 44.99, 250, 'c2222222-2222-2222-2222-222222222222'),
+('Docker Deep Dive', 'docker-deep-dive', 'Container guide', 49.99, 200, 'c2222222-2222-2222-2222-222222222222'),
+('PostgreSQL Guide', 'postgresql-guide', 'Database book', 54.99, 180, 'c2222222-2222-2222-2222-222222222222'),
+('System Design', 'system-design', 'Interview prep', 42.99, 220, 'c2222222-2222-2222-2222-222222222222'),
 
 -- Clothing
-('T-Shirt', 'Cotton t-shirt', 19.99, 500, 'c3333333-3333-3333-3333-333333333333'),
-('Jeans', 'Blue jeans', 59.99, 300, 'c3333333-3333-3333-3333-333333333333'),
-('Hoodie', 'Comfortable hoodie', 39.99, 200, 'c3333333-3333-3333-3333-333333333333'),
-('Sneakers', 'Sport shoes', 79.99, 150, 'c3333333-3333-3333-3333-333333333333'),
-('Jacket', 'Winter jacket', 129.99, 100, 'c3333333-3333-3333-3333-333333333333'),
-('Cap', 'Baseball cap', 14.99, 400, 'c3333333-3333-3333-3333-333333333333'),
-('Socks Pack', '5 pairs', 9.99, 600, 'c3333333-3333-3333-3333-333333333333');
+('T-Shirt', 't-shirt', 'Cotton t-shirt', 19.99, 500, 'c3333333-3333-3333-3333-333333333333'),
+('Jeans', 'jeans', 'Blue jeans', 59.99, 300, 'c3333333-3333-3333-3333-333333333333'),
+('Hoodie', 'hoodie', 'Comfortable hoodie', 39.99, 200, 'c3333333-3333-3333-3333-333333333333'),
+('Sneakers', 'sneakers', 'Sport shoes', 79.99, 150, 'c3333333-3333-3333-3333-333333333333'),
+('Jacket', 'jacket', 'Winter jacket', 129.99, 100, 'c3333333-3333-3333-3333-333333333333'),
+('Cap', 'cap', 'Baseball cap', 14.99, 400, 'c3333333-3333-3333-3333-333333333333'),
+('Socks Pack', 'socks-pack', '5 pairs', 9.99, 600, 'c3333333-3333-3333-3333-333333333333');
 
 SELECT 'Seed data loaded!' AS status;